@@ -1,18 +1,32 @@
 package app
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
 	log "github.com/public-forge/go-logger"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	_ "github.com/vadymlab/slot-game/docs" // Import for loading Swagger documentation
+	"github.com/vadymlab/slot-game/internal/advisorylock"
 	"github.com/vadymlab/slot-game/internal/config"
 	controller "github.com/vadymlab/slot-game/internal/controllers"
 	"github.com/vadymlab/slot-game/internal/database"
+	"github.com/vadymlab/slot-game/internal/eventbus"
+	"github.com/vadymlab/slot-game/internal/exchange"
+	"github.com/vadymlab/slot-game/internal/grpcserver"
+	"github.com/vadymlab/slot-game/internal/interfaces"
 	"github.com/vadymlab/slot-game/internal/redis"
+	"github.com/vadymlab/slot-game/internal/reporting"
 	"github.com/vadymlab/slot-game/internal/repository"
+	"github.com/vadymlab/slot-game/internal/retention"
+	"github.com/vadymlab/slot-game/internal/rtp"
 	"github.com/vadymlab/slot-game/internal/server"
 	"github.com/vadymlab/slot-game/internal/service"
+	"github.com/vadymlab/slot-game/internal/spinbatch"
+	"github.com/vadymlab/slot-game/internal/tracing"
+	"github.com/vadymlab/slot-game/internal/webhook"
 	"go.uber.org/fx"
 )
 
@@ -25,52 +39,159 @@ func initController(router *gin.Engine, ctrl controller.BaseController) {
 }
 
 // ConfigModule sets up the configuration dependencies for the application.
-// It includes providers for logging, slot configuration, and Redis configuration.
+// It includes providers for logging, slot configuration, password and login policies, and Redis configuration.
 var ConfigModule = fx.Module("config",
 	fx.Provide(config.GetLogConfig),
 	fx.Provide(config.GetSlotConfig),
+	fx.Provide(config.GetPasswordPolicy),
+	fx.Provide(config.GetLoginPolicy),
 	fx.Provide(redis.GetRedisConfig),
 )
 
+// ConfigDump logs the effective configuration once at startup, so that the settings an instance
+// actually runs with can be checked in its logs. Secrets, such as the JWT secret, the database
+// password and credentials embedded in URLs, are masked.
+var ConfigDump = fx.Invoke(func(
+	slotConfig *config.SlotConfig,
+	passwordPolicy *config.PasswordPolicy,
+	loginPolicy *config.LoginPolicy,
+	apiConfig *server.APIConfig,
+	pgConfig *postgres.PgConfig,
+	poolConfig *database.PoolConfig,
+	replicaConfig *database.ReplicaConfig,
+	redisConfig *redis.Config,
+	webhookConfig *webhook.Config,
+	retentionConfig *retention.Config,
+	rtpConfig *rtp.Config,
+	migrationConfig *database.MigrationConfig,
+	tracingConfig *tracing.Config,
+	spinBatchConfig *spinbatch.Config,
+	reportingConfig *reporting.Config,
+	eventBusConfig *eventbus.Config,
+	exchangeConfig *exchange.Config,
+	grpcConfig *grpcserver.Config,
+) {
+	log.FromContext(context.Background()).Infow("effective configuration",
+		"slot", config.Masked(slotConfig, "TrustedAPIKeys"),
+		"password_policy", config.Masked(passwordPolicy),
+		"login_policy", config.Masked(loginPolicy),
+		"api", config.Masked(apiConfig, "JWTSecret"),
+		"database", config.Masked(pgConfig, "Password"),
+		"database_pool", config.Masked(poolConfig),
+		"database_replica", config.Masked(replicaConfig),
+		"redis", config.Masked(redisConfig),
+		"webhook", config.Masked(webhookConfig, "Secret"),
+		"retention", config.Masked(retentionConfig),
+		"rtp", config.Masked(rtpConfig),
+		"migration", config.Masked(migrationConfig),
+		"tracing", config.Masked(tracingConfig),
+		"spin_batch", config.Masked(spinBatchConfig),
+		"reporting", config.Masked(reportingConfig, "Secret"),
+		"event_bus", config.Masked(eventBusConfig),
+		"exchange", config.Masked(exchangeConfig),
+		"grpc", config.Masked(grpcConfig),
+	)
+})
+
 // Repositories defines providers for the repository layer, which is responsible
 // for data persistence and retrieval logic. Includes providers for UserRepository
-// and SlotRepository, which handle user data and slot game data, respectively.
+// and SlotRepository, which handle user data and slot game data, respectively,
+// as well as the promo code, ledger, wallet, authentication event, withdrawal and game session repositories.
 var Repositories = fx.Provide(
 	repository.NewUserRepository,
 	repository.NewSlotRepository,
+	repository.NewPromoRepository,
+	repository.NewLedgerRepository,
+	repository.NewWalletRepository,
+	repository.NewAuthEventRepository,
+	repository.NewWithdrawalRepository,
+	repository.NewSessionRepository,
 )
 
 // Services defines providers for the service layer, which contains business logic.
 // It includes UserService and SlotService, handling operations related to user
-// management and slot game logic.
+// management and slot game logic, the default hook migrating the balance of ended demo sessions,
+// the SessionService grouping spins into game sessions, and the
+// EventNotifier publishing their significant events and the events of the event bus.
 var Services = fx.Provide(
+	service.NewEventNotifier,
 	service.NewUserService,
 	service.NewSlotService,
+	service.NewDemoMigration,
+	service.NewLoginGuard,
+	service.NewRegistrationGuard,
+	service.NewWalletService,
+	service.NewAuthAuditService,
+	service.NewWithdrawalService,
+	service.NewSessionService,
+)
+
+// Decorators wraps service and repository providers with optional cross-cutting behavior, such as
+// the Redis-backed user cache and the tracing spans, without changing the services themselves.
+var Decorators = fx.Decorate(
+	decorateUserService,
+	service.NewTracedSlotService,
+	service.NewTracedWalletService,
+	repository.NewTracedSlotRepository,
+	repository.NewTracedUserRepository,
+	repository.NewTracedWalletRepository,
+	repository.NewTracedLedgerRepository,
 )
 
+// decorateUserService layers the user cache and the tracing spans on the user service, as Fx
+// allows a single decorator per type. The spans wrap the cache, so that cache hits are traced too.
+func decorateUserService(
+	redisConfig *redis.Config,
+	cache interfaces.IUserCache,
+	tracingConfig *tracing.Config,
+	userService interfaces.IUserService,
+) interfaces.IUserService {
+	return service.NewTracedUserService(tracingConfig, service.NewCachedUserService(redisConfig, cache, userService))
+}
+
 // Controllers defines providers for HTTP controllers, responsible for handling
 // HTTP requests and interacting with the service layer. This includes controllers
-// for user management, system status, wallet operations, and slot game endpoints.
+// for user management, system status, wallet operations, slot game and admin endpoints.
 var Controllers = fx.Provide(
 	controller.NewUserController,
 	controller.NewStatusController,
 	controller.NewWalletController,
 	controller.NewSlotController,
+	controller.NewAdminController,
 )
 
 // RootModule orchestrates the complete application setup, assembling repositories,
 // services, controllers, and configurations into an fx.Module for dependency injection.
 //
 // Additionally, it sets up Swagger API documentation, initializes HTTP controllers,
-// and enables logging capabilities.
+// serves the gRPC API when its port is set and enables logging capabilities.
 var RootModule = fx.Module("server",
 	Repositories,
 	Services,
+	Decorators,
 	Controllers,
 	ConfigModule,
 	database.DBModule,
+	database.MigrationModule,
+	database.AutoMigrate,
+	advisorylock.Module,
 	server.Module,
 	redis.Module,
+	webhook.Module,
+	eventbus.Module,
+	tracing.Module,
+	retention.Module,
+	retention.Scheduler,
+	rtp.Module,
+	rtp.Scheduler,
+	spinbatch.Module,
+	spinbatch.Flusher,
+	reporting.Module,
+	reporting.Deliverer,
+	exchange.Module,
+	grpcserver.Module,
+	grpcserver.Listener,
+	ConfigDump,
 	fx.Provide(log.NewLogger),
 	fx.Invoke(func(router *gin.Engine,
 
@@ -78,6 +199,7 @@ var RootModule = fx.Module("server",
 		statusController *controller.StatusController,
 		walletController *controller.WalletController,
 		slotController *controller.SlotController,
+		adminController *controller.AdminController,
 	) {
 		// Registers Swagger API documentation handler on /swagger endpoint
 		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -87,5 +209,6 @@ var RootModule = fx.Module("server",
 		initController(router, statusController)
 		initController(router, walletController)
 		initController(router, slotController)
+		initController(router, adminController)
 	}),
 )
@@ -0,0 +1,48 @@
+package app
+
+import (
+	log "github.com/public-forge/go-logger"
+	"github.com/urfave/cli/v2"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/database"
+	"go.uber.org/fx"
+)
+
+// RunMigrate applies the pending database schema migrations embedded in the binary and exits.
+// Instances migrating at the same time wait for each other, so it is safe to run on every deploy.
+//
+// Parameters:
+//   - c: *cli.Context, a context object from the CLI, containing configuration and command line arguments.
+//
+// Returns:
+//   - error: An error if the application cannot be assembled or a migration fails.
+func RunMigrate(c *cli.Context) error {
+	var migrateErr error
+	migrateApp := fx.New(
+		fx.NopLogger,
+		fx.Provide(func() *cli.Context {
+			return c
+		}),
+		fx.Provide(config.GetLogConfig),
+		database.DBModule,
+		database.MigrationModule,
+		fx.Invoke(func(cfg *log.Config, migrator *database.Migrator) {
+			logger, err := log.NewLogger(cfg)
+			if err != nil {
+				migrateErr = err
+				return
+			}
+			ctx := log.ToContext(c.Context, logger)
+			applied, err := migrator.Up(ctx)
+			if err != nil {
+				migrateErr = err
+				return
+			}
+			logger.Infof("migrate finished, %d migrations applied", applied)
+		}),
+	)
+	if err := migrateApp.Err(); err != nil {
+		return err
+	}
+	return migrateErr
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	log "github.com/public-forge/go-logger"
+	"github.com/urfave/cli/v2"
+	"github.com/vadymlab/slot-game/internal/advisorylock"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/database"
+	"github.com/vadymlab/slot-game/internal/repository"
+	"github.com/vadymlab/slot-game/internal/retention"
+	"go.uber.org/fx"
+)
+
+// RunPruneSpins deletes the spins outside the configured retention window once and exits.
+// It takes the same advisory lock as the periodic job, so it never prunes concurrently with
+// a running instance; if the lock is held elsewhere, nothing is pruned.
+//
+// Parameters:
+//   - c: *cli.Context, a context object from the CLI, containing configuration and command line arguments.
+//
+// Returns:
+//   - error: An error if the application cannot be assembled or pruning fails.
+func RunPruneSpins(c *cli.Context) error {
+	var pruneErr error
+	pruneApp := fx.New(
+		fx.NopLogger,
+		fx.Provide(func() *cli.Context {
+			return c
+		}),
+		fx.Provide(config.GetLogConfig),
+		database.DBModule,
+		advisorylock.Module,
+		retention.Module,
+		fx.Provide(repository.NewSlotRepository),
+		fx.Invoke(func(cfg *log.Config, pruner *retention.Pruner) {
+			logger, err := log.NewLogger(cfg)
+			if err != nil {
+				pruneErr = err
+				return
+			}
+			ctx := log.ToContext(c.Context, logger)
+			deleted, err := pruner.Run(ctx)
+			if err != nil {
+				pruneErr = err
+				return
+			}
+			logger.Infof("prune-spins finished, %d spins deleted", deleted)
+		}),
+	)
+	if err := pruneApp.Err(); err != nil {
+		return err
+	}
+	return pruneErr
+}
@@ -0,0 +1,19 @@
+CREATE TABLE ledger_entries
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER        NOT NULL,
+    type       VARCHAR(32)    NOT NULL,
+    amount     NUMERIC(10, 2) NOT NULL,
+    reference  VARCHAR(255),
+    created_at TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+
+    -- Foreign key constraint to users table
+    CONSTRAINT fk_ledger_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_ledger_entries_user_id ON ledger_entries (user_id);
@@ -0,0 +1,37 @@
+CREATE TABLE promo_codes
+(
+    id             SERIAL PRIMARY KEY,
+    code           VARCHAR(64) UNIQUE NOT NULL,
+    type           VARCHAR(16)        NOT NULL,
+    value          NUMERIC(10, 2)     NOT NULL,
+    cap            NUMERIC(10, 2)     NOT NULL DEFAULT 0,
+    min_deposit    NUMERIC(10, 2)     NOT NULL DEFAULT 0,
+    per_user_limit INTEGER            NOT NULL DEFAULT 0,
+    expires_at     TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    deleted_at     TIMESTAMPTZ
+);
+
+CREATE TABLE promo_redemptions
+(
+    id            SERIAL PRIMARY KEY,
+    promo_code_id INTEGER        NOT NULL,
+    user_id       INTEGER        NOT NULL,
+    bonus_amount  NUMERIC(10, 2) NOT NULL,
+    created_at    TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    deleted_at    TIMESTAMPTZ,
+
+    -- Foreign key constraints to promo_codes and users tables
+    CONSTRAINT fk_redemption_promo_code
+        FOREIGN KEY (promo_code_id)
+            REFERENCES promo_codes (id)
+            ON UPDATE CASCADE,
+    CONSTRAINT fk_redemption_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_promo_redemptions_promo_user ON promo_redemptions (promo_code_id, user_id);
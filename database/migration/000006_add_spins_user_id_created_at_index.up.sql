@@ -0,0 +1,2 @@
+-- Serves the spin history query: filter by user, newest first
+CREATE INDEX IF NOT EXISTS idx_spins_user_id_created_at ON spins (user_id, created_at DESC);
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS is_admin;
+
+ALTER TABLE spins
+    DROP COLUMN IF EXISTS void_reason,
+    DROP COLUMN IF EXISTS voided_at;
@@ -0,0 +1,6 @@
+ALTER TABLE spins
+    ADD COLUMN voided_at   TIMESTAMPTZ,
+    ADD COLUMN void_reason VARCHAR(255);
+
+ALTER TABLE users
+    ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
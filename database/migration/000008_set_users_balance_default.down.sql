@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ALTER COLUMN balance DROP NOT NULL,
+    ALTER COLUMN balance SET DEFAULT NULL;
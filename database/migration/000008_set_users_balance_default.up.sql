@@ -0,0 +1,7 @@
+UPDATE users
+SET balance = 0
+WHERE balance IS NULL;
+
+ALTER TABLE users
+    ALTER COLUMN balance SET DEFAULT 0,
+    ALTER COLUMN balance SET NOT NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_spins_created_at;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_spins_created_at ON spins (created_at);
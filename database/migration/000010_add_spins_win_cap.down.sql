@@ -0,0 +1,3 @@
+ALTER TABLE spins
+    DROP COLUMN IF EXISTS win_capped,
+    DROP COLUMN IF EXISTS raw_win_amount;
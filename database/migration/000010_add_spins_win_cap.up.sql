@@ -0,0 +1,5 @@
+ALTER TABLE spins
+    ADD COLUMN raw_win_amount NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN win_capped     BOOLEAN        NOT NULL DEFAULT FALSE;
+
+UPDATE spins SET raw_win_amount = win_amount;
@@ -0,0 +1,19 @@
+CREATE TABLE wallets
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER        NOT NULL,
+    currency   VARCHAR(3)     NOT NULL,
+    balance    NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+
+    -- Foreign key constraint to users table
+    CONSTRAINT fk_wallet_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON UPDATE CASCADE,
+
+    -- A user holds at most one wallet per currency
+    CONSTRAINT uq_wallets_user_currency UNIQUE (user_id, currency)
+);
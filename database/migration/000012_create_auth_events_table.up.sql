@@ -0,0 +1,23 @@
+CREATE TABLE auth_events
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER,
+    login      VARCHAR(255) NOT NULL,
+    type       VARCHAR(32)  NOT NULL,
+    success    BOOLEAN      NOT NULL,
+    ip         VARCHAR(45),
+    user_agent VARCHAR(512),
+    trace_id   VARCHAR(64),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+
+    -- Foreign key constraint to users table; failed logins for unknown logins have no user
+    CONSTRAINT fk_auth_event_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON UPDATE CASCADE
+);
+
+-- Serves the security log query: filter by user, newest first
+CREATE INDEX idx_auth_events_user_id_created_at ON auth_events (user_id, created_at DESC);
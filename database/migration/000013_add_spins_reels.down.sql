@@ -0,0 +1,2 @@
+ALTER TABLE spins
+    DROP COLUMN IF EXISTS reels;
@@ -0,0 +1,3 @@
+-- Symbols shown on the reels, comma-separated; empty for spins played before this migration
+ALTER TABLE spins
+    ADD COLUMN reels VARCHAR(255) NOT NULL DEFAULT '';
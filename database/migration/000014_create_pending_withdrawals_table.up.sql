@@ -0,0 +1,20 @@
+CREATE TABLE pending_withdrawals
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER        NOT NULL,
+    amount     NUMERIC(10, 2) NOT NULL,
+    status     VARCHAR(16)    NOT NULL,
+    decided_at TIMESTAMPTZ,
+    reason     VARCHAR(255),
+    created_at TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+
+    -- Foreign key constraint to users table
+    CONSTRAINT fk_pending_withdrawal_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_pending_withdrawals_user_id ON pending_withdrawals (user_id);
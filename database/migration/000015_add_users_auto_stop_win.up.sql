@@ -0,0 +1,3 @@
+-- Win above which the client is told to stop spinning; NULL uses the configured default
+ALTER TABLE users
+    ADD COLUMN auto_stop_win NUMERIC(10, 2);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_spins_session_id;
+
+ALTER TABLE spins
+    DROP COLUMN IF EXISTS session_id;
+
+DROP TABLE IF EXISTS game_sessions;
@@ -0,0 +1,23 @@
+CREATE TABLE game_sessions
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER     NOT NULL,
+    ended_at   TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+
+    -- Foreign key constraint to users table
+    CONSTRAINT fk_game_session_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_game_sessions_user_id_created_at ON game_sessions (user_id, created_at DESC);
+
+-- Spins played before sessions were tracked keep a NULL session
+ALTER TABLE spins
+    ADD COLUMN session_id INTEGER REFERENCES game_sessions (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE INDEX idx_spins_session_id ON spins (session_id);
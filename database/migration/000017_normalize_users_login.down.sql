@@ -0,0 +1,6 @@
+-- The original spelling of normalized logins is not kept, so they stay normalized; renamed logins are restored
+UPDATE users
+SET login = (SELECT old_login FROM login_renames r WHERE r.user_id = users.id)
+WHERE id IN (SELECT user_id FROM login_renames);
+
+DROP TABLE login_renames;
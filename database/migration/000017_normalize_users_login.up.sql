@@ -0,0 +1,29 @@
+-- Logins are matched trimmed and lowercased, so every stored login is normalized. Where several
+-- accounts share a normalized login, the one already spelled that way, or else the oldest, keeps
+-- it; the others get it suffixed with their ID, and the migration fails if that is taken too. The
+-- renames are recorded in login_renames, so that the affected users can be told their new login.
+CREATE TABLE login_renames
+(
+    user_id    INTEGER PRIMARY KEY REFERENCES users (id),
+    old_login  VARCHAR(255) NOT NULL,
+    new_login  VARCHAR(255) NOT NULL,
+    renamed_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO login_renames (user_id, old_login, new_login)
+SELECT id, login, LOWER(TRIM(login)) || '-' || id
+FROM (SELECT id,
+             login,
+             ROW_NUMBER() OVER (PARTITION BY LOWER(TRIM(login))
+                 ORDER BY login = LOWER(TRIM(login)) DESC, id) AS claim
+      FROM users) claims
+WHERE claim > 1;
+
+UPDATE users
+SET login = (SELECT new_login FROM login_renames r WHERE r.user_id = users.id)
+WHERE id IN (SELECT user_id FROM login_renames);
+
+UPDATE users
+SET login = LOWER(TRIM(login))
+WHERE login <> LOWER(TRIM(login))
+  AND id NOT IN (SELECT user_id FROM login_renames);
@@ -0,0 +1,3 @@
+-- Game each spin was played in; spins played before games were introduced played the default game
+ALTER TABLE spins
+    ADD COLUMN game_id VARCHAR(64) NOT NULL DEFAULT 'default';
@@ -0,0 +1,3 @@
+-- End of the user's self-exclusion, during which the user can neither spin nor deposit
+ALTER TABLE users
+    ADD COLUMN excluded_until TIMESTAMPTZ;
@@ -0,0 +1,5 @@
+ALTER TABLE spins
+    DROP COLUMN IF EXISTS original_win_amount,
+    DROP COLUMN IF EXISTS original_bet_amount,
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS currency;
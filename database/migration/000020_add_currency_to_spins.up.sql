@@ -0,0 +1,7 @@
+-- Currency a bet was placed in when it differed from the base currency, the exchange rate it was
+-- converted at and the bet and win in that currency; empty and 0 for bets in the base currency
+ALTER TABLE spins
+    ADD COLUMN currency            VARCHAR(3)     NOT NULL DEFAULT '',
+    ADD COLUMN exchange_rate       NUMERIC(18, 8) NOT NULL DEFAULT 0,
+    ADD COLUMN original_bet_amount NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN original_win_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE game_sessions
+    DROP COLUMN IF EXISTS reality_checked_at;
@@ -0,0 +1,4 @@
+-- Time the user last acknowledged a reality check of the session; the next one is due an
+-- interval after it, or after the start of the session while none was acknowledged
+ALTER TABLE game_sessions
+    ADD COLUMN reality_checked_at TIMESTAMPTZ;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_ledger_entries_user_id_type_created_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deposit_limits_pending_at,
+    DROP COLUMN IF EXISTS pending_deposit_limit_daily,
+    DROP COLUMN IF EXISTS pending_deposit_limit_per_transaction,
+    DROP COLUMN IF EXISTS deposit_limit_daily,
+    DROP COLUMN IF EXISTS deposit_limit_per_transaction;
@@ -0,0 +1,13 @@
+-- Deposit limits of the user; NULL leaves deposits unlimited
+ALTER TABLE users
+    ADD COLUMN deposit_limit_per_transaction NUMERIC(10, 2),
+    ADD COLUMN deposit_limit_daily NUMERIC(10, 2);
+
+-- Raised or removed deposit limits waiting out the cooldown, applying from deposit_limits_pending_at
+ALTER TABLE users
+    ADD COLUMN pending_deposit_limit_per_transaction NUMERIC(10, 2),
+    ADD COLUMN pending_deposit_limit_daily NUMERIC(10, 2),
+    ADD COLUMN deposit_limits_pending_at TIMESTAMPTZ;
+
+-- Sums the deposits of a user over the last day for the daily deposit limit
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_user_id_type_created_at ON ledger_entries (user_id, type, created_at);
@@ -0,0 +1,3 @@
+ALTER TABLE spins
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash;
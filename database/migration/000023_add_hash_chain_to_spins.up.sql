@@ -0,0 +1,5 @@
+-- Hash of the previous spin of the user and of the spin itself, chaining the spin records of each
+-- user; empty for spins written while the hash chain was disabled
+ALTER TABLE spins
+    ADD COLUMN prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN hash      VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+ALTER TABLE spins
+    DROP COLUMN IF EXISTS free_spins_awarded;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS free_spins;
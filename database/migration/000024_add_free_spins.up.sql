@@ -0,0 +1,7 @@
+-- Free spins awarded to the user by scatters
+ALTER TABLE users
+    ADD COLUMN free_spins INTEGER NOT NULL DEFAULT 0;
+
+-- Free spins awarded by the spin along with its payout; 0 for spins played before this migration
+ALTER TABLE spins
+    ADD COLUMN free_spins_awarded INTEGER NOT NULL DEFAULT 0;
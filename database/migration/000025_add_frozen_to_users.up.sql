@@ -0,0 +1,3 @@
+-- Whether fraud ops froze the account pending an investigation
+ALTER TABLE users
+    ADD COLUMN frozen BOOLEAN NOT NULL DEFAULT FALSE;
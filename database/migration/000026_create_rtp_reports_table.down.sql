@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_rtp_reports_window_end;
+
+DROP TABLE IF EXISTS rtp_reports;
@@ -0,0 +1,19 @@
+-- Periodic reports of the realized return to player of the spins within a window
+CREATE TABLE rtp_reports
+(
+    id            SERIAL PRIMARY KEY,
+    window_start  TIMESTAMPTZ      NOT NULL,
+    window_end    TIMESTAMPTZ      NOT NULL,
+    total_spins   BIGINT           NOT NULL DEFAULT 0,
+    winning_spins BIGINT           NOT NULL DEFAULT 0,
+    total_wagered DOUBLE PRECISION NOT NULL DEFAULT 0,
+    total_won     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    realized_rtp  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    target_rtp    DOUBLE PRECISION NOT NULL,
+    drifted       BOOLEAN          NOT NULL DEFAULT FALSE,
+    created_at    TIMESTAMPTZ      NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ      NOT NULL DEFAULT NOW(),
+    deleted_at    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_rtp_reports_window_end ON rtp_reports (window_end DESC);
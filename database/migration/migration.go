@@ -0,0 +1,11 @@
+// Package migration embeds the SQL schema migrations of the service, so that the binary can apply
+// them with the migrate command. The same files are applied by golang-migrate in the migrations
+// container, which ignores this Go file.
+package migration
+
+import "embed"
+
+// Files holds the numbered up and down migrations, e.g. 000002_create_users_table.up.sql.
+//
+//go:embed *.sql
+var Files embed.FS
@@ -0,0 +1,109 @@
+package migration
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openUsers returns an in-memory database with the columns of the users table the login
+// migrations touch. The test is skipped when the SQLite driver is built without cgo.
+func openUsers(t *testing.T, logins ...string) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	db.SetMaxOpenConns(1)
+	if err := db.Ping(); err != nil {
+		t.Skipf("SQLite is unavailable: %v", err)
+	}
+	_, err = db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, login VARCHAR(255) UNIQUE NOT NULL)")
+	require.NoError(t, err)
+	for _, login := range logins {
+		_, err = db.Exec("INSERT INTO users (login) VALUES (?)", login)
+		require.NoError(t, err)
+	}
+	return db
+}
+
+// apply executes a migration file.
+func apply(t *testing.T, db *sql.DB, name string) error {
+	statements, err := Files.ReadFile(name)
+	require.NoError(t, err)
+	_, err = db.Exec(string(statements))
+	return err
+}
+
+// logins returns the logins of the users by ID.
+func logins(t *testing.T, db *sql.DB) map[int]string {
+	rows, err := db.Query("SELECT id, login FROM users")
+	require.NoError(t, err)
+	defer rows.Close()
+	res := map[int]string{}
+	for rows.Next() {
+		var id int
+		var login string
+		require.NoError(t, rows.Scan(&id, &login))
+		res[id] = login
+	}
+	require.NoError(t, rows.Err())
+	return res
+}
+
+// TestNormalizeUsersLogin_RenamesClashes checks that every login is normalized: of the accounts
+// sharing a normalized login, the one already spelled that way, or else the oldest, keeps it and
+// the others are renamed with their ID and recorded, and the down migration restores the renamed.
+func TestNormalizeUsersLogin_RenamesClashes(t *testing.T) {
+	db := openUsers(t, " Alice ", "Bob", "bob", "BOB ", "Carol", " CAROL", "dave")
+
+	require.NoError(t, apply(t, db, "000017_normalize_users_login.up.sql"))
+
+	assert.Equal(t, map[int]string{
+		1: "alice",
+		2: "bob-2",
+		3: "bob",
+		4: "bob-4",
+		5: "carol",
+		6: "carol-6",
+		7: "dave",
+	}, logins(t, db))
+
+	rows, err := db.Query("SELECT user_id, old_login, new_login FROM login_renames ORDER BY user_id")
+	require.NoError(t, err)
+	var renames [][3]string
+	for rows.Next() {
+		var rename [3]string
+		require.NoError(t, rows.Scan(&rename[0], &rename[1], &rename[2]))
+		renames = append(renames, rename)
+	}
+	require.NoError(t, rows.Close())
+	assert.Equal(t, [][3]string{
+		{"2", "Bob", "bob-2"},
+		{"4", "BOB ", "bob-4"},
+		{"6", " CAROL", "carol-6"},
+	}, renames)
+
+	require.NoError(t, apply(t, db, "000017_normalize_users_login.down.sql"))
+
+	assert.Equal(t, map[int]string{
+		1: "alice",
+		2: "Bob",
+		3: "bob",
+		4: "BOB ",
+		5: "carol",
+		6: " CAROL",
+		7: "dave",
+	}, logins(t, db))
+}
+
+// TestNormalizeUsersLogin_SuffixTakenFails checks that the migration fails rather than leave a
+// login un-normalized when the suffixed login of a renamed account is taken as well.
+func TestNormalizeUsersLogin_SuffixTakenFails(t *testing.T) {
+	db := openUsers(t, "bob", "Bob", "bob-2")
+
+	err := apply(t, db, "000017_normalize_users_login.up.sql")
+
+	assert.ErrorContains(t, err, "UNIQUE")
+}
@@ -15,73 +15,75 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/login": {
-            "post": {
-                "description": "Authenticates a user and returns a JWT token",
-                "consumes": [
-                    "application/json"
+        "/api/admin/maintenance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Reports whether the game and wallet endpoints are in maintenance mode, by the admin toggle or the maintenance flag",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Login user",
+                "summary": "Get maintenance mode",
                 "parameters": [
                     {
-                        "description": "Login request body",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token for authenticated user",
+                        "description": "Maintenance mode",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.MaintenanceResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request due to invalid input or incorrect login details",
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     }
                 }
-            }
-        },
-        "/api/profile": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the profile and balance of the authenticated user",
+                "description": "Switches the maintenance mode of the game and wallet endpoints on or off for all instances.\nWhile the maintenance flag is set, the endpoints stay in maintenance mode regardless of the toggle",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Get user profile",
+                "summary": "Switch maintenance mode",
                 "parameters": [
                     {
                         "type": "string",
@@ -89,100 +91,168 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Maintenance request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MaintenanceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User profile information",
+                        "description": "Maintenance mode",
                         "schema": {
-                            "$ref": "#/definitions/response.ProfileResponse"
+                            "$ref": "#/definitions/response.MaintenanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - user not authenticated",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     }
                 }
             }
         },
-        "/api/register": {
+        "/api/admin/spins/{id}/void": {
             "post": {
-                "description": "Allows a new user to register with their details",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refunds the bet and claws back the win of a disputed spin, marks it voided and records compensating ledger entries.\nA spin can only be voided once.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Void a spin",
                 "parameters": [
                     {
-                        "description": "Registration request body",
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Spin ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Void request body",
                         "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.RegisterRequest"
+                            "$ref": "#/definitions/request.VoidSpinRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User registered successfully",
+                        "description": "The voided spin",
                         "schema": {
-                            "$ref": "#/definitions/response.RegisterResponse"
+                            "$ref": "#/definitions/response.VoidedSpinResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request due to invalid input",
+                        "description": "Bad request due to invalid input or insufficient funds for the clawback",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Spin not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "409": {
-                        "description": "Conflict - user already exists",
+                        "description": "Conflict - the spin has already been voided",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     }
                 }
             }
         },
-        "/api/slot/history": {
-            "post": {
+        "/api/admin/users": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the user's spin history, showing past spins with their results",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves a page of the users whose login contains the search, case-insensitively, with their balance and account details.\nUsers are ordered by creation, newest first, or by balance, highest first; order=asc reverses the order. Password hashes are never returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Slot"
+                    "Admin"
                 ],
-                "summary": "Get spin history",
+                "summary": "List users",
                 "parameters": [
                     {
                         "type": "string",
@@ -190,45 +260,99 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the login the users must contain",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created",
+                            "balance"
+                        ],
+                        "type": "string",
+                        "description": "Order of the users (default created)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Direction of the order (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of users to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of past spin results",
+                        "description": "Page of users",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.SpinHistoryResponse"
-                            }
+                            "$ref": "#/definitions/response.Page-response_AdminUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid search, order or pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     }
                 }
             }
         },
-        "/api/slot/spin": {
-            "post": {
+        "/api/admin/users/{id}/frozen": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Initiates a spin with the specified bet amount and returns the result.",
+                "description": "Freezes or unfreezes the account of a user. While frozen, the user's login, spins, deposits,\nwithdrawals and transfers are rejected with 403 and the ACCOUNT_FROZEN code",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Slot"
+                    "Admin"
                 ],
-                "summary": "Spin the slot machine",
+                "summary": "Freeze or unfreeze a user",
                 "parameters": [
                     {
                         "type": "string",
@@ -238,272 +362,3350 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Spin request body",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Freeze request body",
                         "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.SpinRequest"
+                            "$ref": "#/definitions/request.FreezeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Spin result with win amount",
+                        "description": "The updated user",
                         "schema": {
-                            "$ref": "#/definitions/response.SpinResponse"
+                            "$ref": "#/definitions/response.AdminUserResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request due to invalid input or insufficient funds",
+                        "description": "Bad request due to an invalid user ID or input",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     }
                 }
             }
         },
-        "/api/status": {
+        "/api/admin/users/{id}/spin-chain": {
             "get": {
-                "description": "Returns a simple status message indicating the server is operational",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Status"
-                ],
-                "summary": "Check server status",
-                "responses": {
-                    "200": {
-                        "description": "Server status message",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/wallet/deposit": {
-            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows the user to deposit funds into their wallet",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Recomputes the hash of each chained spin of the user, oldest first, and checks that it links to the hash of the spin before it.\nVerification stops at the first broken spin. Spins written while the hash chain was disabled are not part of it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallet"
+                    "Admin"
                 ],
-                "summary": "Deposit funds into wallet",
+                "summary": "Verify the spin hash chain of a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "bearer",
-                        "description": "JWT Token",
+                        "description": "Bearer token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Deposit amount",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.DepositRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated wallet balance",
+                        "description": "Result of the verification",
                         "schema": {
-                            "$ref": "#/definitions/response.DepositResponse"
+                            "$ref": "#/definitions/response.SpinChainResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Bad request due to an invalid user ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - user not authenticated",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     }
                 }
             }
         },
-        "/api/wallet/withdraw": {
+        "/api/admin/withdrawals/{id}/approve": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows the user to withdraw funds from their wallet",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Finalizes a pending withdrawal. The held funds already left the user's balance when the withdrawal was requested.\nA withdrawal can only be approved or rejected once.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallet"
+                    "Admin"
                 ],
-                "summary": "Withdraw funds from wallet",
+                "summary": "Approve a withdrawal",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "bearer",
-                        "description": "JWT Token",
+                        "description": "Bearer token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Withdraw amount",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.WithdrawRequest"
-                        }
+                        "type": "integer",
+                        "description": "Withdrawal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated wallet balance",
+                        "description": "The approved withdrawal",
                         "schema": {
-                            "$ref": "#/definitions/response.WithdrawResponse"
+                            "$ref": "#/definitions/response.WithdrawalResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Bad request due to an invalid withdrawal ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - user not authenticated",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Forbidden - user is not an admin",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
                         }
-                    }
-                }
-            }
-        }
-    },
+                    },
+                    "404": {
+                        "description": "Withdrawal not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - the withdrawal has already been approved or rejected",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawals/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refuses a pending withdrawal, returns the held funds to the user's balance and records a compensating ledger entry.\nA withdrawal can only be approved or rejected once.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reject a withdrawal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Withdrawal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reject request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RejectWithdrawalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The rejected withdrawal",
+                        "schema": {
+                            "$ref": "#/definitions/response.WithdrawalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Withdrawal not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - the withdrawal has already been approved or rejected",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/login": {
+            "post": {
+                "description": "Authenticates a user and returns a JWT token",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token for authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/response.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or incorrect login details",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the account is frozen",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - nonce has already been used, or too many active login sessions",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked - too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - too many login attempts from the client or for the login",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends the active game session and the login session of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Logout user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Game session ended"
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the profile and the balances per currency of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User profile information",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the login of the authenticated user; the current password is required",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change user login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Login change request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated user profile",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input or incorrect password",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - login already taken",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile/deposit-limits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the per-transaction and daily deposit limits in effect for the authenticated user,\nalong with raised or removed limits still waiting out the cooldown",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get deposit limits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deposit limits",
+                        "schema": {
+                            "$ref": "#/definitions/response.DepositLimitsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the per-transaction and daily deposit limits of the authenticated user; null removes a limit.\nLowered limits apply right away, raised or removed limits once the configured cooldown has passed",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change deposit limits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Deposit limits request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateDepositLimitsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated deposit limits",
+                        "schema": {
+                            "$ref": "#/definitions/response.DepositLimitsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to a limit that is not positive",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile/security": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of the logins, registration and login changes of the authenticated user, newest first,\nincluding failed attempts, with the IP address and user agent they were made from",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get security log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of events to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of authentication events",
+                        "schema": {
+                            "$ref": "#/definitions/response.Page-response_AuthEventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile/self-exclude": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Excludes the authenticated user from spinning and depositing for the requested number of days.\nThe exclusion lifts by itself once it ends; it cannot be reversed early, and a shorter period keeps the running exclusion",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Self-exclude from play",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Self-exclusion request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SelfExcludeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "End of the self-exclusion",
+                        "schema": {
+                            "$ref": "#/definitions/response.SelfExclusionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to an invalid number of days",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the play settings of the authenticated user, such as the auto-stop threshold",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get play settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Play settings",
+                        "schema": {
+                            "$ref": "#/definitions/response.SettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the play settings of the authenticated user. A spin winning more than auto_stop_win\nis flagged with should_stop; null falls back to the server default and 0 disables the auto-stop",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change play settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Play settings request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated play settings",
+                        "schema": {
+                            "$ref": "#/definitions/response.SettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to a negative threshold",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/register": {
+            "post": {
+                "description": "Allows a new user to register with their details.\nA retry with the same Idempotency-Key header and credentials returns the originally registered user.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key making retries of the registration safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Registration request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User registered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - user already exists or the idempotency key was used for a different registration",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - too many registration attempts from the client or for the login",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/config": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the symbols with their display names and icons, the paytable and the allowed bets of a game.\nThe response carries an ETag and a Cache-Control header; a matching If-None-Match header is answered with 304 Not Modified",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Get game configuration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game to describe; empty describes the default game",
+                        "name": "game_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Public configuration of the game",
+                        "schema": {
+                            "$ref": "#/definitions/response.GameConfigResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified - the cached response is still current"
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found - the game does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/demo/end": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends the play-money demo session of the user, discarding the demo balance, which is never credited to the real wallet.\nWith the demo balance migration enabled, the balance is passed to the migration hook instead, which ignores it by default",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "End a demo session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance the demo session ended with",
+                        "schema": {
+                            "$ref": "#/definitions/response.DemoEndResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/demo/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts a new play-money demo session, resetting the demo balance to the configured amount",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Start a demo session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Demo balance of the new session",
+                        "schema": {
+                            "$ref": "#/definitions/response.DemoSessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - demo mode is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/history": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of the user's spin history, newest first, showing past spins with their results",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Get spin history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of spins to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of spins to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the history, YYYY-MM-DD in UTC",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the history, YYYY-MM-DD in UTC",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of past spin results",
+                        "schema": {
+                            "$ref": "#/definitions/response.Page-response_SpinHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid pagination parameters or dates",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/history.csv": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams the user's spin history, newest first, as CSV with a date,bet_amount,win_amount,reels header row.\nThe reels are separated by spaces and empty for spins played before the reels were stored.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Export spin history as CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the history, YYYY-MM-DD in UTC",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the history, YYYY-MM-DD in UTC",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV file of past spin results",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid dates",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client accepts neither JSON nor CSV",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/leaderboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the top players by total winnings for the given period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Get leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Aggregation period",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Top players ordered by total winnings",
+                        "schema": {
+                            "$ref": "#/definitions/response.Page-response_LeaderboardEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid period",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/reality-check/ack": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Acknowledges the reality check of the active game session, reported by the spins once the reality check interval has passed.\nThe spins carry the next reality check once the interval has passed since the acknowledgment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Acknowledge reality check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Reality check acknowledged"
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of the user's game sessions, newest first, with the spins, bets and wins of each.\nA session ends on logout or login, or once the user has been inactive for the session timeout;\nthe end of a session that timed out is only recorded when the next one starts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Get game sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of sessions to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of sessions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of game session summaries",
+                        "schema": {
+                            "$ref": "#/definitions/response.Page-response_SessionSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/spin": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Initiates a spin of the game named by game_id, or of the default game, with the specified bet amount and returns the result.\nWith the X-Demo-Mode header set to true, the spin uses the play-money demo balance and is not persisted.\nA bet placed in another currency is converted into the base currency at the current exchange rate; the result then also holds the bet and the win in that currency.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Spin the slot machine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Play the spin with the demo balance",
+                        "name": "X-Demo-Mode",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Reels",
+                        "in": "header"
+                    },
+                    {
+                        "type": "number",
+                        "description": "QA only: payout forced on the spin; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Win",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Spin request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SpinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Spin result with win amount",
+                        "schema": {
+                            "$ref": "#/definitions/response.SpinResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input, a disallowed bet amount, a currency without an exchange rate or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the user is self-excluded from play or the account is frozen",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found - the game does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - another spin of the user is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - the daily spin limit is reached",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/spin/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Plays up to count spins with the same bet and returns each result, their aggregate and the final balance.\nWhen the balance no longer covers the bet or the daily spin limit is reached mid-batch, the batch ends early and summary.stopped_by holds the error code.\nDemo mode is not supported.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Play several spins at once",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Reels",
+                        "in": "header"
+                    },
+                    {
+                        "type": "number",
+                        "description": "QA only: payout forced on the spins; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Win",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Bulk spin request body",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BulkSpinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Results of the spins played with their aggregate",
+                        "schema": {
+                            "$ref": "#/definitions/response.BulkSpinResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input, a count above the maximum, a disallowed bet amount, insufficient funds or demo mode",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the user is self-excluded from play or the account is frozen",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found - the game does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - another spin of the user is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - the daily spin limit is reached",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/spin/free": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Plays one of the user's free spins in the game named by the game_id query parameter, or in the default game, and returns the result.\nThe spin uses up a free spin instead of debiting a bet; its win is paid for the configured free spin bet and the spin is recorded without a bet.\nDemo mode is not supported.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Play a free spin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Reels",
+                        "in": "header"
+                    },
+                    {
+                        "type": "number",
+                        "description": "QA only: payout forced on the spin; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Win",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game to play; empty plays the default game",
+                        "name": "game_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result of the free spin",
+                        "schema": {
+                            "$ref": "#/definitions/response.SpinResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - the user has no free spins left, or demo mode",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the user is self-excluded from play or the account is frozen",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found - the game does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - another spin of the user is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - the daily spin limit is reached",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/spin/last": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the result of the user's last spin exactly as the spin returned it, while it is kept after the spin.\nOf a bulk spin, the last spin of the batch is kept. Demo spins are not kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Get the last spin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result of the last spin",
+                        "schema": {
+                            "$ref": "#/definitions/response.SpinResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found - the user has not spun recently",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/spin/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Plays a spin and streams its reels one by one as server-sent events, followed by the result.\nEach \"reel\" event carries a response.ReelEvent, the final \"result\" event a response.SpinResponse.\nThe bet amount and the game are read from the bet_amount and game_id query parameters for GET and from the JSON or XML body for POST.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Spin the slot machine with a streamed reveal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Play the spin with the demo balance",
+                        "name": "X-Demo-Mode",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Reels",
+                        "in": "header"
+                    },
+                    {
+                        "type": "number",
+                        "description": "QA only: payout forced on the spin; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Win",
+                        "in": "header"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Bet amount of a GET request",
+                        "name": "bet_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game of a GET request; empty plays the default game",
+                        "name": "game_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Spin request body of a POST request",
+                        "name": "req",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.SpinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of reel events followed by the spin result",
+                        "schema": {
+                            "$ref": "#/definitions/response.SpinResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input, a disallowed bet amount, a currency without an exchange rate or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the user is self-excluded from play or the account is frozen",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found - the game does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client accepts neither JSON nor server-sent events",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - another spin of the user is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the body of a POST request is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - the daily spin limit is reached",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Plays a spin and streams its reels one by one as server-sent events, followed by the result.\nEach \"reel\" event carries a response.ReelEvent, the final \"result\" event a response.SpinResponse.\nThe bet amount and the game are read from the bet_amount and game_id query parameters for GET and from the JSON or XML body for POST.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Spin the slot machine with a streamed reveal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Play the spin with the demo balance",
+                        "name": "X-Demo-Mode",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Reels",
+                        "in": "header"
+                    },
+                    {
+                        "type": "number",
+                        "description": "QA only: payout forced on the spin; ignored unless forced outcomes are enabled",
+                        "name": "X-Force-Win",
+                        "in": "header"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Bet amount of a GET request",
+                        "name": "bet_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game of a GET request; empty plays the default game",
+                        "name": "game_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Spin request body of a POST request",
+                        "name": "req",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.SpinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of reel events followed by the spin result",
+                        "schema": {
+                            "$ref": "#/definitions/response.SpinResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request due to invalid input, a disallowed bet amount, a currency without an exchange rate or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the user is self-excluded from play or the account is frozen",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found - the game does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client accepts neither JSON nor server-sent events",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - another spin of the user is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the body of a POST request is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - the daily spin limit is reached",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/slot/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the user's total spins, total wagered, total won, net result, biggest win and win rate.\nVoided spins are not counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slot"
+                ],
+                "summary": "Get player statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Play statistics of the user",
+                        "schema": {
+                            "$ref": "#/definitions/response.SpinStatsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/status": {
+            "get": {
+                "description": "Returns a simple status message indicating the server is operational",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Status"
+                ],
+                "summary": "Check server status",
+                "responses": {
+                    "200": {
+                        "description": "Server status message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wallet/balance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the balance of the user in a single currency, the base currency by default.\nA currency the user holds no wallet in has a balance of 0",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get wallet balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "bearer",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code of the currency, the base currency by default",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance in the currency",
+                        "schema": {
+                            "$ref": "#/definitions/response.BalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid currency code",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wallet/deposit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows the user to deposit funds into their wallet, optionally applying a promo code bonus",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Deposit funds into wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "bearer",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Deposit amount",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DepositRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated wallet balance",
+                        "schema": {
+                            "$ref": "#/definitions/response.DepositResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or promo code, or the deposit exceeds the deposit limits",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the user is self-excluded from play or the account is frozen",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wallet/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Debits the user's wallet and credits the recipient's wallet in a single transaction,\nrecording both sides in the ledger. Transfers to oneself are rejected",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Transfer funds to another user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "bearer",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipient and amount",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated wallet balance of the sender",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, self-transfer or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the account is frozen, or the recipient's account is frozen or self-excluded",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipient not found",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wallet/withdraw": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows the user to withdraw funds from their wallet.\nWhen withdrawals await admin approval, the amount is held as a pending withdrawal and the response is 202;\nheld funds leave the spendable balance until the withdrawal is rejected.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Withdraw funds from wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "bearer",
+                        "description": "JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Withdraw amount",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.WithdrawRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated wallet balance",
+                        "schema": {
+                            "$ref": "#/definitions/response.WithdrawResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Updated wallet balance and the pending withdrawal",
+                        "schema": {
+                            "$ref": "#/definitions/response.WithdrawResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the account is too new to withdraw or is frozen",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "406": {
+                        "description": "Not acceptable - the client does not accept JSON",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type - the request body is neither JSON nor XML",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponseMessage"
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
+        "request.BulkSpinRequest": {
+            "type": "object",
+            "required": [
+                "bet_amount",
+                "count"
+            ],
+            "properties": {
+                "bet_amount": {
+                    "description": "Bet amount of each spin, required and must be greater than 0",
+                    "type": "number"
+                },
+                "count": {
+                    "description": "Number of spins to play, required and must be greater than 0",
+                    "type": "integer"
+                },
+                "game_id": {
+                    "description": "Game to play; empty plays the default game",
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
         "request.DepositRequest": {
             "type": "object",
-            "required": [
-                "amount"
-            ],
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Transaction amount, required field",
+                    "type": "number"
+                },
+                "promo_code": {
+                    "description": "Optional promo code granting a deposit bonus",
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "request.FreezeRequest": {
+            "type": "object",
+            "required": [
+                "frozen"
+            ],
+            "properties": {
+                "frozen": {
+                    "description": "Whether the account is frozen, required",
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.LoginRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "description": "Login is the user's login email address or, if the login policy allows it, username.\nThis field is required; it is trimmed and lowercased, then its format is checked against the login policy.",
+                    "type": "string"
+                },
+                "nonce": {
+                    "description": "Nonce is an optional unique value generated by the client for each login request.\nA request repeating a recently used nonce is rejected as a replay.",
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 16
+                },
+                "password": {
+                    "description": "Password is the user's login password. This field is required and must be\nat least 8 characters long, providing basic security against weak passwords.",
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "request.MaintenanceRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "description": "Whether maintenance mode is on, required",
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "description": "Login is the user's login email address or, if the login policy allows it, username.\nThis field is required; it is trimmed and lowercased, then its format is checked against the login policy.",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password is the user's login password. This field is required and must be\nat least 8 characters long, providing basic security against weak passwords.",
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "request.RejectWithdrawalRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Reason for rejecting the withdrawal, required",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "request.SelfExcludeRequest": {
+            "type": "object",
+            "required": [
+                "days"
+            ],
+            "properties": {
+                "days": {
+                    "description": "Days is the number of days the user is excluded for, starting now. This field is required\nand must be between 1 and 3650.",
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1
+                }
+            }
+        },
+        "request.SpinRequest": {
+            "type": "object",
+            "required": [
+                "bet_amount"
+            ],
+            "properties": {
+                "bet_amount": {
+                    "description": "Bet amount, required and must be greater than 0",
+                    "type": "number"
+                },
+                "currency": {
+                    "description": "ISO 4217 code of the currency of the bet, in any case; empty bets in the base currency. Ignored by demo spins",
+                    "type": "string"
+                },
+                "game_id": {
+                    "description": "Game to play; empty plays the default game",
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "request.TransferRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "recipient_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Transaction amount, required field",
+                    "type": "number"
+                },
+                "recipient_id": {
+                    "description": "External ID of the user receiving the funds",
+                    "type": "string",
+                    "example": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+                }
+            }
+        },
+        "request.UpdateDepositLimitsRequest": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "Daily is the largest sum of deposits of a day. Null or omitted removes the limit.",
+                    "type": "number"
+                },
+                "per_transaction": {
+                    "description": "PerTransaction is the largest single deposit. Null or omitted removes the limit.",
+                    "type": "number"
+                }
+            }
+        },
+        "request.UpdateLoginRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "description": "Login is the new login email address or, if the login policy allows it, username.\nThis field is required; it is trimmed and lowercased, then its format is checked against the login policy.",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password is the user's current password, confirming the change.",
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "auto_stop_win": {
+                    "description": "AutoStopWin is the win above which the client is told to stop spinning. Null or omitted\nfalls back to the server default; 0 disables the auto-stop.",
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.VoidSpinRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Reason for voiding the spin, required",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "request.WithdrawRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Transaction amount, required field",
+                    "type": "number"
+                }
+            }
+        },
+        "response.AdminUserResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "Balance in the base currency",
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "Time the account was created",
+                    "type": "string"
+                },
+                "excluded_until": {
+                    "description": "End of the user's self-exclusion; omitted if the user never excluded themselves",
+                    "type": "string"
+                },
+                "frozen": {
+                    "description": "Whether an admin froze the account pending an investigation",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "Unique identifier of the user",
+                    "type": "string"
+                },
+                "is_admin": {
+                    "description": "Whether the user may use the admin endpoints",
+                    "type": "boolean"
+                },
+                "login": {
+                    "description": "Login name of the user",
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuthEventResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "The date and time of the operation, formatted as \"YYYY-MM-DD HH:MM:SS\"",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "Client IP address the operation was made from",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "Whether the operation succeeded",
+                    "type": "boolean"
+                },
+                "trace_id": {
+                    "description": "Trace ID of the request",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Operation, such as \"login\", \"register\" or \"login_change\"",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "description": "User-Agent header of the client",
+                    "type": "string"
+                }
+            }
+        },
+        "response.BalanceResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Balance in the currency",
+                    "type": "number"
+                },
+                "currency": {
+                    "description": "ISO 4217 currency code",
+                    "type": "string"
+                }
+            }
+        },
+        "response.BulkSpinResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "The balance of the user after the last spin",
+                    "type": "number"
+                },
+                "reality_check": {
+                    "description": "The time and money spent in the game session after the batch; omitted until due",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.RealityCheckResponse"
+                        }
+                    ]
+                },
+                "spins": {
+                    "description": "The results of the spins played, in order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SpinResponse"
+                    }
+                },
+                "summary": {
+                    "description": "The aggregate of the spins played",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.BulkSpinSummary"
+                        }
+                    ]
+                }
+            }
+        },
+        "response.BulkSpinSummary": {
+            "type": "object",
+            "properties": {
+                "net": {
+                    "description": "The total won minus the total wagered",
+                    "type": "number"
+                },
+                "played": {
+                    "description": "The number of spins played",
+                    "type": "integer"
+                },
+                "requested": {
+                    "description": "The number of spins requested",
+                    "type": "integer"
+                },
+                "stopped_by": {
+                    "description": "Error code of the reason the batch ended early, such as \"INSUFFICIENT_FUNDS\"",
+                    "type": "string"
+                },
+                "total_wagered": {
+                    "description": "The sum of the bets of the spins played",
+                    "type": "number"
+                },
+                "total_won": {
+                    "description": "The sum of the wins of the spins played",
+                    "type": "number"
+                }
+            }
+        },
+        "response.DemoEndResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "The play-money balance the demo session ended with; null if no session was active",
+                    "type": "number"
+                },
+                "migrated": {
+                    "description": "Whether the balance was passed to the demo migration hook instead of being discarded",
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.DemoSessionResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "The play-money balance of the demo session",
+                    "type": "number"
+                }
+            }
+        },
+        "response.DepositLimitsResponse": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "Largest sum of deposits of a day; null when unlimited",
+                    "type": "number"
+                },
+                "pending": {
+                    "description": "Raised or removed limits waiting out the cooldown; null when none are pending",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.PendingDepositLimitsResponse"
+                        }
+                    ]
+                },
+                "per_transaction": {
+                    "description": "Largest single deposit; null when unlimited",
+                    "type": "number"
+                }
+            }
+        },
+        "response.DepositResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "Updated wallet balance after the deposit transaction",
+                    "type": "number"
+                },
+                "bonus": {
+                    "description": "Bonus credited by the applied promo code",
+                    "type": "number"
+                }
+            }
+        },
+        "response.GameConfigResponse": {
+            "type": "object",
+            "properties": {
+                "bet_denominations": {
+                    "description": "Allowed bets; omitted when any bet is allowed",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "currency": {
+                    "description": "Currency of the bets and wins",
+                    "type": "string",
+                    "example": "USD"
+                },
+                "game_id": {
+                    "description": "ID of the game",
+                    "type": "string",
+                    "example": "default"
+                },
+                "max_bet": {
+                    "description": "Largest allowed bet; omitted when any bet is allowed",
+                    "type": "number"
+                },
+                "max_win_per_spin": {
+                    "description": "Win cap of a single spin; omitted when uncapped",
+                    "type": "number"
+                },
+                "min_bet": {
+                    "description": "Smallest allowed bet; omitted when any bet is allowed",
+                    "type": "number"
+                },
+                "pay_both_ways": {
+                    "description": "Whether matches from the last reel pay as well",
+                    "type": "boolean"
+                },
+                "paytable": {
+                    "description": "Line payouts, from the highest to the lowest number of matches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PaytableEntryResponse"
+                    }
+                },
+                "reels": {
+                    "description": "Number of reels",
+                    "type": "integer",
+                    "example": 3
+                },
+                "scatter": {
+                    "description": "Scatter payout; omitted when the game has no scatter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ScatterPayoutResponse"
+                        }
+                    ]
+                },
+                "symbols": {
+                    "description": "Symbols shown on the reels",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.GameSymbolResponse"
+                    }
+                }
+            }
+        },
+        "response.GameSymbolResponse": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "description": "URL or asset key of the icon",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "One of regular, wild or scatter",
+                    "type": "string",
+                    "example": "regular"
+                },
+                "name": {
+                    "description": "Display name",
+                    "type": "string",
+                    "example": "Ace"
+                },
+                "symbol": {
+                    "description": "Symbol as shown in the reels of a spin",
+                    "type": "string",
+                    "example": "A"
+                },
+                "two_match_multiplier": {
+                    "description": "Own two-match multiplier; omitted when the paytable applies",
+                    "type": "number"
+                }
+            }
+        },
+        "response.LeaderboardEntryResponse": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "description": "Anonymized player name",
+                    "type": "string"
+                },
+                "net_profit": {
+                    "description": "Total winnings minus total bets within the period",
+                    "type": "number"
+                },
+                "rank": {
+                    "description": "Position of the player on the leaderboard, starting at 1",
+                    "type": "integer"
+                },
+                "total_win": {
+                    "description": "Sum of all win amounts within the period",
+                    "type": "number"
+                }
+            }
+        },
+        "response.LineWinResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Number of reels showing the matched symbol, wilds included",
+                    "type": "integer"
+                },
+                "line": {
+                    "description": "Zero-based index of the paying line; omitted for a scatter win",
+                    "type": "integer"
+                },
+                "payout": {
+                    "description": "Amount paid for the combination, including the streak multiplier but before the maximum win per spin",
+                    "type": "number"
+                },
+                "reversed": {
+                    "description": "Whether the match was counted from the last reel; only set by games paying both ways",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "description": "The matched symbol",
+                    "type": "string"
+                }
+            }
+        },
+        "response.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "JWT token for the authenticated user",
+                    "type": "string"
+                }
+            }
+        },
+        "response.MaintenanceResponse": {
+            "type": "object",
             "properties": {
-                "amount": {
-                    "description": "Transaction amount, required field",
-                    "type": "number"
+                "enabled": {
+                    "description": "Whether the endpoints are in maintenance mode",
+                    "type": "boolean"
+                },
+                "flag": {
+                    "description": "Whether the maintenance flag is set, which keeps maintenance mode on regardless of the toggle",
+                    "type": "boolean"
+                },
+                "toggle": {
+                    "description": "Whether the admin toggle is on",
+                    "type": "boolean"
                 }
             }
         },
-        "request.LoginRequest": {
+        "response.Page-response_AdminUserResponse": {
             "type": "object",
-            "required": [
-                "login",
-                "password"
-            ],
             "properties": {
-                "login": {
-                    "description": "User's login email, required and must be a valid email format",
-                    "type": "string"
+                "data": {
+                    "description": "Items of the current page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AdminUserResponse"
+                    }
                 },
-                "password": {
-                    "description": "User's password, required and must be at least 8 characters",
-                    "type": "string",
-                    "minLength": 8
+                "limit": {
+                    "description": "Maximum number of items per page",
+                    "type": "integer"
+                },
+                "offset": {
+                    "description": "Number of items skipped before the current page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total number of items across all pages",
+                    "type": "integer"
                 }
             }
         },
-        "request.RegisterRequest": {
+        "response.Page-response_AuthEventResponse": {
             "type": "object",
-            "required": [
-                "login",
-                "password"
-            ],
             "properties": {
-                "login": {
-                    "description": "User's login email, required and must be a valid email format",
-                    "type": "string"
+                "data": {
+                    "description": "Items of the current page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AuthEventResponse"
+                    }
                 },
-                "password": {
-                    "description": "User's password, required and must be at least 8 characters",
-                    "type": "string",
-                    "minLength": 8
+                "limit": {
+                    "description": "Maximum number of items per page",
+                    "type": "integer"
+                },
+                "offset": {
+                    "description": "Number of items skipped before the current page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total number of items across all pages",
+                    "type": "integer"
                 }
             }
         },
-        "request.SpinRequest": {
+        "response.Page-response_LeaderboardEntryResponse": {
             "type": "object",
-            "required": [
-                "bet_amount"
-            ],
             "properties": {
-                "bet_amount": {
-                    "description": "Bet amount, required and must be greater than 0",
-                    "type": "number"
+                "data": {
+                    "description": "Items of the current page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LeaderboardEntryResponse"
+                    }
+                },
+                "limit": {
+                    "description": "Maximum number of items per page",
+                    "type": "integer"
+                },
+                "offset": {
+                    "description": "Number of items skipped before the current page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total number of items across all pages",
+                    "type": "integer"
                 }
             }
         },
-        "request.WithdrawRequest": {
+        "response.Page-response_SessionSummaryResponse": {
             "type": "object",
-            "required": [
-                "amount"
-            ],
             "properties": {
-                "amount": {
-                    "description": "Transaction amount, required field",
+                "data": {
+                    "description": "Items of the current page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SessionSummaryResponse"
+                    }
+                },
+                "limit": {
+                    "description": "Maximum number of items per page",
+                    "type": "integer"
+                },
+                "offset": {
+                    "description": "Number of items skipped before the current page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total number of items across all pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.Page-response_SpinHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Items of the current page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SpinHistoryResponse"
+                    }
+                },
+                "limit": {
+                    "description": "Maximum number of items per page",
+                    "type": "integer"
+                },
+                "offset": {
+                    "description": "Number of items skipped before the current page",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total number of items across all pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PaytableEntryResponse": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "description": "Number of matching symbols on consecutive reels",
+                    "type": "integer",
+                    "example": 3
+                },
+                "multiplier": {
+                    "description": "Multiplier applied to the bet",
+                    "type": "number",
+                    "example": 10
+                },
+                "probability": {
+                    "description": "Probability of the match; omitted when probabilities are hidden",
                     "type": "number"
                 }
             }
         },
-        "response.DepositResponse": {
+        "response.PendingDepositLimitsResponse": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "description": "Updated wallet balance after the deposit transaction",
+                "daily": {
+                    "description": "Largest sum of deposits of a day once the limits apply; null when unlimited",
+                    "type": "number"
+                },
+                "effective_at": {
+                    "description": "Time the pending limits apply from",
+                    "type": "string"
+                },
+                "per_transaction": {
+                    "description": "Largest single deposit once the limits apply; null when unlimited",
                     "type": "number"
                 }
             }
@@ -512,9 +3714,20 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "balance": {
-                    "description": "User's current wallet balance",
+                    "description": "User's current balance in the base currency",
                     "type": "number"
                 },
+                "balances": {
+                    "description": "User's balances per currency, the base currency first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BalanceResponse"
+                    }
+                },
+                "free_spins": {
+                    "description": "Free spins awarded to the user by scatters",
+                    "type": "integer"
+                },
                 "id": {
                     "description": "Unique identifier for the user",
                     "type": "string"
@@ -525,6 +3738,35 @@ const docTemplate = `{
                 }
             }
         },
+        "response.RealityCheckResponse": {
+            "type": "object",
+            "properties": {
+                "net_loss": {
+                    "description": "Total wagered minus total won; negative while the user is ahead",
+                    "type": "number"
+                },
+                "session_duration": {
+                    "description": "Seconds played since the game session started",
+                    "type": "integer"
+                },
+                "session_started_at": {
+                    "description": "Time the game session started",
+                    "type": "string"
+                },
+                "total_spins": {
+                    "description": "Number of spins played in the session",
+                    "type": "integer"
+                },
+                "total_wagered": {
+                    "description": "Sum of all bet amounts of the session",
+                    "type": "number"
+                },
+                "total_won": {
+                    "description": "Sum of all win amounts of the session",
+                    "type": "number"
+                }
+            }
+        },
         "response.RegisterResponse": {
             "type": "object",
             "properties": {
@@ -538,6 +3780,105 @@ const docTemplate = `{
                 }
             }
         },
+        "response.ScatterPayoutResponse": {
+            "type": "object",
+            "properties": {
+                "free_spins": {
+                    "description": "Free spins awarded along with the payout; omitted when none are awarded",
+                    "type": "integer"
+                },
+                "min_count": {
+                    "description": "Number of scatters required for the payout",
+                    "type": "integer",
+                    "example": 3
+                },
+                "multiplier": {
+                    "description": "Multiplier applied to the bet",
+                    "type": "number",
+                    "example": 5
+                },
+                "probability": {
+                    "description": "Probability of a scatter on a reel; omitted when probabilities are hidden",
+                    "type": "number"
+                }
+            }
+        },
+        "response.SelfExclusionResponse": {
+            "type": "object",
+            "properties": {
+                "excluded_until": {
+                    "description": "End of the self-exclusion, until which the user can neither spin nor deposit",
+                    "type": "string"
+                }
+            }
+        },
+        "response.SessionSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "ended_at": {
+                    "description": "Time the session ended; absent while it may still be active",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the session, as recorded with its spins",
+                    "type": "integer"
+                },
+                "last_spin_at": {
+                    "description": "Time of the last spin; absent if none was played",
+                    "type": "string"
+                },
+                "net": {
+                    "description": "Total won minus total wagered",
+                    "type": "number"
+                },
+                "started_at": {
+                    "description": "Time the session started",
+                    "type": "string"
+                },
+                "total_spins": {
+                    "description": "Number of spins played",
+                    "type": "integer"
+                },
+                "total_wagered": {
+                    "description": "Sum of all bet amounts",
+                    "type": "number"
+                },
+                "total_won": {
+                    "description": "Sum of all win amounts",
+                    "type": "number"
+                }
+            }
+        },
+        "response.SettingsResponse": {
+            "type": "object",
+            "properties": {
+                "auto_stop_win": {
+                    "description": "Win above which the client is told to stop spinning; null when the server default applies",
+                    "type": "number"
+                }
+            }
+        },
+        "response.SpinChainResponse": {
+            "type": "object",
+            "properties": {
+                "broken_at": {
+                    "description": "ID of the first spin failing verification; omitted if the chain is intact",
+                    "type": "integer"
+                },
+                "checked": {
+                    "description": "Number of chained spins checked, up to and including the first broken one",
+                    "type": "integer"
+                },
+                "last_hash": {
+                    "description": "Hash of the last spin verified; omitted if no spin was verified",
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Whether every spin checked matched its hash and linked to the previous spin",
+                    "type": "boolean"
+                }
+            }
+        },
         "response.SpinHistoryResponse": {
             "type": "object",
             "properties": {
@@ -558,9 +3899,162 @@ const docTemplate = `{
         "response.SpinResponse": {
             "type": "object",
             "properties": {
+                "balance": {
+                    "description": "The balance of the user after the spin",
+                    "type": "number"
+                },
+                "big_win": {
+                    "description": "Whether the win multiplier exceeded the big win threshold, for clients to celebrate",
+                    "type": "boolean"
+                },
+                "bonuses": {
+                    "description": "The bonus features triggered by the spin, such as \"wild\" or \"scatter\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "currency": {
+                    "description": "The currency the bet was placed in; omitted for a bet in the base currency",
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "description": "The base currency units one unit of the currency was worth at the time of the spin",
+                    "type": "number"
+                },
+                "free_spins_awarded": {
+                    "description": "The free spins awarded by the scatters of the spin, on top of the wins; omitted if none",
+                    "type": "integer"
+                },
+                "game_id": {
+                    "description": "The game the spin was played in",
+                    "type": "string"
+                },
+                "jackpot": {
+                    "description": "The progressive jackpot won on top of the line wins, included in the win amount",
+                    "type": "number"
+                },
+                "original_bet_amount": {
+                    "description": "The bet in the currency it was placed in",
+                    "type": "number"
+                },
+                "original_win_amount": {
+                    "description": "The win converted into the currency of the bet",
+                    "type": "number"
+                },
+                "reality_check": {
+                    "description": "The time and money spent in the game session, for the client to remind the user; omitted until due",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.RealityCheckResponse"
+                        }
+                    ]
+                },
+                "reels": {
+                    "description": "The symbols shown on each reel",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "should_stop": {
+                    "description": "Whether the win exceeded the user's auto-stop threshold and the client should stop spinning",
+                    "type": "boolean"
+                },
+                "streak": {
+                    "description": "Consecutive wins of the user including this spin; omitted after a loss",
+                    "type": "integer"
+                },
                 "win_amount": {
                     "description": "The amount the user won on this spin",
                     "type": "number"
+                },
+                "win_capped": {
+                    "description": "Whether the win was reduced to the maximum win per spin",
+                    "type": "boolean"
+                },
+                "win_multiplier": {
+                    "description": "The ratio of the amount won to the amount bet; omitted for a loss",
+                    "type": "number"
+                },
+                "wins": {
+                    "description": "The combinations that paid; empty for a loss",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.LineWinResponse"
+                    }
+                }
+            }
+        },
+        "response.SpinStatsResponse": {
+            "type": "object",
+            "properties": {
+                "biggest_win": {
+                    "description": "Largest win of a single spin",
+                    "type": "number"
+                },
+                "net": {
+                    "description": "Total won minus total wagered",
+                    "type": "number"
+                },
+                "total_spins": {
+                    "description": "Number of spins played",
+                    "type": "integer"
+                },
+                "total_wagered": {
+                    "description": "Sum of all bet amounts",
+                    "type": "number"
+                },
+                "total_won": {
+                    "description": "Sum of all win amounts",
+                    "type": "number"
+                },
+                "win_rate": {
+                    "description": "Share of spins with a win, between 0 and 1",
+                    "type": "number"
+                }
+            }
+        },
+        "response.TransferResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "The transferred amount",
+                    "type": "number"
+                },
+                "balance": {
+                    "description": "Updated wallet balance of the sender after the transfer",
+                    "type": "number"
+                },
+                "recipient_id": {
+                    "description": "External ID of the user who received the funds",
+                    "type": "string",
+                    "example": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+                }
+            }
+        },
+        "response.VoidedSpinResponse": {
+            "type": "object",
+            "properties": {
+                "bet_amount": {
+                    "description": "The bet amount refunded to the user",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "The numeric ID of the spin",
+                    "type": "integer"
+                },
+                "void_reason": {
+                    "description": "The reason the spin was voided",
+                    "type": "string"
+                },
+                "voided_at": {
+                    "description": "The date and time the spin was voided, formatted as \"YYYY-MM-DD HH:MM:SS\"",
+                    "type": "string"
+                },
+                "win_amount": {
+                    "description": "The win amount clawed back from the user",
+                    "type": "number"
                 }
             }
         },
@@ -570,20 +4064,92 @@ const docTemplate = `{
                 "balance": {
                     "description": "Updated wallet balance after the withdrawal transaction",
                     "type": "number"
+                },
+                "withdrawal": {
+                    "description": "The pending withdrawal, if withdrawals await approval",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.WithdrawalResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "response.WithdrawalResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "The amount held for the withdrawal",
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "The date and time the withdrawal was requested, formatted as \"YYYY-MM-DD HH:MM:SS\"",
+                    "type": "string"
+                },
+                "decided_at": {
+                    "description": "The date and time the withdrawal was approved or rejected, formatted as \"YYYY-MM-DD HH:MM:SS\"",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The numeric ID of the withdrawal",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "The reason the withdrawal was rejected",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "The approval state: pending, approved or rejected",
+                    "type": "string"
+                }
+            }
+        },
+        "server.ErrorResponseMessage": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number",
+                    "example": 20
+                },
+                "code": {
+                    "type": "string",
+                    "example": "INSUFFICIENT_FUNDS"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "shortfall": {
+                    "type": "number",
+                    "example": 30
+                },
+                "trace_id": {
+                    "type": "string",
+                    "example": "3f1c2a9e-8d4b-4a47-9a3e-0d9b1f2c7e11"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "JWT token in the format \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "",
+	Version:          "1.0",
 	Host:             "",
 	BasePath:         "",
 	Schemes:          []string{},
-	Title:            "",
-	Description:      "",
+	Title:            "Slot Game API",
+	Description:      "REST API for the slot game: user management, wallet operations and slot spins.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
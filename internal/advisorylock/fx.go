@@ -0,0 +1,9 @@
+package advisorylock
+
+import "go.uber.org/fx"
+
+// Module provides the advisory Locker as an Fx module for components running
+// periodic tasks that must not run on several instances at once.
+var Module = fx.Options(
+	fx.Provide(NewLocker),
+)
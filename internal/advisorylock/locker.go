@@ -0,0 +1,73 @@
+package advisorylock
+
+import (
+	"context"
+	"hash/fnv"
+
+	"github.com/jinzhu/gorm"
+	log "github.com/public-forge/go-logger"
+)
+
+// Locker runs tasks under Postgres advisory locks so that, across all instances of the
+// service sharing a database, at most one runs a given task at a time.
+//
+// The lock is taken with pg_try_advisory_xact_lock on a dedicated transaction that is
+// held open while the task runs. Ending the transaction releases the lock, so the lock is
+// also released when the task fails or panics, and by Postgres when the instance dies.
+type Locker struct {
+	db *gorm.DB // Database connection pool used to open the lock-holding transactions
+}
+
+// Run executes task if the advisory lock for name can be acquired and releases the lock
+// once the task returns. It does not wait: if another instance holds the lock, the task is skipped.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals; passed to the task.
+//   - name: The name of the task; instances using the same name exclude each other.
+//   - task: The function to run while holding the lock.
+//
+// Returns:
+//   - True if the lock was acquired and the task was run, false if it is held elsewhere.
+//   - An error if the lock could not be queried, or the error returned by the task.
+func (l *Locker) Run(ctx context.Context, name string, task func(ctx context.Context) error) (bool, error) {
+	tx := l.db.BeginTx(ctx, nil)
+	if err := tx.Error; err != nil {
+		return false, err
+	}
+	// Ending the transaction releases the lock; nothing is written on it, so it is always rolled back.
+	defer tx.Rollback()
+
+	var acquired bool
+	if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", Key(name)).Row().Scan(&acquired); err != nil {
+		return false, err
+	}
+	if !acquired {
+		log.FromContext(ctx).Debugf("advisory lock %q is held by another instance, skipping", name)
+		return false, nil
+	}
+	return true, task(ctx)
+}
+
+// Key derives the 64-bit advisory lock key for a task name.
+//
+// Parameters:
+//   - name: The name of the task.
+//
+// Returns:
+//   - The advisory lock key.
+func Key(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// NewLocker creates a Locker on top of the given database connection.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//
+// Returns:
+//   - A pointer to a Locker instance.
+func NewLocker(db *gorm.DB) *Locker {
+	return &Locker{db: db}
+}
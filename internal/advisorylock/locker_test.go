@@ -0,0 +1,144 @@
+package advisorylock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLocks emulates the transaction-scoped advisory locks of Postgres for the fake driver.
+type fakeLocks struct {
+	mu      sync.Mutex
+	holders map[int64]*fakeConn
+}
+
+// tryLock acquires key for conn unless another connection holds it.
+func (l *fakeLocks) tryLock(conn *fakeConn, key int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if holder, ok := l.holders[key]; ok && holder != conn {
+		return false
+	}
+	l.holders[key] = conn
+	return true
+}
+
+// releaseAll releases the locks held by conn, as Postgres does when its transaction ends.
+func (l *fakeLocks) releaseAll(conn *fakeConn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key, holder := range l.holders {
+		if holder == conn {
+			delete(l.holders, key)
+		}
+	}
+}
+
+// fakeDriver is a database/sql driver answering pg_try_advisory_xact_lock queries.
+type fakeDriver struct{ locks *fakeLocks }
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{locks: d.locks}, nil }
+
+type fakeConn struct{ locks *fakeLocks }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return &fakeStmt{conn: c}, nil }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return &fakeTx{conn: c}, nil }
+
+type fakeTx struct{ conn *fakeConn }
+
+func (t *fakeTx) Commit() error   { t.conn.locks.releaseAll(t.conn); return nil }
+func (t *fakeTx) Rollback() error { t.conn.locks.releaseAll(t.conn); return nil }
+
+type fakeStmt struct{ conn *fakeConn }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return 1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{value: s.conn.locks.tryLock(s.conn, args[0].(int64))}, nil
+}
+
+type fakeRows struct {
+	value bool
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"pg_try_advisory_xact_lock"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+var registerOnce sync.Once
+
+// newTestLocker returns a Locker backed by the fake advisory lock driver.
+func newTestLocker(t *testing.T) *Locker {
+	registerOnce.Do(func() {
+		sql.Register("fake-advisory", &fakeDriver{locks: &fakeLocks{holders: map[int64]*fakeConn{}}})
+	})
+	sqlDB, err := sql.Open("fake-advisory", "")
+	assert.NoError(t, err)
+	db, err := gorm.Open("postgres", sqlDB)
+	assert.NoError(t, err)
+	return NewLocker(db)
+}
+
+func TestRun_SecondAcquisitionFailsWhileHeld(t *testing.T) {
+	locker := newTestLocker(t)
+	ctx := context.Background()
+
+	var nestedRan bool
+	ran, err := locker.Run(ctx, "jackpot-seed", func(ctx context.Context) error {
+		// A second instance trying the same lock while it is held must skip the task
+		acquired, err := locker.Run(ctx, "jackpot-seed", func(context.Context) error {
+			nestedRan = true
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, acquired)
+
+		// A different task name is not affected
+		acquired, err = locker.Run(ctx, "limit-reset", func(context.Context) error { return nil })
+		assert.NoError(t, err)
+		assert.True(t, acquired)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	assert.False(t, nestedRan)
+}
+
+func TestRun_ReleasesLockAfterTaskError(t *testing.T) {
+	locker := newTestLocker(t)
+	ctx := context.Background()
+	taskErr := errors.New("seeding failed")
+
+	ran, err := locker.Run(ctx, "jackpot-seed", func(context.Context) error { return taskErr })
+	assert.True(t, ran)
+	assert.ErrorIs(t, err, taskErr)
+
+	ran, err = locker.Run(ctx, "jackpot-seed", func(context.Context) error { return nil })
+	assert.True(t, ran)
+	assert.NoError(t, err)
+}
+
+func TestKey_Stable(t *testing.T) {
+	assert.Equal(t, Key("jackpot-seed"), Key("jackpot-seed"))
+	assert.NotEqual(t, Key("jackpot-seed"), Key("limit-reset"))
+}
@@ -1,24 +1,191 @@
 package config
 
-import "github.com/urfave/cli/v2"
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// defaultNumReels is the number of reels used when none is configured.
+const defaultNumReels = 3
 
 // Constants for flag names used in SlotConfig
 const (
-	multiplierThree       = "multiplier-three"        // Flag for multiplier when three symbols match
-	multiplierTwo         = "multiplier-two"          // Flag for multiplier when two symbols match
-	twoMatchProbability   = "two-match-probability"   // Flag for probability of winning with two matches
-	threeMatchProbability = "three-match-probability" // Flag for probability of winning with three matches
-	rateLIMIT             = "rate-limit"              // Flag for rate limit (requests per second)
+	multiplierThree       = "multiplier-three"           // Flag for multiplier when three symbols match
+	multiplierTwo         = "multiplier-two"             // Flag for multiplier when two symbols match
+	twoMatchMultipliers   = "two-match-multipliers"      // Flag for the two-match multipliers of individual symbols
+	symbolDisplay         = "symbol-display"             // Flag for the display names and icons of individual symbols
+	hideProbabilities     = "hide-probabilities"         // Flag for leaving the probabilities out of the public game configuration
+	twoMatchProbability   = "two-match-probability"      // Flag for probability of winning with two matches
+	threeMatchProbability = "three-match-probability"    // Flag for probability of winning with three matches
+	rateLIMIT             = "rate-limit"                 // Flag for rate limit (requests per second)
+	rateLimitFailOpen     = "rate-limit-fail-open"       // Flag for letting requests through while the rate limit store is unavailable
+	rateLimitPrefix       = "rate-limit-prefix"          // Flag for the prefix of the rate limit keys in Redis
+	trustedAPIKeys        = "trusted-api-keys"           // Flag for the API keys of trusted integrations
+	trustedRateLimit      = "trusted-rate-limit"         // Flag for the rate limit of trusted integrations
+	authRateLimit         = "auth-rate-limit"            // Flag for the rate limit of the login and registration attempts
+	leaderboardSize       = "leaderboard-size"           // Flag for number of entries returned by the leaderboard
+	numReels              = "num-reels"                  // Flag for number of reels
+	reelStrips            = "reel-strips"                // Flag for the ordered symbols of each reel
+	payouts               = "payouts"                    // Flag for additional payout table entries
+	demoEnabled           = "demo-enabled"               // Flag for enabling play-money demo spins
+	demoBalance           = "demo-balance"               // Flag for the play-money balance of a new demo session
+	demoMigration         = "demo-balance-migration"     // Flag for passing the balance of an ended demo session to the migration hook
+	welcomeBalance        = "welcome-balance"            // Flag for the balance credited to newly registered users
+	maxWinPerSpin         = "max-win-per-spin"           // Flag for the maximum payout of a single spin
+	payoutRounding        = "payout-rounding"            // Flag for the rounding policy of the payouts
+	payoutDecimals        = "payout-decimals"            // Flag for the number of decimals payouts are rounded to
+	amountDecimals        = "amount-decimals"            // Flag for the maximum number of decimals of the bet and wallet amounts
+	betDenominations      = "bet-denominations"          // Flag for the bet amounts allowed for a spin
+	spinRevealDelay       = "spin-reveal-delay"          // Flag for the delay between the reel events of a streamed spin
+	baseCurrency          = "base-currency"              // Flag for the currency of the users' main balance
+	wildSymbol            = "wild-symbol"                // Flag for the wild symbol
+	wildProbability       = "wild-probability"           // Flag for the probability of a wild on an eligible reel
+	scatterSymbol         = "scatter-symbol"             // Flag for the scatter symbol
+	scatterProbability    = "scatter-probability"        // Flag for the probability of a scatter on an eligible reel
+	scatterMinCount       = "scatter-min-count"          // Flag for the number of scatters triggering the scatter payout
+	scatterMultiplier     = "scatter-multiplier"         // Flag for the multiplier of the scatter payout
+	scatterFreeSpins      = "scatter-free-spins"         // Flag for the free spins awarded by the scatter payout
+	freeSpinBet           = "free-spin-bet"              // Flag for the bet a free spin is paid for
+	streakMultipliers     = "streak-multipliers"         // Flag for the payout multipliers of consecutive wins
+	withdrawalApproval    = "withdrawal-approval"        // Flag for holding withdrawals until an admin approves them
+	minWithdrawalAge      = "min-withdrawal-account-age" // Flag for the age an account must reach before it may withdraw
+	shortfallDetails      = "insufficient-funds-details" // Flag for reporting the balance and shortfall of refused withdrawals
+	depositLimitCooldown  = "deposit-limit-cooldown"     // Flag for the hours before a raised or removed deposit limit applies
+	autoStopWin           = "auto-stop-win"              // Flag for the default win above which the client is told to stop
+	bigWinMultiplier      = "big-win-multiplier"         // Flag for the win to bet ratio above which a spin is a big win
+	maxSpinsPerDay        = "max-spins-per-day"          // Flag for the maximum number of spins of a user per day
+	dayTimezone           = "day-timezone"               // Flag for the time zone in which the daily windows are counted
+	spinLimitTimezone     = "spin-limit-timezone"        // Flag for the time zone of the daily spin limit
+	spinLimitResetHour    = "spin-limit-reset-hour"      // Flag for the hour at which the daily spin limit resets
+	maxBulkSpins          = "max-bulk-spins"             // Flag for the maximum number of spins of a bulk spin request
+	realityCheckInterval  = "reality-check-interval"     // Flag for the minutes of play between two reality checks
+	preSpinBalanceCheck   = "pre-spin-balance-check"     // Flag for rejecting underfunded spins before they are played
+	forcedOutcomes        = "qa-forced-outcomes"         // Flag for letting QA force the outcome of spins; never for production
+	jackpotProbability    = "jackpot-probability"        // Flag for the probability of a spin hitting the jackpot
+	jackpotSeed           = "jackpot-seed"               // Flag for the amount the jackpot pays on top of the contributions
+	jackpotContribution   = "jackpot-contribution"       // Flag for the share of each bet added to the jackpot
+	spinLogSampleRate     = "spin-log-sample-rate"       // Flag for logging the result of 1 in N spins
+	spinHashChain         = "spin-hash-chain"            // Flag for chaining the spin records of each user with hashes
+	gamesFile             = "games-file"                 // Flag for the file defining further slot games
+	payBothWays           = "pay-both-ways"              // Flag for paying line matches from the last reel as well as from the first
+	fullLinePaysTwice     = "full-line-pays-twice"       // Flag for paying a full line in both directions when wins pay both ways
+	symbols               = "symbols"                    // Key of the regular symbols of a game in the games file
 )
 
 // SlotConfig defines configuration parameters for the slot game,
 // including multipliers and probabilities for different winning scenarios.
 type SlotConfig struct {
-	MultiplierThree       float64 // Multiplier applied when three symbols match
-	MultiplierTwo         float64 // Multiplier applied when two symbols match
-	TwoMatchProbability   float64 // Probability for winning with two matching symbols
-	ThreeMatchProbability float64 // Probability for winning with three matching symbols
-	RateLimit             string  // Rate limit for requests per second
+	MultiplierThree       float64               // Multiplier applied when three symbols match
+	MultiplierTwo         float64               // Multiplier applied when two symbols match
+	TwoMatchMultipliers   map[string]float64    // Two-match multipliers of individual symbols, including the wild; other symbols pay MultiplierTwo
+	SymbolDisplays        SymbolDisplays        // Display names and icons of individual symbols; other symbols are shown by their bare name
+	HideProbabilities     bool                  // Leave the probabilities out of the game configuration published to clients
+	TwoMatchProbability   float64               // Probability for winning with two matching symbols
+	ThreeMatchProbability float64               // Probability for winning with three matching symbols
+	RateLimit             string                // Rate limit for requests per second
+	RateLimitFailOpen     bool                  // Let requests through instead of rejecting them while Redis is unavailable
+	RateLimitPrefix       string                // Prefix of the rate limit keys in Redis, separating environments sharing a Redis
+	TrustedAPIKeys        []string              // API keys of trusted integrations, which are not limited like other clients
+	TrustedRateLimit      string                // Rate limit per trusted API key; empty exempts trusted integrations from rate limiting
+	AuthRateLimit         string                // Rate limit of the login and registration attempts per client IP and per login; empty disables it
+	LeaderboardSize       int                   // Number of entries returned by the leaderboard
+	NumReels              int                   // Number of reels; values below 2 fall back to 3
+	ReelStrips            [][]string            // Ordered symbols of each reel, stopped at random positions; empty draws the reels from the paytable probabilities
+	AdditionalPayouts     []PayoutEntry         // Payouts for further match counts, such as 4 or 5 of a kind
+	DemoEnabled           bool                  // Allow play-money demo spins that are never persisted
+	DemoBalance           float64               // Play-money balance a demo session starts with
+	DemoMigration         bool                  // Pass the balance of an ended demo session to the migration hook; off, it is always discarded
+	WelcomeBalance        float64               // Balance credited to newly registered users; 0 disables the credit
+	MaxWinPerSpin         float64               // Maximum payout of a single spin; 0 disables the cap
+	PayoutRounding        string                // Rounding policy of the payouts, one of the PayoutRounding constants; empty keeps them unrounded
+	PayoutDecimals        int                   // Number of decimals payouts are rounded to, at most the 2 decimals balances are stored with
+	AmountDecimals        int                   // Maximum number of decimals of the bets and wallet amounts sent by clients, at most the 2 decimals balances are stored with
+	BetDenominations      []float64             // Bet amounts allowed for a spin; empty allows any positive bet
+	SpinRevealDelay       int                   // Delay in milliseconds before each reel event of a streamed spin
+	BaseCurrency          string                // ISO 4217 code of the currency of the users' main balance
+	WildSymbol            string                // Symbol substituting for any other symbol in a line match; empty disables wilds
+	WildProbability       float64               // Probability of a wild landing on a reel inside a winning run
+	ScatterSymbol         string                // Symbol paying anywhere on the reels; empty disables scatters
+	ScatterProbability    float64               // Probability of a scatter landing on a reel outside the winning run
+	ScatterMinCount       int                   // Number of scatters required for the scatter payout
+	ScatterMultiplier     float64               // Multiplier of the scatter payout, added to any line win
+	ScatterFreeSpins      int                   // Free spins awarded along with the scatter payout, on top of any line win; 0 awards none
+	FreeSpinBet           float64               // Bet a free spin is paid for; it is not debited from the balance
+	PayBothWays           bool                  // Pay line matches counted from the last reel as well as from the first one
+	FullLinePaysTwice     bool                  // Pay a match across all reels in both directions when paying both ways
+	StreakMultipliers     []float64             // Payout multipliers of the 1st, 2nd, ... consecutive win; empty disables streaks
+	WithdrawalApproval    bool                  // Hold withdrawn funds until an admin approves or rejects the withdrawal
+	MinWithdrawalAge      int                   // Hours an account must exist before it may withdraw; 0 allows withdrawals right away
+	ShortfallDetails      bool                  // Report the balance and the missing amount when a withdrawal exceeds the balance
+	DepositLimitCooldown  int                   // Hours before a raised or removed deposit limit applies; lowered limits apply right away
+	AutoStopWin           float64               // Default win above which the client is told to stop spinning; 0 disables the auto-stop
+	BigWinMultiplier      float64               // Win to bet ratio above which a spin is flagged as a big win; 0 disables the flag
+	MaxSpinsPerDay        int                   // Maximum number of spins of a user per day; 0 disables the limit
+	DayTimezone           string                // IANA time zone in which the daily windows, such as the daily deposit limit, are counted
+	DayLocation           *time.Location        // Time zone loaded from DayTimezone at startup; nil counts in UTC
+	SpinLimitTimezone     string                // IANA time zone in which the days of the spin limit are counted; empty uses DayTimezone
+	SpinLimitResetHour    int                   // Hour of the day, between 0 and 23, at which the spin limit resets
+	MaxBulkSpins          int                   // Maximum number of spins a bulk spin request may ask for
+	RealityCheckInterval  int                   // Minutes of play after which the spins carry a reality check until it is acknowledged; 0 disables it
+	PreSpinBalanceCheck   bool                  // Reject spins whose bet exceeds the balance read at the start, before playing them
+	ForcedOutcomes        bool                  // Let spin requests force the reels or the win instead of drawing them; test environments only
+	JackpotProbability    float64               // Probability of a spin hitting the progressive jackpot; 0 disables the jackpot
+	JackpotSeed           float64               // Amount the jackpot pays on top of the contributions collected since the last hit
+	JackpotContribution   float64               // Share of each bet, between 0 and 1, added to the jackpot
+	SpinLogSampleRate     int                   // Log the result of 1 in N spins; big wins, capped wins and jackpots are always logged
+	SpinHashChain         bool                  // Chain each spin record of a user to the previous one with a hash, making tampering evident
+	Symbols               []string              // Regular symbols shown on the reels; empty uses Symbols
+	GamesFile             string                // Path of the JSON file defining further games; empty defines none
+	Games                 map[string]SlotConfig // Further games keyed by game ID, each with its own symbols, paytable and probabilities
+}
+
+// JackpotEnabled reports whether spins may hit the progressive jackpot.
+func (c *SlotConfig) JackpotEnabled() bool {
+	return c.JackpotProbability > 0
+}
+
+// RealityCheckEnabled reports whether the spins carry reality checks.
+func (c *SlotConfig) RealityCheckEnabled() bool {
+	return c.RealityCheckInterval > 0
+}
+
+// SpinLimitEnabled reports whether the number of spins per day is limited.
+func (c *SlotConfig) SpinLimitEnabled() bool {
+	return c.MaxSpinsPerDay > 0
+}
+
+// DayStart returns the start of the day containing t: midnight in the time zone of the daily
+// windows.
+//
+// Parameters:
+//   - t: The time within the day.
+//
+// Returns:
+//
+//	The start of the day, in the time zone of the daily windows.
+func (c *SlotConfig) DayStart(t time.Time) time.Time {
+	location := c.DayLocation
+	if location == nil {
+		location = time.UTC
+	}
+	year, month, day := t.In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, location)
+}
+
+// SpinLimitDay returns the day of the spin limit a spin made at t counts towards, formatted as
+// YYYY-MM-DD. Days start at the reset hour in the given time zone, so with a reset hour of 6 a
+// spin at 05:59 still counts towards the previous day.
+//
+// Parameters:
+//   - t: The time of the spin.
+//   - location: The time zone of the spin limit, as loaded from SpinLimitTimezone.
+//
+// Returns:
+//
+//	The day the spin counts towards.
+func (c *SlotConfig) SpinLimitDay(t time.Time, location *time.Location) string {
+	return t.In(location).Add(-time.Duration(c.SpinLimitResetHour) * time.Hour).Format(time.DateOnly)
 }
 
 // GetSlotConfig returns a SlotConfig instance populated from CLI context flags.
@@ -30,15 +197,93 @@ type SlotConfig struct {
 //
 // Returns:
 //
-//	A pointer to a SlotConfig struct with values obtained from the CLI flags.
-func GetSlotConfig(c *cli.Context) *SlotConfig {
-	return &SlotConfig{
+//	A pointer to a SlotConfig struct with values obtained from the CLI flags,
+//	or an error if the payout table entries are malformed or a setting is out of range.
+func GetSlotConfig(c *cli.Context) (*SlotConfig, error) {
+	additionalPayouts, err := parsePayouts(c.StringSlice(payouts))
+	if err != nil {
+		return nil, err
+	}
+	twoMatch, err := parseSymbolMultipliers(c.StringSlice(twoMatchMultipliers))
+	if err != nil {
+		return nil, err
+	}
+	displays, err := parseSymbolDisplays(c.StringSlice(symbolDisplay))
+	if err != nil {
+		return nil, err
+	}
+	cfg := &SlotConfig{
 		MultiplierThree:       c.Float64(multiplierThree),
 		MultiplierTwo:         c.Float64(multiplierTwo),
+		TwoMatchMultipliers:   twoMatch,
+		SymbolDisplays:        displays,
+		HideProbabilities:     c.Bool(hideProbabilities),
 		TwoMatchProbability:   c.Float64(twoMatchProbability),
 		ThreeMatchProbability: c.Float64(threeMatchProbability),
 		RateLimit:             c.String(rateLIMIT),
+		RateLimitPrefix:       c.String(rateLimitPrefix),
+		TrustedAPIKeys:        c.StringSlice(trustedAPIKeys),
+		TrustedRateLimit:      c.String(trustedRateLimit),
+		AuthRateLimit:         c.String(authRateLimit),
+		LeaderboardSize:       c.Int(leaderboardSize),
+		NumReels:              c.Int(numReels),
+		ReelStrips:            parseReelStrips(c.StringSlice(reelStrips)),
+		AdditionalPayouts:     additionalPayouts,
+		DemoEnabled:           c.Bool(demoEnabled),
+		DemoBalance:           c.Float64(demoBalance),
+		DemoMigration:         c.Bool(demoMigration),
+		WelcomeBalance:        c.Float64(welcomeBalance),
+		MaxWinPerSpin:         c.Float64(maxWinPerSpin),
+		PayoutRounding:        c.String(payoutRounding),
+		PayoutDecimals:        c.Int(payoutDecimals),
+		AmountDecimals:        c.Int(amountDecimals),
+		BetDenominations:      c.Float64Slice(betDenominations),
+		SpinRevealDelay:       c.Int(spinRevealDelay),
+		BaseCurrency:          c.String(baseCurrency),
+		WildSymbol:            c.String(wildSymbol),
+		WildProbability:       c.Float64(wildProbability),
+		ScatterSymbol:         c.String(scatterSymbol),
+		ScatterProbability:    c.Float64(scatterProbability),
+		ScatterMinCount:       c.Int(scatterMinCount),
+		ScatterMultiplier:     c.Float64(scatterMultiplier),
+		ScatterFreeSpins:      c.Int(scatterFreeSpins),
+		FreeSpinBet:           c.Float64(freeSpinBet),
+		PayBothWays:           c.Bool(payBothWays),
+		FullLinePaysTwice:     c.Bool(fullLinePaysTwice),
+		StreakMultipliers:     c.Float64Slice(streakMultipliers),
+		WithdrawalApproval:    c.Bool(withdrawalApproval),
+		MinWithdrawalAge:      c.Int(minWithdrawalAge),
+		ShortfallDetails:      c.Bool(shortfallDetails),
+		DepositLimitCooldown:  c.Int(depositLimitCooldown),
+		AutoStopWin:           c.Float64(autoStopWin),
+		BigWinMultiplier:      c.Float64(bigWinMultiplier),
+		MaxSpinsPerDay:        c.Int(maxSpinsPerDay),
+		DayTimezone:           c.String(dayTimezone),
+		SpinLimitTimezone:     c.String(spinLimitTimezone),
+		SpinLimitResetHour:    c.Int(spinLimitResetHour),
+		MaxBulkSpins:          c.Int(maxBulkSpins),
+		RealityCheckInterval:  c.Int(realityCheckInterval),
+		PreSpinBalanceCheck:   c.Bool(preSpinBalanceCheck),
+		ForcedOutcomes:        c.Bool(forcedOutcomes),
+		JackpotProbability:    c.Float64(jackpotProbability),
+		JackpotSeed:           c.Float64(jackpotSeed),
+		JackpotContribution:   c.Float64(jackpotContribution),
+		SpinLogSampleRate:     c.Int(spinLogSampleRate),
+		SpinHashChain:         c.Bool(spinHashChain),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
+	// Both time zones have been validated
+	cfg.DayLocation, _ = time.LoadLocation(cfg.DayTimezone)
+	if cfg.SpinLimitTimezone == "" {
+		cfg.SpinLimitTimezone = cfg.DayTimezone
+	}
+	cfg.GamesFile = c.String(gamesFile)
+	if cfg.Games, err = loadGames(cfg.GamesFile, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
 // SlotFlags defines the command-line flags for configuring the slot game,
@@ -58,6 +303,22 @@ var SlotFlags = []cli.Flag{
 		Usage:   "Multiplier for two matching symbols",
 		EnvVars: []string{"MULTIPLIER_TWO"}, // Environment variable for multiplier on two matches
 	},
+	&cli.StringSliceFlag{
+		Name:    twoMatchMultipliers,
+		Usage:   "Two-match multipliers of individual symbols as symbol:multiplier, e.g. \"W:5,A:3\"; other symbols pay --multiplier-two",
+		EnvVars: []string{"TWO_MATCH_MULTIPLIERS"}, // Environment variable for the per-symbol two-match multipliers
+	},
+	&cli.StringSliceFlag{
+		Name:    symbolDisplay,
+		Usage:   "Display names and icons of individual symbols as symbol:name:icon, e.g. \"A:Ace:https://cdn.example.com/ace.png\"; the icon is optional",
+		EnvVars: []string{"SYMBOL_DISPLAY"}, // Environment variable for the symbol display metadata
+	},
+	&cli.BoolFlag{
+		Name:    hideProbabilities,
+		Value:   true,
+		Usage:   "Leave the probabilities out of the game configuration served by /api/slot/config",
+		EnvVars: []string{"HIDE_PROBABILITIES"}, // Environment variable for hiding the probabilities from clients
+	},
 	&cli.Float64Flag{
 		Name:    twoMatchProbability,
 		Value:   0.30,
@@ -76,4 +337,300 @@ var SlotFlags = []cli.Flag{
 		Usage:   "Rate limit for requests per second( 5 reqs/second: \"5-S\", 10 reqs/minute: \"10-M\", 100 reqs/hour: \"100-H\")",
 		EnvVars: []string{"RATE_LIMIT"}, // Environment variable for rate limit
 	},
+	&cli.BoolFlag{
+		Name:    rateLimitFailOpen,
+		Value:   true,
+		Usage:   "Let requests through without rate limiting while Redis is unavailable; when disabled they are rejected with 503",
+		EnvVars: []string{"RATE_LIMIT_FAIL_OPEN"}, // Environment variable for the rate limit failure mode
+	},
+	&cli.StringFlag{
+		Name:    rateLimitPrefix,
+		Value:   "limiter",
+		Usage:   "Prefix of the rate limit keys in Redis, e.g. \"limiter:staging\"; environments sharing a Redis need different prefixes",
+		EnvVars: []string{"RATE_LIMIT_PREFIX"}, // Environment variable for the rate limit key prefix
+	},
+	&cli.StringSliceFlag{
+		Name:    trustedAPIKeys,
+		Usage:   "API keys of trusted integrations; requests presenting one in the X-API-Key header are not limited by the client rate limit",
+		EnvVars: []string{"TRUSTED_API_KEYS"}, // Environment variable for the trusted API keys
+	},
+	&cli.StringFlag{
+		Name:    trustedRateLimit,
+		Usage:   "Rate limit per trusted API key, in the format of --rate-limit; empty exempts trusted integrations from rate limiting",
+		EnvVars: []string{"TRUSTED_RATE_LIMIT"}, // Environment variable for the rate limit of trusted integrations
+	},
+	&cli.StringFlag{
+		Name:    authRateLimit,
+		Value:   "5-M",
+		Usage:   "Rate limit of the login and registration attempts per client IP and per login, in the format of --rate-limit; empty disables it",
+		EnvVars: []string{"AUTH_RATE_LIMIT"}, // Environment variable for the rate limit of the authentication endpoints
+	},
+	&cli.IntFlag{
+		Name:    leaderboardSize,
+		Value:   10,
+		Usage:   "Number of entries returned by the leaderboard",
+		EnvVars: []string{"LEADERBOARD_SIZE"}, // Environment variable for leaderboard size
+	},
+	&cli.IntFlag{
+		Name:    numReels,
+		Value:   defaultNumReels,
+		Usage:   "Number of reels; wins are evaluated for matching symbols on consecutive reels from the first one",
+		EnvVars: []string{"NUM_REELS"}, // Environment variable for number of reels
+	},
+	&cli.StringSliceFlag{
+		Name:    reelStrips,
+		Usage:   "Reel strips, one per reel, each listing the symbols of the reel in order separated by spaces, e.g. \"A B A C W D,A A B S C D,A B C C D B\"; empty draws the reels from the paytable probabilities",
+		EnvVars: []string{"REEL_STRIPS"}, // Environment variable for the reel strips
+	},
+	&cli.StringSliceFlag{
+		Name:    payouts,
+		Usage:   "Additional payouts as matches:multiplier:probability, e.g. \"4:25:0.01,5:100:0.002\"",
+		EnvVars: []string{"PAYOUTS"}, // Environment variable for additional payouts
+	},
+	&cli.BoolFlag{
+		Name:    demoEnabled,
+		Value:   false,
+		Usage:   "Allow play-money demo spins requested with the X-Demo-Mode header",
+		EnvVars: []string{"DEMO_ENABLED"}, // Environment variable for enabling demo mode
+	},
+	&cli.Float64Flag{
+		Name:    demoBalance,
+		Value:   1000,
+		Usage:   "Play-money balance a demo session starts with",
+		EnvVars: []string{"DEMO_BALANCE"}, // Environment variable for the demo balance
+	},
+	&cli.BoolFlag{
+		Name:    demoMigration,
+		Value:   false,
+		Usage:   "Pass the play-money balance of an ended demo session to the migration hook instead of discarding it",
+		EnvVars: []string{"DEMO_BALANCE_MIGRATION"}, // Environment variable for the demo balance migration
+	},
+	&cli.Float64Flag{
+		Name:    welcomeBalance,
+		Value:   0,
+		Usage:   "Balance credited to newly registered users; 0 disables the credit",
+		EnvVars: []string{"WELCOME_BALANCE"}, // Environment variable for the welcome balance
+	},
+	&cli.Float64Flag{
+		Name:    maxWinPerSpin,
+		Value:   0,
+		Usage:   "Maximum payout of a single spin; larger wins are capped to it. 0 disables the cap",
+		EnvVars: []string{"MAX_WIN_PER_SPIN"}, // Environment variable for the win cap
+	},
+	&cli.StringFlag{
+		Name:    payoutRounding,
+		Value:   PayoutRoundingRound,
+		Usage:   "Rounding of the payouts before they are credited: \"round\" half away from zero, \"floor\" down in favor of the house, \"ceil\" up in favor of the player or \"none\"",
+		EnvVars: []string{"PAYOUT_ROUNDING"}, // Environment variable for the payout rounding policy
+	},
+	&cli.IntFlag{
+		Name:    payoutDecimals,
+		Value:   2,
+		Usage:   "Number of decimals payouts are rounded to, between 0 and 2; 2 rounds to minor units such as cents",
+		EnvVars: []string{"PAYOUT_DECIMALS"}, // Environment variable for the payout decimals
+	},
+	&cli.IntFlag{
+		Name:    amountDecimals,
+		Value:   2,
+		Usage:   "Maximum number of decimals of the bets, deposits, withdrawals and transfers, between 0 and 2; more precise amounts are rejected",
+		EnvVars: []string{"AMOUNT_DECIMALS"}, // Environment variable for the amount decimals
+	},
+	&cli.Float64SliceFlag{
+		Name:    betDenominations,
+		Usage:   "Bet amounts allowed for a spin, e.g. \"1,2,5,10\"; empty allows any positive bet",
+		EnvVars: []string{"BET_DENOMINATIONS"}, // Environment variable for the allowed bet amounts
+	},
+	&cli.IntFlag{
+		Name:    spinRevealDelay,
+		Value:   500,
+		Usage:   "Delay in milliseconds before each reel symbol is revealed by a streamed spin; 0 reveals them at once",
+		EnvVars: []string{"SPIN_REVEAL_DELAY"}, // Environment variable for the reel reveal delay
+	},
+	&cli.StringFlag{
+		Name:    baseCurrency,
+		Value:   "USD",
+		Usage:   "ISO 4217 code of the currency of the users' main balance; other currencies are held in wallets",
+		EnvVars: []string{"BASE_CURRENCY"}, // Environment variable for the base currency
+	},
+	&cli.StringFlag{
+		Name:    wildSymbol,
+		Usage:   "Wild symbol substituting for any other symbol in a line match, e.g. \"W\"; empty disables wilds",
+		EnvVars: []string{"WILD_SYMBOL"}, // Environment variable for the wild symbol
+	},
+	&cli.Float64Flag{
+		Name:    wildProbability,
+		Value:   0.1,
+		Usage:   "Probability of a wild landing on a reel inside a winning run",
+		EnvVars: []string{"WILD_PROBABILITY"}, // Environment variable for the wild probability
+	},
+	&cli.StringFlag{
+		Name:    scatterSymbol,
+		Usage:   "Scatter symbol paying regardless of its position, e.g. \"S\"; empty disables scatters",
+		EnvVars: []string{"SCATTER_SYMBOL"}, // Environment variable for the scatter symbol
+	},
+	&cli.Float64Flag{
+		Name:    scatterProbability,
+		Value:   0.05,
+		Usage:   "Probability of a scatter landing on a reel outside the winning run",
+		EnvVars: []string{"SCATTER_PROBABILITY"}, // Environment variable for the scatter probability
+	},
+	&cli.IntFlag{
+		Name:    scatterMinCount,
+		Value:   2,
+		Usage:   "Number of scatters anywhere on the reels required for the scatter payout",
+		EnvVars: []string{"SCATTER_MIN_COUNT"}, // Environment variable for the scatter count
+	},
+	&cli.Float64Flag{
+		Name:    scatterMultiplier,
+		Value:   5,
+		Usage:   "Multiplier of the scatter payout, added to any line win",
+		EnvVars: []string{"SCATTER_MULTIPLIER"}, // Environment variable for the scatter multiplier
+	},
+	&cli.IntFlag{
+		Name:    scatterFreeSpins,
+		Value:   0,
+		Usage:   "Number of free spins awarded when the scatter payout is triggered, on top of any line win; 0 awards none",
+		EnvVars: []string{"SCATTER_FREE_SPINS"}, // Environment variable for the scatter free spins
+	},
+	&cli.Float64Flag{
+		Name:    freeSpinBet,
+		Value:   1,
+		Usage:   "Bet a free spin is paid for; the bet is not debited from the balance",
+		EnvVars: []string{"FREE_SPIN_BET"}, // Environment variable for the free spin bet
+	},
+	&cli.BoolFlag{
+		Name:    payBothWays,
+		Value:   false,
+		Usage:   "Pay line matches counted from the last reel as well as from the first one; both wins add up",
+		EnvVars: []string{"PAY_BOTH_WAYS"}, // Environment variable for paying wins in both directions
+	},
+	&cli.BoolFlag{
+		Name:    fullLinePaysTwice,
+		Value:   false,
+		Usage:   "Pay a match across all reels in both directions when paying both ways; otherwise it pays once",
+		EnvVars: []string{"FULL_LINE_PAYS_TWICE"}, // Environment variable for paying full lines twice
+	},
+	&cli.Float64SliceFlag{
+		Name:    streakMultipliers,
+		Usage:   "Payout multipliers of the 1st, 2nd, ... consecutive win, e.g. \"1,1.1,1.25,1.5\"; the last one applies to longer streaks. Empty disables streaks",
+		EnvVars: []string{"STREAK_MULTIPLIERS"}, // Environment variable for the streak multiplier curve
+	},
+	&cli.BoolFlag{
+		Name:    withdrawalApproval,
+		Value:   false,
+		Usage:   "Hold withdrawn funds as pending until an admin approves or rejects the withdrawal",
+		EnvVars: []string{"WITHDRAWAL_APPROVAL"}, // Environment variable for the withdrawal approval toggle
+	},
+	&cli.IntFlag{
+		Name:    minWithdrawalAge,
+		Value:   0,
+		Usage:   "Hours an account must exist before it may withdraw, e.g. 72; 0 allows withdrawals right away",
+		EnvVars: []string{"MIN_WITHDRAWAL_ACCOUNT_AGE"}, // Environment variable for the minimum account age of withdrawals
+	},
+	&cli.BoolFlag{
+		Name:    shortfallDetails,
+		Value:   true,
+		Usage:   "Include the balance and the shortfall in the error body of withdrawals exceeding the balance",
+		EnvVars: []string{"INSUFFICIENT_FUNDS_DETAILS"}, // Environment variable for reporting the shortfall
+	},
+	&cli.IntFlag{
+		Name:    depositLimitCooldown,
+		Value:   24,
+		Usage:   "Hours before a raised or removed deposit limit applies; lowered limits apply right away, 0 applies all changes right away",
+		EnvVars: []string{"DEPOSIT_LIMIT_COOLDOWN"}, // Environment variable for the deposit limit cooldown
+	},
+	&cli.Float64Flag{
+		Name:    autoStopWin,
+		Value:   0,
+		Usage:   "Default win above which the client is told to stop spinning; users may set their own threshold. 0 disables the auto-stop",
+		EnvVars: []string{"AUTO_STOP_WIN"}, // Environment variable for the default auto-stop threshold
+	},
+	&cli.Float64Flag{
+		Name:    bigWinMultiplier,
+		Value:   0,
+		Usage:   "Win to bet ratio above which a spin is flagged as a big win, so that clients can celebrate it; 0 disables the flag",
+		EnvVars: []string{"BIG_WIN_MULTIPLIER"}, // Environment variable for the big win threshold
+	},
+	&cli.IntFlag{
+		Name:    maxSpinsPerDay,
+		Value:   0,
+		Usage:   "Maximum number of spins a user may make per day; further spins are rejected until the limit resets. 0 disables the limit",
+		EnvVars: []string{"MAX_SPINS_PER_DAY"}, // Environment variable for the daily spin limit
+	},
+	&cli.StringFlag{
+		Name:    dayTimezone,
+		Value:   "UTC",
+		Usage:   "IANA time zone, such as \"Europe/Berlin\", in which the daily windows, such as the daily deposit limit, start at midnight",
+		EnvVars: []string{"DAY_TIMEZONE"}, // Environment variable for the time zone of the daily windows
+	},
+	&cli.StringFlag{
+		Name:    spinLimitTimezone,
+		Usage:   "IANA time zone, such as \"Europe/Berlin\", in which the days of the spin limit are counted; empty uses the day time zone",
+		EnvVars: []string{"SPIN_LIMIT_TIMEZONE"}, // Environment variable for the time zone of the spin limit
+	},
+	&cli.IntFlag{
+		Name:    spinLimitResetHour,
+		Value:   0,
+		Usage:   "Hour of the day, between 0 and 23, at which the daily spin limit resets",
+		EnvVars: []string{"SPIN_LIMIT_RESET_HOUR"}, // Environment variable for the reset hour of the spin limit
+	},
+	&cli.IntFlag{
+		Name:    maxBulkSpins,
+		Value:   10,
+		Usage:   "Maximum number of spins a single bulk spin request may ask for",
+		EnvVars: []string{"MAX_BULK_SPINS"}, // Environment variable for the maximum bulk spin count
+	},
+	&cli.IntFlag{
+		Name:    realityCheckInterval,
+		Value:   0,
+		Usage:   "Minutes of play in a game session after which the spins carry a reality check of the time and money spent, until the user acknowledges it; 0 disables reality checks",
+		EnvVars: []string{"REALITY_CHECK_INTERVAL"}, // Environment variable for the reality check interval
+	},
+	&cli.BoolFlag{
+		Name:    preSpinBalanceCheck,
+		Value:   true,
+		Usage:   "Reject a spin whose bet exceeds the balance before playing it; the balance update still checks the funds",
+		EnvVars: []string{"PRE_SPIN_BALANCE_CHECK"}, // Environment variable for the pre-spin balance check
+	},
+	&cli.BoolFlag{
+		Name:    forcedOutcomes,
+		Value:   false,
+		Usage:   "Let spin requests force the reels or the win with the X-Force-Reels and X-Force-Win headers, bypassing the RNG; for QA environments only, never enable in production",
+		EnvVars: []string{"QA_FORCED_OUTCOMES"}, // Environment variable for forcing spin outcomes in QA
+	},
+	&cli.Float64Flag{
+		Name:    jackpotProbability,
+		Value:   0,
+		Usage:   "Probability of a spin hitting the progressive jackpot shared by all games and instances; 0 disables the jackpot",
+		EnvVars: []string{"JACKPOT_PROBABILITY"}, // Environment variable for the jackpot probability
+	},
+	&cli.Float64Flag{
+		Name:    jackpotSeed,
+		Value:   1000,
+		Usage:   "Amount the jackpot pays on top of the contributions collected since the last hit",
+		EnvVars: []string{"JACKPOT_SEED"}, // Environment variable for the jackpot seed
+	},
+	&cli.Float64Flag{
+		Name:    jackpotContribution,
+		Value:   0.01,
+		Usage:   "Share of each bet, between 0 and 1, added to the jackpot, e.g. 0.01 for 1%",
+		EnvVars: []string{"JACKPOT_CONTRIBUTION"}, // Environment variable for the jackpot contribution
+	},
+	&cli.IntFlag{
+		Name:    spinLogSampleRate,
+		Value:   1,
+		Usage:   "Log the result of 1 in N spins to reduce the log volume under load; big wins, capped wins, jackpots and errors are always logged",
+		EnvVars: []string{"SPIN_LOG_SAMPLE_RATE"}, // Environment variable for the spin log sample rate
+	},
+	&cli.BoolFlag{
+		Name:    spinHashChain,
+		Value:   false,
+		Usage:   "Chain each spin record of a user to the previous one with a SHA-256 hash; spins are then written within their transaction",
+		EnvVars: []string{"SPIN_HASH_CHAIN"}, // Environment variable for the spin hash chain
+	},
+	&cli.StringFlag{
+		Name:    gamesFile,
+		Usage:   "Path of a JSON file defining further slot games by game id, each with its own symbols, paytable and probabilities",
+		EnvVars: []string{"GAMES_FILE"}, // Environment variable for the games file
+	},
 }
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SymbolDisplay describes how front-ends present a symbol of the reels.
+type SymbolDisplay struct {
+	Name string // Display name of the symbol, e.g. "Cherry"
+	Icon string // URL or asset key of the symbol's icon; empty leaves the icon to the client
+}
+
+// SymbolDisplays holds the display metadata of individual symbols keyed by symbol.
+type SymbolDisplays map[string]SymbolDisplay
+
+// Display returns the display metadata of the symbol. Symbols without configured metadata are
+// shown by their bare name.
+func (c *SlotConfig) Display(symbol string) SymbolDisplay {
+	if display, ok := c.SymbolDisplays[symbol]; ok {
+		return display
+	}
+	return SymbolDisplay{Name: symbol}
+}
+
+// parseSymbolDisplays parses the display metadata of individual symbols in the "symbol:name:icon"
+// format, for example "A:Ace:https://cdn.example.com/ace.png". The icon is optional and may contain
+// colons itself.
+func parseSymbolDisplays(values []string) (SymbolDisplays, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	displays := make(SymbolDisplays, len(values))
+	for _, value := range values {
+		parts := strings.SplitN(strings.TrimSpace(value), ":", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid symbol display %q: expected symbol:name or symbol:name:icon", value)
+		}
+		if _, duplicate := displays[parts[0]]; duplicate {
+			return nil, fmt.Errorf("invalid symbol display %q: symbol %q is given twice", value, parts[0])
+		}
+		display := SymbolDisplay{Name: parts[1]}
+		if len(parts) == 3 {
+			display.Icon = parts[2]
+		}
+		displays[parts[0]] = display
+	}
+	return displays, nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSymbolDisplays(t *testing.T) {
+	displays, err := parseSymbolDisplays([]string{"A:Ace:https://cdn.example.com/ace.png", " W:Wild"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, SymbolDisplays{
+		"A": {Name: "Ace", Icon: "https://cdn.example.com/ace.png"},
+		"W": {Name: "Wild"},
+	}, displays)
+
+	for _, value := range []string{"A", ":Ace", "A:", "A:Ace,A:Ace"} {
+		_, err := parseSymbolDisplays(strings.Split(value, ","))
+		assert.Error(t, err, value)
+	}
+}
+
+func TestDisplay_FallsBackToSymbol(t *testing.T) {
+	c := &SlotConfig{SymbolDisplays: SymbolDisplays{"A": {Name: "Ace"}}}
+
+	assert.Equal(t, SymbolDisplay{Name: "Ace"}, c.Display("A"))
+	assert.Equal(t, SymbolDisplay{Name: "B"}, c.Display("B"))
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// DefaultGameID identifies the game configured by the slot flags. Spins naming no game play it.
+const DefaultGameID = "default"
+
+// gameID matches the identifier of a game in the games file, such as "fruits".
+var gameID = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// GameDefinition describes a slot game of the games file: its symbols, paytable and probabilities.
+// The keys match the names of the slot flags configuring the default game. Settings not tied to a
+// game, such as the bet denominations, the win cap or the streak multipliers, are shared by all games.
+type GameDefinition struct {
+	Symbols               []string   `json:"symbols"`                 // Regular symbols shown on the reels; empty uses the default symbols
+	NumReels              int        `json:"num-reels"`               // Number of reels; values below 2 fall back to 3
+	ReelStrips            [][]string `json:"reel-strips"`             // Ordered symbols of each reel; empty draws the reels from the paytable probabilities
+	MultiplierTwo         float64    `json:"multiplier-two"`          // Multiplier applied when two symbols match
+	TwoMatchMultipliers   []string   `json:"two-match-multipliers"`   // Two-match multipliers of individual symbols in the "symbol:multiplier" format
+	SymbolDisplay         []string   `json:"symbol-display"`          // Display names and icons of individual symbols in the "symbol:name:icon" format
+	MultiplierThree       float64    `json:"multiplier-three"`        // Multiplier applied when three symbols match
+	TwoMatchProbability   float64    `json:"two-match-probability"`   // Probability for winning with two matching symbols
+	ThreeMatchProbability float64    `json:"three-match-probability"` // Probability for winning with three matching symbols
+	Payouts               []string   `json:"payouts"`                 // Further payouts in the "matches:multiplier:probability" format
+	WildSymbol            string     `json:"wild-symbol"`             // Wild symbol; empty disables wilds
+	WildProbability       float64    `json:"wild-probability"`        // Probability of a wild on an eligible reel
+	ScatterSymbol         string     `json:"scatter-symbol"`          // Scatter symbol; empty disables scatters
+	ScatterProbability    float64    `json:"scatter-probability"`     // Probability of a scatter on an eligible reel
+	ScatterMinCount       int        `json:"scatter-min-count"`       // Number of scatters triggering the scatter payout
+	ScatterMultiplier     float64    `json:"scatter-multiplier"`      // Multiplier of the scatter payout
+	ScatterFreeSpins      int        `json:"scatter-free-spins"`      // Free spins awarded by the scatter payout
+	PayBothWays           bool       `json:"pay-both-ways"`           // Pay line matches from the last reel as well
+	FullLinePaysTwice     bool       `json:"full-line-pays-twice"`    // Pay a full line in both directions
+}
+
+// Game returns the configuration of the game with the given ID. An empty ID and DefaultGameID
+// name the game configured by the slot flags.
+//
+// Parameters:
+//   - id: The ID of the game.
+//
+// Returns:
+//   - The configuration of the game.
+//   - Whether the game exists.
+func (c *SlotConfig) Game(id string) (*SlotConfig, bool) {
+	if id == "" || id == DefaultGameID {
+		return c, true
+	}
+	game, ok := c.Games[id]
+	if !ok {
+		return nil, false
+	}
+	return &game, true
+}
+
+// ReelSymbols returns the regular symbols of the game, defaulting to Symbols.
+func (c *SlotConfig) ReelSymbols() []string {
+	if len(c.Symbols) == 0 {
+		return Symbols
+	}
+	return c.Symbols
+}
+
+// loadGames reads the games file, a JSON object of game definitions keyed by game ID, and builds
+// the configuration of each game on top of the shared settings of base. An empty path defines no
+// further games.
+//
+// Parameters:
+//   - path: The path of the games file.
+//   - base: The configuration of the default game, providing the shared settings.
+//
+// Returns:
+//
+//	The games keyed by ID, or an error if the file cannot be read or a game is invalid.
+func loadGames(path string, base *SlotConfig) (map[string]SlotConfig, error) {
+	if path == "" {
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", gamesFile, err)
+	}
+	defer file.Close()
+	var definitions map[string]GameDefinition
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&definitions); err != nil {
+		return nil, fmt.Errorf("%s: invalid games file %s: %w", gamesFile, path, err)
+	}
+
+	games := make(map[string]SlotConfig, len(definitions))
+	var errs []error
+	for id, definition := range definitions {
+		if id == DefaultGameID || !gameID.MatchString(id) {
+			errs = append(errs, fmt.Errorf("%s: invalid game id %q", gamesFile, id))
+			continue
+		}
+		game, err := definition.apply(base)
+		if err == nil {
+			err = game.Validate()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("game %q: %w", id, err))
+			continue
+		}
+		games[id] = *game
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+// apply returns a copy of base playing the game of the definition.
+func (d GameDefinition) apply(base *SlotConfig) (*SlotConfig, error) {
+	additionalPayouts, err := parsePayouts(d.Payouts)
+	if err != nil {
+		return nil, err
+	}
+	twoMatch, err := parseSymbolMultipliers(d.TwoMatchMultipliers)
+	if err != nil {
+		return nil, err
+	}
+	displays, err := parseSymbolDisplays(d.SymbolDisplay)
+	if err != nil {
+		return nil, err
+	}
+	game := *base
+	game.Games = nil
+	game.GamesFile = ""
+	game.Symbols = d.Symbols
+	game.NumReels = d.NumReels
+	game.ReelStrips = d.ReelStrips
+	game.MultiplierTwo = d.MultiplierTwo
+	game.TwoMatchMultipliers = twoMatch
+	game.SymbolDisplays = displays
+	game.MultiplierThree = d.MultiplierThree
+	game.TwoMatchProbability = d.TwoMatchProbability
+	game.ThreeMatchProbability = d.ThreeMatchProbability
+	game.AdditionalPayouts = additionalPayouts
+	game.WildSymbol = d.WildSymbol
+	game.WildProbability = d.WildProbability
+	game.ScatterSymbol = d.ScatterSymbol
+	game.ScatterProbability = d.ScatterProbability
+	game.ScatterMinCount = d.ScatterMinCount
+	game.ScatterMultiplier = d.ScatterMultiplier
+	game.ScatterFreeSpins = d.ScatterFreeSpins
+	game.PayBothWays = d.PayBothWays
+	game.FullLinePaysTwice = d.FullLinePaysTwice
+	return &game, nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeGamesFile writes a games file into a temporary directory and returns its path.
+func writeGamesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "games.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadGames(t *testing.T) {
+	base := validSlotConfig()
+	base.MaxWinPerSpin = 500
+	path := writeGamesFile(t, `{
+		"fruits": {
+			"symbols": ["CHERRY", "LEMON", "PLUM"],
+			"num-reels": 5,
+			"multiplier-two": 3,
+			"two-match-multipliers": ["CHERRY:6"],
+			"symbol-display": ["CHERRY:Cherry:cherry.png"],
+			"multiplier-three": 20,
+			"two-match-probability": 0.2,
+			"three-match-probability": 0.1,
+			"payouts": ["5:100:0.001"]
+		},
+		"gems": {
+			"multiplier-two": 1.5, "multiplier-three": 8, "wild-symbol": "W", "wild-probability": 0.5,
+			"reel-strips": [["A", "W", "B"], ["A", "C"], ["D", "A", "A"]]
+		}
+	}`)
+
+	games, err := loadGames(path, base)
+
+	require.NoError(t, err)
+	require.Len(t, games, 2)
+	fruits := games["fruits"]
+	assert.Equal(t, []string{"CHERRY", "LEMON", "PLUM"}, fruits.ReelSymbols())
+	assert.Equal(t, 5, fruits.Reels())
+	assert.Equal(t, []PayoutEntry{{Matches: 5, Multiplier: 100, Probability: 0.001}}, fruits.AdditionalPayouts)
+	assert.Equal(t, map[string]float64{"CHERRY": 6}, fruits.TwoMatchMultipliers)
+	assert.Equal(t, SymbolDisplay{Name: "Cherry", Icon: "cherry.png"}, fruits.Display("CHERRY"))
+	assert.Equal(t, 500.0, fruits.MaxWinPerSpin, "settings not tied to a game are shared")
+	gems := games["gems"]
+	assert.Equal(t, Symbols, gems.ReelSymbols())
+	assert.Equal(t, "W", gems.WildSymbol)
+	assert.Equal(t, [][]string{{"A", "W", "B"}, {"A", "C"}, {"D", "A", "A"}}, gems.ReelStrips)
+	assert.Empty(t, fruits.ReelStrips)
+}
+
+func TestLoadGames_NoFile(t *testing.T) {
+	games, err := loadGames("", validSlotConfig())
+
+	assert.NoError(t, err)
+	assert.Empty(t, games)
+}
+
+func TestLoadGames_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"UnknownKey", `{"fruits": {"multiplier": 2}}`, "unknown field"},
+		{"DefaultID", `{"default": {"multiplier-two": 2, "multiplier-three": 10}}`, `invalid game id "default"`},
+		{"InvalidID", `{"Fruits!": {"multiplier-two": 2, "multiplier-three": 10}}`, `invalid game id "Fruits!"`},
+		{"InvalidSettings", `{"fruits": {"multiplier-three": 10}}`, `game "fruits": invalid slot configuration: multiplier-two must be positive, got 0`},
+		{"InvalidPayout", `{"fruits": {"multiplier-two": 2, "multiplier-three": 10, "payouts": ["4:25"]}}`, `game "fruits": invalid payout "4:25"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := loadGames(writeGamesFile(t, tc.content), validSlotConfig())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestGame(t *testing.T) {
+	c := validSlotConfig()
+	c.Games = map[string]SlotConfig{"fruits": {MultiplierThree: 20}}
+
+	game, ok := c.Game("")
+	assert.True(t, ok)
+	assert.Same(t, c, game)
+	game, ok = c.Game(DefaultGameID)
+	assert.True(t, ok)
+	assert.Same(t, c, game)
+	game, ok = c.Game("fruits")
+	require.True(t, ok)
+	assert.Equal(t, 20.0, game.MultiplierThree)
+	_, ok = c.Game("gems")
+	assert.False(t, ok)
+}
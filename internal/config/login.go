@@ -0,0 +1,37 @@
+package config
+
+import "github.com/urfave/cli/v2"
+
+// Constants for flag names used in LoginPolicy
+const (
+	loginAllowUsernames = "login-allow-usernames" // Flag for accepting usernames as well as email addresses as logins
+)
+
+// LoginPolicy defines which logins users may register and sign in with.
+type LoginPolicy struct {
+	AllowUsernames bool // Accept usernames as logins; by default a login must be an email address
+}
+
+// GetLoginPolicy returns a LoginPolicy instance populated from CLI context flags.
+//
+// Parameters:
+//   - c: The CLI context from which to retrieve flag values.
+//
+// Returns:
+//
+//	A pointer to a LoginPolicy struct with values obtained from the CLI flags.
+func GetLoginPolicy(c *cli.Context) *LoginPolicy {
+	return &LoginPolicy{
+		AllowUsernames: c.Bool(loginAllowUsernames),
+	}
+}
+
+// LoginFlags defines the command-line flags for configuring the login policy.
+var LoginFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:    loginAllowUsernames,
+		Value:   false,
+		Usage:   "Accept usernames (3-32 letters, digits, dots, underscores or hyphens) as well as email addresses as logins",
+		EnvVars: []string{"LOGIN_ALLOW_USERNAMES"},
+	},
+}
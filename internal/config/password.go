@@ -0,0 +1,65 @@
+package config
+
+import "github.com/urfave/cli/v2"
+
+// Constants for flag names used in PasswordPolicy
+const (
+	passwordBlockCommon      = "password-block-common"       // Flag for rejecting commonly used passwords
+	passwordBlacklist        = "password-blacklist"          // Flag for additional disallowed passwords
+	passwordRequireMixedCase = "password-require-mixed-case" // Flag for requiring upper and lower case letters
+	passwordRequireDigit     = "password-require-digit"      // Flag for requiring at least one digit
+)
+
+// PasswordPolicy defines the rules a password must satisfy at registration,
+// on top of the minimum length enforced by the request validation.
+type PasswordPolicy struct {
+	BlockCommon      bool     // Reject passwords from the built-in list of common weak passwords
+	Blacklist        []string // Additional passwords that are not allowed
+	RequireMixedCase bool     // Require both upper and lower case letters
+	RequireDigit     bool     // Require at least one digit
+}
+
+// GetPasswordPolicy returns a PasswordPolicy instance populated from CLI context flags.
+//
+// Parameters:
+//   - c: The CLI context from which to retrieve flag values.
+//
+// Returns:
+//
+//	A pointer to a PasswordPolicy struct with values obtained from the CLI flags.
+func GetPasswordPolicy(c *cli.Context) *PasswordPolicy {
+	return &PasswordPolicy{
+		BlockCommon:      c.Bool(passwordBlockCommon),
+		Blacklist:        c.StringSlice(passwordBlacklist),
+		RequireMixedCase: c.Bool(passwordRequireMixedCase),
+		RequireDigit:     c.Bool(passwordRequireDigit),
+	}
+}
+
+// PasswordFlags defines the command-line flags for configuring the password policy
+// applied at registration.
+var PasswordFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:    passwordBlockCommon,
+		Value:   true,
+		Usage:   "Reject commonly used weak passwords at registration",
+		EnvVars: []string{"PASSWORD_BLOCK_COMMON"},
+	},
+	&cli.StringSliceFlag{
+		Name:    passwordBlacklist,
+		Usage:   "Additional passwords that are not allowed at registration (comma separated)",
+		EnvVars: []string{"PASSWORD_BLACKLIST"},
+	},
+	&cli.BoolFlag{
+		Name:    passwordRequireMixedCase,
+		Value:   false,
+		Usage:   "Require passwords to contain both upper and lower case letters",
+		EnvVars: []string{"PASSWORD_REQUIRE_MIXED_CASE"},
+	},
+	&cli.BoolFlag{
+		Name:    passwordRequireDigit,
+		Value:   false,
+		Usage:   "Require passwords to contain at least one digit",
+		EnvVars: []string{"PASSWORD_REQUIRE_DIGIT"},
+	},
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Symbols are the regular symbols shown on the reels. Wild and scatter symbols come on top of them.
+var Symbols = []string{"A", "B", "C", "D"}
+
+// Rounding policies of the payouts.
+const (
+	PayoutRoundingNone  = "none"  // Payouts are credited as computed
+	PayoutRoundingRound = "round" // Payouts are rounded half away from zero
+	PayoutRoundingFloor = "floor" // Payouts are rounded down, never paying more than computed
+	PayoutRoundingCeil  = "ceil"  // Payouts are rounded up, never paying less than computed
+)
+
+// maxPayoutDecimals is the number of decimals the balances and spin amounts are stored with.
+const maxPayoutDecimals = 2
+
+// PayoutEntry defines the payout for a number of matching symbols on consecutive reels,
+// counted from the first reel.
+type PayoutEntry struct {
+	Matches     int     // Number of matching symbols required
+	Multiplier  float64 // Multiplier applied to the bet amount
+	Probability float64 // Probability of landing this number of matches
+}
+
+// Paytable returns the payout entries for the configured number of reels, ordered from the
+// highest to the lowest number of matches. The two- and three-match entries are derived from
+// the dedicated multiplier and probability settings; additional payouts may add or override entries.
+// Entries requiring more matches than there are reels are omitted.
+func (c *SlotConfig) Paytable() []PayoutEntry {
+	byMatches := map[int]PayoutEntry{
+		2: {Matches: 2, Multiplier: c.MultiplierTwo, Probability: c.TwoMatchProbability},
+		3: {Matches: 3, Multiplier: c.MultiplierThree, Probability: c.ThreeMatchProbability},
+	}
+	for _, e := range c.AdditionalPayouts {
+		byMatches[e.Matches] = e
+	}
+
+	reels := c.Reels()
+	table := make([]PayoutEntry, 0, len(byMatches))
+	for _, e := range byMatches {
+		if e.Matches <= reels {
+			table = append(table, e)
+		}
+	}
+	sort.Slice(table, func(i, j int) bool {
+		return table[i].Matches > table[j].Matches
+	})
+	return table
+}
+
+// LineMultiplier returns the multiplier of a line win of the paytable entry showing the given
+// symbol. Two-match wins pay the symbol's own two-match multiplier when one is configured, so that,
+// for example, two wilds may pay more than two low symbols; all other wins pay the entry's multiplier.
+func (c *SlotConfig) LineMultiplier(entry PayoutEntry, symbol string) float64 {
+	if multiplier, ok := c.TwoMatchMultipliers[symbol]; ok && entry.Matches == 2 {
+		return multiplier
+	}
+	return entry.Multiplier
+}
+
+// Reels returns the configured number of reels, defaulting to three.
+func (c *SlotConfig) Reels() int {
+	if c.NumReels < 2 {
+		return defaultNumReels
+	}
+	return c.NumReels
+}
+
+// StreaksEnabled reports whether consecutive wins earn streak multipliers.
+func (c *SlotConfig) StreaksEnabled() bool {
+	return len(c.StreakMultipliers) > 0
+}
+
+// StreakMultiplier returns the payout multiplier of the given win in a row, counted from 1.
+// Streaks longer than the curve keep its last multiplier; without a streak the multiplier is 1.
+func (c *SlotConfig) StreakMultiplier(streak int) float64 {
+	if streak <= 0 || !c.StreaksEnabled() {
+		return 1
+	}
+	return c.StreakMultipliers[min(streak, len(c.StreakMultipliers))-1]
+}
+
+// RoundPayout rounds a payout to PayoutDecimals decimals according to PayoutRounding, so that the
+// credited amount is the one the balances store. Without a rounding policy the payout is kept as is.
+func (c *SlotConfig) RoundPayout(payout float64) float64 {
+	scale := math.Pow10(c.PayoutDecimals)
+	switch c.PayoutRounding {
+	case PayoutRoundingRound:
+		return math.Round(payout*scale) / scale
+	case PayoutRoundingFloor:
+		// The tolerance keeps amounts such as 0.29, stored as 0.28999..., from losing a minor unit
+		return math.Floor(payout*scale+1e-9) / scale
+	case PayoutRoundingCeil:
+		// The tolerance keeps amounts such as 0.07, scaled to 7.00...01, from gaining a minor unit
+		return math.Ceil(payout*scale-1e-9) / scale
+	default:
+		return payout
+	}
+}
+
+// parsePayouts parses payout entries in the "matches:multiplier:probability" format,
+// for example "4:25:0.01".
+func parsePayouts(values []string) ([]PayoutEntry, error) {
+	entries := make([]PayoutEntry, 0, len(values))
+	for _, value := range values {
+		parts := strings.Split(strings.TrimSpace(value), ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid payout %q: expected matches:multiplier:probability", value)
+		}
+		matches, err := strconv.Atoi(parts[0])
+		if err != nil || matches < 2 {
+			return nil, fmt.Errorf("invalid payout %q: matches must be an integer of at least 2", value)
+		}
+		multiplier, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || multiplier <= 0 {
+			return nil, fmt.Errorf("invalid payout %q: multiplier must be a positive number", value)
+		}
+		probability, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil || probability < 0 || probability > 1 {
+			return nil, fmt.Errorf("invalid payout %q: probability must be between 0 and 1", value)
+		}
+		entries = append(entries, PayoutEntry{Matches: matches, Multiplier: multiplier, Probability: probability})
+	}
+	return entries, nil
+}
+
+// parseReelStrips parses reel strips listing the symbols of each reel in order, separated by
+// whitespace, for example "A B A C W D".
+func parseReelStrips(values []string) [][]string {
+	if len(values) == 0 {
+		return nil
+	}
+	strips := make([][]string, 0, len(values))
+	for _, value := range values {
+		strips = append(strips, strings.Fields(value))
+	}
+	return strips
+}
+
+// parseSymbolMultipliers parses multipliers of individual symbols in the "symbol:multiplier"
+// format, for example "W:5".
+func parseSymbolMultipliers(values []string) (map[string]float64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	multipliers := make(map[string]float64, len(values))
+	for _, value := range values {
+		symbol, multiplierValue, ok := strings.Cut(strings.TrimSpace(value), ":")
+		if !ok || symbol == "" {
+			return nil, fmt.Errorf("invalid symbol multiplier %q: expected symbol:multiplier", value)
+		}
+		multiplier, err := strconv.ParseFloat(multiplierValue, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid symbol multiplier %q: multiplier must be a number", value)
+		}
+		if _, duplicate := multipliers[symbol]; duplicate {
+			return nil, fmt.Errorf("invalid symbol multiplier %q: symbol %q is given twice", value, symbol)
+		}
+		multipliers[symbol] = multiplier
+	}
+	return multipliers, nil
+}
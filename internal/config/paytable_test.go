@@ -0,0 +1,115 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePayouts(t *testing.T) {
+	entries, err := parsePayouts([]string{"4:25:0.01", " 5:100:0.002"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []PayoutEntry{
+		{Matches: 4, Multiplier: 25, Probability: 0.01},
+		{Matches: 5, Multiplier: 100, Probability: 0.002},
+	}, entries)
+}
+
+func TestParsePayouts_Invalid(t *testing.T) {
+	for _, value := range []string{"4:25", "1:2:0.5", "4:x:0.1", "4:0:0.1", "4:25:1.5"} {
+		_, err := parsePayouts([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseSymbolMultipliers(t *testing.T) {
+	multipliers, err := parseSymbolMultipliers([]string{"W:5", " A:3.5"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"W": 5, "A": 3.5}, multipliers)
+
+	for _, value := range []string{"W", ":5", "W:x", "W:5,W:6"} {
+		_, err := parseSymbolMultipliers(strings.Split(value, ","))
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseReelStrips(t *testing.T) {
+	assert.Nil(t, parseReelStrips(nil))
+	assert.Equal(t, [][]string{{"A", "B", "W"}, {"C"}, {}}, parseReelStrips([]string{" A  B W", "C", ""}))
+}
+
+func TestLineMultiplier_FallsBackToPaytable(t *testing.T) {
+	c := &SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, TwoMatchMultipliers: map[string]float64{"W": 5}}
+	two := PayoutEntry{Matches: 2, Multiplier: 2}
+	three := PayoutEntry{Matches: 3, Multiplier: 10}
+
+	assert.Equal(t, 5.0, c.LineMultiplier(two, "W"))
+	assert.Equal(t, 2.0, c.LineMultiplier(two, "A"))
+	assert.Equal(t, 10.0, c.LineMultiplier(three, "W"), "only two-match wins pay the symbol multipliers")
+}
+
+func TestPaytable_FiltersAndOrdersByMatches(t *testing.T) {
+	c := &SlotConfig{
+		NumReels:        4,
+		MultiplierTwo:   2,
+		MultiplierThree: 10,
+		AdditionalPayouts: []PayoutEntry{
+			{Matches: 5, Multiplier: 100},
+			{Matches: 4, Multiplier: 25},
+		},
+	}
+
+	table := c.Paytable()
+
+	// The five-match entry does not fit on four reels
+	assert.Equal(t, []int{4, 3, 2}, []int{table[0].Matches, table[1].Matches, table[2].Matches})
+	assert.Len(t, table, 3)
+}
+
+func TestReels_DefaultsToThree(t *testing.T) {
+	assert.Equal(t, 3, (&SlotConfig{}).Reels())
+	assert.Equal(t, 5, (&SlotConfig{NumReels: 5}).Reels())
+}
+
+func TestStreakMultiplier(t *testing.T) {
+	c := &SlotConfig{StreakMultipliers: []float64{1, 1.5, 2}}
+
+	assert.Equal(t, 1.0, c.StreakMultiplier(0))
+	assert.Equal(t, 1.0, c.StreakMultiplier(1))
+	assert.Equal(t, 1.5, c.StreakMultiplier(2))
+	assert.Equal(t, 2.0, c.StreakMultiplier(3))
+	assert.Equal(t, 2.0, c.StreakMultiplier(10))
+	assert.Equal(t, 1.0, (&SlotConfig{}).StreakMultiplier(5))
+}
+
+func TestRoundPayout(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rounding string
+		decimals int
+		payout   float64
+		expected float64
+	}{
+		{"RoundToMinorUnits", PayoutRoundingRound, 2, 3.3333, 3.33},
+		{"RoundHalfUp", PayoutRoundingRound, 2, 0.125, 0.13},
+		{"FloorToMinorUnits", PayoutRoundingFloor, 2, 6.6667, 6.66},
+		{"FloorKeepsExactAmount", PayoutRoundingFloor, 2, 0.29, 0.29},
+		{"RoundToWholeUnits", PayoutRoundingRound, 0, 12.5, 13},
+		{"FloorToWholeUnits", PayoutRoundingFloor, 0, 12.99, 12},
+		{"CeilToMinorUnits", PayoutRoundingCeil, 2, 3.3333, 3.34},
+		{"CeilKeepsExactAmount", PayoutRoundingCeil, 2, 0.07, 0.07},
+		{"CeilToWholeUnits", PayoutRoundingCeil, 0, 12.01, 13},
+		{"None", PayoutRoundingNone, 2, 3.3333, 3.3333},
+		{"Unset", "", 2, 3.3333, 3.3333},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &SlotConfig{PayoutRounding: tc.rounding, PayoutDecimals: tc.decimals}
+			assert.Equal(t, tc.expected, c.RoundPayout(tc.payout))
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// currencyCode matches an ISO 4217 currency code, such as "USD".
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Validate checks that the slot settings describe a sensible game: every probability must be
+// within [0, 1], every multiplier, streak multiplier and bet denomination must be positive and the
+// welcome balance, win cap, auto-stop threshold, big win multiplier, minimum withdrawal account age, daily spin limit and reveal delay must not be negative. The base currency must be an ISO 4217 code.
+// Payouts must be rounded by a known policy to at most the 2 decimals balances are stored with.
+// The spin limit must reset at an hour between 0 and 23 of a known time zone, and bulk spin requests
+// must be allowed at least one spin, as must the spin log sample rate. The jackpot contribution is a share of the bet within [0, 1]
+// and the jackpot seed must not be negative.
+// Enabled wild and scatter symbols must differ from each other and have sensible settings; free
+// spins awarded by the scatter must be paid for a positive bet.
+// Two-match multipliers may only be given for the regular symbols and the wild symbol. A game's
+// own symbols must be at least two distinct, non-empty symbols without commas. Reel strips must be
+// given for every reel and may only show the regular, wild and scatter symbols, as may the symbol
+// display metadata.
+//
+// Returns:
+//
+//	An error listing every invalid setting, or nil if the configuration is valid.
+func (c *SlotConfig) Validate() error {
+	var errs []error
+	checkProbability := func(name string, value float64) {
+		if value < 0 || value > 1 {
+			errs = append(errs, fmt.Errorf("%s must be between 0 and 1, got %v", name, value))
+		}
+	}
+	checkMultiplier := func(name string, value float64) {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %v", name, value))
+		}
+	}
+
+	checkProbability(twoMatchProbability, c.TwoMatchProbability)
+	checkProbability(threeMatchProbability, c.ThreeMatchProbability)
+	checkMultiplier(multiplierTwo, c.MultiplierTwo)
+	checkMultiplier(multiplierThree, c.MultiplierThree)
+	for symbol, multiplier := range c.TwoMatchMultipliers {
+		checkMultiplier(fmt.Sprintf("%s multiplier for %q", twoMatchMultipliers, symbol), multiplier)
+		if symbol != c.WildSymbol && !slices.Contains(c.ReelSymbols(), symbol) {
+			errs = append(errs, fmt.Errorf("%s must name regular symbols or the wild symbol, got %q", twoMatchMultipliers, symbol))
+		}
+	}
+	for _, e := range c.AdditionalPayouts {
+		checkProbability(fmt.Sprintf("%s probability for %d matches", payouts, e.Matches), e.Probability)
+		checkMultiplier(fmt.Sprintf("%s multiplier for %d matches", payouts, e.Matches), e.Multiplier)
+	}
+	if c.WildSymbol != "" {
+		checkProbability(wildProbability, c.WildProbability)
+	}
+	if c.ScatterSymbol != "" {
+		checkProbability(scatterProbability, c.ScatterProbability)
+		checkMultiplier(scatterMultiplier, c.ScatterMultiplier)
+		if c.ScatterMinCount < 1 {
+			errs = append(errs, fmt.Errorf("%s must be at least 1, got %d", scatterMinCount, c.ScatterMinCount))
+		}
+		if c.ScatterFreeSpins < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %d", scatterFreeSpins, c.ScatterFreeSpins))
+		}
+		if c.ScatterFreeSpins > 0 {
+			checkMultiplier(freeSpinBet, c.FreeSpinBet)
+		}
+	}
+	if len(c.Symbols) > 0 {
+		distinct := slices.Clone(c.Symbols)
+		slices.Sort(distinct)
+		distinct = slices.Compact(distinct)
+		if len(distinct) < 2 || len(distinct) != len(c.Symbols) || slices.ContainsFunc(c.Symbols, invalidSymbol) {
+			errs = append(errs, fmt.Errorf("%s must be at least 2 distinct, non-empty symbols without commas, got %q", symbols, c.Symbols))
+		}
+	}
+	for _, special := range []struct{ name, symbol string }{{wildSymbol, c.WildSymbol}, {scatterSymbol, c.ScatterSymbol}} {
+		if slices.Contains(c.ReelSymbols(), special.symbol) {
+			errs = append(errs, fmt.Errorf("%s must not be a regular symbol, got %q", special.name, special.symbol))
+		}
+	}
+	if len(c.ReelStrips) > 0 && len(c.ReelStrips) != c.Reels() {
+		errs = append(errs, fmt.Errorf("%s must give a strip for each of the %d reels, got %d", reelStrips, c.Reels(), len(c.ReelStrips)))
+	}
+	stripSymbol := func(symbol string) bool {
+		return slices.Contains(c.ReelSymbols(), symbol) || symbol != "" && (symbol == c.WildSymbol || symbol == c.ScatterSymbol)
+	}
+	for i, strip := range c.ReelStrips {
+		if len(strip) == 0 {
+			errs = append(errs, fmt.Errorf("%s strip of reel %d must not be empty", reelStrips, i+1))
+		}
+		for _, symbol := range strip {
+			if !stripSymbol(symbol) {
+				errs = append(errs, fmt.Errorf("%s strip of reel %d must show regular, wild or scatter symbols, got %q", reelStrips, i+1, symbol))
+			}
+		}
+	}
+	for symbol := range c.SymbolDisplays {
+		if !stripSymbol(symbol) {
+			errs = append(errs, fmt.Errorf("%s must name regular, wild or scatter symbols, got %q", symbolDisplay, symbol))
+		}
+	}
+	if c.WildSymbol != "" && c.WildSymbol == c.ScatterSymbol {
+		errs = append(errs, fmt.Errorf("%s and %s must differ, got %q", wildSymbol, scatterSymbol, c.WildSymbol))
+	}
+	if c.WelcomeBalance < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %v", welcomeBalance, c.WelcomeBalance))
+	}
+	if c.MaxWinPerSpin < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %v", maxWinPerSpin, c.MaxWinPerSpin))
+	}
+	if c.AutoStopWin < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %v", autoStopWin, c.AutoStopWin))
+	}
+	if !slices.Contains([]string{"", PayoutRoundingNone, PayoutRoundingRound, PayoutRoundingFloor, PayoutRoundingCeil}, c.PayoutRounding) {
+		errs = append(errs, fmt.Errorf("%s must be one of %s, %s, %s or %s, got %q",
+			payoutRounding, PayoutRoundingRound, PayoutRoundingFloor, PayoutRoundingCeil, PayoutRoundingNone, c.PayoutRounding))
+	}
+	if c.PayoutDecimals < 0 || c.PayoutDecimals > maxPayoutDecimals {
+		errs = append(errs, fmt.Errorf("%s must be between 0 and %d, got %d", payoutDecimals, maxPayoutDecimals, c.PayoutDecimals))
+	}
+	if c.AmountDecimals < 0 || c.AmountDecimals > maxPayoutDecimals {
+		errs = append(errs, fmt.Errorf("%s must be between 0 and %d, got %d", amountDecimals, maxPayoutDecimals, c.AmountDecimals))
+	}
+	if c.MinWithdrawalAge < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", minWithdrawalAge, c.MinWithdrawalAge))
+	}
+	if c.DepositLimitCooldown < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", depositLimitCooldown, c.DepositLimitCooldown))
+	}
+	if c.BigWinMultiplier < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %v", bigWinMultiplier, c.BigWinMultiplier))
+	}
+	if c.MaxSpinsPerDay < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", maxSpinsPerDay, c.MaxSpinsPerDay))
+	}
+	if c.RealityCheckInterval < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", realityCheckInterval, c.RealityCheckInterval))
+	}
+	if c.SpinLimitResetHour < 0 || c.SpinLimitResetHour > 23 {
+		errs = append(errs, fmt.Errorf("%s must be between 0 and 23, got %d", spinLimitResetHour, c.SpinLimitResetHour))
+	}
+	if _, err := time.LoadLocation(c.DayTimezone); err != nil {
+		errs = append(errs, fmt.Errorf("%s must be a known time zone, got %q", dayTimezone, c.DayTimezone))
+	}
+	if _, err := time.LoadLocation(c.SpinLimitTimezone); err != nil {
+		errs = append(errs, fmt.Errorf("%s must be a known time zone, got %q", spinLimitTimezone, c.SpinLimitTimezone))
+	}
+	checkProbability(jackpotProbability, c.JackpotProbability)
+	checkProbability(jackpotContribution, c.JackpotContribution)
+	if c.JackpotSeed < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %v", jackpotSeed, c.JackpotSeed))
+	}
+	if c.MaxBulkSpins < 1 {
+		errs = append(errs, fmt.Errorf("%s must be at least 1, got %d", maxBulkSpins, c.MaxBulkSpins))
+	}
+	if c.SpinLogSampleRate < 1 {
+		errs = append(errs, fmt.Errorf("%s must be at least 1, got %d", spinLogSampleRate, c.SpinLogSampleRate))
+	}
+	if c.SpinRevealDelay < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", spinRevealDelay, c.SpinRevealDelay))
+	}
+	if !currencyCode.MatchString(c.BaseCurrency) {
+		errs = append(errs, fmt.Errorf("%s must be a three-letter ISO 4217 code, got %q", baseCurrency, c.BaseCurrency))
+	}
+	for _, denomination := range c.BetDenominations {
+		if denomination <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %v", betDenominations, denomination))
+		}
+	}
+	for _, multiplier := range c.StreakMultipliers {
+		checkMultiplier(streakMultipliers, multiplier)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid slot configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// invalidSymbol reports whether a symbol is empty or contains a comma, which separates the
+// symbols of the stored reels.
+func invalidSymbol(symbol string) bool {
+	return symbol == "" || strings.Contains(symbol, ",")
+}
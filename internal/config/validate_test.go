@@ -0,0 +1,108 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// validSlotConfig returns a configuration matching the flag defaults.
+func validSlotConfig() *SlotConfig {
+	return &SlotConfig{
+		MultiplierThree:       10,
+		MultiplierTwo:         2,
+		TwoMatchProbability:   0.3,
+		ThreeMatchProbability: 0.05,
+		BaseCurrency:          "USD",
+		MaxBulkSpins:          10,
+		SpinLogSampleRate:     1,
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	c := validSlotConfig()
+	c.TwoMatchProbability = 1
+	c.ThreeMatchProbability = 0
+	c.AdditionalPayouts = []PayoutEntry{{Matches: 4, Multiplier: 25, Probability: 0.01}}
+
+	assert.NoError(t, c.Validate())
+}
+
+func TestValidate_OutOfRange(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(c *SlotConfig)
+		expected string
+	}{
+		{"TwoMatchProbabilityAboveOne", func(c *SlotConfig) { c.TwoMatchProbability = 1.5 }, "two-match-probability must be between 0 and 1, got 1.5"},
+		{"ThreeMatchProbabilityNegative", func(c *SlotConfig) { c.ThreeMatchProbability = -0.1 }, "three-match-probability must be between 0 and 1, got -0.1"},
+		{"MultiplierTwoNegative", func(c *SlotConfig) { c.MultiplierTwo = -2 }, "multiplier-two must be positive, got -2"},
+		{"MultiplierThreeZero", func(c *SlotConfig) { c.MultiplierThree = 0 }, "multiplier-three must be positive, got 0"},
+		{"AdditionalPayoutProbability", func(c *SlotConfig) {
+			c.AdditionalPayouts = []PayoutEntry{{Matches: 4, Multiplier: 25, Probability: 2}}
+		}, "payouts probability for 4 matches must be between 0 and 1, got 2"},
+		{"TwoMatchMultiplierZero", func(c *SlotConfig) { c.TwoMatchMultipliers = map[string]float64{"A": 0} }, "two-match-multipliers multiplier for \"A\" must be positive, got 0"},
+		{"TwoMatchMultiplierUnknownSymbol", func(c *SlotConfig) { c.TwoMatchMultipliers = map[string]float64{"W": 5} }, "two-match-multipliers must name regular symbols or the wild symbol, got \"W\""},
+		{"SymbolDisplayUnknownSymbol", func(c *SlotConfig) { c.SymbolDisplays = SymbolDisplays{"W": {Name: "Wild"}} }, "symbol-display must name regular, wild or scatter symbols, got \"W\""},
+		{"WelcomeBalanceNegative", func(c *SlotConfig) { c.WelcomeBalance = -5 }, "welcome-balance must not be negative, got -5"},
+		{"MaxWinPerSpinNegative", func(c *SlotConfig) { c.MaxWinPerSpin = -1 }, "max-win-per-spin must not be negative, got -1"},
+		{"AutoStopWinNegative", func(c *SlotConfig) { c.AutoStopWin = -1 }, "auto-stop-win must not be negative, got -1"},
+		{"MinWithdrawalAgeNegative", func(c *SlotConfig) { c.MinWithdrawalAge = -1 }, "min-withdrawal-account-age must not be negative, got -1"},
+		{"DepositLimitCooldownNegative", func(c *SlotConfig) { c.DepositLimitCooldown = -1 }, "deposit-limit-cooldown must not be negative, got -1"},
+		{"BigWinMultiplierNegative", func(c *SlotConfig) { c.BigWinMultiplier = -1 }, "big-win-multiplier must not be negative, got -1"},
+		{"PayoutRoundingUnknown", func(c *SlotConfig) { c.PayoutRounding = "bankers" }, "payout-rounding must be one of round, floor, ceil or none, got \"bankers\""},
+		{"PayoutDecimalsAboveStored", func(c *SlotConfig) { c.PayoutDecimals = 3 }, "payout-decimals must be between 0 and 2, got 3"},
+		{"AmountDecimalsAboveStored", func(c *SlotConfig) { c.AmountDecimals = 3 }, "amount-decimals must be between 0 and 2, got 3"},
+		{"MaxSpinsPerDayNegative", func(c *SlotConfig) { c.MaxSpinsPerDay = -1 }, "max-spins-per-day must not be negative, got -1"},
+		{"RealityCheckIntervalNegative", func(c *SlotConfig) { c.RealityCheckInterval = -1 }, "reality-check-interval must not be negative, got -1"},
+		{"SpinLimitResetHourTooLate", func(c *SlotConfig) { c.SpinLimitResetHour = 24 }, "spin-limit-reset-hour must be between 0 and 23, got 24"},
+		{"SymbolsDuplicated", func(c *SlotConfig) { c.Symbols = []string{"A", "A"} }, "symbols must be at least 2 distinct, non-empty symbols without commas, got [\"A\" \"A\"]"},
+		{"WildIsGameSymbol", func(c *SlotConfig) { c.Symbols, c.WildSymbol = []string{"X", "Y"}, "X" }, "wild-symbol must not be a regular symbol, got \"X\""},
+		{"JackpotProbabilityAboveOne", func(c *SlotConfig) { c.JackpotProbability = 1.5 }, "jackpot-probability must be between 0 and 1, got 1.5"},
+		{"JackpotContributionAboveOne", func(c *SlotConfig) { c.JackpotContribution = 2 }, "jackpot-contribution must be between 0 and 1, got 2"},
+		{"JackpotSeedNegative", func(c *SlotConfig) { c.JackpotSeed = -1 }, "jackpot-seed must not be negative, got -1"},
+		{"SpinLogSampleRateZero", func(c *SlotConfig) { c.SpinLogSampleRate = 0 }, "spin-log-sample-rate must be at least 1, got 0"},
+		{"MaxBulkSpinsZero", func(c *SlotConfig) { c.MaxBulkSpins = 0 }, "max-bulk-spins must be at least 1, got 0"},
+		{"DayTimezoneUnknown", func(c *SlotConfig) { c.DayTimezone = "Mars/Olympus" }, "day-timezone must be a known time zone, got \"Mars/Olympus\""},
+		{"SpinLimitTimezoneUnknown", func(c *SlotConfig) { c.SpinLimitTimezone = "Mars/Olympus" }, "spin-limit-timezone must be a known time zone, got \"Mars/Olympus\""},
+		{"BaseCurrencyLowercase", func(c *SlotConfig) { c.BaseCurrency = "usd" }, "base-currency must be a three-letter ISO 4217 code, got \"usd\""},
+		{"BetDenominationZero", func(c *SlotConfig) { c.BetDenominations = []float64{1, 0} }, "bet-denominations must be positive, got 0"},
+		{"WildProbabilityAboveOne", func(c *SlotConfig) { c.WildSymbol, c.WildProbability = "W", 2 }, "wild-probability must be between 0 and 1, got 2"},
+		{"ScatterMinCountZero", func(c *SlotConfig) {
+			c.ScatterSymbol, c.ScatterMultiplier, c.ScatterMinCount = "S", 5, 0
+		}, "scatter-min-count must be at least 1, got 0"},
+		{"ScatterFreeSpinsNegative", func(c *SlotConfig) {
+			c.ScatterSymbol, c.ScatterMultiplier, c.ScatterMinCount, c.ScatterFreeSpins = "S", 5, 3, -1
+		}, "scatter-free-spins must not be negative, got -1"},
+		{"FreeSpinBetZero", func(c *SlotConfig) {
+			c.ScatterSymbol, c.ScatterMultiplier, c.ScatterMinCount, c.ScatterFreeSpins = "S", 5, 3, 10
+		}, "free-spin-bet must be positive, got 0"},
+		{"WildIsRegularSymbol", func(c *SlotConfig) { c.WildSymbol = "A" }, "wild-symbol must not be a regular symbol, got \"A\""},
+		{"ReelStripsMissingReel", func(c *SlotConfig) { c.ReelStrips = [][]string{{"A", "B"}, {"A", "C"}} }, "reel-strips must give a strip for each of the 3 reels, got 2"},
+		{"ReelStripEmpty", func(c *SlotConfig) { c.ReelStrips = [][]string{{"A", "B"}, {}, {"A", "C"}} }, "reel-strips strip of reel 2 must not be empty"},
+		{"ReelStripUnknownSymbol", func(c *SlotConfig) { c.ReelStrips = [][]string{{"A", "B"}, {"A", "W"}, {"A", "C"}} }, "reel-strips strip of reel 2 must show regular, wild or scatter symbols, got \"W\""},
+		{"WildEqualsScatter", func(c *SlotConfig) {
+			c.WildSymbol, c.ScatterSymbol, c.ScatterMultiplier, c.ScatterMinCount = "X", "X", 5, 2
+		}, "wild-symbol and scatter-symbol must differ, got \"X\""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := validSlotConfig()
+			tc.modify(c)
+
+			err := c.Validate()
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	c := validSlotConfig()
+	c.TwoMatchProbability = 1.5
+	c.MultiplierTwo = -1
+
+	err := c.Validate()
+	assert.ErrorContains(t, err, "two-match-probability")
+	assert.ErrorContains(t, err, "multiplier-two")
+}
@@ -12,6 +12,14 @@ const CtxFieldTraceID CtxKey = "trace_id"
 // allowing for user identification and access control throughout the request lifecycle.
 const CtxFieldUserID CtxKey = "user_id"
 
+// CtxFieldTokenID is the context key for storing the unique ID (jti) of the request's JWT token,
+// identifying the login session the request belongs to.
+const CtxFieldTokenID CtxKey = "token_id"
+
+// CtxFieldForcedOutcome is the context key for storing the spin outcome QA forces on a request,
+// honored only while forced outcomes are enabled.
+const CtxFieldForcedOutcome CtxKey = "forced_outcome"
+
 // CtxFieldLogger is the context key for storing the logger instance,
 // which facilitates structured and traceable logging within a request context.
 const CtxFieldLogger CtxKey = "logger"
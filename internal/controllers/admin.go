@@ -0,0 +1,436 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/database"
+	"github.com/vadymlab/slot-game/internal/dto/request"
+	"github.com/vadymlab/slot-game/internal/dto/response"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/server"
+	"github.com/vadymlab/slot-game/internal/server/jwt"
+	"github.com/vadymlab/slot-game/internal/validators"
+)
+
+// AdminController manages support operations that are restricted to admin users,
+// such as voiding disputed spins, deciding on pending withdrawals, finding and freezing users and switching maintenance mode.
+type AdminController struct {
+	config            *server.APIConfig             // API configuration, including JWT settings
+	userService       interfaces.IUserService       // Service used to verify the admin flag of the caller
+	slotService       interfaces.ISlotService       // Service for slot game operations
+	withdrawalService interfaces.IWithdrawalService // Service for withdrawals awaiting approval
+	maintenance       interfaces.IMaintenanceStore  // Toggle putting the game and wallet endpoints into maintenance mode
+	tokens            interfaces.ITokenStore        // Active login sessions the JWT tokens must belong to
+}
+
+// NewAdminController initializes a new AdminController with the provided configuration and services.
+//
+// Parameters:
+//   - config: A pointer to the API configuration struct.
+//   - userService: An implementation of IUserService used to verify the admin flag.
+//   - slotService: An implementation of ISlotService for slot game functionality.
+//   - withdrawalService: An implementation of IWithdrawalService for approving and rejecting withdrawals.
+//   - maintenance: The maintenance mode toggle shared by all instances.
+//   - tokens: The store of the active login sessions, rejecting tokens of ended sessions.
+//
+// Returns:
+//
+//	A pointer to an AdminController instance.
+func NewAdminController(
+	config *server.APIConfig,
+	userService interfaces.IUserService,
+	slotService interfaces.ISlotService,
+	withdrawalService interfaces.IWithdrawalService,
+	maintenance interfaces.IMaintenanceStore,
+	tokens interfaces.ITokenStore,
+) *AdminController {
+	return &AdminController{
+		config:            config,
+		userService:       userService,
+		slotService:       slotService,
+		withdrawalService: withdrawalService,
+		maintenance:       maintenance,
+		tokens:            tokens,
+	}
+}
+
+// InitRoute registers the admin routes under the "/admin" endpoint, applying JWT authentication
+// and the admin check. Routes include:
+//   - "/spins/:id/void" for voiding a disputed spin.
+//   - "/withdrawals/:id/approve" and "/withdrawals/:id/reject" for deciding on a pending withdrawal.
+//   - "/users" for finding users.
+//   - "/users/:id/spin-chain" for verifying the hash chain of a user's spins.
+//   - "/users/:id/frozen" for freezing and unfreezing an account.
+//   - "/maintenance" for reading and switching maintenance mode.
+//
+// The admin routes stay up during maintenance. The routes taking a body read JSON or XML only and
+// reject other Content-Types with 415.
+//
+// Parameters:
+//   - route: A Gin RouterGroup to which the admin routes will be added.
+//
+// Returns:
+//
+//	An updated RouterGroup with initialized admin routes.
+func (c *AdminController) InitRoute(route *gin.RouterGroup) *gin.RouterGroup {
+	g := route.Group("/admin", jwt.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), AdminMiddleware(c.userService))
+	g.POST("/spins/:id/void", server.RequireJSONOrXML(), c.voidSpin)
+	g.POST("/withdrawals/:id/approve", c.approveWithdrawal)
+	g.POST("/withdrawals/:id/reject", server.RequireJSONOrXML(), c.rejectWithdrawal)
+	g.GET("/users", c.listUsers)
+	g.GET("/users/:id/spin-chain", c.verifySpinChain)
+	g.PUT("/users/:id/frozen", server.RequireJSONOrXML(), c.setFrozen)
+	g.GET("/maintenance", c.getMaintenance)
+	g.PUT("/maintenance", server.RequireJSONOrXML(), c.setMaintenance)
+	return route
+}
+
+// GetRoute returns the base route path for AdminController.
+func (c *AdminController) GetRoute() string {
+	return "/api"
+}
+
+// voidSpin reverses a disputed spin, refunding the bet and clawing back the win.
+//
+// @Summary Void a spin
+// @Description Refunds the bet and claws back the win of a disputed spin, marks it voided and records compensating ledger entries.
+// @Description A spin can only be voided once.
+// @Tags Admin
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Spin ID"
+// @Param req body request.VoidSpinRequest true "Void request body"
+// @Success 200 {object} response.VoidedSpinResponse "The voided spin"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input or insufficient funds for the clawback"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - user is not an admin"
+// @Failure 404 {object} server.ErrorResponseMessage "Spin not found"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - the spin has already been voided"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/admin/spins/{id}/void [post]
+func (c *AdminController) voidSpin(ctx *gin.Context) {
+	spinID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		server.ErrorBadRequest(ctx, "invalid spin id")
+		return
+	}
+	req := request.VoidSpinRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.FromContext(ctx).Error(err)
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	spin, err := c.slotService.VoidSpin(ctx.Request.Context(), uint(spinID), req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceError.ErrSpinNotFound):
+			server.NotFoundErrorResponse(ctx, err)
+		case errors.Is(err, serviceError.ErrSpinAlreadyVoided):
+			server.ConflictErrorResponse(ctx, err)
+		case errors.Is(err, serviceError.ErrInsufficientFunds):
+			server.ErrorBadRequest(ctx, err)
+		default:
+			server.InternalErrorResponse(ctx, err.Error())
+		}
+		return
+	}
+	server.SuccessResponse(ctx, response.VoidedSpinFromModel(spin))
+}
+
+// approveWithdrawal finalizes a pending withdrawal, paying out the held funds.
+//
+// @Summary Approve a withdrawal
+// @Description Finalizes a pending withdrawal. The held funds already left the user's balance when the withdrawal was requested.
+// @Description A withdrawal can only be approved or rejected once.
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Withdrawal ID"
+// @Success 200 {object} response.WithdrawalResponse "The approved withdrawal"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to an invalid withdrawal ID"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - user is not an admin"
+// @Failure 404 {object} server.ErrorResponseMessage "Withdrawal not found"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - the withdrawal has already been approved or rejected"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/admin/withdrawals/{id}/approve [post]
+func (c *AdminController) approveWithdrawal(ctx *gin.Context) {
+	withdrawalID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		server.ErrorBadRequest(ctx, "invalid withdrawal id")
+		return
+	}
+	withdrawal, err := c.withdrawalService.Approve(ctx.Request.Context(), uint(withdrawalID))
+	if err != nil {
+		withdrawalErrorResponse(ctx, err)
+		return
+	}
+	server.SuccessResponse(ctx, response.WithdrawalFromModel(withdrawal))
+}
+
+// rejectWithdrawal refuses a pending withdrawal, returning the held funds to the user.
+//
+// @Summary Reject a withdrawal
+// @Description Refuses a pending withdrawal, returns the held funds to the user's balance and records a compensating ledger entry.
+// @Description A withdrawal can only be approved or rejected once.
+// @Tags Admin
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Withdrawal ID"
+// @Param req body request.RejectWithdrawalRequest true "Reject request body"
+// @Success 200 {object} response.WithdrawalResponse "The rejected withdrawal"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - user is not an admin"
+// @Failure 404 {object} server.ErrorResponseMessage "Withdrawal not found"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - the withdrawal has already been approved or rejected"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/admin/withdrawals/{id}/reject [post]
+func (c *AdminController) rejectWithdrawal(ctx *gin.Context) {
+	withdrawalID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		server.ErrorBadRequest(ctx, "invalid withdrawal id")
+		return
+	}
+	req := request.RejectWithdrawalRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.FromContext(ctx).Error(err)
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	withdrawal, err := c.withdrawalService.Reject(ctx.Request.Context(), uint(withdrawalID), req.Reason)
+	if err != nil {
+		withdrawalErrorResponse(ctx, err)
+		return
+	}
+	server.SuccessResponse(ctx, response.WithdrawalFromModel(withdrawal))
+}
+
+// listUsers retrieves a page of the users, optionally only those whose login contains the search,
+// ordered by creation or balance. Only non-sensitive fields of the users are returned.
+//
+// @Summary List users
+// @Description Retrieves a page of the users whose login contains the search, case-insensitively, with their balance and account details.
+// @Description Users are ordered by creation, newest first, or by balance, highest first; order=asc reverses the order. Password hashes are never returned.
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param search query string false "Part of the login the users must contain"
+// @Param sort query string false "Order of the users (default created)" Enums(created, balance)
+// @Param order query string false "Direction of the order (default desc)" Enums(asc, desc)
+// @Param limit query int false "Maximum number of users to return (default 20, max 100)"
+// @Param offset query int false "Number of users to skip"
+// @Success 200 {object} response.Page[response.AdminUserResponse] "Page of users"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid search, order or pagination parameters"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - user is not an admin"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/admin/users [get]
+func (c *AdminController) listUsers(ctx *gin.Context) {
+	req := request.UserListRequest{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	filter := &models.UserFilter{Search: req.Search, SortBy: req.Sort, Ascending: req.Order == "asc"}
+	// The list only reads, so it may be served by the read replica
+	users, total, err := c.userService.ListUsers(database.WithReadOnly(ctx.Request.Context()), filter, req.GetLimit(), req.Offset)
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, response.NewPage(response.AdminUsersFromModels(users), total, req.GetLimit(), req.Offset))
+}
+
+// verifySpinChain walks the hash chain of a user's spins and reports the first spin that was altered
+// or removed since it was written.
+//
+// @Summary Verify the spin hash chain of a user
+// @Description Recomputes the hash of each chained spin of the user, oldest first, and checks that it links to the hash of the spin before it.
+// @Description Verification stops at the first broken spin. Spins written while the hash chain was disabled are not part of it.
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "User ID"
+// @Success 200 {object} response.SpinChainResponse "Result of the verification"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to an invalid user ID"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - user is not an admin"
+// @Failure 404 {object} server.ErrorResponseMessage "User not found"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/admin/users/{id}/spin-chain [get]
+func (c *AdminController) verifySpinChain(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		server.ErrorBadRequest(ctx, "invalid user id")
+		return
+	}
+	result, err := c.slotService.VerifySpinChain(ctx.Request.Context(), &userID)
+	if err != nil {
+		if errors.Is(err, serviceError.ErrUserNotFound) {
+			server.NotFoundErrorResponse(ctx, err)
+			return
+		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, response.SpinChainFromModel(result))
+}
+
+// setFrozen freezes or unfreezes the account of a user pending a fraud investigation. A frozen user
+// can neither log in, spin, deposit, withdraw nor transfer funds.
+//
+// @Summary Freeze or unfreeze a user
+// @Description Freezes or unfreezes the account of a user. While frozen, the user's login, spins, deposits,
+// @Description withdrawals and transfers are rejected with 403 and the ACCOUNT_FROZEN code
+// @Tags Admin
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "User ID"
+// @Param req body request.FreezeRequest true "Freeze request body"
+// @Success 200 {object} response.AdminUserResponse "The updated user"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to an invalid user ID or input"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - user is not an admin"
+// @Failure 404 {object} server.ErrorResponseMessage "User not found"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/admin/users/{id}/frozen [put]
+func (c *AdminController) setFrozen(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		server.ErrorBadRequest(ctx, "invalid user id")
+		return
+	}
+	req := request.FreezeRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.FromContext(ctx).Error(err)
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	user, err := c.userService.SetFrozen(ctx.Request.Context(), &userID, *req.Frozen)
+	if err != nil {
+		if errors.Is(err, serviceError.ErrUserNotFound) {
+			server.NotFoundErrorResponse(ctx, err)
+			return
+		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	log.FromContext(ctx).Infow("user account frozen state changed", "user_id", userID, "frozen", *req.Frozen)
+	server.SuccessResponse(ctx, response.AdminUserFromModel(user))
+}
+
+// getMaintenance reports whether the game and wallet endpoints are in maintenance mode.
+//
+// @Summary Get maintenance mode
+// @Description Reports whether the game and wallet endpoints are in maintenance mode, by the admin toggle or the maintenance flag
+// @Tags Admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} response.MaintenanceResponse "Maintenance mode"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - user is not an admin"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/admin/maintenance [get]
+func (c *AdminController) getMaintenance(ctx *gin.Context) {
+	toggle, err := c.maintenance.Enabled(ctx.Request.Context())
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, c.maintenanceResponse(toggle))
+}
+
+// setMaintenance switches maintenance mode on or off for all instances of the API. While the
+// maintenance flag is set, switching the toggle off does not end maintenance mode.
+//
+// @Summary Switch maintenance mode
+// @Description Switches the maintenance mode of the game and wallet endpoints on or off for all instances.
+// @Description While the maintenance flag is set, the endpoints stay in maintenance mode regardless of the toggle
+// @Tags Admin
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param req body request.MaintenanceRequest true "Maintenance request body"
+// @Success 200 {object} response.MaintenanceResponse "Maintenance mode"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - user is not an admin"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/admin/maintenance [put]
+func (c *AdminController) setMaintenance(ctx *gin.Context) {
+	req := request.MaintenanceRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.FromContext(ctx).Error(err)
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	if err := c.maintenance.SetEnabled(ctx.Request.Context(), *req.Enabled); err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	log.FromContext(ctx).Infow("maintenance mode toggle switched", "enabled", *req.Enabled)
+	server.SuccessResponse(ctx, c.maintenanceResponse(*req.Enabled))
+}
+
+// maintenanceResponse describes maintenance mode given the state of the admin toggle.
+func (c *AdminController) maintenanceResponse(toggle bool) *response.MaintenanceResponse {
+	return &response.MaintenanceResponse{
+		Enabled: toggle || c.config.Maintenance,
+		Toggle:  toggle,
+		Flag:    c.config.Maintenance,
+	}
+}
+
+// withdrawalErrorResponse maps an error of a withdrawal decision to its response.
+func withdrawalErrorResponse(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, serviceError.ErrWithdrawalNotFound):
+		server.NotFoundErrorResponse(ctx, err)
+	case errors.Is(err, serviceError.ErrWithdrawalNotPending):
+		server.ConflictErrorResponse(ctx, err)
+	default:
+		server.InternalErrorResponse(ctx, err.Error())
+	}
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/dto/response"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// newAdminTestEngine serves the user list and the account freeze to the authenticated user, guarded
+// by the admin check.
+func newAdminTestEngine(userService *mocks.MockIUserService, userID uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	c := NewAdminController(nil, userService, nil, nil, nil, nil)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), userID.String())
+	})
+	router.GET("/users", AdminMiddleware(userService), c.listUsers)
+	router.PUT("/users/:id/frozen", AdminMiddleware(userService), c.setFrozen)
+	return router
+}
+
+// getUsers lists the users with the given query string.
+func getUsers(router *gin.Engine, query string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users"+query, nil))
+	return rec
+}
+
+func TestListUsers_SearchesAndPaginates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminID, playerID := uuid.New(), uuid.New()
+	createdAt := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().GetByExternalID(gomock.Any(), &adminID).Return(&models.User{IsAdmin: true}, nil)
+	userService.EXPECT().ListUsers(gomock.Any(), &models.UserFilter{Search: "bob", SortBy: models.UserSortBalance, Ascending: true}, 10, 20).
+		Return([]*models.User{{
+			Model:      gorm.Model{ID: 3, CreatedAt: createdAt},
+			ExternalID: &playerID,
+			Login:      "bob@example.com",
+			Password:   "$2a$10$hash",
+			Balance:    12.5,
+		}}, int64(21), nil)
+
+	rec := getUsers(newAdminTestEngine(userService, adminID), "?search=bob&sort=balance&order=asc&limit=10&offset=20")
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "password")
+	assert.NotContains(t, rec.Body.String(), "$2a$10$hash")
+	var page response.Page[response.AdminUserResponse]
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Equal(t, int64(21), page.Total)
+	assert.Equal(t, 10, page.Limit)
+	assert.Equal(t, 20, page.Offset)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, &playerID, page.Data[0].ID)
+	assert.Equal(t, "bob@example.com", page.Data[0].Login)
+	assert.Equal(t, response.Money(12.5), page.Data[0].Balance)
+	assert.Equal(t, createdAt, page.Data[0].CreatedAt)
+}
+
+func TestListUsers_DefaultsToNewestFirst(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminID := uuid.New()
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().GetByExternalID(gomock.Any(), &adminID).Return(&models.User{IsAdmin: true}, nil)
+	userService.EXPECT().ListUsers(gomock.Any(), &models.UserFilter{}, 20, 0).Return(nil, int64(0), nil)
+
+	rec := getUsers(newAdminTestEngine(userService, adminID), "")
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"data":[]`)
+}
+
+func TestListUsers_RejectsInvalidQuery(t *testing.T) {
+	for _, query := range []string{"?sort=password", "?order=up", "?limit=101", "?offset=-1"} {
+		t.Run(query, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adminID := uuid.New()
+			userService := mocks.NewMockIUserService(ctrl)
+			userService.EXPECT().GetByExternalID(gomock.Any(), &adminID).Return(&models.User{IsAdmin: true}, nil)
+
+			rec := getUsers(newAdminTestEngine(userService, adminID), query)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestListUsers_RequiresAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Listing the users fails the test
+	userID := uuid.New()
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().GetByExternalID(gomock.Any(), &userID).Return(&models.User{}, nil)
+
+	rec := getUsers(newAdminTestEngine(userService, userID), "?search=bob")
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+// putFrozen freezes or unfreezes the user with the given ID.
+func putFrozen(router *gin.Engine, userID, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/users/"+userID+"/frozen", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSetFrozen_FreezesAndUnfreezesUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminID, playerID := uuid.New(), uuid.New()
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().GetByExternalID(gomock.Any(), &adminID).Return(&models.User{IsAdmin: true}, nil).Times(2)
+	userService.EXPECT().SetFrozen(gomock.Any(), &playerID, true).Return(&models.User{ExternalID: &playerID, Frozen: true}, nil)
+	userService.EXPECT().SetFrozen(gomock.Any(), &playerID, false).Return(&models.User{ExternalID: &playerID}, nil)
+	router := newAdminTestEngine(userService, adminID)
+
+	for _, frozen := range []bool{true, false} {
+		body, _ := json.Marshal(map[string]bool{"frozen": frozen})
+		rec := putFrozen(router, playerID.String(), string(body))
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var user response.AdminUserResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &user))
+		assert.Equal(t, &playerID, user.ID)
+		assert.Equal(t, frozen, user.Frozen)
+	}
+}
+
+func TestSetFrozen_Rejected(t *testing.T) {
+	playerID := uuid.New()
+	tests := []struct {
+		name   string
+		userID string
+		body   string
+		err    error
+		status int
+	}{
+		{"invalid user id", "not-a-uuid", `{"frozen":true}`, nil, http.StatusBadRequest},
+		{"missing frozen", playerID.String(), `{}`, nil, http.StatusBadRequest},
+		{"user not found", playerID.String(), `{"frozen":true}`, serviceError.ErrUserNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adminID := uuid.New()
+			userService := mocks.NewMockIUserService(ctrl)
+			userService.EXPECT().GetByExternalID(gomock.Any(), &adminID).Return(&models.User{IsAdmin: true}, nil)
+			if tt.err != nil {
+				userService.EXPECT().SetFrozen(gomock.Any(), &playerID, true).Return(nil, tt.err)
+			}
+
+			rec := putFrozen(newAdminTestEngine(userService, adminID), tt.userID, tt.body)
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
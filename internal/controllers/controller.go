@@ -4,7 +4,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/interfaces"
 	"github.com/vadymlab/slot-game/internal/server"
+	mw "github.com/vadymlab/slot-game/internal/server/jwt"
 )
 
 // BaseController defines a fundamental interface for controllers, offering
@@ -21,8 +23,8 @@ type BaseController interface {
 }
 
 // GetUserFromContext retrieves the user ID from the context, if available, and returns it as a UUID pointer.
-// If the user ID is not present in the context, or if it is invalid, appropriate error responses
-// are sent back to the client and nil is returned.
+// If the user ID is not present in the context, or if it is not a valid UUID, the request is not
+// authenticated: a 401 response is sent back to the client and nil is returned.
 //
 // Parameters:
 //   - ctx: The Gin context from which to retrieve the user ID.
@@ -40,8 +42,36 @@ func GetUserFromContext(ctx *gin.Context) *uuid.UUID {
 
 	uUID, err := uuid.Parse(userID)
 	if err != nil {
-		server.InternalErrorResponse(ctx, err.Error())
+		server.UnauthorizedErrorResponse(ctx, mw.ErrInvalidTokenSubject)
 		return nil
 	}
 	return &uUID
 }
+
+// AdminMiddleware restricts the routes it guards to admin users. It must run after the JWT
+// authentication middleware; requests of users without the admin flag are rejected with 403.
+//
+// Parameters:
+//   - userService: Service used to look up the authenticated user.
+//
+// Returns:
+//
+//	A Gin middleware handler.
+func AdminMiddleware(userService interfaces.IUserService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := GetUserFromContext(ctx)
+		if userID == nil {
+			return
+		}
+		user, err := userService.GetByExternalID(ctx.Request.Context(), userID)
+		if err != nil {
+			server.InternalErrorResponse(ctx, err.Error())
+			return
+		}
+		if user == nil || !user.IsAdmin {
+			server.ForbiddenErrorResponse(ctx, "admin access required")
+			return
+		}
+		ctx.Next()
+	}
+}
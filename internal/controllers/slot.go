@@ -1,29 +1,60 @@
 package controller
 
 import (
+	"context"
+	"encoding/csv"
 	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	log "github.com/public-forge/go-logger"
 	libredis "github.com/redis/go-redis/v9"
 	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/database"
 	"github.com/vadymlab/slot-game/internal/dto/request"
 	"github.com/vadymlab/slot-game/internal/dto/response"
 	serviceError "github.com/vadymlab/slot-game/internal/error"
 	"github.com/vadymlab/slot-game/internal/interfaces"
 	"github.com/vadymlab/slot-game/internal/middlewares"
+	"github.com/vadymlab/slot-game/internal/models"
 	"github.com/vadymlab/slot-game/internal/server"
 	"github.com/vadymlab/slot-game/internal/server/jwt"
 	"github.com/vadymlab/slot-game/internal/validators"
 )
 
+// HeaderDemoMode is the HTTP header requesting a play-money demo spin instead of a real one.
+const HeaderDemoMode = "X-Demo-Mode"
+
+// Headers forcing the outcome of a spin for QA, honored only while forced outcomes are enabled.
+const (
+	HeaderForceReels = "X-Force-Reels" // Comma-separated symbols shown on the reels, e.g. "A,A,A"
+	HeaderForceWin   = "X-Force-Win"   // Payout of the spin before the streak, rounding and win cap
+)
+
+// historyCSVFlushRows is the number of rows of the spin history export sent to the client at once.
+const historyCSVFlushRows = 100
+
+// Names of the server-sent events emitted by a streamed spin.
+const (
+	EventReel   = "reel"   // A reel symbol is revealed
+	EventResult = "result" // The spin result, sent after all reels are revealed
+)
+
 // SlotController manages slot game operations, including processing spin requests
 // and retrieving user spin history. It connects to slotService for core operations
 // and applies JWT authentication for protected routes.
 type SlotController struct {
-	config      *server.APIConfig       // API configuration, including JWT settings
-	slotService interfaces.ISlotService // Service interface for slot game operations
+	config      *server.APIConfig          // API configuration, including JWT settings
+	slotService interfaces.ISlotService    // Service interface for slot game operations
+	sessions    interfaces.ISessionService // Service summarizing the user's game sessions
 	appConfig   *config.SlotConfig
 	redisClient *libredis.Client
+	maintenance interfaces.IMaintenanceStore // Toggle putting the slot endpoints into maintenance mode
+	tokens      interfaces.ITokenStore       // Active login sessions the JWT tokens must belong to
 }
 
 // NewSlotController initializes a new SlotController with the provided configuration
@@ -32,22 +63,51 @@ type SlotController struct {
 // Parameters:
 //   - config: A pointer to the API configuration struct.
 //   - slotService: An implementation of the ISlotService interface for slot game functionality.
+//   - sessions: An implementation of the ISessionService interface summarizing the game sessions.
+//   - maintenance: The maintenance mode toggle admins switch on during deploys and incidents.
+//   - tokens: The store of the active login sessions, rejecting tokens of ended sessions.
 //
 // Returns:
 //
 //	A pointer to a SlotController instance.
-func NewSlotController(config *server.APIConfig, appConfig *config.SlotConfig, redisClient *libredis.Client, slotService interfaces.ISlotService) *SlotController {
+func NewSlotController(
+	config *server.APIConfig,
+	appConfig *config.SlotConfig,
+	redisClient *libredis.Client,
+	slotService interfaces.ISlotService,
+	sessions interfaces.ISessionService,
+	maintenance interfaces.IMaintenanceStore,
+	tokens interfaces.ITokenStore,
+) *SlotController {
 	return &SlotController{
 		config:      config,
 		slotService: slotService,
+		sessions:    sessions,
 		appConfig:   appConfig,
 		redisClient: redisClient,
+		maintenance: maintenance,
+		tokens:      tokens,
 	}
 }
 
-// InitRoute registers the slot game routes under the "/slot" endpoint, applying JWT
-// middleware for authentication. Routes include "/spin" for spinning and "/history" for retrieving
-// the user's spin history.
+// InitRoute registers the slot game routes under the "/slot" endpoint, applying JWT middleware for
+// authentication. Routes include:
+//   - "/spin" for spinning.
+//   - "/spin/bulk" for playing several spins at once.
+//   - "/spin/free" for playing a free spin.
+//   - "/spin/last" for replaying the result of the last spin.
+//   - "/spin/stream" for spinning with the reels revealed one by one as server-sent events.
+//   - "/demo/start" and "/demo/end" for starting and ending a play-money demo session.
+//   - "/history" for retrieving the user's spin history and "/history.csv" for exporting it as CSV.
+//   - "/stats" for the user's play statistics.
+//   - "/sessions" for the summaries of the user's game sessions.
+//   - "/reality-check/ack" for acknowledging a reality check.
+//   - "/leaderboard" for listing the top winners.
+//   - "/config" for the public configuration of a game.
+//
+// The endpoints only produce JSON, or server-sent events for the stream and CSV for the export, and
+// reject other Accept headers with 406. The spin endpoints read JSON or XML bodies only and reject
+// other Content-Types with 415. In maintenance mode, all slot endpoints are answered with 503.
 //
 // Parameters:
 //   - route: A Gin RouterGroup to which the slot game routes will be added.
@@ -56,9 +116,25 @@ func NewSlotController(config *server.APIConfig, appConfig *config.SlotConfig, r
 //
 //	An updated RouterGroup with initialized slot game routes.
 func (c *SlotController) InitRoute(route *gin.RouterGroup) *gin.RouterGroup {
-	g := route.Group("/slot", middlewares.NewRateLimiter(c.appConfig, c.redisClient), jwt.AuthMiddleware(c.config.JWTSecret))
-	g.POST("/spin", c.spin)
-	g.POST("/history", c.history)
+	g := route.Group("/slot", server.Maintenance(c.config, c.maintenance), middlewares.NewRateLimiter(c.appConfig, c.redisClient), jwt.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens))
+	stream := server.AcceptJSON(server.MediaTypeEventStream)
+	g.GET("/spin/stream", stream, c.spinStream)
+	g.POST("/spin/stream", stream, server.RequireJSONOrXML(), c.spinStream)
+	g.GET("/history.csv", server.AcceptJSON(server.MediaTypeCSV), c.exportHistory)
+
+	j := g.Group("", server.AcceptJSON())
+	j.POST("/spin", server.RequireJSONOrXML(), c.spin)
+	j.POST("/spin/bulk", server.RequireJSONOrXML(), c.bulkSpin)
+	j.POST("/spin/free", c.freeSpin)
+	j.GET("/spin/last", c.lastSpin)
+	j.POST("/demo/start", c.startDemo)
+	j.POST("/demo/end", c.endDemo)
+	j.POST("/history", c.history)
+	j.GET("/stats", c.stats)
+	j.GET("/sessions", c.sessionSummaries)
+	j.POST("/reality-check/ack", c.acknowledgeRealityCheck)
+	j.GET("/leaderboard", c.leaderboard)
+	j.GET("/config", middlewares.ETag(c.config.CacheMaxAge), c.gameConfig)
 	return route
 }
 
@@ -70,23 +146,116 @@ func (c *SlotController) GetRoute() string {
 
 // spin processes a slot spin request, validates input, retrieves the user ID from context,
 // and invokes slotService.spin to perform the spin operation. If successful, it returns the spin result.
+// Requests carrying the X-Demo-Mode header are played with the demo balance and never persisted.
 // In case of errors, it responds with appropriate error messages.
 //
-// @Summary spin the slot machine
-// @Description Initiates a spin with the specified bet amount and returns the result.
+// @Summary Spin the slot machine
+// @Description Initiates a spin of the game named by game_id, or of the default game, with the specified bet amount and returns the result.
+// @Description With the X-Demo-Mode header set to true, the spin uses the play-money demo balance and is not persisted.
+// @Description A bet placed in another currency is converted into the base currency at the current exchange rate; the result then also holds the bet and the win in that currency.
 // @Tags Slot
-// @Accept json
+// @Accept json,xml
 // @Produce json
 // @Param Authorization header string true "Bearer token"
-// @Param req body request.SpinRequest true "spin request body"
-// @Success 200 {object} response.SpinResponse "spin result with win amount"
-// @Failure 400 {string} string "Bad request due to invalid input or insufficient funds"
-// @Failure 500 {string} string "Internal server error"
+// @Param X-Demo-Mode header bool false "Play the spin with the demo balance"
+// @Param X-Force-Reels header string false "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled"
+// @Param X-Force-Win header number false "QA only: payout forced on the spin; ignored unless forced outcomes are enabled"
+// @Param req body request.SpinRequest true "Spin request body"
+// @Success 200 {object} response.SpinResponse "Spin result with win amount"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input, a disallowed bet amount, a currency without an exchange rate or insufficient funds"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - the user is self-excluded from play or the account is frozen"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 404 {object} server.ErrorResponseMessage "Not found - the game does not exist"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - another spin of the user is in progress"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 429 {object} server.ErrorResponseMessage "Too many requests - the daily spin limit is reached"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
 // @Security BearerAuth
 // @Router /api/slot/spin [post]
 func (c *SlotController) spin(ctx *gin.Context) {
 	req := request.SpinRequest{}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.FromContext(ctx).Error(err)
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	if errs := validators.ValidateAmount("betamount", req.BetAmount, c.appConfig.AmountDecimals); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	bit, err := c.play(ctx, req)
+	if err != nil {
+		spinErrorResponse(ctx, err)
+		return
+	}
+	server.SuccessResponse(ctx, response.SpinFromModel(bit))
+}
+
+// lastSpin replays the result of the user's last committed spin, for a client that lost the
+// response of a spin. The result is kept for a short time only; demo spins are not kept.
+//
+// @Summary Get the last spin
+// @Description Returns the result of the user's last spin exactly as the spin returned it, while it is kept after the spin.
+// @Description Of a bulk spin, the last spin of the batch is kept. Demo spins are not kept.
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} response.SpinResponse "Result of the last spin"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 404 {object} server.ErrorResponseMessage "Not found - the user has not spun recently"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/spin/last [get]
+func (c *SlotController) lastSpin(ctx *gin.Context) {
+	spin, err := c.slotService.LastSpin(ctx.Request.Context(), GetUserFromContext(ctx))
+	if err != nil {
+		if errors.Is(err, serviceError.ErrSpinNotFound) {
+			server.NotFoundErrorResponse(ctx, err)
+			return
+		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, response.SpinFromModel(spin))
+}
+
+// bulkSpin plays several spins with the same bet at once, each settled like a single spin, and
+// returns their results with an aggregate and the final balance. The batch ends early when a spin
+// cannot be played, such as when the balance runs out; the spins played before stand and the
+// summary tells why the batch ended. Bulk spins always play with the real balance.
+//
+// @Summary Play several spins at once
+// @Description Plays up to count spins with the same bet and returns each result, their aggregate and the final balance.
+// @Description When the balance no longer covers the bet or the daily spin limit is reached mid-batch, the batch ends early and summary.stopped_by holds the error code.
+// @Description Demo mode is not supported.
+// @Tags Slot
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param X-Force-Reels header string false "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled"
+// @Param X-Force-Win header number false "QA only: payout forced on the spins; ignored unless forced outcomes are enabled"
+// @Param req body request.BulkSpinRequest true "Bulk spin request body"
+// @Success 200 {object} response.BulkSpinResponse "Results of the spins played with their aggregate"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input, a count above the maximum, a disallowed bet amount, insufficient funds or demo mode"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - the user is self-excluded from play or the account is frozen"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 404 {object} server.ErrorResponseMessage "Not found - the game does not exist"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - another spin of the user is in progress"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 429 {object} server.ErrorResponseMessage "Too many requests - the daily spin limit is reached"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/spin/bulk [post]
+func (c *SlotController) bulkSpin(ctx *gin.Context) {
+	req := request.BulkSpinRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
 		log.FromContext(ctx).Error(err)
 		server.ErrorBadRequest(ctx, err)
 		return
@@ -95,38 +264,540 @@ func (c *SlotController) spin(ctx *gin.Context) {
 		server.ErrorsBadRequest(ctx, errs)
 		return
 	}
+	if errs := validators.ValidateAmount("betamount", req.BetAmount, c.appConfig.AmountDecimals); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	if isDemoRequest(ctx) {
+		server.ErrorBadRequest(ctx, "bulk spins are not available in demo mode")
+		return
+	}
+	if err := c.forceOutcome(ctx); err != nil {
+		spinErrorResponse(ctx, err)
+		return
+	}
+	batch, err := c.slotService.BulkSpin(ctx.Request.Context(), GetUserFromContext(ctx), req.GameID, req.BetAmount, req.Count)
+	if err != nil {
+		spinErrorResponse(ctx, err)
+		return
+	}
+	server.SuccessResponse(ctx, response.BulkSpinFromModel(batch))
+}
+
+// freeSpin plays one of the user's free spins, awarded by scatters. No bet is debited: the spin
+// uses up a free spin and its win is paid as a spin of the free spin bet would. Free spins always
+// play with the real balance.
+//
+// @Summary Play a free spin
+// @Description Plays one of the user's free spins in the game named by the game_id query parameter, or in the default game, and returns the result.
+// @Description The spin uses up a free spin instead of debiting a bet; its win is paid for the configured free spin bet and the spin is recorded without a bet.
+// @Description Demo mode is not supported.
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param X-Force-Reels header string false "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled"
+// @Param X-Force-Win header number false "QA only: payout forced on the spin; ignored unless forced outcomes are enabled"
+// @Param game_id query string false "Game to play; empty plays the default game"
+// @Success 200 {object} response.SpinResponse "Result of the free spin"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request - the user has no free spins left, or demo mode"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - the user is self-excluded from play or the account is frozen"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 404 {object} server.ErrorResponseMessage "Not found - the game does not exist"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - another spin of the user is in progress"
+// @Failure 429 {object} server.ErrorResponseMessage "Too many requests - the daily spin limit is reached"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/spin/free [post]
+func (c *SlotController) freeSpin(ctx *gin.Context) {
+	if isDemoRequest(ctx) {
+		server.ErrorBadRequest(ctx, "free spins are not available in demo mode")
+		return
+	}
+	if err := c.forceOutcome(ctx); err != nil {
+		spinErrorResponse(ctx, err)
+		return
+	}
+	spin, err := c.slotService.FreeSpin(ctx.Request.Context(), GetUserFromContext(ctx), ctx.Query("game_id"))
+	if err != nil {
+		spinErrorResponse(ctx, err)
+		return
+	}
+	server.SuccessResponse(ctx, response.SpinFromModel(spin))
+}
+
+// spinStream plays a spin like spin and reveals its result as server-sent events, for clients that
+// let the server pace the reel animation. The whole spin, including the single balance change, is
+// settled before streaming starts; the stream only paces the reveal. One "reel" event is sent per
+// reel, each after the configured reveal delay, followed by a "result" event with the win and balance.
+// Errors occurring before the spin is settled are returned as regular JSON error responses.
+//
+// @Summary Spin the slot machine with a streamed reveal
+// @Description Plays a spin and streams its reels one by one as server-sent events, followed by the result.
+// @Description Each "reel" event carries a response.ReelEvent, the final "result" event a response.SpinResponse.
+// @Description The bet amount and the game are read from the bet_amount and game_id query parameters for GET and from the JSON or XML body for POST.
+// @Tags Slot
+// @Accept json,xml
+// @Produce text/event-stream
+// @Param Authorization header string true "Bearer token"
+// @Param X-Demo-Mode header bool false "Play the spin with the demo balance"
+// @Param X-Force-Reels header string false "QA only: comma-separated symbols forced on the reels; ignored unless forced outcomes are enabled"
+// @Param X-Force-Win header number false "QA only: payout forced on the spin; ignored unless forced outcomes are enabled"
+// @Param bet_amount query number false "Bet amount of a GET request"
+// @Param game_id query string false "Game of a GET request; empty plays the default game"
+// @Param req body request.SpinRequest false "Spin request body of a POST request"
+// @Success 200 {object} response.SpinResponse "Stream of reel events followed by the spin result"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input, a disallowed bet amount, a currency without an exchange rate or insufficient funds"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - the user is self-excluded from play or the account is frozen"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client accepts neither JSON nor server-sent events"
+// @Failure 404 {object} server.ErrorResponseMessage "Not found - the game does not exist"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - another spin of the user is in progress"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the body of a POST request is neither JSON nor XML"
+// @Failure 429 {object} server.ErrorResponseMessage "Too many requests - the daily spin limit is reached"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/spin/stream [get]
+// @Router /api/slot/spin/stream [post]
+func (c *SlotController) spinStream(ctx *gin.Context) {
+	req := request.SpinRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.FromContext(ctx).Error(err)
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	if errs := validators.ValidateAmount("betamount", req.BetAmount, c.appConfig.AmountDecimals); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	bit, err := c.play(ctx, req)
+	if err != nil {
+		spinErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	delay := time.Duration(c.appConfig.SpinRevealDelay) * time.Millisecond
+	for i, symbol := range bit.Reels {
+		if delay > 0 {
+			select {
+			case <-ctx.Request.Context().Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+		ctx.SSEvent(EventReel, response.ReelEvent{Reel: i, Symbol: symbol})
+		server.Flush(ctx)
+	}
+	ctx.SSEvent(EventResult, response.SpinFromModel(bit))
+	server.Flush(ctx)
+}
+
+// play performs a spin of the requested game for the authenticated user, using the demo balance if
+// the request asks for it.
+func (c *SlotController) play(ctx *gin.Context, req request.SpinRequest) (*models.Spin, error) {
+	if err := c.forceOutcome(ctx); err != nil {
+		return nil, err
+	}
+	userID := GetUserFromContext(ctx)
+	if isDemoRequest(ctx) {
+		return c.slotService.DemoSpin(ctx.Request.Context(), userID, req.GameID, req.BetAmount)
+	}
+	if req.Currency != "" {
+		return c.slotService.SpinInCurrency(ctx.Request.Context(), userID, req.GameID, req.Currency, req.BetAmount)
+	}
+	return c.slotService.RetrySpin(ctx.Request.Context(), userID, req.GameID, req.BetAmount)
+}
+
+// forceOutcome stores the outcome forced by the X-Force-Reels and X-Force-Win headers in the request
+// context for the spin service. The headers are ignored unless forced outcomes are enabled.
+//
+// Returns:
+//   - ErrInvalidForcedOutcome if the forced win is not a number; otherwise, nil.
+func (c *SlotController) forceOutcome(ctx *gin.Context) error {
+	if !c.appConfig.ForcedOutcomes {
+		return nil
+	}
+	reels, win := ctx.GetHeader(HeaderForceReels), ctx.GetHeader(HeaderForceWin)
+	if reels == "" && win == "" {
+		return nil
+	}
+	outcome := &models.ForcedOutcome{}
+	if reels != "" {
+		for _, symbol := range strings.Split(reels, ",") {
+			outcome.Reels = append(outcome.Reels, strings.TrimSpace(symbol))
+		}
+	}
+	if win != "" {
+		amount, err := strconv.ParseFloat(win, 64)
+		if err != nil {
+			return serviceError.ErrInvalidForcedOutcome
+		}
+		outcome.Win = &amount
+	}
+	log.FromContext(ctx).Warnf("spin outcome forced: reels %q, win %q", reels, win)
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), constants.CtxFieldForcedOutcome, outcome))
+	return nil
+}
+
+// spinErrorResponse responds to a failed spin with the status matching the error.
+func spinErrorResponse(ctx *gin.Context, err error) {
+	if errors.Is(err, serviceError.ErrInsufficientFunds) || errors.Is(err, serviceError.ErrDemoDisabled) ||
+		errors.Is(err, serviceError.ErrInvalidBetDenomination) || errors.Is(err, serviceError.ErrInvalidSpinCount) ||
+		errors.Is(err, serviceError.ErrInvalidForcedOutcome) || errors.Is(err, serviceError.ErrUnsupportedCurrency) ||
+		errors.Is(err, serviceError.ErrInvalidAmount) || errors.Is(err, serviceError.ErrNoFreeSpins) {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errors.Is(err, serviceError.ErrSelfExcluded) || errors.Is(err, serviceError.ErrAccountFrozen) {
+		server.ForbiddenErrorResponse(ctx, err)
+		return
+	}
+	if errors.Is(err, serviceError.ErrGameNotFound) {
+		server.NotFoundErrorResponse(ctx, err)
+		return
+	}
+	if errors.Is(err, serviceError.ErrSpinInProgress) {
+		server.ConflictErrorResponse(ctx, err)
+		return
+	}
+	if errors.Is(err, serviceError.ErrSpinLimitReached) {
+		server.TooManyRequestsErrorResponse(ctx, err)
+		return
+	}
+	server.InternalErrorResponse(ctx, err.Error())
+}
+
+// startDemo starts a new play-money demo session for the user, resetting the demo balance.
+//
+// @Summary Start a demo session
+// @Description Starts a new play-money demo session, resetting the demo balance to the configured amount
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} response.DemoSessionResponse "Demo balance of the new session"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request - demo mode is disabled"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/demo/start [post]
+func (c *SlotController) startDemo(ctx *gin.Context) {
 	userID := GetUserFromContext(ctx)
-	bit, err := c.slotService.RetrySpin(ctx.Request.Context(), userID, req.BetAmount)
+	balance, err := c.slotService.StartDemo(ctx.Request.Context(), userID)
 	if err != nil {
-		if errors.Is(err, serviceError.ErrInsufficientFunds) {
+		if errors.Is(err, serviceError.ErrDemoDisabled) {
 			server.ErrorBadRequest(ctx, err)
 			return
 		}
 		server.InternalErrorResponse(ctx, err.Error())
 		return
 	}
-	server.SuccessResponse(ctx, response.SpinFromModel(bit))
+	server.SuccessResponse(ctx, &response.DemoSessionResponse{Balance: response.Money(*balance)})
 }
 
-// history retrieves the user's spin history from slotService and returns it as a structured
-// response. If an error occurs, it responds with an internal server error message.
+// endDemo ends the play-money demo session of the user when switching back to real play. The demo
+// balance is discarded; it never reaches the real wallet.
+//
+// @Summary End a demo session
+// @Description Ends the play-money demo session of the user, discarding the demo balance, which is never credited to the real wallet.
+// @Description With the demo balance migration enabled, the balance is passed to the migration hook instead, which ignores it by default
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} response.DemoEndResponse "Balance the demo session ended with"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/demo/end [post]
+func (c *SlotController) endDemo(ctx *gin.Context) {
+	userID := GetUserFromContext(ctx)
+	balance, migrated, err := c.slotService.EndDemo(ctx.Request.Context(), userID)
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, &response.DemoEndResponse{Balance: response.MoneyPtr(balance), Migrated: migrated})
+}
+
+// isDemoRequest reports whether the request asks for a demo spin through the X-Demo-Mode header.
+func isDemoRequest(ctx *gin.Context) bool {
+	demo, err := strconv.ParseBool(ctx.GetHeader(HeaderDemoMode))
+	return err == nil && demo
+}
+
+// history retrieves a page of the user's spin history from slotService and returns it wrapped
+// in a pagination envelope. The optional from and to days restrict the history to the spins played
+// on those days, both inclusive. If an error occurs, it responds with an internal server error message.
 //
 // @Summary Get spin history
-// @Description Retrieves the user's spin history, showing past spins with their results
+// @Description Retrieves a page of the user's spin history, newest first, showing past spins with their results
 // @Tags Slot
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
-// @Success 200 {array} response.SpinHistoryResponse "List of past spin results"
-// @Failure 500 {string} string "Internal server error"
+// @Param limit query int false "Maximum number of spins to return (default 20, max 100)"
+// @Param offset query int false "Number of spins to skip"
+// @Param from query string false "First day of the history, YYYY-MM-DD in UTC"
+// @Param to query string false "Last day of the history, YYYY-MM-DD in UTC"
+// @Success 200 {object} response.Page[response.SpinHistoryResponse] "Page of past spin results"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid pagination parameters or dates"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
 // @Security BearerAuth
 // @Router /api/slot/history [post]
 func (c *SlotController) history(ctx *gin.Context) {
+	req := request.SpinHistoryRequest{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	userID := GetUserFromContext(ctx)
+	from, to := req.Bounds()
+	// The history only reads, so it may be served by the read replica
+	history, total, err := c.slotService.History(database.WithReadOnly(ctx.Request.Context()), userID, from, to, req.GetLimit(), req.Offset)
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, response.NewPage(response.SpinHistoryFromModels(history), total, req.GetLimit(), req.Offset))
+}
+
+// exportHistory streams the user's whole spin history, newest first, as a CSV file with the date,
+// bet amount, win amount and reels of each spin. The rows are written while the spins are read and
+// flushed in batches, so the history is never held in memory. It honors the same from and to days
+// as history. Errors occurring before the first row is sent are returned as regular JSON error
+// responses; later ones can only cut the export short and are logged.
+//
+// @Summary Export spin history as CSV
+// @Description Streams the user's spin history, newest first, as CSV with a date,bet_amount,win_amount,reels header row.
+// @Description The reels are separated by spaces and empty for spins played before the reels were stored.
+// @Tags Slot
+// @Produce text/csv
+// @Param Authorization header string true "Bearer token"
+// @Param from query string false "First day of the history, YYYY-MM-DD in UTC"
+// @Param to query string false "Last day of the history, YYYY-MM-DD in UTC"
+// @Success 200 {string} string "CSV file of past spin results"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid dates"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client accepts neither JSON nor CSV"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/history.csv [get]
+func (c *SlotController) exportHistory(ctx *gin.Context) {
+	req := request.DateRangeRequest{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	userID := GetUserFromContext(ctx)
+	from, to := req.Bounds()
+
+	ctx.Header("Content-Type", server.MediaTypeCSV+"; charset=utf-8")
+	ctx.Header("Content-Disposition", `attachment; filename="spin-history.csv"`)
+	writer := csv.NewWriter(ctx.Writer)
+	err := writer.Write(response.SpinHistoryCSVHeader)
+	rows := 0
+	if err == nil {
+		err = c.slotService.ExportHistory(ctx.Request.Context(), userID, from, to, func(spin *models.Spin) error {
+			if err := writer.Write(response.SpinHistoryCSVRecord(spin)); err != nil {
+				return err
+			}
+			rows++
+			if rows%historyCSVFlushRows == 0 {
+				writer.Flush()
+				server.Flush(ctx)
+			}
+			return writer.Error()
+		})
+	}
+	if err != nil {
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Disposition")
+			ctx.Writer.Header().Del("Content-Type")
+			server.InternalErrorResponse(ctx, err.Error())
+			return
+		}
+		log.FromContext(ctx).Errorf("spin history export stopped after %d rows: %v", rows, err)
+		return
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.FromContext(ctx).Errorf("spin history export stopped after %d rows: %v", rows, err)
+	}
+}
+
+// stats retrieves the play statistics of the user from slotService.
+// If an error occurs, it responds with an internal server error message.
+//
+// @Summary Get player statistics
+// @Description Retrieves the user's total spins, total wagered, total won, net result, biggest win and win rate.
+// @Description Voided spins are not counted.
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} response.SpinStatsResponse "Play statistics of the user"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/stats [get]
+func (c *SlotController) stats(ctx *gin.Context) {
 	userID := GetUserFromContext(ctx)
-	history, err := c.slotService.History(ctx.Request.Context(), userID)
+	stats, err := c.slotService.Stats(ctx.Request.Context(), userID)
 	if err != nil {
 		server.InternalErrorResponse(ctx, err.Error())
 		return
 	}
-	server.SuccessResponse(ctx, response.SpinHistoryFromModels(history))
+	server.SuccessResponse(ctx, response.SpinStatsFromModel(stats))
+}
+
+// sessionSummaries retrieves a page of the summaries of the user's game sessions, newest first,
+// with the number of spins and the amounts wagered and won in each session.
+//
+// @Summary Get game sessions
+// @Description Retrieves a page of the user's game sessions, newest first, with the spins, bets and wins of each.
+// @Description A session ends on logout or login, or once the user has been inactive for the session timeout;
+// @Description the end of a session that timed out is only recorded when the next one starts.
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param limit query int false "Maximum number of sessions to return (default 20, max 100)"
+// @Param offset query int false "Number of sessions to skip"
+// @Success 200 {object} response.Page[response.SessionSummaryResponse] "Page of game session summaries"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid pagination parameters"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/sessions [get]
+func (c *SlotController) sessionSummaries(ctx *gin.Context) {
+	req := request.PageRequest{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	userID := GetUserFromContext(ctx)
+	// The summaries only read, so they may be served by the read replica
+	summaries, total, err := c.sessions.Summaries(database.WithReadOnly(ctx.Request.Context()), userID, req.GetLimit(), req.Offset)
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, response.NewPage(response.SessionSummariesFromModels(summaries), total, req.GetLimit(), req.Offset))
+}
+
+// acknowledgeRealityCheck acknowledges the reality check of the user's active game session, so that
+// the spins only carry the next one once the reality check interval has passed again.
+//
+// @Summary Acknowledge reality check
+// @Description Acknowledges the reality check of the active game session, reported by the spins once the reality check interval has passed.
+// @Description The spins carry the next reality check once the interval has passed since the acknowledgment.
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 204 "Reality check acknowledged"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/reality-check/ack [post]
+func (c *SlotController) acknowledgeRealityCheck(ctx *gin.Context) {
+	userID := GetUserFromContext(ctx)
+	if err := c.sessions.AcknowledgeRealityCheck(ctx.Request.Context(), userID); err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// leaderboard retrieves the top players by total winnings for the requested period
+// and returns them with anonymized display names.
+//
+// @Summary Get leaderboard
+// @Description Retrieves the top players by total winnings for the given period
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param period query string false "Aggregation period" Enums(day, week, all)
+// @Success 200 {object} response.Page[response.LeaderboardEntryResponse] "Top players ordered by total winnings"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid period"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/slot/leaderboard [get]
+func (c *SlotController) leaderboard(ctx *gin.Context) {
+	req := request.LeaderboardRequest{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	// The leaderboard only reads, so it may be served by the read replica
+	entries, err := c.slotService.Leaderboard(database.WithReadOnly(ctx.Request.Context()), req.Period)
+	if err != nil {
+		if errors.Is(err, serviceError.ErrInvalidPeriod) {
+			server.ErrorBadRequest(ctx, err)
+			return
+		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, response.NewPage(response.LeaderboardFromModels(entries), int64(len(entries)), c.appConfig.LeaderboardSize, 0))
+}
+
+// gameConfig returns the public configuration of a game: its symbols with their display metadata,
+// its paytable and the allowed bets, so that clients can render the game. The probabilities are
+// left out when they are configured to be hidden. The response carries an ETag and may be cached
+// for the configured time; a request sending the current ETag in If-None-Match is answered with 304.
+//
+// @Summary Get game configuration
+// @Description Returns the symbols with their display names and icons, the paytable and the allowed bets of a game.
+// @Description The response carries an ETag and a Cache-Control header; a matching If-None-Match header is answered with 304 Not Modified
+// @Tags Slot
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param game_id query string false "Game to describe; empty describes the default game"
+// @Param If-None-Match header string false "ETag of a cached response"
+// @Success 200 {object} response.GameConfigResponse "Public configuration of the game"
+// @Success 304 "Not modified - the cached response is still current"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 404 {object} server.ErrorResponseMessage "Not found - the game does not exist"
+// @Failure 406 {object} server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Security BearerAuth
+// @Router /api/slot/config [get]
+func (c *SlotController) gameConfig(ctx *gin.Context) {
+	gameID := ctx.Query("game_id")
+	game, ok := c.appConfig.Game(gameID)
+	if !ok {
+		server.NotFoundErrorResponse(ctx, serviceError.ErrGameNotFound)
+		return
+	}
+	if gameID == "" {
+		gameID = config.DefaultGameID
+	}
+	server.SuccessResponse(ctx, response.GameConfigFromConfig(gameID, game))
 }
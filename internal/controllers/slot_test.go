@@ -0,0 +1,665 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/dto/response"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/server"
+)
+
+// sseEvent is a server-sent event parsed from a response body.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// parseEvents splits a server-sent event stream into its events.
+func parseEvents(t *testing.T, body string) []sseEvent {
+	var events []sseEvent
+	var current sseEvent
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			current.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			current.data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case line == "" && current.name != "":
+			events = append(events, current)
+			current = sseEvent{}
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+// newStreamTestEngine serves the streaming spin, bulk spin, free spin and history export handlers for an authenticated user.
+func newStreamTestEngine(slotService *mocks.MockISlotService, userID uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{SpinRevealDelay: 0}, nil, slotService, nil, nil, nil)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), userID.String())
+	})
+	router.GET("/spin/stream", c.spinStream)
+	router.POST("/spin/stream", c.spinStream)
+	router.POST("/spin/bulk", c.bulkSpin)
+	router.POST("/spin/free", c.freeSpin)
+	router.GET("/history.csv", c.exportHistory)
+	return router
+}
+
+// newServerTestHandler serves the streaming spin and history export handlers for an authenticated
+// user on their API paths, through the engine and the HTTP server handler the application runs.
+func newServerTestHandler(slotService *mocks.MockISlotService, userID uuid.UUID) http.Handler {
+	gin.SetMode(gin.TestMode)
+	apiConfig := &server.APIConfig{RequestTimeout: 5}
+	c := NewSlotController(nil, &config.SlotConfig{SpinRevealDelay: 0}, nil, slotService, nil, nil, nil)
+	router := server.NewEngine(apiConfig, nil)
+	g := router.Group("/api/slot", func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), userID.String())
+	})
+	g.GET("/spin/stream", c.spinStream)
+	g.GET("/history.csv", c.exportHistory)
+	return server.NewServer(router, apiConfig).Handler
+}
+
+func TestSpinStream_FlushedThroughServerHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	balance := 95.0
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().RetrySpin(gomock.Any(), &userID, "", 5.0).Return(&models.Spin{
+		BetAmount: 5,
+		Reels:     []string{"A", "B", "C"},
+		Balance:   &balance,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/slot/spin/stream?bet_amount=5", nil)
+	rec := httptest.NewRecorder()
+	newServerTestHandler(slotService, userID).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	// The events reach the client as they are written instead of being buffered by the timeout handler
+	assert.True(t, rec.Flushed)
+	events := parseEvents(t, rec.Body.String())
+	require.Len(t, events, 4)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, EventReel, events[i].name)
+	}
+	assert.Equal(t, EventResult, events[3].name)
+}
+
+func TestSpinStream_EmitsReelsThenResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	balance := 115.0
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().RetrySpin(gomock.Any(), &userID, "", 5.0).Return(&models.Spin{
+		BetAmount: 5,
+		WinAmount: 20,
+		Reels:     []string{"A", "A", "B", "C"},
+		Balance:   &balance,
+	}, nil).Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/spin/stream?bet_amount=5", nil)
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(slotService, userID).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	events := parseEvents(t, rec.Body.String())
+	require.Len(t, events, 5)
+	for i, symbol := range []string{"A", "A", "B", "C"} {
+		assert.Equal(t, EventReel, events[i].name)
+		var reel response.ReelEvent
+		require.NoError(t, json.Unmarshal([]byte(events[i].data), &reel))
+		assert.Equal(t, response.ReelEvent{Reel: i, Symbol: symbol}, reel)
+	}
+	assert.Equal(t, EventResult, events[4].name)
+	var result response.SpinResponse
+	require.NoError(t, json.Unmarshal([]byte(events[4].data), &result))
+	assert.Equal(t, response.Money(20), result.WinAmount)
+	require.NotNil(t, result.Balance)
+	assert.Equal(t, response.Money(balance), *result.Balance)
+}
+
+func TestSpinStream_ErrorBeforeStreaming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().RetrySpin(gomock.Any(), &userID, "", 5.0).Return(nil, serviceError.ErrInsufficientFunds)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin/stream", strings.NewReader(`{"bet_amount":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(slotService, userID).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, parseEvents(t, rec.Body.String()))
+}
+
+func TestSpinStream_UnknownGame(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().RetrySpin(gomock.Any(), &userID, "fruits", 5.0).Return(nil, serviceError.ErrGameNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/spin/stream?bet_amount=5&game_id=fruits", nil)
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(slotService, userID).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), serviceError.CodeGameNotFound)
+}
+
+func TestBulkSpin_ReportsPartialBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	first, second := 15.0, 5.0
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().BulkSpin(gomock.Any(), &userID, "", 10.0, 5).Return(&models.BulkSpin{
+		Requested: 5,
+		Spins: []*models.Spin{
+			{BetAmount: 10, WinAmount: 20, Balance: &first},
+			{BetAmount: 10, WinAmount: 0, Balance: &second},
+		},
+		Stopped: serviceError.ErrInsufficientFunds,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin/bulk", strings.NewReader(`{"bet_amount":10,"count":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(slotService, userID).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res response.BulkSpinResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, res.Spins, 2)
+	assert.Equal(t, response.BulkSpinSummary{
+		Requested:    5,
+		Played:       2,
+		TotalWagered: 20,
+		TotalWon:     20,
+		Net:          0,
+		StoppedBy:    serviceError.CodeInsufficientFunds,
+	}, res.Summary)
+	require.NotNil(t, res.Balance)
+	assert.Equal(t, response.Money(second), *res.Balance)
+}
+
+func TestBulkSpin_RejectsDemoMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodPost, "/spin/bulk", strings.NewReader(`{"bet_amount":10,"count":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDemoMode, "true")
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(mocks.NewMockISlotService(ctrl), uuid.New()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestFreeSpin_PlaysRequestedGame(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	balance := 20.0
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().FreeSpin(gomock.Any(), &userID, "fruits").Return(&models.Spin{
+		GameID: "fruits", WinAmount: 20, Bonuses: []string{models.BonusFree}, Balance: &balance,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin/free?game_id=fruits", nil)
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(slotService, userID).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res response.SpinResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, 20.0, float64(res.WinAmount))
+	assert.Equal(t, []string{models.BonusFree}, res.Bonuses)
+}
+
+func TestFreeSpin_NoFreeSpinsLeft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().FreeSpin(gomock.Any(), &userID, "").Return(nil, serviceError.ErrNoFreeSpins)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin/free", nil)
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(slotService, userID).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body server.ErrorResponseMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, serviceError.CodeNoFreeSpins, body.Code)
+}
+
+func TestExportHistory_StreamsCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+	spins := []*models.Spin{
+		{BetAmount: 5, WinAmount: 20, Reels: models.Reels{"A", "A", "B"}},
+		{BetAmount: 2.5, WinAmount: 0},
+	}
+	spins[0].CreatedAt = time.Date(2024, 3, 2, 18, 30, 0, 0, time.UTC)
+	spins[1].CreatedAt = time.Date(2024, 3, 1, 9, 5, 7, 0, time.UTC)
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().ExportHistory(gomock.Any(), &userID, &from, &to, gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ *uuid.UUID, _, _ *time.Time, fn func(*models.Spin) error) error {
+			for _, spin := range spins {
+				if err := fn(spin); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/history.csv?from=2024-03-01&to=2024-03-02", nil)
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(slotService, userID).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "spin-history.csv")
+	records, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"date", "bet_amount", "win_amount", "reels"},
+		{"2024-03-02 18:30:00", "5.00", "20.00", "A A B"},
+		{"2024-03-01 09:05:07", "2.50", "0.00", ""},
+	}, records)
+}
+
+func TestExportHistory_FlushedThroughServerHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	rows := 2*historyCSVFlushRows + 1
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().ExportHistory(gomock.Any(), &userID, nil, nil, gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ *uuid.UUID, _, _ *time.Time, fn func(*models.Spin) error) error {
+			for i := 0; i < rows; i++ {
+				if err := fn(&models.Spin{BetAmount: 5, WinAmount: 10}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/slot/history.csv", nil)
+	rec := httptest.NewRecorder()
+	newServerTestHandler(slotService, userID).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	// The rows reach the client in batches instead of being buffered by the timeout handler
+	assert.True(t, rec.Flushed)
+	records, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, rows+1)
+}
+
+func TestExportHistory_ErrorBeforeFirstRow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().ExportHistory(gomock.Any(), &userID, nil, nil, gomock.Any()).Return(errors.New("database down"))
+
+	req := httptest.NewRequest(http.MethodGet, "/history.csv", nil)
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(slotService, userID).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	assert.Empty(t, rec.Header().Get("Content-Disposition"))
+}
+
+func TestExportHistory_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest(http.MethodGet, "/history.csv?from=2024-03-02&to=2024-03-01", nil)
+	rec := httptest.NewRecorder()
+	newStreamTestEngine(mocks.NewMockISlotService(ctrl), uuid.New()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGameConfig_ReturnsSymbolsAndPaytable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{
+		MultiplierTwo:         2,
+		MultiplierThree:       10,
+		TwoMatchProbability:   0.3,
+		ThreeMatchProbability: 0.05,
+		TwoMatchMultipliers:   map[string]float64{"W": 5},
+		Symbols:               []string{"A", "B"},
+		WildSymbol:            "W",
+		SymbolDisplays:        config.SymbolDisplays{"A": {Name: "Ace", Icon: "https://cdn.example.com/ace.png"}},
+		BetDenominations:      []float64{5, 1, 10},
+		BaseCurrency:          "USD",
+		HideProbabilities:     true,
+	}, nil, nil, nil, nil, nil)
+	router := gin.New()
+	router.GET("/config", c.gameConfig)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/config", nil))
+
+	wildMultiplier := 5.0
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "probability", "the probabilities are hidden")
+	var game response.GameConfigResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &game))
+	assert.Equal(t, config.DefaultGameID, game.GameID)
+	assert.Equal(t, 3, game.Reels)
+	assert.Equal(t, []response.GameSymbolResponse{
+		{Symbol: "A", Kind: response.SymbolKindRegular, Name: "Ace", Icon: "https://cdn.example.com/ace.png"},
+		{Symbol: "B", Kind: response.SymbolKindRegular, Name: "B"},
+		{Symbol: "W", Kind: response.SymbolKindWild, Name: "W", TwoMatchMultiplier: &wildMultiplier},
+	}, game.Symbols)
+	assert.Equal(t, []response.PaytableEntryResponse{
+		{Matches: 3, Multiplier: 10},
+		{Matches: 2, Multiplier: 2},
+	}, game.Paytable)
+	require.NotNil(t, game.MinBet)
+	require.NotNil(t, game.MaxBet)
+	assert.Equal(t, response.Money(1), *game.MinBet)
+	assert.Equal(t, response.Money(10), *game.MaxBet)
+	assert.Nil(t, game.Scatter)
+}
+
+func TestGameConfig_ShowsProbabilitiesOfRequestedGame(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{
+		Games: map[string]config.SlotConfig{
+			"fruits": {
+				Symbols:               []string{"CHERRY", "LEMON"},
+				MultiplierTwo:         3,
+				MultiplierThree:       20,
+				TwoMatchProbability:   0.25,
+				ThreeMatchProbability: 0.02,
+				ScatterSymbol:         "STAR",
+				ScatterProbability:    0.1,
+				ScatterMinCount:       3,
+				ScatterMultiplier:     5,
+			},
+		},
+	}, nil, nil, nil, nil, nil)
+	router := gin.New()
+	router.GET("/config", c.gameConfig)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/config?game_id=fruits", nil))
+
+	three, two, scatter := 0.02, 0.25, 0.1
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var game response.GameConfigResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &game))
+	assert.Equal(t, "fruits", game.GameID)
+	require.Len(t, game.Symbols, 3)
+	assert.Equal(t, response.SymbolKindScatter, game.Symbols[2].Kind)
+	assert.Equal(t, []response.PaytableEntryResponse{
+		{Matches: 3, Multiplier: 20, Probability: &three},
+		{Matches: 2, Multiplier: 3, Probability: &two},
+	}, game.Paytable)
+	require.NotNil(t, game.Scatter)
+	assert.Equal(t, &response.ScatterPayoutResponse{MinCount: 3, Multiplier: 5, Probability: &scatter}, game.Scatter)
+	assert.Nil(t, game.MinBet, "any bet is allowed")
+}
+
+func TestGameConfig_UnknownGame(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{}, nil, nil, nil, nil, nil)
+	router := gin.New()
+	router.GET("/config", c.gameConfig)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/config?game_id=fruits", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSpin_ForcedOutcomeHeaders(t *testing.T) {
+	win := 50.0
+	testCases := []struct {
+		name     string
+		enabled  bool
+		expected *models.ForcedOutcome
+	}{
+		{"Enabled", true, &models.ForcedOutcome{Reels: []string{"A", "A", "B"}, Win: &win}},
+		{"Disabled", false, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userID := uuid.New()
+			slotService := mocks.NewMockISlotService(ctrl)
+			slotService.EXPECT().RetrySpin(gomock.Any(), &userID, "", 5.0).DoAndReturn(
+				func(ctx context.Context, _ *uuid.UUID, _ string, _ float64) (*models.Spin, error) {
+					forced, _ := ctx.Value(constants.CtxFieldForcedOutcome).(*models.ForcedOutcome)
+					assert.Equal(t, tc.expected, forced)
+					return &models.Spin{BetAmount: 5}, nil
+				})
+
+			gin.SetMode(gin.TestMode)
+			c := NewSlotController(nil, &config.SlotConfig{ForcedOutcomes: tc.enabled}, nil, slotService, nil, nil, nil)
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) {
+				ctx.Set(string(constants.CtxFieldUserID), userID.String())
+			})
+			router.POST("/spin", c.spin)
+
+			req := httptest.NewRequest(http.MethodPost, "/spin", strings.NewReader(`{"bet_amount":5}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(HeaderForceReels, "A, A, B")
+			req.Header.Set(HeaderForceWin, "50")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestSpin_InvalidForcedWin(t *testing.T) {
+	// The spin is not played, so any call to the slot service fails the test
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{ForcedOutcomes: true}, nil, mocks.NewMockISlotService(ctrl), nil, nil, nil)
+	router := gin.New()
+	router.POST("/spin", c.spin)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin", strings.NewReader(`{"bet_amount":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderForceWin, "lots")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), serviceError.CodeInvalidForcedOutcome)
+}
+
+func TestSpin_InCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().SpinInCurrency(gomock.Any(), &userID, "", "eur", 9.99).Return(&models.Spin{
+		BetAmount: 10.79, WinAmount: 107.9, Currency: "EUR", ExchangeRate: 1.08, OriginalBetAmount: 9.99, OriginalWinAmount: 99.91,
+	}, nil)
+
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{AmountDecimals: 2}, nil, slotService, nil, nil, nil)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), userID.String())
+	})
+	router.POST("/spin", c.spin)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin", strings.NewReader(`{"bet_amount":9.99,"currency":"eur"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	body := rec.Body.String()
+	assert.Contains(t, body, `"win_amount":107.90`)
+	assert.Contains(t, body, `"currency":"EUR"`)
+	assert.Contains(t, body, `"exchange_rate":1.08`)
+	assert.Contains(t, body, `"original_bet_amount":9.99`)
+	assert.Contains(t, body, `"original_win_amount":99.91`)
+}
+
+func TestSpin_TooPreciseBet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The spin is never played, so a call to the slot service fails the test
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{AmountDecimals: 2}, nil, mocks.NewMockISlotService(ctrl), nil, nil, nil)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), uuid.NewString())
+	})
+	router.POST("/spin", c.spin)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin", strings.NewReader(`{"bet_amount":10.123456789}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "betamount::decimals::2")
+}
+
+func TestSpin_UnsupportedCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().SpinInCurrency(gomock.Any(), &userID, "", "JPY", 100.0).Return(nil, serviceError.ErrUnsupportedCurrency)
+
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{}, nil, slotService, nil, nil, nil)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), userID.String())
+	})
+	router.POST("/spin", c.spin)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin", strings.NewReader(`{"bet_amount":100,"currency":"JPY"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), serviceError.CodeUnsupportedCurrency)
+}
+
+func TestSpin_ReportsRealityCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	startedAt := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	slotService := mocks.NewMockISlotService(ctrl)
+	slotService.EXPECT().RetrySpin(gomock.Any(), &userID, "", 5.0).Return(&models.Spin{
+		BetAmount: 5,
+		RealityCheck: &models.RealityCheck{
+			SessionID: 7, StartedAt: startedAt, Duration: 61*time.Minute + 30*time.Second, TotalSpins: 40, TotalWagered: 200, TotalWon: 120.5,
+		},
+	}, nil)
+
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{}, nil, slotService, nil, nil, nil)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), userID.String())
+	})
+	router.POST("/spin", c.spin)
+
+	req := httptest.NewRequest(http.MethodPost, "/spin", strings.NewReader(`{"bet_amount":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	body := rec.Body.String()
+	assert.Contains(t, body, `"session_started_at":"2024-03-10T12:00:00Z"`)
+	assert.Contains(t, body, `"session_duration":3690`)
+	assert.Contains(t, body, `"total_spins":40`)
+	assert.Contains(t, body, `"net_loss":79.50`)
+}
+
+func TestAcknowledgeRealityCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	sessions := mocks.NewMockISessionService(ctrl)
+	sessions.EXPECT().AcknowledgeRealityCheck(gomock.Any(), &userID).Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	c := NewSlotController(nil, &config.SlotConfig{}, nil, nil, sessions, nil, nil)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), userID.String())
+	})
+	router.POST("/reality-check/ack", c.acknowledgeRealityCheck)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reality-check/ack", nil))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
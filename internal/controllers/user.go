@@ -2,22 +2,48 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	log "github.com/public-forge/go-logger"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/database"
 	"github.com/vadymlab/slot-game/internal/dto/request"
 	"github.com/vadymlab/slot-game/internal/dto/response"
 	serviceError "github.com/vadymlab/slot-game/internal/error"
 	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/middlewares"
+	"github.com/vadymlab/slot-game/internal/models"
 	"github.com/vadymlab/slot-game/internal/server"
 	mw "github.com/vadymlab/slot-game/internal/server/jwt"
 	"github.com/vadymlab/slot-game/internal/validators"
+	"net/http"
+	"time"
 )
 
+// HeaderIdempotencyKey is the HTTP header carrying the idempotency key of a registration.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// maxIdempotencyKeyLength is the maximum accepted length of an idempotency key.
+const maxIdempotencyKeyLength = 255
+
 // UserController manages user-related actions, including registration, login, and profile retrieval.
 // It connects to userService for core user operations and uses JWT authentication for protected routes.
 type UserController struct {
-	userService interfaces.IUserService // Service for managing user-related operations
-	config      *server.APIConfig       // API configuration with JWT settings
+	userService    interfaces.IUserService       // Service for managing user-related operations
+	config         *server.APIConfig             // API configuration with JWT settings
+	passwordPolicy *config.PasswordPolicy        // Password rules applied at registration
+	loginPolicy    *config.LoginPolicy           // Rules for the format of logins
+	loginGuard     interfaces.ILoginGuard        // Guard against replayed logins and credential stuffing
+	registrations  interfaces.IRegistrationGuard // Guard replaying retried registrations
+	walletService  interfaces.IWalletService     // Service reading the balances per currency
+	authAudit      interfaces.IAuthAuditService  // Audit log of logins, registrations and login changes
+	sessions       interfaces.ISessionService    // Service starting a game session on login and ending it on logout
+	tokens         interfaces.ITokenStore        // Active login sessions, limited per user
+	appConfig      *config.SlotConfig            // Slot configuration, including the rate limit of the authentication attempts
+	redisClient    *libredis.Client              // Redis client backing the rate limit of the authentication attempts
 }
 
 // NewUserController creates a new instance of UserController with the given userService and config.
@@ -25,19 +51,54 @@ type UserController struct {
 // Parameters:
 //   - userService: Implementation of IUserService for user business logic.
 //   - config: API configuration, including JWT settings.
+//   - passwordPolicy: Password rules applied at registration.
+//   - loginPolicy: Rules for the format of logins, such as whether usernames are accepted.
+//   - loginGuard: Guard locking logins after repeated failures and rejecting replayed nonces.
+//   - registrations: Guard replaying registrations retried with the same idempotency key.
+//   - walletService: Service reading the user's balances per currency.
+//   - authAudit: Audit log recording every login, registration and login change.
+//   - sessions: Service starting a game session on login and ending it on logout.
+//   - tokens: The store of the active login sessions, recording each issued token.
+//   - appConfig: Slot configuration, including the rate limit of the authentication attempts.
+//   - redisClient: Redis client backing the rate limit of the authentication attempts.
 //
 // Returns:
 //
 //	A pointer to UserController.
-func NewUserController(userService interfaces.IUserService, config *server.APIConfig) *UserController {
+func NewUserController(
+	userService interfaces.IUserService,
+	config *server.APIConfig,
+	passwordPolicy *config.PasswordPolicy,
+	loginPolicy *config.LoginPolicy,
+	loginGuard interfaces.ILoginGuard,
+	registrations interfaces.IRegistrationGuard,
+	walletService interfaces.IWalletService,
+	authAudit interfaces.IAuthAuditService,
+	sessions interfaces.ISessionService,
+	tokens interfaces.ITokenStore,
+	appConfig *config.SlotConfig,
+	redisClient *libredis.Client,
+) *UserController {
 	return &UserController{
-		userService: userService,
-		config:      config,
+		userService:    userService,
+		config:         config,
+		passwordPolicy: passwordPolicy,
+		loginPolicy:    loginPolicy,
+		loginGuard:     loginGuard,
+		registrations:  registrations,
+		walletService:  walletService,
+		authAudit:      authAudit,
+		sessions:       sessions,
+		tokens:         tokens,
+		appConfig:      appConfig,
+		redisClient:    redisClient,
 	}
 }
 
-// InitRoute initializes routes for user-related endpoints, including registration, login, and profile retrieval.
-// The profile endpoint is protected and requires JWT authentication.
+// InitRoute initializes routes for user-related endpoints, including registration, login, logout, profile retrieval,
+// login change, the play settings, the deposit limits, the self-exclusion and the security log. The profile endpoints are protected and require JWT authentication.
+// The endpoints reading a body reject bodies that are neither JSON nor XML with 415. Registration and
+// login attempts are throttled per client IP and per login by their own rate limit.
 //
 // Parameters:
 //   - route: A Gin RouterGroup to which user routes will be added.
@@ -46,9 +107,18 @@ func NewUserController(userService interfaces.IUserService, config *server.APICo
 //
 //	An updated RouterGroup with initialized user routes.
 func (c *UserController) InitRoute(route *gin.RouterGroup) *gin.RouterGroup {
-	route.POST("/register", c.register)
-	route.POST("/login", c.login)
-	route.GET("/profile", mw.AuthMiddleware(c.config.JWTSecret), c.profile)
+	authLimit := middlewares.NewAuthRateLimiter(c.appConfig, c.redisClient)
+	route.POST("/register", authLimit, server.RequireJSONOrXML(), c.register)
+	route.POST("/login", authLimit, server.RequireJSONOrXML(), c.login)
+	route.POST("/logout", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), c.logout)
+	route.GET("/profile", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), c.profile)
+	route.PATCH("/profile", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), server.RequireJSONOrXML(), c.updateProfile)
+	route.GET("/profile/settings", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), c.settings)
+	route.PUT("/profile/settings", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), server.RequireJSONOrXML(), c.updateSettings)
+	route.GET("/profile/deposit-limits", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), c.depositLimits)
+	route.PUT("/profile/deposit-limits", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), server.RequireJSONOrXML(), c.updateDepositLimits)
+	route.GET("/profile/security", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), c.security)
+	route.POST("/profile/self-exclude", mw.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens), server.RequireJSONOrXML(), c.selfExclude)
 	return route
 }
 
@@ -59,109 +129,531 @@ func (c *UserController) GetRoute() string {
 
 // register handles user registration by validating input, checking for existing users,
 // and creating a new user. If successful, it returns the user’s registration details.
+// A registration retried with the same Idempotency-Key header and credentials returns the
+// originally registered user instead of a conflict.
 //
 // @Summary Register a new user
-// @Description Allows a new user to register with their details
+// @Description Allows a new user to register with their details.
+// @Description A retry with the same Idempotency-Key header and credentials returns the originally registered user.
 // @Tags User
-// @Accept json
+// @Accept json,xml
 // @Produce json
+// @Param Idempotency-Key header string false "Key making retries of the registration safe"
 // @Param req body request.RegisterRequest true "Registration request body"
 // @Success 200 {object} response.RegisterResponse "User registered successfully"
-// @Failure 400 {string} string "Bad request due to invalid input"
-// @Failure 409 {string} string "Conflict - user already exists"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - user already exists or the idempotency key was used for a different registration"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 429 {object} server.ErrorResponseMessage "Too many requests - too many registration attempts from the client or for the login"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
 // @Router /api/register [post]
 func (c *UserController) register(ctx *gin.Context) {
 	req := request.RegisterRequest{}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBind(&req); err != nil {
 		log.FromContext(ctx).Error(err)
 		server.ErrorBadRequest(ctx, err)
 		return
 	}
+	// Stray spaces or capitals neither fail the validation nor create a second account
+	req.Login = models.NormalizeLogin(req.Login)
 	if errs := validators.Validate(req); errs != nil {
 		server.ErrorsBadRequest(ctx, errs)
 		return
 	}
-	user, err := c.userService.Register(ctx.Request.Context(), req.Login, req.Password)
+	if errs := validators.ValidateLogin(req.Login, c.loginPolicy); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	if errs := validators.ValidatePassword(req.Password, c.passwordPolicy); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	key := ctx.GetHeader(HeaderIdempotencyKey)
+	if len(key) > maxIdempotencyKeyLength {
+		server.ErrorBadRequest(ctx, fmt.Sprintf("%s must not exceed %d characters", HeaderIdempotencyKey, maxIdempotencyKeyLength))
+		return
+	}
+	user, err := c.registrations.Register(ctx.Request.Context(), key, req.Login, req.Password)
 	if err != nil {
-		if errors.As(err, &serviceError.UserAlreadyExists{}) {
-			server.ConflictErrorResponse(ctx, err.Error())
+		if errors.As(err, &serviceError.UserAlreadyExists{}) || errors.Is(err, serviceError.ErrIdempotencyKeyReused) {
+			c.recordAuthEvent(ctx, models.AuthEventTypeRegister, req.Login, nil, false)
+			server.ConflictErrorResponse(ctx, err)
 			return
 		}
 		server.InternalErrorResponse(ctx, err.Error())
 		return
 	}
+	c.recordAuthEvent(ctx, models.AuthEventTypeRegister, req.Login, &user.ID, true)
 	server.SuccessResponse(ctx, response.RegisterFromModel(user))
 }
 
 // login authenticates a user by validating credentials and generating a JWT token if successful.
-// Returns a token upon successful authentication; otherwise, returns an error.
+// A successful login starts a new game session and records the token as a login session; over the
+// login session limit, either the oldest session is ended or the login is rejected. Returns a token
+// upon successful authentication; otherwise, returns an error.
 //
 // @Summary Login user
 // @Description Authenticates a user and returns a JWT token
 // @Tags User
-// @Accept json
+// @Accept json,xml
 // @Produce json
 // @Param req body request.LoginRequest true "Login request body"
-// @Success 200 {object} map[string]string "Token for authenticated user"
-// @Failure 400 {string} string "Bad request due to invalid input or incorrect login details"
-// @Failure 500 {string} string "Internal server error"
+// @Success 200 {object} response.LoginResponse "Token for authenticated user"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input or incorrect login details"
+// @Failure 403 {object} server.ErrorResponseMessage "Forbidden - the account is frozen"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - nonce has already been used, or too many active login sessions"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 423 {object} server.ErrorResponseMessage "Locked - too many failed login attempts"
+// @Failure 429 {object} server.ErrorResponseMessage "Too many requests - too many login attempts from the client or for the login"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
 // @Router /api/login [post]
 func (c *UserController) login(ctx *gin.Context) {
 	req := request.LoginRequest{}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBind(&req); err != nil {
 		log.FromContext(ctx).Error(err)
 		server.ErrorBadRequest(ctx, err)
 		return
 	}
+	req.Login = models.NormalizeLogin(req.Login)
 	if errs := validators.Validate(req); errs != nil {
 		server.ErrorsBadRequest(ctx, errs)
 		return
 	}
+	if errs := validators.ValidateLogin(req.Login, c.loginPolicy); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	if err := c.loginGuard.Check(ctx.Request.Context(), req.Login, req.Nonce); err != nil {
+		c.recordAuthEvent(ctx, models.AuthEventTypeLogin, req.Login, nil, false)
+		if errors.Is(err, serviceError.ErrNonceReused) {
+			server.ConflictErrorResponse(ctx, err)
+			return
+		}
+		server.LockedErrorResponse(ctx, err)
+		return
+	}
 	usr, err := c.userService.Login(ctx.Request.Context(), req.Login, req.Password)
 	if err != nil {
-		if errors.Is(err, &serviceError.UserNotFound{}) {
+		if errors.Is(err, serviceError.ErrUserNotFound) || errors.Is(err, serviceError.ErrInvalidPass) {
+			c.recordAuthEvent(ctx, models.AuthEventTypeLogin, req.Login, nil, false)
+			if lockErr := c.loginGuard.RecordFailure(ctx.Request.Context(), req.Login); lockErr != nil {
+				server.LockedErrorResponse(ctx, lockErr)
+				return
+			}
 			server.ErrorBadRequest(ctx, err)
 			return
 		}
-		if errors.Is(err, &serviceError.InvalidPassword{}) {
-			server.ErrorBadRequest(ctx, err)
+		if errors.Is(err, serviceError.ErrAccountFrozen) {
+			c.recordAuthEvent(ctx, models.AuthEventTypeLogin, req.Login, nil, false)
+			server.ForbiddenErrorResponse(ctx, err)
 			return
 		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
 	}
-	token, err := mw.GenerateToken(usr.ExternalID, c.config.JWTSecret, c.config.JWTSecretLifeTime)
+	c.loginGuard.RecordSuccess(ctx.Request.Context(), req.Login)
+	token, tokenID, err := mw.GenerateToken(usr.ExternalID, c.config.JWTSecret, c.config.JWTSecretLifeTime)
 	if err != nil {
 		server.InternalErrorResponse(ctx, err.Error())
 		return
 	}
-	server.SuccessResponse(ctx, gin.H{"token": "Bearer " + token})
+	if !c.addToken(ctx, usr.ExternalID, tokenID) {
+		c.recordAuthEvent(ctx, models.AuthEventTypeLogin, req.Login, &usr.ID, false)
+		server.ConflictErrorResponse(ctx, serviceError.ErrTooManySessions)
+		return
+	}
+	c.recordAuthEvent(ctx, models.AuthEventTypeLogin, req.Login, &usr.ID, true)
+	// Session tracking only feeds analytics, so it never fails the login
+	if _, err := c.sessions.Start(ctx.Request.Context(), usr.ExternalID); err != nil {
+		log.FromContext(ctx).Warnf("failed to start game session: %v", err)
+	}
+	server.SuccessResponse(ctx, response.LoginResponse{Token: "Bearer " + token})
+}
+
+// addToken records the token issued by a login as an active login session of the user. A store
+// that cannot be reached never fails the login.
+//
+// Returns:
+//
+//	False if the user already has the maximum number of active login sessions and new logins are rejected.
+func (c *UserController) addToken(ctx *gin.Context, userID *uuid.UUID, tokenID string) bool {
+	if c.tokens == nil {
+		return true
+	}
+	ttl := time.Duration(c.config.JWTSecretLifeTime)*time.Minute + time.Duration(c.config.JWTLeeway)*time.Second
+	added, err := c.tokens.Add(ctx.Request.Context(), userID, tokenID, ttl)
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to record the login session: %v", err)
+		return true
+	}
+	return added
 }
 
-// profile retrieves the profile details of the authenticated user, including the user's ID, login, and balance.
+// logout ends the active game session of the authenticated user and the login session of the
+// token, freeing its slot when the number of login sessions is limited. Without the limit, the JWT
+// token stays valid until it expires; a later spin starts a new game session.
+//
+// @Summary Logout user
+// @Description Ends the active game session and the login session of the authenticated user
+// @Tags User
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 204 "Game session ended"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/logout [post]
+func (c *UserController) logout(ctx *gin.Context) {
+	userID := GetUserFromContext(ctx)
+	if err := c.sessions.End(ctx.Request.Context(), userID); err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	if c.tokens != nil {
+		if err := c.tokens.Remove(ctx.Request.Context(), userID, ctx.GetString(string(constants.CtxFieldTokenID))); err != nil {
+			server.InternalErrorResponse(ctx, err.Error())
+			return
+		}
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// profile retrieves the profile details of the authenticated user, including the user's ID, login, and
+// balances. The balance field holds the base currency balance, the balances field lists all currencies.
 // This endpoint requires JWT authentication.
 //
 // @Summary Get user profile
-// @Description Retrieves the profile and balance of the authenticated user
+// @Description Retrieves the profile and the balances per currency of the authenticated user
 // @Tags User
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Success 200 {object} response.ProfileResponse "User profile information"
-// @Failure 401 {string} string "Unauthorized - user not authenticated"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
 // @Security BearerAuth
 // @Router /api/profile [get]
 func (c *UserController) profile(ctx *gin.Context) {
 	uUID := GetUserFromContext(ctx)
-	user, err := c.userService.GetByExternalID(ctx.Request.Context(), uUID)
+	// The profile only reads, so it may be served by the read replica
+	user, err := c.userService.GetByExternalID(database.WithReadOnly(ctx.Request.Context()), uUID)
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	c.profileResponse(ctx, user)
+}
+
+// updateProfile changes the login of the authenticated user after confirming the current password
+// and returns the updated profile. This endpoint requires JWT authentication.
+//
+// @Summary Change user login
+// @Description Changes the login of the authenticated user; the current password is required
+// @Tags User
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param req body request.UpdateLoginRequest true "Login change request body"
+// @Success 200 {object} response.ProfileResponse "Updated user profile"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid input or incorrect password"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 409 {object} server.ErrorResponseMessage "Conflict - login already taken"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/profile [patch]
+func (c *UserController) updateProfile(ctx *gin.Context) {
+	req := request.UpdateLoginRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	req.Login = models.NormalizeLogin(req.Login)
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	if errs := validators.ValidateLogin(req.Login, c.loginPolicy); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	uUID := GetUserFromContext(ctx)
+	user, err := c.userService.UpdateLogin(ctx.Request.Context(), uUID, req.Login, req.Password)
+	if err != nil {
+		if errors.Is(err, serviceError.ErrUserExists) {
+			c.recordLoginChangeFailure(ctx, uUID, req.Login)
+			server.ConflictErrorResponse(ctx, err)
+			return
+		}
+		if errors.Is(err, serviceError.ErrInvalidPass) {
+			c.recordLoginChangeFailure(ctx, uUID, req.Login)
+			server.ErrorBadRequest(ctx, err)
+			return
+		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	c.recordAuthEvent(ctx, models.AuthEventTypeLoginChange, req.Login, &user.ID, true)
+	c.profileResponse(ctx, user)
+}
+
+// settings retrieves the play settings of the authenticated user. This endpoint requires JWT authentication.
+//
+// @Summary Get play settings
+// @Description Retrieves the play settings of the authenticated user, such as the auto-stop threshold
+// @Tags User
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} response.SettingsResponse "Play settings"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 404 {object} server.ErrorResponseMessage "User not found"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/profile/settings [get]
+func (c *UserController) settings(ctx *gin.Context) {
+	user, err := c.userService.GetByExternalID(ctx.Request.Context(), GetUserFromContext(ctx))
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	if user == nil {
+		server.NotFoundErrorResponse(ctx, serviceError.ErrUserNotFound)
+		return
+	}
+	server.SuccessResponse(ctx, response.SettingsFromModel(user))
+}
+
+// updateSettings replaces the play settings of the authenticated user and returns them.
+// Spins winning more than the auto-stop threshold are flagged with should_stop, telling the
+// client to stop spinning. This endpoint requires JWT authentication.
+//
+// @Summary Change play settings
+// @Description Replaces the play settings of the authenticated user. A spin winning more than auto_stop_win
+// @Description is flagged with should_stop; null falls back to the server default and 0 disables the auto-stop
+// @Tags User
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param req body request.UpdateSettingsRequest true "Play settings request body"
+// @Success 200 {object} response.SettingsResponse "Updated play settings"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to a negative threshold"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 404 {object} server.ErrorResponseMessage "User not found"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/profile/settings [put]
+func (c *UserController) updateSettings(ctx *gin.Context) {
+	req := request.UpdateSettingsRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	user, err := c.userService.UpdateSettings(ctx.Request.Context(), GetUserFromContext(ctx), req.AutoStopWin)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceError.ErrInvalidAmount):
+			server.ErrorBadRequest(ctx, err)
+		case errors.Is(err, serviceError.ErrUserNotFound):
+			server.NotFoundErrorResponse(ctx, err)
+		default:
+			server.InternalErrorResponse(ctx, err.Error())
+		}
+		return
+	}
+	server.SuccessResponse(ctx, response.SettingsFromModel(user))
+}
+
+// depositLimits retrieves the deposit limits of the authenticated user. This endpoint requires JWT authentication.
+//
+// @Summary Get deposit limits
+// @Description Retrieves the per-transaction and daily deposit limits in effect for the authenticated user,
+// @Description along with raised or removed limits still waiting out the cooldown
+// @Tags User
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} response.DepositLimitsResponse "Deposit limits"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 404 {object} server.ErrorResponseMessage "User not found"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/profile/deposit-limits [get]
+func (c *UserController) depositLimits(ctx *gin.Context) {
+	user, err := c.userService.GetByExternalID(ctx.Request.Context(), GetUserFromContext(ctx))
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	if user == nil {
+		server.NotFoundErrorResponse(ctx, serviceError.ErrUserNotFound)
+		return
+	}
+	server.SuccessResponse(ctx, response.DepositLimitsFromModel(user, time.Now()))
+}
+
+// updateDepositLimits replaces the deposit limits of the authenticated user and returns them. Lowered
+// limits apply right away; raised or removed limits only apply once the cooldown has passed.
+// This endpoint requires JWT authentication.
+//
+// @Summary Change deposit limits
+// @Description Replaces the per-transaction and daily deposit limits of the authenticated user; null removes a limit.
+// @Description Lowered limits apply right away, raised or removed limits once the configured cooldown has passed
+// @Tags User
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param req body request.UpdateDepositLimitsRequest true "Deposit limits request body"
+// @Success 200 {object} response.DepositLimitsResponse "Updated deposit limits"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to a limit that is not positive"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 404 {object} server.ErrorResponseMessage "User not found"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/profile/deposit-limits [put]
+func (c *UserController) updateDepositLimits(ctx *gin.Context) {
+	req := request.UpdateDepositLimitsRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	limits := models.DepositLimits{PerTransaction: req.PerTransaction, Daily: req.Daily}
+	user, err := c.userService.SetDepositLimits(ctx.Request.Context(), GetUserFromContext(ctx), limits)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceError.ErrInvalidAmount):
+			server.ErrorBadRequest(ctx, err)
+		case errors.Is(err, serviceError.ErrUserNotFound):
+			server.NotFoundErrorResponse(ctx, err)
+		default:
+			server.InternalErrorResponse(ctx, err.Error())
+		}
+		return
+	}
+	server.SuccessResponse(ctx, response.DepositLimitsFromModel(user, time.Now()))
+}
+
+// selfExclude excludes the authenticated user from spinning and depositing for the requested number
+// of days and returns the end of the exclusion. A running exclusion can be extended but not ended or
+// shortened early; a shorter period keeps the running exclusion. This endpoint requires JWT authentication.
+//
+// @Summary Self-exclude from play
+// @Description Excludes the authenticated user from spinning and depositing for the requested number of days.
+// @Description The exclusion lifts by itself once it ends; it cannot be reversed early, and a shorter period keeps the running exclusion
+// @Tags User
+// @Accept json,xml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param req body request.SelfExcludeRequest true "Self-exclusion request body"
+// @Success 200 {object} response.SelfExclusionResponse "End of the self-exclusion"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to an invalid number of days"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 404 {object} server.ErrorResponseMessage "User not found"
+// @Failure 415 {object} server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/profile/self-exclude [post]
+func (c *UserController) selfExclude(ctx *gin.Context) {
+	req := request.SelfExcludeRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	period := time.Duration(req.Days) * 24 * time.Hour
+	user, err := c.userService.SelfExclude(ctx.Request.Context(), GetUserFromContext(ctx), period)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceError.ErrInvalidPeriod):
+			server.ErrorBadRequest(ctx, err)
+		case errors.Is(err, serviceError.ErrUserNotFound):
+			server.NotFoundErrorResponse(ctx, err)
+		default:
+			server.InternalErrorResponse(ctx, err.Error())
+		}
+		return
+	}
+	server.SuccessResponse(ctx, response.SelfExclusionResponse{ExcludedUntil: user.ExcludedUntil})
+}
+
+// security retrieves a page of the authentication events of the authenticated user, newest first,
+// so that users can spot logins they did not make. This endpoint requires JWT authentication.
+//
+// @Summary Get security log
+// @Description Retrieves a page of the logins, registration and login changes of the authenticated user, newest first,
+// @Description including failed attempts, with the IP address and user agent they were made from
+// @Tags User
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param limit query int false "Maximum number of events to return (default 20, max 100)"
+// @Param offset query int false "Number of events to skip"
+// @Success 200 {object} response.Page[response.AuthEventResponse] "Page of authentication events"
+// @Failure 400 {object} server.ErrorResponseMessage "Bad request due to invalid pagination parameters"
+// @Failure 401 {object} server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure 500 {object} server.ErrorResponseMessage "Internal server error"
+// @Security BearerAuth
+// @Router /api/profile/security [get]
+func (c *UserController) security(ctx *gin.Context) {
+	req := request.PageRequest{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	uUID := GetUserFromContext(ctx)
+	events, total, err := c.authAudit.Events(ctx.Request.Context(), uUID, req.GetLimit(), req.Offset)
 	if err != nil {
 		server.InternalErrorResponse(ctx, err.Error())
 		return
 	}
-	responseDto := response.ProfileResponse{
-		ID:      user.ExternalID,
-		Login:   user.Login,
-		Balance: user.Balance,
+	server.SuccessResponse(ctx, response.NewPage(response.AuthEventsFromModels(events), total, req.GetLimit(), req.Offset))
+}
+
+// recordAuthEvent records an authentication event with the client IP address, user agent and
+// trace ID of the request. Events without a user are attributed to the user holding the login.
+func (c *UserController) recordAuthEvent(ctx *gin.Context, eventType, login string, userID *uint, success bool) {
+	c.authAudit.Record(ctx.Request.Context(), &models.AuthEvent{
+		UserID:    userID,
+		Login:     login,
+		Type:      eventType,
+		Success:   success,
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+		TraceID:   ctx.GetString(string(constants.CtxFieldTraceID)),
+	})
+}
+
+// recordLoginChangeFailure records a rejected login change of the authenticated user. The event is
+// attributed by the user's ID, as the requested login may belong to someone else.
+func (c *UserController) recordLoginChangeFailure(ctx *gin.Context, userID *uuid.UUID, login string) {
+	user, err := c.userService.GetByExternalID(ctx.Request.Context(), userID)
+	if err != nil {
+		log.FromContext(ctx).Warnw("failed to resolve user for the auth event", "error", err)
+		return
+	}
+	c.recordAuthEvent(ctx, models.AuthEventTypeLoginChange, login, &user.ID, false)
+}
+
+// profileResponse responds with the profile of the user, including their balances per currency.
+func (c *UserController) profileResponse(ctx *gin.Context, user *models.User) {
+	balances, err := c.walletService.GetBalances(ctx.Request.Context(), user.ExternalID)
+	if err != nil {
+		server.InternalErrorResponse(ctx, err.Error())
+		return
 	}
-	server.SuccessResponse(ctx, responseDto)
+	server.SuccessResponse(ctx, response.ProfileFromModel(user, balances))
 }
@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/dto/response"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/server"
+	mw "github.com/vadymlab/slot-game/internal/server/jwt"
+)
+
+// userControllerMocks holds the mocked dependencies of a UserController under test.
+type userControllerMocks struct {
+	userService   *mocks.MockIUserService
+	loginGuard    *mocks.MockILoginGuard
+	registrations *mocks.MockIRegistrationGuard
+	authAudit     *mocks.MockIAuthAuditService
+	sessions      *mocks.MockISessionService
+	tokens        *mocks.MockITokenStore
+}
+
+// newUserTestEngine serves the user routes with the given login policy.
+func newUserTestEngine(ctrl *gomock.Controller, loginPolicy *config.LoginPolicy) (*gin.Engine, *userControllerMocks) {
+	gin.SetMode(gin.TestMode)
+	m := &userControllerMocks{
+		userService:   mocks.NewMockIUserService(ctrl),
+		loginGuard:    mocks.NewMockILoginGuard(ctrl),
+		registrations: mocks.NewMockIRegistrationGuard(ctrl),
+		authAudit:     mocks.NewMockIAuthAuditService(ctrl),
+		sessions:      mocks.NewMockISessionService(ctrl),
+		tokens:        mocks.NewMockITokenStore(ctrl),
+	}
+	c := NewUserController(m.userService, &server.APIConfig{JWTSecret: "secret", JWTSecretLifeTime: 5},
+		&config.PasswordPolicy{}, loginPolicy, m.loginGuard, m.registrations, nil, m.authAudit, m.sessions, m.tokens, &config.SlotConfig{}, nil)
+	router := gin.New()
+	c.InitRoute(router.Group(""))
+	return router, m
+}
+
+// post sends a JSON body to the router.
+func post(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "slot-client/1.0")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRegisterAndLogin_WithUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, m := newUserTestEngine(ctrl, &config.LoginPolicy{AllowUsernames: true})
+
+	userID := uuid.New()
+	user := &models.User{ExternalID: &userID, Login: "lucky_player"}
+	m.registrations.EXPECT().Register(gomock.Any(), "", "lucky_player", "s3cret-pass").Return(user, nil)
+	m.authAudit.EXPECT().Record(gomock.Any(), gomock.Any()).Times(2)
+
+	rec := post(router, "/register", `{"login":"lucky_player","password":"s3cret-pass"}`)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var registered response.RegisterResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &registered))
+	assert.Equal(t, "lucky_player", registered.Login)
+
+	m.loginGuard.EXPECT().Check(gomock.Any(), "lucky_player", "").Return(nil)
+	m.userService.EXPECT().Login(gomock.Any(), "lucky_player", "s3cret-pass").Return(user, nil)
+	m.loginGuard.EXPECT().RecordSuccess(gomock.Any(), "lucky_player")
+	m.tokens.EXPECT().Add(gomock.Any(), &userID, gomock.Any(), 5*time.Minute).Return(true, nil)
+	m.sessions.EXPECT().Start(gomock.Any(), &userID).Return(&models.Session{}, nil)
+
+	rec = post(router, "/login", `{"login":"lucky_player","password":"s3cret-pass"}`)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var login response.LoginResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &login))
+	assert.True(t, strings.HasPrefix(login.Token, "Bearer "))
+}
+
+func TestRegisterAndLogin_NormalizesLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, m := newUserTestEngine(ctrl, &config.LoginPolicy{})
+
+	userID := uuid.New()
+	user := &models.User{ExternalID: &userID, Login: "user@example.com"}
+	// The guards, the audit log and the services all see the normalized login
+	m.registrations.EXPECT().Register(gomock.Any(), "", "user@example.com", "s3cret-pass").Return(user, nil)
+	m.authAudit.EXPECT().Record(gomock.Any(), gomock.Any()).Do(func(_ context.Context, event *models.AuthEvent) {
+		assert.Equal(t, "user@example.com", event.Login)
+	}).Times(2)
+
+	rec := post(router, "/register", `{"login":" User@Example.com ","password":"s3cret-pass"}`)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	m.loginGuard.EXPECT().Check(gomock.Any(), "user@example.com", "").Return(nil)
+	m.userService.EXPECT().Login(gomock.Any(), "user@example.com", "s3cret-pass").Return(user, nil)
+	m.loginGuard.EXPECT().RecordSuccess(gomock.Any(), "user@example.com")
+	m.tokens.EXPECT().Add(gomock.Any(), &userID, gomock.Any(), 5*time.Minute).Return(true, nil)
+	m.sessions.EXPECT().Start(gomock.Any(), &userID).Return(&models.Session{}, nil)
+
+	rec = post(router, "/login", `{"login":"USER@example.COM  ","password":"s3cret-pass"}`)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestRegister_UsernameRejectedByDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, _ := newUserTestEngine(ctrl, &config.LoginPolicy{})
+
+	rec := post(router, "/register", `{"login":"lucky_player","password":"s3cret-pass"}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "login::email::")
+}
+
+func TestRegister_RequiresJSONBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, m := newUserTestEngine(ctrl, &config.LoginPolicy{})
+
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader("login=player%40example.com&password=s3cret-pass"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	body := &server.ErrorResponseMessage{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeUnsupportedMediaType, body.Code)
+
+	userID := uuid.New()
+	m.registrations.EXPECT().Register(gomock.Any(), "", "player@example.com", "s3cret-pass").
+		Return(&models.User{ExternalID: &userID, Login: "player@example.com"}, nil)
+	m.authAudit.EXPECT().Record(gomock.Any(), gomock.Any())
+
+	rec = post(router, "/register", `{"login":"player@example.com","password":"s3cret-pass"}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestLogin_FailureRecordsAuthEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, m := newUserTestEngine(ctrl, &config.LoginPolicy{})
+
+	m.loginGuard.EXPECT().Check(gomock.Any(), "player@example.com", "").Return(nil)
+	m.userService.EXPECT().Login(gomock.Any(), "player@example.com", "wrong-pass").Return(nil, serviceError.ErrInvalidPass)
+	m.loginGuard.EXPECT().RecordFailure(gomock.Any(), "player@example.com").Return(nil)
+	var recorded *models.AuthEvent
+	m.authAudit.EXPECT().Record(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *models.AuthEvent) { recorded = event })
+
+	rec := post(router, "/login", `{"login":"player@example.com","password":"wrong-pass"}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	require.NotNil(t, recorded)
+	assert.Equal(t, models.AuthEventTypeLogin, recorded.Type)
+	assert.Equal(t, "player@example.com", recorded.Login)
+	assert.False(t, recorded.Success)
+	assert.Nil(t, recorded.UserID)
+	assert.Equal(t, "192.0.2.1", recorded.IP)
+	assert.Equal(t, "slot-client/1.0", recorded.UserAgent)
+}
+
+func TestLogin_RejectedOverSessionLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, m := newUserTestEngine(ctrl, &config.LoginPolicy{})
+
+	userID := uuid.New()
+	user := &models.User{ExternalID: &userID, Login: "player@example.com"}
+	m.loginGuard.EXPECT().Check(gomock.Any(), "player@example.com", "").Return(nil)
+	m.userService.EXPECT().Login(gomock.Any(), "player@example.com", "s3cret-pass").Return(user, nil)
+	m.loginGuard.EXPECT().RecordSuccess(gomock.Any(), "player@example.com")
+	// The store rejects the token instead of ending the oldest session, so no game session starts
+	m.tokens.EXPECT().Add(gomock.Any(), &userID, gomock.Any(), 5*time.Minute).Return(false, nil)
+	var recorded *models.AuthEvent
+	m.authAudit.EXPECT().Record(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *models.AuthEvent) { recorded = event })
+
+	rec := post(router, "/login", `{"login":"player@example.com","password":"s3cret-pass"}`)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	body := &server.ErrorResponseMessage{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeTooManySessions, body.Code)
+	require.NotNil(t, recorded)
+	assert.False(t, recorded.Success)
+}
+
+func TestProfile_EndedSessionRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, m := newUserTestEngine(ctrl, &config.LoginPolicy{})
+
+	userID := uuid.New()
+	token, tokenID, err := mw.GenerateToken(&userID, "secret", 5)
+	require.NoError(t, err)
+	// A newer login over the session limit has ended the session of the token
+	m.tokens.EXPECT().Active(gomock.Any(), &userID, tokenID).Return(false, nil)
+	req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	body := &server.ErrorResponseMessage{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, []string{mw.ErrTokenRevoked.Error()}, body.Errors)
+}
+
+func TestLogout_EndsLoginSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, m := newUserTestEngine(ctrl, &config.LoginPolicy{})
+
+	userID := uuid.New()
+	token, tokenID, err := mw.GenerateToken(&userID, "secret", 5)
+	require.NoError(t, err)
+	m.tokens.EXPECT().Active(gomock.Any(), &userID, tokenID).Return(true, nil)
+	m.sessions.EXPECT().End(gomock.Any(), &userID).Return(nil)
+	m.tokens.EXPECT().Remove(gomock.Any(), &userID, tokenID).Return(nil)
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+}
+
+func TestProfile_TokenSubjectNotUUID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router, _ := newUserTestEngine(ctrl, &config.LoginPolicy{})
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		Subject:   "not-a-uuid",
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	body := &server.ErrorResponseMessage{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeUnauthorized, body.Code)
+}
+
+func TestGetUserFromContext_SubjectNotUUID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/profile", nil)
+	ctx.Set(string(constants.CtxFieldUserID), "not-a-uuid")
+
+	assert.Nil(t, GetUserFromContext(ctx))
+	assert.True(t, ctx.IsAborted())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	body := &server.ErrorResponseMessage{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeUnauthorized, body.Code)
+	assert.Equal(t, []string{mw.ErrInvalidTokenSubject.Error()}, body.Errors)
+}
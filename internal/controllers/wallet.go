@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/config"
 	"github.com/vadymlab/slot-game/internal/dto/request"
 	"github.com/vadymlab/slot-game/internal/dto/response"
 	error2 "github.com/vadymlab/slot-game/internal/error"
@@ -13,30 +14,55 @@ import (
 	"github.com/vadymlab/slot-game/internal/validators"
 )
 
-// WalletController manages wallet-related operations, including depositing and withdrawing funds.
+// WalletController manages wallet-related operations, including depositing, withdrawing and transferring funds.
 type WalletController struct {
-	config      *server.APIConfig       // API configuration settings, including JWT secret
-	userService interfaces.IUserService // Service for user-related operations
+	config            *server.APIConfig             // API configuration settings, including JWT secret
+	appConfig         *config.SlotConfig            // Game configuration, including whether withdrawals await approval
+	userService       interfaces.IUserService       // Service for user-related operations
+	walletService     interfaces.IWalletService     // Service reading the balances per currency
+	withdrawalService interfaces.IWithdrawalService // Service holding withdrawals until an admin approves them
+	maintenance       interfaces.IMaintenanceStore  // Toggle putting the wallet endpoints into maintenance mode
+	tokens            interfaces.ITokenStore        // Active login sessions the JWT tokens must belong to
 }
 
-// NewWalletController creates a new instance of WalletController with the provided API configuration and user service.
+// NewWalletController creates a new instance of WalletController with the provided configuration and services.
 //
 // Parameters:
 //   - config: A pointer to the API configuration struct, including JWT settings.
+//   - appConfig: A pointer to the slot configuration, deciding whether withdrawals await approval.
 //   - userService: Implementation of IUserService for managing user wallet operations.
+//   - walletService: Implementation of IWalletService reading the balances per currency.
+//   - withdrawalService: Implementation of IWithdrawalService for withdrawals awaiting approval.
+//   - maintenance: The maintenance mode toggle admins switch on during deploys and incidents.
+//   - tokens: The store of the active login sessions, rejecting tokens of ended sessions.
 //
 // Returns:
 //
 //	A pointer to WalletController.
-func NewWalletController(config *server.APIConfig, userService interfaces.IUserService) *WalletController {
+func NewWalletController(
+	config *server.APIConfig,
+	appConfig *config.SlotConfig,
+	userService interfaces.IUserService,
+	walletService interfaces.IWalletService,
+	withdrawalService interfaces.IWithdrawalService,
+	maintenance interfaces.IMaintenanceStore,
+	tokens interfaces.ITokenStore,
+) *WalletController {
 	return &WalletController{
-		config:      config,
-		userService: userService,
+		config:            config,
+		appConfig:         appConfig,
+		userService:       userService,
+		walletService:     walletService,
+		withdrawalService: withdrawalService,
+		maintenance:       maintenance,
+		tokens:            tokens,
 	}
 }
 
 // InitRoute initializes wallet-related routes within the provided router group,
-// including deposit and withdraw endpoints, both protected by JWT authentication middleware.
+// including the balance, deposit, withdraw and transfer endpoints, all protected by JWT authentication middleware.
+// The endpoints only produce JSON and reject other Accept headers with 406, and only read JSON
+// or XML bodies, rejecting other Content-Types with 415. In maintenance mode, they are answered with 503.
 //
 // Parameters:
 //   - route: A Gin RouterGroup to which wallet routes will be added.
@@ -45,9 +71,11 @@ func NewWalletController(config *server.APIConfig, userService interfaces.IUserS
 //
 //	An updated RouterGroup with initialized wallet routes.
 func (c *WalletController) InitRoute(route *gin.RouterGroup) *gin.RouterGroup {
-	g := route.Group("/wallet", jwt.AuthMiddleware(c.config.JWTSecret))
-	g.POST("/deposit", c.deposit)
-	g.POST("/withdraw", c.withdraw)
+	g := route.Group("/wallet", server.Maintenance(c.config, c.maintenance), server.AcceptJSON(), jwt.AuthMiddleware(c.config.JWTSecret, c.config.JWTLeeway, c.tokens))
+	g.GET("/balance", c.balance)
+	g.POST("/deposit", server.RequireJSONOrXML(), c.deposit)
+	g.POST("/withdraw", server.RequireJSONOrXML(), c.withdraw)
+	g.POST("/transfer", server.RequireJSONOrXML(), c.transfer)
 	return route
 }
 
@@ -56,24 +84,67 @@ func (c *WalletController) GetRoute() string {
 	return "/api"
 }
 
+// balance reads the user's balance in a single currency, without the rest of the profile, for
+// clients polling the balance.
+//
+// @Summary      Get wallet balance
+// @Description  Returns the balance of the user in a single currency, the base currency by default.
+// @Description  A currency the user holds no wallet in has a balance of 0
+// @Tags         Wallet
+// @Produce      json
+// @Param        Authorization  header    string  true   "JWT Token"  format(bearer)
+// @Param        currency       query     string  false  "ISO 4217 code of the currency, the base currency by default"
+// @Success      200            {object}  response.BalanceResponse "Balance in the currency"
+// @Failure      400            {object}  server.ErrorResponseMessage "Invalid currency code"
+// @Failure      401            {object}  server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure      404            {object}  server.ErrorResponseMessage "User not found"
+// @Failure      406            {object}  server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure      500            {object}  server.ErrorResponseMessage "Internal server error"
+// @Security     BearerAuth
+// @Router       /api/wallet/balance [get]
+func (c *WalletController) balance(ctx *gin.Context) {
+	req := request.BalanceRequest{}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	balance, err := c.walletService.GetBalance(ctx.Request.Context(), GetUserFromContext(ctx), req.Currency)
+	if err != nil {
+		if errors.Is(err, error2.ErrUserNotFound) {
+			server.NotFoundErrorResponse(ctx, err)
+			return
+		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	server.SuccessResponse(ctx, &response.BalanceResponse{Currency: balance.Currency, Amount: response.Money(balance.Amount)})
+}
+
 // deposit handles fund deposits to the user's wallet.
 //
 // @Summary      Deposit funds into wallet
-// @Description  Allows the user to deposit funds into their wallet
+// @Description  Allows the user to deposit funds into their wallet, optionally applying a promo code bonus
 // @Tags         Wallet
-// @Accept       json
+// @Accept       json,xml
 // @Produce      json
 // @Param        Authorization  header    string              true  "JWT Token"                    format(bearer)
 // @Param        data           body      request.DepositRequest true  "Deposit amount"
 // @Success      200            {object}  response.DepositResponse "Updated wallet balance"
-// @Failure      400            {string}  string "Invalid request payload"
-// @Failure      401            {string}  string "Unauthorized - user not authenticated"
-// @Failure      500            {string}  string "Internal server error"
+// @Failure      400            {object}  server.ErrorResponseMessage "Invalid request payload or promo code, or the deposit exceeds the deposit limits"
+// @Failure      401            {object}  server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure      403            {object}  server.ErrorResponseMessage "Forbidden - the user is self-excluded from play or the account is frozen"
+// @Failure      406            {object}  server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure      415            {object}  server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure      500            {object}  server.ErrorResponseMessage "Internal server error"
 // @Security     BearerAuth
 // @Router       /api/wallet/deposit [post]
 func (c *WalletController) deposit(ctx *gin.Context) {
 	req := request.DepositRequest{}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBind(&req); err != nil {
 		log.FromContext(ctx).Error(err)
 		server.ErrorBadRequest(ctx, err)
 		return
@@ -82,18 +153,30 @@ func (c *WalletController) deposit(ctx *gin.Context) {
 		server.ErrorsBadRequest(ctx, errs)
 		return
 	}
+	if errs := validators.ValidateAmount("amount", req.Amount, c.appConfig.AmountDecimals); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
 	userID := GetUserFromContext(ctx)
-	balance, err := c.userService.Deposit(ctx.Request.Context(), userID, req.Amount)
+	balance, bonus, err := c.userService.DepositWithPromo(ctx.Request.Context(), userID, req.Amount, req.PromoCode)
 	if err != nil {
-		if errors.Is(err, error2.ErrInvalidAmount) || errors.Is(err, error2.ErrInsufficientFunds) {
+		if errors.Is(err, error2.ErrInvalidAmount) || errors.Is(err, error2.ErrInsufficientFunds) || errors.Is(err, error2.ErrDepositLimitExceeded) || isPromoError(err) {
 			server.ErrorBadRequest(ctx, err)
 			return
 		}
+		if errors.Is(err, error2.ErrSelfExcluded) || errors.Is(err, error2.ErrAccountFrozen) {
+			server.ForbiddenErrorResponse(ctx, err)
+			return
+		}
 		server.InternalErrorResponse(ctx, err.Error())
 		return
 	}
+	if balanceMissing(ctx, balance) {
+		return
+	}
 	responseDto := response.DepositResponse{
-		Balance: *balance,
+		Balance: response.Money(*balance),
+		Bonus:   response.Money(bonus),
 	}
 	server.SuccessResponse(ctx, responseDto)
 }
@@ -101,21 +184,27 @@ func (c *WalletController) deposit(ctx *gin.Context) {
 // withdraw handles fund withdrawals from the user's wallet.
 //
 // @Summary      Withdraw funds from wallet
-// @Description  Allows the user to withdraw funds from their wallet
+// @Description  Allows the user to withdraw funds from their wallet.
+// @Description  When withdrawals await admin approval, the amount is held as a pending withdrawal and the response is 202;
+// @Description  held funds leave the spendable balance until the withdrawal is rejected.
 // @Tags         Wallet
-// @Accept       json
+// @Accept       json,xml
 // @Produce      json
 // @Param        Authorization  header    string                true  "JWT Token"                    format(bearer)
 // @Param        data           body      request.WithdrawRequest true  "Withdraw amount"
 // @Success      200            {object}  response.WithdrawResponse "Updated wallet balance"
-// @Failure      400            {string}  string "Invalid request payload"
-// @Failure      401            {string}  string "Unauthorized - user not authenticated"
-// @Failure      500            {string}  string "Internal server error"
+// @Success      202            {object}  response.WithdrawResponse "Updated wallet balance and the pending withdrawal"
+// @Failure      400            {object}  server.ErrorResponseMessage "Invalid request payload or insufficient funds"
+// @Failure      401            {object}  server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure      403            {object}  server.ErrorResponseMessage "Forbidden - the account is too new to withdraw or is frozen"
+// @Failure      406            {object}  server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure      415            {object}  server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure      500            {object}  server.ErrorResponseMessage "Internal server error"
 // @Security     BearerAuth
 // @Router       /api/wallet/withdraw [post]
 func (c *WalletController) withdraw(ctx *gin.Context) {
 	req := request.WithdrawRequest{}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBind(&req); err != nil {
 		log.FromContext(ctx).Error(err)
 		server.ErrorBadRequest(ctx, err)
 		return
@@ -124,14 +213,143 @@ func (c *WalletController) withdraw(ctx *gin.Context) {
 		server.ErrorsBadRequest(ctx, errs)
 		return
 	}
+	if errs := validators.ValidateAmount("amount", req.Amount, c.appConfig.AmountDecimals); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
 	userID := GetUserFromContext(ctx)
+	if c.appConfig.WithdrawalApproval {
+		c.requestWithdrawal(ctx, req.Amount)
+		return
+	}
 	balance, err := c.userService.Withdraw(ctx.Request.Context(), userID, req.Amount)
 	if err != nil {
+		if errors.Is(err, error2.ErrInvalidAmount) || errors.Is(err, error2.ErrInsufficientFunds) {
+			server.ErrorBadRequest(ctx, err)
+			return
+		}
+		if errors.Is(err, error2.ErrWithdrawalNotAllowedYet) || errors.Is(err, error2.ErrAccountFrozen) {
+			server.ForbiddenErrorResponse(ctx, err)
+			return
+		}
 		server.InternalErrorResponse(ctx, err.Error())
 		return
 	}
+	if balanceMissing(ctx, balance) {
+		return
+	}
 	responseDto := response.WithdrawResponse{
-		Balance: *balance,
+		Balance: response.Money(*balance),
+	}
+	server.SuccessResponse(ctx, responseDto)
+}
+
+// transfer handles fund transfers from the user's wallet to another user's wallet.
+//
+// @Summary      Transfer funds to another user
+// @Description  Debits the user's wallet and credits the recipient's wallet in a single transaction,
+// @Description  recording both sides in the ledger. Transfers to oneself are rejected
+// @Tags         Wallet
+// @Accept       json,xml
+// @Produce      json
+// @Param        Authorization  header    string                  true  "JWT Token"                    format(bearer)
+// @Param        data           body      request.TransferRequest true  "Recipient and amount"
+// @Success      200            {object}  response.TransferResponse "Updated wallet balance of the sender"
+// @Failure      400            {object}  server.ErrorResponseMessage "Invalid request payload, self-transfer or insufficient funds"
+// @Failure      401            {object}  server.ErrorResponseMessage "Unauthorized - user not authenticated"
+// @Failure      403            {object}  server.ErrorResponseMessage "Forbidden - the account is frozen, or the recipient's account is frozen or self-excluded"
+// @Failure      404            {object}  server.ErrorResponseMessage "Recipient not found"
+// @Failure      406            {object}  server.ErrorResponseMessage "Not acceptable - the client does not accept JSON"
+// @Failure      415            {object}  server.ErrorResponseMessage "Unsupported media type - the request body is neither JSON nor XML"
+// @Failure      500            {object}  server.ErrorResponseMessage "Internal server error"
+// @Security     BearerAuth
+// @Router       /api/wallet/transfer [post]
+func (c *WalletController) transfer(ctx *gin.Context) {
+	req := request.TransferRequest{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.FromContext(ctx).Error(err)
+		server.ErrorBadRequest(ctx, err)
+		return
+	}
+	if errs := validators.Validate(req); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	if errs := validators.ValidateAmount("amount", req.Amount, c.appConfig.AmountDecimals); errs != nil {
+		server.ErrorsBadRequest(ctx, errs)
+		return
+	}
+	balance, err := c.userService.Transfer(ctx.Request.Context(), GetUserFromContext(ctx), &req.RecipientID, req.Amount)
+	if err != nil {
+		if errors.Is(err, error2.ErrInvalidAmount) || errors.Is(err, error2.ErrInsufficientFunds) || errors.Is(err, error2.ErrSelfTransfer) {
+			server.ErrorBadRequest(ctx, err)
+			return
+		}
+		if errors.Is(err, error2.ErrAccountFrozen) || errors.Is(err, error2.ErrRecipientUnavailable) {
+			server.ForbiddenErrorResponse(ctx, err)
+			return
+		}
+		if errors.Is(err, error2.ErrRecipientNotFound) || errors.Is(err, error2.ErrUserNotFound) {
+			server.NotFoundErrorResponse(ctx, err)
+			return
+		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	if balanceMissing(ctx, balance) {
+		return
+	}
+	responseDto := response.TransferResponse{
+		Balance:     response.Money(*balance),
+		Amount:      response.Money(req.Amount),
+		RecipientID: req.RecipientID.String(),
 	}
 	server.SuccessResponse(ctx, responseDto)
 }
+
+// requestWithdrawal holds the amount as a pending withdrawal that awaits admin approval.
+func (c *WalletController) requestWithdrawal(ctx *gin.Context, amount float64) {
+	withdrawal, balance, err := c.withdrawalService.Request(ctx.Request.Context(), GetUserFromContext(ctx), amount)
+	if err != nil {
+		if errors.Is(err, error2.ErrInvalidAmount) || errors.Is(err, error2.ErrInsufficientFunds) {
+			server.ErrorBadRequest(ctx, err)
+			return
+		}
+		if errors.Is(err, error2.ErrWithdrawalNotAllowedYet) || errors.Is(err, error2.ErrAccountFrozen) {
+			server.ForbiddenErrorResponse(ctx, err)
+			return
+		}
+		server.InternalErrorResponse(ctx, err.Error())
+		return
+	}
+	if balanceMissing(ctx, balance) {
+		return
+	}
+	responseDto := response.WithdrawResponse{
+		Balance:    response.Money(*balance),
+		Withdrawal: response.WithdrawalFromModel(withdrawal),
+	}
+	server.AcceptedResponse(ctx, responseDto)
+}
+
+// balanceMissing responds with 500 Internal Server Error if a wallet operation reported success
+// without the resulting balance, instead of dereferencing the nil balance.
+//
+// Returns:
+//
+//	true if the balance is missing and the response has been written.
+func balanceMissing(ctx *gin.Context, balance *float64) bool {
+	if balance != nil {
+		return false
+	}
+	server.InternalErrorResponse(ctx, "wallet operation succeeded without returning the balance")
+	return true
+}
+
+// isPromoError reports whether err is caused by a promo code that cannot be applied.
+func isPromoError(err error) bool {
+	return errors.Is(err, error2.ErrPromoNotFound) ||
+		errors.Is(err, error2.ErrPromoExpired) ||
+		errors.Is(err, error2.ErrPromoLimitReached) ||
+		errors.Is(err, error2.ErrPromoMinDeposit)
+}
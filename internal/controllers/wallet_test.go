@@ -0,0 +1,383 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/dto/response"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/server"
+)
+
+// newWalletTestEngine serves the deposit, withdraw and transfer handlers for an authenticated user.
+func newWalletTestEngine(
+	appConfig *config.SlotConfig,
+	userService *mocks.MockIUserService,
+	withdrawalService *mocks.MockIWithdrawalService,
+	userID uuid.UUID,
+) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	c := NewWalletController(nil, appConfig, userService, nil, withdrawalService, nil, nil)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set(string(constants.CtxFieldUserID), userID.String())
+	})
+	router.POST("/deposit", server.RequireJSONOrXML(), c.deposit)
+	router.POST("/withdraw", server.RequireJSONOrXML(), c.withdraw)
+	router.POST("/transfer", server.RequireJSONOrXML(), c.transfer)
+	return router
+}
+
+// postBody sends a body with the given Content-Type to the router.
+func postBody(router *gin.Engine, path, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDeposit_BindsJSONAndXMLBodies(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"JSON", "application/json", `{"amount":25.5,"promo_code":"WELCOME"}`},
+		{"XML", "application/xml", `<DepositRequest><amount>25.5</amount><promo_code>WELCOME</promo_code></DepositRequest>`},
+		{"TextXMLWithCharset", "text/xml; charset=utf-8", `<?xml version="1.0" encoding="UTF-8"?><deposit><amount>25.5</amount><promo_code>WELCOME</promo_code></deposit>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userID := uuid.New()
+			balance := 125.5
+			userService := mocks.NewMockIUserService(ctrl)
+			userService.EXPECT().DepositWithPromo(gomock.Any(), &userID, 25.5, "WELCOME").Return(&balance, 5.0, nil)
+			router := newWalletTestEngine(&config.SlotConfig{AmountDecimals: 2}, userService, nil, userID)
+
+			rec := postBody(router, "/deposit", tc.contentType, tc.body)
+
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.JSONEq(t, `{"balance":125.50,"bonus":5.00}`, rec.Body.String())
+		})
+	}
+}
+
+func TestDeposit_InvalidXMLBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The service is never called, so DepositWithPromo fails the test
+	router := newWalletTestEngine(&config.SlotConfig{}, mocks.NewMockIUserService(ctrl), nil, uuid.New())
+
+	rec := postBody(router, "/deposit", "application/xml", `<DepositRequest><amount>lots</amount>`)
+
+	body := &server.ErrorResponseMessage{}
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeBadRequest, body.Code)
+}
+
+func TestDeposit_SelfExcluded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().DepositWithPromo(gomock.Any(), &userID, 25.0, "").Return(nil, 0.0, serviceError.ErrSelfExcluded)
+	router := newWalletTestEngine(&config.SlotConfig{}, userService, nil, userID)
+
+	rec := postBody(router, "/deposit", "application/json", `{"amount":25}`)
+
+	body := &server.ErrorResponseMessage{}
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeSelfExcluded, body.Code)
+}
+
+func TestWallet_AccountFrozen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID, recipientID := uuid.New(), uuid.New()
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().DepositWithPromo(gomock.Any(), &userID, 25.0, "").Return(nil, 0.0, serviceError.ErrAccountFrozen)
+	userService.EXPECT().Withdraw(gomock.Any(), &userID, 25.0).Return(nil, serviceError.ErrAccountFrozen)
+	userService.EXPECT().Transfer(gomock.Any(), &userID, &recipientID, 25.0).Return(nil, serviceError.ErrAccountFrozen)
+	router := newWalletTestEngine(&config.SlotConfig{}, userService, nil, userID)
+
+	for path, reqBody := range map[string]string{
+		"/deposit":  `{"amount":25}`,
+		"/withdraw": `{"amount":25}`,
+		"/transfer": `{"amount":25,"recipient_id":"` + recipientID.String() + `"}`,
+	} {
+		rec := postBody(router, path, "application/json", reqBody)
+
+		body := &server.ErrorResponseMessage{}
+		assert.Equal(t, http.StatusForbidden, rec.Code, path)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+		assert.Equal(t, serviceError.CodeAccountFrozen, body.Code, path)
+	}
+}
+
+func TestDeposit_DepositLimitExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().DepositWithPromo(gomock.Any(), &userID, 25.0, "").Return(nil, 0.0, serviceError.ErrDepositLimitExceeded)
+	router := newWalletTestEngine(&config.SlotConfig{}, userService, nil, userID)
+
+	rec := postBody(router, "/deposit", "application/json", `{"amount":25}`)
+
+	body := &server.ErrorResponseMessage{}
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeDepositLimitExceeded, body.Code)
+}
+
+func TestDeposit_TooPreciseAmount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The deposit is never made, so DepositWithPromo fails the test
+	router := newWalletTestEngine(&config.SlotConfig{AmountDecimals: 2}, mocks.NewMockIUserService(ctrl), nil, uuid.New())
+
+	rec := postBody(router, "/deposit", "application/json", `{"amount":25.001}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "amount::decimals::2")
+}
+
+func TestBalance_SelectsCurrency(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		currency string
+		expected string
+	}{
+		{"BaseCurrency", "", "", `{"currency":"USD","amount":100.00}`},
+		{"Currency", "?currency=eur", "eur", `{"currency":"EUR","amount":25.50}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userID := uuid.New()
+			walletService := mocks.NewMockIWalletService(ctrl)
+			balance := &models.Balance{Currency: "USD", Amount: 100}
+			if tc.currency != "" {
+				balance = &models.Balance{Currency: "EUR", Amount: 25.5}
+			}
+			walletService.EXPECT().GetBalance(gomock.Any(), &userID, tc.currency).Return(balance, nil)
+			gin.SetMode(gin.TestMode)
+			c := NewWalletController(nil, &config.SlotConfig{}, nil, walletService, nil, nil, nil)
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) { ctx.Set(string(constants.CtxFieldUserID), userID.String()) })
+			router.GET("/balance", c.balance)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/balance"+tc.query, nil))
+
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.JSONEq(t, tc.expected, rec.Body.String())
+		})
+	}
+}
+
+func TestBalance_InvalidCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The service is never called, so GetBalance fails the test
+	c := NewWalletController(nil, &config.SlotConfig{}, nil, mocks.NewMockIWalletService(ctrl), nil, nil, nil)
+	router := gin.New()
+	router.GET("/balance", c.balance)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/balance?currency=EURO", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWithdraw_HeldForApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	balance := 70.0
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	// The balance is not withdrawn directly, so Withdraw fails the test
+	userService := mocks.NewMockIUserService(ctrl)
+	withdrawalService := mocks.NewMockIWithdrawalService(ctrl)
+	withdrawalService.EXPECT().Request(gomock.Any(), &userID, 30.0).Return(&models.PendingWithdrawal{
+		Model:  gorm.Model{ID: 5, CreatedAt: createdAt},
+		UserID: 1,
+		Amount: 30,
+		Status: models.WithdrawalStatusPending,
+	}, &balance, nil)
+	router := newWalletTestEngine(&config.SlotConfig{WithdrawalApproval: true}, userService, withdrawalService, userID)
+
+	rec := postBody(router, "/withdraw", "application/json", `{"amount":30}`)
+
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var withdrawn response.WithdrawResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &withdrawn))
+	assert.Equal(t, response.Money(70), withdrawn.Balance)
+	require.NotNil(t, withdrawn.Withdrawal)
+	assert.Equal(t, uint(5), withdrawn.Withdrawal.ID)
+	assert.Equal(t, models.WithdrawalStatusPending, withdrawn.Withdrawal.Status)
+	assert.Equal(t, "2024-03-01 12:00:00", withdrawn.Withdrawal.CreatedAt)
+}
+
+func TestWithdraw_AccountTooNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().Withdraw(gomock.Any(), &userID, 30.0).Return(nil, serviceError.ErrWithdrawalNotAllowedYet)
+	router := newWalletTestEngine(&config.SlotConfig{MinWithdrawalAge: 72}, userService, nil, userID)
+
+	rec := postBody(router, "/withdraw", "application/json", `{"amount":30}`)
+
+	body := &server.ErrorResponseMessage{}
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeWithdrawalNotAllowedYet, body.Code)
+}
+
+func TestWithdraw_InsufficientFundsShortfall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().Withdraw(gomock.Any(), &userID, 50.0).Return(nil, serviceError.NewFundsShortfall(20, 50))
+	router := newWalletTestEngine(&config.SlotConfig{ShortfallDetails: true}, userService, nil, userID)
+
+	rec := postBody(router, "/withdraw", "application/json", `{"amount":50}`)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"balance":20.00,"shortfall":30.00`)
+	body := &server.ErrorResponseMessage{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeInsufficientFunds, body.Code)
+}
+
+func TestTransfer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID, recipientID := uuid.New(), uuid.New()
+	balance := 70.0
+	userService := mocks.NewMockIUserService(ctrl)
+	userService.EXPECT().Transfer(gomock.Any(), &userID, &recipientID, 30.0).Return(&balance, nil)
+	router := newWalletTestEngine(&config.SlotConfig{}, userService, nil, userID)
+
+	rec := postBody(router, "/transfer", "application/json", `{"amount":30,"recipient_id":"`+recipientID.String()+`"}`)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"balance":70.00,"amount":30.00,"recipient_id":"`+recipientID.String()+`"}`, rec.Body.String())
+}
+
+func TestTransfer_InvalidRecipient(t *testing.T) {
+	userID := uuid.New()
+	router := newWalletTestEngine(&config.SlotConfig{}, nil, nil, userID)
+
+	rec := postBody(router, "/transfer", "application/json", `{"amount":30,"recipient_id":"not-a-uuid"}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestTransfer_Errors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"SelfTransfer", serviceError.ErrSelfTransfer, http.StatusBadRequest, serviceError.CodeSelfTransfer},
+		{"InsufficientFunds", serviceError.ErrInsufficientFunds, http.StatusBadRequest, serviceError.CodeInsufficientFunds},
+		{"RecipientNotFound", serviceError.ErrRecipientNotFound, http.StatusNotFound, serviceError.CodeRecipientNotFound},
+		{"RecipientUnavailable", serviceError.ErrRecipientUnavailable, http.StatusForbidden, serviceError.CodeRecipientUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userID, recipientID := uuid.New(), uuid.New()
+			userService := mocks.NewMockIUserService(ctrl)
+			userService.EXPECT().Transfer(gomock.Any(), &userID, &recipientID, 30.0).Return(nil, tc.err)
+			router := newWalletTestEngine(&config.SlotConfig{}, userService, nil, userID)
+
+			rec := postBody(router, "/transfer", "application/json", `{"amount":30,"recipient_id":"`+recipientID.String()+`"}`)
+
+			body := &server.ErrorResponseMessage{}
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+			assert.Equal(t, tc.wantCode, body.Code)
+		})
+	}
+}
+
+func TestWallet_NilBalance(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		body   string
+		expect func(userService *mocks.MockIUserService, userID uuid.UUID)
+	}{
+		{"Deposit", "/deposit", `{"amount":25}`, func(userService *mocks.MockIUserService, userID uuid.UUID) {
+			userService.EXPECT().DepositWithPromo(gomock.Any(), &userID, 25.0, "").Return(nil, 0.0, nil)
+		}},
+		{"Withdraw", "/withdraw", `{"amount":25}`, func(userService *mocks.MockIUserService, userID uuid.UUID) {
+			userService.EXPECT().Withdraw(gomock.Any(), &userID, 25.0).Return(nil, nil)
+		}},
+		{"Transfer", "/transfer", `{"recipient_id":"` + uuid.NewString() + `","amount":25}`, func(userService *mocks.MockIUserService, userID uuid.UUID) {
+			userService.EXPECT().Transfer(gomock.Any(), &userID, gomock.Any(), 25.0).Return(nil, nil)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userID := uuid.New()
+			userService := mocks.NewMockIUserService(ctrl)
+			tc.expect(userService, userID)
+			router := newWalletTestEngine(&config.SlotConfig{}, userService, nil, userID)
+
+			rec := postBody(router, tc.path, "application/json", tc.body)
+
+			body := &server.ErrorResponseMessage{}
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+			assert.Equal(t, serviceError.CodeInternal, body.Code)
+		})
+	}
+}
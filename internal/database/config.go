@@ -1,22 +1,49 @@
 package database
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/public-forge/go-gorm-unit-of-work/postgres"
 	"github.com/urfave/cli/v2"
 )
 
 // Constants for PostgreSQL database configuration parameters.
 const (
-	postgresUser                  = "postgres-user"           // Database username
-	postgresPassword              = "postgres-password"       // Database user password
-	postgresDb                    = "postgres-db"             // Database name
-	postgresSchema                = "postgres-schema"         // Database schema
-	postgresLogMode               = "postgres-log-mode"       // Query logging mode
-	postgresHost                  = "postgres-host"           // Database host
-	postgresConnectionMaxLifeTime = "postgres-max-life-time"  // Maximum connection lifetime in milliseconds
-	postgresMaxOpenConnection     = "postgres-max-connection" // Maximum number of open connections
+	postgresUser                  = "postgres-user"                // Database username
+	postgresPassword              = "postgres-password"            // Database user password
+	postgresDb                    = "postgres-db"                  // Database name
+	postgresSchema                = "postgres-schema"              // Database schema
+	postgresLogMode               = "postgres-log-mode"            // Query logging mode
+	postgresHost                  = "postgres-host"                // Database host
+	postgresConnectionMaxLifeTime = "postgres-max-life-time"       // Maximum connection lifetime in milliseconds
+	postgresMaxOpenConnection     = "postgres-max-connection"      // Maximum number of open connections
+	postgresMaxIdleConnection     = "postgres-max-idle-connection" // Maximum number of idle connections
+	postgresConnectionMaxIdleTime = "postgres-max-idle-time"       // Maximum idle time of a connection in milliseconds
+	autoMigrate                   = "auto-migrate"                 // Apply pending migrations on startup
 )
 
+// MigrationConfig holds the settings controlling when schema migrations are applied.
+type MigrationConfig struct {
+	AutoMigrate bool // Apply pending migrations on startup; otherwise they are applied by the migrate command only
+}
+
+// GetMigrationConfig reads the migration settings from the CLI context.
+//
+// Parameters:
+//   - c: The CLI context from which configuration values are read.
+//
+// Returns:
+//
+//	A pointer to a MigrationConfig struct.
+func GetMigrationConfig(c *cli.Context) *MigrationConfig {
+	return &MigrationConfig{
+		AutoMigrate: c.Bool(autoMigrate),
+	}
+}
+
 // GetPostgresConfig creates and returns a PgConfig structure containing PostgreSQL
 // configuration settings for establishing database connections.
 //
@@ -39,6 +66,64 @@ func GetPostgresConfig(c *cli.Context) *postgres.PgConfig {
 	}
 }
 
+// PoolConfig holds the connection pool settings not covered by postgres.PgConfig.
+type PoolConfig struct {
+	MaxOpenConnections int // Maximum number of open connections; 0 means unlimited
+	MaxIdleConnections int // Maximum number of idle connections kept in the pool
+	ConnMaxIdleTimeMS  int // Maximum time in milliseconds a connection may stay idle; 0 means no limit
+}
+
+// GetPoolConfig reads the connection pool settings from the CLI context and validates them.
+//
+// Parameters:
+//   - c: The CLI context from which configuration values are read.
+//
+// Returns:
+//
+//	A pointer to a PoolConfig struct, or an error if the settings are invalid.
+func GetPoolConfig(c *cli.Context) (*PoolConfig, error) {
+	cfg := &PoolConfig{
+		MaxOpenConnections: c.Int(postgresMaxOpenConnection),
+		MaxIdleConnections: c.Int(postgresMaxIdleConnection),
+		ConnMaxIdleTimeMS:  c.Int(postgresConnectionMaxIdleTime),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that the pool settings are not negative and that no more connections are kept
+// idle than may be open.
+//
+// Returns:
+//
+//	An error listing every invalid setting, or nil if the configuration is valid.
+func (c *PoolConfig) Validate() error {
+	var errs []error
+	if c.MaxIdleConnections < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", postgresMaxIdleConnection, c.MaxIdleConnections))
+	}
+	if c.ConnMaxIdleTimeMS < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", postgresConnectionMaxIdleTime, c.ConnMaxIdleTimeMS))
+	}
+	if c.MaxOpenConnections > 0 && c.MaxIdleConnections > c.MaxOpenConnections {
+		errs = append(errs, fmt.Errorf("%s must not exceed %s, got %d > %d",
+			postgresMaxIdleConnection, postgresMaxOpenConnection, c.MaxIdleConnections, c.MaxOpenConnections))
+	}
+	return errors.Join(errs...)
+}
+
+// Apply sets the idle connection limits of the pool. The open connection limit and the
+// connection lifetime are applied by postgres.Open from postgres.PgConfig.
+//
+// Parameters:
+//   - db: The connection pool behind the GORM instance.
+func (c *PoolConfig) Apply(db *sql.DB) {
+	db.SetMaxIdleConns(c.MaxIdleConnections)
+	db.SetConnMaxIdleTime(time.Duration(c.ConnMaxIdleTimeMS) * time.Millisecond)
+}
+
 // DatabaseFlags defines CLI flags for configuring PostgreSQL connections.
 // These flags allow database connection settings to be specified via
 // command-line arguments or environment variables.
@@ -85,10 +170,28 @@ var DatabaseFlags = []cli.Flag{
 		Usage:   "Maximum number of open PostgreSQL connections",
 		EnvVars: []string{"POSTGRES_MAX_OPEN_CONNECTION"},
 	},
+	&cli.IntFlag{
+		Name:    postgresMaxIdleConnection,
+		Value:   10,
+		Usage:   "Maximum number of idle PostgreSQL connections kept in the pool; must not exceed the maximum number of open connections",
+		EnvVars: []string{"POSTGRES_MAX_IDLE_CONNECTION"},
+	},
+	&cli.IntFlag{
+		Name:    postgresConnectionMaxIdleTime,
+		Value:   0,
+		Usage:   "Maximum time in milliseconds a PostgreSQL connection may stay idle before it is closed; 0 keeps idle connections open",
+		EnvVars: []string{"POSTGRES_CONNECTION_MAX_IDLE_TIME"},
+	},
 	&cli.BoolFlag{
 		Name:    postgresLogMode,
 		Value:   true,
 		Usage:   "Enable or disable query logging in PostgreSQL",
 		EnvVars: []string{"POSTGRES_QUERY_LOGGING"},
 	},
+	&cli.BoolFlag{
+		Name:    autoMigrate,
+		Value:   false,
+		Usage:   "Apply pending schema migrations on startup; when disabled they are applied by the migrate command",
+		EnvVars: []string{"AUTO_MIGRATE"},
+	},
 }
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// poolDriver is a database/sql driver whose connections do nothing, used to observe the pool.
+type poolDriver struct{}
+
+func (poolDriver) Open(string) (driver.Conn, error) { return poolConn{}, nil }
+
+type poolConn struct{}
+
+func (poolConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (poolConn) Close() error                        { return nil }
+func (poolConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func init() {
+	sql.Register("pool", poolDriver{})
+}
+
+func TestPoolConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  PoolConfig
+		wantErr bool
+	}{
+		{"Valid", PoolConfig{MaxOpenConnections: 10, MaxIdleConnections: 5, ConnMaxIdleTimeMS: 1000}, false},
+		{"IdleEqualsOpen", PoolConfig{MaxOpenConnections: 10, MaxIdleConnections: 10}, false},
+		{"UnlimitedOpen", PoolConfig{MaxIdleConnections: 50}, false},
+		{"IdleExceedsOpen", PoolConfig{MaxOpenConnections: 10, MaxIdleConnections: 11}, true},
+		{"NegativeIdle", PoolConfig{MaxOpenConnections: 10, MaxIdleConnections: -1}, true},
+		{"NegativeIdleTime", PoolConfig{MaxOpenConnections: 10, ConnMaxIdleTimeMS: -1}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPoolConfig_Apply(t *testing.T) {
+	db, err := sql.Open("pool", "")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(5)
+
+	(&PoolConfig{MaxOpenConnections: 5, MaxIdleConnections: 2, ConnMaxIdleTimeMS: 1}).Apply(db)
+
+	// Open more connections than may stay idle, then return them all to the pool.
+	conns := make([]*sql.Conn, 4)
+	for i := range conns {
+		conns[i], err = db.Conn(context.Background())
+		require.NoError(t, err)
+	}
+	for _, conn := range conns {
+		require.NoError(t, conn.Close())
+	}
+
+	stats := db.Stats()
+	assert.Equal(t, 5, stats.MaxOpenConnections)
+	assert.Equal(t, int64(2), stats.MaxIdleClosed)
+	assert.LessOrEqual(t, stats.Idle, 2)
+	assert.Eventually(t, func() bool {
+		return db.Stats().MaxIdleTimeClosed > 0
+	}, 3*time.Second, 50*time.Millisecond)
+}
@@ -8,14 +8,23 @@ import (
 
 // DBModule is an Fx module that provides the configuration, connection, and holder for PostgreSQL database integration.
 // It initializes the PostgreSQL configuration, establishes a connection, and verifies connectivity.
+// It also provides the router serving read-only queries from the optional read replica.
 var DBModule = fx.Module("database",
 
 	// Provides the PostgreSQL configuration using the GetPostgresConfig function.
 	fx.Provide(GetPostgresConfig),
 
+	// Provides the connection pool settings not covered by the PostgreSQL configuration.
+	fx.Provide(GetPoolConfig),
+
 	// Provides a connection to the PostgreSQL database, initialized by NewConnect.
 	fx.Provide(postgres.NewConnect),
 
+	// Invokes a function to apply the idle connection limits to the connection pool.
+	fx.Invoke(func(db *gorm.DB, pool *PoolConfig) {
+		pool.Apply(db.DB())
+	}),
+
 	// Provides a holder for the database instance, facilitating dependency injection.
 	fx.Provide(postgres.NewDBHolder),
 
@@ -28,4 +37,18 @@ var DBModule = fx.Module("database",
 	fx.Invoke(func(db *gorm.DB) {
 		postgres.CheckConnection(db)
 	}),
+
+	// Provides the read replica settings and the router sending read-only queries to the replica.
+	fx.Provide(GetReplicaConfig),
+	fx.Provide(NewReadRouter),
 )
+
+// MigrationModule provides the migration settings and the Migrator.
+var MigrationModule = fx.Options(
+	fx.Provide(GetMigrationConfig),
+	fx.Provide(NewMigrator),
+)
+
+// AutoMigrate applies pending schema migrations on startup when the auto-migrate flag is set.
+// Startup fails if a migration fails.
+var AutoMigrate = fx.Invoke(runAutoMigrate)
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"sync"
+
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+)
+
+// transactionHooks collects the hooks registered with AfterTransaction, by the transaction context
+// of each transaction tracked with TrackTransaction.
+var transactionHooks sync.Map // postgres.ITransactionContext -> *hookList
+
+// hookList is the list of hooks of a tracked transaction.
+type hookList struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// TrackTransaction starts collecting the hooks that the operations joining the transaction of ctx
+// register with AfterTransaction, and returns a function running them. The owner of the outermost
+// transaction calls it once the transaction has been committed or rolled back. The function does
+// nothing if ctx carries no transaction context, as no operation can join the transaction then,
+// or if an enclosing owner already tracks the transaction.
+func TrackTransaction(ctx context.Context) func() {
+	tr, ok := ctx.Value(postgres.TransactionContextKey).(postgres.ITransactionContext)
+	if !ok {
+		return func() {}
+	}
+	list := &hookList{}
+	if _, tracked := transactionHooks.LoadOrStore(tr, list); tracked {
+		return func() {}
+	}
+	return func() {
+		transactionHooks.CompareAndDelete(tr, list)
+		list.mu.Lock()
+		hooks := list.hooks
+		list.hooks = nil
+		list.mu.Unlock()
+		for _, hook := range hooks {
+			hook()
+		}
+	}
+}
+
+// AfterTransaction registers fn to run once the tracked transaction of ctx ends, for work such as
+// cache invalidation that must not happen before the changes of the transaction are visible.
+//
+// Returns:
+//   - false if the transaction of ctx is not tracked, in which case fn is not registered; otherwise, true.
+func AfterTransaction(ctx context.Context, fn func()) bool {
+	tr, ok := ctx.Value(postgres.TransactionContextKey).(postgres.ITransactionContext)
+	if !ok {
+		return false
+	}
+	value, ok := transactionHooks.Load(tr)
+	if !ok {
+		return false
+	}
+	list := value.(*hookList)
+	list.mu.Lock()
+	list.hooks = append(list.hooks, fn)
+	list.mu.Unlock()
+	return true
+}
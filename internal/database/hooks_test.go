@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAfterTransaction_RunsOnceOutermostTransactionEnds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, postgres.NewMockITransactionContext(ctrl))
+
+	var ran []string
+	end := TrackTransaction(ctx)
+	// A nested owner joins the outer transaction, so the hooks wait for the outer owner
+	endNested := TrackTransaction(ctx)
+	assert.True(t, AfterTransaction(ctx, func() { ran = append(ran, "first") }))
+	assert.True(t, AfterTransaction(ctx, func() { ran = append(ran, "second") }))
+
+	endNested()
+	assert.Empty(t, ran)
+	end()
+	assert.Equal(t, []string{"first", "second"}, ran)
+
+	// Once the transaction has ended, it no longer collects hooks
+	assert.False(t, AfterTransaction(ctx, func() { t.Fatal("hook must not be registered") }))
+	end()
+	assert.Len(t, ran, 2)
+}
+
+func TestAfterTransaction_Untracked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, postgres.NewMockITransactionContext(ctrl))
+
+	assert.False(t, AfterTransaction(context.Background(), func() { t.Fatal("hook must not be registered") }))
+	assert.False(t, AfterTransaction(ctx, func() { t.Fatal("hook must not be registered") }))
+	TrackTransaction(context.Background())()
+}
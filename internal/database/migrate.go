@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/database/migration"
+	"github.com/vadymlab/slot-game/internal/advisorylock"
+)
+
+// migrationLockName is the advisory lock name serializing migrations across instances.
+const migrationLockName = "schema-migrations"
+
+// Migration is a single numbered schema migration.
+type Migration struct {
+	Version uint64 // Version of the migration, the number prefixing its file name
+	Name    string // File name of the up migration
+	SQL     string // Statements applying the migration
+}
+
+// Migrator applies the embedded SQL migrations to the database.
+//
+// The applied version is recorded in the schema_migrations table in the format used by
+// golang-migrate, so the migrate command and the migrations container can be used interchangeably.
+// All pending migrations are applied in a single transaction under an advisory lock: instances
+// migrating at the same time wait for each other, and a failing migration leaves the schema unchanged.
+type Migrator struct {
+	db     *gorm.DB // Database connection the migrations are applied to
+	source fs.FS    // File system holding the *.up.sql migrations
+}
+
+// Up applies all migrations newer than the version recorded in the database.
+//
+// Parameters:
+//   - ctx: Context for managing cancellation signals.
+//
+// Returns:
+//   - The number of applied migrations; 0 if the schema is up to date.
+//   - An error if the migrations cannot be read, the database is left dirty by golang-migrate,
+//     or a migration fails.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return 0, err
+	}
+
+	tx := m.db.BeginTx(ctx, nil)
+	if err := tx.Error; err != nil {
+		return 0, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", advisorylock.Key(migrationLockName)).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)").Error; err != nil {
+		return 0, err
+	}
+	var version uint64
+	var dirty bool
+	err = tx.Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Row().Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("database schema is dirty at version %d; fix it and force the version with golang-migrate", version)
+	}
+
+	applied := 0
+	for _, migration := range migrations {
+		if migration.Version <= version {
+			continue
+		}
+		if err := tx.Exec(migration.SQL).Error; err != nil {
+			return 0, fmt.Errorf("migration %s failed: %w", migration.Name, err)
+		}
+		log.FromContext(ctx).Infof("applied migration %s", migration.Name)
+		version = migration.Version
+		applied++
+	}
+	if applied == 0 {
+		return 0, nil
+	}
+	if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", version).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return 0, err
+	}
+	committed = true
+	return applied, nil
+}
+
+// Migrations lists the up migrations of the source, ordered by version.
+//
+// Returns:
+//   - The up migrations, oldest first.
+//   - An error if a migration cannot be read or its file name does not start with a version.
+func (m *Migrator) Migrations() ([]*Migration, error) {
+	names, err := fs.Glob(m.source, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	migrations := make([]*Migration, 0, len(names))
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(path.Base(name), "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version prefix", name)
+		}
+		content, err := fs.ReadFile(m.source, name)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, &Migration{Version: version, Name: name, SQL: string(content)})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// NewMigrator creates a Migrator applying the migrations embedded in the binary.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//
+// Returns:
+//   - A pointer to a Migrator instance.
+func NewMigrator(db *gorm.DB) *Migrator {
+	return &Migrator{db: db, source: migration.Files}
+}
+
+// runAutoMigrate applies pending migrations on startup if auto-migration is enabled.
+// It leaves the schema untouched otherwise.
+func runAutoMigrate(config *MigrationConfig, migrator *Migrator) error {
+	if !config.AutoMigrate {
+		return nil
+	}
+	ctx := context.Background()
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	log.FromContext(ctx).Infof("auto-migration finished, %d migrations applied", applied)
+	return nil
+}
@@ -0,0 +1,229 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaDriver is a database/sql driver simulating the schema_migrations table. Statements
+// executed in a transaction are recorded, and the recorded version changes, only on commit.
+type schemaDriver struct {
+	mu        sync.Mutex
+	version   int64    // Version in schema_migrations; 0 if the table is empty
+	dirty     bool     // Dirty flag in schema_migrations
+	committed []string // Statements of committed transactions
+	pending   []string // Statements of the open transaction
+	failOn    string   // Statements containing this text fail
+}
+
+func (d *schemaDriver) Open(string) (driver.Conn, error) { return &schemaConn{driver: d}, nil }
+
+// reset empties the table and the recorded statements.
+func (d *schemaDriver) reset(version int64, dirty bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.version, d.dirty, d.committed, d.pending, d.failOn = version, dirty, nil, nil, ""
+}
+
+// statements returns the committed statements that change the schema or the recorded version.
+func (d *schemaDriver) statements() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var res []string
+	for _, s := range d.committed {
+		if !strings.HasPrefix(s, "SELECT") {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+type schemaConn struct{ driver *schemaDriver }
+
+func (c *schemaConn) Prepare(query string) (driver.Stmt, error) {
+	return &schemaStmt{driver: c.driver, query: query}, nil
+}
+func (c *schemaConn) Close() error              { return nil }
+func (c *schemaConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *schemaConn) Commit() error {
+	d := c.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.committed = append(d.committed, d.pending...)
+	d.pending = nil
+	return nil
+}
+
+func (c *schemaConn) Rollback() error {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.pending = nil
+	return nil
+}
+
+type schemaStmt struct {
+	driver *schemaDriver
+	query  string
+}
+
+func (s *schemaStmt) Close() error  { return nil }
+func (s *schemaStmt) NumInput() int { return -1 }
+
+func (s *schemaStmt) Exec(args []driver.Value) (driver.Result, error) {
+	d := s.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.failOn != "" && strings.Contains(s.query, d.failOn) {
+		return nil, errors.New("syntax error")
+	}
+	d.pending = append(d.pending, strings.TrimSpace(s.query))
+	if strings.HasPrefix(s.query, "INSERT INTO schema_migrations") {
+		d.version = args[0].(int64)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *schemaStmt) Query([]driver.Value) (driver.Rows, error) {
+	d := s.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	rows := &schemaRows{}
+	if d.version > 0 {
+		rows.values = []driver.Value{d.version, d.dirty}
+	}
+	return rows, nil
+}
+
+type schemaRows struct{ values []driver.Value }
+
+func (r *schemaRows) Columns() []string { return []string{"version", "dirty"} }
+func (r *schemaRows) Close() error      { return nil }
+func (r *schemaRows) Next(dest []driver.Value) error {
+	if r.values == nil {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.values = nil
+	return nil
+}
+
+var (
+	schema             = &schemaDriver{}
+	registerSchemaOnce sync.Once
+)
+
+// testMigrations is a migration source with three migrations and their down migrations.
+var testMigrations = fstest.MapFS{
+	"000001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id SERIAL);")},
+	"000001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	"000002_create_spins.up.sql":   {Data: []byte("CREATE TABLE spins (id SERIAL);")},
+	"000002_create_spins.down.sql": {Data: []byte("DROP TABLE spins;")},
+	"000010_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON spins (id);")},
+	"000010_add_index.down.sql":    {Data: []byte("DROP INDEX idx;")},
+}
+
+// newTestMigrator returns a Migrator of the test migrations backed by the schema driver.
+func newTestMigrator(t *testing.T, version int64, dirty bool) *Migrator {
+	registerSchemaOnce.Do(func() { sql.Register("schema", schema) })
+	schema.reset(version, dirty)
+	sqlDB, err := sql.Open("schema", "")
+	require.NoError(t, err)
+	db, err := gorm.Open("postgres", sqlDB)
+	require.NoError(t, err)
+	return &Migrator{db: db, source: testMigrations}
+}
+
+func TestMigrations_OrderedByVersion(t *testing.T) {
+	migrations, err := newTestMigrator(t, 0, false).Migrations()
+
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.Equal(t, []uint64{1, 2, 10}, []uint64{migrations[0].Version, migrations[1].Version, migrations[2].Version})
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := NewMigrator(nil).Migrations()
+
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, uint64(1), migrations[0].Version)
+}
+
+func TestAutoMigrate_DisabledLeavesSchemaUntouched(t *testing.T) {
+	migrator := newTestMigrator(t, 0, false)
+
+	err := runAutoMigrate(&MigrationConfig{AutoMigrate: false}, migrator)
+
+	assert.NoError(t, err)
+	assert.Empty(t, schema.statements())
+	assert.Equal(t, int64(0), schema.version)
+}
+
+func TestAutoMigrate_EnabledAppliesMigrations(t *testing.T) {
+	migrator := newTestMigrator(t, 0, false)
+
+	err := runAutoMigrate(&MigrationConfig{AutoMigrate: true}, migrator)
+
+	assert.NoError(t, err)
+	assert.Contains(t, schema.statements(), "CREATE TABLE users (id SERIAL);")
+	assert.Equal(t, int64(10), schema.version)
+}
+
+func TestUp_AppliesPendingMigrations(t *testing.T) {
+	migrator := newTestMigrator(t, 1, false)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)",
+		"CREATE TABLE spins (id SERIAL);",
+		"CREATE INDEX idx ON spins (id);",
+		"DELETE FROM schema_migrations",
+		"INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)",
+	}, schema.statements())
+	assert.Equal(t, int64(10), schema.version)
+}
+
+func TestUp_UpToDate(t *testing.T) {
+	migrator := newTestMigrator(t, 10, false)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+	assert.Equal(t, int64(10), schema.version)
+}
+
+func TestUp_FailedMigrationRollsBack(t *testing.T) {
+	migrator := newTestMigrator(t, 0, false)
+	schema.failOn = "CREATE INDEX"
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.ErrorContains(t, err, "000010_add_index.up.sql")
+	assert.Equal(t, 0, applied)
+	assert.Empty(t, schema.statements())
+}
+
+func TestUp_DirtySchemaRejected(t *testing.T) {
+	migrator := newTestMigrator(t, 2, true)
+
+	_, err := migrator.Up(context.Background())
+
+	assert.ErrorContains(t, err, "dirty at version 2")
+	assert.Empty(t, schema.statements())
+}
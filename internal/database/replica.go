@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	log "github.com/public-forge/go-logger"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/fx"
+)
+
+// Constants for the read replica configuration parameters.
+const (
+	postgresReadReplica = "postgres-read-replica" // Route read-only queries to the replica
+	postgresReplicaHost = "postgres-replica-host" // Replica host address
+)
+
+// readOnlyKey is the context key marking a request that only reads.
+type readOnlyKey struct{}
+
+// ReplicaConfig holds the settings of the read replica serving the read-only endpoints.
+// The replica shares the credentials, database name and schema of the primary.
+type ReplicaConfig struct {
+	Enabled bool   // Route the queries of read-only endpoints to the replica
+	Host    string // Replica host address; when empty, the primary serves all queries
+}
+
+// Configured reports whether read-only queries are routed to a replica.
+func (c *ReplicaConfig) Configured() bool {
+	return c.Enabled && c.Host != ""
+}
+
+// GetReplicaConfig reads the read replica settings from the CLI context.
+//
+// Parameters:
+//   - c: The CLI context from which configuration values are read.
+//
+// Returns:
+//
+//	A pointer to a ReplicaConfig struct.
+func GetReplicaConfig(c *cli.Context) *ReplicaConfig {
+	return &ReplicaConfig{
+		Enabled: c.Bool(postgresReadReplica),
+		Host:    c.String(postgresReplicaHost),
+	}
+}
+
+// ReplicaFlags defines CLI flags for configuring the read replica.
+var ReplicaFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:    postgresReadReplica,
+		Value:   false,
+		Usage:   "Serve the history, profile and leaderboard queries from the read replica; the primary serves them when no replica host is set",
+		EnvVars: []string{"POSTGRES_READ_REPLICA"},
+	},
+	&cli.StringFlag{
+		Name:    postgresReplicaHost,
+		Usage:   "PostgreSQL read replica host address; it shares the credentials, database and schema of the primary",
+		EnvVars: []string{"POSTGRES_REPLICA_HOST", "PG_REPLICA_HOST"},
+	},
+}
+
+// WithReadOnly marks the context of a request that only reads, so that the repositories may
+// serve its queries from the read replica. Queries writing or locking rows ignore the mark.
+//
+// Parameters:
+//   - ctx: The request context.
+//
+// Returns:
+//
+//	A context carrying the read-only mark.
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// IsReadOnly reports whether the context was marked by WithReadOnly.
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// ReadRouter selects the connection read-only queries run on. Queries of requests marked with
+// WithReadOnly go to the replica, every other query stays on the primary. A router without a
+// replica, including a nil router, always selects the primary.
+//
+// The replica lags slightly behind the primary, so a change may not be visible right away to
+// the endpoints it serves.
+type ReadRouter struct {
+	replica *gorm.DB // Connection to the read replica; nil when the primary serves all queries
+}
+
+// DB selects the connection for a read-only query.
+//
+// Parameters:
+//   - ctx: The request context, possibly marked by WithReadOnly.
+//   - primary: The primary connection or transaction the query would otherwise run on.
+//
+// Returns:
+//
+//	The replica connection for marked requests when a replica is configured, otherwise primary.
+func (r *ReadRouter) DB(ctx context.Context, primary *gorm.DB) *gorm.DB {
+	if r == nil || r.replica == nil || !IsReadOnly(ctx) {
+		return primary
+	}
+	return r.replica
+}
+
+// NewReadRouter connects to the read replica when one is configured and closes the connection when
+// the application stops. Without a replica, the router selects the primary for every query.
+//
+// Parameters:
+//   - lc: Fx lifecycle used to close the replica connection.
+//   - cfg: The read replica settings.
+//   - pgConfig: The primary's settings, shared by the replica except for the host.
+//   - pool: The connection pool settings, applied to the replica as well.
+//
+// Returns:
+//
+//	A pointer to a ReadRouter instance.
+func NewReadRouter(lc fx.Lifecycle, cfg *ReplicaConfig, pgConfig *postgres.PgConfig, pool *PoolConfig) *ReadRouter {
+	if !cfg.Configured() {
+		if cfg.Enabled {
+			log.FromDefaultContext().Warnf("%s is set without %s, read-only queries use the primary", postgresReadReplica, postgresReplicaHost)
+		}
+		return &ReadRouter{}
+	}
+
+	replicaConfig := *pgConfig
+	replicaConfig.Host = cfg.Host
+	replica := postgres.NewConnect(&replicaConfig)
+	pool.Apply(replica.DB())
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			return replica.Close()
+		},
+	})
+	return &ReadRouter{replica: replica}
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestReplicaConfig_Configured(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   ReplicaConfig
+		expected bool
+	}{
+		{"EnabledWithHost", ReplicaConfig{Enabled: true, Host: "replica:5432"}, true},
+		{"EnabledWithoutHost", ReplicaConfig{Enabled: true}, false},
+		{"DisabledWithHost", ReplicaConfig{Host: "replica:5432"}, false},
+		{"Disabled", ReplicaConfig{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.config.Configured())
+		})
+	}
+}
+
+func TestReadRouter_DB(t *testing.T) {
+	// Distinct handles are enough to tell the selected connection apart
+	primary := &gorm.DB{}
+	replica := &gorm.DB{}
+	readOnly := WithReadOnly(context.Background())
+
+	testCases := []struct {
+		name     string
+		router   *ReadRouter
+		ctx      context.Context
+		expected *gorm.DB
+	}{
+		{"ReadOnlyWithReplica", &ReadRouter{replica: replica}, readOnly, replica},
+		{"WriteWithReplica", &ReadRouter{replica: replica}, context.Background(), primary},
+		{"ReadOnlyWithoutReplica", &ReadRouter{}, readOnly, primary},
+		{"NilRouter", nil, readOnly, primary},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Same(t, tc.expected, tc.router.DB(tc.ctx, primary))
+		})
+	}
+}
+
+func TestNewReadRouter_FallsBackToPrimaryWithoutHost(t *testing.T) {
+	router := NewReadRouter(fxtest.NewLifecycle(t), &ReplicaConfig{Enabled: true}, &postgres.PgConfig{}, &PoolConfig{})
+
+	primary := &gorm.DB{}
+	assert.Same(t, primary, router.DB(WithReadOnly(context.Background()), primary))
+}
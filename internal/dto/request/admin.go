@@ -0,0 +1,31 @@
+package request
+
+// VoidSpinRequest represents the request body for voiding a disputed spin.
+type VoidSpinRequest struct {
+	Reason string `json:"reason" xml:"reason" validate:"required,max=255"` // Reason for voiding the spin, required
+}
+
+// RejectWithdrawalRequest represents the request body for rejecting a pending withdrawal.
+type RejectWithdrawalRequest struct {
+	Reason string `json:"reason" xml:"reason" validate:"required,max=255"` // Reason for rejecting the withdrawal, required
+}
+
+// MaintenanceRequest represents the request body for switching maintenance mode on or off.
+type MaintenanceRequest struct {
+	Enabled *bool `json:"enabled" xml:"enabled" validate:"required"` // Whether maintenance mode is on, required
+}
+
+// FreezeRequest represents the request body for freezing or unfreezing the account of a user.
+type FreezeRequest struct {
+	Frozen *bool `json:"frozen" xml:"frozen" validate:"required"` // Whether the account is frozen, required
+}
+
+// UserListRequest represents the query parameters for listing users to admins. Search selects the
+// users whose login contains it; Sort orders them by creation or balance, newest and highest first
+// unless Order is "asc".
+type UserListRequest struct {
+	PageRequest
+	Search string `form:"search" validate:"omitempty,max=255"`             // Part of the login the users must contain
+	Sort   string `form:"sort" validate:"omitempty,oneof=created balance"` // Order of the users: created or balance
+	Order  string `form:"order" validate:"omitempty,oneof=asc desc"`       // Direction of the order: asc or desc
+}
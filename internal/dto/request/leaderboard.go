@@ -0,0 +1,7 @@
+package request
+
+// LeaderboardRequest represents the query parameters for retrieving the leaderboard.
+// Period selects the aggregation window; an empty value aggregates all spins.
+type LeaderboardRequest struct {
+	Period string `form:"period" validate:"omitempty,oneof=day week all"` // Aggregation window: day, week or all
+}
@@ -0,0 +1,18 @@
+package request
+
+// DefaultPageLimit is the number of items returned by paginated list endpoints when no limit is given.
+const DefaultPageLimit = 20
+
+// PageRequest represents the query parameters for paginated list endpoints.
+type PageRequest struct {
+	Limit  int `form:"limit" validate:"omitempty,min=1,max=100"` // Maximum number of items to return
+	Offset int `form:"offset" validate:"omitempty,min=0"`        // Number of items to skip
+}
+
+// GetLimit returns the requested page size, or DefaultPageLimit if none was given.
+func (r PageRequest) GetLimit() int {
+	if r.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	return r.Limit
+}
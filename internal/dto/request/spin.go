@@ -1,7 +1,46 @@
 package request
 
+import "time"
+
 // SpinRequest represents the data required to initiate a spin in the slot game.
-// The BetAmount specifies the amount of the bet placed for the spin.
+// The BetAmount specifies the amount of the bet placed for the spin, the GameID the game played and
+// the Currency the currency the bet is placed in.
 type SpinRequest struct {
-	BetAmount float64 `json:"bet_amount" validate:"required,gt=0"` // Bet amount, required and must be greater than 0
+	BetAmount float64 `json:"bet_amount" xml:"bet_amount" form:"bet_amount" validate:"required,gt=0"`   // Bet amount, required and must be greater than 0
+	GameID    string  `json:"game_id" xml:"game_id" form:"game_id" validate:"max=64"`                   // Game to play; empty plays the default game
+	Currency  string  `json:"currency" xml:"currency" form:"currency" validate:"omitempty,len=3,alpha"` // ISO 4217 code of the currency of the bet, in any case; empty bets in the base currency. Ignored by demo spins
+}
+
+// BulkSpinRequest represents the data required to play several spins with the same bet at once.
+// The Count specifies how many spins to play, at most the configured maximum number of bulk spins.
+type BulkSpinRequest struct {
+	BetAmount float64 `json:"bet_amount" xml:"bet_amount" validate:"required,gt=0"` // Bet amount of each spin, required and must be greater than 0
+	Count     int     `json:"count" xml:"count" validate:"required,gt=0"`           // Number of spins to play, required and must be greater than 0
+	GameID    string  `json:"game_id" xml:"game_id" validate:"max=64"`              // Game to play; empty plays the default game
+}
+
+// DateRangeRequest represents the optional date filters of the spin history endpoints, given as
+// YYYY-MM-DD days in UTC. Both days are inclusive; an omitted day leaves that side of the range open.
+type DateRangeRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`                                  // First day of the range
+	To   time.Time `form:"to" time_format:"2006-01-02" time_utc:"1" validate:"omitempty,gtefield=From"` // Last day of the range, not before From
+}
+
+// Bounds returns the range as an inclusive lower and an exclusive upper bound for the spin
+// creation time, with nil for an omitted day.
+func (r DateRangeRequest) Bounds() (from, to *time.Time) {
+	if !r.From.IsZero() {
+		from = &r.From
+	}
+	if !r.To.IsZero() {
+		end := r.To.AddDate(0, 0, 1)
+		to = &end
+	}
+	return from, to
+}
+
+// SpinHistoryRequest represents the query parameters for retrieving a page of the spin history.
+type SpinHistoryRequest struct {
+	PageRequest
+	DateRangeRequest
 }
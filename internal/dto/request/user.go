@@ -3,19 +3,23 @@ package request
 // BaseAuthRequest represents the base structure for an authentication request,
 // containing user credentials with validation rules for security and integrity.
 type BaseAuthRequest struct {
-	// Login is the user's login email address. This field is required and must
-	// conform to a valid email format to ensure proper identification.
-	Login string `json:"login" validate:"required,email"`
+	// Login is the user's login email address or, if the login policy allows it, username.
+	// This field is required; it is trimmed and lowercased, then its format is checked against the login policy.
+	Login string `json:"login" xml:"login" validate:"required"`
 
 	// Password is the user's login password. This field is required and must be
 	// at least 8 characters long, providing basic security against weak passwords.
-	Password string `json:"password" validate:"required,min=8"`
+	Password string `json:"password" xml:"password" validate:"required,min=8"`
 }
 
 // LoginRequest represents the request body for a user login operation.
 // It includes fields for user credentials and applies validation constraints.
 type LoginRequest struct {
 	BaseAuthRequest
+
+	// Nonce is an optional unique value generated by the client for each login request.
+	// A request repeating a recently used nonce is rejected as a replay.
+	Nonce string `json:"nonce,omitempty" xml:"nonce,omitempty" validate:"omitempty,min=16,max=128"`
 }
 
 // RegisterRequest represents the request body for a user registration operation.
@@ -23,3 +27,37 @@ type LoginRequest struct {
 type RegisterRequest struct {
 	BaseAuthRequest
 }
+
+// UpdateLoginRequest represents the request body for changing the login of the authenticated user.
+// The current password is required to confirm the change.
+type UpdateLoginRequest struct {
+	// Login is the new login email address or, if the login policy allows it, username.
+	// This field is required; it is trimmed and lowercased, then its format is checked against the login policy.
+	Login string `json:"login" xml:"login" validate:"required"`
+
+	// Password is the user's current password, confirming the change.
+	Password string `json:"password" xml:"password" validate:"required"`
+}
+
+// UpdateSettingsRequest represents the request body for changing the play settings of the authenticated user.
+type UpdateSettingsRequest struct {
+	// AutoStopWin is the win above which the client is told to stop spinning. Null or omitted
+	// falls back to the server default; 0 disables the auto-stop.
+	AutoStopWin *float64 `json:"auto_stop_win" xml:"auto_stop_win" validate:"omitempty,gte=0"`
+}
+
+// UpdateDepositLimitsRequest represents the request body for changing the deposit limits of the authenticated user.
+type UpdateDepositLimitsRequest struct {
+	// PerTransaction is the largest single deposit. Null or omitted removes the limit.
+	PerTransaction *float64 `json:"per_transaction" xml:"per_transaction" validate:"omitempty,gt=0"`
+
+	// Daily is the largest sum of deposits of a day. Null or omitted removes the limit.
+	Daily *float64 `json:"daily" xml:"daily" validate:"omitempty,gt=0"`
+}
+
+// SelfExcludeRequest represents the request body for excluding the authenticated user from play.
+type SelfExcludeRequest struct {
+	// Days is the number of days the user is excluded for, starting now. This field is required
+	// and must be between 1 and 3650.
+	Days int `json:"days" xml:"days" validate:"required,min=1,max=3650"`
+}
@@ -1,15 +1,18 @@
 package request
 
+import "github.com/google/uuid"
+
 // BaseWalletRequest represents a base request structure for wallet transactions.
 // It includes the amount to be deposited or withdrawn, with a validation constraint.
 type BaseWalletRequest struct {
-	Amount float64 `json:"amount" validate:"required,gt=0"` // Transaction amount, required field
+	Amount float64 `json:"amount" xml:"amount" validate:"required,gt=0"` // Transaction amount, required field
 }
 
 // DepositRequest represents a request to deposit funds into the user's wallet.
-// It embeds BaseWalletRequest to include the amount field.
+// It embeds BaseWalletRequest to include the amount field and accepts an optional promo code.
 type DepositRequest struct {
 	BaseWalletRequest
+	PromoCode string `json:"promo_code,omitempty" xml:"promo_code,omitempty" validate:"omitempty,max=64"` // Optional promo code granting a deposit bonus
 }
 
 // WithdrawRequest represents a request to withdraw funds from the user's wallet.
@@ -17,3 +20,16 @@ type DepositRequest struct {
 type WithdrawRequest struct {
 	BaseWalletRequest
 }
+
+// TransferRequest represents a request to transfer funds from the user's wallet to another user.
+// It embeds BaseWalletRequest to include the amount field.
+type TransferRequest struct {
+	BaseWalletRequest
+	RecipientID uuid.UUID `json:"recipient_id" xml:"recipient_id" validate:"required" swaggertype:"string" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"` // External ID of the user receiving the funds
+}
+
+// BalanceRequest represents the query parameters for reading the balance in a single currency.
+// An empty currency selects the base currency.
+type BalanceRequest struct {
+	Currency string `form:"currency" validate:"omitempty,len=3,alpha"` // ISO 4217 code of the currency, in any case
+}
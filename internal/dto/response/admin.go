@@ -0,0 +1,118 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// VoidedSpinResponse represents the response returned after a spin is voided.
+type VoidedSpinResponse struct {
+	ID         uint   `json:"id"`          // The numeric ID of the spin
+	BetAmount  Money  `json:"bet_amount"`  // The bet amount refunded to the user
+	WinAmount  Money  `json:"win_amount"`  // The win amount clawed back from the user
+	VoidReason string `json:"void_reason"` // The reason the spin was voided
+	VoidedAt   string `json:"voided_at"`   // The date and time the spin was voided, formatted as "YYYY-MM-DD HH:MM:SS"
+}
+
+// VoidedSpinFromModel converts a voided Spin model instance to a VoidedSpinResponse instance.
+//
+// Parameters:
+//   - model: A pointer to a voided models.Spin instance.
+//
+// Returns:
+//
+//	A pointer to a VoidedSpinResponse instance containing the mapped data from the input model.
+func VoidedSpinFromModel(model *models.Spin) *VoidedSpinResponse {
+	res := &VoidedSpinResponse{
+		ID:         model.ID,
+		BetAmount:  Money(model.BetAmount),
+		WinAmount:  Money(model.WinAmount),
+		VoidReason: model.VoidReason,
+	}
+	if model.VoidedAt != nil {
+		res.VoidedAt = model.VoidedAt.Format("2006-01-02 15:04:05")
+	}
+	return res
+}
+
+// MaintenanceResponse represents the maintenance mode of the game and wallet endpoints.
+type MaintenanceResponse struct {
+	Enabled bool `json:"enabled"` // Whether the endpoints are in maintenance mode
+	Toggle  bool `json:"toggle"`  // Whether the admin toggle is on
+	Flag    bool `json:"flag"`    // Whether the maintenance flag is set, which keeps maintenance mode on regardless of the toggle
+}
+
+// AdminUserResponse represents a user listed to admins. It only holds non-sensitive fields; the
+// password hash is never included.
+type AdminUserResponse struct {
+	ID            *uuid.UUID `json:"id"`                       // Unique identifier of the user
+	Login         string     `json:"login"`                    // Login name of the user
+	Balance       Money      `json:"balance"`                  // Balance in the base currency
+	IsAdmin       bool       `json:"is_admin"`                 // Whether the user may use the admin endpoints
+	ExcludedUntil *time.Time `json:"excluded_until,omitempty"` // End of the user's self-exclusion; omitted if the user never excluded themselves
+	Frozen        bool       `json:"frozen"`                   // Whether an admin froze the account pending an investigation
+	CreatedAt     time.Time  `json:"created_at"`               // Time the account was created
+}
+
+// AdminUsersFromModels converts a slice of User models to a slice of AdminUserResponse instances.
+//
+// Parameters:
+//   - users: A slice of pointers to models.User instances.
+//
+// Returns:
+//
+//	A slice of pointers to AdminUserResponse instances, one for each user.
+func AdminUsersFromModels(users []*models.User) []*AdminUserResponse {
+	res := make([]*AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		res = append(res, AdminUserFromModel(user))
+	}
+	return res
+}
+
+// AdminUserFromModel converts a User model to an AdminUserResponse instance.
+//
+// Parameters:
+//   - user: A pointer to a models.User instance.
+//
+// Returns:
+//
+//	A pointer to an AdminUserResponse instance.
+func AdminUserFromModel(user *models.User) *AdminUserResponse {
+	return &AdminUserResponse{
+		ID:            user.ExternalID,
+		Login:         user.Login,
+		Balance:       Money(user.Balance),
+		IsAdmin:       user.IsAdmin,
+		ExcludedUntil: user.ExcludedUntil,
+		Frozen:        user.Frozen,
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+// SpinChainResponse represents the result of verifying the hash chain of a user's spins.
+type SpinChainResponse struct {
+	Valid    bool   `json:"valid"`               // Whether every spin checked matched its hash and linked to the previous spin
+	Checked  int    `json:"checked"`             // Number of chained spins checked, up to and including the first broken one
+	BrokenAt *uint  `json:"broken_at,omitempty"` // ID of the first spin failing verification; omitted if the chain is intact
+	LastHash string `json:"last_hash,omitempty"` // Hash of the last spin verified; omitted if no spin was verified
+}
+
+// SpinChainFromModel converts a SpinChainVerification to a SpinChainResponse instance.
+//
+// Parameters:
+//   - model: A pointer to a models.SpinChainVerification instance.
+//
+// Returns:
+//
+//	A pointer to a SpinChainResponse instance containing the mapped data from the input model.
+func SpinChainFromModel(model *models.SpinChainVerification) *SpinChainResponse {
+	return &SpinChainResponse{
+		Valid:    model.Valid(),
+		Checked:  model.Checked,
+		BrokenAt: model.BrokenAt,
+		LastHash: model.LastHash,
+	}
+}
@@ -0,0 +1,121 @@
+package response
+
+import (
+	"slices"
+
+	"github.com/vadymlab/slot-game/internal/config"
+)
+
+// Kinds of the symbols of a game.
+const (
+	SymbolKindRegular = "regular" // Symbol paying in line matches
+	SymbolKindWild    = "wild"    // Symbol substituting for any other symbol in a line match
+	SymbolKindScatter = "scatter" // Symbol paying anywhere on the reels
+)
+
+// GameConfigResponse represents the public configuration of a slot game, from which clients
+// render the game.
+type GameConfigResponse struct {
+	GameID           string                  `json:"game_id" example:"default"`   // ID of the game
+	Reels            int                     `json:"reels" example:"3"`           // Number of reels
+	Symbols          []GameSymbolResponse    `json:"symbols"`                     // Symbols shown on the reels
+	Paytable         []PaytableEntryResponse `json:"paytable"`                    // Line payouts, from the highest to the lowest number of matches
+	Scatter          *ScatterPayoutResponse  `json:"scatter,omitempty"`           // Scatter payout; omitted when the game has no scatter
+	PayBothWays      bool                    `json:"pay_both_ways"`               // Whether matches from the last reel pay as well
+	Currency         string                  `json:"currency" example:"USD"`      // Currency of the bets and wins
+	MinBet           *Money                  `json:"min_bet,omitempty"`           // Smallest allowed bet; omitted when any bet is allowed
+	MaxBet           *Money                  `json:"max_bet,omitempty"`           // Largest allowed bet; omitted when any bet is allowed
+	BetDenominations []Money                 `json:"bet_denominations,omitempty"` // Allowed bets; omitted when any bet is allowed
+	MaxWinPerSpin    *Money                  `json:"max_win_per_spin,omitempty"`  // Win cap of a single spin; omitted when uncapped
+}
+
+// GameSymbolResponse represents a symbol of a game with its display metadata.
+type GameSymbolResponse struct {
+	Symbol             string   `json:"symbol" example:"A"`             // Symbol as shown in the reels of a spin
+	Kind               string   `json:"kind" example:"regular"`         // One of regular, wild or scatter
+	Name               string   `json:"name" example:"Ace"`             // Display name
+	Icon               string   `json:"icon,omitempty"`                 // URL or asset key of the icon
+	TwoMatchMultiplier *float64 `json:"two_match_multiplier,omitempty"` // Own two-match multiplier; omitted when the paytable applies
+}
+
+// PaytableEntryResponse represents a line payout of a game.
+type PaytableEntryResponse struct {
+	Matches     int      `json:"matches" example:"3"`     // Number of matching symbols on consecutive reels
+	Multiplier  float64  `json:"multiplier" example:"10"` // Multiplier applied to the bet
+	Probability *float64 `json:"probability,omitempty"`   // Probability of the match; omitted when probabilities are hidden
+}
+
+// ScatterPayoutResponse represents the scatter payout of a game.
+type ScatterPayoutResponse struct {
+	MinCount    int      `json:"min_count" example:"3"`  // Number of scatters required for the payout
+	Multiplier  float64  `json:"multiplier" example:"5"` // Multiplier applied to the bet
+	Probability *float64 `json:"probability,omitempty"`  // Probability of a scatter on a reel; omitted when probabilities are hidden
+	FreeSpins   int      `json:"free_spins,omitempty"`   // Free spins awarded along with the payout; omitted when none are awarded
+}
+
+// GameConfigFromConfig creates a GameConfigResponse from the configuration of a game. The
+// probabilities are left out when the game hides them.
+//
+// Parameters:
+//   - gameID: The ID of the game.
+//   - game: The configuration of the game.
+//
+// Returns:
+//
+//	A pointer to a GameConfigResponse instance describing the game.
+func GameConfigFromConfig(gameID string, game *config.SlotConfig) *GameConfigResponse {
+	probability := func(value float64) *float64 {
+		if game.HideProbabilities {
+			return nil
+		}
+		return &value
+	}
+
+	res := &GameConfigResponse{
+		GameID:      gameID,
+		Reels:       game.Reels(),
+		PayBothWays: game.PayBothWays,
+		Currency:    game.BaseCurrency,
+	}
+	symbol := func(symbol, kind string) GameSymbolResponse {
+		display := game.Display(symbol)
+		s := GameSymbolResponse{Symbol: symbol, Kind: kind, Name: display.Name, Icon: display.Icon}
+		if multiplier, ok := game.TwoMatchMultipliers[symbol]; ok {
+			s.TwoMatchMultiplier = &multiplier
+		}
+		return s
+	}
+	for _, s := range game.ReelSymbols() {
+		res.Symbols = append(res.Symbols, symbol(s, SymbolKindRegular))
+	}
+	if game.WildSymbol != "" {
+		res.Symbols = append(res.Symbols, symbol(game.WildSymbol, SymbolKindWild))
+	}
+	if game.ScatterSymbol != "" {
+		res.Symbols = append(res.Symbols, symbol(game.ScatterSymbol, SymbolKindScatter))
+		res.Scatter = &ScatterPayoutResponse{
+			MinCount:    game.ScatterMinCount,
+			Multiplier:  game.ScatterMultiplier,
+			Probability: probability(game.ScatterProbability),
+			FreeSpins:   game.ScatterFreeSpins,
+		}
+	}
+	for _, e := range game.Paytable() {
+		res.Paytable = append(res.Paytable, PaytableEntryResponse{
+			Matches:     e.Matches,
+			Multiplier:  e.Multiplier,
+			Probability: probability(e.Probability),
+		})
+	}
+	if len(game.BetDenominations) > 0 {
+		minBet, maxBet := slices.Min(game.BetDenominations), slices.Max(game.BetDenominations)
+		res.MinBet, res.MaxBet = MoneyPtr(&minBet), MoneyPtr(&maxBet)
+		for _, bet := range game.BetDenominations {
+			res.BetDenominations = append(res.BetDenominations, Money(bet))
+		}
+	}
+	if game.MaxWinPerSpin > 0 {
+		res.MaxWinPerSpin = MoneyPtr(&game.MaxWinPerSpin)
+	}
+	return res
+}
@@ -0,0 +1,43 @@
+package response
+
+import "github.com/vadymlab/slot-game/internal/models"
+
+// LeaderboardEntryResponse represents a single row of the leaderboard.
+// The player's login is anonymized so that other users cannot identify them.
+type LeaderboardEntryResponse struct {
+	Rank        int    `json:"rank"`         // Position of the player on the leaderboard, starting at 1
+	DisplayName string `json:"display_name"` // Anonymized player name
+	TotalWin    Money  `json:"total_win"`    // Sum of all win amounts within the period
+	NetProfit   Money  `json:"net_profit"`   // Total winnings minus total bets within the period
+}
+
+// LeaderboardFromModels converts a slice of LeaderboardEntry models to a slice of
+// LeaderboardEntryResponse instances, assigning ranks in the given order.
+//
+// Parameters:
+//   - models: A slice of pointers to models.LeaderboardEntry instances, ordered by rank.
+//
+// Returns:
+//
+//	A slice of pointers to LeaderboardEntryResponse instances.
+func LeaderboardFromModels(models []*models.LeaderboardEntry) []*LeaderboardEntryResponse {
+	res := make([]*LeaderboardEntryResponse, 0, len(models))
+	for i, model := range models {
+		res = append(res, &LeaderboardEntryResponse{
+			Rank:        i + 1,
+			DisplayName: anonymize(model.Login),
+			TotalWin:    Money(model.TotalWin),
+			NetProfit:   Money(model.NetProfit()),
+		})
+	}
+	return res
+}
+
+// anonymize masks a login, keeping only its first two characters.
+func anonymize(login string) string {
+	runes := []rune(login)
+	if len(runes) > 2 {
+		runes = runes[:2]
+	}
+	return string(runes) + "***"
+}
@@ -0,0 +1,40 @@
+package response
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Money is a monetary amount in a response. It is serialized as a JSON number with exactly two
+// decimal places, so that floating point artifacts such as 9.799999999999999 or exponent notation
+// never reach clients.
+type Money float64
+
+// MarshalJSON encodes the amount rounded half away from zero to two decimal places, e.g. 9.80.
+func (m Money) MarshalJSON() ([]byte, error) {
+	value := float64(m)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("unsupported money amount: %v", value)
+	}
+	return []byte(m.String()), nil
+}
+
+// String formats the amount rounded half away from zero to two decimal places, e.g. 9.80.
+func (m Money) String() string {
+	rounded := math.Round(float64(m)*100) / 100
+	if rounded == 0 {
+		// Avoids serializing tiny negative amounts as -0.00
+		rounded = 0
+	}
+	return strconv.FormatFloat(rounded, 'f', 2, 64)
+}
+
+// MoneyPtr converts an optional amount to an optional Money, keeping nil as nil.
+func MoneyPtr(amount *float64) *Money {
+	if amount == nil {
+		return nil
+	}
+	m := Money(*amount)
+	return &m
+}
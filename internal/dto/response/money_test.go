@@ -0,0 +1,49 @@
+package response
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   float64
+		expected string
+	}{
+		{"FloatingPointArtifact", 10.1 - 0.3, "9.80"},
+		{"Whole", 100, "100.00"},
+		{"RoundsHalfUp", 0.125, "0.13"},
+		{"NoExponent", 1e9 + 0.004, "1000000000.00"},
+		{"Negative", -2.006, "-2.01"},
+		{"NegativeZero", -0.001, "0.00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(Money(tc.amount))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(data))
+		})
+	}
+}
+
+func TestMoney_InResponse(t *testing.T) {
+	balance := 10.1 - 0.3
+
+	data, err := json.Marshal(SpinResponse{WinAmount: 0.1 + 0.2, Wins: []*LineWinResponse{}, Balance: MoneyPtr(&balance)})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"win_amount":0.30,"wins":[],"balance":9.80}`, string(data))
+	assert.Contains(t, string(data), `"balance":9.80`)
+}
+
+func TestMoney_UnsupportedAmount(t *testing.T) {
+	_, err := json.Marshal(Money(math.NaN()))
+
+	assert.Error(t, err)
+}
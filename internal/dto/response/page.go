@@ -0,0 +1,33 @@
+package response
+
+// Page represents a paginated list response. Data is always serialized as an array,
+// so an empty page is returned as `"data": []` rather than an empty body.
+type Page[T any] struct {
+	Data   []T   `json:"data"`   // Items of the current page
+	Total  int64 `json:"total"`  // Total number of items across all pages
+	Limit  int   `json:"limit"`  // Maximum number of items per page
+	Offset int   `json:"offset"` // Number of items skipped before the current page
+}
+
+// NewPage creates a Page from the given items and pagination parameters.
+//
+// Parameters:
+//   - data: The items of the current page; nil is treated as an empty page.
+//   - total: The total number of items across all pages.
+//   - limit: The maximum number of items per page.
+//   - offset: The number of items skipped before the current page.
+//
+// Returns:
+//
+//	A pointer to a Page instance.
+func NewPage[T any](data []T, total int64, limit, offset int) *Page[T] {
+	if data == nil {
+		data = make([]T, 0)
+	}
+	return &Page[T]{
+		Data:   data,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
@@ -0,0 +1,45 @@
+package response
+
+import (
+	"time"
+
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// SessionSummaryResponse represents the play of a single game session of the authenticated user.
+type SessionSummaryResponse struct {
+	ID           uint       `json:"id"`                     // ID of the session, as recorded with its spins
+	StartedAt    time.Time  `json:"started_at"`             // Time the session started
+	EndedAt      *time.Time `json:"ended_at,omitempty"`     // Time the session ended; absent while it may still be active
+	LastSpinAt   *time.Time `json:"last_spin_at,omitempty"` // Time of the last spin; absent if none was played
+	TotalSpins   int64      `json:"total_spins"`            // Number of spins played
+	TotalWagered Money      `json:"total_wagered"`          // Sum of all bet amounts
+	TotalWon     Money      `json:"total_won"`              // Sum of all win amounts
+	Net          Money      `json:"net"`                    // Total won minus total wagered
+}
+
+// SessionSummariesFromModels converts a slice of SessionSummary models to a slice of
+// SessionSummaryResponse instances.
+//
+// Parameters:
+//   - models: A slice of pointers to models.SessionSummary instances.
+//
+// Returns:
+//
+//	A slice of pointers to SessionSummaryResponse instances.
+func SessionSummariesFromModels(models []*models.SessionSummary) []*SessionSummaryResponse {
+	res := make([]*SessionSummaryResponse, 0, len(models))
+	for _, model := range models {
+		res = append(res, &SessionSummaryResponse{
+			ID:           model.SessionID,
+			StartedAt:    model.StartedAt,
+			EndedAt:      model.EndedAt,
+			LastSpinAt:   model.LastSpinAt,
+			TotalSpins:   model.TotalSpins,
+			TotalWagered: Money(model.TotalWagered),
+			TotalWon:     Money(model.TotalWon),
+			Net:          Money(model.Net()),
+		})
+	}
+	return res
+}
@@ -1,19 +1,105 @@
 package response
 
-import "github.com/vadymlab/slot-game/internal/models"
+import (
+	"strings"
+	"time"
+
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/models"
+)
 
 // SpinResponse represents the response returned after a spin is completed,
-// containing information about the amount won in that spin.
+// containing the game played, the amount won in that spin, the jackpot won, the reels shown, the
+// bonus features triggered, the combinations that paid, the free spins awarded, the win streak, the balance after the spin,
+// whether the win is big enough to celebrate, whether the client should stop spinning after a big win,
+// for a bet placed in another currency than the base currency, the bet and the win in that currency
+// and, once one is due, the reality check of the game session.
 type SpinResponse struct {
-	WinAmount float64 `json:"win_amount"` // The amount the user won on this spin
+	GameID        string                `json:"game_id,omitempty"`             // The game the spin was played in
+	WinAmount     Money                 `json:"win_amount"`                    // The amount the user won on this spin
+	WinMultiplier float64               `json:"win_multiplier,omitempty"`      // The ratio of the amount won to the amount bet; omitted for a loss
+	BigWin        bool                  `json:"big_win,omitempty"`             // Whether the win multiplier exceeded the big win threshold, for clients to celebrate
+	WinCapped     bool                  `json:"win_capped,omitempty"`          // Whether the win was reduced to the maximum win per spin
+	Jackpot       Money                 `json:"jackpot,omitempty"`             // The progressive jackpot won on top of the line wins, included in the win amount
+	Reels         []string              `json:"reels,omitempty"`               // The symbols shown on each reel
+	Bonuses       []string              `json:"bonuses,omitempty"`             // The bonus features triggered by the spin, such as "wild" or "scatter"
+	Wins          []*LineWinResponse    `json:"wins"`                          // The combinations that paid; empty for a loss
+	FreeSpins     int                   `json:"free_spins_awarded,omitempty"`  // The free spins awarded by the scatters of the spin, on top of the wins; omitted if none
+	Streak        int                   `json:"streak,omitempty"`              // Consecutive wins of the user including this spin; omitted after a loss
+	Balance       *Money                `json:"balance,omitempty"`             // The balance of the user after the spin
+	ShouldStop    bool                  `json:"should_stop,omitempty"`         // Whether the win exceeded the user's auto-stop threshold and the client should stop spinning
+	Currency      string                `json:"currency,omitempty"`            // The currency the bet was placed in; omitted for a bet in the base currency
+	ExchangeRate  float64               `json:"exchange_rate,omitempty"`       // The base currency units one unit of the currency was worth at the time of the spin
+	OriginalBet   *Money                `json:"original_bet_amount,omitempty"` // The bet in the currency it was placed in
+	OriginalWin   *Money                `json:"original_win_amount,omitempty"` // The win converted into the currency of the bet
+	RealityCheck  *RealityCheckResponse `json:"reality_check,omitempty"`       // The time and money spent in the game session, for the client to remind the user; omitted until due
+}
+
+// BulkSpinResponse represents the response returned after a bulk spin, containing the result of
+// each spin played, their aggregate, the balance after the last one and, once one is due, the
+// reality check of the game session.
+type BulkSpinResponse struct {
+	Spins        []*SpinResponse       `json:"spins"`                   // The results of the spins played, in order
+	Summary      BulkSpinSummary       `json:"summary"`                 // The aggregate of the spins played
+	Balance      *Money                `json:"balance,omitempty"`       // The balance of the user after the last spin
+	RealityCheck *RealityCheckResponse `json:"reality_check,omitempty"` // The time and money spent in the game session after the batch; omitted until due
+}
+
+// BulkSpinSummary represents the aggregate of the spins of a bulk spin.
+type BulkSpinSummary struct {
+	Requested    int    `json:"requested"`            // The number of spins requested
+	Played       int    `json:"played"`               // The number of spins played
+	TotalWagered Money  `json:"total_wagered"`        // The sum of the bets of the spins played
+	TotalWon     Money  `json:"total_won"`            // The sum of the wins of the spins played
+	Net          Money  `json:"net"`                  // The total won minus the total wagered
+	StoppedBy    string `json:"stopped_by,omitempty"` // Error code of the reason the batch ended early, such as "INSUFFICIENT_FUNDS"
+}
+
+// RealityCheckResponse represents a responsible gaming reminder of the time and money the user has
+// spent in the current game session. Clients show it to the user and acknowledge it, postponing the
+// next one.
+type RealityCheckResponse struct {
+	SessionStartedAt time.Time `json:"session_started_at"` // Time the game session started
+	SessionDuration  int64     `json:"session_duration"`   // Seconds played since the game session started
+	TotalSpins       int64     `json:"total_spins"`        // Number of spins played in the session
+	TotalWagered     Money     `json:"total_wagered"`      // Sum of all bet amounts of the session
+	TotalWon         Money     `json:"total_won"`          // Sum of all win amounts of the session
+	NetLoss          Money     `json:"net_loss"`           // Total wagered minus total won; negative while the user is ahead
+}
+
+// LineWinResponse represents a single paying combination of a spin.
+type LineWinResponse struct {
+	Line     *int   `json:"line,omitempty"`     // Zero-based index of the paying line; omitted for a scatter win
+	Reversed bool   `json:"reversed,omitempty"` // Whether the match was counted from the last reel; only set by games paying both ways
+	Symbol   string `json:"symbol"`             // The matched symbol
+	Count    int    `json:"count"`              // Number of reels showing the matched symbol, wilds included
+	Payout   Money  `json:"payout"`             // Amount paid for the combination, including the streak multiplier but before the maximum win per spin
+}
+
+// ReelEvent represents a single reel symbol revealed by a streamed spin.
+type ReelEvent struct {
+	Reel   int    `json:"reel"`   // Zero-based position of the reel
+	Symbol string `json:"symbol"` // The symbol shown on the reel
+}
+
+// DemoSessionResponse represents the response returned after a demo session is started,
+// containing the play-money balance the session starts with.
+type DemoSessionResponse struct {
+	Balance Money `json:"balance"` // The play-money balance of the demo session
+}
+
+// DemoEndResponse represents the response returned after a demo session is ended.
+type DemoEndResponse struct {
+	Balance  *Money `json:"balance"`  // The play-money balance the demo session ended with; null if no session was active
+	Migrated bool   `json:"migrated"` // Whether the balance was passed to the demo migration hook instead of being discarded
 }
 
 // SpinHistoryResponse represents a structured response for a user's spin history.
 // It includes essential details such as the bet amount, win amount, and the date of each spin.
 type SpinHistoryResponse struct {
-	BetAmount float64 `json:"bet_amount"` // The amount the user bet on this spin
-	WinAmount float64 `json:"win_amount"` // The amount the user won on this spin
-	Date      string  `json:"date"`       // The date and time of this spin, formatted as "YYYY-MM-DD HH:MM:SS"
+	BetAmount Money  `json:"bet_amount"` // The amount the user bet on this spin
+	WinAmount Money  `json:"win_amount"` // The amount the user won on this spin
+	Date      string `json:"date"`       // The date and time of this spin, formatted as "YYYY-MM-DD HH:MM:SS"
 }
 
 // SpinFromModel creates a SpinResponse instance from a Spin model.
@@ -24,10 +110,93 @@ type SpinHistoryResponse struct {
 //
 // Returns:
 //
-//	A pointer to a SpinResponse instance with the win amount mapped from the input model.
+//	A pointer to a SpinResponse instance with the game, win amount and multiplier, big win flag, cap flag, jackpot, reels, bonuses, wins, free spins, streak, balance, auto-stop flag currency amounts and reality check mapped from the input model.
 func SpinFromModel(model *models.Spin) *SpinResponse {
-	return &SpinResponse{
-		WinAmount: model.WinAmount,
+	res := &SpinResponse{
+		GameID:        model.GameID,
+		WinAmount:     Money(model.WinAmount),
+		WinMultiplier: model.WinMultiplier(),
+		BigWin:        model.BigWin,
+		WinCapped:     model.WinCapped,
+		Jackpot:       Money(model.Jackpot),
+		Reels:         model.Reels,
+		Bonuses:       model.Bonuses,
+		Wins:          make([]*LineWinResponse, 0, len(model.Wins)),
+		FreeSpins:     model.FreeSpinsAwarded,
+		Streak:        model.Streak,
+		Balance:       MoneyPtr(model.Balance),
+		ShouldStop:    model.ShouldStop,
+		RealityCheck:  RealityCheckFromModel(model.RealityCheck),
+	}
+	if model.Currency != "" {
+		res.Currency = model.Currency
+		res.ExchangeRate = model.ExchangeRate
+		res.OriginalBet = MoneyPtr(&model.OriginalBetAmount)
+		res.OriginalWin = MoneyPtr(&model.OriginalWinAmount)
+	}
+	for _, win := range model.Wins {
+		lineWin := &LineWinResponse{Reversed: win.Reversed, Symbol: win.Symbol, Count: win.Count, Payout: Money(win.Payout)}
+		if win.Line != models.ScatterLine {
+			line := win.Line
+			lineWin.Line = &line
+		}
+		res.Wins = append(res.Wins, lineWin)
+	}
+	return res
+}
+
+// BulkSpinFromModel creates a BulkSpinResponse instance from a BulkSpin model.
+//
+// Parameters:
+//   - model: A pointer to a models.BulkSpin instance containing the played batch.
+//
+// Returns:
+//
+//	A pointer to a BulkSpinResponse instance with the result of each spin, the aggregate, the final balance and the reality check.
+func BulkSpinFromModel(model *models.BulkSpin) *BulkSpinResponse {
+	res := &BulkSpinResponse{
+		Spins: make([]*SpinResponse, 0, len(model.Spins)),
+		Summary: BulkSpinSummary{
+			Requested:    model.Requested,
+			Played:       len(model.Spins),
+			TotalWagered: Money(model.TotalWagered()),
+			TotalWon:     Money(model.TotalWon()),
+			Net:          Money(model.TotalWon() - model.TotalWagered()),
+		},
+		Balance:      MoneyPtr(model.Balance()),
+		RealityCheck: RealityCheckFromModel(model.RealityCheck),
+	}
+	if model.Stopped != nil {
+		res.Summary.StoppedBy = serviceError.Code(model.Stopped)
+		if res.Summary.StoppedBy == "" {
+			res.Summary.StoppedBy = serviceError.CodeInternal
+		}
+	}
+	for _, spin := range model.Spins {
+		res.Spins = append(res.Spins, SpinFromModel(spin))
+	}
+	return res
+}
+
+// RealityCheckFromModel creates a RealityCheckResponse instance from a RealityCheck model.
+//
+// Parameters:
+//   - model: A pointer to a models.RealityCheck instance; may be nil.
+//
+// Returns:
+//
+//	A pointer to a RealityCheckResponse instance with the session start, duration and amounts, or nil for a nil model.
+func RealityCheckFromModel(model *models.RealityCheck) *RealityCheckResponse {
+	if model == nil {
+		return nil
+	}
+	return &RealityCheckResponse{
+		SessionStartedAt: model.StartedAt,
+		SessionDuration:  int64(model.Duration / time.Second),
+		TotalSpins:       model.TotalSpins,
+		TotalWagered:     Money(model.TotalWagered),
+		TotalWon:         Money(model.TotalWon),
+		NetLoss:          Money(model.NetLoss()),
 	}
 }
 
@@ -42,8 +211,8 @@ func SpinFromModel(model *models.Spin) *SpinResponse {
 //	A pointer to a SpinHistoryResponse instance containing the mapped data from the input model.
 func SpinHistoryFromModel(model *models.Spin) *SpinHistoryResponse {
 	return &SpinHistoryResponse{
-		BetAmount: model.BetAmount,
-		WinAmount: model.WinAmount,
+		BetAmount: Money(model.BetAmount),
+		WinAmount: Money(model.WinAmount),
 		Date:      model.CreatedAt.Format("2006-01-02 15:04:05"),
 	}
 }
@@ -59,9 +228,27 @@ func SpinHistoryFromModel(model *models.Spin) *SpinHistoryResponse {
 //	A slice of pointers to SpinHistoryResponse instances, with each entry containing mapped data
 //	from a corresponding input model.
 func SpinHistoryFromModels(models []*models.Spin) []*SpinHistoryResponse {
-	var res []*SpinHistoryResponse
+	res := make([]*SpinHistoryResponse, 0, len(models))
 	for _, model := range models {
 		res = append(res, SpinHistoryFromModel(model))
 	}
 	return res
 }
+
+// SpinHistoryCSVHeader is the header row of the spin history CSV export.
+var SpinHistoryCSVHeader = []string{"date", "bet_amount", "win_amount", "reels"}
+
+// SpinHistoryCSVRecord converts a Spin model instance to a row of the spin history CSV export,
+// matching SpinHistoryCSVHeader. The reels are separated by spaces and empty for spins played
+// before the reels were stored.
+//
+// Parameters:
+//   - model: A pointer to a models.Spin instance containing the original spin data.
+//
+// Returns:
+//
+//	The CSV fields of the spin.
+func SpinHistoryCSVRecord(model *models.Spin) []string {
+	history := SpinHistoryFromModel(model)
+	return []string{history.Date, history.BetAmount.String(), history.WinAmount.String(), strings.Join(model.Reels, " ")}
+}
@@ -0,0 +1,40 @@
+package response
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+func TestSpinFromModel_Wins(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spin     *models.Spin
+		expected string
+	}{
+		{"NoWin", &models.Spin{BetAmount: 5}, `{"win_amount":0,"wins":[]}`},
+		{"LineAndScatter", &models.Spin{BetAmount: 5, WinAmount: 35, Wins: []models.LineWin{
+			{Line: 0, Symbol: "A", Count: 3, Multiplier: 10, Payout: 30},
+			{Line: models.ScatterLine, Symbol: "S", Count: 2, Multiplier: 1, Payout: 5},
+		}}, `{"win_amount":35,"win_multiplier":7,"wins":[{"line":0,"symbol":"A","count":3,"payout":30},{"symbol":"S","count":2,"payout":5}]}`},
+		{"LineWinAndFreeSpins", &models.Spin{BetAmount: 5, WinAmount: 35, FreeSpinsAwarded: 10, Bonuses: []string{models.BonusScatter}, Wins: []models.LineWin{
+			{Line: 0, Symbol: "A", Count: 3, Multiplier: 10, Payout: 30},
+			{Line: models.ScatterLine, Symbol: "S", Count: 2, Multiplier: 1, Payout: 5},
+		}}, `{"win_amount":35,"win_multiplier":7,"bonuses":["scatter"],"free_spins_awarded":10,"wins":[{"line":0,"symbol":"A","count":3,"payout":30},{"symbol":"S","count":2,"payout":5}]}`},
+		{"BigWin", &models.Spin{BetAmount: 2, WinAmount: 100, BigWin: true},
+			`{"win_amount":100,"win_multiplier":50,"big_win":true,"wins":[]}`},
+		{"Jackpot", &models.Spin{BetAmount: 10, WinAmount: 1040.5, Jackpot: 1040.5, Bonuses: []string{models.BonusJackpot}},
+			`{"win_amount":1040.5,"win_multiplier":104.05,"jackpot":1040.5,"bonuses":["jackpot"],"wins":[]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(SpinFromModel(tc.spin))
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(data))
+		})
+	}
+}
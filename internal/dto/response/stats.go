@@ -0,0 +1,32 @@
+package response
+
+import "github.com/vadymlab/slot-game/internal/models"
+
+// SpinStatsResponse represents the play statistics of the authenticated user.
+type SpinStatsResponse struct {
+	TotalSpins   int64   `json:"total_spins"`   // Number of spins played
+	TotalWagered Money   `json:"total_wagered"` // Sum of all bet amounts
+	TotalWon     Money   `json:"total_won"`     // Sum of all win amounts
+	Net          Money   `json:"net"`           // Total won minus total wagered
+	BiggestWin   Money   `json:"biggest_win"`   // Largest win of a single spin
+	WinRate      float64 `json:"win_rate"`      // Share of spins with a win, between 0 and 1
+}
+
+// SpinStatsFromModel creates a SpinStatsResponse instance from a SpinStats model.
+//
+// Parameters:
+//   - model: A pointer to a models.SpinStats instance containing the aggregated statistics.
+//
+// Returns:
+//
+//	A pointer to a SpinStatsResponse instance with the statistics mapped from the input model.
+func SpinStatsFromModel(model *models.SpinStats) *SpinStatsResponse {
+	return &SpinStatsResponse{
+		TotalSpins:   model.TotalSpins,
+		TotalWagered: Money(model.TotalWagered),
+		TotalWon:     Money(model.TotalWon),
+		Net:          Money(model.Net()),
+		BiggestWin:   Money(model.BiggestWin),
+		WinRate:      model.WinRate(),
+	}
+}
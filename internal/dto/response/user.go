@@ -3,6 +3,7 @@ package response
 import (
 	"github.com/google/uuid"
 	"github.com/vadymlab/slot-game/internal/models"
+	"time"
 )
 
 // LoginResponse represents the response body for a successful login operation.
@@ -12,11 +13,100 @@ type LoginResponse struct {
 }
 
 // ProfileResponse represents the response body for retrieving a user's profile information.
-// It includes the user's unique identifier, login, and wallet balance.
+// It includes the user's unique identifier, login, base currency balance and the balances in all currencies.
 type ProfileResponse struct {
-	ID      *uuid.UUID `json:"id"`      // Unique identifier for the user
-	Login   string     `json:"login"`   // User's login name
-	Balance float64    `json:"balance"` // User's current wallet balance
+	ID        *uuid.UUID         `json:"id"`         // Unique identifier for the user
+	Login     string             `json:"login"`      // User's login name
+	Balance   Money              `json:"balance"`    // User's current balance in the base currency
+	Balances  []*BalanceResponse `json:"balances"`   // User's balances per currency, the base currency first
+	FreeSpins int                `json:"free_spins"` // Free spins awarded to the user by scatters
+}
+
+// BalanceResponse represents the balance of a user in a single currency.
+type BalanceResponse struct {
+	Currency string `json:"currency"` // ISO 4217 currency code
+	Amount   Money  `json:"amount"`   // Balance in the currency
+}
+
+// ProfileFromModel creates a ProfileResponse instance from a User model.
+//
+// Parameters:
+//   - user: A pointer to a models.User instance containing user data.
+//   - balances: The user's balances per currency, the base currency first.
+//
+// Returns:
+//
+//	A pointer to a ProfileResponse instance containing the user's ID, login, balances and free spins.
+func ProfileFromModel(user *models.User, balances []*models.Balance) *ProfileResponse {
+	res := &ProfileResponse{
+		ID:        user.ExternalID,
+		Login:     user.Login,
+		Balance:   Money(user.Balance),
+		Balances:  make([]*BalanceResponse, 0, len(balances)),
+		FreeSpins: user.FreeSpins,
+	}
+	for _, balance := range balances {
+		res.Balances = append(res.Balances, &BalanceResponse{Currency: balance.Currency, Amount: Money(balance.Amount)})
+	}
+	return res
+}
+
+// SettingsResponse represents the play settings of a user.
+type SettingsResponse struct {
+	AutoStopWin *Money `json:"auto_stop_win"` // Win above which the client is told to stop spinning; null when the server default applies
+}
+
+// SettingsFromModel creates a SettingsResponse instance from a User model.
+//
+// Parameters:
+//   - user: A pointer to a models.User instance containing the user's settings.
+//
+// Returns:
+//
+//	A pointer to a SettingsResponse instance containing the user's auto-stop threshold.
+func SettingsFromModel(user *models.User) *SettingsResponse {
+	return &SettingsResponse{AutoStopWin: MoneyPtr(user.AutoStopWin)}
+}
+
+// DepositLimitsResponse represents the deposit limits of a user.
+type DepositLimitsResponse struct {
+	PerTransaction *Money                        `json:"per_transaction"` // Largest single deposit; null when unlimited
+	Daily          *Money                        `json:"daily"`           // Largest sum of deposits of a day; null when unlimited
+	Pending        *PendingDepositLimitsResponse `json:"pending"`         // Raised or removed limits waiting out the cooldown; null when none are pending
+}
+
+// PendingDepositLimitsResponse represents raised or removed deposit limits waiting out the cooldown.
+type PendingDepositLimitsResponse struct {
+	PerTransaction *Money    `json:"per_transaction"` // Largest single deposit once the limits apply; null when unlimited
+	Daily          *Money    `json:"daily"`           // Largest sum of deposits of a day once the limits apply; null when unlimited
+	EffectiveAt    time.Time `json:"effective_at"`    // Time the pending limits apply from
+}
+
+// DepositLimitsFromModel creates a DepositLimitsResponse instance from a User model.
+//
+// Parameters:
+//   - user: A pointer to a models.User instance containing the user's deposit limits.
+//   - now: The time at which the limits are reported.
+//
+// Returns:
+//
+//	A pointer to a DepositLimitsResponse instance containing the limits in effect and the pending ones.
+func DepositLimitsFromModel(user *models.User, now time.Time) *DepositLimitsResponse {
+	limits := user.DepositLimits(now)
+	res := &DepositLimitsResponse{PerTransaction: MoneyPtr(limits.PerTransaction), Daily: MoneyPtr(limits.Daily)}
+	if pending := user.PendingDepositLimits(now); pending != nil {
+		res.Pending = &PendingDepositLimitsResponse{
+			PerTransaction: MoneyPtr(pending.PerTransaction),
+			Daily:          MoneyPtr(pending.Daily),
+			EffectiveAt:    *user.DepositLimitsPendingAt,
+		}
+	}
+	return res
+}
+
+// SelfExclusionResponse represents the self-exclusion of a user.
+type SelfExclusionResponse struct {
+	ExcludedUntil *time.Time `json:"excluded_until"` // End of the self-exclusion, until which the user can neither spin nor deposit
 }
 
 // RegisterResponse represents the response body for a successful user registration.
@@ -41,3 +131,36 @@ func RegisterFromModel(user *models.User) *RegisterResponse {
 		Login: user.Login,
 	}
 }
+
+// AuthEventResponse represents a single entry of the user's authentication audit log.
+type AuthEventResponse struct {
+	Type      string `json:"type"`       // Operation, such as "login", "register" or "login_change"
+	Success   bool   `json:"success"`    // Whether the operation succeeded
+	IP        string `json:"ip"`         // Client IP address the operation was made from
+	UserAgent string `json:"user_agent"` // User-Agent header of the client
+	TraceID   string `json:"trace_id"`   // Trace ID of the request
+	Date      string `json:"date"`       // The date and time of the operation, formatted as "YYYY-MM-DD HH:MM:SS"
+}
+
+// AuthEventsFromModels converts a slice of AuthEvent model instances to a slice of AuthEventResponse instances.
+//
+// Parameters:
+//   - events: A slice of pointers to models.AuthEvent instances.
+//
+// Returns:
+//
+//	A slice of pointers to AuthEventResponse instances, one for each event.
+func AuthEventsFromModels(events []*models.AuthEvent) []*AuthEventResponse {
+	res := make([]*AuthEventResponse, 0, len(events))
+	for _, event := range events {
+		res = append(res, &AuthEventResponse{
+			Type:      event.Type,
+			Success:   event.Success,
+			IP:        event.IP,
+			UserAgent: event.UserAgent,
+			TraceID:   event.TraceID,
+			Date:      event.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return res
+}
@@ -1,13 +1,58 @@
 package response
 
+import "github.com/vadymlab/slot-game/internal/models"
+
 // DepositResponse represents the response body for a successful deposit transaction.
-// It includes the updated wallet balance after the deposit.
+// It includes the updated wallet balance after the deposit and the bonus credited by a promo code.
 type DepositResponse struct {
-	Balance float64 `json:"balance"` // Updated wallet balance after the deposit transaction
+	Balance Money `json:"balance"`         // Updated wallet balance after the deposit transaction
+	Bonus   Money `json:"bonus,omitempty"` // Bonus credited by the applied promo code
 }
 
 // WithdrawResponse represents the response body for a successful withdrawal transaction.
-// It includes the updated wallet balance after the withdrawal.
+// It includes the updated wallet balance after the withdrawal and, when withdrawals await
+// admin approval, the pending withdrawal holding the amount.
 type WithdrawResponse struct {
-	Balance float64 `json:"balance"` // Updated wallet balance after the withdrawal transaction
+	Balance    Money               `json:"balance"`              // Updated wallet balance after the withdrawal transaction
+	Withdrawal *WithdrawalResponse `json:"withdrawal,omitempty"` // The pending withdrawal, if withdrawals await approval
+}
+
+// TransferResponse represents the response body for a successful transfer to another user.
+// It includes the sender's updated wallet balance after the transfer.
+type TransferResponse struct {
+	Balance     Money  `json:"balance"`                                                     // Updated wallet balance of the sender after the transfer
+	Amount      Money  `json:"amount"`                                                      // The transferred amount
+	RecipientID string `json:"recipient_id" example:"6ba7b810-9dad-11d1-80b4-00c04fd430c8"` // External ID of the user who received the funds
+}
+
+// WithdrawalResponse represents a withdrawal awaiting or past admin approval.
+type WithdrawalResponse struct {
+	ID        uint   `json:"id"`                   // The numeric ID of the withdrawal
+	Amount    Money  `json:"amount"`               // The amount held for the withdrawal
+	Status    string `json:"status"`               // The approval state: pending, approved or rejected
+	Reason    string `json:"reason,omitempty"`     // The reason the withdrawal was rejected
+	CreatedAt string `json:"created_at"`           // The date and time the withdrawal was requested, formatted as "YYYY-MM-DD HH:MM:SS"
+	DecidedAt string `json:"decided_at,omitempty"` // The date and time the withdrawal was approved or rejected, formatted as "YYYY-MM-DD HH:MM:SS"
+}
+
+// WithdrawalFromModel converts a PendingWithdrawal model instance to a WithdrawalResponse instance.
+//
+// Parameters:
+//   - model: A pointer to a models.PendingWithdrawal instance.
+//
+// Returns:
+//
+//	A pointer to a WithdrawalResponse instance containing the mapped data from the input model.
+func WithdrawalFromModel(model *models.PendingWithdrawal) *WithdrawalResponse {
+	res := &WithdrawalResponse{
+		ID:        model.ID,
+		Amount:    Money(model.Amount),
+		Status:    model.Status,
+		Reason:    model.Reason,
+		CreatedAt: model.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if model.DecidedAt != nil {
+		res.DecidedAt = model.DecidedAt.Format("2006-01-02 15:04:05")
+	}
+	return res
 }
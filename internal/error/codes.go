@@ -0,0 +1,105 @@
+package error
+
+import "errors"
+
+// Stable machine-readable error codes returned to API clients alongside error messages.
+const (
+	CodeUserNotFound            = "USER_NOT_FOUND"             // The requested user does not exist
+	CodeUserExists              = "USER_EXISTS"                // A user with the same login already exists
+	CodeInvalidCreds            = "INVALID_CREDENTIALS"        // The login or password is incorrect
+	CodeInsufficientFunds       = "INSUFFICIENT_FUNDS"         // The user's balance does not cover the operation
+	CodeInvalidAmount           = "INVALID_AMOUNT"             // The transaction amount is invalid
+	CodeInvalidPeriod           = "INVALID_PERIOD"             // The requested aggregation period is not supported
+	CodeAccountLocked           = "ACCOUNT_LOCKED"             // The login is locked after too many failed attempts
+	CodeNonceReused             = "NONCE_REUSED"               // The login request replays a used nonce
+	CodeTooManySessions         = "TOO_MANY_SESSIONS"          // The user already has the maximum number of active login sessions
+	CodeSpinInProgress          = "SPIN_IN_PROGRESS"           // The user already has the maximum number of spins in flight
+	CodeSpinLimitReached        = "SPIN_LIMIT_REACHED"         // The user has made the maximum number of spins of the day
+	CodeInvalidSpinCount        = "INVALID_SPIN_COUNT"         // The bulk spin count exceeds the allowed maximum
+	CodeGameNotFound            = "GAME_NOT_FOUND"             // The spin names a game that does not exist
+	CodeSelfExcluded            = "SELF_EXCLUDED"              // The user has excluded themselves from spinning and depositing
+	CodeAccountFrozen           = "ACCOUNT_FROZEN"             // An admin froze the account pending an investigation
+	CodeNoFreeSpins             = "NO_FREE_SPINS"              // The user has no free spins left to play
+	CodeDepositLimitExceeded    = "DEPOSIT_LIMIT_EXCEEDED"     // The deposit exceeds the user's per-transaction or daily deposit limit
+	CodeInvalidForcedOutcome    = "INVALID_FORCED_OUTCOME"     // The forced spin outcome does not fit the game
+	CodeUnsupportedCurrency     = "UNSUPPORTED_CURRENCY"       // The bet is placed in a currency without an exchange rate
+	CodeDemoDisabled            = "DEMO_DISABLED"              // A demo spin was requested while demo mode is disabled
+	CodeInvalidBetDenomination  = "INVALID_BET_DENOMINATION"   // The bet is not one of the allowed denominations
+	CodeIdempotencyKeyReused    = "IDEMPOTENCY_KEY_REUSED"     // The idempotency key was used for a different registration
+	CodePromoNotFound           = "PROMO_NOT_FOUND"            // The promo code does not exist
+	CodePromoExpired            = "PROMO_EXPIRED"              // The promo code has expired
+	CodePromoLimitReached       = "PROMO_LIMIT_REACHED"        // The user has exhausted the promo code
+	CodePromoMinDeposit         = "PROMO_MIN_DEPOSIT"          // The deposit is below the promo code minimum
+	CodeSpinNotFound            = "SPIN_NOT_FOUND"             // The spin does not exist
+	CodeSpinAlreadyVoided       = "SPIN_ALREADY_VOIDED"        // The spin has already been voided
+	CodeWithdrawalNotFound      = "WITHDRAWAL_NOT_FOUND"       // The withdrawal does not exist
+	CodeWithdrawalNotPending    = "WITHDRAWAL_NOT_PENDING"     // The withdrawal has already been approved or rejected
+	CodeWithdrawalNotAllowedYet = "WITHDRAWAL_NOT_ALLOWED_YET" // The account is too new to withdraw
+	CodeSelfTransfer            = "SELF_TRANSFER"              // The transfer names the sender as the recipient
+	CodeRecipientNotFound       = "RECIPIENT_NOT_FOUND"        // The recipient of the transfer does not exist
+	CodeRecipientUnavailable    = "RECIPIENT_UNAVAILABLE"      // The recipient of the transfer may not receive funds
+	CodeValidation              = "VALIDATION_ERROR"           // The request failed field validation
+	CodeBadRequest              = "BAD_REQUEST"                // The request is malformed
+	CodeUnauthorized            = "UNAUTHORIZED"               // The request is not authenticated
+	CodeForbidden               = "FORBIDDEN"                  // The authenticated user may not perform the request
+	CodeNotFound                = "NOT_FOUND"                  // The requested resource does not exist
+	CodeConflict                = "CONFLICT"                   // The request conflicts with the current state
+	CodeTooManyRequests         = "TOO_MANY_REQUESTS"          // The user has made too many requests
+	CodeNotAcceptable           = "NOT_ACCEPTABLE"             // The client accepts none of the media types of the endpoint
+	CodeUnsupportedMediaType    = "UNSUPPORTED_MEDIA_TYPE"     // The request body is not in a media type the endpoint reads
+	CodeMaintenance             = "MAINTENANCE"                // The endpoint is unavailable during maintenance
+	CodeServerBusy              = "SERVER_BUSY"                // The server is handling the maximum number of requests at once
+	CodeInternal                = "INTERNAL_ERROR"             // An unexpected server error occurred
+)
+
+// codes maps each predefined error to its stable error code.
+var codes = []struct {
+	err  error
+	code string
+}{
+	{ErrUserNotFound, CodeUserNotFound},
+	{ErrUserExists, CodeUserExists},
+	{ErrInvalidPass, CodeInvalidCreds},
+	{ErrInsufficientFunds, CodeInsufficientFunds},
+	{ErrInvalidAmount, CodeInvalidAmount},
+	{ErrInvalidPeriod, CodeInvalidPeriod},
+	{ErrAccountLocked, CodeAccountLocked},
+	{ErrNonceReused, CodeNonceReused},
+	{ErrTooManySessions, CodeTooManySessions},
+	{ErrSpinInProgress, CodeSpinInProgress},
+	{ErrSpinLimitReached, CodeSpinLimitReached},
+	{ErrInvalidSpinCount, CodeInvalidSpinCount},
+	{ErrGameNotFound, CodeGameNotFound},
+	{ErrSelfExcluded, CodeSelfExcluded},
+	{ErrAccountFrozen, CodeAccountFrozen},
+	{ErrNoFreeSpins, CodeNoFreeSpins},
+	{ErrDepositLimitExceeded, CodeDepositLimitExceeded},
+	{ErrInvalidForcedOutcome, CodeInvalidForcedOutcome},
+	{ErrUnsupportedCurrency, CodeUnsupportedCurrency},
+	{ErrDemoDisabled, CodeDemoDisabled},
+	{ErrInvalidBetDenomination, CodeInvalidBetDenomination},
+	{ErrIdempotencyKeyReused, CodeIdempotencyKeyReused},
+	{ErrPromoNotFound, CodePromoNotFound},
+	{ErrPromoExpired, CodePromoExpired},
+	{ErrPromoLimitReached, CodePromoLimitReached},
+	{ErrPromoMinDeposit, CodePromoMinDeposit},
+	{ErrSpinNotFound, CodeSpinNotFound},
+	{ErrSpinAlreadyVoided, CodeSpinAlreadyVoided},
+	{ErrWithdrawalNotFound, CodeWithdrawalNotFound},
+	{ErrWithdrawalNotPending, CodeWithdrawalNotPending},
+	{ErrWithdrawalNotAllowedYet, CodeWithdrawalNotAllowedYet},
+	{ErrSelfTransfer, CodeSelfTransfer},
+	{ErrRecipientNotFound, CodeRecipientNotFound},
+	{ErrRecipientUnavailable, CodeRecipientUnavailable},
+}
+
+// Code returns the stable error code for err, or an empty string if err
+// is not (and does not wrap) one of the predefined errors.
+func Code(err error) string {
+	for _, c := range codes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return ""
+}
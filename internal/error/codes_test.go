@@ -0,0 +1,58 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCode(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected string
+	}{
+		{ErrUserNotFound, CodeUserNotFound},
+		{ErrUserExists, CodeUserExists},
+		{ErrInvalidPass, CodeInvalidCreds},
+		{ErrInsufficientFunds, CodeInsufficientFunds},
+		{ErrInvalidAmount, CodeInvalidAmount},
+		{ErrInvalidPeriod, CodeInvalidPeriod},
+		{ErrAccountLocked, CodeAccountLocked},
+		{ErrNonceReused, CodeNonceReused},
+		{ErrTooManySessions, CodeTooManySessions},
+		{ErrSpinLimitReached, CodeSpinLimitReached},
+		{ErrInvalidSpinCount, CodeInvalidSpinCount},
+		{ErrGameNotFound, CodeGameNotFound},
+		{ErrSelfExcluded, CodeSelfExcluded},
+		{ErrAccountFrozen, CodeAccountFrozen},
+		{ErrNoFreeSpins, CodeNoFreeSpins},
+		{ErrDepositLimitExceeded, CodeDepositLimitExceeded},
+		{ErrInvalidForcedOutcome, CodeInvalidForcedOutcome},
+		{ErrUnsupportedCurrency, CodeUnsupportedCurrency},
+		{ErrInvalidBetDenomination, CodeInvalidBetDenomination},
+		{ErrIdempotencyKeyReused, CodeIdempotencyKeyReused},
+		{ErrPromoNotFound, CodePromoNotFound},
+		{ErrPromoExpired, CodePromoExpired},
+		{ErrPromoLimitReached, CodePromoLimitReached},
+		{ErrPromoMinDeposit, CodePromoMinDeposit},
+		{ErrSpinNotFound, CodeSpinNotFound},
+		{ErrSpinAlreadyVoided, CodeSpinAlreadyVoided},
+		{ErrWithdrawalNotFound, CodeWithdrawalNotFound},
+		{ErrWithdrawalNotPending, CodeWithdrawalNotPending},
+		{ErrWithdrawalNotAllowedYet, CodeWithdrawalNotAllowedYet},
+		{ErrSelfTransfer, CodeSelfTransfer},
+		{ErrRecipientNotFound, CodeRecipientNotFound},
+		{ErrRecipientUnavailable, CodeRecipientUnavailable},
+		{fmt.Errorf("withdraw: %w", ErrInsufficientFunds), CodeInsufficientFunds},
+		{NewFundsShortfall(20, 50), CodeInsufficientFunds},
+		{errors.New("connection refused"), ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tc.expected, Code(tc.err))
+		})
+	}
+}
@@ -2,11 +2,28 @@ package error
 
 // Predefined user-related errors.
 var (
-	ErrUserNotFound      = &UserNotFound{}      // Error for when a user cannot be found
-	ErrUserExists        = &UserAlreadyExists{} // Error for when a user already exists during registration
-	ErrInvalidPass       = &InvalidPassword{}   // Error for when user credentials are incorrect
-	ErrInsufficientFunds = &InefficientFunds{}  // Error for when a user has insufficient funds for a transaction
-	ErrInvalidAmount     = &InefficientFunds{}  // Error for when a transaction amount is invalid
+	ErrUserNotFound           = &UserNotFound{}           // Error for when a user cannot be found
+	ErrUserExists             = &UserAlreadyExists{}      // Error for when a user already exists during registration
+	ErrInvalidPass            = &InvalidPassword{}        // Error for when user credentials are incorrect
+	ErrInsufficientFunds      = &InefficientFunds{}       // Error for when a user has insufficient funds for a transaction
+	ErrInvalidAmount          = &InvalidAmount{}          // Error for when a transaction amount is invalid
+	ErrInvalidPeriod          = &InvalidPeriod{}          // Error for when a leaderboard period is not supported
+	ErrAccountLocked          = &AccountLocked{}          // Error for when a login is locked after too many failed attempts
+	ErrNonceReused            = &NonceReused{}            // Error for when a login request replays a used nonce
+	ErrTooManySessions        = &TooManySessions{}        // Error for when a login exceeds the user's maximum number of active sessions
+	ErrSpinInProgress         = &SpinInProgress{}         // Error for when a user already has the maximum number of spins in flight
+	ErrDemoDisabled           = &DemoDisabled{}           // Error for when a demo spin is requested while demo mode is disabled
+	ErrInvalidBetDenomination = &InvalidBetDenomination{} // Error for when a bet is not one of the allowed denominations
+	ErrIdempotencyKeyReused   = &IdempotencyKeyReused{}   // Error for when a registration reuses an idempotency key with other credentials
+	ErrSpinLimitReached       = &SpinLimitReached{}       // Error for when a user has made the maximum number of spins of the day
+	ErrInvalidSpinCount       = &InvalidSpinCount{}       // Error for when a bulk spin asks for more spins than allowed
+	ErrGameNotFound           = &GameNotFound{}           // Error for when a spin names a game that does not exist
+	ErrSelfExcluded           = &SelfExcluded{}           // Error for when a self-excluded user spins or deposits
+	ErrDepositLimitExceeded   = &DepositLimitExceeded{}   // Error for when a deposit exceeds the user's deposit limits
+	ErrInvalidForcedOutcome   = &InvalidForcedOutcome{}   // Error for when a forced spin outcome does not fit the game
+	ErrUnsupportedCurrency    = &UnsupportedCurrency{}    // Error for when a bet is placed in a currency without an exchange rate
+	ErrAccountFrozen          = &AccountFrozen{}          // Error for when a frozen user logs in, spins, deposits or withdraws
+	ErrNoFreeSpins            = &NoFreeSpins{}            // Error for when a user without free spins plays a free spin
 )
 
 // UserNotFound represents an error for when a requested user does not exist.
@@ -24,6 +41,59 @@ type InefficientFunds struct{}
 // InvalidAmount represents an error for an invalid transaction amount.
 type InvalidAmount struct{}
 
+// InvalidPeriod represents an error for an unsupported aggregation period.
+type InvalidPeriod struct{}
+
+// AccountLocked represents an error for a login temporarily locked after too many failed attempts.
+type AccountLocked struct{}
+
+// NonceReused represents an error for a replayed login request.
+type NonceReused struct{}
+
+// TooManySessions represents an error for a login rejected because the user already has the
+// maximum number of active login sessions.
+type TooManySessions struct{}
+
+// SpinInProgress represents an error for a spin overlapping the user's in-flight spins.
+type SpinInProgress struct{}
+
+// DemoDisabled represents an error for a demo request while demo mode is disabled.
+type DemoDisabled struct{}
+
+// InvalidBetDenomination represents an error for a bet outside the allowed denominations.
+type InvalidBetDenomination struct{}
+
+// IdempotencyKeyReused represents an error for an idempotency key reused for a different registration.
+type IdempotencyKeyReused struct{}
+
+// SpinLimitReached represents an error for a spin beyond the user's daily spin limit.
+type SpinLimitReached struct{}
+
+// InvalidSpinCount represents an error for a bulk spin count outside the allowed range.
+type InvalidSpinCount struct{}
+
+// GameNotFound represents an error for a spin of an unknown game.
+type GameNotFound struct{}
+
+// SelfExcluded represents an error for a spin or deposit during the user's self-exclusion.
+type SelfExcluded struct{}
+
+// AccountFrozen represents an error for a login, spin, deposit or withdrawal of an account an admin froze.
+type AccountFrozen struct{}
+
+// NoFreeSpins represents an error for a free spin played by a user who has no free spins left.
+type NoFreeSpins struct{}
+
+// DepositLimitExceeded represents an error for a deposit above the user's per-transaction limit or
+// taking the deposits of the day above the user's daily limit.
+type DepositLimitExceeded struct{}
+
+// InvalidForcedOutcome represents an error for a forced spin outcome that the game cannot show.
+type InvalidForcedOutcome struct{}
+
+// UnsupportedCurrency represents an error for a bet in a currency that has no exchange rate to the base currency.
+type UnsupportedCurrency struct{}
+
 // Error returns the error message for UserNotFound.
 func (cs UserNotFound) Error() string {
 	return "user not found"
@@ -48,3 +118,237 @@ func (cs InefficientFunds) Error() string {
 func (cs InvalidAmount) Error() string {
 	return "invalid amount"
 }
+
+// Error returns the error message for InvalidPeriod.
+func (cs InvalidPeriod) Error() string {
+	return "invalid period"
+}
+
+// Error returns the error message for AccountLocked.
+func (cs AccountLocked) Error() string {
+	return "account temporarily locked due to too many failed login attempts"
+}
+
+// Error returns the error message for NonceReused.
+func (cs NonceReused) Error() string {
+	return "nonce has already been used"
+}
+
+// Error returns the error message for TooManySessions.
+func (cs TooManySessions) Error() string {
+	return "too many active login sessions"
+}
+
+// Error returns the error message for SpinInProgress.
+func (cs SpinInProgress) Error() string {
+	return "another spin is already in progress"
+}
+
+// Error returns the error message for SpinLimitReached.
+func (cs SpinLimitReached) Error() string {
+	return "daily spin limit reached"
+}
+
+// Error returns the error message for InvalidSpinCount.
+func (cs InvalidSpinCount) Error() string {
+	return "spin count exceeds the maximum number of bulk spins"
+}
+
+// Error returns the error message for GameNotFound.
+func (cs GameNotFound) Error() string {
+	return "game not found"
+}
+
+// Error returns the error message for SelfExcluded.
+func (cs SelfExcluded) Error() string {
+	return "account is self-excluded from play"
+}
+
+// Error returns the error message for AccountFrozen.
+func (cs AccountFrozen) Error() string {
+	return "account is frozen pending an investigation"
+}
+
+// Error returns the error message for NoFreeSpins.
+func (cs NoFreeSpins) Error() string {
+	return "no free spins left"
+}
+
+// Error returns the error message for DepositLimitExceeded.
+func (cs DepositLimitExceeded) Error() string {
+	return "deposit exceeds the deposit limit"
+}
+
+// Error returns the error message for InvalidForcedOutcome.
+func (cs InvalidForcedOutcome) Error() string {
+	return "forced outcome does not fit the game"
+}
+
+// Error returns the error message for UnsupportedCurrency.
+func (cs UnsupportedCurrency) Error() string {
+	return "no exchange rate for the currency"
+}
+
+// Error returns the error message for DemoDisabled.
+func (cs DemoDisabled) Error() string {
+	return "demo mode is disabled"
+}
+
+// Error returns the error message for InvalidBetDenomination.
+func (cs InvalidBetDenomination) Error() string {
+	return "bet amount is not an allowed denomination"
+}
+
+// Error returns the error message for IdempotencyKeyReused.
+func (cs IdempotencyKeyReused) Error() string {
+	return "idempotency key has already been used for a different registration"
+}
+
+// Predefined promo code errors.
+var (
+	ErrPromoNotFound     = &PromoNotFound{}     // Error for when a promo code does not exist
+	ErrPromoExpired      = &PromoExpired{}      // Error for when a promo code has expired
+	ErrPromoLimitReached = &PromoLimitReached{} // Error for when a user has exhausted a promo code
+	ErrPromoMinDeposit   = &PromoMinDeposit{}   // Error for when a deposit is below the promo code minimum
+)
+
+// PromoNotFound represents an error for an unknown promo code.
+type PromoNotFound struct{}
+
+// PromoExpired represents an error for an expired promo code.
+type PromoExpired struct{}
+
+// PromoLimitReached represents an error for exceeding the per-user usage limit of a promo code.
+type PromoLimitReached struct{}
+
+// PromoMinDeposit represents an error for a deposit below the promo code minimum.
+type PromoMinDeposit struct{}
+
+// Error returns the error message for PromoNotFound.
+func (cs PromoNotFound) Error() string {
+	return "promo code not found"
+}
+
+// Error returns the error message for PromoExpired.
+func (cs PromoExpired) Error() string {
+	return "promo code expired"
+}
+
+// Error returns the error message for PromoLimitReached.
+func (cs PromoLimitReached) Error() string {
+	return "promo code usage limit reached"
+}
+
+// Error returns the error message for PromoMinDeposit.
+func (cs PromoMinDeposit) Error() string {
+	return "deposit amount is below the promo code minimum"
+}
+
+// Predefined spin administration errors.
+var (
+	ErrSpinNotFound      = &SpinNotFound{}      // Error for when a spin does not exist
+	ErrSpinAlreadyVoided = &SpinAlreadyVoided{} // Error for when a spin has already been voided
+)
+
+// SpinNotFound represents an error for an unknown spin.
+type SpinNotFound struct{}
+
+// SpinAlreadyVoided represents an error for voiding a spin a second time.
+type SpinAlreadyVoided struct{}
+
+// Error returns the error message for SpinNotFound.
+func (cs SpinNotFound) Error() string {
+	return "spin not found"
+}
+
+// Error returns the error message for SpinAlreadyVoided.
+func (cs SpinAlreadyVoided) Error() string {
+	return "spin has already been voided"
+}
+
+// Predefined withdrawal approval errors.
+var (
+	ErrWithdrawalNotFound      = &WithdrawalNotFound{}      // Error for when a pending withdrawal does not exist
+	ErrWithdrawalNotPending    = &WithdrawalNotPending{}    // Error for when a withdrawal has already been approved or rejected
+	ErrWithdrawalNotAllowedYet = &WithdrawalNotAllowedYet{} // Error for when an account is too new to withdraw
+)
+
+// WithdrawalNotFound represents an error for an unknown withdrawal.
+type WithdrawalNotFound struct{}
+
+// WithdrawalNotPending represents an error for deciding on a withdrawal a second time.
+type WithdrawalNotPending struct{}
+
+// WithdrawalNotAllowedYet represents an error for a withdrawal before the account reached the minimum age.
+type WithdrawalNotAllowedYet struct{}
+
+// Error returns the error message for WithdrawalNotFound.
+func (cs WithdrawalNotFound) Error() string {
+	return "withdrawal not found"
+}
+
+// Error returns the error message for WithdrawalNotPending.
+func (cs WithdrawalNotPending) Error() string {
+	return "withdrawal has already been approved or rejected"
+}
+
+// Error returns the error message for WithdrawalNotAllowedYet.
+func (cs WithdrawalNotAllowedYet) Error() string {
+	return "account is too new to withdraw"
+}
+
+// Predefined transfer errors.
+var (
+	ErrSelfTransfer         = &SelfTransfer{}         // Error for when a user transfers funds to themselves
+	ErrRecipientNotFound    = &RecipientNotFound{}    // Error for when the recipient of a transfer does not exist
+	ErrRecipientUnavailable = &RecipientUnavailable{} // Error for when the recipient of a transfer is frozen or self-excluded
+)
+
+// SelfTransfer represents an error for a transfer whose recipient is the sender.
+type SelfTransfer struct{}
+
+// RecipientNotFound represents an error for a transfer to an unknown user.
+type RecipientNotFound struct{}
+
+// RecipientUnavailable represents an error for a transfer to a user who may not receive funds.
+// It does not tell whether the recipient is frozen or self-excluded.
+type RecipientUnavailable struct{}
+
+// Error returns the error message for SelfTransfer.
+func (cs SelfTransfer) Error() string {
+	return "cannot transfer funds to yourself"
+}
+
+// Error returns the error message for RecipientNotFound.
+func (cs RecipientNotFound) Error() string {
+	return "transfer recipient not found"
+}
+
+// Error returns the error message for RecipientUnavailable.
+func (cs RecipientUnavailable) Error() string {
+	return "transfer recipient cannot receive funds"
+}
+
+// FundsShortfall represents insufficient funds together with the balance and the amount missing
+// for the transaction, so that clients can tell how much more is needed. It matches
+// ErrInsufficientFunds.
+type FundsShortfall struct {
+	Balance   float64 // The user's balance when the transaction was refused
+	Shortfall float64 // The amount the balance falls short of the transaction
+}
+
+// NewFundsShortfall returns the insufficient funds error of a transaction of the given amount
+// refused for the given balance.
+func NewFundsShortfall(balance, amount float64) *FundsShortfall {
+	return &FundsShortfall{Balance: balance, Shortfall: amount - balance}
+}
+
+// Error returns the error message for FundsShortfall.
+func (cs *FundsShortfall) Error() string {
+	return ErrInsufficientFunds.Error()
+}
+
+// Unwrap returns ErrInsufficientFunds, so that errors.Is matches the shortfall.
+func (cs *FundsShortfall) Unwrap() error {
+	return ErrInsufficientFunds
+}
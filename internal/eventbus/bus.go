@@ -0,0 +1,129 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"go.uber.org/fx"
+)
+
+// Headers set on every published event.
+const (
+	HeaderEventType = "Event-Type" // Type of the published event
+	HeaderTraceID   = "Trace-ID"   // Trace ID of the request that caused the event
+)
+
+// natsBus implements IEventBus by publishing JSON events to a NATS JetStream stream. The stream
+// stores the events, so that consumers can replay them from any point within the retention.
+type natsBus struct {
+	cfg  *Config             // Event bus settings
+	conn *nats.Conn          // Connection to the NATS server
+	js   jetstream.JetStream // JetStream context acknowledging the stored events
+}
+
+// Publish stores the event in the stream and waits for the acknowledgement. The event ID is sent
+// as the message ID, so that the stream discards the event if a retry publishes it again.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - event: The event to publish.
+//
+// Returns:
+//   - An error if the stream did not acknowledge the event within the timeout.
+func (b *natsBus) Publish(ctx context.Context, event *models.DomainEvent) error {
+	msg, err := newMessage(b.cfg.SubjectPrefix, event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(b.cfg.Timeout)*time.Second)
+	defer cancel()
+	_, err = b.js.PublishMsg(ctx, msg, jetstream.WithMsgID(event.ID))
+	return err
+}
+
+// ensureStream creates the stream capturing the subjects of the events, or updates it to the
+// configured subjects and retention.
+func (b *natsBus) ensureStream(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(b.cfg.Timeout)*time.Second)
+	defer cancel()
+	_, err := b.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     b.cfg.Stream,
+		Subjects: b.cfg.Subjects(),
+		MaxAge:   time.Duration(b.cfg.Retention) * time.Hour,
+	})
+	return err
+}
+
+// Subject returns the subject events of the given type are published on, e.g. "slot.SpinCompleted".
+func Subject(prefix, eventType string) string {
+	return prefix + "." + eventType
+}
+
+// newMessage encodes the event as a JSON message on the subject of its type.
+func newMessage(prefix string, event *models.DomainEvent) (*nats.Msg, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	msg := nats.NewMsg(Subject(prefix, event.Type))
+	msg.Data = body
+	msg.Header.Set(HeaderEventType, event.Type)
+	if event.TraceID != "" {
+		msg.Header.Set(HeaderTraceID, event.TraceID)
+	}
+	return msg, nil
+}
+
+// noopBus discards all events; it is used when no NATS server is configured.
+type noopBus struct{}
+
+// Publish discards the event.
+func (noopBus) Publish(context.Context, *models.DomainEvent) error {
+	return nil
+}
+
+// NewEventBus creates an IEventBus publishing to the configured NATS server. When no URL is
+// configured, a bus discarding all events is returned. The connection is retried in the
+// background, so an unreachable server does not prevent the startup; the stream is set up when
+// the application starts and the pending messages are flushed when it stops.
+//
+// Parameters:
+//   - lc: Fx lifecycle used to set up the stream and to close the connection.
+//   - cfg: Event bus settings.
+//
+// Returns:
+//   - An IEventBus implementation.
+//   - An error if the connection cannot be set up.
+func NewEventBus(lc fx.Lifecycle, cfg *Config) (interfaces.IEventBus, error) {
+	if !cfg.Enabled() {
+		return noopBus{}, nil
+	}
+	conn, err := nats.Connect(cfg.URL, nats.RetryOnFailedConnect(true), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	bus := &natsBus{cfg: cfg, conn: conn, js: js}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := bus.ensureStream(ctx); err != nil {
+				log.FromContext(ctx).Warnf("cannot set up event stream %s, events may not be stored: %v", cfg.Stream, err)
+			}
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			return conn.Drain()
+		},
+	})
+	return bus, nil
+}
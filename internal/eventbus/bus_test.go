@@ -0,0 +1,42 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/models"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestNewMessage_SubjectHeadersAndBody(t *testing.T) {
+	event := &models.DomainEvent{
+		ID:         "1",
+		Type:       models.DomainEventDepositMade,
+		TraceID:    "trace-1",
+		UserID:     "user-1",
+		Amount:     25,
+		OccurredAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	msg, err := newMessage("slot", event)
+
+	require.NoError(t, err)
+	assert.Equal(t, "slot.DepositMade", msg.Subject)
+	assert.Equal(t, models.DomainEventDepositMade, msg.Header.Get(HeaderEventType))
+	assert.Equal(t, "trace-1", msg.Header.Get(HeaderTraceID))
+	var decoded models.DomainEvent
+	require.NoError(t, json.Unmarshal(msg.Data, &decoded))
+	assert.Equal(t, *event, decoded)
+}
+
+func TestNewEventBus_DisabledWithoutURL(t *testing.T) {
+	bus, err := NewEventBus(fxtest.NewLifecycle(t), &Config{})
+
+	require.NoError(t, err)
+	assert.IsType(t, noopBus{}, bus)
+	assert.NoError(t, bus.Publish(context.Background(), &models.DomainEvent{}))
+}
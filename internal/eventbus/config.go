@@ -0,0 +1,117 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Constants defining the event bus configuration flags.
+const (
+	eventBusURL           = "event-bus-url"
+	eventBusSubjectPrefix = "event-bus-subject-prefix"
+	eventBusStream        = "event-bus-stream"
+	eventBusRetention     = "event-bus-retention"
+	eventBusTimeout       = "event-bus-timeout"
+)
+
+// Config represents the settings of the NATS JetStream bus receiving the domain events.
+type Config struct {
+	URL           string // NATS server URL; empty disables the bus
+	SubjectPrefix string // Prefix of the subjects the events are published on, followed by the event type
+	Stream        string // JetStream stream storing the events for replay
+	Retention     int    // Hours the stream keeps the events; 0 keeps them until removed
+	Timeout       int    // Timeout of a single publish in seconds
+}
+
+// Enabled reports whether events should be published.
+func (c *Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// Subjects returns the subjects captured by the stream: every subject below the prefix.
+func (c *Config) Subjects() []string {
+	return []string{c.SubjectPrefix + ".>"}
+}
+
+// Validate checks that an enabled bus has a subject prefix, a stream, a positive timeout and no
+// negative retention.
+//
+// Returns:
+//   - An error listing every invalid setting, or nil if the configuration is valid.
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	var errs []error
+	if c.SubjectPrefix == "" {
+		errs = append(errs, fmt.Errorf("%s is required when %s is set", eventBusSubjectPrefix, eventBusURL))
+	}
+	if c.Stream == "" {
+		errs = append(errs, fmt.Errorf("%s is required when %s is set", eventBusStream, eventBusURL))
+	}
+	if c.Retention < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", eventBusRetention, c.Retention))
+	}
+	if c.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("%s must be positive, got %d", eventBusTimeout, c.Timeout))
+	}
+	return errors.Join(errs...)
+}
+
+// GetEventBusConfig reads the event bus settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the event bus settings.
+//   - (error): An error if the settings are invalid.
+func GetEventBusConfig(c *cli.Context) (*Config, error) {
+	cfg := &Config{
+		URL:           c.String(eventBusURL),
+		SubjectPrefix: c.String(eventBusSubjectPrefix),
+		Stream:        c.String(eventBusStream),
+		Retention:     c.Int(eventBusRetention),
+		Timeout:       c.Int(eventBusTimeout),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Flags defines the CLI flags available for configuring the event bus.
+var Flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    eventBusURL,
+		Usage:   "NATS server URL receiving the spin and wallet events, e.g. nats://nats:4222; empty disables publishing",
+		EnvVars: []string{"EVENT_BUS_URL"},
+	},
+	&cli.StringFlag{
+		Name:    eventBusSubjectPrefix,
+		Value:   "slot",
+		Usage:   "Prefix of the subjects the events are published on, e.g. slot.SpinCompleted",
+		EnvVars: []string{"EVENT_BUS_SUBJECT_PREFIX"},
+	},
+	&cli.StringFlag{
+		Name:    eventBusStream,
+		Value:   "SLOT_EVENTS",
+		Usage:   "JetStream stream storing the events, so that consumers can replay them",
+		EnvVars: []string{"EVENT_BUS_STREAM"},
+	},
+	&cli.IntFlag{
+		Name:    eventBusRetention,
+		Value:   168,
+		Usage:   "Hours the stream keeps the events; 0 keeps them until removed",
+		EnvVars: []string{"EVENT_BUS_RETENTION"},
+	},
+	&cli.IntFlag{
+		Name:    eventBusTimeout,
+		Value:   5,
+		Usage:   "Timeout of publishing a single event in seconds",
+		EnvVars: []string{"EVENT_BUS_TIMEOUT"},
+	},
+}
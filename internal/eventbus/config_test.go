@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{URL: "nats://nats:4222", SubjectPrefix: "slot", Stream: "SLOT_EVENTS", Retention: 168, Timeout: 5}
+	with := func(change func(*Config)) Config {
+		cfg := valid
+		change(&cfg)
+		return cfg
+	}
+
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"Disabled", Config{}, false},
+		{"Enabled", valid, false},
+		{"KeepForever", with(func(c *Config) { c.Retention = 0 }), false},
+		{"MissingSubjectPrefix", with(func(c *Config) { c.SubjectPrefix = "" }), true},
+		{"MissingStream", with(func(c *Config) { c.Stream = "" }), true},
+		{"NegativeRetention", with(func(c *Config) { c.Retention = -1 }), true},
+		{"ZeroTimeout", with(func(c *Config) { c.Timeout = 0 }), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package eventbus
+
+import "go.uber.org/fx"
+
+// Module provides the event bus configuration and the event bus as an Fx module.
+var Module = fx.Options(
+	fx.Provide(GetEventBusConfig),
+	fx.Provide(NewEventBus),
+)
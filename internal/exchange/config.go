@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Constants defining the exchange rate configuration flags.
+const (
+	exchangeRates        = "exchange-rates"
+	exchangeRatesURL     = "exchange-rates-url"
+	exchangeRatesRefresh = "exchange-rates-refresh"
+	exchangeRatesTimeout = "exchange-rates-timeout"
+)
+
+// Config represents the settings of the exchange rates converting bets placed in other currencies.
+type Config struct {
+	Rates   map[string]float64 // Static rates as base currency units per unit of each currency, keyed by ISO 4217 code
+	URL     string             // Endpoint serving the current rates as a JSON object; empty uses the static rates only
+	Refresh int                // Time in seconds for which rates fetched from the endpoint are used before they are fetched again
+	Timeout int                // Timeout of a single rate fetch in seconds
+}
+
+// Enabled reports whether bets can be placed in currencies other than the base currency.
+func (c *Config) Enabled() bool {
+	return len(c.Rates) > 0 || c.URL != ""
+}
+
+// Validate checks that the static rates are positive and that rates fetched from an endpoint have
+// positive timings.
+//
+// Returns:
+//   - An error listing every invalid setting, or nil if the configuration is valid.
+func (c *Config) Validate() error {
+	var errs []error
+	for currency, rate := range c.Rates {
+		if rate <= 0 {
+			errs = append(errs, fmt.Errorf("%s: rate of %s must be positive, got %v", exchangeRates, currency, rate))
+		}
+	}
+	if c.URL == "" {
+		return errors.Join(errs...)
+	}
+	for _, setting := range []struct {
+		name  string
+		value int
+	}{{exchangeRatesRefresh, c.Refresh}, {exchangeRatesTimeout, c.Timeout}} {
+		if setting.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %d", setting.name, setting.value))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// parseRates parses rates in the "CUR:rate" format separated by commas, for example "EUR:1.08,GBP:1.27".
+// Currency codes are upper-cased.
+func parseRates(value string) (map[string]float64, error) {
+	rates := map[string]float64{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		currency, rate, ok := strings.Cut(entry, ":")
+		if !ok || len(currency) != 3 {
+			return nil, fmt.Errorf("invalid exchange rate %q: expected CUR:rate", entry)
+		}
+		amount, err := strconv.ParseFloat(rate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate %q: %w", entry, err)
+		}
+		rates[strings.ToUpper(currency)] = amount
+	}
+	return rates, nil
+}
+
+// GetExchangeConfig reads the exchange rate settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the exchange rate settings.
+//   - (error): An error if the rates cannot be parsed or the settings are invalid.
+func GetExchangeConfig(c *cli.Context) (*Config, error) {
+	rates, err := parseRates(c.String(exchangeRates))
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{
+		Rates:   rates,
+		URL:     c.String(exchangeRatesURL),
+		Refresh: c.Int(exchangeRatesRefresh),
+		Timeout: c.Int(exchangeRatesTimeout),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Flags defines the CLI flags available for configuring the exchange rates.
+var Flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    exchangeRates,
+		Usage:   "Static exchange rates as base currency units per unit of each currency, e.g. \"EUR:1.08,GBP:1.27\"; empty accepts bets in the base currency only",
+		EnvVars: []string{"EXCHANGE_RATES"},
+	},
+	&cli.StringFlag{
+		Name:    exchangeRatesURL,
+		Usage:   "Endpoint serving the current exchange rates as a JSON object such as {\"EUR\": 1.08}; the static rates are used until it answers",
+		EnvVars: []string{"EXCHANGE_RATES_URL"},
+	},
+	&cli.IntFlag{
+		Name:    exchangeRatesRefresh,
+		Value:   300,
+		Usage:   "Time in seconds for which exchange rates fetched from the endpoint are used before they are fetched again",
+		EnvVars: []string{"EXCHANGE_RATES_REFRESH"},
+	},
+	&cli.IntFlag{
+		Name:    exchangeRatesTimeout,
+		Value:   5,
+		Usage:   "Timeout of a single exchange rate fetch in seconds",
+		EnvVars: []string{"EXCHANGE_RATES_TIMEOUT"},
+	},
+}
@@ -0,0 +1,49 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"Disabled", Config{}, false},
+		{"StaticRates", Config{Rates: map[string]float64{"EUR": 1.08}}, false},
+		{"Endpoint", Config{URL: "https://rates.example/latest", Refresh: 300, Timeout: 5}, false},
+		{"ZeroRate", Config{Rates: map[string]float64{"EUR": 0}}, true},
+		{"ZeroRefresh", Config{URL: "https://rates.example/latest", Refresh: 0, Timeout: 5}, true},
+		{"NegativeTimeout", Config{URL: "https://rates.example/latest", Refresh: 300, Timeout: -1}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseRates(t *testing.T) {
+	rates, err := parseRates("eur:1.08, GBP:1.27,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"EUR": 1.08, "GBP": 1.27}, rates)
+
+	rates, err = parseRates("")
+	require.NoError(t, err)
+	assert.Empty(t, rates)
+
+	for _, value := range []string{"EUR", "EURO:1.08", "EUR:abc"} {
+		_, err := parseRates(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,9 @@
+package exchange
+
+import "go.uber.org/fx"
+
+// Module provides the exchange rate configuration and the exchange rate provider as an Fx module.
+var Module = fx.Options(
+	fx.Provide(GetExchangeConfig),
+	fx.Provide(NewProvider),
+)
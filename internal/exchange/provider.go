@@ -0,0 +1,148 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/public-forge/go-logger"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/httpclient"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// minorUnits is the scale of the two decimals the balances are stored with.
+const minorUnits = 100
+
+// ToBase converts an amount of a currency into the base currency, rounded half away from zero to
+// minor units such as cents.
+//
+// Parameters:
+//   - amount: The amount in the currency.
+//   - rate: The number of base currency units one unit of the currency is worth.
+//
+// Returns:
+//   - The amount in the base currency.
+func ToBase(amount, rate float64) float64 {
+	return math.Round(amount*rate*minorUnits) / minorUnits
+}
+
+// FromBase converts an amount of the base currency into a currency, rounded half away from zero to
+// minor units such as cents.
+//
+// Parameters:
+//   - amount: The amount in the base currency.
+//   - rate: The number of base currency units one unit of the currency is worth.
+//
+// Returns:
+//   - The amount in the currency.
+func FromBase(amount, rate float64) float64 {
+	return math.Round(amount/rate*minorUnits) / minorUnits
+}
+
+// staticProvider serves the exchange rates of the configuration.
+type staticProvider struct {
+	rates map[string]float64 // Rates keyed by upper-case currency code
+}
+
+// Rate returns the configured rate of the currency.
+func (p *staticProvider) Rate(_ context.Context, currency string) (float64, error) {
+	rate, ok := p.rates[currency]
+	if !ok {
+		return 0, serviceError.ErrUnsupportedCurrency
+	}
+	return rate, nil
+}
+
+// httpProvider serves the exchange rates published by an external endpoint. The rates are fetched
+// when a rate is needed and the last fetch is older than the refresh interval, and used until the
+// next fetch. A failed fetch keeps the rates of the last successful one, falling back to the static
+// rates before any fetch succeeded, and is not repeated before the refresh interval has passed.
+type httpProvider struct {
+	config    *Config            // Endpoint, refresh interval and the static fallback rates
+	client    *http.Client       // HTTP client used for the fetches
+	mu        sync.Mutex         // Guards the fetched rates and the time of the last fetch
+	rates     map[string]float64 // Rates of the last successful fetch keyed by upper-case currency code; nil before one succeeded
+	fetchedAt time.Time          // Time of the last fetch attempt
+	now       func() time.Time   // Clock deciding when the rates are fetched again
+}
+
+// Rate returns the current rate of the currency, fetching the rates first if they are due.
+func (p *httpProvider) Rate(ctx context.Context, currency string) (float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if now := p.now(); p.fetchedAt.IsZero() || now.Sub(p.fetchedAt) >= time.Duration(p.config.Refresh)*time.Second {
+		p.fetchedAt = now
+		rates, err := p.fetch(ctx)
+		if err != nil {
+			log.FromContext(ctx).Warnf("exchange rate fetch failed, keeping the previous rates: %v", err)
+		} else {
+			p.rates = rates
+		}
+	}
+	rates := p.rates
+	if rates == nil {
+		rates = p.config.Rates
+	}
+	rate, ok := rates[currency]
+	if !ok {
+		return 0, serviceError.ErrUnsupportedCurrency
+	}
+	return rate, nil
+}
+
+// fetch reads the rates from the endpoint, which answers with a JSON object mapping currency codes
+// to their rates. Rates that are not positive are dropped.
+func (p *httpProvider) fetch(ctx context.Context) (map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	var body map[string]float64
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	rates := make(map[string]float64, len(body))
+	for currency, rate := range body {
+		if rate > 0 {
+			rates[strings.ToUpper(currency)] = rate
+		}
+	}
+	return rates, nil
+}
+
+// NewProvider creates the exchange rate provider of the configuration: the external endpoint when
+// one is configured, the static rates otherwise. Without any rates no provider is created, so that
+// bets are only accepted in the base currency.
+//
+// Parameters:
+//   - config: Config containing the static rates and the endpoint.
+//
+// Returns:
+//   - An implementation of IExchangeRateProvider, or nil if no rates are configured.
+func NewProvider(config *Config) interfaces.IExchangeRateProvider {
+	if !config.Enabled() {
+		return nil
+	}
+	if config.URL == "" {
+		return &staticProvider{rates: config.Rates}
+	}
+	return &httpProvider{
+		config: config,
+		client: httpclient.New(time.Duration(config.Timeout) * time.Second),
+		now:    time.Now,
+	}
+}
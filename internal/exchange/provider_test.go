@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+)
+
+func TestConversion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   float64
+		rate     float64
+		toBase   float64
+		fromBase float64
+	}{
+		{"WholeAmount", 10, 1.08, 10.8, 9.26},
+		{"RoundHalfUp", 0.5, 1.25, 0.63, 0.4},
+		{"RoundDown", 3, 0.333, 1, 9.01},
+		{"UnitRate", 7.25, 1, 7.25, 7.25},
+		{"WeakCurrency", 1000, 0.0067, 6.7, 149253.73},
+		{"TinyAmount", 0.01, 0.0067, 0, 1.49},
+		{"Zero", 0, 1.08, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.toBase, ToBase(tc.amount, tc.rate))
+			assert.Equal(t, tc.fromBase, FromBase(tc.amount, tc.rate))
+		})
+	}
+}
+
+func TestNewProvider_StaticRates(t *testing.T) {
+	provider := NewProvider(&Config{Rates: map[string]float64{"EUR": 1.08}})
+
+	rate, err := provider.Rate(context.Background(), "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 1.08, rate)
+
+	_, err = provider.Rate(context.Background(), "GBP")
+	assert.ErrorIs(t, err, serviceError.ErrUnsupportedCurrency)
+}
+
+func TestNewProvider_DisabledWithoutRates(t *testing.T) {
+	assert.Nil(t, NewProvider(&Config{}))
+}
+
+func TestHTTPProvider_CachesRatesUntilRefresh(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// The rate rises with every fetch, revealing which fetch served it
+		if fetches.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"eur": 1.08, "GBP": 1.27, "XXX": 0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"EUR": 1.1}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	provider := NewProvider(&Config{URL: server.URL, Refresh: 60, Timeout: 5}).(*httpProvider)
+	provider.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	rate, err := provider.Rate(ctx, "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 1.08, rate)
+	rate, err = provider.Rate(ctx, "GBP")
+	require.NoError(t, err)
+	assert.Equal(t, 1.27, rate)
+	_, err = provider.Rate(ctx, "XXX")
+	assert.ErrorIs(t, err, serviceError.ErrUnsupportedCurrency, "rates that are not positive are dropped")
+	assert.Equal(t, int32(1), fetches.Load())
+
+	now = now.Add(time.Minute)
+	rate, err = provider.Rate(ctx, "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 1.1, rate)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestHTTPProvider_FailedFetchKeepsPreviousRates(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fetches.Add(1) == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"EUR": 1.08}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	provider := NewProvider(&Config{URL: server.URL, Refresh: 60, Timeout: 5}).(*httpProvider)
+	provider.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := provider.Rate(ctx, "EUR")
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	rate, err := provider.Rate(ctx, "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 1.08, rate, "the failed fetch keeps the rates of the previous one")
+
+	// The failed fetch is not repeated before the refresh interval has passed
+	_, err = provider.Rate(ctx, "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestHTTPProvider_StaticRatesUntilFirstFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	provider := NewProvider(&Config{Rates: map[string]float64{"EUR": 1.05}, URL: server.URL, Refresh: 60, Timeout: 5})
+
+	rate, err := provider.Rate(context.Background(), "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 1.05, rate)
+}
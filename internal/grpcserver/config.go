@@ -0,0 +1,79 @@
+package grpcserver
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Constants defining the gRPC server configuration flags.
+const (
+	grpcHost = "grpc-host"
+	grpcPort = "grpc-port"
+)
+
+// maxPort is the highest TCP port the gRPC server may listen on.
+const maxPort = 65535
+
+// Config represents the address the gRPC API listens on.
+type Config struct {
+	Host string // Host address the gRPC server listens on
+	Port int    // Port the gRPC server listens on; 0 disables the gRPC API
+}
+
+// Enabled reports whether the gRPC API is served.
+func (c *Config) Enabled() bool {
+	return c.Port > 0
+}
+
+// Address returns the host and port the gRPC server listens on.
+func (c *Config) Address() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
+// Validate checks that the port is a valid TCP port or 0.
+//
+// Returns:
+//   - An error if the port is out of range, or nil if the configuration is valid.
+func (c *Config) Validate() error {
+	if c.Port < 0 || c.Port > maxPort {
+		return fmt.Errorf("%s must be between 0 and %d, got %d", grpcPort, maxPort, c.Port)
+	}
+	return nil
+}
+
+// GetGRPCConfig reads the gRPC server settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the gRPC server settings.
+//   - (error): An error if the settings are invalid.
+func GetGRPCConfig(c *cli.Context) (*Config, error) {
+	cfg := &Config{
+		Host: c.String(grpcHost),
+		Port: c.Int(grpcPort),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Flags defines the CLI flags available for configuring the gRPC server.
+var Flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    grpcHost,
+		Value:   "0.0.0.0",
+		Usage:   "gRPC server host address",
+		EnvVars: []string{"GRPC_HOST"},
+	},
+	&cli.IntFlag{
+		Name:    grpcPort,
+		Value:   0,
+		Usage:   "gRPC server port, e.g. 9000; 0 disables the gRPC API",
+		EnvVars: []string{"GRPC_PORT"},
+	},
+}
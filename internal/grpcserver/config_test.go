@@ -0,0 +1,32 @@
+package grpcserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		port    int
+		wantErr bool
+	}{
+		{"Valid", 9000, false},
+		{"Disabled", 0, false},
+		{"NegativePort", -1, true},
+		{"PortOutOfRange", 65536, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Config{Host: "0.0.0.0", Port: tc.port}
+			err := cfg.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
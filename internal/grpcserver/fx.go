@@ -0,0 +1,73 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+
+	log "github.com/public-forge/go-logger"
+	slotv1 "github.com/vadymlab/slot-game/api/proto/slot/v1"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/server"
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+)
+
+// Module provides the gRPC server configuration and the SlotService implementation as an Fx module.
+var Module = fx.Options(
+	fx.Provide(GetGRPCConfig),
+	fx.Provide(NewServer),
+)
+
+// Listener serves the gRPC API with the application and stops it gracefully on shutdown, letting
+// the calls in progress finish within the shutdown timeout. The API is not served when its port is 0.
+var Listener = fx.Invoke(func(lc fx.Lifecycle, config *Config, apiConfig *server.APIConfig, tokens interfaces.ITokenStore, srv *Server) {
+	if !config.Enabled() {
+		return
+	}
+	grpcServer := NewGRPCServer(apiConfig, tokens, srv)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", config.Address())
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := grpcServer.Serve(listener); err != nil {
+					log.FromContext(context.Background()).Error(err)
+				}
+			}()
+			log.FromContext(ctx).Infof("Starting gRPC API on %s.", config.Address())
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			// Calls still running when the shutdown times out are cancelled
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcServer.Stop()
+			}
+			return nil
+		},
+	})
+})
+
+// NewGRPCServer creates a gRPC server serving the SlotService, with every call authenticated by
+// AuthInterceptor using the JWT settings of the REST API.
+//
+// Parameters:
+//   - apiConfig: API configuration with the JWT secret and leeway.
+//   - tokens: Active login sessions the JWT tokens must belong to.
+//   - srv: The SlotService implementation.
+//
+// Returns:
+//   - A pointer to the gRPC server, ready to serve on a listener.
+func NewGRPCServer(apiConfig *server.APIConfig, tokens interfaces.ITokenStore, srv *Server) *grpc.Server {
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(AuthInterceptor(apiConfig.JWTSecret, apiConfig.JWTLeeway, tokens)))
+	slotv1.RegisterSlotServiceServer(grpcServer, srv)
+	return grpcServer
+}
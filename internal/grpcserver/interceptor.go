@@ -0,0 +1,58 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/server/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationKey is the metadata key carrying the JWT token in the "Bearer <token>" format.
+const authorizationKey = "authorization"
+
+// AuthInterceptor authenticates every unary call with the JWT token in the "authorization"
+// metadata, applying the same rules as jwt.AuthMiddleware does to the REST API. If the token is
+// valid, the user ID from its subject is stored in the call's context; otherwise, the call fails
+// with codes.Unauthenticated. With a token store, tokens whose login session has been ended are
+// rejected; while the store cannot be reached, the tokens are accepted.
+func AuthInterceptor(secret string, leeway int, tokens interfaces.ITokenStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var authorization string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(authorizationKey); len(values) > 0 {
+				authorization = values[0]
+			}
+		}
+		userID, tokenID, err := jwt.Authenticate(authorization, secret, leeway)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if tokens != nil {
+			uID := uuid.MustParse(userID)
+			active, err := tokens.Active(ctx, &uID, tokenID)
+			if err != nil {
+				log.FromContext(ctx).Warnf("failed to check the login session, accepting the token: %v", err)
+			} else if !active {
+				return nil, status.Error(codes.Unauthenticated, jwt.ErrTokenRevoked.Error())
+			}
+		}
+		return handler(context.WithValue(ctx, constants.CtxFieldUserID, userID), req)
+	}
+}
+
+// userFromContext returns the ID of the user AuthInterceptor authenticated the call for.
+func userFromContext(ctx context.Context) (*uuid.UUID, error) {
+	userID, _ := ctx.Value(constants.CtxFieldUserID).(string)
+	uID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, jwt.ErrInvalidTokenSubject.Error())
+	}
+	return &uID, nil
+}
@@ -0,0 +1,209 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/public-forge/go-logger"
+	slotv1 "github.com/vadymlab/slot-game/api/proto/slot/v1"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/database"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/server"
+	"github.com/vadymlab/slot-game/internal/validators"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the SlotService of the gRPC API on top of the same services as the REST API.
+// Its calls must be authenticated by AuthInterceptor.
+type Server struct {
+	slotv1.UnimplementedSlotServiceServer
+	userService       interfaces.IUserService       // Service for user profiles and wallet operations
+	slotService       interfaces.ISlotService       // Service for slot game spins
+	withdrawalService interfaces.IWithdrawalService // Service holding withdrawals that await admin approval
+	appConfig         *config.SlotConfig            // Slot configuration with the amount precision and withdrawal approval
+	apiConfig         *server.APIConfig             // API configuration with the maintenance flag and message
+	maintenance       interfaces.IMaintenanceStore  // Maintenance mode toggle shared by all instances
+}
+
+// NewServer creates a new Server.
+//
+// Parameters:
+//   - userService: Service for user profiles and wallet operations.
+//   - slotService: Service for slot game spins.
+//   - withdrawalService: Service holding withdrawals that await admin approval.
+//   - appConfig: Slot configuration with the amount precision and withdrawal approval.
+//   - apiConfig: API configuration with the maintenance flag and message.
+//   - maintenance: Maintenance mode toggle shared by all instances.
+//
+// Returns:
+//   - A pointer to the initialized Server.
+func NewServer(
+	userService interfaces.IUserService,
+	slotService interfaces.ISlotService,
+	withdrawalService interfaces.IWithdrawalService,
+	appConfig *config.SlotConfig,
+	apiConfig *server.APIConfig,
+	maintenance interfaces.IMaintenanceStore,
+) *Server {
+	return &Server{
+		userService:       userService,
+		slotService:       slotService,
+		withdrawalService: withdrawalService,
+		appConfig:         appConfig,
+		apiConfig:         apiConfig,
+		maintenance:       maintenance,
+	}
+}
+
+// Spin places a bet in the requested game for the authenticated user, like POST /api/slot/spin.
+func (s *Server) Spin(ctx context.Context, req *slotv1.SpinRequest) (*slotv1.SpinResponse, error) {
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.available(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.validateAmount("bet_amount", req.GetBetAmount()); err != nil {
+		return nil, err
+	}
+	spin, err := s.slotService.RetrySpin(ctx, userID, req.GetGameId(), req.GetBetAmount())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if spin.Balance == nil {
+		return nil, status.Error(codes.Internal, "spin succeeded without returning the balance")
+	}
+	return &slotv1.SpinResponse{
+		WinAmount: spin.WinAmount,
+		Balance:   *spin.Balance,
+		Reels:     spin.Reels,
+		GameId:    spin.GameID,
+	}, nil
+}
+
+// Deposit adds funds to the authenticated user's wallet, applying the promo code if one is given,
+// like POST /api/wallet/deposit.
+func (s *Server) Deposit(ctx context.Context, req *slotv1.DepositRequest) (*slotv1.BalanceResponse, error) {
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.available(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.validateAmount("amount", req.GetAmount()); err != nil {
+		return nil, err
+	}
+	balance, bonus, err := s.userService.DepositWithPromo(ctx, userID, req.GetAmount(), req.GetPromoCode())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if balance == nil {
+		return nil, status.Error(codes.Internal, "wallet operation succeeded without returning the balance")
+	}
+	return &slotv1.BalanceResponse{Balance: *balance, Bonus: bonus}, nil
+}
+
+// Withdraw removes funds from the authenticated user's wallet, like POST /api/wallet/withdraw.
+// When withdrawals await admin approval, the amount is held as a pending withdrawal instead.
+func (s *Server) Withdraw(ctx context.Context, req *slotv1.WithdrawRequest) (*slotv1.BalanceResponse, error) {
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.available(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.validateAmount("amount", req.GetAmount()); err != nil {
+		return nil, err
+	}
+	var balance *float64
+	if s.appConfig.WithdrawalApproval {
+		_, balance, err = s.withdrawalService.Request(ctx, userID, req.GetAmount())
+	} else {
+		balance, err = s.userService.Withdraw(ctx, userID, req.GetAmount())
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if balance == nil {
+		return nil, status.Error(codes.Internal, "wallet operation succeeded without returning the balance")
+	}
+	return &slotv1.BalanceResponse{Balance: *balance}, nil
+}
+
+// Profile returns the authenticated user's profile, like GET /api/profile.
+func (s *Server) Profile(ctx context.Context, _ *slotv1.ProfileRequest) (*slotv1.ProfileResponse, error) {
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The profile only reads, so it may be served by the read replica
+	user, err := s.userService.GetByExternalID(database.WithReadOnly(ctx), userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &slotv1.ProfileResponse{
+		Id:      user.ExternalID.String(),
+		Login:   user.Login,
+		Balance: user.Balance,
+	}, nil
+}
+
+// available fails the call with codes.Unavailable while maintenance mode is on, as the game and
+// wallet endpoints of the REST API do. A toggle that cannot be read is logged and treated as off.
+func (s *Server) available(ctx context.Context) error {
+	if s.apiConfig.Maintenance {
+		return status.Error(codes.Unavailable, s.apiConfig.MaintenanceMessage)
+	}
+	enabled, err := s.maintenance.Enabled(ctx)
+	if err != nil {
+		log.FromContext(ctx).Warnf("maintenance toggle unavailable, assuming maintenance mode is off: %v", err)
+		return nil
+	}
+	if enabled {
+		return status.Error(codes.Unavailable, s.apiConfig.MaintenanceMessage)
+	}
+	return nil
+}
+
+// validateAmount fails the call with codes.InvalidArgument unless the amount is positive and has
+// at most the configured number of decimals, as the REST API validates amounts.
+func (s *Server) validateAmount(field string, amount float64) error {
+	if amount <= 0 {
+		return status.Error(codes.InvalidArgument, serviceError.ErrInvalidAmount.Error())
+	}
+	if errs := validators.ValidateAmount(field, amount, s.appConfig.AmountDecimals); errs != nil {
+		return status.Error(codes.InvalidArgument, errs[0])
+	}
+	return nil
+}
+
+// statusError converts a service error into the gRPC status matching the HTTP status the REST API
+// responds with to the same error.
+func statusError(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, serviceError.ErrInsufficientFunds):
+		code = codes.FailedPrecondition
+	case errors.Is(err, serviceError.ErrInvalidAmount), errors.Is(err, serviceError.ErrInvalidBetDenomination),
+		errors.Is(err, serviceError.ErrDepositLimitExceeded), errors.Is(err, serviceError.ErrPromoNotFound),
+		errors.Is(err, serviceError.ErrPromoExpired), errors.Is(err, serviceError.ErrPromoLimitReached),
+		errors.Is(err, serviceError.ErrPromoMinDeposit):
+		code = codes.InvalidArgument
+	case errors.Is(err, serviceError.ErrSelfExcluded), errors.Is(err, serviceError.ErrAccountFrozen),
+		errors.Is(err, serviceError.ErrWithdrawalNotAllowedYet):
+		code = codes.PermissionDenied
+	case errors.Is(err, serviceError.ErrUserNotFound), errors.Is(err, serviceError.ErrGameNotFound):
+		code = codes.NotFound
+	case errors.Is(err, serviceError.ErrSpinInProgress):
+		code = codes.Aborted
+	case errors.Is(err, serviceError.ErrSpinLimitReached):
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,253 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	slotv1 "github.com/vadymlab/slot-game/api/proto/slot/v1"
+	"github.com/vadymlab/slot-game/internal/config"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/server"
+	"github.com/vadymlab/slot-game/internal/server/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testSecret is the JWT secret the server under test validates tokens with.
+const testSecret = "secret"
+
+// grpcServerMocks holds the mocked dependencies of a gRPC server under test.
+type grpcServerMocks struct {
+	userService       *mocks.MockIUserService
+	slotService       *mocks.MockISlotService
+	withdrawalService *mocks.MockIWithdrawalService
+	maintenance       *mocks.MockIMaintenanceStore
+	tokens            *mocks.MockITokenStore
+}
+
+// newTestClient serves the gRPC API, authenticated by AuthInterceptor, on an in-memory listener
+// and returns a client connected to it.
+func newTestClient(t *testing.T, ctrl *gomock.Controller, appConfig *config.SlotConfig) (slotv1.SlotServiceClient, *grpcServerMocks) {
+	m := &grpcServerMocks{
+		userService:       mocks.NewMockIUserService(ctrl),
+		slotService:       mocks.NewMockISlotService(ctrl),
+		withdrawalService: mocks.NewMockIWithdrawalService(ctrl),
+		maintenance:       mocks.NewMockIMaintenanceStore(ctrl),
+		tokens:            mocks.NewMockITokenStore(ctrl),
+	}
+	apiConfig := &server.APIConfig{JWTSecret: testSecret, MaintenanceMessage: "down for maintenance"}
+	srv := NewServer(m.userService, m.slotService, m.withdrawalService, appConfig, apiConfig, m.maintenance)
+	grpcServer := NewGRPCServer(apiConfig, m.tokens, srv)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return slotv1.NewSlotServiceClient(conn), m
+}
+
+// authorized returns a context carrying a valid token of the user, whose session m accepts.
+func authorized(t *testing.T, m *grpcServerMocks, userID uuid.UUID) context.Context {
+	token, tokenID, err := jwt.GenerateToken(&userID, testSecret, 5)
+	require.NoError(t, err)
+	m.tokens.EXPECT().Active(gomock.Any(), &userID, tokenID).Return(true, nil)
+	return metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "Bearer "+token)
+}
+
+func TestGRPC_Unauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2})
+
+	userID := uuid.New()
+	token, tokenID, err := jwt.GenerateToken(&userID, testSecret, 5)
+	require.NoError(t, err)
+	forged, _, err := jwt.GenerateToken(&userID, "other-secret", 5)
+	require.NoError(t, err)
+	m.tokens.EXPECT().Active(gomock.Any(), &userID, tokenID).Return(false, nil)
+
+	testCases := []struct {
+		name          string
+		authorization string
+		wantMessage   string
+	}{
+		{"MissingToken", "", jwt.ErrTokenRequired.Error()},
+		{"NotBearer", token, jwt.ErrInvalidTokenFormat.Error()},
+		{"ForgedToken", "Bearer " + forged, jwt.ErrInvalidToken.Error()},
+		{"EndedSession", "Bearer " + token, jwt.ErrTokenRevoked.Error()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, authorizationKey, tc.authorization)
+			}
+			_, err := client.Profile(ctx, &slotv1.ProfileRequest{})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			assert.Equal(t, tc.wantMessage, status.Convert(err).Message())
+		})
+	}
+}
+
+func TestGRPC_Spin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2})
+
+	userID := uuid.New()
+	balance := 95.0
+	m.maintenance.EXPECT().Enabled(gomock.Any()).Return(false, nil)
+	m.slotService.EXPECT().RetrySpin(gomock.Any(), &userID, "fruits", 10.0).
+		Return(&models.Spin{GameID: "fruits", WinAmount: 5, Balance: &balance, Reels: models.Reels{"A", "B", "C"}}, nil)
+
+	res, err := client.Spin(authorized(t, m, userID), &slotv1.SpinRequest{BetAmount: 10, GameId: "fruits"})
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, res.GetWinAmount())
+	assert.Equal(t, 95.0, res.GetBalance())
+	assert.Equal(t, []string{"A", "B", "C"}, res.GetReels())
+	assert.Equal(t, "fruits", res.GetGameId())
+}
+
+func TestGRPC_SpinErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{"InsufficientFunds", serviceError.ErrInsufficientFunds, codes.FailedPrecondition},
+		{"SelfExcluded", serviceError.ErrSelfExcluded, codes.PermissionDenied},
+		{"GameNotFound", serviceError.ErrGameNotFound, codes.NotFound},
+		{"SpinInProgress", serviceError.ErrSpinInProgress, codes.Aborted},
+		{"SpinLimitReached", serviceError.ErrSpinLimitReached, codes.ResourceExhausted},
+		{"Unexpected", assert.AnError, codes.Internal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2})
+
+			userID := uuid.New()
+			m.maintenance.EXPECT().Enabled(gomock.Any()).Return(false, nil)
+			m.slotService.EXPECT().RetrySpin(gomock.Any(), &userID, "", 10.0).Return(nil, tc.err)
+
+			_, err := client.Spin(authorized(t, m, userID), &slotv1.SpinRequest{BetAmount: 10})
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			assert.Equal(t, tc.err.Error(), status.Convert(err).Message())
+		})
+	}
+}
+
+func TestGRPC_InvalidAmount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2})
+
+	userID := uuid.New()
+	m.maintenance.EXPECT().Enabled(gomock.Any()).Return(false, nil).Times(3)
+
+	_, err := client.Spin(authorized(t, m, userID), &slotv1.SpinRequest{BetAmount: 0})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Deposit(authorized(t, m, userID), &slotv1.DepositRequest{Amount: 1.005})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Withdraw(authorized(t, m, userID), &slotv1.WithdrawRequest{Amount: -5})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_Maintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2})
+
+	userID := uuid.New()
+	m.maintenance.EXPECT().Enabled(gomock.Any()).Return(true, nil)
+
+	_, err := client.Spin(authorized(t, m, userID), &slotv1.SpinRequest{BetAmount: 10})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "down for maintenance", status.Convert(err).Message())
+}
+
+func TestGRPC_Deposit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2})
+
+	userID := uuid.New()
+	balance := 120.0
+	m.maintenance.EXPECT().Enabled(gomock.Any()).Return(false, nil)
+	m.userService.EXPECT().DepositWithPromo(gomock.Any(), &userID, 100.0, "WELCOME").Return(&balance, 20.0, nil)
+
+	res, err := client.Deposit(authorized(t, m, userID), &slotv1.DepositRequest{Amount: 100, PromoCode: "WELCOME"})
+	require.NoError(t, err)
+	assert.Equal(t, 120.0, res.GetBalance())
+	assert.Equal(t, 20.0, res.GetBonus())
+}
+
+func TestGRPC_Withdraw(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2})
+
+	userID := uuid.New()
+	balance := 40.0
+	m.maintenance.EXPECT().Enabled(gomock.Any()).Return(false, nil).Times(2)
+	m.userService.EXPECT().Withdraw(gomock.Any(), &userID, 60.0).Return(&balance, nil)
+	m.userService.EXPECT().Withdraw(gomock.Any(), &userID, 500.0).Return(nil, serviceError.ErrInsufficientFunds)
+
+	res, err := client.Withdraw(authorized(t, m, userID), &slotv1.WithdrawRequest{Amount: 60})
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, res.GetBalance())
+
+	_, err = client.Withdraw(authorized(t, m, userID), &slotv1.WithdrawRequest{Amount: 500})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestGRPC_WithdrawAwaitingApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2, WithdrawalApproval: true})
+
+	userID := uuid.New()
+	balance := 40.0
+	m.maintenance.EXPECT().Enabled(gomock.Any()).Return(false, nil)
+	m.withdrawalService.EXPECT().Request(gomock.Any(), &userID, 60.0).Return(&models.PendingWithdrawal{}, &balance, nil)
+
+	res, err := client.Withdraw(authorized(t, m, userID), &slotv1.WithdrawRequest{Amount: 60})
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, res.GetBalance())
+}
+
+func TestGRPC_Profile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, m := newTestClient(t, ctrl, &config.SlotConfig{AmountDecimals: 2})
+
+	userID := uuid.New()
+	m.userService.EXPECT().GetByExternalID(gomock.Any(), &userID).
+		Return(&models.User{ExternalID: &userID, Login: "player", Balance: 75}, nil)
+
+	res, err := client.Profile(authorized(t, m, userID), &slotv1.ProfileRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, userID.String(), res.GetId())
+	assert.Equal(t, "player", res.GetLogin())
+	assert.Equal(t, 75.0, res.GetBalance())
+}
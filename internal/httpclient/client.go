@@ -0,0 +1,44 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/middlewares"
+)
+
+// traceTransport wraps a RoundTripper, setting the X-Trace-ID header of outgoing requests to the
+// trace ID of their context, so that external systems can correlate their logs with the request
+// that caused the call.
+type traceTransport struct {
+	base http.RoundTripper // Transport the requests are sent with
+}
+
+// RoundTrip sends the request with the trace ID of its context. Requests already carrying a trace
+// ID, and requests made outside of a traced request, are sent unchanged.
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	traceID, _ := req.Context().Value(constants.CtxFieldTraceID).(string)
+	if traceID == "" || req.Header.Get(middlewares.HeaderTraceID) != "" {
+		return t.base.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	req.Header.Set(middlewares.HeaderTraceID, traceID)
+	return t.base.RoundTrip(req)
+}
+
+// New creates the HTTP client used to call external systems, propagating the trace ID of each
+// request's context in the X-Trace-ID header.
+//
+// Parameters:
+//   - timeout: Time limit of each request, including reading the response body.
+//
+// Returns:
+//   - A pointer to an http.Client.
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &traceTransport{base: http.DefaultTransport},
+	}
+}
@@ -0,0 +1,50 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/middlewares"
+)
+
+func TestNew_PropagatesTraceID(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		header   string
+		expected string
+	}{
+		{"FromContext", middlewares.WithTraceID(context.Background(), "trace-1"), "", "trace-1"},
+		{"NoTraceID", context.Background(), "", ""},
+		{"AlreadySet", middlewares.WithTraceID(context.Background(), "trace-1"), "trace-2", "trace-2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var received string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Get(middlewares.HeaderTraceID)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(tc.ctx, http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+			if tc.header != "" {
+				req.Header.Set(middlewares.HeaderTraceID, tc.header)
+			}
+			resp, err := New(time.Second).Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, tc.expected, received)
+			// The caller's request is left untouched
+			assert.Equal(t, tc.header, req.Header.Get(middlewares.HeaderTraceID))
+		})
+	}
+}
@@ -0,0 +1,449 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// IUserCache defines methods for caching user records keyed by their external identifier.
+type IUserCache interface {
+	// Get retrieves a cached user by their UUID identifier.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - id: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to a User model if cached, or nil on a cache miss.
+	//   - An error if the cache cannot be reached.
+	Get(ctx context.Context, id *uuid.UUID) (*models.User, error)
+
+	// Set stores a user in the cache under their external identifier.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - user: A pointer to the User model to cache.
+	//
+	// Returns:
+	//   - An error if the cache cannot be reached.
+	Set(ctx context.Context, user *models.User) error
+
+	// Delete removes a cached user, forcing the next read to hit the database.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - id: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - An error if the cache cannot be reached.
+	Delete(ctx context.Context, id *uuid.UUID) error
+}
+
+// ILoginAttemptStore defines methods for tracking failed login attempts and used login nonces
+// within a sliding time window.
+type ILoginAttemptStore interface {
+	// Count returns the number of failed login attempts recorded for the login within the window.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - login: The login the attempts were made for.
+	//
+	// Returns:
+	//   - The number of failed attempts, or 0 if none are recorded.
+	//   - An error if the store cannot be reached.
+	Count(ctx context.Context, login string) (int, error)
+
+	// Increment records a failed login attempt and returns the updated count.
+	// The window starts with the first failed attempt.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - login: The login the attempt was made for.
+	//
+	// Returns:
+	//   - The number of failed attempts within the window, including this one.
+	//   - An error if the store cannot be reached.
+	Increment(ctx context.Context, login string) (int, error)
+
+	// Reset clears the failed login attempts recorded for the login.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - login: The login to reset.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	Reset(ctx context.Context, login string) error
+
+	// UseNonce marks a client nonce as used.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - nonce: The nonce sent by the client.
+	//
+	// Returns:
+	//   - True if the nonce was not used before within the window.
+	//   - An error if the store cannot be reached.
+	UseNonce(ctx context.Context, nonce string) (bool, error)
+}
+
+// IRegistrationKeyStore defines methods for remembering the registrations made with an idempotency key.
+type IRegistrationKeyStore interface {
+	// Get returns the registration made with the idempotency key.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - key: The idempotency key sent by the client.
+	//
+	// Returns:
+	//   - A pointer to the registration, or nil if the key has not been used.
+	//   - An error if the store cannot be reached.
+	Get(ctx context.Context, key string) (*models.RegistrationKey, error)
+
+	// Save remembers the registration made with the idempotency key, unless the key is already in use.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - key: The idempotency key sent by the client.
+	//   - registration: The registration made with the key.
+	//
+	// Returns:
+	//   - True if the registration was stored, false if the key was already in use.
+	//   - An error if the store cannot be reached.
+	Save(ctx context.Context, key string, registration *models.RegistrationKey) (bool, error)
+}
+
+// ISpinLock defines methods for limiting the number of spins a user may have in flight at once.
+type ISpinLock interface {
+	// Acquire reserves an in-flight spin slot for the user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - True if a slot was reserved, false if the user already has the maximum number of spins in flight.
+	//   - An error if the store cannot be reached.
+	Acquire(ctx context.Context, userID *uuid.UUID) (bool, error)
+
+	// Release frees a spin slot previously reserved by Acquire.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	Release(ctx context.Context, userID *uuid.UUID) error
+}
+
+// IDemoWallet defines methods for managing the ephemeral play-money balance of demo sessions.
+// Demo balances are kept apart from the real wallet and are never persisted in the database.
+type IDemoWallet interface {
+	// Balance returns the demo balance of the user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the demo balance, or nil if the user has no active demo session.
+	//   - An error if the store cannot be reached.
+	Balance(ctx context.Context, userID *uuid.UUID) (*float64, error)
+
+	// Reset starts a new demo session with the given balance, discarding the previous one.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - balance: The play-money balance the session starts with.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	Reset(ctx context.Context, userID *uuid.UUID, balance float64) error
+
+	// Withdraw deducts the amount from the demo balance.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - amount: The amount to deduct.
+	//
+	// Returns:
+	//   - A pointer to the updated demo balance.
+	//   - ErrInsufficientFunds if the demo balance does not cover the amount.
+	//   - An error if the store cannot be reached.
+	Withdraw(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error)
+
+	// Deposit adds the amount to the demo balance.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - amount: The amount to add.
+	//
+	// Returns:
+	//   - A pointer to the updated demo balance.
+	//   - An error if the store cannot be reached.
+	Deposit(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error)
+
+	// End ends the demo session, discarding the demo balance.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the balance the session ended with, or nil if the user had no active demo session.
+	//   - An error if the store cannot be reached.
+	End(ctx context.Context, userID *uuid.UUID) (*float64, error)
+}
+
+// IWinStreakStore defines methods for tracking the number of consecutive winning spins of a user.
+type IWinStreakStore interface {
+	// Get returns the current win streak of the user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - The number of consecutive winning spins, or 0 if the user has no streak.
+	//   - An error if the store cannot be reached.
+	Get(ctx context.Context, userID *uuid.UUID) (int, error)
+
+	// Set stores the win streak of the user; a streak of 0 clears it.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - streak: The number of consecutive winning spins.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	Set(ctx context.Context, userID *uuid.UUID, streak int) error
+}
+
+// ISessionStore defines methods for tracking the active game session of a user. A session
+// expires once it has not been extended for the session timeout.
+type ISessionStore interface {
+	// Get returns the active game session of the user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - The numeric ID of the active session, or 0 if the user has none or it timed out.
+	//   - An error if the store cannot be reached.
+	Get(ctx context.Context, userID *uuid.UUID) (uint, error)
+
+	// Set makes the session the active one of the user and extends it by the session timeout.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - sessionID: The numeric ID of the session.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	Set(ctx context.Context, userID *uuid.UUID, sessionID uint) error
+
+	// Delete clears the active game session of the user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	Delete(ctx context.Context, userID *uuid.UUID) error
+}
+
+// IReportQueue defines a durable queue of spin reports awaiting delivery to the regulator.
+// A report stays queued until its delivery is acknowledged, so every report is delivered at least once.
+type IReportQueue interface {
+	// Enqueue adds a report, due for delivery right away.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - report: The report to deliver.
+	//
+	// Returns:
+	//   - An error if the queue cannot be reached.
+	Enqueue(ctx context.Context, report *models.SpinReport) error
+
+	// Claim returns up to limit reports that are due and postpones them by the lease, so that
+	// they are not claimed again meanwhile. A report that is not acknowledged within the lease,
+	// for example because its delivery failed, becomes due again.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - now: The current time; reports due at or before it are claimed.
+	//   - lease: How long the claimed reports are postponed.
+	//   - limit: The maximum number of reports to claim.
+	//
+	// Returns:
+	//   - The claimed reports, oldest first.
+	//   - An error if the queue cannot be reached.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.SpinReport, error)
+
+	// Ack removes a delivered report from the queue.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - id: The identifier of the delivered report.
+	//
+	// Returns:
+	//   - An error if the queue cannot be reached.
+	Ack(ctx context.Context, id string) error
+}
+
+// IDailySpinCounter defines methods for counting the spins each user made per day, backing the
+// daily spin limit. The counts of past days expire on their own.
+type IDailySpinCounter interface {
+	// Count returns the number of spins the user made on the day.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - day: The day of the spin limit, formatted as YYYY-MM-DD.
+	//
+	// Returns:
+	//   - The number of spins made on the day, or 0 if none was counted.
+	//   - An error if the counter cannot be reached.
+	Count(ctx context.Context, userID *uuid.UUID, day string) (int, error)
+
+	// Increment counts another spin of the user on the day.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - day: The day of the spin limit, formatted as YYYY-MM-DD.
+	//
+	// Returns:
+	//   - The number of spins made on the day, including this one.
+	//   - An error if the counter cannot be reached.
+	Increment(ctx context.Context, userID *uuid.UUID, day string) (int, error)
+}
+
+// IMaintenanceStore defines methods for the maintenance mode toggle shared by all instances of the
+// API, which admins switch on during deploys and incidents.
+type IMaintenanceStore interface {
+	// Enabled reports whether the maintenance mode toggle is on.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//
+	// Returns:
+	//   - Whether the toggle is on; false if it was never switched on.
+	//   - An error if the store cannot be reached.
+	Enabled(ctx context.Context) (bool, error)
+
+	// SetEnabled switches the maintenance mode toggle on or off.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - enabled: Whether maintenance mode is on.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	SetEnabled(ctx context.Context, enabled bool) error
+}
+
+// IJackpotStore defines methods for the progressive jackpot pool shared by all instances of the
+// API. The pool holds the contributions of the spins on top of the jackpot seed; every method
+// updates it atomically, so that concurrent spins neither lose contributions nor pay them twice.
+type IJackpotStore interface {
+	// Contribute adds the amount to the pool.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - amount: The amount to add, such as the share of a bet.
+	//
+	// Returns:
+	//   - The contributions in the pool, including this one.
+	//   - An error if the store cannot be reached.
+	Contribute(ctx context.Context, amount float64) (float64, error)
+
+	// Hit takes all contributions of the pool for a jackpot win and resets it.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//
+	// Returns:
+	//   - The contributions taken from the pool; 0 if it was empty.
+	//   - An error if the store cannot be reached.
+	Hit(ctx context.Context) (float64, error)
+}
+
+// ILastSpinStore defines methods for keeping the last committed spin of each user for a short
+// time, so that a client that lost the response of a spin can fetch its result.
+type ILastSpinStore interface {
+	// Get returns the last spin of the user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the last spin, or nil if the user has not spun recently.
+	//   - An error if the store cannot be reached.
+	Get(ctx context.Context, userID *uuid.UUID) (*models.Spin, error)
+
+	// Set stores the spin as the last spin of the user, replacing the previous one.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - spin: The committed spin.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	Set(ctx context.Context, userID *uuid.UUID, spin *models.Spin) error
+}
+
+// ITokenStore defines methods for tracking the JWT tokens issued to each user, limiting how many
+// login sessions a user may have active at once.
+type ITokenStore interface {
+	// Add records a token issued to the user until it expires. When the user already has the
+	// maximum number of active tokens, either the oldest tokens are removed to make room or the
+	// token is not recorded, depending on the configuration.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - tokenID: The unique ID (jti) of the token.
+	//   - ttl: How long the token is accepted.
+	//
+	// Returns:
+	//   - True if the token was recorded, false if the user has too many active tokens.
+	//   - An error if the store cannot be reached.
+	Add(ctx context.Context, userID *uuid.UUID, tokenID string, ttl time.Duration) (bool, error)
+
+	// Active reports whether the token is still recorded for the user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - tokenID: The unique ID (jti) of the token.
+	//
+	// Returns:
+	//   - Whether the token is active; always true when the number of sessions is not limited.
+	//   - An error if the store cannot be reached.
+	Active(ctx context.Context, userID *uuid.UUID, tokenID string) (bool, error)
+
+	// Remove forgets the token, freeing its session slot.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - tokenID: The unique ID (jti) of the token.
+	//
+	// Returns:
+	//   - An error if the store cannot be reached.
+	Remove(ctx context.Context, userID *uuid.UUID, tokenID string) error
+}
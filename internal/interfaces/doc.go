@@ -2,3 +2,5 @@ package interfaces
 
 //go:generate mockgen -source=repositories.go -destination=./mocks/mock_repositories.go -package=mocks
 //go:generate mockgen -source=services.go -destination=./mocks/mock_services.go -package=mocks
+//go:generate mockgen -source=cache.go -destination=./mocks/mock_cache.go -package=mocks
+//go:generate mockgen -source=events.go -destination=./mocks/mock_events.go -package=mocks
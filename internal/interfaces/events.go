@@ -0,0 +1,47 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// IEventPublisher defines a transport delivering business events to external subscribers.
+type IEventPublisher interface {
+	// Publish delivers the event, retrying on transient failures.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - event: The event to deliver.
+	//
+	// Returns:
+	//   - An error if the event could not be delivered.
+	Publish(ctx context.Context, event *models.Event) error
+}
+
+// IEventBus defines a message broker receiving the domain events of committed spins and wallet
+// operations. Events are kept by the broker, so that consumers can replay them.
+type IEventBus interface {
+	// Publish sends the event to the broker.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - event: The event to publish.
+	//
+	// Returns:
+	//   - An error if the broker did not accept the event.
+	Publish(ctx context.Context, event *models.DomainEvent) error
+}
+
+// ISpinReporter defines how committed spins are reported to a regulator.
+type ISpinReporter interface {
+	// Report queues the outcome of a committed spin for delivery to the regulator. Failures to
+	// queue the report are logged, as the spin itself cannot be undone anymore.
+	//
+	// Parameters:
+	//   - ctx: Context of the request that performed the spin.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - spin: The committed spin.
+	Report(ctx context.Context, userID *uuid.UUID, spin *models.Spin)
+}
@@ -0,0 +1,826 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cache.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/vadymlab/slot-game/internal/models"
+)
+
+// MockIUserCache is a mock of IUserCache interface.
+type MockIUserCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserCacheMockRecorder
+}
+
+// MockIUserCacheMockRecorder is the mock recorder for MockIUserCache.
+type MockIUserCacheMockRecorder struct {
+	mock *MockIUserCache
+}
+
+// NewMockIUserCache creates a new mock instance.
+func NewMockIUserCache(ctrl *gomock.Controller) *MockIUserCache {
+	mock := &MockIUserCache{ctrl: ctrl}
+	mock.recorder = &MockIUserCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserCache) EXPECT() *MockIUserCacheMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIUserCache) Delete(ctx context.Context, id *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIUserCacheMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserCache)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockIUserCache) Get(ctx context.Context, id *uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIUserCacheMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIUserCache)(nil).Get), ctx, id)
+}
+
+// Set mocks base method.
+func (m *MockIUserCache) Set(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockIUserCacheMockRecorder) Set(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockIUserCache)(nil).Set), ctx, user)
+}
+
+// MockILoginAttemptStore is a mock of ILoginAttemptStore interface.
+type MockILoginAttemptStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginAttemptStoreMockRecorder
+}
+
+// MockILoginAttemptStoreMockRecorder is the mock recorder for MockILoginAttemptStore.
+type MockILoginAttemptStoreMockRecorder struct {
+	mock *MockILoginAttemptStore
+}
+
+// NewMockILoginAttemptStore creates a new mock instance.
+func NewMockILoginAttemptStore(ctrl *gomock.Controller) *MockILoginAttemptStore {
+	mock := &MockILoginAttemptStore{ctrl: ctrl}
+	mock.recorder = &MockILoginAttemptStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginAttemptStore) EXPECT() *MockILoginAttemptStoreMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockILoginAttemptStore) Count(ctx context.Context, login string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, login)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockILoginAttemptStoreMockRecorder) Count(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockILoginAttemptStore)(nil).Count), ctx, login)
+}
+
+// Increment mocks base method.
+func (m *MockILoginAttemptStore) Increment(ctx context.Context, login string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, login)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockILoginAttemptStoreMockRecorder) Increment(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockILoginAttemptStore)(nil).Increment), ctx, login)
+}
+
+// Reset mocks base method.
+func (m *MockILoginAttemptStore) Reset(ctx context.Context, login string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockILoginAttemptStoreMockRecorder) Reset(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockILoginAttemptStore)(nil).Reset), ctx, login)
+}
+
+// UseNonce mocks base method.
+func (m *MockILoginAttemptStore) UseNonce(ctx context.Context, nonce string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseNonce", ctx, nonce)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseNonce indicates an expected call of UseNonce.
+func (mr *MockILoginAttemptStoreMockRecorder) UseNonce(ctx, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseNonce", reflect.TypeOf((*MockILoginAttemptStore)(nil).UseNonce), ctx, nonce)
+}
+
+// MockIRegistrationKeyStore is a mock of IRegistrationKeyStore interface.
+type MockIRegistrationKeyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRegistrationKeyStoreMockRecorder
+}
+
+// MockIRegistrationKeyStoreMockRecorder is the mock recorder for MockIRegistrationKeyStore.
+type MockIRegistrationKeyStoreMockRecorder struct {
+	mock *MockIRegistrationKeyStore
+}
+
+// NewMockIRegistrationKeyStore creates a new mock instance.
+func NewMockIRegistrationKeyStore(ctrl *gomock.Controller) *MockIRegistrationKeyStore {
+	mock := &MockIRegistrationKeyStore{ctrl: ctrl}
+	mock.recorder = &MockIRegistrationKeyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRegistrationKeyStore) EXPECT() *MockIRegistrationKeyStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIRegistrationKeyStore) Get(ctx context.Context, key string) (*models.RegistrationKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*models.RegistrationKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIRegistrationKeyStoreMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIRegistrationKeyStore)(nil).Get), ctx, key)
+}
+
+// Save mocks base method.
+func (m *MockIRegistrationKeyStore) Save(ctx context.Context, key string, registration *models.RegistrationKey) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, key, registration)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIRegistrationKeyStoreMockRecorder) Save(ctx, key, registration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIRegistrationKeyStore)(nil).Save), ctx, key, registration)
+}
+
+// MockISpinLock is a mock of ISpinLock interface.
+type MockISpinLock struct {
+	ctrl     *gomock.Controller
+	recorder *MockISpinLockMockRecorder
+}
+
+// MockISpinLockMockRecorder is the mock recorder for MockISpinLock.
+type MockISpinLockMockRecorder struct {
+	mock *MockISpinLock
+}
+
+// NewMockISpinLock creates a new mock instance.
+func NewMockISpinLock(ctrl *gomock.Controller) *MockISpinLock {
+	mock := &MockISpinLock{ctrl: ctrl}
+	mock.recorder = &MockISpinLockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISpinLock) EXPECT() *MockISpinLockMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockISpinLock) Acquire(ctx context.Context, userID *uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockISpinLockMockRecorder) Acquire(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockISpinLock)(nil).Acquire), ctx, userID)
+}
+
+// Release mocks base method.
+func (m *MockISpinLock) Release(ctx context.Context, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockISpinLockMockRecorder) Release(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockISpinLock)(nil).Release), ctx, userID)
+}
+
+// MockIDemoWallet is a mock of IDemoWallet interface.
+type MockIDemoWallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDemoWalletMockRecorder
+}
+
+// MockIDemoWalletMockRecorder is the mock recorder for MockIDemoWallet.
+type MockIDemoWalletMockRecorder struct {
+	mock *MockIDemoWallet
+}
+
+// NewMockIDemoWallet creates a new mock instance.
+func NewMockIDemoWallet(ctrl *gomock.Controller) *MockIDemoWallet {
+	mock := &MockIDemoWallet{ctrl: ctrl}
+	mock.recorder = &MockIDemoWalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDemoWallet) EXPECT() *MockIDemoWalletMockRecorder {
+	return m.recorder
+}
+
+// Balance mocks base method.
+func (m *MockIDemoWallet) Balance(ctx context.Context, userID *uuid.UUID) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", ctx, userID)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance.
+func (mr *MockIDemoWalletMockRecorder) Balance(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockIDemoWallet)(nil).Balance), ctx, userID)
+}
+
+// Deposit mocks base method.
+func (m *MockIDemoWallet) Deposit(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deposit", ctx, userID, amount)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deposit indicates an expected call of Deposit.
+func (mr *MockIDemoWalletMockRecorder) Deposit(ctx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockIDemoWallet)(nil).Deposit), ctx, userID, amount)
+}
+
+// End mocks base method.
+func (m *MockIDemoWallet) End(ctx context.Context, userID *uuid.UUID) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "End", ctx, userID)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// End indicates an expected call of End.
+func (mr *MockIDemoWalletMockRecorder) End(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "End", reflect.TypeOf((*MockIDemoWallet)(nil).End), ctx, userID)
+}
+
+// Reset mocks base method.
+func (m *MockIDemoWallet) Reset(ctx context.Context, userID *uuid.UUID, balance float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, userID, balance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockIDemoWalletMockRecorder) Reset(ctx, userID, balance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockIDemoWallet)(nil).Reset), ctx, userID, balance)
+}
+
+// Withdraw mocks base method.
+func (m *MockIDemoWallet) Withdraw(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, userID, amount)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockIDemoWalletMockRecorder) Withdraw(ctx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockIDemoWallet)(nil).Withdraw), ctx, userID, amount)
+}
+
+// MockIWinStreakStore is a mock of IWinStreakStore interface.
+type MockIWinStreakStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWinStreakStoreMockRecorder
+}
+
+// MockIWinStreakStoreMockRecorder is the mock recorder for MockIWinStreakStore.
+type MockIWinStreakStoreMockRecorder struct {
+	mock *MockIWinStreakStore
+}
+
+// NewMockIWinStreakStore creates a new mock instance.
+func NewMockIWinStreakStore(ctrl *gomock.Controller) *MockIWinStreakStore {
+	mock := &MockIWinStreakStore{ctrl: ctrl}
+	mock.recorder = &MockIWinStreakStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWinStreakStore) EXPECT() *MockIWinStreakStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIWinStreakStore) Get(ctx context.Context, userID *uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIWinStreakStoreMockRecorder) Get(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIWinStreakStore)(nil).Get), ctx, userID)
+}
+
+// Set mocks base method.
+func (m *MockIWinStreakStore) Set(ctx context.Context, userID *uuid.UUID, streak int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, userID, streak)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockIWinStreakStoreMockRecorder) Set(ctx, userID, streak interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockIWinStreakStore)(nil).Set), ctx, userID, streak)
+}
+
+// MockISessionStore is a mock of ISessionStore interface.
+type MockISessionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionStoreMockRecorder
+}
+
+// MockISessionStoreMockRecorder is the mock recorder for MockISessionStore.
+type MockISessionStoreMockRecorder struct {
+	mock *MockISessionStore
+}
+
+// NewMockISessionStore creates a new mock instance.
+func NewMockISessionStore(ctrl *gomock.Controller) *MockISessionStore {
+	mock := &MockISessionStore{ctrl: ctrl}
+	mock.recorder = &MockISessionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionStore) EXPECT() *MockISessionStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockISessionStore) Delete(ctx context.Context, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockISessionStoreMockRecorder) Delete(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockISessionStore)(nil).Delete), ctx, userID)
+}
+
+// Get mocks base method.
+func (m *MockISessionStore) Get(ctx context.Context, userID *uuid.UUID) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockISessionStoreMockRecorder) Get(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockISessionStore)(nil).Get), ctx, userID)
+}
+
+// Set mocks base method.
+func (m *MockISessionStore) Set(ctx context.Context, userID *uuid.UUID, sessionID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockISessionStoreMockRecorder) Set(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockISessionStore)(nil).Set), ctx, userID, sessionID)
+}
+
+// MockIReportQueue is a mock of IReportQueue interface.
+type MockIReportQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReportQueueMockRecorder
+}
+
+// MockIReportQueueMockRecorder is the mock recorder for MockIReportQueue.
+type MockIReportQueueMockRecorder struct {
+	mock *MockIReportQueue
+}
+
+// NewMockIReportQueue creates a new mock instance.
+func NewMockIReportQueue(ctrl *gomock.Controller) *MockIReportQueue {
+	mock := &MockIReportQueue{ctrl: ctrl}
+	mock.recorder = &MockIReportQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReportQueue) EXPECT() *MockIReportQueueMockRecorder {
+	return m.recorder
+}
+
+// Ack mocks base method.
+func (m *MockIReportQueue) Ack(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ack", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ack indicates an expected call of Ack.
+func (mr *MockIReportQueueMockRecorder) Ack(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ack", reflect.TypeOf((*MockIReportQueue)(nil).Ack), ctx, id)
+}
+
+// Claim mocks base method.
+func (m *MockIReportQueue) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.SpinReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*models.SpinReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockIReportQueueMockRecorder) Claim(ctx, now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockIReportQueue)(nil).Claim), ctx, now, lease, limit)
+}
+
+// Enqueue mocks base method.
+func (m *MockIReportQueue) Enqueue(ctx context.Context, report *models.SpinReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockIReportQueueMockRecorder) Enqueue(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockIReportQueue)(nil).Enqueue), ctx, report)
+}
+
+// MockIDailySpinCounter is a mock of IDailySpinCounter interface.
+type MockIDailySpinCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDailySpinCounterMockRecorder
+}
+
+// MockIDailySpinCounterMockRecorder is the mock recorder for MockIDailySpinCounter.
+type MockIDailySpinCounterMockRecorder struct {
+	mock *MockIDailySpinCounter
+}
+
+// NewMockIDailySpinCounter creates a new mock instance.
+func NewMockIDailySpinCounter(ctrl *gomock.Controller) *MockIDailySpinCounter {
+	mock := &MockIDailySpinCounter{ctrl: ctrl}
+	mock.recorder = &MockIDailySpinCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDailySpinCounter) EXPECT() *MockIDailySpinCounterMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockIDailySpinCounter) Count(ctx context.Context, userID *uuid.UUID, day string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, userID, day)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockIDailySpinCounterMockRecorder) Count(ctx, userID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockIDailySpinCounter)(nil).Count), ctx, userID, day)
+}
+
+// Increment mocks base method.
+func (m *MockIDailySpinCounter) Increment(ctx context.Context, userID *uuid.UUID, day string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, userID, day)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockIDailySpinCounterMockRecorder) Increment(ctx, userID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockIDailySpinCounter)(nil).Increment), ctx, userID, day)
+}
+
+// MockIMaintenanceStore is a mock of IMaintenanceStore interface.
+type MockIMaintenanceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMaintenanceStoreMockRecorder
+}
+
+// MockIMaintenanceStoreMockRecorder is the mock recorder for MockIMaintenanceStore.
+type MockIMaintenanceStoreMockRecorder struct {
+	mock *MockIMaintenanceStore
+}
+
+// NewMockIMaintenanceStore creates a new mock instance.
+func NewMockIMaintenanceStore(ctrl *gomock.Controller) *MockIMaintenanceStore {
+	mock := &MockIMaintenanceStore{ctrl: ctrl}
+	mock.recorder = &MockIMaintenanceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMaintenanceStore) EXPECT() *MockIMaintenanceStoreMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockIMaintenanceStore) Enabled(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockIMaintenanceStoreMockRecorder) Enabled(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockIMaintenanceStore)(nil).Enabled), ctx)
+}
+
+// SetEnabled mocks base method.
+func (m *MockIMaintenanceStore) SetEnabled(ctx context.Context, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnabled", ctx, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEnabled indicates an expected call of SetEnabled.
+func (mr *MockIMaintenanceStoreMockRecorder) SetEnabled(ctx, enabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockIMaintenanceStore)(nil).SetEnabled), ctx, enabled)
+}
+
+// MockIJackpotStore is a mock of IJackpotStore interface.
+type MockIJackpotStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIJackpotStoreMockRecorder
+}
+
+// MockIJackpotStoreMockRecorder is the mock recorder for MockIJackpotStore.
+type MockIJackpotStoreMockRecorder struct {
+	mock *MockIJackpotStore
+}
+
+// NewMockIJackpotStore creates a new mock instance.
+func NewMockIJackpotStore(ctrl *gomock.Controller) *MockIJackpotStore {
+	mock := &MockIJackpotStore{ctrl: ctrl}
+	mock.recorder = &MockIJackpotStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIJackpotStore) EXPECT() *MockIJackpotStoreMockRecorder {
+	return m.recorder
+}
+
+// Contribute mocks base method.
+func (m *MockIJackpotStore) Contribute(ctx context.Context, amount float64) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contribute", ctx, amount)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Contribute indicates an expected call of Contribute.
+func (mr *MockIJackpotStoreMockRecorder) Contribute(ctx, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contribute", reflect.TypeOf((*MockIJackpotStore)(nil).Contribute), ctx, amount)
+}
+
+// Hit mocks base method.
+func (m *MockIJackpotStore) Hit(ctx context.Context) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hit", ctx)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hit indicates an expected call of Hit.
+func (mr *MockIJackpotStoreMockRecorder) Hit(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hit", reflect.TypeOf((*MockIJackpotStore)(nil).Hit), ctx)
+}
+
+// MockILastSpinStore is a mock of ILastSpinStore interface.
+type MockILastSpinStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockILastSpinStoreMockRecorder
+}
+
+// MockILastSpinStoreMockRecorder is the mock recorder for MockILastSpinStore.
+type MockILastSpinStoreMockRecorder struct {
+	mock *MockILastSpinStore
+}
+
+// NewMockILastSpinStore creates a new mock instance.
+func NewMockILastSpinStore(ctrl *gomock.Controller) *MockILastSpinStore {
+	mock := &MockILastSpinStore{ctrl: ctrl}
+	mock.recorder = &MockILastSpinStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILastSpinStore) EXPECT() *MockILastSpinStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockILastSpinStore) Get(ctx context.Context, userID *uuid.UUID) (*models.Spin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(*models.Spin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockILastSpinStoreMockRecorder) Get(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockILastSpinStore)(nil).Get), ctx, userID)
+}
+
+// Set mocks base method.
+func (m *MockILastSpinStore) Set(ctx context.Context, userID *uuid.UUID, spin *models.Spin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, userID, spin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockILastSpinStoreMockRecorder) Set(ctx, userID, spin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockILastSpinStore)(nil).Set), ctx, userID, spin)
+}
+
+// MockITokenStore is a mock of ITokenStore interface.
+type MockITokenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenStoreMockRecorder
+}
+
+// MockITokenStoreMockRecorder is the mock recorder for MockITokenStore.
+type MockITokenStoreMockRecorder struct {
+	mock *MockITokenStore
+}
+
+// NewMockITokenStore creates a new mock instance.
+func NewMockITokenStore(ctrl *gomock.Controller) *MockITokenStore {
+	mock := &MockITokenStore{ctrl: ctrl}
+	mock.recorder = &MockITokenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenStore) EXPECT() *MockITokenStoreMockRecorder {
+	return m.recorder
+}
+
+// Active mocks base method.
+func (m *MockITokenStore) Active(ctx context.Context, userID *uuid.UUID, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Active", ctx, userID, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Active indicates an expected call of Active.
+func (mr *MockITokenStoreMockRecorder) Active(ctx, userID, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Active", reflect.TypeOf((*MockITokenStore)(nil).Active), ctx, userID, tokenID)
+}
+
+// Add mocks base method.
+func (m *MockITokenStore) Add(ctx context.Context, userID *uuid.UUID, tokenID string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, userID, tokenID, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockITokenStoreMockRecorder) Add(ctx, userID, tokenID, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockITokenStore)(nil).Add), ctx, userID, tokenID, ttl)
+}
+
+// Remove mocks base method.
+func (m *MockITokenStore) Remove(ctx context.Context, userID *uuid.UUID, tokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userID, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockITokenStoreMockRecorder) Remove(ctx, userID, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockITokenStore)(nil).Remove), ctx, userID, tokenID)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: events.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/vadymlab/slot-game/internal/models"
+)
+
+// MockIEventPublisher is a mock of IEventPublisher interface.
+type MockIEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventPublisherMockRecorder
+}
+
+// MockIEventPublisherMockRecorder is the mock recorder for MockIEventPublisher.
+type MockIEventPublisherMockRecorder struct {
+	mock *MockIEventPublisher
+}
+
+// NewMockIEventPublisher creates a new mock instance.
+func NewMockIEventPublisher(ctrl *gomock.Controller) *MockIEventPublisher {
+	mock := &MockIEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockIEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventPublisher) EXPECT() *MockIEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIEventPublisher) Publish(ctx context.Context, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIEventPublisherMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIEventPublisher)(nil).Publish), ctx, event)
+}
+
+// MockIEventBus is a mock of IEventBus interface.
+type MockIEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventBusMockRecorder
+}
+
+// MockIEventBusMockRecorder is the mock recorder for MockIEventBus.
+type MockIEventBusMockRecorder struct {
+	mock *MockIEventBus
+}
+
+// NewMockIEventBus creates a new mock instance.
+func NewMockIEventBus(ctrl *gomock.Controller) *MockIEventBus {
+	mock := &MockIEventBus{ctrl: ctrl}
+	mock.recorder = &MockIEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventBus) EXPECT() *MockIEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIEventBus) Publish(ctx context.Context, event *models.DomainEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIEventBusMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIEventBus)(nil).Publish), ctx, event)
+}
+
+// MockISpinReporter is a mock of ISpinReporter interface.
+type MockISpinReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockISpinReporterMockRecorder
+}
+
+// MockISpinReporterMockRecorder is the mock recorder for MockISpinReporter.
+type MockISpinReporterMockRecorder struct {
+	mock *MockISpinReporter
+}
+
+// NewMockISpinReporter creates a new mock instance.
+func NewMockISpinReporter(ctrl *gomock.Controller) *MockISpinReporter {
+	mock := &MockISpinReporter{ctrl: ctrl}
+	mock.recorder = &MockISpinReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISpinReporter) EXPECT() *MockISpinReporterMockRecorder {
+	return m.recorder
+}
+
+// Report mocks base method.
+func (m *MockISpinReporter) Report(ctx context.Context, userID *uuid.UUID, spin *models.Spin) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Report", ctx, userID, spin)
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockISpinReporterMockRecorder) Report(ctx, userID, spin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockISpinReporter)(nil).Report), ctx, userID, spin)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,6 +37,36 @@ func (m *MockIUserRepository) EXPECT() *MockIUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddFreeSpins mocks base method.
+func (m *MockIUserRepository) AddFreeSpins(ctx context.Context, userID uint, count int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFreeSpins", ctx, userID, count)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFreeSpins indicates an expected call of AddFreeSpins.
+func (mr *MockIUserRepositoryMockRecorder) AddFreeSpins(ctx, userID, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFreeSpins", reflect.TypeOf((*MockIUserRepository)(nil).AddFreeSpins), ctx, userID, count)
+}
+
+// ApplySpinResult mocks base method.
+func (m *MockIUserRepository) ApplySpinResult(ctx context.Context, userID uint, bet, win float64) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySpinResult", ctx, userID, bet, win)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplySpinResult indicates an expected call of ApplySpinResult.
+func (mr *MockIUserRepositoryMockRecorder) ApplySpinResult(ctx, userID, bet, win interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySpinResult", reflect.TypeOf((*MockIUserRepository)(nil).ApplySpinResult), ctx, userID, bet, win)
+}
+
 // Create mocks base method.
 func (m *MockIUserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -51,22 +82,51 @@ func (mr *MockIUserRepositoryMockRecorder) Create(ctx, user interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserRepository)(nil).Create), ctx, user)
 }
 
+// Debit mocks base method.
+func (m *MockIUserRepository) Debit(ctx context.Context, userID uint, amount float64) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Debit", ctx, userID, amount)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Debit indicates an expected call of Debit.
+func (mr *MockIUserRepositoryMockRecorder) Debit(ctx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debit", reflect.TypeOf((*MockIUserRepository)(nil).Debit), ctx, userID, amount)
+}
+
 // Deposit mocks base method.
-func (m *MockIUserRepository) Deposit(ctx context.Context, userId uint, amount float64) (*float64, error) {
+func (m *MockIUserRepository) Deposit(ctx context.Context, userID uint, amount float64) (*float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Deposit", ctx, userId, amount)
+	ret := m.ctrl.Call(m, "Deposit", ctx, userID, amount)
 	ret0, _ := ret[0].(*float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Deposit indicates an expected call of Deposit.
-func (mr *MockIUserRepositoryMockRecorder) Deposit(ctx, userId, amount interface{}) *gomock.Call {
+func (mr *MockIUserRepositoryMockRecorder) Deposit(ctx, userID, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockIUserRepository)(nil).Deposit), ctx, userId, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockIUserRepository)(nil).Deposit), ctx, userID, amount)
 }
 
-// GetByExternalId mocks base method.
+// ExtendExclusion mocks base method.
+func (m *MockIUserRepository) ExtendExclusion(ctx context.Context, userID uint, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendExclusion", ctx, userID, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendExclusion indicates an expected call of ExtendExclusion.
+func (mr *MockIUserRepositoryMockRecorder) ExtendExclusion(ctx, userID, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendExclusion", reflect.TypeOf((*MockIUserRepository)(nil).ExtendExclusion), ctx, userID, until)
+}
+
+// GetByExternalID mocks base method.
 func (m *MockIUserRepository) GetByExternalID(ctx context.Context, id *uuid.UUID) (*models.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByExternalID", ctx, id)
@@ -75,13 +135,13 @@ func (m *MockIUserRepository) GetByExternalID(ctx context.Context, id *uuid.UUID
 	return ret0, ret1
 }
 
-// GetByExternalId indicates an expected call of GetByExternalId.
-func (mr *MockIUserRepositoryMockRecorder) GetByExternalId(ctx, id interface{}) *gomock.Call {
+// GetByExternalID indicates an expected call of GetByExternalID.
+func (mr *MockIUserRepositoryMockRecorder) GetByExternalID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExternalID", reflect.TypeOf((*MockIUserRepository)(nil).GetByExternalID), ctx, id)
 }
 
-// GetById mocks base method.
+// GetByID mocks base method.
 func (m *MockIUserRepository) GetByID(ctx context.Context, id uint) (*models.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByID", ctx, id)
@@ -90,8 +150,8 @@ func (m *MockIUserRepository) GetByID(ctx context.Context, id uint) (*models.Use
 	return ret0, ret1
 }
 
-// GetById indicates an expected call of GetById.
-func (mr *MockIUserRepositoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIUserRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIUserRepository)(nil).GetByID), ctx, id)
 }
@@ -111,19 +171,125 @@ func (mr *MockIUserRepositoryMockRecorder) GetByLogin(ctx, login interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLogin", reflect.TypeOf((*MockIUserRepository)(nil).GetByLogin), ctx, login)
 }
 
+// GetUsers mocks base method.
+func (m *MockIUserRepository) GetUsers(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIUserRepositoryMockRecorder) GetUsers(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIUserRepository)(nil).GetUsers), ctx, filter, limit, offset)
+}
+
+// LockUsers mocks base method.
+func (m *MockIUserRepository) LockUsers(ctx context.Context, userIDs ...uint) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range userIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LockUsers", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUsers indicates an expected call of LockUsers.
+func (mr *MockIUserRepositoryMockRecorder) LockUsers(ctx interface{}, userIDs ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, userIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUsers", reflect.TypeOf((*MockIUserRepository)(nil).LockUsers), varargs...)
+}
+
+// UpdateAutoStopWin mocks base method.
+func (m *MockIUserRepository) UpdateAutoStopWin(ctx context.Context, userID uint, autoStopWin *float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAutoStopWin", ctx, userID, autoStopWin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAutoStopWin indicates an expected call of UpdateAutoStopWin.
+func (mr *MockIUserRepositoryMockRecorder) UpdateAutoStopWin(ctx, userID, autoStopWin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAutoStopWin", reflect.TypeOf((*MockIUserRepository)(nil).UpdateAutoStopWin), ctx, userID, autoStopWin)
+}
+
+// UpdateDepositLimits mocks base method.
+func (m *MockIUserRepository) UpdateDepositLimits(ctx context.Context, userID uint, limits, pending models.DepositLimits, pendingAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDepositLimits", ctx, userID, limits, pending, pendingAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDepositLimits indicates an expected call of UpdateDepositLimits.
+func (mr *MockIUserRepositoryMockRecorder) UpdateDepositLimits(ctx, userID, limits, pending, pendingAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDepositLimits", reflect.TypeOf((*MockIUserRepository)(nil).UpdateDepositLimits), ctx, userID, limits, pending, pendingAt)
+}
+
+// UpdateFrozen mocks base method.
+func (m *MockIUserRepository) UpdateFrozen(ctx context.Context, userID uint, frozen bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFrozen", ctx, userID, frozen)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFrozen indicates an expected call of UpdateFrozen.
+func (mr *MockIUserRepositoryMockRecorder) UpdateFrozen(ctx, userID, frozen interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFrozen", reflect.TypeOf((*MockIUserRepository)(nil).UpdateFrozen), ctx, userID, frozen)
+}
+
+// UpdateLogin mocks base method.
+func (m *MockIUserRepository) UpdateLogin(ctx context.Context, userID uint, login string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLogin", ctx, userID, login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLogin indicates an expected call of UpdateLogin.
+func (mr *MockIUserRepositoryMockRecorder) UpdateLogin(ctx, userID, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogin", reflect.TypeOf((*MockIUserRepository)(nil).UpdateLogin), ctx, userID, login)
+}
+
+// UseFreeSpin mocks base method.
+func (m *MockIUserRepository) UseFreeSpin(ctx context.Context, userID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseFreeSpin", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseFreeSpin indicates an expected call of UseFreeSpin.
+func (mr *MockIUserRepositoryMockRecorder) UseFreeSpin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseFreeSpin", reflect.TypeOf((*MockIUserRepository)(nil).UseFreeSpin), ctx, userID)
+}
+
 // Withdraw mocks base method.
-func (m *MockIUserRepository) Withdraw(ctx context.Context, userId uint, amount float64) (*float64, error) {
+func (m *MockIUserRepository) Withdraw(ctx context.Context, userID uint, amount float64) (*float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Withdraw", ctx, userId, amount)
+	ret := m.ctrl.Call(m, "Withdraw", ctx, userID, amount)
 	ret0, _ := ret[0].(*float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Withdraw indicates an expected call of Withdraw.
-func (mr *MockIUserRepositoryMockRecorder) Withdraw(ctx, userId, amount interface{}) *gomock.Call {
+func (mr *MockIUserRepositoryMockRecorder) Withdraw(ctx, userID, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockIUserRepository)(nil).Withdraw), ctx, userId, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockIUserRepository)(nil).Withdraw), ctx, userID, amount)
 }
 
 // MockIWalletRepository is a mock of IWalletRepository interface.
@@ -150,18 +316,63 @@ func (m *MockIWalletRepository) EXPECT() *MockIWalletRepositoryMockRecorder {
 }
 
 // GetBalance mocks base method.
-func (m *MockIWalletRepository) GetBalance(ctx context.Context, userId uint) (float64, error) {
+func (m *MockIWalletRepository) GetBalance(ctx context.Context, userID uint) (float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBalance", ctx, userId)
+	ret := m.ctrl.Call(m, "GetBalance", ctx, userID)
 	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBalance indicates an expected call of GetBalance.
-func (mr *MockIWalletRepositoryMockRecorder) GetBalance(ctx, userId interface{}) *gomock.Call {
+func (mr *MockIWalletRepositoryMockRecorder) GetBalance(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockIWalletRepository)(nil).GetBalance), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockIWalletRepository)(nil).GetBalance), ctx, userID)
+}
+
+// GetBalanceByExternalID mocks base method.
+func (m *MockIWalletRepository) GetBalanceByExternalID(ctx context.Context, userID *uuid.UUID) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceByExternalID", ctx, userID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceByExternalID indicates an expected call of GetBalanceByExternalID.
+func (mr *MockIWalletRepositoryMockRecorder) GetBalanceByExternalID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceByExternalID", reflect.TypeOf((*MockIWalletRepository)(nil).GetBalanceByExternalID), ctx, userID)
+}
+
+// GetWalletBalanceByExternalID mocks base method.
+func (m *MockIWalletRepository) GetWalletBalanceByExternalID(ctx context.Context, userID *uuid.UUID, currency string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletBalanceByExternalID", ctx, userID, currency)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletBalanceByExternalID indicates an expected call of GetWalletBalanceByExternalID.
+func (mr *MockIWalletRepositoryMockRecorder) GetWalletBalanceByExternalID(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletBalanceByExternalID", reflect.TypeOf((*MockIWalletRepository)(nil).GetWalletBalanceByExternalID), ctx, userID, currency)
+}
+
+// GetWallets mocks base method.
+func (m *MockIWalletRepository) GetWallets(ctx context.Context, userID uint) ([]*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallets", ctx, userID)
+	ret0, _ := ret[0].([]*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallets indicates an expected call of GetWallets.
+func (mr *MockIWalletRepositoryMockRecorder) GetWallets(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallets", reflect.TypeOf((*MockIWalletRepository)(nil).GetWallets), ctx, userID)
 }
 
 // MockISlotRepository is a mock of ISlotRepository interface.
@@ -187,6 +398,20 @@ func (m *MockISlotRepository) EXPECT() *MockISlotRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddRTPReport mocks base method.
+func (m *MockISlotRepository) AddRTPReport(ctx context.Context, report *models.RTPReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRTPReport", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRTPReport indicates an expected call of AddRTPReport.
+func (mr *MockISlotRepositoryMockRecorder) AddRTPReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRTPReport", reflect.TypeOf((*MockISlotRepository)(nil).AddRTPReport), ctx, report)
+}
+
 // AddSpin mocks base method.
 func (m *MockISlotRepository) AddSpin(ctx context.Context, spin *models.Spin) error {
 	m.ctrl.T.Helper()
@@ -201,17 +426,548 @@ func (mr *MockISlotRepositoryMockRecorder) AddSpin(ctx, spin interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSpin", reflect.TypeOf((*MockISlotRepository)(nil).AddSpin), ctx, spin)
 }
 
+// AddSpins mocks base method.
+func (m *MockISlotRepository) AddSpins(ctx context.Context, spins []*models.Spin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSpins", ctx, spins)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSpins indicates an expected call of AddSpins.
+func (mr *MockISlotRepositoryMockRecorder) AddSpins(ctx, spins interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSpins", reflect.TypeOf((*MockISlotRepository)(nil).AddSpins), ctx, spins)
+}
+
+// DeleteSpinsBefore mocks base method.
+func (m *MockISlotRepository) DeleteSpinsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSpinsBefore", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSpinsBefore indicates an expected call of DeleteSpinsBefore.
+func (mr *MockISlotRepositoryMockRecorder) DeleteSpinsBefore(ctx, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSpinsBefore", reflect.TypeOf((*MockISlotRepository)(nil).DeleteSpinsBefore), ctx, before, limit)
+}
+
+// EachChainedSpin mocks base method.
+func (m *MockISlotRepository) EachChainedSpin(ctx context.Context, userID uint, fn func(*models.Spin) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachChainedSpin", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachChainedSpin indicates an expected call of EachChainedSpin.
+func (mr *MockISlotRepositoryMockRecorder) EachChainedSpin(ctx, userID, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachChainedSpin", reflect.TypeOf((*MockISlotRepository)(nil).EachChainedSpin), ctx, userID, fn)
+}
+
+// EachSpin mocks base method.
+func (m *MockISlotRepository) EachSpin(ctx context.Context, userID uint, from, to *time.Time, fn func(*models.Spin) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachSpin", ctx, userID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachSpin indicates an expected call of EachSpin.
+func (mr *MockISlotRepositoryMockRecorder) EachSpin(ctx, userID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachSpin", reflect.TypeOf((*MockISlotRepository)(nil).EachSpin), ctx, userID, from, to, fn)
+}
+
+// GetLeaderboard mocks base method.
+func (m *MockISlotRepository) GetLeaderboard(ctx context.Context, since *time.Time, limit int) ([]*models.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", ctx, since, limit)
+	ret0, _ := ret[0].([]*models.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockISlotRepositoryMockRecorder) GetLeaderboard(ctx, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockISlotRepository)(nil).GetLeaderboard), ctx, since, limit)
+}
+
+// GetSpinForUpdate mocks base method.
+func (m *MockISlotRepository) GetSpinForUpdate(ctx context.Context, spinID uint) (*models.Spin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpinForUpdate", ctx, spinID)
+	ret0, _ := ret[0].(*models.Spin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpinForUpdate indicates an expected call of GetSpinForUpdate.
+func (mr *MockISlotRepositoryMockRecorder) GetSpinForUpdate(ctx, spinID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpinForUpdate", reflect.TypeOf((*MockISlotRepository)(nil).GetSpinForUpdate), ctx, spinID)
+}
+
+// GetSpinStats mocks base method.
+func (m *MockISlotRepository) GetSpinStats(ctx context.Context, userID uint) (*models.SpinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpinStats", ctx, userID)
+	ret0, _ := ret[0].(*models.SpinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpinStats indicates an expected call of GetSpinStats.
+func (mr *MockISlotRepositoryMockRecorder) GetSpinStats(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpinStats", reflect.TypeOf((*MockISlotRepository)(nil).GetSpinStats), ctx, userID)
+}
+
+// GetSpinStatsBetween mocks base method.
+func (m *MockISlotRepository) GetSpinStatsBetween(ctx context.Context, from, to time.Time) (*models.SpinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpinStatsBetween", ctx, from, to)
+	ret0, _ := ret[0].(*models.SpinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpinStatsBetween indicates an expected call of GetSpinStatsBetween.
+func (mr *MockISlotRepositoryMockRecorder) GetSpinStatsBetween(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpinStatsBetween", reflect.TypeOf((*MockISlotRepository)(nil).GetSpinStatsBetween), ctx, from, to)
+}
+
 // GetSpins mocks base method.
-func (m *MockISlotRepository) GetSpins(ctx context.Context, userId uint) ([]*models.Spin, error) {
+func (m *MockISlotRepository) GetSpins(ctx context.Context, userID uint, from, to *time.Time, limit, offset int) ([]*models.Spin, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSpins", ctx, userId)
+	ret := m.ctrl.Call(m, "GetSpins", ctx, userID, from, to, limit, offset)
 	ret0, _ := ret[0].([]*models.Spin)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSpins indicates an expected call of GetSpins.
+func (mr *MockISlotRepositoryMockRecorder) GetSpins(ctx, userID, from, to, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpins", reflect.TypeOf((*MockISlotRepository)(nil).GetSpins), ctx, userID, from, to, limit, offset)
+}
+
+// LastSpinHash mocks base method.
+func (m *MockISlotRepository) LastSpinHash(ctx context.Context, userID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastSpinHash", ctx, userID)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSpins indicates an expected call of GetSpins.
-func (mr *MockISlotRepositoryMockRecorder) GetSpins(ctx, userId interface{}) *gomock.Call {
+// LastSpinHash indicates an expected call of LastSpinHash.
+func (mr *MockISlotRepositoryMockRecorder) LastSpinHash(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastSpinHash", reflect.TypeOf((*MockISlotRepository)(nil).LastSpinHash), ctx, userID)
+}
+
+// VoidSpin mocks base method.
+func (m *MockISlotRepository) VoidSpin(ctx context.Context, spinID uint, reason string, voidedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidSpin", ctx, spinID, reason, voidedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VoidSpin indicates an expected call of VoidSpin.
+func (mr *MockISlotRepositoryMockRecorder) VoidSpin(ctx, spinID, reason, voidedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidSpin", reflect.TypeOf((*MockISlotRepository)(nil).VoidSpin), ctx, spinID, reason, voidedAt)
+}
+
+// MockISpinWriter is a mock of ISpinWriter interface.
+type MockISpinWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockISpinWriterMockRecorder
+}
+
+// MockISpinWriterMockRecorder is the mock recorder for MockISpinWriter.
+type MockISpinWriterMockRecorder struct {
+	mock *MockISpinWriter
+}
+
+// NewMockISpinWriter creates a new mock instance.
+func NewMockISpinWriter(ctrl *gomock.Controller) *MockISpinWriter {
+	mock := &MockISpinWriter{ctrl: ctrl}
+	mock.recorder = &MockISpinWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISpinWriter) EXPECT() *MockISpinWriterMockRecorder {
+	return m.recorder
+}
+
+// Write mocks base method.
+func (m *MockISpinWriter) Write(ctx context.Context, spin *models.Spin) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Write", ctx, spin)
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockISpinWriterMockRecorder) Write(ctx, spin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockISpinWriter)(nil).Write), ctx, spin)
+}
+
+// MockILedgerRepository is a mock of ILedgerRepository interface.
+type MockILedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILedgerRepositoryMockRecorder
+}
+
+// MockILedgerRepositoryMockRecorder is the mock recorder for MockILedgerRepository.
+type MockILedgerRepositoryMockRecorder struct {
+	mock *MockILedgerRepository
+}
+
+// NewMockILedgerRepository creates a new mock instance.
+func NewMockILedgerRepository(ctrl *gomock.Controller) *MockILedgerRepository {
+	mock := &MockILedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockILedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILedgerRepository) EXPECT() *MockILedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddEntry mocks base method.
+func (m *MockILedgerRepository) AddEntry(ctx context.Context, entry *models.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEntry indicates an expected call of AddEntry.
+func (mr *MockILedgerRepositoryMockRecorder) AddEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEntry", reflect.TypeOf((*MockILedgerRepository)(nil).AddEntry), ctx, entry)
+}
+
+// SumEntries mocks base method.
+func (m *MockILedgerRepository) SumEntries(ctx context.Context, userID uint, entryType string, since time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumEntries", ctx, userID, entryType, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumEntries indicates an expected call of SumEntries.
+func (mr *MockILedgerRepositoryMockRecorder) SumEntries(ctx, userID, entryType, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumEntries", reflect.TypeOf((*MockILedgerRepository)(nil).SumEntries), ctx, userID, entryType, since)
+}
+
+// MockIWithdrawalRepository is a mock of IWithdrawalRepository interface.
+type MockIWithdrawalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWithdrawalRepositoryMockRecorder
+}
+
+// MockIWithdrawalRepositoryMockRecorder is the mock recorder for MockIWithdrawalRepository.
+type MockIWithdrawalRepositoryMockRecorder struct {
+	mock *MockIWithdrawalRepository
+}
+
+// NewMockIWithdrawalRepository creates a new mock instance.
+func NewMockIWithdrawalRepository(ctrl *gomock.Controller) *MockIWithdrawalRepository {
+	mock := &MockIWithdrawalRepository{ctrl: ctrl}
+	mock.recorder = &MockIWithdrawalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWithdrawalRepository) EXPECT() *MockIWithdrawalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddWithdrawal mocks base method.
+func (m *MockIWithdrawalRepository) AddWithdrawal(ctx context.Context, withdrawal *models.PendingWithdrawal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWithdrawal", ctx, withdrawal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWithdrawal indicates an expected call of AddWithdrawal.
+func (mr *MockIWithdrawalRepositoryMockRecorder) AddWithdrawal(ctx, withdrawal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWithdrawal", reflect.TypeOf((*MockIWithdrawalRepository)(nil).AddWithdrawal), ctx, withdrawal)
+}
+
+// DecideWithdrawal mocks base method.
+func (m *MockIWithdrawalRepository) DecideWithdrawal(ctx context.Context, withdrawalID uint, status, reason string, decidedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideWithdrawal", ctx, withdrawalID, status, reason, decidedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecideWithdrawal indicates an expected call of DecideWithdrawal.
+func (mr *MockIWithdrawalRepositoryMockRecorder) DecideWithdrawal(ctx, withdrawalID, status, reason, decidedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideWithdrawal", reflect.TypeOf((*MockIWithdrawalRepository)(nil).DecideWithdrawal), ctx, withdrawalID, status, reason, decidedAt)
+}
+
+// GetWithdrawalForUpdate mocks base method.
+func (m *MockIWithdrawalRepository) GetWithdrawalForUpdate(ctx context.Context, withdrawalID uint) (*models.PendingWithdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawalForUpdate", ctx, withdrawalID)
+	ret0, _ := ret[0].(*models.PendingWithdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawalForUpdate indicates an expected call of GetWithdrawalForUpdate.
+func (mr *MockIWithdrawalRepositoryMockRecorder) GetWithdrawalForUpdate(ctx, withdrawalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalForUpdate", reflect.TypeOf((*MockIWithdrawalRepository)(nil).GetWithdrawalForUpdate), ctx, withdrawalID)
+}
+
+// MockIAuthEventRepository is a mock of IAuthEventRepository interface.
+type MockIAuthEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthEventRepositoryMockRecorder
+}
+
+// MockIAuthEventRepositoryMockRecorder is the mock recorder for MockIAuthEventRepository.
+type MockIAuthEventRepositoryMockRecorder struct {
+	mock *MockIAuthEventRepository
+}
+
+// NewMockIAuthEventRepository creates a new mock instance.
+func NewMockIAuthEventRepository(ctrl *gomock.Controller) *MockIAuthEventRepository {
+	mock := &MockIAuthEventRepository{ctrl: ctrl}
+	mock.recorder = &MockIAuthEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthEventRepository) EXPECT() *MockIAuthEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddEvent mocks base method.
+func (m *MockIAuthEventRepository) AddEvent(ctx context.Context, event *models.AuthEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEvent indicates an expected call of AddEvent.
+func (mr *MockIAuthEventRepositoryMockRecorder) AddEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvent", reflect.TypeOf((*MockIAuthEventRepository)(nil).AddEvent), ctx, event)
+}
+
+// GetEvents mocks base method.
+func (m *MockIAuthEventRepository) GetEvents(ctx context.Context, userID uint, limit, offset int) ([]*models.AuthEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*models.AuthEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockIAuthEventRepositoryMockRecorder) GetEvents(ctx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockIAuthEventRepository)(nil).GetEvents), ctx, userID, limit, offset)
+}
+
+// MockIPromoRepository is a mock of IPromoRepository interface.
+type MockIPromoRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPromoRepositoryMockRecorder
+}
+
+// MockIPromoRepositoryMockRecorder is the mock recorder for MockIPromoRepository.
+type MockIPromoRepositoryMockRecorder struct {
+	mock *MockIPromoRepository
+}
+
+// NewMockIPromoRepository creates a new mock instance.
+func NewMockIPromoRepository(ctrl *gomock.Controller) *MockIPromoRepository {
+	mock := &MockIPromoRepository{ctrl: ctrl}
+	mock.recorder = &MockIPromoRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPromoRepository) EXPECT() *MockIPromoRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddRedemption mocks base method.
+func (m *MockIPromoRepository) AddRedemption(ctx context.Context, redemption *models.PromoRedemption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRedemption", ctx, redemption)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRedemption indicates an expected call of AddRedemption.
+func (mr *MockIPromoRepositoryMockRecorder) AddRedemption(ctx, redemption interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRedemption", reflect.TypeOf((*MockIPromoRepository)(nil).AddRedemption), ctx, redemption)
+}
+
+// CountRedemptions mocks base method.
+func (m *MockIPromoRepository) CountRedemptions(ctx context.Context, promoCodeID, userID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRedemptions", ctx, promoCodeID, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRedemptions indicates an expected call of CountRedemptions.
+func (mr *MockIPromoRepositoryMockRecorder) CountRedemptions(ctx, promoCodeID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRedemptions", reflect.TypeOf((*MockIPromoRepository)(nil).CountRedemptions), ctx, promoCodeID, userID)
+}
+
+// GetByCode mocks base method.
+func (m *MockIPromoRepository) GetByCode(ctx context.Context, code string) (*models.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*models.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockIPromoRepositoryMockRecorder) GetByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockIPromoRepository)(nil).GetByCode), ctx, code)
+}
+
+// MockISessionRepository is a mock of ISessionRepository interface.
+type MockISessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionRepositoryMockRecorder
+}
+
+// MockISessionRepositoryMockRecorder is the mock recorder for MockISessionRepository.
+type MockISessionRepositoryMockRecorder struct {
+	mock *MockISessionRepository
+}
+
+// NewMockISessionRepository creates a new mock instance.
+func NewMockISessionRepository(ctrl *gomock.Controller) *MockISessionRepository {
+	mock := &MockISessionRepository{ctrl: ctrl}
+	mock.recorder = &MockISessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionRepository) EXPECT() *MockISessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcknowledgeRealityCheck mocks base method.
+func (m *MockISessionRepository) AcknowledgeRealityCheck(ctx context.Context, sessionID uint, acknowledgedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeRealityCheck", ctx, sessionID, acknowledgedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcknowledgeRealityCheck indicates an expected call of AcknowledgeRealityCheck.
+func (mr *MockISessionRepositoryMockRecorder) AcknowledgeRealityCheck(ctx, sessionID, acknowledgedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeRealityCheck", reflect.TypeOf((*MockISessionRepository)(nil).AcknowledgeRealityCheck), ctx, sessionID, acknowledgedAt)
+}
+
+// AddSession mocks base method.
+func (m *MockISessionRepository) AddSession(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockISessionRepositoryMockRecorder) AddSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockISessionRepository)(nil).AddSession), ctx, session)
+}
+
+// EndSession mocks base method.
+func (m *MockISessionRepository) EndSession(ctx context.Context, sessionID uint, endedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndSession", ctx, sessionID, endedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndSession indicates an expected call of EndSession.
+func (mr *MockISessionRepositoryMockRecorder) EndSession(ctx, sessionID, endedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndSession", reflect.TypeOf((*MockISessionRepository)(nil).EndSession), ctx, sessionID, endedAt)
+}
+
+// GetSession mocks base method.
+func (m *MockISessionRepository) GetSession(ctx context.Context, sessionID uint) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionID)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockISessionRepositoryMockRecorder) GetSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockISessionRepository)(nil).GetSession), ctx, sessionID)
+}
+
+// GetSessionSummaries mocks base method.
+func (m *MockISessionRepository) GetSessionSummaries(ctx context.Context, userID uint, limit, offset int) ([]*models.SessionSummary, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionSummaries", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*models.SessionSummary)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSessionSummaries indicates an expected call of GetSessionSummaries.
+func (mr *MockISessionRepositoryMockRecorder) GetSessionSummaries(ctx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionSummaries", reflect.TypeOf((*MockISessionRepository)(nil).GetSessionSummaries), ctx, userID, limit, offset)
+}
+
+// GetSessionSummary mocks base method.
+func (m *MockISessionRepository) GetSessionSummary(ctx context.Context, sessionID uint) (*models.SessionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionSummary", ctx, sessionID)
+	ret0, _ := ret[0].(*models.SessionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionSummary indicates an expected call of GetSessionSummary.
+func (mr *MockISessionRepositoryMockRecorder) GetSessionSummary(ctx, sessionID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpins", reflect.TypeOf((*MockISlotRepository)(nil).GetSpins), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionSummary", reflect.TypeOf((*MockISessionRepository)(nil).GetSessionSummary), ctx, sessionID)
 }
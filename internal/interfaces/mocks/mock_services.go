@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,19 +37,65 @@ func (m *MockIUserService) EXPECT() *MockIUserServiceMockRecorder {
 	return m.recorder
 }
 
+// ApplySpinResult mocks base method.
+func (m *MockIUserService) ApplySpinResult(ctx context.Context, userID *uuid.UUID, bet, win float64) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySpinResult", ctx, userID, bet, win)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplySpinResult indicates an expected call of ApplySpinResult.
+func (mr *MockIUserServiceMockRecorder) ApplySpinResult(ctx, userID, bet, win interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySpinResult", reflect.TypeOf((*MockIUserService)(nil).ApplySpinResult), ctx, userID, bet, win)
+}
+
+// AwardFreeSpins mocks base method.
+func (m *MockIUserService) AwardFreeSpins(ctx context.Context, userID *uuid.UUID, count int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardFreeSpins", ctx, userID, count)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardFreeSpins indicates an expected call of AwardFreeSpins.
+func (mr *MockIUserServiceMockRecorder) AwardFreeSpins(ctx, userID, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardFreeSpins", reflect.TypeOf((*MockIUserService)(nil).AwardFreeSpins), ctx, userID, count)
+}
+
 // Deposit mocks base method.
-func (m *MockIUserService) Deposit(ctx context.Context, userId *uuid.UUID, amount float64) (*float64, error) {
+func (m *MockIUserService) Deposit(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Deposit", ctx, userId, amount)
+	ret := m.ctrl.Call(m, "Deposit", ctx, userID, amount)
 	ret0, _ := ret[0].(*float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Deposit indicates an expected call of Deposit.
-func (mr *MockIUserServiceMockRecorder) Deposit(ctx, userId, amount interface{}) *gomock.Call {
+func (mr *MockIUserServiceMockRecorder) Deposit(ctx, userID, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockIUserService)(nil).Deposit), ctx, userId, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockIUserService)(nil).Deposit), ctx, userID, amount)
+}
+
+// DepositWithPromo mocks base method.
+func (m *MockIUserService) DepositWithPromo(ctx context.Context, userID *uuid.UUID, amount float64, promoCode string) (*float64, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DepositWithPromo", ctx, userID, amount, promoCode)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DepositWithPromo indicates an expected call of DepositWithPromo.
+func (mr *MockIUserServiceMockRecorder) DepositWithPromo(ctx, userID, amount, promoCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositWithPromo", reflect.TypeOf((*MockIUserService)(nil).DepositWithPromo), ctx, userID, amount, promoCode)
 }
 
 // GetByExternalID mocks base method.
@@ -60,8 +107,8 @@ func (m *MockIUserService) GetByExternalID(ctx context.Context, id *uuid.UUID) (
 	return ret0, ret1
 }
 
-// GetByExternalId indicates an expected call of GetByExternalId.
-func (mr *MockIUserServiceMockRecorder) GetByExternalId(ctx, id interface{}) *gomock.Call {
+// GetByExternalID indicates an expected call of GetByExternalID.
+func (mr *MockIUserServiceMockRecorder) GetByExternalID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExternalID", reflect.TypeOf((*MockIUserService)(nil).GetByExternalID), ctx, id)
 }
@@ -75,12 +122,28 @@ func (m *MockIUserService) GetByID(ctx context.Context, id uint) (*models.User,
 	return ret0, ret1
 }
 
-// GetById indicates an expected call of GetById.
-func (mr *MockIUserServiceMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIUserServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIUserService)(nil).GetByID), ctx, id)
 }
 
+// ListUsers mocks base method.
+func (m *MockIUserService) ListUsers(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockIUserServiceMockRecorder) ListUsers(ctx, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockIUserService)(nil).ListUsers), ctx, filter, limit, offset)
+}
+
 // Login mocks base method.
 func (m *MockIUserService) Login(ctx context.Context, login, password string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -111,19 +174,124 @@ func (mr *MockIUserServiceMockRecorder) Register(ctx, login, password interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIUserService)(nil).Register), ctx, login, password)
 }
 
+// SelfExclude mocks base method.
+func (m *MockIUserService) SelfExclude(ctx context.Context, userID *uuid.UUID, period time.Duration) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelfExclude", ctx, userID, period)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelfExclude indicates an expected call of SelfExclude.
+func (mr *MockIUserServiceMockRecorder) SelfExclude(ctx, userID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelfExclude", reflect.TypeOf((*MockIUserService)(nil).SelfExclude), ctx, userID, period)
+}
+
+// SetDepositLimits mocks base method.
+func (m *MockIUserService) SetDepositLimits(ctx context.Context, userID *uuid.UUID, limits models.DepositLimits) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDepositLimits", ctx, userID, limits)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDepositLimits indicates an expected call of SetDepositLimits.
+func (mr *MockIUserServiceMockRecorder) SetDepositLimits(ctx, userID, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDepositLimits", reflect.TypeOf((*MockIUserService)(nil).SetDepositLimits), ctx, userID, limits)
+}
+
+// SetFrozen mocks base method.
+func (m *MockIUserService) SetFrozen(ctx context.Context, userID *uuid.UUID, frozen bool) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFrozen", ctx, userID, frozen)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetFrozen indicates an expected call of SetFrozen.
+func (mr *MockIUserServiceMockRecorder) SetFrozen(ctx, userID, frozen interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFrozen", reflect.TypeOf((*MockIUserService)(nil).SetFrozen), ctx, userID, frozen)
+}
+
+// Transfer mocks base method.
+func (m *MockIUserService) Transfer(ctx context.Context, senderID, recipientID *uuid.UUID, amount float64) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, senderID, recipientID, amount)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockIUserServiceMockRecorder) Transfer(ctx, senderID, recipientID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockIUserService)(nil).Transfer), ctx, senderID, recipientID, amount)
+}
+
+// UpdateLogin mocks base method.
+func (m *MockIUserService) UpdateLogin(ctx context.Context, userID *uuid.UUID, login, password string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLogin", ctx, userID, login, password)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLogin indicates an expected call of UpdateLogin.
+func (mr *MockIUserServiceMockRecorder) UpdateLogin(ctx, userID, login, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogin", reflect.TypeOf((*MockIUserService)(nil).UpdateLogin), ctx, userID, login, password)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockIUserService) UpdateSettings(ctx context.Context, userID *uuid.UUID, autoStopWin *float64) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, userID, autoStopWin)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockIUserServiceMockRecorder) UpdateSettings(ctx, userID, autoStopWin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockIUserService)(nil).UpdateSettings), ctx, userID, autoStopWin)
+}
+
+// UseFreeSpin mocks base method.
+func (m *MockIUserService) UseFreeSpin(ctx context.Context, userID *uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseFreeSpin", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseFreeSpin indicates an expected call of UseFreeSpin.
+func (mr *MockIUserServiceMockRecorder) UseFreeSpin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseFreeSpin", reflect.TypeOf((*MockIUserService)(nil).UseFreeSpin), ctx, userID)
+}
+
 // Withdraw mocks base method.
-func (m *MockIUserService) Withdraw(ctx context.Context, userId *uuid.UUID, amount float64) (*float64, error) {
+func (m *MockIUserService) Withdraw(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Withdraw", ctx, userId, amount)
+	ret := m.ctrl.Call(m, "Withdraw", ctx, userID, amount)
 	ret0, _ := ret[0].(*float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Withdraw indicates an expected call of Withdraw.
-func (mr *MockIUserServiceMockRecorder) Withdraw(ctx, userId, amount interface{}) *gomock.Call {
+func (mr *MockIUserServiceMockRecorder) Withdraw(ctx, userID, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockIUserService)(nil).Withdraw), ctx, userId, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockIUserService)(nil).Withdraw), ctx, userID, amount)
 }
 
 // MockISlotService is a mock of ISlotService interface.
@@ -149,32 +317,674 @@ func (m *MockISlotService) EXPECT() *MockISlotServiceMockRecorder {
 	return m.recorder
 }
 
+// BulkSpin mocks base method.
+func (m *MockISlotService) BulkSpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64, count int) (*models.BulkSpin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkSpin", ctx, userID, gameID, betAmount, count)
+	ret0, _ := ret[0].(*models.BulkSpin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkSpin indicates an expected call of BulkSpin.
+func (mr *MockISlotServiceMockRecorder) BulkSpin(ctx, userID, gameID, betAmount, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkSpin", reflect.TypeOf((*MockISlotService)(nil).BulkSpin), ctx, userID, gameID, betAmount, count)
+}
+
+// DemoSpin mocks base method.
+func (m *MockISlotService) DemoSpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64) (*models.Spin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DemoSpin", ctx, userID, gameID, betAmount)
+	ret0, _ := ret[0].(*models.Spin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DemoSpin indicates an expected call of DemoSpin.
+func (mr *MockISlotServiceMockRecorder) DemoSpin(ctx, userID, gameID, betAmount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DemoSpin", reflect.TypeOf((*MockISlotService)(nil).DemoSpin), ctx, userID, gameID, betAmount)
+}
+
+// EndDemo mocks base method.
+func (m *MockISlotService) EndDemo(ctx context.Context, userID *uuid.UUID) (*float64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndDemo", ctx, userID)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EndDemo indicates an expected call of EndDemo.
+func (mr *MockISlotServiceMockRecorder) EndDemo(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndDemo", reflect.TypeOf((*MockISlotService)(nil).EndDemo), ctx, userID)
+}
+
+// ExportHistory mocks base method.
+func (m *MockISlotService) ExportHistory(ctx context.Context, userID *uuid.UUID, from, to *time.Time, fn func(*models.Spin) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportHistory", ctx, userID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportHistory indicates an expected call of ExportHistory.
+func (mr *MockISlotServiceMockRecorder) ExportHistory(ctx, userID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportHistory", reflect.TypeOf((*MockISlotService)(nil).ExportHistory), ctx, userID, from, to, fn)
+}
+
+// FreeSpin mocks base method.
+func (m *MockISlotService) FreeSpin(ctx context.Context, userID *uuid.UUID, gameID string) (*models.Spin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreeSpin", ctx, userID, gameID)
+	ret0, _ := ret[0].(*models.Spin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreeSpin indicates an expected call of FreeSpin.
+func (mr *MockISlotServiceMockRecorder) FreeSpin(ctx, userID, gameID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreeSpin", reflect.TypeOf((*MockISlotService)(nil).FreeSpin), ctx, userID, gameID)
+}
+
 // History mocks base method.
-func (m *MockISlotService) History(ctx context.Context, userId *uuid.UUID) ([]*models.Spin, error) {
+func (m *MockISlotService) History(ctx context.Context, userID *uuid.UUID, from, to *time.Time, limit, offset int) ([]*models.Spin, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "History", ctx, userId)
+	ret := m.ctrl.Call(m, "History", ctx, userID, from, to, limit, offset)
 	ret0, _ := ret[0].([]*models.Spin)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// History indicates an expected call of History.
+func (mr *MockISlotServiceMockRecorder) History(ctx, userID, from, to, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockISlotService)(nil).History), ctx, userID, from, to, limit, offset)
+}
+
+// LastSpin mocks base method.
+func (m *MockISlotService) LastSpin(ctx context.Context, userID *uuid.UUID) (*models.Spin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastSpin", ctx, userID)
+	ret0, _ := ret[0].(*models.Spin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// History indicates an expected call of History.
-func (mr *MockISlotServiceMockRecorder) History(ctx, userId interface{}) *gomock.Call {
+// LastSpin indicates an expected call of LastSpin.
+func (mr *MockISlotServiceMockRecorder) LastSpin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastSpin", reflect.TypeOf((*MockISlotService)(nil).LastSpin), ctx, userID)
+}
+
+// Leaderboard mocks base method.
+func (m *MockISlotService) Leaderboard(ctx context.Context, period string) ([]*models.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Leaderboard", ctx, period)
+	ret0, _ := ret[0].([]*models.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Leaderboard indicates an expected call of Leaderboard.
+func (mr *MockISlotServiceMockRecorder) Leaderboard(ctx, period interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockISlotService)(nil).History), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leaderboard", reflect.TypeOf((*MockISlotService)(nil).Leaderboard), ctx, period)
 }
 
 // RetrySpin mocks base method.
-func (m *MockISlotService) RetrySpin(ctx context.Context, userId *uuid.UUID, betAmount float64) (*models.Spin, error) {
+func (m *MockISlotService) RetrySpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64) (*models.Spin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RetrySpin", ctx, userId, betAmount)
+	ret := m.ctrl.Call(m, "RetrySpin", ctx, userID, gameID, betAmount)
 	ret0, _ := ret[0].(*models.Spin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RetrySpin indicates an expected call of RetrySpin.
-func (mr *MockISlotServiceMockRecorder) RetrySpin(ctx, userId, betAmount interface{}) *gomock.Call {
+func (mr *MockISlotServiceMockRecorder) RetrySpin(ctx, userID, gameID, betAmount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrySpin", reflect.TypeOf((*MockISlotService)(nil).RetrySpin), ctx, userID, gameID, betAmount)
+}
+
+// SpinInCurrency mocks base method.
+func (m *MockISlotService) SpinInCurrency(ctx context.Context, userID *uuid.UUID, gameID, currency string, betAmount float64) (*models.Spin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpinInCurrency", ctx, userID, gameID, currency, betAmount)
+	ret0, _ := ret[0].(*models.Spin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpinInCurrency indicates an expected call of SpinInCurrency.
+func (mr *MockISlotServiceMockRecorder) SpinInCurrency(ctx, userID, gameID, currency, betAmount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpinInCurrency", reflect.TypeOf((*MockISlotService)(nil).SpinInCurrency), ctx, userID, gameID, currency, betAmount)
+}
+
+// StartDemo mocks base method.
+func (m *MockISlotService) StartDemo(ctx context.Context, userID *uuid.UUID) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartDemo", ctx, userID)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartDemo indicates an expected call of StartDemo.
+func (mr *MockISlotServiceMockRecorder) StartDemo(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartDemo", reflect.TypeOf((*MockISlotService)(nil).StartDemo), ctx, userID)
+}
+
+// Stats mocks base method.
+func (m *MockISlotService) Stats(ctx context.Context, userID *uuid.UUID) (*models.SpinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, userID)
+	ret0, _ := ret[0].(*models.SpinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockISlotServiceMockRecorder) Stats(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockISlotService)(nil).Stats), ctx, userID)
+}
+
+// VerifySpinChain mocks base method.
+func (m *MockISlotService) VerifySpinChain(ctx context.Context, userID *uuid.UUID) (*models.SpinChainVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySpinChain", ctx, userID)
+	ret0, _ := ret[0].(*models.SpinChainVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySpinChain indicates an expected call of VerifySpinChain.
+func (mr *MockISlotServiceMockRecorder) VerifySpinChain(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySpinChain", reflect.TypeOf((*MockISlotService)(nil).VerifySpinChain), ctx, userID)
+}
+
+// VoidSpin mocks base method.
+func (m *MockISlotService) VoidSpin(ctx context.Context, spinID uint, reason string) (*models.Spin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidSpin", ctx, spinID, reason)
+	ret0, _ := ret[0].(*models.Spin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidSpin indicates an expected call of VoidSpin.
+func (mr *MockISlotServiceMockRecorder) VoidSpin(ctx, spinID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidSpin", reflect.TypeOf((*MockISlotService)(nil).VoidSpin), ctx, spinID, reason)
+}
+
+// MockIWalletService is a mock of IWalletService interface.
+type MockIWalletService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWalletServiceMockRecorder
+}
+
+// MockIWalletServiceMockRecorder is the mock recorder for MockIWalletService.
+type MockIWalletServiceMockRecorder struct {
+	mock *MockIWalletService
+}
+
+// NewMockIWalletService creates a new mock instance.
+func NewMockIWalletService(ctrl *gomock.Controller) *MockIWalletService {
+	mock := &MockIWalletService{ctrl: ctrl}
+	mock.recorder = &MockIWalletServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWalletService) EXPECT() *MockIWalletServiceMockRecorder {
+	return m.recorder
+}
+
+// GetBalance mocks base method.
+func (m *MockIWalletService) GetBalance(ctx context.Context, userID *uuid.UUID, currency string) (*models.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, userID, currency)
+	ret0, _ := ret[0].(*models.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockIWalletServiceMockRecorder) GetBalance(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockIWalletService)(nil).GetBalance), ctx, userID, currency)
+}
+
+// GetBalances mocks base method.
+func (m *MockIWalletService) GetBalances(ctx context.Context, userID *uuid.UUID) ([]*models.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", ctx, userID)
+	ret0, _ := ret[0].([]*models.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockIWalletServiceMockRecorder) GetBalances(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockIWalletService)(nil).GetBalances), ctx, userID)
+}
+
+// MockIExchangeRateProvider is a mock of IExchangeRateProvider interface.
+type MockIExchangeRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExchangeRateProviderMockRecorder
+}
+
+// MockIExchangeRateProviderMockRecorder is the mock recorder for MockIExchangeRateProvider.
+type MockIExchangeRateProviderMockRecorder struct {
+	mock *MockIExchangeRateProvider
+}
+
+// NewMockIExchangeRateProvider creates a new mock instance.
+func NewMockIExchangeRateProvider(ctrl *gomock.Controller) *MockIExchangeRateProvider {
+	mock := &MockIExchangeRateProvider{ctrl: ctrl}
+	mock.recorder = &MockIExchangeRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExchangeRateProvider) EXPECT() *MockIExchangeRateProviderMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockIExchangeRateProvider) Rate(ctx context.Context, currency string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, currency)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockIExchangeRateProviderMockRecorder) Rate(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockIExchangeRateProvider)(nil).Rate), ctx, currency)
+}
+
+// MockIDemoMigration is a mock of IDemoMigration interface.
+type MockIDemoMigration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDemoMigrationMockRecorder
+}
+
+// MockIDemoMigrationMockRecorder is the mock recorder for MockIDemoMigration.
+type MockIDemoMigrationMockRecorder struct {
+	mock *MockIDemoMigration
+}
+
+// NewMockIDemoMigration creates a new mock instance.
+func NewMockIDemoMigration(ctrl *gomock.Controller) *MockIDemoMigration {
+	mock := &MockIDemoMigration{ctrl: ctrl}
+	mock.recorder = &MockIDemoMigrationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDemoMigration) EXPECT() *MockIDemoMigrationMockRecorder {
+	return m.recorder
+}
+
+// Migrate mocks base method.
+func (m *MockIDemoMigration) Migrate(ctx context.Context, userID *uuid.UUID, balance float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", ctx, userID, balance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockIDemoMigrationMockRecorder) Migrate(ctx, userID, balance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockIDemoMigration)(nil).Migrate), ctx, userID, balance)
+}
+
+// MockIRegistrationGuard is a mock of IRegistrationGuard interface.
+type MockIRegistrationGuard struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRegistrationGuardMockRecorder
+}
+
+// MockIRegistrationGuardMockRecorder is the mock recorder for MockIRegistrationGuard.
+type MockIRegistrationGuardMockRecorder struct {
+	mock *MockIRegistrationGuard
+}
+
+// NewMockIRegistrationGuard creates a new mock instance.
+func NewMockIRegistrationGuard(ctrl *gomock.Controller) *MockIRegistrationGuard {
+	mock := &MockIRegistrationGuard{ctrl: ctrl}
+	mock.recorder = &MockIRegistrationGuardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRegistrationGuard) EXPECT() *MockIRegistrationGuardMockRecorder {
+	return m.recorder
+}
+
+// Register mocks base method.
+func (m *MockIRegistrationGuard) Register(ctx context.Context, key, login, password string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, key, login, password)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockIRegistrationGuardMockRecorder) Register(ctx, key, login, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIRegistrationGuard)(nil).Register), ctx, key, login, password)
+}
+
+// MockILoginGuard is a mock of ILoginGuard interface.
+type MockILoginGuard struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginGuardMockRecorder
+}
+
+// MockILoginGuardMockRecorder is the mock recorder for MockILoginGuard.
+type MockILoginGuardMockRecorder struct {
+	mock *MockILoginGuard
+}
+
+// NewMockILoginGuard creates a new mock instance.
+func NewMockILoginGuard(ctrl *gomock.Controller) *MockILoginGuard {
+	mock := &MockILoginGuard{ctrl: ctrl}
+	mock.recorder = &MockILoginGuardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginGuard) EXPECT() *MockILoginGuardMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockILoginGuard) Check(ctx context.Context, login, nonce string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, login, nonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockILoginGuardMockRecorder) Check(ctx, login, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockILoginGuard)(nil).Check), ctx, login, nonce)
+}
+
+// RecordFailure mocks base method.
+func (m *MockILoginGuard) RecordFailure(ctx context.Context, login string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockILoginGuardMockRecorder) RecordFailure(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockILoginGuard)(nil).RecordFailure), ctx, login)
+}
+
+// RecordSuccess mocks base method.
+func (m *MockILoginGuard) RecordSuccess(ctx context.Context, login string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordSuccess", ctx, login)
+}
+
+// RecordSuccess indicates an expected call of RecordSuccess.
+func (mr *MockILoginGuardMockRecorder) RecordSuccess(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSuccess", reflect.TypeOf((*MockILoginGuard)(nil).RecordSuccess), ctx, login)
+}
+
+// MockIAuthAuditService is a mock of IAuthAuditService interface.
+type MockIAuthAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthAuditServiceMockRecorder
+}
+
+// MockIAuthAuditServiceMockRecorder is the mock recorder for MockIAuthAuditService.
+type MockIAuthAuditServiceMockRecorder struct {
+	mock *MockIAuthAuditService
+}
+
+// NewMockIAuthAuditService creates a new mock instance.
+func NewMockIAuthAuditService(ctrl *gomock.Controller) *MockIAuthAuditService {
+	mock := &MockIAuthAuditService{ctrl: ctrl}
+	mock.recorder = &MockIAuthAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthAuditService) EXPECT() *MockIAuthAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// Events mocks base method.
+func (m *MockIAuthAuditService) Events(ctx context.Context, userID *uuid.UUID, limit, offset int) ([]*models.AuthEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Events", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*models.AuthEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Events indicates an expected call of Events.
+func (mr *MockIAuthAuditServiceMockRecorder) Events(ctx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Events", reflect.TypeOf((*MockIAuthAuditService)(nil).Events), ctx, userID, limit, offset)
+}
+
+// Record mocks base method.
+func (m *MockIAuthAuditService) Record(ctx context.Context, event *models.AuthEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, event)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockIAuthAuditServiceMockRecorder) Record(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockIAuthAuditService)(nil).Record), ctx, event)
+}
+
+// MockIWithdrawalService is a mock of IWithdrawalService interface.
+type MockIWithdrawalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWithdrawalServiceMockRecorder
+}
+
+// MockIWithdrawalServiceMockRecorder is the mock recorder for MockIWithdrawalService.
+type MockIWithdrawalServiceMockRecorder struct {
+	mock *MockIWithdrawalService
+}
+
+// NewMockIWithdrawalService creates a new mock instance.
+func NewMockIWithdrawalService(ctrl *gomock.Controller) *MockIWithdrawalService {
+	mock := &MockIWithdrawalService{ctrl: ctrl}
+	mock.recorder = &MockIWithdrawalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWithdrawalService) EXPECT() *MockIWithdrawalServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockIWithdrawalService) Approve(ctx context.Context, withdrawalID uint) (*models.PendingWithdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, withdrawalID)
+	ret0, _ := ret[0].(*models.PendingWithdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockIWithdrawalServiceMockRecorder) Approve(ctx, withdrawalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockIWithdrawalService)(nil).Approve), ctx, withdrawalID)
+}
+
+// Reject mocks base method.
+func (m *MockIWithdrawalService) Reject(ctx context.Context, withdrawalID uint, reason string) (*models.PendingWithdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, withdrawalID, reason)
+	ret0, _ := ret[0].(*models.PendingWithdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockIWithdrawalServiceMockRecorder) Reject(ctx, withdrawalID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockIWithdrawalService)(nil).Reject), ctx, withdrawalID, reason)
+}
+
+// Request mocks base method.
+func (m *MockIWithdrawalService) Request(ctx context.Context, userID *uuid.UUID, amount float64) (*models.PendingWithdrawal, *float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", ctx, userID, amount)
+	ret0, _ := ret[0].(*models.PendingWithdrawal)
+	ret1, _ := ret[1].(*float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockIWithdrawalServiceMockRecorder) Request(ctx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockIWithdrawalService)(nil).Request), ctx, userID, amount)
+}
+
+// MockISessionService is a mock of ISessionService interface.
+type MockISessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionServiceMockRecorder
+}
+
+// MockISessionServiceMockRecorder is the mock recorder for MockISessionService.
+type MockISessionServiceMockRecorder struct {
+	mock *MockISessionService
+}
+
+// NewMockISessionService creates a new mock instance.
+func NewMockISessionService(ctrl *gomock.Controller) *MockISessionService {
+	mock := &MockISessionService{ctrl: ctrl}
+	mock.recorder = &MockISessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionService) EXPECT() *MockISessionServiceMockRecorder {
+	return m.recorder
+}
+
+// AcknowledgeRealityCheck mocks base method.
+func (m *MockISessionService) AcknowledgeRealityCheck(ctx context.Context, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeRealityCheck", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcknowledgeRealityCheck indicates an expected call of AcknowledgeRealityCheck.
+func (mr *MockISessionServiceMockRecorder) AcknowledgeRealityCheck(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeRealityCheck", reflect.TypeOf((*MockISessionService)(nil).AcknowledgeRealityCheck), ctx, userID)
+}
+
+// Current mocks base method.
+func (m *MockISessionService) Current(ctx context.Context, userID *uuid.UUID) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Current", ctx, userID)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Current indicates an expected call of Current.
+func (mr *MockISessionServiceMockRecorder) Current(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Current", reflect.TypeOf((*MockISessionService)(nil).Current), ctx, userID)
+}
+
+// End mocks base method.
+func (m *MockISessionService) End(ctx context.Context, userID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "End", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// End indicates an expected call of End.
+func (mr *MockISessionServiceMockRecorder) End(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "End", reflect.TypeOf((*MockISessionService)(nil).End), ctx, userID)
+}
+
+// RealityCheck mocks base method.
+func (m *MockISessionService) RealityCheck(ctx context.Context, sessionID uint, interval time.Duration) (*models.RealityCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RealityCheck", ctx, sessionID, interval)
+	ret0, _ := ret[0].(*models.RealityCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RealityCheck indicates an expected call of RealityCheck.
+func (mr *MockISessionServiceMockRecorder) RealityCheck(ctx, sessionID, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RealityCheck", reflect.TypeOf((*MockISessionService)(nil).RealityCheck), ctx, sessionID, interval)
+}
+
+// Start mocks base method.
+func (m *MockISessionService) Start(ctx context.Context, userID *uuid.UUID) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userID)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockISessionServiceMockRecorder) Start(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockISessionService)(nil).Start), ctx, userID)
+}
+
+// Summaries mocks base method.
+func (m *MockISessionService) Summaries(ctx context.Context, userID *uuid.UUID, limit, offset int) ([]*models.SessionSummary, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summaries", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*models.SessionSummary)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Summaries indicates an expected call of Summaries.
+func (mr *MockISessionServiceMockRecorder) Summaries(ctx, userID, limit, offset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrySpin", reflect.TypeOf((*MockISlotService)(nil).RetrySpin), ctx, userId, betAmount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summaries", reflect.TypeOf((*MockISessionService)(nil).Summaries), ctx, userID, limit, offset)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/vadymlab/slot-game/internal/models"
+	"time"
 )
 
 // IUserRepository defines methods for user data operations in the repository layer.
@@ -29,6 +30,7 @@ type IUserRepository interface {
 	//
 	// Returns:
 	//   - A pointer to the created User model.
+	//   - ErrUserExists if the login violates the unique constraint.
 	//   - An error if any issues occur during creation.
 	Create(ctx context.Context, user *models.User) (*models.User, error)
 
@@ -54,6 +56,80 @@ type IUserRepository interface {
 	//   - An error if any issues occur during retrieval.
 	GetByID(ctx context.Context, id uint) (*models.User, error)
 
+	// GetUsers retrieves a page of the users matching the filter, in the order it asks for.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - filter: The search and order of the users.
+	//   - limit: The maximum number of users to return.
+	//   - offset: The number of users to skip.
+	//
+	// Returns:
+	//   - A slice of pointers to User models representing the requested page.
+	//   - The total number of users matching the filter.
+	//   - An error if any issues occur during retrieval.
+	GetUsers(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int64, error)
+
+	// UpdateLogin changes the login of a specified user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//   - login: The new login name.
+	//
+	// Returns:
+	//   - ErrUserExists if the login violates the unique constraint.
+	//   - An error if any issues occur during the update.
+	UpdateLogin(ctx context.Context, userID uint, login string) error
+
+	// UpdateAutoStopWin changes the auto-stop threshold of a specified user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//   - autoStopWin: The new threshold; nil falls back to the configured default.
+	//
+	// Returns:
+	//   - An error if any issues occur during the update.
+	UpdateAutoStopWin(ctx context.Context, userID uint, autoStopWin *float64) error
+
+	// ExtendExclusion sets the end of a specified user's self-exclusion, keeping an exclusion
+	// that already ends later.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//   - until: The requested end of the self-exclusion.
+	//
+	// Returns:
+	//   - An error if any issues occur during the update.
+	ExtendExclusion(ctx context.Context, userID uint, until time.Time) error
+
+	// UpdateFrozen freezes or unfreezes the account of a specified user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//   - frozen: Whether the account is frozen.
+	//
+	// Returns:
+	//   - An error if any issues occur during the update.
+	UpdateFrozen(ctx context.Context, userID uint, frozen bool) error
+
+	// UpdateDepositLimits replaces the deposit limits of a specified user, along with the raised
+	// or removed limits waiting out their cooldown.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//   - limits: The deposit limits applying right away.
+	//   - pending: The deposit limits applying from pendingAt.
+	//   - pendingAt: The time the pending limits apply from; nil if none are pending.
+	//
+	// Returns:
+	//   - An error if any issues occur during the update.
+	UpdateDepositLimits(ctx context.Context, userID uint, limits, pending models.DepositLimits, pendingAt *time.Time) error
+
 	// Deposit increases the balance of a specified user by the given amount.
 	//
 	// Parameters:
@@ -77,11 +153,73 @@ type IUserRepository interface {
 	//   - A pointer to the updated balance as a float64.
 	//   - An error if any issues occur during the withdrawal.
 	Withdraw(ctx context.Context, userID uint, amount float64) (*float64, error)
+
+	// Debit decreases the balance of a specified user, provided the balance covers the amount.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//   - amount: The amount to be deducted from the user's balance.
+	//
+	// Returns:
+	//   - A pointer to the updated balance as a float64.
+	//   - ErrInsufficientFunds if the balance does not cover the amount or the user does not exist.
+	//   - An error if the update fails.
+	Debit(ctx context.Context, userID uint, amount float64) (*float64, error)
+
+	// LockUsers locks the rows of the specified users, in ID order, until the surrounding
+	// transaction ends.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userIDs: The unique numeric IDs of the users.
+	//
+	// Returns:
+	//   - An error if any issues occur while locking.
+	LockUsers(ctx context.Context, userIDs ...uint) error
+
+	// ApplySpinResult settles a spin in a single update: the balance of the user is changed by the
+	// win minus the bet, provided that it covers the bet.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user who played the spin.
+	//   - bet: The amount bet on the spin.
+	//   - win: The amount won by the spin.
+	//
+	// Returns:
+	//   - A pointer to the updated balance as a float64.
+	//   - ErrInsufficientFunds if the balance does not cover the bet or the user does not exist,
+	//     or another error if the update fails.
+	ApplySpinResult(ctx context.Context, userID uint, bet, win float64) (*float64, error)
+
+	// AddFreeSpins adds free spins to the free spins of a user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//   - count: The number of free spins to add.
+	//
+	// Returns:
+	//   - The free spins of the user after the update.
+	//   - ErrUserNotFound if the user does not exist, or another error if the update fails.
+	AddFreeSpins(ctx context.Context, userID uint, count int) (int, error)
+
+	// UseFreeSpin takes one free spin from the free spins of a user, if the user has any left.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//
+	// Returns:
+	//   - The free spins of the user left after the update.
+	//   - ErrNoFreeSpins if the user has no free spins left or does not exist, or another error if the update fails.
+	UseFreeSpin(ctx context.Context, userID uint) (int, error)
 }
 
 // IWalletRepository defines methods for wallet-related data operations in the repository layer.
 type IWalletRepository interface {
-	// GetBalance retrieves the balance of a specified user.
+	// GetBalance retrieves the base currency balance of a specified user.
 	//
 	// Parameters:
 	//   - ctx: Context for managing request-scoped values and cancellation signals.
@@ -91,6 +229,41 @@ type IWalletRepository interface {
 	//   - The user's balance as a float64.
 	//   - An error if any issues occur during retrieval.
 	GetBalance(ctx context.Context, userID uint) (float64, error)
+
+	// GetWallets retrieves the wallets a user holds in currencies other than the base currency.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: The unique numeric ID of the user.
+	//
+	// Returns:
+	//   - A slice of pointers to the user's Wallet models, ordered by currency.
+	//   - An error if any issues occur during retrieval.
+	GetWallets(ctx context.Context, userID uint) ([]*models.Wallet, error)
+
+	// GetBalanceByExternalID retrieves the base currency balance of a user identified by their UUID.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - The user's balance as a float64.
+	//   - ErrUserNotFound if the user does not exist, or an error if any issues occur during retrieval.
+	GetBalanceByExternalID(ctx context.Context, userID *uuid.UUID) (float64, error)
+
+	// GetWalletBalanceByExternalID retrieves the balance of the wallet a user identified by their UUID
+	// holds in a currency other than the base currency.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - currency: The ISO 4217 code of the wallet's currency.
+	//
+	// Returns:
+	//   - The wallet's balance as a float64, or 0 if the user holds no wallet in the currency.
+	//   - ErrUserNotFound if the user does not exist, or an error if any issues occur during retrieval.
+	GetWalletBalanceByExternalID(ctx context.Context, userID *uuid.UUID, currency string) (float64, error)
 }
 
 // ISlotRepository defines methods for slot game data operations in the repository layer.
@@ -105,14 +278,358 @@ type ISlotRepository interface {
 	//   - An error if any issues occur during recording of the spin.
 	AddSpin(ctx context.Context, spin *models.Spin) error
 
-	// GetSpins retrieves a user's spin history from the repository.
+	// AddSpins records a batch of spins in a single transaction.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - spins: Pointers to the Spin models to be recorded.
+	//
+	// Returns:
+	//   - An error if any issues occur during recording; no spin of the batch is recorded then.
+	AddSpins(ctx context.Context, spins []*models.Spin) error
+
+	// GetSpins retrieves a page of a user's spin history from the repository, newest first.
 	//
 	// Parameters:
 	//   - ctx: Context for managing request-scoped values and cancellation signals.
 	//   - userId: The unique numeric ID of the user whose spin history is being retrieved.
+	//   - from: Optional inclusive lower bound for the spin creation time.
+	//   - to: Optional exclusive upper bound for the spin creation time.
+	//   - limit: The maximum number of spins to return.
+	//   - offset: The number of spins to skip.
 	//
 	// Returns:
-	//   - A slice of pointers to Spin models representing the user's spin history.
+	//   - A slice of pointers to Spin models representing the requested page.
+	//   - The total number of spins of the user within the date range.
 	//   - An error if any issues occur during retrieval.
-	GetSpins(ctx context.Context, userID uint) ([]*models.Spin, error)
+	GetSpins(ctx context.Context, userID uint, from, to *time.Time, limit, offset int) ([]*models.Spin, int64, error)
+
+	// EachSpin walks a user's whole spin history within the date range, newest first, one spin at a time.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: The unique numeric ID of the user whose spin history is being read.
+	//   - from: Optional inclusive lower bound for the spin creation time.
+	//   - to: Optional exclusive upper bound for the spin creation time.
+	//   - fn: Function called with each spin; an error stops the walk.
+	//
+	// Returns:
+	//   - An error if any issues occur during retrieval, or the error returned by fn.
+	EachSpin(ctx context.Context, userID uint, from, to *time.Time, fn func(*models.Spin) error) error
+
+	// GetLeaderboard aggregates total winnings per user and returns the top entries.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - since: Optional lower bound for the spin creation time; nil aggregates all spins.
+	//   - limit: The maximum number of entries to return.
+	//
+	// Returns:
+	//   - A slice of pointers to LeaderboardEntry models ordered by total winnings.
+	//   - An error if any issues occur during aggregation.
+	GetLeaderboard(ctx context.Context, since *time.Time, limit int) ([]*models.LeaderboardEntry, error)
+
+	// GetSpinStats aggregates the spins of a user into their play statistics.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: The unique numeric ID of the user.
+	//
+	// Returns:
+	//   - A pointer to the SpinStats of the user; all zeros if the user has no spins.
+	//   - An error if any issues occur during aggregation.
+	GetSpinStats(ctx context.Context, userID uint) (*models.SpinStats, error)
+
+	// GetSpinStatsBetween aggregates the spins of all users created within a window.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - from: Inclusive lower bound for the spin creation time.
+	//   - to: Exclusive upper bound for the spin creation time.
+	//
+	// Returns:
+	//   - A pointer to the SpinStats of the window; all zeros if no spin was created within it.
+	//   - An error if any issues occur during aggregation.
+	GetSpinStatsBetween(ctx context.Context, from, to time.Time) (*models.SpinStats, error)
+
+	// AddRTPReport records a report of the realized RTP of a window.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - report: A pointer to the RTPReport to be recorded.
+	//
+	// Returns:
+	//   - An error if any issues occur during recording of the report.
+	AddRTPReport(ctx context.Context, report *models.RTPReport) error
+
+	// GetSpinForUpdate retrieves a spin by its numeric ID and locks it until the surrounding
+	// transaction ends.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - spinID: The unique numeric ID of the spin.
+	//
+	// Returns:
+	//   - A pointer to a Spin model if found, or nil if not found.
+	//   - An error if any issues occur during retrieval.
+	GetSpinForUpdate(ctx context.Context, spinID uint) (*models.Spin, error)
+
+	// VoidSpin marks a spin as voided with the given reason.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - spinID: The unique numeric ID of the spin.
+	//   - reason: The reason for voiding the spin.
+	//   - voidedAt: The time the spin is voided at.
+	//
+	// Returns:
+	//   - ErrSpinAlreadyVoided if the spin has already been voided.
+	//   - An error if any issues occur during the update.
+	VoidSpin(ctx context.Context, spinID uint, reason string, voidedAt time.Time) error
+
+	// LastSpinHash returns the hash of the user's latest chained spin.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: The unique numeric ID of the user.
+	//
+	// Returns:
+	//   - The hash of the latest chained spin, or an empty string if the user has none.
+	//   - An error if any issues occur during retrieval.
+	LastSpinHash(ctx context.Context, userID uint) (string, error)
+
+	// EachChainedSpin walks the user's chained spins in the order they were written, one spin at a time.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: The unique numeric ID of the user.
+	//   - fn: Function called with each spin; an error stops the walk.
+	//
+	// Returns:
+	//   - An error if any issues occur during retrieval, or the error returned by fn.
+	EachChainedSpin(ctx context.Context, userID uint, fn func(*models.Spin) error) error
+
+	// DeleteSpinsBefore permanently deletes up to limit spins created before the given time.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - before: Spins created before this time are deleted.
+	//   - limit: The maximum number of spins to delete.
+	//
+	// Returns:
+	//   - The number of deleted spins.
+	//   - An error if any issues occur during the deletion.
+	DeleteSpinsBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// ISpinWriter defines how settled spins are handed over for persistence in the background.
+type ISpinWriter interface {
+	// Write hands over a spin whose balance change has already been committed. Failures to
+	// persist the spin are logged, as the spin itself cannot be undone anymore.
+	//
+	// Parameters:
+	//   - ctx: Context of the request that settled the spin.
+	//   - spin: A pointer to the Spin model to be recorded.
+	Write(ctx context.Context, spin *models.Spin)
+}
+
+// ILedgerRepository defines methods for recording balance changes in the ledger.
+type ILedgerRepository interface {
+	// AddEntry records a new ledger entry.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - entry: A pointer to the LedgerEntry model to be recorded.
+	//
+	// Returns:
+	//   - An error if any issues occur while recording the entry.
+	AddEntry(ctx context.Context, entry *models.LedgerEntry) error
+
+	// SumEntries sums the amounts of a user's ledger entries of the given type recorded since a
+	// given time.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: The unique numeric ID of the user.
+	//   - entryType: The type of the summed entries, one of the LedgerType constants.
+	//   - since: The time from which entries are summed.
+	//
+	// Returns:
+	//   - The sum of the amounts, 0 if there are no such entries.
+	//   - An error if any issues occur during the query.
+	SumEntries(ctx context.Context, userID uint, entryType string, since time.Time) (float64, error)
+}
+
+// IWithdrawalRepository defines methods for storing withdrawals awaiting admin approval.
+type IWithdrawalRepository interface {
+	// AddWithdrawal records a new pending withdrawal.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - withdrawal: A pointer to the PendingWithdrawal model to be recorded.
+	//
+	// Returns:
+	//   - An error if any issues occur while recording the withdrawal.
+	AddWithdrawal(ctx context.Context, withdrawal *models.PendingWithdrawal) error
+
+	// GetWithdrawalForUpdate retrieves a withdrawal by its numeric ID and locks it for
+	// the rest of the transaction.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - withdrawalID: The unique numeric ID of the withdrawal.
+	//
+	// Returns:
+	//   - A pointer to a PendingWithdrawal model if found, or nil if not found.
+	//   - An error if any issues occur during retrieval.
+	GetWithdrawalForUpdate(ctx context.Context, withdrawalID uint) (*models.PendingWithdrawal, error)
+
+	// DecideWithdrawal moves a pending withdrawal to its final status.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - withdrawalID: The unique numeric ID of the withdrawal.
+	//   - status: The final status, approved or rejected.
+	//   - reason: The reason for the decision, if any.
+	//   - decidedAt: The time of the decision.
+	//
+	// Returns:
+	//   - ErrWithdrawalNotPending if the withdrawal has already been decided on.
+	//   - An error if any issues occur during the update.
+	DecideWithdrawal(ctx context.Context, withdrawalID uint, status, reason string, decidedAt time.Time) error
+}
+
+// IAuthEventRepository defines methods for recording and reading the authentication audit log.
+type IAuthEventRepository interface {
+	// AddEvent records a new authentication event.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - event: A pointer to the AuthEvent model to be recorded.
+	//
+	// Returns:
+	//   - An error if any issues occur while recording the event.
+	AddEvent(ctx context.Context, event *models.AuthEvent) error
+
+	// GetEvents retrieves a page of the authentication events of a user, newest first.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: The unique numeric ID of the user.
+	//   - limit: The maximum number of events to return.
+	//   - offset: The number of events to skip.
+	//
+	// Returns:
+	//   - A slice of pointers to AuthEvent models representing the requested page.
+	//   - The total number of events of the user.
+	//   - An error if any issues occur during retrieval.
+	GetEvents(ctx context.Context, userID uint, limit, offset int) ([]*models.AuthEvent, int64, error)
+}
+
+// IPromoRepository defines methods for accessing promo codes and their redemptions.
+type IPromoRepository interface {
+	// GetByCode retrieves a promo code by its code.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - code: The promo code entered by the user.
+	//
+	// Returns:
+	//   - A pointer to a PromoCode model if found, or nil if not found.
+	//   - An error if any issues occur during retrieval.
+	GetByCode(ctx context.Context, code string) (*models.PromoCode, error)
+
+	// CountRedemptions returns how many times a user has redeemed a promo code.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - promoCodeID: The numeric ID of the promo code.
+	//   - userID: The numeric ID of the user.
+	//
+	// Returns:
+	//   - The number of redemptions.
+	//   - An error if any issues occur during the count.
+	CountRedemptions(ctx context.Context, promoCodeID, userID uint) (int, error)
+
+	// AddRedemption records a promo code redemption.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - redemption: A pointer to the PromoRedemption model to be recorded.
+	//
+	// Returns:
+	//   - An error if any issues occur while recording the redemption.
+	AddRedemption(ctx context.Context, redemption *models.PromoRedemption) error
+}
+
+// ISessionRepository defines methods for storing game sessions and summarizing their spins.
+type ISessionRepository interface {
+	// AddSession records a new game session. Sessions of the user that have not ended are ended
+	// at their last spin, or at their start if no spin was played.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - session: A pointer to the Session model to be recorded.
+	//
+	// Returns:
+	//   - An error if any issues occur while recording the session.
+	AddSession(ctx context.Context, session *models.Session) error
+
+	// EndSession ends a game session that has not ended yet.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - sessionID: The unique numeric ID of the session.
+	//   - endedAt: The time the session ended.
+	//
+	// Returns:
+	//   - An error if any issues occur during the update.
+	EndSession(ctx context.Context, sessionID uint, endedAt time.Time) error
+
+	// GetSessionSummaries retrieves a page of the summaries of a user's game sessions, newest first.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: The unique numeric ID of the user.
+	//   - limit: The maximum number of sessions to return.
+	//   - offset: The number of sessions to skip.
+	//
+	// Returns:
+	//   - A slice of pointers to SessionSummary models representing the requested page.
+	//   - The total number of sessions of the user.
+	//   - An error if any issues occur during retrieval.
+	GetSessionSummaries(ctx context.Context, userID uint, limit, offset int) ([]*models.SessionSummary, int64, error)
+
+	// GetSession retrieves a game session by its ID.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - sessionID: The unique numeric ID of the session.
+	//
+	// Returns:
+	//   - A pointer to the Session model if found, or nil if not found.
+	//   - An error if any issues occur during retrieval.
+	GetSession(ctx context.Context, sessionID uint) (*models.Session, error)
+
+	// GetSessionSummary retrieves the summary of a single game session.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - sessionID: The unique numeric ID of the session.
+	//
+	// Returns:
+	//   - A pointer to the SessionSummary model if the session exists, or nil if not found.
+	//   - An error if any issues occur during aggregation.
+	GetSessionSummary(ctx context.Context, sessionID uint) (*models.SessionSummary, error)
+
+	// AcknowledgeRealityCheck records the time the user acknowledged a reality check of the session.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - sessionID: The unique numeric ID of the session.
+	//   - acknowledgedAt: The time the reality check was acknowledged.
+	//
+	// Returns:
+	//   - An error if any issues occur during the update.
+	AcknowledgeRealityCheck(ctx context.Context, sessionID uint, acknowledgedAt time.Time) error
 }
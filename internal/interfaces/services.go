@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/vadymlab/slot-game/internal/models"
+	"time"
 )
 
 // IUserService defines service-level methods for handling user-related actions,
@@ -55,6 +56,88 @@ type IUserService interface {
 	//   - An error if the user is not found or if any issues occur.
 	GetByID(ctx context.Context, id uint) (*models.User, error)
 
+	// ListUsers retrieves a page of the users matching the filter, for the admins to find users.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - filter: The search and order of the users.
+	//   - limit: The maximum number of users to return.
+	//   - offset: The number of users to skip.
+	//
+	// Returns:
+	//   - A slice of pointers to User models representing the requested page.
+	//   - The total number of users matching the filter.
+	//   - An error if retrieval fails.
+	ListUsers(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int64, error)
+
+	// UpdateLogin changes the login of a user after confirming their current password.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - login: The new login identifier.
+	//   - password: The user's current password.
+	//
+	// Returns:
+	//   - A pointer to the updated User model.
+	//   - ErrInvalidPass if the password is incorrect, ErrUserExists if the login is taken,
+	//     or another error if the update fails.
+	UpdateLogin(ctx context.Context, userID *uuid.UUID, login, password string) (*models.User, error)
+
+	// UpdateSettings changes the play settings of a user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - autoStopWin: The win above which the client is told to stop spinning; nil uses the configured default.
+	//
+	// Returns:
+	//   - A pointer to the updated User model.
+	//   - ErrInvalidAmount if the threshold is negative, ErrUserNotFound if the user does not exist,
+	//     or another error if the update fails.
+	UpdateSettings(ctx context.Context, userID *uuid.UUID, autoStopWin *float64) (*models.User, error)
+
+	// SelfExclude excludes a user from spinning and depositing for the given period. A running
+	// self-exclusion can be extended but not shortened.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - period: How long the user is excluded for, starting now.
+	//
+	// Returns:
+	//   - A pointer to the updated User model, carrying the end of the self-exclusion.
+	//   - ErrInvalidPeriod if the period is not positive, ErrUserNotFound if the user does not exist,
+	//     or another error if the update fails.
+	SelfExclude(ctx context.Context, userID *uuid.UUID, period time.Duration) (*models.User, error)
+
+	// SetFrozen freezes or unfreezes the account of a user. A frozen user can neither log in,
+	// spin, deposit, withdraw nor transfer funds.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - frozen: Whether the account is frozen.
+	//
+	// Returns:
+	//   - A pointer to the updated User model.
+	//   - ErrUserNotFound if the user does not exist, or another error if the update fails.
+	SetFrozen(ctx context.Context, userID *uuid.UUID, frozen bool) (*models.User, error)
+
+	// SetDepositLimits changes the deposit limits of a user. Lowered limits apply right away, while
+	// raised or removed limits only apply once the configured cooldown has passed.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - limits: The requested deposit limits; a nil limit removes it.
+	//
+	// Returns:
+	//   - A pointer to the updated User model, carrying the current and the pending deposit limits.
+	//   - ErrInvalidAmount if a limit is not positive, ErrUserNotFound if the user does not exist,
+	//     or another error if the update fails.
+	SetDepositLimits(ctx context.Context, userID *uuid.UUID, limits models.DepositLimits) (*models.User, error)
+
 	// Deposit adds a specified amount to the balance of a user identified by their UUID.
 	//
 	// Parameters:
@@ -67,6 +150,23 @@ type IUserService interface {
 	//   - An error if the deposit fails or any issues occur.
 	Deposit(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error)
 
+	// DepositWithPromo deposits funds into the user's wallet, recording the deposit in the ledger.
+	// When a promo code is given, it is validated and the bonus is credited as a separate ledger entry
+	// within the same transaction.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - amount: The amount to be deposited to the user's balance.
+	//   - promoCode: An optional promo code; empty means no bonus.
+	//
+	// Returns:
+	//   - A pointer to the updated balance as a float64.
+	//   - The credited bonus amount, or 0 if no promo code was applied.
+	//   - ErrDepositLimitExceeded if the deposit exceeds the user's deposit limits, or an error if the
+	//     deposit fails or the promo code is invalid.
+	DepositWithPromo(ctx context.Context, userID *uuid.UUID, amount float64, promoCode string) (*float64, float64, error)
+
 	// Withdraw deducts a specified amount from the balance of a user identified by their UUID.
 	//
 	// Parameters:
@@ -78,21 +178,494 @@ type IUserService interface {
 	//   - A pointer to the updated balance as a float64.
 	//   - An error if the withdrawal fails or any issues occur.
 	Withdraw(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error)
+
+	// Transfer moves funds from the balance of one user to the balance of another within a single
+	// transaction, recording the debit and the credit in the ledger.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - senderID: A UUID representing the external identifier of the user sending the funds.
+	//   - recipientID: A UUID representing the external identifier of the user receiving the funds.
+	//   - amount: The amount to be transferred.
+	//
+	// Returns:
+	//   - A pointer to the sender's updated balance as a float64.
+	//   - ErrInvalidAmount, ErrSelfTransfer, ErrRecipientNotFound or ErrInsufficientFunds, or
+	//     another error if the transfer fails.
+	Transfer(ctx context.Context, senderID, recipientID *uuid.UUID, amount float64) (*float64, error)
+
+	// ApplySpinResult settles a spin of a user identified by their UUID, withdrawing the bet and
+	// crediting the win in a single balance update.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: A UUID representing the user's external identifier.
+	//   - bet: The amount bet on the spin.
+	//   - win: The amount won by the spin.
+	//
+	// Returns:
+	//   - A pointer to the updated balance as a float64.
+	//   - ErrInsufficientFunds if the balance does not cover the bet, or another error if the update fails.
+	ApplySpinResult(ctx context.Context, userID *uuid.UUID, bet, win float64) (*float64, error)
+
+	// AwardFreeSpins adds free spins to the free spins of a user identified by their UUID.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: A UUID representing the user's external identifier.
+	//   - count: The number of free spins awarded.
+	//
+	// Returns:
+	//   - The free spins of the user after the award.
+	//   - ErrUserNotFound if the user does not exist, or another error if the update fails.
+	AwardFreeSpins(ctx context.Context, userID *uuid.UUID, count int) (int, error)
+
+	// UseFreeSpin takes one free spin from the free spins of a user identified by their UUID.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userId: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - The free spins of the user left after the free spin.
+	//   - ErrUserNotFound if the user does not exist, ErrNoFreeSpins if the user has no free spins left,
+	//     or another error if the update fails.
+	UseFreeSpin(ctx context.Context, userID *uuid.UUID) (int, error)
 }
 
 // ISlotService defines service-level methods for handling slot game actions,
 // including spinning and retrieving a user's spin history.
 type ISlotService interface {
-	RetrySpin(ctx context.Context, userID *uuid.UUID, betAmount float64) (*models.Spin, error)
+	RetrySpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64) (*models.Spin, error)
+
+	// BulkSpin plays up to count spins with the same bet in a row, each settled in its own transaction.
+	// The batch ends early, keeping the spins played before, when a spin cannot be played, such as
+	// when the balance no longer covers the bet.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - gameID: The ID of the game to play; empty plays the default game.
+	//   - betAmount: The amount of the bet placed for each spin.
+	//   - count: The number of spins to play.
+	//
+	// Returns:
+	//   - A pointer to the played batch, with the error that ended it early if any.
+	//   - ErrInvalidSpinCount if the count exceeds the maximum number of bulk spins, ErrGameNotFound if
+	//     the game does not exist, or the error of the first spin if none could be played.
+	BulkSpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64, count int) (*models.BulkSpin, error)
+
+	// FreeSpin plays one of the user's free spins, awarded by scatters. The spin uses up a free spin
+	// instead of debiting a bet and pays its win as a spin of the free spin bet would.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - gameID: The ID of the game to play; empty plays the default game.
+	//
+	// Returns:
+	//   - A pointer to a spin model representing the spin result, recorded without a bet.
+	//   - ErrGameNotFound if the game does not exist, ErrNoFreeSpins if the user has no free spins left,
+	//     or another error indicating the failure reason.
+	FreeSpin(ctx context.Context, userID *uuid.UUID, gameID string) (*models.Spin, error)
+
+	// SpinInCurrency performs a spin for a user with a bet placed in another currency than the base
+	// currency. The bet is converted into the base currency at the current exchange rate and the spin
+	// is settled against the base currency balance; the win is converted back at the same rate, and
+	// both the original and the converted amounts are recorded on the spin.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - gameID: The ID of the game to play; empty plays the default game.
+	//   - currency: The ISO 4217 code of the currency of the bet, in any case; empty or the base
+	//     currency plays an unconverted spin.
+	//   - betAmount: The amount of the bet in the currency.
+	//
+	// Returns:
+	//   - A pointer to a spin model representing the spin result.
+	//   - ErrUnsupportedCurrency if no exchange rate is known for the currency, or any error of RetrySpin.
+	SpinInCurrency(ctx context.Context, userID *uuid.UUID, gameID, currency string, betAmount float64) (*models.Spin, error)
+
+	// LastSpin returns the result of the user's last committed spin while it is kept for replay,
+	// so that a client that lost the response of a spin can fetch it.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the last spin, as returned by the spin.
+	//   - ErrSpinNotFound if the user has not spun recently or the replay is disabled, or an error
+	//     if the store cannot be reached.
+	LastSpin(ctx context.Context, userID *uuid.UUID) (*models.Spin, error)
+
+	// DemoSpin performs a play-money spin for a user. The bet and the payout only change the
+	// user's demo balance; neither the spin nor the balance change is persisted, so demo spins
+	// never appear in the history or the leaderboard. A demo session is started on the first demo spin.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - gameID: The ID of the game to play; empty plays the default game.
+	//   - betAmount: The play-money amount of the bet.
+	//
+	// Returns:
+	//   - A pointer to an unsaved spin model representing the spin result.
+	//   - ErrDemoDisabled if demo mode is disabled, ErrGameNotFound if the game does not exist,
+	//     ErrInsufficientFunds if the demo balance does not cover the bet, or another error if the spin fails.
+	DemoSpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64) (*models.Spin, error)
+
+	// StartDemo starts a new demo session for a user, resetting the demo balance to the configured amount.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the demo balance of the new session.
+	//   - ErrDemoDisabled if demo mode is disabled, or another error if the session cannot be started.
+	StartDemo(ctx context.Context, userID *uuid.UUID) (*float64, error)
+
+	// EndDemo ends the demo session of a user when switching back to real play, discarding the
+	// demo balance. Demo funds never reach the real wallet: the balance is only passed to the
+	// migration hook when the demo balance migration is enabled.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the balance the demo session ended with, or nil if the user had no demo session.
+	//   - Whether the balance was passed to the migration hook.
+	//   - An error if the session cannot be ended or the migration hook fails.
+	EndDemo(ctx context.Context, userID *uuid.UUID) (*float64, bool, error)
 
-	// History retrieves the spin history for a specified user.
+	// History retrieves a page of the spin history for a specified user, newest first.
 	//
 	// Parameters:
 	//   - ctx: Context for managing request-scoped values and cancellation signals.
 	//   - userID: A UUID representing the user's external identifier.
+	//   - from: Optional inclusive lower bound for the spin creation time.
+	//   - to: Optional exclusive upper bound for the spin creation time.
+	//   - limit: The maximum number of spins to return.
+	//   - offset: The number of spins to skip.
 	//
 	// Returns:
-	//   - A slice of pointers to spin models representing the user's spin history.
+	//   - A slice of pointers to spin models representing the requested page.
+	//   - The total number of spins of the user within the date range.
 	//   - An error if retrieval fails or any issues occur.
-	History(ctx context.Context, userID *uuid.UUID) ([]*models.Spin, error)
+	History(ctx context.Context, userID *uuid.UUID, from, to *time.Time, limit, offset int) ([]*models.Spin, int64, error)
+
+	// ExportHistory walks the whole spin history of a user within the date range, newest first,
+	// without loading it into memory.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - from: Optional inclusive lower bound for the spin creation time.
+	//   - to: Optional exclusive upper bound for the spin creation time.
+	//   - fn: Function called with each spin; an error stops the export.
+	//
+	// Returns:
+	//   - An error if the user cannot be resolved or retrieval fails, or the error returned by fn.
+	ExportHistory(ctx context.Context, userID *uuid.UUID, from, to *time.Time, fn func(*models.Spin) error) error
+
+	// Leaderboard retrieves the top users by total winnings for the given period.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - period: The aggregation window, one of "day", "week" or "all".
+	//
+	// Returns:
+	//   - A slice of pointers to LeaderboardEntry models ordered by total winnings.
+	//   - An error if the period is invalid or retrieval fails.
+	Leaderboard(ctx context.Context, period string) ([]*models.LeaderboardEntry, error)
+
+	// Stats retrieves the play statistics of a specified user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the SpinStats of the user; all zeros if the user has no spins.
+	//   - An error if retrieval fails or any issues occur.
+	Stats(ctx context.Context, userID *uuid.UUID) (*models.SpinStats, error)
+
+	// VerifySpinChain walks the hash chain of a user's spins and reports the first broken spin.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the SpinChainVerification of the user's spins.
+	//   - ErrUserNotFound if the user does not exist, or an error if retrieval fails.
+	VerifySpinChain(ctx context.Context, userID *uuid.UUID) (*models.SpinChainVerification, error)
+
+	// VoidSpin reverses a disputed spin: the bet is refunded, the win is clawed back and the spin
+	// is marked voided, with compensating ledger entries written in the same transaction.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - spinID: The unique numeric ID of the spin.
+	//   - reason: The reason for voiding the spin.
+	//
+	// Returns:
+	//   - A pointer to the voided spin.
+	//   - ErrSpinNotFound if the spin does not exist, ErrSpinAlreadyVoided if it has already been voided,
+	//     ErrInsufficientFunds if the user's balance does not cover the clawback, or another error if the void fails.
+	VoidSpin(ctx context.Context, spinID uint, reason string) (*models.Spin, error)
+}
+
+// IWalletService defines service-level methods for reading the balances of a user's wallets.
+type IWalletService interface {
+	// GetBalances retrieves all balances of a user, the base currency balance first.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A slice of pointers to the user's Balance models.
+	//   - ErrUserNotFound if the user does not exist, or an error if retrieval fails.
+	GetBalances(ctx context.Context, userID *uuid.UUID) ([]*models.Balance, error)
+
+	// GetBalance retrieves the balance of a user in a single currency without loading the user.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - currency: The ISO 4217 code of the currency; empty selects the base currency.
+	//
+	// Returns:
+	//   - A pointer to the user's Balance model in the currency.
+	//   - ErrUserNotFound if the user does not exist, or an error if retrieval fails.
+	GetBalance(ctx context.Context, userID *uuid.UUID, currency string) (*models.Balance, error)
+}
+
+// IExchangeRateProvider defines a source of the exchange rates converting bets placed in other
+// currencies into the base currency.
+type IExchangeRateProvider interface {
+	// Rate returns the current exchange rate of a currency.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - currency: The upper-case ISO 4217 code of the currency.
+	//
+	// Returns:
+	//   - The number of base currency units one unit of the currency is worth.
+	//   - ErrUnsupportedCurrency if no rate is known for the currency.
+	Rate(ctx context.Context, currency string) (float64, error)
+}
+
+// IDemoMigration defines the hook receiving the play-money balance of an ended demo session. It is
+// only called when the demo balance migration is enabled in the configuration.
+type IDemoMigration interface {
+	// Migrate handles the final balance of a user's ended demo session.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - balance: The play-money balance the demo session ended with.
+	//
+	// Returns:
+	//   - An error if the balance cannot be handled.
+	Migrate(ctx context.Context, userID *uuid.UUID, balance float64) error
+}
+
+// IRegistrationGuard defines service-level methods making registration retries idempotent.
+type IRegistrationGuard interface {
+	// Register registers a user. A registration retried with the same idempotency key and the
+	// same credentials returns the originally registered user instead of failing as a duplicate.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - key: An optional idempotency key; empty registers without replay protection.
+	//   - login: The login identifier for the new user.
+	//   - password: The user's password.
+	//
+	// Returns:
+	//   - A pointer to the registered or originally registered User model.
+	//   - ErrIdempotencyKeyReused if the key was used for different credentials,
+	//     ErrUserExists if the login is taken, or another error if the registration fails.
+	Register(ctx context.Context, key, login, password string) (*models.User, error)
+}
+
+// ILoginGuard defines service-level methods protecting the login endpoint against
+// replayed requests and credential stuffing.
+type ILoginGuard interface {
+	// Check verifies that the login is not locked and that the optional nonce has not been used before.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - login: The login the request is made for.
+	//   - nonce: An optional client nonce; empty skips the replay check.
+	//
+	// Returns:
+	//   - ErrAccountLocked if the login is locked, ErrNonceReused if the nonce was used; otherwise, nil.
+	Check(ctx context.Context, login, nonce string) error
+
+	// RecordFailure records a failed login attempt.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - login: The login the attempt was made for.
+	//
+	// Returns:
+	//   - ErrAccountLocked if this attempt reached the lockout threshold; otherwise, nil.
+	RecordFailure(ctx context.Context, login string) error
+
+	// RecordSuccess clears the failed attempts of the login after a successful authentication.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - login: The authenticated login.
+	RecordSuccess(ctx context.Context, login string)
+}
+
+// IAuthAuditService defines service-level methods for the authentication audit log.
+type IAuthAuditService interface {
+	// Record writes the authentication event in the background, so that the audit log never
+	// delays or fails the authentication itself. An event without a user is attributed to the
+	// user holding its login, if any.
+	//
+	// Parameters:
+	//   - ctx: Context of the request that performed the operation.
+	//   - event: The event to record.
+	Record(ctx context.Context, event *models.AuthEvent)
+
+	// Events retrieves a page of the authentication events of a user, newest first.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - limit: The maximum number of events to return.
+	//   - offset: The number of events to skip.
+	//
+	// Returns:
+	//   - A slice of pointers to AuthEvent models representing the requested page.
+	//   - The total number of events of the user.
+	//   - ErrUserNotFound if the user does not exist, or an error if retrieval fails.
+	Events(ctx context.Context, userID *uuid.UUID, limit, offset int) ([]*models.AuthEvent, int64, error)
+}
+
+// IWithdrawalService defines service-level methods for withdrawals that await admin approval.
+type IWithdrawalService interface {
+	// Request holds the amount of a withdrawal: it is taken from the user's balance, so it can
+	// no longer be spent, and recorded as a pending withdrawal until an admin decides on it.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - amount: The amount to withdraw.
+	//
+	// Returns:
+	//   - A pointer to the pending withdrawal.
+	//   - A pointer to the user's balance after the funds are held.
+	//   - ErrInvalidAmount if the amount is not positive, ErrUserNotFound if the user does not exist,
+	//     ErrInsufficientFunds if the balance does not cover the amount, or another error if the request fails.
+	Request(ctx context.Context, userID *uuid.UUID, amount float64) (*models.PendingWithdrawal, *float64, error)
+
+	// Approve finalizes a pending withdrawal; the held funds have already left the balance.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - withdrawalID: The unique numeric ID of the withdrawal.
+	//
+	// Returns:
+	//   - A pointer to the approved withdrawal.
+	//   - ErrWithdrawalNotFound if the withdrawal does not exist, ErrWithdrawalNotPending if it has
+	//     already been decided on, or another error if the approval fails.
+	Approve(ctx context.Context, withdrawalID uint) (*models.PendingWithdrawal, error)
+
+	// Reject refuses a pending withdrawal and returns the held funds to the user's balance,
+	// with a compensating ledger entry written in the same transaction.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - withdrawalID: The unique numeric ID of the withdrawal.
+	//   - reason: The reason for rejecting the withdrawal.
+	//
+	// Returns:
+	//   - A pointer to the rejected withdrawal.
+	//   - ErrWithdrawalNotFound if the withdrawal does not exist, ErrWithdrawalNotPending if it has
+	//     already been decided on, or another error if the rejection fails.
+	Reject(ctx context.Context, withdrawalID uint, reason string) (*models.PendingWithdrawal, error)
+}
+
+// ISessionService defines service-level methods for grouping the spins of a user into game sessions.
+// A session ends once the user has been inactive for the session timeout.
+type ISessionService interface {
+	// Start starts a new game session of the user, ending the current one.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - A pointer to the started session.
+	//   - ErrUserNotFound if the user does not exist, or an error if the session cannot be started.
+	Start(ctx context.Context, userID *uuid.UUID) (*models.Session, error)
+
+	// Current returns the active game session of the user and extends it by the session timeout.
+	// A new session is started if the user has none or the last one timed out.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - The numeric ID of the active session.
+	//   - ErrUserNotFound if the user does not exist, or an error if the session cannot be tracked.
+	Current(ctx context.Context, userID *uuid.UUID) (uint, error)
+
+	// End ends the active game session of the user, if any.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - An error if the session cannot be ended.
+	End(ctx context.Context, userID *uuid.UUID) error
+
+	// Summaries retrieves a page of the summaries of the user's game sessions, newest first.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//   - limit: The maximum number of sessions to return.
+	//   - offset: The number of sessions to skip.
+	//
+	// Returns:
+	//   - A slice of pointers to SessionSummary models representing the requested page.
+	//   - The total number of sessions of the user.
+	//   - ErrUserNotFound if the user does not exist, or an error if retrieval fails.
+	Summaries(ctx context.Context, userID *uuid.UUID, limit, offset int) ([]*models.SessionSummary, int64, error)
+
+	// RealityCheck returns the reality check of a game session once the interval has passed since
+	// the start of the session or, after one was acknowledged, since the acknowledgment.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - sessionID: The numeric ID of the session.
+	//   - interval: The time of play between two reality checks.
+	//
+	// Returns:
+	//   - A pointer to the RealityCheck of the session, or nil if none is due.
+	//   - An error if the session cannot be read.
+	RealityCheck(ctx context.Context, sessionID uint, interval time.Duration) (*models.RealityCheck, error)
+
+	// AcknowledgeRealityCheck acknowledges the reality check of the user's active game session,
+	// postponing the next one by the interval. Nothing happens if the user has no active session.
+	//
+	// Parameters:
+	//   - ctx: Context for managing request-scoped values and cancellation signals.
+	//   - userID: A UUID representing the user's external identifier.
+	//
+	// Returns:
+	//   - An error if the acknowledgment cannot be recorded.
+	AcknowledgeRealityCheck(ctx context.Context, userID *uuid.UUID) error
 }
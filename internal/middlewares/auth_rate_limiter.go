@@ -0,0 +1,133 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	logger "github.com/public-forge/go-logger"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/ulule/limiter/v3"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
+)
+
+// NewAuthRateLimiter sets up and returns a Gin middleware throttling the login and registration
+// attempts by AuthRateLimit, separately from the rate limit of the game endpoints. Attempts are
+// counted per client IP and per login, so that neither a single client trying many logins nor many
+// clients trying a single login get more attempts than the limit. Trusted API keys are not exempt.
+// The counters share the Redis store, the prefix and the failure mode of the client rate limiter.
+// Without an AuthRateLimit, attempts are not throttled.
+//
+// Parameters:
+//   - config (*config.SlotConfig): Configuration structure containing rate limit settings.
+//   - redisClient (*libredis.Client): Redis client instance used as the backend for the rate limiter.
+//
+// Returns:
+//   - (gin.HandlerFunc): Gin middleware handler function throttling the authentication attempts.
+func NewAuthRateLimiter(config *config.SlotConfig, redisClient *libredis.Client) gin.HandlerFunc {
+	if config.AuthRateLimit == "" {
+		return func(c *gin.Context) { c.Next() }
+	}
+	rate, err := limiter.NewRateFromFormatted(config.AuthRateLimit)
+	if err != nil {
+		panic(err) // Panic on invalid rate format
+	}
+	prefix := config.RateLimitPrefix
+	if prefix == "" {
+		prefix = limiter.DefaultPrefix
+	}
+	return AuthRateLimit(limiter.New(&redisStore{client: redisClient, prefix: prefix}, rate), config.RateLimitFailOpen)
+}
+
+// AuthRateLimit returns a Gin middleware enforcing the given limiter on both the client IP and the
+// login named in the request body of an authentication attempt. An attempt is rejected with status
+// 429 once either counter reached the limit; the rate limit headers describe the counter closest to
+// it. Rejections are logged and counted in RateLimitRejections like those of RateLimit. Limiter
+// store failures are logged; the request then proceeds when failOpen is set and is answered with
+// status 503 otherwise.
+func AuthRateLimit(rateLimiter *limiter.Limiter, failOpen bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keys := []string{"auth:ip:" + c.ClientIP()}
+		if login := requestLogin(c); login != "" {
+			// Only a digest of the login is used as limiter key, so that logins are neither stored nor logged
+			digest := sha256.Sum256([]byte(login))
+			keys = append(keys, "auth:login:"+hex.EncodeToString(digest[:8]))
+		}
+
+		var closest limiter.Context
+		reachedKey := ""
+		for i, key := range keys {
+			limit, err := rateLimiter.Get(c, key)
+			if err != nil {
+				logger.FromContext(c).Warnw("auth rate limiter unavailable",
+					"trace_id", c.GetString(string(constants.CtxFieldTraceID)),
+					"key", key,
+					"fail_open", failOpen,
+					"error", err.Error(),
+				)
+				if !failOpen {
+					c.String(http.StatusServiceUnavailable, "Rate limiter unavailable")
+					c.Abort()
+					return
+				}
+				c.Next()
+				return
+			}
+			if i == 0 || limit.Remaining < closest.Remaining {
+				closest = limit
+			}
+			if limit.Reached && reachedKey == "" {
+				reachedKey = key
+			}
+		}
+
+		c.Header(HeaderRateLimitLimit, strconv.FormatInt(closest.Limit, 10))
+		c.Header(HeaderRateLimitRemaining, strconv.FormatInt(closest.Remaining, 10))
+		c.Header(HeaderRateLimitReset, strconv.FormatInt(closest.Reset, 10))
+
+		if reachedKey != "" {
+			RateLimitRejections.Add(1)
+			logger.FromContext(c).Warnw("auth rate limit exceeded",
+				"trace_id", c.GetString(string(constants.CtxFieldTraceID)),
+				"key", reachedKey,
+				"path", c.Request.URL.Path,
+			)
+			c.String(http.StatusTooManyRequests, "Limit exceeded")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// requestLogin returns the trimmed and lowercased login of a JSON or XML authentication request,
+// or an empty string if the body names none. The body is restored for the handler.
+func requestLogin(c *gin.Context) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	var req struct {
+		Login string `json:"login" xml:"login"`
+	}
+	switch c.ContentType() {
+	case binding.MIMEXML, binding.MIMEXML2:
+		_ = xml.Unmarshal(body, &req)
+	default:
+		_ = json.Unmarshal(body, &req)
+	}
+	return strings.ToLower(strings.TrimSpace(req.Login))
+}
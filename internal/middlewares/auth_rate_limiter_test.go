@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
+	"github.com/vadymlab/slot-game/internal/config"
+)
+
+// newAuthTestEngine serves a login endpoint throttled to two attempts per minute and a spin endpoint
+// throttled to one request per minute, both counted in the same store. The login handler echoes the
+// request body, showing that the body is restored after the login has been read from it.
+func newAuthTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	router := gin.New()
+	router.POST("/login", AuthRateLimit(limiter.New(store, limiter.Rate{Period: time.Minute, Limit: 2}), true), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+	router.GET("/spin", RateLimit(limiter.New(store, limiter.Rate{Period: time.Minute, Limit: 1}), true, nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+// sendLogin makes a login attempt from the client IP with the body of the given content type.
+func sendLogin(router *gin.Engine, ip, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+	req.RemoteAddr = ip + ":1234"
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthRateLimit_ThrottlesRepeatedLogins(t *testing.T) {
+	router := newAuthTestEngine()
+	body := `{"login":"player@example.com","password":"wrong-password"}`
+	before := RateLimitRejections.Value()
+
+	for i := 0; i < 2; i++ {
+		rec := sendLogin(router, "203.0.113.7", "application/json", body)
+		assert.Equal(t, http.StatusOK, rec.Code, "attempt %d", i+1)
+		assert.Equal(t, body, rec.Body.String(), "the handler reads the whole body")
+	}
+	throttled := sendLogin(router, "203.0.113.7", "application/json", body)
+	assert.Equal(t, http.StatusTooManyRequests, throttled.Code)
+	assert.Equal(t, "2", throttled.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "0", throttled.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, before+1, RateLimitRejections.Value())
+
+	// The game endpoints keep their own limit, untouched by the login attempts
+	req := httptest.NewRequest(http.MethodGet, "/spin", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(HeaderRateLimitLimit))
+}
+
+func TestAuthRateLimit_CountsPerLogin(t *testing.T) {
+	router := newAuthTestEngine()
+
+	// Attempts on one login from different clients share the login's counter; the login is
+	// matched regardless of its case and the format of the body
+	assert.Equal(t, http.StatusOK, sendLogin(router, "203.0.113.7", "application/json", `{"login":"player@example.com"}`).Code)
+	assert.Equal(t, http.StatusOK, sendLogin(router, "198.51.100.1", "application/xml", `<LoginRequest><login> Player@Example.com </login></LoginRequest>`).Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendLogin(router, "192.0.2.10", "application/json", `{"login":"PLAYER@example.com"}`).Code)
+
+	// Other logins from a fresh client are not affected
+	assert.Equal(t, http.StatusOK, sendLogin(router, "192.0.2.10", "application/json", `{"login":"other@example.com"}`).Code)
+}
+
+func TestAuthRateLimit_CountsPerClientIP(t *testing.T) {
+	router := newAuthTestEngine()
+
+	// A client trying a different login every time is still limited by its IP
+	for i, login := range []string{"first@example.com", "second@example.com"} {
+		assert.Equal(t, http.StatusOK, sendLogin(router, "203.0.113.7", "application/json", `{"login":"`+login+`"}`).Code, "attempt %d", i+1)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, sendLogin(router, "203.0.113.7", "application/json", `{"login":"third@example.com"}`).Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendLogin(router, "203.0.113.7", "text/plain", "not a login").Code)
+}
+
+func TestNewAuthRateLimiter_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Without a rate limit Redis is never used, so a nil client is fine
+	router.POST("/login", NewAuthRateLimiter(&config.SlotConfig{}, nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i := 0; i < 3; i++ {
+		rec := sendLogin(router, "203.0.113.7", "application/json", `{"login":"player@example.com"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+	}
+}
@@ -0,0 +1,108 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etagWriter wraps gin.ResponseWriter, buffering the status and the body of the response
+// so that the ETag can be computed from the complete body before anything is sent.
+type etagWriter struct {
+	gin.ResponseWriter
+	buf    bytes.Buffer // Body buffered until the response is complete
+	status int          // Status code recorded until the headers are written
+}
+
+// WriteHeader records the status code; headers are written once the response is complete.
+func (w *etagWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+// Status returns the recorded status code.
+func (w *etagWriter) Status() int {
+	return w.status
+}
+
+// WriteHeaderNow is a no-op; headers are written once the response is complete.
+func (w *etagWriter) WriteHeaderNow() {}
+
+// Write buffers data until the response is complete.
+func (w *etagWriter) Write(data []byte) (int, error) {
+	return w.buf.Write(data)
+}
+
+// WriteString buffers the string in the same way as Write.
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+// ETag is a middleware letting clients cache the successful responses of read endpoints. It sets
+// a strong ETag computed from a hash of the response body and a Cache-Control header allowing the
+// client to reuse the response for maxAge seconds; with a maxAge of 0 the client must revalidate
+// every time. A request whose If-None-Match header matches the ETag is answered with 304 Not
+// Modified and no body. The body is buffered until the handler completes, so the middleware must
+// not be used on streamed responses. Other methods than GET and HEAD, and responses other than
+// 200 OK, are passed through unchanged.
+//
+// Parameters:
+//   - maxAge: Seconds clients may reuse a response without revalidating it.
+//
+// Returns:
+//   - (gin.HandlerFunc): Gin middleware handler function.
+func ETag(maxAge int) gin.HandlerFunc {
+	cacheControl := fmt.Sprintf("private, max-age=%d", maxAge)
+	if maxAge <= 0 {
+		cacheControl = "private, no-cache"
+	}
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &etagWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.status == http.StatusOK {
+			sum := sha256.Sum256(w.buf.Bytes())
+			etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+			header := w.Header()
+			header.Set("ETag", etag)
+			header.Set("Cache-Control", cacheControl)
+			if matchesETag(c.GetHeader("If-None-Match"), etag) {
+				header.Del("Content-Type")
+				header.Del("Content-Length")
+				w.ResponseWriter.WriteHeader(http.StatusNotModified)
+				w.ResponseWriter.WriteHeaderNow()
+				return
+			}
+		}
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.WriteHeaderNow()
+		if w.buf.Len() > 0 {
+			if _, err := w.ResponseWriter.Write(w.buf.Bytes()); err != nil {
+				_ = c.Error(err)
+			}
+		}
+	}
+}
+
+// matchesETag reports whether the If-None-Match header lists the ETag or is "*". Weak validators
+// match their strong counterpart, as If-None-Match uses the weak comparison.
+func matchesETag(ifNoneMatch, etag string) bool {
+	for _, part := range strings.Split(ifNoneMatch, ",") {
+		candidate := strings.TrimPrefix(strings.TrimSpace(part), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package middlewares
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newETagTestEngine serves the given body under /config through the ETag middleware.
+func newETagTestEngine(maxAge int, body *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/config", ETag(maxAge), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"symbols": *body})
+	})
+	router.GET("/missing", ETag(maxAge), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "GAME_NOT_FOUND"})
+	})
+	return router
+}
+
+// get requests the path, sending the ETag in If-None-Match unless it is empty.
+func get(router http.Handler, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestETag_NotModifiedOnMatchingETag(t *testing.T) {
+	body := "A,B,C"
+	router := newETagTestEngine(60, &body)
+
+	first := get(router, "/config", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "private, max-age=60", first.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"symbols":"A,B,C"}`, first.Body.String())
+
+	// The same content yields the same ETag, so the cached response is still current
+	for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		rec := get(router, "/config", ifNoneMatch)
+		assert.Equal(t, http.StatusNotModified, rec.Code, ifNoneMatch)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+	}
+}
+
+func TestETag_ChangedContentGetsNewETag(t *testing.T) {
+	body := "A,B,C"
+	router := newETagTestEngine(60, &body)
+	etag := get(router, "/config", "").Header().Get("ETag")
+
+	body = "A,B,C,D"
+	rec := get(router, "/config", etag)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+	assert.JSONEq(t, `{"symbols":"A,B,C,D"}`, rec.Body.String())
+}
+
+func TestETag_ZeroMaxAgeRevalidates(t *testing.T) {
+	body := "A,B,C"
+	rec := get(newETagTestEngine(0, &body), "/config", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "private, no-cache", rec.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+}
+
+func TestETag_ErrorsNotCached(t *testing.T) {
+	body := ""
+	rec := get(newETagTestEngine(60, &body), "/missing", "*")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+	assert.Empty(t, rec.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"code":"GAME_NOT_FOUND"}`, rec.Body.String())
+}
+
+func TestETag_WithGzip(t *testing.T) {
+	// The route middleware runs inside the engine-wide compression, which encodes the full body
+	body := strings.Repeat("A,", 100)
+	engine := gin.New()
+	engine.Use(Gzip(10))
+	engine.GET("/config", ETag(60), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"symbols": body})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/config", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	gz, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	plain, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"symbols":"`+body+`"}`, string(plain))
+
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
@@ -0,0 +1,152 @@
+package middlewares
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gzipWriter wraps gin.ResponseWriter, buffering the response body until it reaches
+// the minimum size. Once the threshold is reached the response is gzip-encoded;
+// responses that stay below it, or are flushed before reaching it, are written uncompressed.
+type gzipWriter struct {
+	gin.ResponseWriter
+	minSize int          // Minimum body size in bytes before compression is applied
+	buf     bytes.Buffer // Body buffered until the compression decision is made
+	gz      *gzip.Writer // Active gzip writer, nil until compression starts
+	status  int          // Status code recorded until the headers are written
+	plain   bool         // Whether the response was flushed uncompressed and is passed through
+}
+
+// WriteHeader records the status code; headers are written once the compression decision is made.
+func (w *gzipWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+// Status returns the recorded status code.
+func (w *gzipWriter) Status() int {
+	return w.status
+}
+
+// WriteHeaderNow is a no-op; headers are written once the compression decision is made.
+func (w *gzipWriter) WriteHeaderNow() {}
+
+// Write buffers data until the minimum size is reached, then switches to gzip encoding.
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	if w.plain {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	w.buf.Write(data)
+	if w.buf.Len() < w.minSize {
+		return len(data), nil
+	}
+	if err := w.startGzip(); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// WriteString buffers or compresses the string in the same way as Write.
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends the data written so far to the client. A response flushed before the compression
+// decision is made, such as a stream of server-sent events, is passed through uncompressed.
+func (w *gzipWriter) Flush() {
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	} else if !w.plain {
+		w.plain = true
+		_ = w.flushPlain()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap returns the wrapped response writer.
+func (w *gzipWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// startGzip sets the encoding headers, writes the status and flushes the buffered body through gzip.
+// Responses that already carry a Content-Encoding are passed through unchanged.
+func (w *gzipWriter) startGzip() error {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return w.flushPlain()
+	}
+	header.Set("Content-Encoding", "gzip")
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	_, err := w.gz.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// flushPlain writes the status and the buffered body without compression.
+func (w *gzipWriter) flushPlain() error {
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// close completes the response, finishing the gzip stream or writing the buffered body as is.
+func (w *gzipWriter) close() error {
+	if w.plain {
+		return nil
+	}
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+	return w.flushPlain()
+}
+
+// Gzip is a middleware that gzip-encodes responses for clients that accept gzip encoding.
+// Responses smaller than minSize bytes are sent uncompressed, since compressing them
+// costs more than it saves.
+func Gzip(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !acceptsGzip(c.Request) {
+			c.Next()
+			return
+		}
+
+		w := &gzipWriter{ResponseWriter: c.Writer, minSize: minSize, status: http.StatusOK}
+		c.Writer = w
+		defer func() {
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+
+		if err := w.close(); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding header allows gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		q := strings.ReplaceAll(strings.TrimSpace(params), " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
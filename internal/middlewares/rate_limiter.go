@@ -1,17 +1,44 @@
 package middlewares
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"expvar"
 	"github.com/gin-gonic/gin"
+	logger "github.com/public-forge/go-logger"
 	libredis "github.com/redis/go-redis/v9"
 	"github.com/ulule/limiter/v3"
-	ginLimiter "github.com/ulule/limiter/v3/drivers/middleware/gin"
 	sredis "github.com/ulule/limiter/v3/drivers/store/redis"
 	"github.com/vadymlab/slot-game/internal/config"
-	"log"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"net/http"
+	"strconv"
+	"sync"
 )
 
+// Rate limit response headers describing the state of the client's quota.
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"     // Maximum number of requests within the period
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining" // Requests left within the current period
+	HeaderRateLimitReset     = "X-RateLimit-Reset"     // Unix time at which the current period ends
+)
+
+// HeaderAPIKey is the HTTP header carrying the API key of a trusted integration.
+const HeaderAPIKey = "X-API-Key"
+
+// RateLimitRejections counts the requests rejected by the rate limiter. It is published
+// through expvar as "rate_limit_rejections".
+var RateLimitRejections = expvar.NewInt("rate_limit_rejections")
+
 // NewRateLimiter sets up and returns a Gin middleware for rate limiting requests.
 // The rate limiter uses Redis as a store and applies limits based on the provided configuration.
+// The store is initialized lazily, so an unreachable Redis never prevents the server from starting;
+// while Redis is unavailable, requests are let through or rejected according to RateLimitFailOpen.
+// Requests presenting one of the TrustedAPIKeys are limited per key by TrustedRateLimit instead,
+// or not at all when no trusted rate limit is configured. The keys of both limiters are stored under
+// RateLimitPrefix, so that environments sharing a Redis keep separate counters.
 //
 // Parameters:
 //   - config (*config.SlotConfig): Configuration structure containing rate limit settings.
@@ -38,18 +65,170 @@ func NewRateLimiter(config *config.SlotConfig, redisClient *libredis.Client) gin
 		panic(err) // Panic on invalid rate format
 	}
 
-	// Initialize Redis-backed store with options for the limiter.
-	store, err := sredis.NewStoreWithOptions(redisClient, limiter.StoreOptions{
-		Prefix: "limiter", // Prefix for limiter keys in Redis
+	// Create a new rate limiter with the specified rate and a Redis store connected on first use.
+	prefix := config.RateLimitPrefix
+	if prefix == "" {
+		prefix = limiter.DefaultPrefix
+	}
+	rateLimiter := limiter.New(&redisStore{client: redisClient, prefix: prefix}, rate)
+
+	trusted := &TrustedClients{Keys: config.TrustedAPIKeys}
+	if config.TrustedRateLimit != "" {
+		trustedRate, err := limiter.NewRateFromFormatted(config.TrustedRateLimit)
+		if err != nil {
+			panic(err) // Panic on invalid rate format
+		}
+		trusted.Limiter = limiter.New(&redisStore{client: redisClient, prefix: prefix}, trustedRate)
+	}
+
+	// Return the Gin middleware handler function for rate limiting.
+	return RateLimit(rateLimiter, config.RateLimitFailOpen, trusted)
+}
+
+// TrustedClients describes the integrations trusted to exceed the client rate limit. They identify
+// themselves with one of the API keys in the HeaderAPIKey header.
+type TrustedClients struct {
+	Keys    []string         // API keys of the trusted integrations
+	Limiter *limiter.Limiter // Limiter applied per trusted API key; nil exempts trusted requests from rate limiting
+}
+
+// trusts reports whether the API key is one of the trusted keys. The keys are compared in constant
+// time, so that the response time does not reveal how much of a key was guessed right.
+func (t *TrustedClients) trusts(apiKey string) bool {
+	if t == nil || apiKey == "" {
+		return false
+	}
+	trusted := false
+	for _, key := range t.Keys {
+		if key != "" && subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			trusted = true
+		}
+	}
+	return trusted
+}
+
+// limiterKey selects the limiter and key a request is limited by: requests presenting a trusted API
+// key are limited per key by the trusted limiter, while all other requests, including those with an
+// unknown API key, are limited per client IP. A nil limiter means the request is not limited. Only a
+// digest of the API key is used as limiter key, so that the key itself is neither stored nor logged.
+func limiterKey(c *gin.Context, rateLimiter *limiter.Limiter, trusted *TrustedClients) (*limiter.Limiter, string) {
+	apiKey := c.GetHeader(HeaderAPIKey)
+	if !trusted.trusts(apiKey) {
+		return rateLimiter, c.ClientIP()
+	}
+	digest := sha256.Sum256([]byte(apiKey))
+	return trusted.Limiter, "api-key:" + hex.EncodeToString(digest[:8])
+}
+
+// redisStore is a limiter.Store backed by Redis that defers the initialization of the underlying
+// store, which loads its scripts into Redis, until Redis is reachable. Initialization is retried on
+// every call until it succeeds.
+type redisStore struct {
+	client sredis.Client // Redis client used by the underlying store
+	prefix string        // Prefix of the limiter keys in Redis
+	mu     sync.Mutex    // Guards store
+	store  limiter.Store // Underlying store; nil until initialized
+}
+
+// get returns the underlying store, initializing it if needed.
+func (s *redisStore) get() (limiter.Store, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.store != nil {
+		return s.store, nil
+	}
+	store, err := sredis.NewStoreWithOptions(s.client, limiter.StoreOptions{
+		Prefix: s.prefix, // Prefix for limiter keys in Redis
 	})
 	if err != nil {
-		log.Fatal(err) // Log and terminate on store initialization failure
-		return nil
+		return nil, err
 	}
+	s.store = store
+	return store, nil
+}
 
-	// Create a new rate limiter with the specified rate and Redis store.
-	rateLimiter := limiter.New(store, rate)
+// Get returns the limit for given identifier.
+func (s *redisStore) Get(ctx context.Context, key string, rate limiter.Rate) (limiter.Context, error) {
+	store, err := s.get()
+	if err != nil {
+		return limiter.Context{}, err
+	}
+	return store.Get(ctx, key, rate)
+}
 
-	// Return the Gin middleware handler function for rate limiting.
-	return ginLimiter.NewMiddleware(rateLimiter)
+// Peek returns the limit for given identifier, without modification on current values.
+func (s *redisStore) Peek(ctx context.Context, key string, rate limiter.Rate) (limiter.Context, error) {
+	store, err := s.get()
+	if err != nil {
+		return limiter.Context{}, err
+	}
+	return store.Peek(ctx, key, rate)
+}
+
+// Reset resets the limit to zero for given identifier.
+func (s *redisStore) Reset(ctx context.Context, key string, rate limiter.Rate) (limiter.Context, error) {
+	store, err := s.get()
+	if err != nil {
+		return limiter.Context{}, err
+	}
+	return store.Reset(ctx, key, rate)
+}
+
+// Increment increments the limit by given count and gives back the new limit for given identifier.
+func (s *redisStore) Increment(ctx context.Context, key string, count int64, rate limiter.Rate) (limiter.Context, error) {
+	store, err := s.get()
+	if err != nil {
+		return limiter.Context{}, err
+	}
+	return store.Increment(ctx, key, count, rate)
+}
+
+// RateLimit returns a Gin middleware enforcing the given limiter per client IP. Requests of trusted
+// clients are limited per API key by the trusted limiter instead, or let through without limiting
+// when trusted clients have none. Every limited response carries the rate limit headers taken from
+// the limiter context. Rejected requests are answered
+// with status 429, logged with their trace ID and limiter key, and counted in RateLimitRejections.
+// Limiter store failures are logged; the request then proceeds when failOpen is set and is
+// answered with status 503 otherwise.
+func RateLimit(rateLimiter *limiter.Limiter, failOpen bool, trusted *TrustedClients) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keyLimiter, key := limiterKey(c, rateLimiter, trusted)
+		if keyLimiter == nil {
+			c.Next()
+			return
+		}
+		limit, err := keyLimiter.Get(c, key)
+		if err != nil {
+			logger.FromContext(c).Warnw("rate limiter unavailable",
+				"trace_id", c.GetString(string(constants.CtxFieldTraceID)),
+				"key", key,
+				"fail_open", failOpen,
+				"error", err.Error(),
+			)
+			if !failOpen {
+				c.String(http.StatusServiceUnavailable, "Rate limiter unavailable")
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		c.Header(HeaderRateLimitLimit, strconv.FormatInt(limit.Limit, 10))
+		c.Header(HeaderRateLimitRemaining, strconv.FormatInt(limit.Remaining, 10))
+		c.Header(HeaderRateLimitReset, strconv.FormatInt(limit.Reset, 10))
+
+		if limit.Reached {
+			RateLimitRejections.Add(1)
+			logger.FromContext(c).Warnw("rate limit exceeded",
+				"trace_id", c.GetString(string(constants.CtxFieldTraceID)),
+				"key", key,
+				"path", c.Request.URL.Path,
+			)
+			c.String(http.StatusTooManyRequests, "Limit exceeded")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
 }
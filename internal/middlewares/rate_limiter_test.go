@@ -0,0 +1,208 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/public-forge/go-logger"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
+	sredis "github.com/ulule/limiter/v3/drivers/store/redis"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
+)
+
+// recordingLogger records the structured warnings written through it.
+type recordingLogger struct {
+	log.Logger
+	mu       sync.Mutex
+	warnings []map[string]interface{}
+}
+
+func (l *recordingLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := map[string]interface{}{"msg": msg}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		entry[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	l.warnings = append(l.warnings, entry)
+}
+
+func TestRateLimit_ThrottledRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := &recordingLogger{Logger: log.GetDefaultLogger()}
+	rateLimiter := limiter.New(memory.NewStore(), limiter.Rate{Period: time.Minute, Limit: 1})
+
+	router := gin.New()
+	router.Use(TraceMiddleware(), func(c *gin.Context) {
+		c.Set(string(constants.CtxFieldLogger), logger)
+	}, RateLimit(rateLimiter, true, nil))
+	router.GET("/spin", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/spin", nil)
+		req.RemoteAddr = "203.0.113.7:1234"
+		req.Header.Set(HeaderTraceID, "trace-1")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	before := RateLimitRejections.Value()
+
+	first := send()
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "0", first.Header().Get(HeaderRateLimitRemaining))
+	assert.Empty(t, logger.warnings)
+
+	throttled := send()
+	assert.Equal(t, http.StatusTooManyRequests, throttled.Code)
+	assert.Equal(t, "1", throttled.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "0", throttled.Header().Get(HeaderRateLimitRemaining))
+	assert.NotEmpty(t, throttled.Header().Get(HeaderRateLimitReset))
+	assert.Equal(t, before+1, RateLimitRejections.Value())
+
+	if assert.Len(t, logger.warnings, 1) {
+		assert.Equal(t, "trace-1", logger.warnings[0]["trace_id"])
+		assert.Equal(t, "203.0.113.7", logger.warnings[0]["key"])
+	}
+}
+
+func TestNewRateLimiter_RedisUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// Nothing listens on port 1, so every Redis command fails immediately
+	redisClient := libredis.NewClient(&libredis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer redisClient.Close()
+
+	testCases := []struct {
+		name     string
+		failOpen bool
+		expected int
+	}{
+		{"FailOpen", true, http.StatusOK},
+		{"FailClosed", false, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			slotConfig := &config.SlotConfig{RateLimit: "1-S", RateLimitFailOpen: tc.failOpen}
+			router := gin.New()
+			router.POST("/api/slot/spin", NewRateLimiter(slotConfig, redisClient), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			for i := 0; i < 3; i++ {
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/slot/spin", nil))
+				assert.Equal(t, tc.expected, rec.Code)
+				assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+			}
+		})
+	}
+}
+
+func TestRateLimit_TrustedAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name     string
+		trusted  *TrustedClients
+		apiKey   string
+		expected []int
+	}{
+		{"NoAPIKey", &TrustedClients{Keys: []string{"partner-key"}}, "", []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}},
+		{"UnknownAPIKey", &TrustedClients{Keys: []string{"partner-key"}}, "guessed-key", []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}},
+		{"TrustedAPIKeyBypasses", &TrustedClients{Keys: []string{"other-key", "partner-key"}}, "partner-key", []int{http.StatusOK, http.StatusOK, http.StatusOK}},
+		{"TrustedAPIKeyElevatedLimit", &TrustedClients{
+			Keys:    []string{"partner-key"},
+			Limiter: limiter.New(memory.NewStore(), limiter.Rate{Period: time.Minute, Limit: 2}),
+		}, "partner-key", []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rateLimiter := limiter.New(memory.NewStore(), limiter.Rate{Period: time.Minute, Limit: 1})
+			router := gin.New()
+			router.Use(RateLimit(rateLimiter, true, tc.trusted))
+			router.GET("/spin", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			send := func(apiKey string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, "/spin", nil)
+				req.RemoteAddr = "203.0.113.7:1234"
+				if apiKey != "" {
+					req.Header.Set(HeaderAPIKey, apiKey)
+				}
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+				return rec
+			}
+
+			for i, expected := range tc.expected {
+				assert.Equal(t, expected, send(tc.apiKey).Code, "request %d", i+1)
+			}
+			// Trusted requests do not use up the quota of other clients sharing the IP
+			if tc.apiKey == "partner-key" {
+				assert.Equal(t, http.StatusOK, send("").Code)
+				assert.Equal(t, http.StatusTooManyRequests, send("").Code)
+			}
+		})
+	}
+}
+
+// sharedRedis is an in-memory stand-in for the Redis server shared by several limiter stores. It
+// only runs the scripts of the limiter store, by the script text standing in for its SHA.
+type sharedRedis struct {
+	sredis.Client
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+func (r *sharedRedis) ScriptLoad(ctx context.Context, script string) *libredis.StringCmd {
+	return libredis.NewStringResult(script, nil)
+}
+
+func (r *sharedRedis) EvalSha(ctx context.Context, sha string, keys []string, args ...interface{}) *libredis.Cmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cmd := libredis.NewCmd(ctx)
+	if strings.Contains(sha, "incrby") {
+		// The store passes the count as an int or an int64
+		r.counters[keys[0]] += reflect.ValueOf(args[0]).Int()
+	}
+	cmd.SetVal([]interface{}{r.counters[keys[0]], time.Minute.Milliseconds()})
+	return cmd
+}
+
+func TestRedisStore_PrefixSeparatesCounters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	redis := &sharedRedis{counters: map[string]int64{}}
+
+	// send makes a request from the same client to a limiter with the given key prefix
+	send := func(prefix string) int {
+		rate := limiter.Rate{Period: time.Minute, Limit: 1}
+		router := gin.New()
+		router.Use(RateLimit(limiter.New(&redisStore{client: redis, prefix: prefix}, rate), false, nil))
+		router.GET("/spin", func(c *gin.Context) { c.Status(http.StatusOK) })
+		req := httptest.NewRequest(http.MethodGet, "/spin", nil)
+		req.RemoteAddr = "203.0.113.7:1234"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send("limiter:staging"))
+	assert.Equal(t, http.StatusOK, send("limiter:production"))
+	assert.Equal(t, http.StatusTooManyRequests, send("limiter:staging"))
+	assert.Equal(t, http.StatusTooManyRequests, send("limiter:production"))
+	assert.Equal(t, map[string]int64{
+		"limiter:staging:203.0.113.7":    2,
+		"limiter:production:203.0.113.7": 2,
+	}, redis.counters)
+}
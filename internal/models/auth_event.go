@@ -0,0 +1,28 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// Authentication event types recorded in the audit log.
+const (
+	AuthEventTypeLogin       = "login"        // A login attempt
+	AuthEventTypeRegister    = "register"     // A registration attempt
+	AuthEventTypeLoginChange = "login_change" // A change of the login, confirmed with the password
+)
+
+// AuthEvent records an authentication related operation of a user for security audits.
+// Events are append-only and written after the operation completes, whether it succeeded or not.
+type AuthEvent struct {
+	gorm.Model
+	UserID    *uint  `gorm:"column:user_id"`          // Foreign key to the User model; nil if the login is unknown
+	Login     string `gorm:"column:login;not null"`   // Login the operation was made for
+	Type      string `gorm:"column:type;not null"`    // Operation, one of the AuthEventType constants
+	Success   bool   `gorm:"column:success;not null"` // Whether the operation succeeded
+	IP        string `gorm:"column:ip"`               // Client IP address of the request
+	UserAgent string `gorm:"column:user_agent"`       // User-Agent header of the request
+	TraceID   string `gorm:"column:trace_id"`         // Trace ID of the request
+}
+
+// TableName sets the table name for the AuthEvent model explicitly.
+func (AuthEvent) TableName() string {
+	return "auth_events"
+}
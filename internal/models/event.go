@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Event types published to external systems.
+const (
+	EventTypeBigWin  = "spin.big_win"   // A spin won at least the configured threshold
+	EventTypeDeposit = "wallet.deposit" // A deposit of at least the configured threshold was made
+)
+
+// Event represents a significant business event delivered to external subscribers,
+// such as affiliates and CRM systems. It is not backed by a table.
+type Event struct {
+	ID         string    `json:"id"`          // Unique event identifier, usable for deduplication
+	Type       string    `json:"type"`        // Event type, one of the EventType constants
+	UserID     string    `json:"user_id"`     // External identifier of the user
+	Amount     float64   `json:"amount"`      // Win or deposit amount
+	OccurredAt time.Time `json:"occurred_at"` // Time the underlying transaction was committed
+}
+
+// Domain event types published to the event bus.
+const (
+	DomainEventSpinCompleted  = "SpinCompleted"  // A spin was committed
+	DomainEventDepositMade    = "DepositMade"    // A deposit was committed
+	DomainEventWithdrawalMade = "WithdrawalMade" // A withdrawal was paid out
+)
+
+// DomainEvent represents a committed change of the game or the wallet, published to the event bus
+// for analytics and downstream systems. Unlike Event, every spin and wallet operation is
+// published, regardless of its amount. It is not backed by a table.
+type DomainEvent struct {
+	ID         string              `json:"id"`                 // Unique event identifier, usable for deduplication
+	Type       string              `json:"type"`               // Event type, one of the DomainEvent constants
+	TraceID    string              `json:"trace_id,omitempty"` // Trace ID of the request that caused the event
+	UserID     string              `json:"user_id"`            // External identifier of the user
+	Amount     float64             `json:"amount"`             // Deposited or withdrawn amount; the bet of a spin
+	OccurredAt time.Time           `json:"occurred_at"`        // Time the underlying transaction was committed
+	Spin       *SpinCompletedEvent `json:"spin,omitempty"`     // Outcome of the spin; only set on SpinCompleted
+}
+
+// SpinCompletedEvent describes the outcome of a committed spin within a DomainEvent.
+type SpinCompletedEvent struct {
+	SpinID    uint     `json:"spin_id,omitempty"`    // ID of the spin; 0 while the spin awaits the batched spin writer
+	GameID    string   `json:"game_id"`              // ID of the game the spin was played in
+	SessionID *uint    `json:"session_id,omitempty"` // Game session the spin was played in; nil if none was tracked
+	BetAmount float64  `json:"bet_amount"`           // The amount bet
+	WinAmount float64  `json:"win_amount"`           // The amount credited, after the win cap
+	Reels     []string `json:"reels"`                // Symbols shown on the reels
+	Bonuses   []string `json:"bonuses,omitempty"`    // Bonus features triggered by the spin
+}
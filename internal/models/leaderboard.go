@@ -0,0 +1,15 @@
+package models
+
+// LeaderboardEntry represents an aggregated leaderboard row for a single user.
+// It is not backed by a table; rows are produced by aggregating the spins table.
+type LeaderboardEntry struct {
+	UserID   uint    `gorm:"column:user_id"`   // Numeric ID of the user
+	Login    string  `gorm:"column:login"`     // Login of the user, anonymized before being returned to clients
+	TotalWin float64 `gorm:"column:total_win"` // Sum of all win amounts within the period
+	TotalBet float64 `gorm:"column:total_bet"` // Sum of all bet amounts within the period
+}
+
+// NetProfit returns the user's total winnings minus their total bets within the period.
+func (e *LeaderboardEntry) NetProfit() float64 {
+	return e.TotalWin - e.TotalBet
+}
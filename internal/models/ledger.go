@@ -0,0 +1,34 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// Ledger entry types describing the reason for a balance change.
+const (
+	LedgerTypeDeposit = "deposit" // Funds deposited by the user
+	LedgerTypeBonus   = "bonus"   // Bonus credited by a promo code
+	LedgerTypeWelcome = "welcome" // Welcome balance credited on registration
+
+	LedgerTypeVoidRefund   = "void_refund"   // Bet of a voided spin returned to the user
+	LedgerTypeVoidClawback = "void_clawback" // Win of a voided spin taken back from the user
+
+	LedgerTypeWithdrawalHold    = "withdrawal_hold"    // Funds of a pending withdrawal reserved until it is approved or rejected
+	LedgerTypeWithdrawalRelease = "withdrawal_release" // Funds of a rejected withdrawal returned to the user
+
+	LedgerTypeTransferOut = "transfer_out" // Funds sent to another user
+	LedgerTypeTransferIn  = "transfer_in"  // Funds received from another user
+)
+
+// LedgerEntry records a single balance change of a user. Entries are append-only
+// and are written within the same transaction as the balance update they describe.
+type LedgerEntry struct {
+	gorm.Model
+	UserID    uint    `gorm:"column:user_id;not null"` // Foreign key to the User model
+	Type      string  `gorm:"column:type;not null"`    // Reason for the balance change, one of the LedgerType constants
+	Amount    float64 `gorm:"column:amount;not null"`  // Signed amount of the balance change
+	Reference string  `gorm:"column:reference"`        // Optional reference, such as the applied promo code or the counterparty of a transfer
+}
+
+// TableName sets the table name for the LedgerEntry model explicitly.
+func (LedgerEntry) TableName() string {
+	return "ledger_entries"
+}
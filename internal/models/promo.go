@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Promo code types defining how the bonus is calculated.
+const (
+	PromoTypePercentage = "percentage" // Bonus is a percentage of the deposit amount
+	PromoTypeFixed      = "fixed"      // Bonus is a fixed amount
+)
+
+// PromoCode represents a deposit bonus offered by an operator promotion.
+type PromoCode struct {
+	gorm.Model
+	Code         string     `gorm:"column:code;unique;not null"`    // Code entered by the user on deposit
+	Type         string     `gorm:"column:type;not null"`           // Bonus calculation type, one of the PromoType constants
+	Value        float64    `gorm:"column:value;not null"`          // Percentage or fixed bonus amount, depending on Type
+	Cap          float64    `gorm:"column:cap;not null"`            // Maximum bonus amount; 0 means no cap
+	MinDeposit   float64    `gorm:"column:min_deposit;not null"`    // Minimum deposit amount required to apply the code
+	PerUserLimit int        `gorm:"column:per_user_limit;not null"` // Maximum redemptions per user; 0 means unlimited
+	ExpiresAt    *time.Time `gorm:"column:expires_at"`              // Expiry time; nil means the code never expires
+}
+
+// TableName sets the table name for the PromoCode model explicitly.
+func (PromoCode) TableName() string {
+	return "promo_codes"
+}
+
+// Expired reports whether the promo code has expired at the given time.
+func (p *PromoCode) Expired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)
+}
+
+// Bonus calculates the bonus credited for the given deposit amount, applying the cap.
+func (p *PromoCode) Bonus(amount float64) float64 {
+	bonus := p.Value
+	if p.Type == PromoTypePercentage {
+		bonus = amount * p.Value / 100
+	}
+	if p.Cap > 0 && bonus > p.Cap {
+		bonus = p.Cap
+	}
+	return bonus
+}
+
+// PromoRedemption records a single use of a promo code by a user.
+type PromoRedemption struct {
+	gorm.Model
+	PromoCodeID uint    `gorm:"column:promo_code_id;not null"` // Foreign key to the PromoCode model
+	UserID      uint    `gorm:"column:user_id;not null"`       // Foreign key to the User model
+	BonusAmount float64 `gorm:"column:bonus_amount;not null"`  // Bonus credited for this redemption
+}
+
+// TableName sets the table name for the PromoRedemption model explicitly.
+func (PromoRedemption) TableName() string {
+	return "promo_redemptions"
+}
@@ -0,0 +1,11 @@
+package models
+
+import "github.com/google/uuid"
+
+// RegistrationKey records the registration made with an idempotency key, so that a retried
+// registration with the same key can be answered with the user created originally.
+// It is not backed by a table; records are kept in Redis for a limited time.
+type RegistrationKey struct {
+	Login  string    `json:"login"`   // Login the user was registered with
+	UserID uuid.UUID `json:"user_id"` // External identifier of the registered user
+}
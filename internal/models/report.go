@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// SpinReportSchema identifies the version of the spin report payload, so that the regulator's
+// endpoint can tell payload formats apart.
+const SpinReportSchema = "slot.spin_report.v1"
+
+// SpinReport represents the outcome of a single committed spin as reported to a regulator.
+// The user is identified by a pseudonym, so that the regulator can relate the spins of a
+// player without learning who the player is. It is not backed by a table.
+type SpinReport struct {
+	ID        string    `json:"id"`        // Unique report identifier; a report may be delivered more than once, so receivers deduplicate by it
+	Schema    string    `json:"schema"`    // Payload version, SpinReportSchema
+	GameID    string    `json:"game_id"`   // Identifier of the game the spin was played on
+	Player    string    `json:"player"`    // Stable pseudonym of the user who played the spin
+	Bet       float64   `json:"bet"`       // The amount bet on the spin
+	Win       float64   `json:"win"`       // The amount credited for the spin
+	Timestamp time.Time `json:"timestamp"` // Time the spin was committed, in UTC
+}
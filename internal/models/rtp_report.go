@@ -0,0 +1,68 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// RTPReport records the realized return to player (RTP) of all spins created within a window,
+// compared with the configured target RTP. Voided spins are left out, as their bets and wins
+// have been reversed. Reports are stored since migration 000026.
+type RTPReport struct {
+	gorm.Model
+	WindowStart  time.Time `gorm:"column:window_start;not null"`  // Inclusive start of the aggregated window
+	WindowEnd    time.Time `gorm:"column:window_end;not null"`    // Exclusive end of the aggregated window
+	TotalSpins   int64     `gorm:"column:total_spins;not null"`   // Number of spins within the window
+	WinningSpins int64     `gorm:"column:winning_spins;not null"` // Number of spins with a win within the window
+	TotalWagered float64   `gorm:"column:total_wagered;not null"` // Sum of the bets within the window
+	TotalWon     float64   `gorm:"column:total_won;not null"`     // Sum of the wins within the window
+	RealizedRTP  float64   `gorm:"column:realized_rtp;not null"`  // Wins as a percentage of the bets; 0 without bets
+	TargetRTP    float64   `gorm:"column:target_rtp;not null"`    // Configured target RTP in percent
+	Drifted      bool      `gorm:"column:drifted;not null"`       // Whether the realized RTP drifted beyond the tolerance
+}
+
+// TableName sets the table name for the RTPReport model explicitly.
+func (RTPReport) TableName() string {
+	return "rtp_reports"
+}
+
+// NewRTPReport builds the report of a window from the aggregated spins of the window.
+//
+// Parameters:
+//   - start: Inclusive start of the window.
+//   - end: Exclusive end of the window.
+//   - stats: The spins of the window, aggregated.
+//   - target: The target RTP in percent.
+//
+// Returns:
+//   - A pointer to the RTPReport, not yet checked for drift.
+func NewRTPReport(start, end time.Time, stats *SpinStats, target float64) *RTPReport {
+	report := &RTPReport{
+		WindowStart:  start,
+		WindowEnd:    end,
+		TotalSpins:   stats.TotalSpins,
+		WinningSpins: stats.WinningSpins,
+		TotalWagered: stats.TotalWagered,
+		TotalWon:     stats.TotalWon,
+		TargetRTP:    target,
+	}
+	if stats.TotalWagered > 0 {
+		report.RealizedRTP = stats.TotalWon / stats.TotalWagered * 100
+	}
+	return report
+}
+
+// Drift returns by how many percentage points the realized RTP deviates from the target, either way.
+func (r *RTPReport) Drift() float64 {
+	return math.Abs(r.RealizedRTP - r.TargetRTP)
+}
+
+// WinRate returns the share of spins with a win within the window, between 0 and 1; 0 without spins.
+func (r *RTPReport) WinRate() float64 {
+	if r.TotalSpins == 0 {
+		return 0
+	}
+	return float64(r.WinningSpins) / float64(r.TotalSpins)
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Session represents a game session, a run of spins played by a user without a longer break.
+// A session starts on login or with the first spin after the previous one timed out, and ends
+// on logout, on the next login or, once the user has been inactive for the session timeout, with
+// the start of the next session. The session of a spin is recorded since migration 000016, the
+// acknowledgment of the reality checks since migration 000021.
+type Session struct {
+	gorm.Model
+	UserID           uint       `gorm:"column:user_id;not null"`   // Foreign key to the User model
+	EndedAt          *time.Time `gorm:"column:ended_at"`           // Time the session ended; nil while it may still be active
+	RealityCheckedAt *time.Time `gorm:"column:reality_checked_at"` // Time the user last acknowledged a reality check; nil if none was
+}
+
+// TableName sets the table name for the Session model explicitly.
+func (Session) TableName() string {
+	return "game_sessions"
+}
+
+// SessionSummary represents the aggregated play of a single game session.
+// It is not backed by a table; it is produced by aggregating the session's spins, voided spins excluded.
+type SessionSummary struct {
+	SessionID    uint       `gorm:"column:session_id"`    // ID of the session
+	StartedAt    time.Time  `gorm:"column:started_at"`    // Time the session started
+	EndedAt      *time.Time `gorm:"column:ended_at"`      // Time the session ended; nil while it may still be active
+	LastSpinAt   *time.Time `gorm:"column:last_spin_at"`  // Time of the last spin of the session; nil if none was played
+	TotalSpins   int64      `gorm:"column:total_spins"`   // Number of spins played
+	TotalWagered float64    `gorm:"column:total_wagered"` // Sum of all bet amounts
+	TotalWon     float64    `gorm:"column:total_won"`     // Sum of all win amounts
+}
+
+// Net returns the session's total winnings minus its total bets.
+func (s *SessionSummary) Net() float64 {
+	return s.TotalWon - s.TotalWagered
+}
+
+// RealityCheck represents a responsible gaming reminder of the time and money the user has spent in
+// the current game session. It is due once the reality check interval has passed since the start of
+// the session or, after one was acknowledged, since the acknowledgment. It is not backed by a table.
+type RealityCheck struct {
+	SessionID    uint          // ID of the session
+	StartedAt    time.Time     // Time the session started
+	Duration     time.Duration // Time played since the session started
+	TotalSpins   int64         // Number of spins played in the session
+	TotalWagered float64       // Sum of all bet amounts of the session
+	TotalWon     float64       // Sum of all win amounts of the session
+}
+
+// NetLoss returns the session's total bets minus its total winnings; negative while the user is ahead.
+func (r *RealityCheck) NetLoss() float64 {
+	return r.TotalWagered - r.TotalWon
+}
@@ -1,18 +1,213 @@
 package models
 
-import "github.com/jinzhu/gorm"
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Bonus features a spin can trigger.
+const (
+	BonusWild    = "wild"    // A wild completed a winning line
+	BonusScatter = "scatter" // Enough scatters landed for the scatter payout
+	BonusStreak  = "streak"  // The payout was raised by the win streak multiplier
+	BonusJackpot = "jackpot" // The spin hit the progressive jackpot
+	BonusForced  = "forced"  // The outcome was forced by QA instead of drawn
+	BonusFree    = "free"    // The spin was a free spin, paid for the free spin bet without debiting it
+)
+
+// ScatterLine is the line index of a scatter win, which pays regardless of the reels' positions.
+const ScatterLine = -1
+
+// ForcedOutcome is a spin outcome QA forces instead of drawing it, for testing how clients present
+// specific results. It is only honored while forced outcomes are enabled in the configuration.
+type ForcedOutcome struct {
+	Reels []string // Symbols shown on each reel; empty draws the reels
+	Win   *float64 // Payout of the spin before the streak, rounding and win cap; nil pays the reels
+}
+
+// Conversion is the exchange of a bet placed in another currency than the base currency. The spin
+// is played and settled with the bet converted into the base currency.
+type Conversion struct {
+	Currency  string  // ISO 4217 code of the currency the bet was placed in
+	Rate      float64 // Number of base currency units one unit of the currency is worth
+	BetAmount float64 // The bet in the currency, before the conversion
+}
+
+// LineWin describes a single paying combination of a spin.
+type LineWin struct {
+	Line       int     // Zero-based index of the paying line, or ScatterLine for a scatter win
+	Reversed   bool    // Whether the match was counted from the last reel instead of the first one
+	Symbol     string  // The matched symbol; the wild symbol if only wilds formed the line
+	Count      int     // Number of reels showing the matched symbol, wilds included
+	Multiplier float64 // Multiplier applied to the bet for this combination
+	Payout     float64 // Amount paid for this combination, including the streak multiplier but before the win cap
+}
+
+// Reels holds the symbols shown on the reels of a spin, stored as a comma-separated column.
+type Reels []string
+
+// Value joins the symbols for storage.
+func (r Reels) Value() (driver.Value, error) {
+	return strings.Join(r, ","), nil
+}
+
+// Scan splits a stored column back into the symbols; an empty column holds no symbols.
+func (r *Reels) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Reels", src)
+	}
+	if value == "" {
+		*r = nil
+		return nil
+	}
+	*r = strings.Split(value, ",")
+	return nil
+}
 
 // Spin represents a spin entry linked to a user. Each spin stores the bet amount,
 // win amount, and a reference to the user who initiated the spin.
+// The history query is served by the idx_spins_user_id_created_at index on (user_id, created_at DESC),
+// created by migration 000006. A voided spin keeps its amounts; the reversal is recorded in the ledger.
+// When the payout exceeded the configured win cap, WinAmount holds the capped payout actually credited
+// and RawWinAmount the payout computed from the reels. The reels are stored since migration 000013,
+// the game session since migration 000016 and the game since migration 000018. A bet placed in another
+// currency than the base currency is stored converted, with the currency, the exchange rate and the
+// original amounts recorded since migration 000020. With the hash chain enabled, each spin of a user
+// stores the hash of the user's previous spin and its own hash, since migration 000023. The free spins
+// a spin awarded are stored since migration 000024.
 type Spin struct {
 	gorm.Model
-	UserID    uint    `gorm:"not null"`                                                         // Foreign key to the User model
-	BetAmount float64 `gorm:"column:bet_amount;not null"`                                       // The amount bet for this spin
-	WinAmount float64 `gorm:"column:win_amount;not null"`                                       // The amount won for this spin
-	User      User    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"` // Association to the User, with update and delete constraints
+	UserID            uint          `gorm:"not null"`                                                         // Foreign key to the User model
+	GameID            string        `gorm:"column:game_id;not null;default:'default'"`                        // ID of the game the spin was played in
+	BetAmount         float64       `gorm:"column:bet_amount;not null"`                                       // The amount bet for this spin
+	WinAmount         float64       `gorm:"column:win_amount;not null"`                                       // The amount won for this spin
+	RawWinAmount      float64       `gorm:"column:raw_win_amount;not null;default:0"`                         // The amount won before the win cap was applied
+	WinCapped         bool          `gorm:"column:win_capped;not null;default:false"`                         // Whether the win was reduced to the win cap
+	VoidedAt          *time.Time    `gorm:"column:voided_at"`                                                 // Time the spin was voided; nil if it stands
+	VoidReason        string        `gorm:"column:void_reason"`                                               // Reason given by the admin who voided the spin
+	Reels             Reels         `gorm:"column:reels;not null"`                                            // Symbols shown on the reels; empty for spins played before they were stored
+	SessionID         *uint         `gorm:"column:session_id"`                                                // Game session the spin was played in; nil if none was tracked
+	Currency          string        `gorm:"column:currency;not null;default:''"`                              // Currency the bet was placed in; empty for a bet in the base currency
+	ExchangeRate      float64       `gorm:"column:exchange_rate;not null;default:0"`                          // Base currency units per unit of Currency at the time of the spin; 0 without a conversion
+	OriginalBetAmount float64       `gorm:"column:original_bet_amount;not null;default:0"`                    // The bet in Currency, converted into BetAmount
+	OriginalWinAmount float64       `gorm:"column:original_win_amount;not null;default:0"`                    // WinAmount converted into Currency
+	PrevHash          string        `gorm:"column:prev_hash;not null;default:''"`                             // Hash of the user's previous spin; empty for the first spin of the chain or without the chain
+	Hash              string        `gorm:"column:hash;not null;default:''"`                                  // Hash over the key fields of the spin and PrevHash; empty without the chain
+	FreeSpinsAwarded  int           `gorm:"column:free_spins_awarded;not null;default:0"`                     // Free spins awarded by the scatters of the spin, on top of its payout
+	Bonuses           []string      `gorm:"-"`                                                                // Bonus features triggered by the spin; only set on the spin result
+	Wins              []LineWin     `gorm:"-"`                                                                // Paying combinations of the spin; only set on the spin result
+	Streak            int           `gorm:"-"`                                                                // Consecutive wins of the user including this spin; only set on the spin result
+	Balance           *float64      `gorm:"-"`                                                                // Balance of the user after the spin; only set on the spin result
+	ShouldStop        bool          `gorm:"-"`                                                                // Whether the win exceeded the user's auto-stop threshold; only set on the spin result
+	BigWin            bool          `gorm:"-"`                                                                // Whether the win to bet ratio exceeded the big win multiplier; only set on the spin result
+	Jackpot           float64       `gorm:"-"`                                                                // Jackpot paid on top of the line wins, included in WinAmount; only set on the spin result
+	RealityCheck      *RealityCheck `gorm:"-"`                                                                // Reality check due after the spin; nil if none is due, only set on the spin result
+	User              User          `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"` // Association to the User, with update and delete constraints
 }
 
 // TableName sets the table name for the Spin model explicitly.
 func (Spin) TableName() string {
 	return "spins"
 }
+
+// WinMultiplier returns the ratio of the amount won to the amount bet, or 0 without a bet.
+func (s *Spin) WinMultiplier() float64 {
+	if s.BetAmount <= 0 {
+		return 0
+	}
+	return s.WinAmount / s.BetAmount
+}
+
+// ComputeHash returns the SHA-256 hash, hex encoded, over the key fields of the spin and PrevHash.
+// The amounts are formatted with the two decimals they are stored with and the creation time in
+// UTC with the microseconds the database keeps, so a spin read back hashes as it did on insert.
+// Voiding a spin does not change its hash.
+func (s *Spin) ComputeHash() string {
+	fields := []string{
+		strconv.FormatUint(uint64(s.UserID), 10),
+		s.GameID,
+		strconv.FormatFloat(s.BetAmount, 'f', 2, 64),
+		strconv.FormatFloat(s.WinAmount, 'f', 2, 64),
+		strconv.FormatFloat(s.RawWinAmount, 'f', 2, 64),
+		strconv.FormatBool(s.WinCapped),
+		strings.Join(s.Reels, ","),
+		s.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		s.PrevHash,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Chain links the spin to the previous spin of the user, setting PrevHash and the resulting Hash.
+// CreatedAt must be set beforehand, as it is part of the hash.
+func (s *Spin) Chain(prevHash string) {
+	s.PrevHash = prevHash
+	s.Hash = s.ComputeHash()
+}
+
+// Voided reports whether the spin has been voided.
+func (s *Spin) Voided() bool {
+	return s.VoidedAt != nil
+}
+
+// SpinChainVerification is the result of walking the hash chain of a user's spins.
+type SpinChainVerification struct {
+	Checked  int    // Number of chained spins checked, up to and including the first broken one
+	BrokenAt *uint  // ID of the first spin whose hash or link to the previous spin does not match; nil if the chain is intact
+	LastHash string // Hash of the last spin checked; empty if no spin was checked
+}
+
+// Valid reports whether every spin checked matched its hash and linked to the previous spin.
+func (v *SpinChainVerification) Valid() bool {
+	return v.BrokenAt == nil
+}
+
+// BulkSpin is the result of a batch of spins played with a single request. Each spin is settled
+// in its own transaction; the batch ends early when a spin cannot be played, keeping the spins
+// played before.
+type BulkSpin struct {
+	Requested    int           // The number of spins requested
+	Spins        []*Spin       // The spins played, in order
+	Stopped      error         // The error that ended the batch before all spins were played; nil if none did
+	RealityCheck *RealityCheck // Reality check due after the batch; nil if none is due
+}
+
+// TotalWagered returns the sum of the bets of the spins played.
+func (b *BulkSpin) TotalWagered() float64 {
+	total := 0.0
+	for _, spin := range b.Spins {
+		total += spin.BetAmount
+	}
+	return total
+}
+
+// TotalWon returns the sum of the wins of the spins played.
+func (b *BulkSpin) TotalWon() float64 {
+	total := 0.0
+	for _, spin := range b.Spins {
+		total += spin.WinAmount
+	}
+	return total
+}
+
+// Balance returns the balance of the user after the last spin played, or nil if none was played.
+func (b *BulkSpin) Balance() *float64 {
+	if len(b.Spins) == 0 {
+		return nil
+	}
+	return b.Spins[len(b.Spins)-1].Balance
+}
@@ -0,0 +1,24 @@
+package models
+
+// SpinStats represents the aggregated play statistics of a single user.
+// It is not backed by a table; it is produced by aggregating the user's spins, voided spins excluded.
+type SpinStats struct {
+	TotalSpins   int64   `gorm:"column:total_spins"`   // Number of spins played
+	TotalWagered float64 `gorm:"column:total_wagered"` // Sum of all bet amounts
+	TotalWon     float64 `gorm:"column:total_won"`     // Sum of all win amounts
+	BiggestWin   float64 `gorm:"column:biggest_win"`   // Largest win amount of a single spin
+	WinningSpins int64   `gorm:"column:winning_spins"` // Number of spins with a win
+}
+
+// Net returns the user's total winnings minus their total bets.
+func (s *SpinStats) Net() float64 {
+	return s.TotalWon - s.TotalWagered
+}
+
+// WinRate returns the share of spins with a win, between 0 and 1; 0 if no spin was played.
+func (s *SpinStats) WinRate() float64 {
+	if s.TotalSpins == 0 {
+		return 0
+	}
+	return float64(s.WinningSpins) / float64(s.TotalSpins)
+}
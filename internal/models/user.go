@@ -1,21 +1,126 @@
 package models
 
 import (
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 )
 
 // User represents a registered user in the system, storing essential
 // account details such as login credentials, balance, and unique identifiers.
+// The auto-stop threshold is stored since migration 000015, the self-exclusion since migration 000019,
+// the deposit limits since migration 000022 and the free spins since migration 000024. Logins are stored normalized, see NormalizeLogin.
 type User struct {
 	gorm.Model
-	ExternalID *uuid.UUID `gorm:"column:external_id;type:uuid;default:uuid_generate_v4();unique;not null"` // Unique UUID for external identification
-	Login      string     `gorm:"column:login;unique;not null"`                                            // Unique login name for the user
-	Password   string     `gorm:"column:password;not null"`                                                // User's hashed password
-	Balance    float64    `gorm:"column:balance;default:null"`                                             // User's current wallet balance
+	ExternalID                        *uuid.UUID `gorm:"column:external_id;type:uuid;default:uuid_generate_v4();unique;not null"` // Unique UUID for external identification
+	Login                             string     `gorm:"column:login;unique;not null"`                                            // Unique login name for the user
+	Password                          string     `gorm:"column:password;not null"`                                                // User's hashed password
+	Balance                           float64    `gorm:"column:balance;not null;default:0"`                                       // User's current wallet balance
+	IsAdmin                           bool       `gorm:"column:is_admin;not null"`                                                // Whether the user may use the admin endpoints
+	AutoStopWin                       *float64   `gorm:"column:auto_stop_win"`                                                    // Win above which the client is told to stop; nil uses the configured default, 0 disables it
+	ExcludedUntil                     *time.Time `gorm:"column:excluded_until"`                                                   // End of the user's self-exclusion; nil if the user never excluded themselves
+	DepositLimitPerTransaction        *float64   `gorm:"column:deposit_limit_per_transaction"`                                    // Largest single deposit; nil leaves single deposits unlimited
+	DepositLimitDaily                 *float64   `gorm:"column:deposit_limit_daily"`                                              // Largest sum of deposits of a day; nil leaves it unlimited
+	PendingDepositLimitPerTransaction *float64   `gorm:"column:pending_deposit_limit_per_transaction"`                            // Per-transaction limit applying from DepositLimitsPendingAt
+	PendingDepositLimitDaily          *float64   `gorm:"column:pending_deposit_limit_daily"`                                      // Daily limit applying from DepositLimitsPendingAt
+	DepositLimitsPendingAt            *time.Time `gorm:"column:deposit_limits_pending_at"`                                        // Time the pending deposit limits apply from; nil if none are pending
+	FreeSpins                         int        `gorm:"column:free_spins;not null;default:0"`                                    // Free spins awarded to the user by scatters
+	Frozen                            bool       `gorm:"column:frozen;not null;default:false"`                                    // Whether an admin froze the account pending an investigation
+}
+
+// DepositLimits caps the deposits of a user. A nil limit leaves the deposits unlimited.
+type DepositLimits struct {
+	PerTransaction *float64 // Largest single deposit
+	Daily          *float64 // Largest sum of deposits of a day
+}
+
+// Looser reports whether any of the limits allows more than the corresponding limit of other,
+// either by being higher or by being removed.
+func (l DepositLimits) Looser(other DepositLimits) bool {
+	return looserLimit(l.PerTransaction, other.PerTransaction) || looserLimit(l.Daily, other.Daily)
+}
+
+// Tightest returns the lower of each pair of limits, treating a nil limit as unlimited.
+func (l DepositLimits) Tightest(other DepositLimits) DepositLimits {
+	return DepositLimits{PerTransaction: lowerLimit(l.PerTransaction, other.PerTransaction), Daily: lowerLimit(l.Daily, other.Daily)}
+}
+
+// looserLimit reports whether limit allows more than other, a nil limit being unlimited.
+func looserLimit(limit, other *float64) bool {
+	if other == nil {
+		return false
+	}
+	return limit == nil || *limit > *other
+}
+
+// lowerLimit returns the lower of the two limits, a nil limit being unlimited.
+func lowerLimit(limit, other *float64) *float64 {
+	if looserLimit(limit, other) {
+		return other
+	}
+	return limit
+}
+
+// DepositLimits returns the deposit limits of the user in effect at the given time: the pending
+// limits once their cooldown has passed, otherwise the current ones.
+func (u *User) DepositLimits(now time.Time) DepositLimits {
+	if u.DepositLimitsPendingAt != nil && !now.Before(*u.DepositLimitsPendingAt) {
+		return DepositLimits{PerTransaction: u.PendingDepositLimitPerTransaction, Daily: u.PendingDepositLimitDaily}
+	}
+	return DepositLimits{PerTransaction: u.DepositLimitPerTransaction, Daily: u.DepositLimitDaily}
+}
+
+// PendingDepositLimits returns the raised or removed deposit limits of the user still waiting out
+// their cooldown at the given time, or nil if there are none.
+func (u *User) PendingDepositLimits(now time.Time) *DepositLimits {
+	if u.DepositLimitsPendingAt == nil || !now.Before(*u.DepositLimitsPendingAt) {
+		return nil
+	}
+	return &DepositLimits{PerTransaction: u.PendingDepositLimitPerTransaction, Daily: u.PendingDepositLimitDaily}
+}
+
+// SelfExcluded reports whether the user's self-exclusion is still running at the given time.
+// The exclusion lifts by itself once its end has passed.
+func (u *User) SelfExcluded(now time.Time) bool {
+	return u.ExcludedUntil != nil && now.Before(*u.ExcludedUntil)
+}
+
+// AccountAge returns how long the account has existed at the given time.
+func (u *User) AccountAge(now time.Time) time.Duration {
+	return now.Sub(u.CreatedAt)
+}
+
+// AutoStopThreshold returns the win above which the user's client is told to stop spinning:
+// the user's own setting, or the given default when the user has none. 0 disables the auto-stop.
+func (u *User) AutoStopThreshold(defaultThreshold float64) float64 {
+	if u.AutoStopWin != nil {
+		return *u.AutoStopWin
+	}
+	return defaultThreshold
+}
+
+// Orders of the users listed to admins.
+const (
+	UserSortCreated = "created" // By the time the account was created
+	UserSortBalance = "balance" // By the balance in the base currency
+)
+
+// UserFilter selects and orders the users listed to admins.
+type UserFilter struct {
+	Search    string // Part of the login the users must contain; empty lists all users
+	SortBy    string // Order of the users, one of the UserSort constants; empty orders them by creation
+	Ascending bool   // Whether the users are listed in ascending instead of descending order
 }
 
 // TableName sets the table name for the User model explicitly.
 func (User) TableName() string {
 	return "users"
 }
+
+// NormalizeLogin trims the surrounding whitespace of a login and lowercases it, so that a login
+// typed with stray spaces or another capitalization still matches the account it belongs to.
+func NormalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
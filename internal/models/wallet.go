@@ -0,0 +1,23 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// Wallet holds the balance of a user in a currency other than the base currency.
+// The base currency balance is kept on the User itself; a user holds at most one wallet per currency.
+type Wallet struct {
+	gorm.Model
+	UserID   uint    `gorm:"column:user_id;not null"`           // Foreign key to the User model
+	Currency string  `gorm:"column:currency;not null"`          // ISO 4217 currency code, such as "EUR"
+	Balance  float64 `gorm:"column:balance;not null;default:0"` // Balance of the wallet in its currency
+}
+
+// TableName sets the table name for the Wallet model explicitly.
+func (Wallet) TableName() string {
+	return "wallets"
+}
+
+// Balance represents the balance of a user in a single currency.
+type Balance struct {
+	Currency string  // ISO 4217 currency code
+	Amount   float64 // Balance in the currency
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Withdrawal statuses tracking the approval of a withdrawal.
+const (
+	WithdrawalStatusPending  = "pending"  // Funds are held until an admin decides on the withdrawal
+	WithdrawalStatusApproved = "approved" // The withdrawal was paid out and the held funds are gone
+	WithdrawalStatusRejected = "rejected" // The withdrawal was refused and the held funds were returned
+)
+
+// PendingWithdrawal represents a withdrawal awaiting admin approval. Its amount is taken
+// from the user's balance when it is requested, so held funds can never be spent on spins.
+type PendingWithdrawal struct {
+	gorm.Model
+	UserID    uint       `gorm:"column:user_id;not null"` // Foreign key to the User model
+	Amount    float64    `gorm:"column:amount;not null"`  // Amount held for the withdrawal
+	Status    string     `gorm:"column:status;not null"`  // Approval state, one of the WithdrawalStatus constants
+	DecidedAt *time.Time `gorm:"column:decided_at"`       // Time the withdrawal was approved or rejected; nil while pending
+	Reason    string     `gorm:"column:reason"`           // Reason given for rejecting the withdrawal
+}
+
+// TableName sets the table name for the PendingWithdrawal model explicitly.
+func (PendingWithdrawal) TableName() string {
+	return "pending_withdrawals"
+}
+
+// Pending reports whether the withdrawal is still awaiting a decision.
+func (w *PendingWithdrawal) Pending() bool {
+	return w.Status == WithdrawalStatusPending
+}
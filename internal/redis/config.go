@@ -4,31 +4,73 @@ import "github.com/urfave/cli/v2"
 
 // Constants defining the Redis configuration flags.
 const (
-	redisURL = "redis-url"
+	redisURL         = "redis-url"
+	userCacheEnabled = "user-cache-enabled"
+	userCacheTTL     = "user-cache-ttl"
+	loginLockout     = "login-lockout-threshold"
+	loginLockoutTTL  = "login-lockout-window"
+	spinConcurrency  = "spin-concurrency-limit"
+	spinLockTTL      = "spin-lock-ttl"
+	demoSessionTTL   = "demo-session-ttl"
+	registrationTTL  = "registration-key-ttl"
+	winStreakTTL     = "win-streak-ttl"
+	sessionTimeout   = "session-timeout"
+	maxLoginSessions = "max-login-sessions"
+	rejectOverLimit  = "reject-logins-over-limit"
+	lastSpinTTL      = "last-spin-ttl"
 )
 
 // Config represents the configuration settings required to connect to the Redis server.
-// It includes a single field, URL, which specifies the Redis connection URL.
+// It includes the connection URL, the settings of the Redis-backed user cache,
+// of the failed login lockout, of the per-user spin concurrency guard, of demo sessions, of
+// registration idempotency keys, of win streaks, of game sessions, of the login session limit and
+// of the replay of the last spin.
 type Config struct {
-	URL string // The Redis connection URL
+	URL                   string // The Redis connection URL
+	UserCacheEnabled      bool   // Enable caching of user profile reads in Redis
+	UserCacheTTL          int    // Time-to-live of cached users in seconds
+	LoginLockoutThreshold int    // Failed login attempts before the account is locked; 0 disables the lockout
+	LoginLockoutWindow    int    // Window in seconds in which failed attempts are counted and the lock lasts
+	SpinConcurrencyLimit  int    // Maximum number of spins a user may have in flight; 0 disables the limit
+	SpinLockTTL           int    // Safety lifetime in seconds of the in-flight spin counters
+	DemoSessionTTL        int    // Lifetime in seconds of an idle demo session balance
+	RegistrationKeyTTL    int    // Lifetime in seconds of a registration idempotency key
+	WinStreakTTL          int    // Lifetime in seconds of a win streak without further spins
+	SessionTimeout        int    // Inactivity in seconds after which a game session ends
+	MaxLoginSessions      int    // Maximum number of active login sessions (JWT tokens) per user; 0 disables the limit
+	RejectLoginsOverLimit bool   // Reject logins over the limit instead of ending the oldest sessions
+	LastSpinTTL           int    // Lifetime in seconds of the last spin kept for replay; 0 disables the replay
 }
 
-// GetRedisConfig reads the Redis URL from the CLI context, allowing configuration via
+// GetRedisConfig reads the Redis settings from the CLI context, allowing configuration via
 // command-line arguments or environment variables.
 //
 // Parameters:
 //   - c (*cli.Context): The CLI context containing flag and environment variable values.
 //
 // Returns:
-//   - (*Config): A Config struct populated with the Redis URL.
+//   - (*Config): A Config struct populated with the Redis settings.
 func GetRedisConfig(c *cli.Context) *Config {
 	return &Config{
-		URL: c.String(redisURL),
+		URL:                   c.String(redisURL),
+		UserCacheEnabled:      c.Bool(userCacheEnabled),
+		UserCacheTTL:          c.Int(userCacheTTL),
+		LoginLockoutThreshold: c.Int(loginLockout),
+		LoginLockoutWindow:    c.Int(loginLockoutTTL),
+		SpinConcurrencyLimit:  c.Int(spinConcurrency),
+		SpinLockTTL:           c.Int(spinLockTTL),
+		DemoSessionTTL:        c.Int(demoSessionTTL),
+		RegistrationKeyTTL:    c.Int(registrationTTL),
+		WinStreakTTL:          c.Int(winStreakTTL),
+		SessionTimeout:        c.Int(sessionTimeout),
+		MaxLoginSessions:      c.Int(maxLoginSessions),
+		RejectLoginsOverLimit: c.Bool(rejectOverLimit),
+		LastSpinTTL:           c.Int(lastSpinTTL),
 	}
 }
 
 // Flags defines the CLI flags available for configuring the Redis connection.
-// These flags enable the URL to be set via command-line arguments or environment variables.
+// These flags enable the settings to be set via command-line arguments or environment variables.
 var Flags = []cli.Flag{
 	&cli.StringFlag{
 		Name:    redisURL,                   // The flag name
@@ -36,4 +78,81 @@ var Flags = []cli.Flag{
 		Usage:   "Redis connection URL",     // Description for usage instructions
 		EnvVars: []string{"REDIS_URL"},      // Environment variable to override the Redis URL
 	},
+	&cli.BoolFlag{
+		Name:    userCacheEnabled,
+		Value:   false,
+		Usage:   "Enable caching of user profile reads in Redis",
+		EnvVars: []string{"USER_CACHE_ENABLED"},
+	},
+	&cli.IntFlag{
+		Name:    userCacheTTL,
+		Value:   30,
+		Usage:   "Time-to-live of cached users in seconds",
+		EnvVars: []string{"USER_CACHE_TTL"},
+	},
+	&cli.IntFlag{
+		Name:    loginLockout,
+		Value:   5,
+		Usage:   "Failed login attempts before the account is temporarily locked; 0 disables the lockout",
+		EnvVars: []string{"LOGIN_LOCKOUT_THRESHOLD"},
+	},
+	&cli.IntFlag{
+		Name:    loginLockoutTTL,
+		Value:   900,
+		Usage:   "Window in seconds in which failed login attempts are counted and the lock lasts",
+		EnvVars: []string{"LOGIN_LOCKOUT_WINDOW"},
+	},
+	&cli.IntFlag{
+		Name:    spinConcurrency,
+		Value:   1,
+		Usage:   "Maximum number of spins a user may have in flight at once; 0 disables the limit",
+		EnvVars: []string{"SPIN_CONCURRENCY_LIMIT"},
+	},
+	&cli.IntFlag{
+		Name:    spinLockTTL,
+		Value:   30,
+		Usage:   "Safety lifetime in seconds of the in-flight spin locks",
+		EnvVars: []string{"SPIN_LOCK_TTL"},
+	},
+	&cli.IntFlag{
+		Name:    demoSessionTTL,
+		Value:   3600,
+		Usage:   "Lifetime in seconds of an idle demo session balance",
+		EnvVars: []string{"DEMO_SESSION_TTL"},
+	},
+	&cli.IntFlag{
+		Name:    registrationTTL,
+		Value:   86400,
+		Usage:   "Lifetime in seconds of a registration idempotency key, within which retried registrations are replayed",
+		EnvVars: []string{"REGISTRATION_KEY_TTL"},
+	},
+	&cli.IntFlag{
+		Name:    winStreakTTL,
+		Value:   86400,
+		Usage:   "Lifetime in seconds of a win streak without further spins; an expired streak starts over",
+		EnvVars: []string{"WIN_STREAK_TTL"},
+	},
+	&cli.IntFlag{
+		Name:    sessionTimeout,
+		Value:   1800,
+		Usage:   "Inactivity in seconds after which a game session ends; the next spin starts a new session",
+		EnvVars: []string{"SESSION_TIMEOUT"},
+	}, &cli.IntFlag{
+		Name:    maxLoginSessions,
+		Value:   0,
+		Usage:   "Maximum number of active login sessions per user; logging in again ends the oldest one. 0 disables the limit",
+		EnvVars: []string{"MAX_LOGIN_SESSIONS"},
+	},
+	&cli.BoolFlag{
+		Name:    rejectOverLimit,
+		Value:   false,
+		Usage:   "Reject logins over --max-login-sessions instead of ending the oldest session",
+		EnvVars: []string{"REJECT_LOGINS_OVER_LIMIT"},
+	},
+	&cli.IntFlag{
+		Name:    lastSpinTTL,
+		Value:   300,
+		Usage:   "Lifetime in seconds of a user's last spin kept for GET /api/slot/spin/last; 0 disables the replay",
+		EnvVars: []string{"LAST_SPIN_TTL"},
+	},
 }
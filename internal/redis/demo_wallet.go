@@ -0,0 +1,112 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// demoBalanceKeyPrefix is the key prefix of demo session balances in Redis.
+const demoBalanceKeyPrefix = "demo_balance:"
+
+// demoWithdrawScript deducts ARGV[1] from the balance and refreshes the session TTL.
+// Returns the updated balance, or false if the balance does not cover the amount.
+var demoWithdrawScript = libredis.NewScript(`
+local balance = tonumber(redis.call("GET", KEYS[1]) or "0")
+local amount = tonumber(ARGV[1])
+if balance < amount then
+	return false
+end
+local updated = redis.call("INCRBYFLOAT", KEYS[1], -amount)
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+return updated
+`)
+
+// demoWallet implements IDemoWallet, keeping each demo balance in a Redis key that
+// expires after the session has been idle for the configured TTL.
+type demoWallet struct {
+	client *libredis.Client // Redis client used for balance operations
+	ttl    time.Duration    // Lifetime of an idle demo session
+}
+
+// Balance returns the demo balance of the user, or nil if no session is active.
+func (w *demoWallet) Balance(ctx context.Context, userID *uuid.UUID) (*float64, error) {
+	balance, err := w.client.Get(ctx, demoBalanceKey(userID)).Float64()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// Reset starts a new demo session with the given balance.
+func (w *demoWallet) Reset(ctx context.Context, userID *uuid.UUID, balance float64) error {
+	return w.client.Set(ctx, demoBalanceKey(userID), balance, w.ttl).Err()
+}
+
+// Withdraw atomically deducts the amount, failing with ErrInsufficientFunds if the balance does not cover it.
+func (w *demoWallet) Withdraw(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
+	balance, err := demoWithdrawScript.Run(ctx, w.client, []string{demoBalanceKey(userID)}, amount, w.ttl.Milliseconds()).Float64()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return nil, serviceError.ErrInsufficientFunds
+		}
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// Deposit adds the amount to the demo balance and refreshes the session TTL.
+func (w *demoWallet) Deposit(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
+	key := demoBalanceKey(userID)
+	var incr *libredis.FloatCmd
+	_, err := w.client.TxPipelined(ctx, func(pipe libredis.Pipeliner) error {
+		incr = pipe.IncrByFloat(ctx, key, amount)
+		pipe.Expire(ctx, key, w.ttl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	balance := incr.Val()
+	return &balance, nil
+}
+
+// End deletes the demo balance in a single step, so that it cannot be spent while the session ends.
+func (w *demoWallet) End(ctx context.Context, userID *uuid.UUID) (*float64, error) {
+	balance, err := w.client.GetDel(ctx, demoBalanceKey(userID)).Float64()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// demoBalanceKey builds the Redis key of a user's demo balance.
+func demoBalanceKey(userID *uuid.UUID) string {
+	return demoBalanceKeyPrefix + userID.String()
+}
+
+// NewDemoWallet creates a Redis-backed IDemoWallet using the demo session TTL from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the demo session TTL.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.IDemoWallet): The demo wallet implementation.
+func NewDemoWallet(cfg *Config, client *libredis.Client) interfaces.IDemoWallet {
+	return &demoWallet{
+		client: client,
+		ttl:    time.Duration(cfg.DemoSessionTTL) * time.Second,
+	}
+}
@@ -1,20 +1,42 @@
 package redis
 
 import (
+	"context"
+	"time"
+
+	log "github.com/public-forge/go-logger"
 	libredis "github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
 )
 
+// pingTimeout bounds the connectivity check performed when the client is created.
+const pingTimeout = 2 * time.Second
+
 // Module provides the Redis client as an Fx module, enabling dependency injection
-// for applications that require Redis as a data store.
+// for applications that require Redis as a data store. It also provides the
+// Redis-backed stores built on top of the client.
 var Module = fx.Options(
 	fx.Provide(NewRedisClient),
+	fx.Provide(NewUserCache),
+	fx.Provide(NewLoginAttemptStore),
+	fx.Provide(NewSpinLock),
+	fx.Provide(NewDemoWallet),
+	fx.Provide(NewRegistrationKeyStore),
+	fx.Provide(NewWinStreakStore),
+	fx.Provide(NewReportQueue),
+	fx.Provide(NewSessionStore),
+	fx.Provide(NewDailySpinCounter),
+	fx.Provide(NewMaintenanceStore),
+	fx.Provide(NewJackpotStore),
+	fx.Provide(NewTokenStore),
+	fx.Provide(NewLastSpinStore),
 )
 
 // NewRedisClient initializes and returns a new Redis client instance configured with
 // the provided Redis server URL from Config. This function parses the URL, creates
-// a Redis client using the go-redis library, and ensures that the client can connect
-// to the specified Redis server.
+// a Redis client using the go-redis library, and checks that the client can connect
+// to the specified Redis server. An unreachable server is only logged as a warning: the
+// Redis-backed features degrade until it recovers instead of preventing the startup.
 //
 // Parameters:
 //   - cfg (*Config): The configuration struct containing the Redis server URL.
@@ -28,5 +50,11 @@ func NewRedisClient(cfg *Config) (*libredis.Client, error) {
 		return nil, err
 	}
 	client := libredis.NewClient(option)
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.FromContext(ctx).Warnf("redis at %s is unreachable, continuing in degraded mode: %v", option.Addr, err)
+	}
 	return client, nil
 }
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// sessionKeyPrefix is the key prefix of the active game sessions in Redis.
+const sessionKeyPrefix = "game_session:"
+
+// sessionStore implements ISessionStore, keeping the active session of each user in a Redis
+// key that expires once the session has not been extended for the session timeout.
+type sessionStore struct {
+	client  *libredis.Client // Redis client used for session operations
+	timeout time.Duration    // Inactivity after which a session ends
+}
+
+// Get returns the active session of the user, or 0 if none is recorded.
+func (s *sessionStore) Get(ctx context.Context, userID *uuid.UUID) (uint, error) {
+	sessionID, err := s.client.Get(ctx, sessionKey(userID)).Uint64()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return uint(sessionID), nil
+}
+
+// Set stores the active session of the user and refreshes its TTL.
+func (s *sessionStore) Set(ctx context.Context, userID *uuid.UUID, sessionID uint) error {
+	return s.client.Set(ctx, sessionKey(userID), sessionID, s.timeout).Err()
+}
+
+// Delete removes the active session of the user.
+func (s *sessionStore) Delete(ctx context.Context, userID *uuid.UUID) error {
+	return s.client.Del(ctx, sessionKey(userID)).Err()
+}
+
+// sessionKey builds the Redis key of a user's active game session.
+func sessionKey(userID *uuid.UUID) string {
+	return sessionKeyPrefix + userID.String()
+}
+
+// NewSessionStore creates a Redis-backed ISessionStore using the session timeout from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the session timeout.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.ISessionStore): The game session store implementation.
+func NewSessionStore(cfg *Config, client *libredis.Client) interfaces.ISessionStore {
+	return &sessionStore{
+		client:  client,
+		timeout: time.Duration(cfg.SessionTimeout) * time.Second,
+	}
+}
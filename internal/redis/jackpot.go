@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// jackpotKey is the Redis key of the jackpot pool shared by all instances.
+const jackpotKey = "jackpot:pool"
+
+// jackpotHitScript takes the whole pool and resets it in one step, so that concurrent hits on
+// different instances never pay the same contributions twice. Returns the pool as a string, as
+// Redis truncates Lua numbers to integers, or false if the pool holds no contributions.
+var jackpotHitScript = libredis.NewScript(`
+local pool = redis.call("GET", KEYS[1])
+if not pool or tonumber(pool) <= 0 then
+	return false
+end
+redis.call("DEL", KEYS[1])
+return pool
+`)
+
+// jackpotStore implements IJackpotStore, keeping the contributions to the jackpot in a single
+// Redis key updated with atomic commands only.
+type jackpotStore struct {
+	client libredis.Cmdable // Redis client used for pool operations
+}
+
+// Contribute atomically adds the amount to the pool with INCRBYFLOAT and returns the new pool.
+func (s *jackpotStore) Contribute(ctx context.Context, amount float64) (float64, error) {
+	return s.client.IncrByFloat(ctx, jackpotKey, amount).Result()
+}
+
+// Hit atomically takes the contributions of the pool and resets it, returning 0 if it was empty.
+func (s *jackpotStore) Hit(ctx context.Context) (float64, error) {
+	pool, err := jackpotHitScript.Run(ctx, s.client, []string{jackpotKey}).Float64()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return pool, nil
+}
+
+// NewJackpotStore creates a Redis-backed IJackpotStore.
+//
+// Parameters:
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.IJackpotStore): The jackpot store implementation.
+func NewJackpotStore(client *libredis.Client) interfaces.IJackpotStore {
+	return &jackpotStore{client: client}
+}
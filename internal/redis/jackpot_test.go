@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// atomicRedis is an in-memory stand-in for the jackpot key in Redis. Like the single-threaded
+// Redis server, it runs every command and script on its own. Only the commands of the jackpot
+// store are supported.
+type atomicRedis struct {
+	libredis.Cmdable
+	mu   sync.Mutex
+	pool *float64 // Value of the jackpot key; nil while the key does not exist
+}
+
+func (r *atomicRedis) IncrByFloat(_ context.Context, _ string, value float64) *libredis.FloatCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pool := value
+	if r.pool != nil {
+		pool += *r.pool
+	}
+	r.pool = &pool
+	return libredis.NewFloatResult(pool, nil)
+}
+
+// EvalSha runs the jackpot hit script.
+func (r *atomicRedis) EvalSha(_ context.Context, sha1 string, _ []string, _ ...interface{}) *libredis.Cmd {
+	if sha1 != jackpotHitScript.Hash() {
+		return libredis.NewCmdResult(nil, libredis.ErrClosed)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pool == nil || *r.pool <= 0 {
+		return libredis.NewCmdResult(nil, libredis.Nil)
+	}
+	pool := strconv.FormatFloat(*r.pool, 'f', -1, 64)
+	r.pool = nil
+	return libredis.NewCmdResult(pool, nil)
+}
+
+func TestJackpotStore_HitTakesPool(t *testing.T) {
+	ctx := context.Background()
+	store := &jackpotStore{client: &atomicRedis{}}
+
+	pool, err := store.Hit(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, pool, "an empty pool pays nothing")
+
+	_, err = store.Contribute(ctx, 2.5)
+	require.NoError(t, err)
+	pool, err = store.Contribute(ctx, 0.25)
+	require.NoError(t, err)
+	assert.Equal(t, 2.75, pool)
+
+	pool, err = store.Hit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2.75, pool)
+	pool, err = store.Hit(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, pool, "a hit resets the pool")
+}
+
+func TestJackpotStore_ConcurrentHitsPayEachContributionOnce(t *testing.T) {
+	ctx := context.Background()
+	store := &jackpotStore{client: &atomicRedis{}}
+	const spins, contribution = 1000, 0.25
+
+	// Every tenth spin hits the jackpot while the others keep contributing
+	var mu sync.Mutex
+	paid := 0.0
+	var wg sync.WaitGroup
+	for i := 0; i < spins; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := store.Contribute(ctx, contribution)
+			assert.NoError(t, err)
+			if i%10 == 0 {
+				pool, err := store.Hit(ctx)
+				assert.NoError(t, err)
+				mu.Lock()
+				paid += pool
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	left, err := store.Hit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, spins*contribution, paid+left, "every contribution is paid exactly once")
+}
@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// lastSpinKeyPrefix is the key prefix of the last spins in Redis.
+const lastSpinKeyPrefix = "last_spin:"
+
+// lastSpinStore implements ILastSpinStore, keeping the last spin of each user as a JSON document
+// in a Redis key that expires after the configured TTL.
+type lastSpinStore struct {
+	client libredis.Cmdable // Redis client used for the last spins
+	ttl    time.Duration    // Lifetime of a last spin
+}
+
+// Get returns the last spin of the user, or nil if it has expired or the user has not spun.
+func (s *lastSpinStore) Get(ctx context.Context, userID *uuid.UUID) (*models.Spin, error) {
+	data, err := s.client.Get(ctx, lastSpinKey(userID)).Bytes()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	spin := &models.Spin{}
+	if err := json.Unmarshal(data, spin); err != nil {
+		return nil, err
+	}
+	return spin, nil
+}
+
+// Set stores the spin as the last spin of the user for the TTL.
+func (s *lastSpinStore) Set(ctx context.Context, userID *uuid.UUID, spin *models.Spin) error {
+	data, err := json.Marshal(spin)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, lastSpinKey(userID), data, s.ttl).Err()
+}
+
+// lastSpinKey builds the Redis key of a user's last spin.
+func lastSpinKey(userID *uuid.UUID) string {
+	return lastSpinKeyPrefix + userID.String()
+}
+
+// NewLastSpinStore creates a Redis-backed ILastSpinStore using the last spin TTL from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the last spin TTL.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.ILastSpinStore): The last spin store implementation, or nil if the replay is disabled.
+func NewLastSpinStore(cfg *Config, client *libredis.Client) interfaces.ILastSpinStore {
+	if cfg.LastSpinTTL <= 0 {
+		return nil
+	}
+	return &lastSpinStore{
+		client: client,
+		ttl:    time.Duration(cfg.LastSpinTTL) * time.Second,
+	}
+}
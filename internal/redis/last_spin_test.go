@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// expiringRedis is an in-memory stand-in for plain Redis keys with a TTL. Only GET and SET are
+// supported.
+type expiringRedis struct {
+	libredis.Cmdable
+	values    map[string][]byte    // Stored values, keyed by key
+	expiresAt map[string]time.Time // Expiry of the stored values, keyed by key
+	now       time.Time            // Current time deciding the expiry
+}
+
+func (r *expiringRedis) Get(_ context.Context, key string) *libredis.StringCmd {
+	value, ok := r.values[key]
+	if !ok || !r.now.Before(r.expiresAt[key]) {
+		return libredis.NewStringResult("", libredis.Nil)
+	}
+	return libredis.NewStringResult(string(value), nil)
+}
+
+func (r *expiringRedis) Set(_ context.Context, key string, value interface{}, expiration time.Duration) *libredis.StatusCmd {
+	r.values[key] = value.([]byte)
+	r.expiresAt[key] = r.now.Add(expiration)
+	return libredis.NewStatusResult("OK", nil)
+}
+
+func TestLastSpinStore_ReplaysUntilExpiry(t *testing.T) {
+	ctx := context.Background()
+	client := &expiringRedis{
+		values:    map[string][]byte{},
+		expiresAt: map[string]time.Time{},
+		now:       time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+	}
+	store := &lastSpinStore{client: client, ttl: time.Minute}
+	userID := uuid.New()
+	balance := 95.0
+	spin := &models.Spin{
+		Model:     gorm.Model{ID: 7},
+		BetAmount: 10,
+		WinAmount: 5,
+		Reels:     models.Reels{"A", "A", "B"},
+		Balance:   &balance,
+	}
+
+	last, err := store.Get(ctx, &userID)
+	require.NoError(t, err)
+	assert.Nil(t, last, "no spin yet")
+
+	require.NoError(t, store.Set(ctx, &userID, spin))
+	client.now = client.now.Add(59 * time.Second)
+	last, err = store.Get(ctx, &userID)
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, uint(7), last.ID)
+	assert.Equal(t, spin.Reels, last.Reels)
+	assert.Equal(t, 95.0, *last.Balance)
+
+	client.now = client.now.Add(time.Second)
+	last, err = store.Get(ctx, &userID)
+	require.NoError(t, err)
+	assert.Nil(t, last, "the spin has expired")
+}
@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// Key prefixes for login attempt tracking in Redis.
+const (
+	loginAttemptsKeyPrefix = "login_attempts:"
+	loginNonceKeyPrefix    = "login_nonce:"
+)
+
+// loginAttemptStore implements ILoginAttemptStore on top of Redis counters that
+// expire at the end of the lockout window.
+type loginAttemptStore struct {
+	client *libredis.Client // Redis client used for counter operations
+	window time.Duration    // Lifetime of recorded attempts and nonces
+}
+
+// Count returns the number of failed attempts recorded for the login.
+func (s *loginAttemptStore) Count(ctx context.Context, login string) (int, error) {
+	count, err := s.client.Get(ctx, loginAttemptsKeyPrefix+login).Int()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return count, nil
+}
+
+// Increment records a failed attempt. The key expiry is only set by the first attempt,
+// so the window is not extended by further failures.
+func (s *loginAttemptStore) Increment(ctx context.Context, login string) (int, error) {
+	key := loginAttemptsKeyPrefix + login
+	var incr *libredis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe libredis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, s.window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(incr.Val()), nil
+}
+
+// Reset removes the recorded attempts for the login.
+func (s *loginAttemptStore) Reset(ctx context.Context, login string) error {
+	return s.client.Del(ctx, loginAttemptsKeyPrefix+login).Err()
+}
+
+// UseNonce stores the nonce for the window, reporting whether it was stored for the first time.
+func (s *loginAttemptStore) UseNonce(ctx context.Context, nonce string) (bool, error) {
+	return s.client.SetNX(ctx, loginNonceKeyPrefix+nonce, 1, s.window).Result()
+}
+
+// NewLoginAttemptStore creates a Redis-backed ILoginAttemptStore using the lockout window from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the lockout window.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.ILoginAttemptStore): The login attempt store implementation.
+func NewLoginAttemptStore(cfg *Config, client *libredis.Client) interfaces.ILoginAttemptStore {
+	return &loginAttemptStore{
+		client: client,
+		window: time.Duration(cfg.LoginLockoutWindow) * time.Second,
+	}
+}
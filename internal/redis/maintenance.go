@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// maintenanceKey is the Redis key of the maintenance mode toggle. The key only exists while the
+// toggle is on.
+const maintenanceKey = "maintenance"
+
+// maintenanceStore implements IMaintenanceStore, keeping the maintenance mode toggle in Redis so
+// that switching it on one instance takes effect on all of them.
+type maintenanceStore struct {
+	client *libredis.Client // Redis client used for toggle operations
+}
+
+// Enabled reports whether the maintenance mode toggle is on.
+func (s *maintenanceStore) Enabled(ctx context.Context) (bool, error) {
+	err := s.client.Get(ctx, maintenanceKey).Err()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// SetEnabled switches the maintenance mode toggle on or off. The toggle does not expire, so
+// maintenance mode lasts until it is switched off.
+func (s *maintenanceStore) SetEnabled(ctx context.Context, enabled bool) error {
+	if !enabled {
+		return s.client.Del(ctx, maintenanceKey).Err()
+	}
+	return s.client.Set(ctx, maintenanceKey, 1, 0).Err()
+}
+
+// NewMaintenanceStore creates a Redis-backed IMaintenanceStore.
+//
+// Parameters:
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.IMaintenanceStore): The maintenance mode toggle implementation.
+func NewMaintenanceStore(client *libredis.Client) interfaces.IMaintenanceStore {
+	return &maintenanceStore{client: client}
+}
@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// registrationKeyPrefix is the key prefix of the registration idempotency keys in Redis.
+const registrationKeyPrefix = "registration_key:"
+
+// registrationKeyStore implements IRegistrationKeyStore on top of Redis, storing the
+// registrations as JSON documents that expire after a fixed time-to-live.
+type registrationKeyStore struct {
+	client *libredis.Client // Redis client used for key operations
+	ttl    time.Duration    // Lifetime of the stored keys
+}
+
+// Get returns the registration stored for the key. An unused key is reported as (nil, nil).
+func (s *registrationKeyStore) Get(ctx context.Context, key string) (*models.RegistrationKey, error) {
+	data, err := s.client.Get(ctx, registrationKeyPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	registration := &models.RegistrationKey{}
+	if err := json.Unmarshal(data, registration); err != nil {
+		return nil, err
+	}
+	return registration, nil
+}
+
+// Save stores the registration for the key unless the key is already in use.
+func (s *registrationKeyStore) Save(ctx context.Context, key string, registration *models.RegistrationKey) (bool, error) {
+	data, err := json.Marshal(registration)
+	if err != nil {
+		return false, err
+	}
+	return s.client.SetNX(ctx, registrationKeyPrefix+key, data, s.ttl).Result()
+}
+
+// NewRegistrationKeyStore creates a Redis-backed IRegistrationKeyStore using the key TTL from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the registration key TTL.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.IRegistrationKeyStore): The registration key store implementation.
+func NewRegistrationKeyStore(cfg *Config, client *libredis.Client) interfaces.IRegistrationKeyStore {
+	return &registrationKeyStore{
+		client: client,
+		ttl:    time.Duration(cfg.RegistrationKeyTTL) * time.Second,
+	}
+}
@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// Keys of the spin report queue in Redis.
+const (
+	reportQueueKey    = "spin_reports:due"      // Sorted set of report IDs scored by the time they are due, in Unix milliseconds
+	reportPayloadsKey = "spin_reports:payloads" // Hash of the JSON-encoded reports keyed by report ID
+)
+
+// claimReports returns the payloads of the due reports and postpones them by the lease in one step,
+// so that concurrent workers never claim the same report. IDs without a payload are dropped.
+//
+// KEYS[1] is the sorted set, KEYS[2] the payload hash; ARGV holds the current time and the end of
+// the lease in Unix milliseconds and the maximum number of reports to claim.
+var claimReports = libredis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[3])
+local payloads = {}
+for _, id in ipairs(ids) do
+	local payload = redis.call('HGET', KEYS[2], id)
+	if payload then
+		redis.call('ZADD', KEYS[1], ARGV[2], id)
+		table.insert(payloads, payload)
+	else
+		redis.call('ZREM', KEYS[1], id)
+	end
+end
+return payloads
+`)
+
+// reportQueue implements IReportQueue on top of Redis. Reports are kept in a hash and scheduled in a
+// sorted set, so that a report survives restarts until its delivery is acknowledged.
+type reportQueue struct {
+	client *libredis.Client // Redis client used for queue operations
+}
+
+// Enqueue stores the report and schedules it for delivery right away.
+func (q *reportQueue) Enqueue(ctx context.Context, report *models.SpinReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	_, err = q.client.TxPipelined(ctx, func(pipe libredis.Pipeliner) error {
+		pipe.HSet(ctx, reportPayloadsKey, report.ID, data)
+		pipe.ZAdd(ctx, reportQueueKey, libredis.Z{Score: float64(time.Now().UnixMilli()), Member: report.ID})
+		return nil
+	})
+	return err
+}
+
+// Claim returns the due reports and postpones them by the lease.
+func (q *reportQueue) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.SpinReport, error) {
+	payloads, err := claimReports.Run(ctx, q.client, []string{reportQueueKey, reportPayloadsKey},
+		now.UnixMilli(), now.Add(lease).UnixMilli(), limit).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*models.SpinReport, 0, len(payloads))
+	for _, payload := range payloads {
+		report := &models.SpinReport{}
+		if err := json.Unmarshal([]byte(payload), report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Ack removes a delivered report.
+func (q *reportQueue) Ack(ctx context.Context, id string) error {
+	_, err := q.client.TxPipelined(ctx, func(pipe libredis.Pipeliner) error {
+		pipe.ZRem(ctx, reportQueueKey, id)
+		pipe.HDel(ctx, reportPayloadsKey, id)
+		return nil
+	})
+	return err
+}
+
+// NewReportQueue creates a Redis-backed IReportQueue.
+//
+// Parameters:
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.IReportQueue): The spin report queue implementation.
+func NewReportQueue(client *libredis.Client) interfaces.IReportQueue {
+	return &reportQueue{client: client}
+}
@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+const (
+	// spinCountKeyPrefix is the key prefix of the daily spin counts in Redis.
+	spinCountKeyPrefix = "spin_count:"
+	// spinCountTTL keeps a day's count for a while after the day ended, long enough for any time
+	// zone and reset hour, after which it expires on its own.
+	spinCountTTL = 48 * time.Hour
+)
+
+// dailySpinCounter implements IDailySpinCounter, keeping the spins of each user and day in a
+// Redis counter.
+type dailySpinCounter struct {
+	client *libredis.Client // Redis client used for counter operations
+}
+
+// Count returns the number of spins the user made on the day, or 0 if none was counted.
+func (c *dailySpinCounter) Count(ctx context.Context, userID *uuid.UUID, day string) (int, error) {
+	count, err := c.client.Get(ctx, spinCountKey(userID, day)).Int()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return count, nil
+}
+
+// Increment counts another spin of the user on the day and returns the new count. The key expiry
+// is only set by the first spin of the day.
+func (c *dailySpinCounter) Increment(ctx context.Context, userID *uuid.UUID, day string) (int, error) {
+	key := spinCountKey(userID, day)
+	var incr *libredis.IntCmd
+	_, err := c.client.TxPipelined(ctx, func(pipe libredis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, spinCountTTL)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(incr.Val()), nil
+}
+
+// spinCountKey builds the Redis key of a user's spin count on a day.
+func spinCountKey(userID *uuid.UUID, day string) string {
+	return spinCountKeyPrefix + userID.String() + ":" + day
+}
+
+// NewDailySpinCounter creates a Redis-backed IDailySpinCounter.
+//
+// Parameters:
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.IDailySpinCounter): The daily spin counter implementation.
+func NewDailySpinCounter(client *libredis.Client) interfaces.IDailySpinCounter {
+	return &dailySpinCounter{client: client}
+}
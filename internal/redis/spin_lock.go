@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// spinLockKeyPrefix is the key prefix of the in-flight spin counters in Redis.
+const spinLockKeyPrefix = "spin_lock:"
+
+// acquireScript increments the in-flight counter and refreshes its safety TTL, undoing
+// the increment when the limit is exceeded. Returns 1 if the slot was reserved.
+var acquireScript = libredis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+if count > tonumber(ARGV[1]) then
+	redis.call("DECR", KEYS[1])
+	return 0
+end
+return 1
+`)
+
+// releaseScript decrements the in-flight counter, removing it once no spin is left in flight.
+var releaseScript = libredis.NewScript(`
+local count = redis.call("DECR", KEYS[1])
+if count <= 0 then
+	redis.call("DEL", KEYS[1])
+end
+return count
+`)
+
+// spinLock implements ISpinLock on top of per-user Redis counters. The counters expire after
+// the safety TTL, so slots held by a crashed instance are eventually freed.
+type spinLock struct {
+	client *libredis.Client // Redis client used for counter operations
+	limit  int              // Maximum number of spins in flight per user; 0 disables the limit
+	ttl    time.Duration    // Safety lifetime of the counters
+}
+
+// Acquire reserves an in-flight spin slot for the user, always succeeding when the limit is disabled.
+func (l *spinLock) Acquire(ctx context.Context, userID *uuid.UUID) (bool, error) {
+	if l.limit <= 0 {
+		return true, nil
+	}
+	reserved, err := acquireScript.Run(ctx, l.client, []string{spinLockKeyPrefix + userID.String()}, l.limit, l.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return reserved == 1, nil
+}
+
+// Release frees a spin slot of the user.
+func (l *spinLock) Release(ctx context.Context, userID *uuid.UUID) error {
+	if l.limit <= 0 {
+		return nil
+	}
+	return releaseScript.Run(ctx, l.client, []string{spinLockKeyPrefix + userID.String()}).Err()
+}
+
+// NewSpinLock creates a Redis-backed ISpinLock using the concurrency limit and safety TTL from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the spin concurrency settings.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.ISpinLock): The spin lock implementation.
+func NewSpinLock(cfg *Config, client *libredis.Client) interfaces.ISpinLock {
+	return &spinLock{
+		client: client,
+		limit:  cfg.SpinConcurrencyLimit,
+		ttl:    time.Duration(cfg.SpinLockTTL) * time.Second,
+	}
+}
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// tokenKeyPrefix is the key prefix of the sorted sets of active tokens in Redis. Each set holds
+// the IDs of a user's tokens scored by their expiry in milliseconds.
+const tokenKeyPrefix = "auth_tokens:"
+
+// addTokenScript drops the expired tokens of the user and records the new token. When the user
+// already has the maximum number of tokens, the oldest ones are removed if ARGV[4] is 1; otherwise
+// the token is not recorded. The set expires with the new token, which is the last one to expire.
+// Returns 1 if the token was recorded.
+var addTokenScript = libredis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+local limit = tonumber(ARGV[3])
+local count = redis.call("ZCARD", KEYS[1])
+if count >= limit then
+	if ARGV[4] ~= "1" then
+		return 0
+	end
+	redis.call("ZPOPMIN", KEYS[1], count - limit + 1)
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[5])
+redis.call("PEXPIREAT", KEYS[1], ARGV[2])
+return 1
+`)
+
+// tokenStore implements ITokenStore on top of per-user Redis sorted sets. All tokens share the
+// same lifetime, so the tokens expiring first are the oldest ones.
+type tokenStore struct {
+	client      libredis.Cmdable // Redis client used for the token sets
+	limit       int              // Maximum number of active tokens per user; 0 disables the limit
+	evictOldest bool             // Remove the oldest tokens instead of rejecting new logins over the limit
+	now         func() time.Time // Current time, replaceable in tests
+}
+
+// Add records the token until its expiry, always succeeding without recording it when the limit is disabled.
+func (s *tokenStore) Add(ctx context.Context, userID *uuid.UUID, tokenID string, ttl time.Duration) (bool, error) {
+	if s.limit <= 0 {
+		return true, nil
+	}
+	now := s.now()
+	evict := 0
+	if s.evictOldest {
+		evict = 1
+	}
+	added, err := addTokenScript.Run(ctx, s.client, []string{tokenKeyPrefix + userID.String()},
+		now.UnixMilli(), now.Add(ttl).UnixMilli(), s.limit, evict, tokenID).Int()
+	if err != nil {
+		return false, err
+	}
+	return added == 1, nil
+}
+
+// Active reports whether the token is recorded and has not expired, always true when the limit is disabled.
+func (s *tokenStore) Active(ctx context.Context, userID *uuid.UUID, tokenID string) (bool, error) {
+	if s.limit <= 0 {
+		return true, nil
+	}
+	expiresAt, err := s.client.ZScore(ctx, tokenKeyPrefix+userID.String(), tokenID).Result()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+	return int64(expiresAt) > s.now().UnixMilli(), nil
+}
+
+// Remove forgets the token of the user.
+func (s *tokenStore) Remove(ctx context.Context, userID *uuid.UUID, tokenID string) error {
+	if s.limit <= 0 {
+		return nil
+	}
+	return s.client.ZRem(ctx, tokenKeyPrefix+userID.String(), tokenID).Err()
+}
+
+// NewTokenStore creates a Redis-backed ITokenStore using the login session limit from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the login session settings.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.ITokenStore): The token store implementation.
+func NewTokenStore(cfg *Config, client *libredis.Client) interfaces.ITokenStore {
+	return &tokenStore{
+		client:      client,
+		limit:       cfg.MaxLoginSessions,
+		evictOldest: !cfg.RejectLoginsOverLimit,
+		now:         time.Now,
+	}
+}
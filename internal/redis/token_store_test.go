@@ -0,0 +1,153 @@
+package redis
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenRedis is an in-memory stand-in for the token sets in Redis. Only the commands and the
+// script of the token store are supported.
+type tokenRedis struct {
+	libredis.Cmdable
+	sets map[string]map[string]int64 // Token IDs and their expiry in milliseconds, keyed by set
+}
+
+// EvalSha runs the add token script.
+func (r *tokenRedis) EvalSha(_ context.Context, sha1 string, keys []string, args ...interface{}) *libredis.Cmd {
+	if sha1 != addTokenScript.Hash() {
+		return libredis.NewCmdResult(nil, libredis.ErrClosed)
+	}
+	now, expiresAt, limit, evict, tokenID := args[0].(int64), args[1].(int64), args[2].(int), args[3].(int), args[4].(string)
+	set := r.sets[keys[0]]
+	if set == nil {
+		set = map[string]int64{}
+		r.sets[keys[0]] = set
+	}
+	for id, expiry := range set {
+		if expiry <= now {
+			delete(set, id)
+		}
+	}
+	if len(set) >= limit {
+		if evict != 1 {
+			return libredis.NewCmdResult(int64(0), nil)
+		}
+		ids := make([]string, 0, len(set))
+		for id := range set {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return set[ids[i]] < set[ids[j]] })
+		for _, id := range ids[:len(set)-limit+1] {
+			delete(set, id)
+		}
+	}
+	set[tokenID] = expiresAt
+	return libredis.NewCmdResult(int64(1), nil)
+}
+
+func (r *tokenRedis) ZScore(_ context.Context, key, member string) *libredis.FloatCmd {
+	expiry, ok := r.sets[key][member]
+	if !ok {
+		return libredis.NewFloatResult(0, libredis.Nil)
+	}
+	return libredis.NewFloatResult(float64(expiry), nil)
+}
+
+func (r *tokenRedis) ZRem(_ context.Context, key string, members ...interface{}) *libredis.IntCmd {
+	for _, member := range members {
+		delete(r.sets[key], member.(string))
+	}
+	return libredis.NewIntResult(int64(len(members)), nil)
+}
+
+// newTestTokenStore creates a token store allowing two sessions per user, with a clock that
+// advances by a second on every login.
+func newTestTokenStore(evictOldest bool) *tokenStore {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	return &tokenStore{
+		client:      &tokenRedis{sets: map[string]map[string]int64{}},
+		limit:       2,
+		evictOldest: evictOldest,
+		now:         func() time.Time { return now },
+	}
+}
+
+func TestTokenStore_EvictsOldestSessionOverLimit(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTokenStore(true)
+	userID := uuid.New()
+
+	for i, tokenID := range []string{"first", "second", "third"} {
+		// Tokens issued later expire later
+		added, err := store.Add(ctx, &userID, tokenID, time.Hour+time.Duration(i)*time.Second)
+		require.NoError(t, err)
+		assert.True(t, added, tokenID)
+	}
+
+	for tokenID, expected := range map[string]bool{"first": false, "second": true, "third": true} {
+		active, err := store.Active(ctx, &userID, tokenID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, active, tokenID)
+	}
+}
+
+func TestTokenStore_RejectsLoginOverLimit(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTokenStore(false)
+	userID := uuid.New()
+
+	for _, tokenID := range []string{"first", "second"} {
+		added, err := store.Add(ctx, &userID, tokenID, time.Hour)
+		require.NoError(t, err)
+		assert.True(t, added, tokenID)
+	}
+	added, err := store.Add(ctx, &userID, "third", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, added, "the third session is rejected")
+	active, err := store.Active(ctx, &userID, "first")
+	require.NoError(t, err)
+	assert.True(t, active, "the existing sessions stay active")
+
+	// Logging out frees a slot for a new session
+	require.NoError(t, store.Remove(ctx, &userID, "first"))
+	added, err = store.Add(ctx, &userID, "third", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
+func TestTokenStore_ExpiredSessionInactive(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTokenStore(false)
+	userID := uuid.New()
+	issuedAt := store.now()
+
+	added, err := store.Add(ctx, &userID, "first", time.Minute)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	store.now = func() time.Time { return issuedAt.Add(time.Minute) }
+	active, err := store.Active(ctx, &userID, "first")
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestTokenStore_UnlimitedAcceptsEveryToken(t *testing.T) {
+	ctx := context.Background()
+	store := &tokenStore{limit: 0, now: time.Now}
+	userID := uuid.New()
+
+	// Without a limit, the store never reaches Redis
+	added, err := store.Add(ctx, &userID, "first", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, added)
+	active, err := store.Active(ctx, &userID, "unknown")
+	require.NoError(t, err)
+	assert.True(t, active)
+}
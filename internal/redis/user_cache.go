@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// userCacheKeyPrefix is the prefix for user cache keys in Redis.
+const userCacheKeyPrefix = "user:"
+
+// userCache implements IUserCache on top of Redis, storing users as JSON
+// documents with a fixed time-to-live.
+type userCache struct {
+	client *libredis.Client // Redis client used for cache operations
+	ttl    time.Duration    // Time-to-live of cached entries
+}
+
+// Get retrieves a cached user by their external identifier.
+// A cache miss is reported as (nil, nil).
+func (c *userCache) Get(ctx context.Context, id *uuid.UUID) (*models.User, error) {
+	data, err := c.client.Get(ctx, userCacheKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	user := &models.User{}
+	if err := json.Unmarshal(data, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Set stores the user in the cache. The password hash is never written to Redis.
+func (c *userCache) Set(ctx context.Context, user *models.User) error {
+	cached := *user
+	cached.Password = ""
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, userCacheKey(user.ExternalID), data, c.ttl).Err()
+}
+
+// Delete removes the cached user.
+func (c *userCache) Delete(ctx context.Context, id *uuid.UUID) error {
+	return c.client.Del(ctx, userCacheKey(id)).Err()
+}
+
+// userCacheKey builds the Redis key for a user's external identifier.
+func userCacheKey(id *uuid.UUID) string {
+	return userCacheKeyPrefix + id.String()
+}
+
+// NewUserCache creates a Redis-backed IUserCache using the TTL from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the cache TTL.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.IUserCache): The user cache implementation.
+func NewUserCache(cfg *Config, client *libredis.Client) interfaces.IUserCache {
+	return &userCache{
+		client: client,
+		ttl:    time.Duration(cfg.UserCacheTTL) * time.Second,
+	}
+}
@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	libredis "github.com/redis/go-redis/v9"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// winStreakKeyPrefix is the key prefix of the win streak counters in Redis.
+const winStreakKeyPrefix = "win_streak:"
+
+// winStreakStore implements IWinStreakStore, keeping each streak in a Redis key that
+// expires once the user has not spun for the configured TTL.
+type winStreakStore struct {
+	client *libredis.Client // Redis client used for streak operations
+	ttl    time.Duration    // Lifetime of a streak without further spins
+}
+
+// Get returns the win streak of the user, or 0 if none is recorded.
+func (s *winStreakStore) Get(ctx context.Context, userID *uuid.UUID) (int, error) {
+	streak, err := s.client.Get(ctx, winStreakKey(userID)).Int()
+	if err != nil {
+		if errors.Is(err, libredis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return streak, nil
+}
+
+// Set stores the win streak of the user and refreshes its TTL, deleting the key for a streak of 0.
+func (s *winStreakStore) Set(ctx context.Context, userID *uuid.UUID, streak int) error {
+	if streak <= 0 {
+		return s.client.Del(ctx, winStreakKey(userID)).Err()
+	}
+	return s.client.Set(ctx, winStreakKey(userID), streak, s.ttl).Err()
+}
+
+// winStreakKey builds the Redis key of a user's win streak.
+func winStreakKey(userID *uuid.UUID) string {
+	return winStreakKeyPrefix + userID.String()
+}
+
+// NewWinStreakStore creates a Redis-backed IWinStreakStore using the win streak TTL from Config.
+//
+// Parameters:
+//   - cfg (*Config): The Redis configuration containing the win streak TTL.
+//   - client (*libredis.Client): The Redis client instance.
+//
+// Returns:
+//   - (interfaces.IWinStreakStore): The win streak store implementation.
+func NewWinStreakStore(cfg *Config, client *libredis.Client) interfaces.IWinStreakStore {
+	return &winStreakStore{
+		client: client,
+		ttl:    time.Duration(cfg.WinStreakTTL) * time.Second,
+	}
+}
@@ -0,0 +1,121 @@
+package reporting
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Constants defining the regulatory reporting configuration flags.
+const (
+	reportingURL           = "reporting-url"
+	reportingSecret        = "reporting-secret"
+	reportingGameID        = "reporting-game-id"
+	reportingTimeout       = "reporting-timeout"
+	reportingRetryInterval = "reporting-retry-interval"
+	reportingPollInterval  = "reporting-poll-interval"
+)
+
+// Config represents the settings of the regulatory spin reports.
+type Config struct {
+	URL           string // Regulator endpoint receiving a report of every spin; empty disables reporting
+	Secret        string // Secret used to sign the reports and to derive the player pseudonyms
+	GameID        string // Identifier of the game sent with every report
+	Timeout       int    // Timeout of a single delivery attempt in seconds
+	RetryInterval int    // Time in seconds after which a report whose delivery failed is retried
+	PollInterval  int    // Interval between checks for due reports in milliseconds
+}
+
+// Enabled reports whether spins are reported.
+func (c *Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// Validate checks that enabled reporting has a signing secret, a game ID and positive timings.
+//
+// Returns:
+//   - An error listing every invalid setting, or nil if the configuration is valid.
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	var errs []error
+	if c.Secret == "" {
+		errs = append(errs, fmt.Errorf("%s is required when %s is set", reportingSecret, reportingURL))
+	}
+	if c.GameID == "" {
+		errs = append(errs, fmt.Errorf("%s is required when %s is set", reportingGameID, reportingURL))
+	}
+	for _, setting := range []struct {
+		name  string
+		value int
+	}{{reportingTimeout, c.Timeout}, {reportingRetryInterval, c.RetryInterval}, {reportingPollInterval, c.PollInterval}} {
+		if setting.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %d", setting.name, setting.value))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetReportingConfig reads the regulatory reporting settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the reporting settings.
+//   - (error): An error if the settings are invalid.
+func GetReportingConfig(c *cli.Context) (*Config, error) {
+	cfg := &Config{
+		URL:           c.String(reportingURL),
+		Secret:        c.String(reportingSecret),
+		GameID:        c.String(reportingGameID),
+		Timeout:       c.Int(reportingTimeout),
+		RetryInterval: c.Int(reportingRetryInterval),
+		PollInterval:  c.Int(reportingPollInterval),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Flags defines the CLI flags available for configuring the regulatory spin reports.
+var Flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    reportingURL,
+		Usage:   "Regulator endpoint receiving a signed report of every spin; empty disables reporting",
+		EnvVars: []string{"REPORTING_URL"},
+	},
+	&cli.StringFlag{
+		Name:    reportingSecret,
+		Usage:   "Secret used to sign the spin reports with HMAC-SHA256 and to derive the player pseudonyms",
+		EnvVars: []string{"REPORTING_SECRET"},
+	},
+	&cli.StringFlag{
+		Name:    reportingGameID,
+		Value:   "slot-game",
+		Usage:   "Identifier of the game sent with every spin report",
+		EnvVars: []string{"REPORTING_GAME_ID"},
+	},
+	&cli.IntFlag{
+		Name:    reportingTimeout,
+		Value:   5,
+		Usage:   "Timeout of a single spin report delivery attempt in seconds",
+		EnvVars: []string{"REPORTING_TIMEOUT"},
+	},
+	&cli.IntFlag{
+		Name:    reportingRetryInterval,
+		Value:   30,
+		Usage:   "Time in seconds after which a spin report whose delivery failed is retried",
+		EnvVars: []string{"REPORTING_RETRY_INTERVAL"},
+	},
+	&cli.IntFlag{
+		Name:    reportingPollInterval,
+		Value:   1000,
+		Usage:   "Interval between checks for due spin reports in milliseconds",
+		EnvVars: []string{"REPORTING_POLL_INTERVAL"},
+	},
+}
@@ -0,0 +1,41 @@
+package reporting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{URL: "https://regulator.example/reports", Secret: "secret", GameID: "slot-game", Timeout: 5, RetryInterval: 30, PollInterval: 1000}
+	with := func(change func(*Config)) Config {
+		cfg := valid
+		change(&cfg)
+		return cfg
+	}
+
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"Disabled", Config{}, false},
+		{"Enabled", valid, false},
+		{"MissingSecret", with(func(c *Config) { c.Secret = "" }), true},
+		{"MissingGameID", with(func(c *Config) { c.GameID = "" }), true},
+		{"ZeroTimeout", with(func(c *Config) { c.Timeout = 0 }), true},
+		{"NegativeRetryInterval", with(func(c *Config) { c.RetryInterval = -1 }), true},
+		{"ZeroPollInterval", with(func(c *Config) { c.PollInterval = 0 }), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
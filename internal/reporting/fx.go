@@ -0,0 +1,39 @@
+package reporting
+
+import (
+	"context"
+
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"go.uber.org/fx"
+)
+
+// Module provides the regulatory reporting configuration and the Reporter as an Fx module.
+var Module = fx.Options(
+	fx.Provide(GetReportingConfig),
+	fx.Provide(NewReporter),
+	fx.Provide(newSpinReporter),
+)
+
+// Deliverer starts delivering the queued reports with the application and stops on shutdown.
+// Nothing is delivered when reporting is disabled.
+var Deliverer = fx.Invoke(func(lc fx.Lifecycle, config *Config, reporter *Reporter) {
+	if !config.Enabled() {
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			reporter.Start()
+			return nil
+		},
+		OnStop: reporter.Stop,
+	})
+})
+
+// newSpinReporter hands the reporter to the slot service. Without a reporting endpoint no reporter
+// is provided, so that spins are not queued for a delivery that never happens.
+func newSpinReporter(config *Config, reporter *Reporter) interfaces.ISpinReporter {
+	if !config.Enabled() {
+		return nil
+	}
+	return reporter
+}
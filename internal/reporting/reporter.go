@@ -0,0 +1,173 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/httpclient"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/webhook"
+)
+
+// Headers set on every report delivery.
+const (
+	HeaderSchema    = "X-Report-Schema"    // Version of the report payload
+	HeaderSignature = "X-Report-Signature" // HMAC-SHA256 signature of the body, formatted as "sha256=<hex>"
+)
+
+// claimLimit is the maximum number of reports delivered by a single poll.
+const claimLimit = 100
+
+// Reporter reports every committed spin to the regulator's endpoint.
+//
+// Reports are not delivered by the request that played the spin: they are queued in Redis and
+// delivered by a background worker, which only removes a report from the queue once the endpoint
+// accepted it. A report whose delivery failed, including one claimed by an instance that stopped
+// meanwhile, is retried after the retry interval, so every report is delivered at least once.
+type Reporter struct {
+	config *Config                 // Endpoint, signing secret and timings
+	queue  interfaces.IReportQueue // Queue holding the reports until they are delivered
+	client *http.Client            // HTTP client used for deliveries
+	stop   chan struct{}           // Closed by Stop to end the background worker
+	done   chan struct{}           // Closed once the background worker has returned
+}
+
+// Report queues a report of the committed spin.
+//
+// Parameters:
+//   - ctx: Context of the request that performed the spin.
+//   - userID: A UUID representing the user's external identifier.
+//   - spin: The committed spin.
+func (r *Reporter) Report(ctx context.Context, userID *uuid.UUID, spin *models.Spin) {
+	report := &models.SpinReport{
+		ID:        uuid.NewString(),
+		Schema:    models.SpinReportSchema,
+		GameID:    r.config.GameID,
+		Player:    r.pseudonym(userID),
+		Bet:       spin.BetAmount,
+		Win:       spin.WinAmount,
+		Timestamp: time.Now().UTC(),
+	}
+	if err := r.queue.Enqueue(context.WithoutCancel(ctx), report); err != nil {
+		log.FromContext(ctx).Errorf("failed to queue spin report %s: %v", report.ID, err)
+	}
+}
+
+// Start runs the background worker delivering the due reports until Stop is called.
+func (r *Reporter) Start() {
+	go r.run()
+}
+
+// Stop ends the background worker. Reports still queued are delivered after the next start.
+//
+// Parameters:
+//   - ctx: Context bounding the wait for the delivery in progress.
+//
+// Returns:
+//   - An error if the context is done before the worker has returned.
+func (r *Reporter) Stop(ctx context.Context) error {
+	close(r.stop)
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run delivers the due reports whenever the poll interval elapses.
+func (r *Reporter) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(time.Duration(r.config.PollInterval) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.deliverDue(context.Background())
+		}
+	}
+}
+
+// deliverDue claims the due reports and delivers them one by one. A delivered report is removed
+// from the queue; a failed one stays claimed until the retry interval has passed.
+func (r *Reporter) deliverDue(ctx context.Context) {
+	lease := time.Duration(r.config.RetryInterval) * time.Second
+	reports, err := r.queue.Claim(ctx, time.Now(), lease, claimLimit)
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to claim due spin reports: %v", err)
+		return
+	}
+	for _, report := range reports {
+		if err := r.deliver(ctx, report); err != nil {
+			log.FromContext(ctx).Warnf("failed to deliver spin report %s, retrying in %v: %v", report.ID, lease, err)
+			continue
+		}
+		if err := r.queue.Ack(ctx, report.ID); err != nil {
+			log.FromContext(ctx).Errorf("failed to remove delivered spin report %s: %v", report.ID, err)
+		}
+	}
+}
+
+// deliver POSTs the signed report to the regulator's endpoint.
+func (r *Reporter) deliver(ctx context.Context, report *models.SpinReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderSchema, report.Schema)
+	req.Header.Set(HeaderSignature, webhook.Sign(r.config.Secret, body))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("reporting endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// pseudonym derives the stable pseudonym of a user, the hex-encoded HMAC-SHA256 of the user's
+// external identifier keyed with the secret. It cannot be traced back to the user without the secret.
+func (r *Reporter) pseudonym(userID *uuid.UUID) string {
+	mac := hmac.New(sha256.New, []byte(r.config.Secret))
+	mac.Write([]byte(userID.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewReporter creates a Reporter for the given settings. Reports are only delivered once started.
+//
+// Parameters:
+//   - config: The endpoint, signing secret and timings.
+//   - queue: The queue holding the reports until they are delivered.
+//
+// Returns:
+//   - A pointer to a Reporter instance.
+func NewReporter(config *Config, queue interfaces.IReportQueue) *Reporter {
+	return &Reporter{
+		config: config,
+		queue:  queue,
+		client: httpclient.New(time.Duration(config.Timeout) * time.Second),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
@@ -0,0 +1,92 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/webhook"
+)
+
+// newTestConfig returns enabled reporting settings delivering to the given URL.
+func newTestConfig(url string) *Config {
+	return &Config{URL: url, Secret: "secret", GameID: "slot-game", Timeout: 5, RetryInterval: 30, PollInterval: 1000}
+}
+
+func TestReporter_ReportEnqueuesReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueue := mocks.NewMockIReportQueue(ctrl)
+	reporter := NewReporter(newTestConfig("http://regulator.invalid"), mockQueue)
+	userID := uuid.New()
+
+	var queued *models.SpinReport
+	mockQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, report *models.SpinReport) error {
+		queued = report
+		return nil
+	})
+
+	reporter.Report(context.Background(), &userID, &models.Spin{BetAmount: 10, WinAmount: 50})
+
+	require.NotNil(t, queued)
+	assert.NotEmpty(t, queued.ID)
+	assert.Equal(t, models.SpinReportSchema, queued.Schema)
+	assert.Equal(t, "slot-game", queued.GameID)
+	assert.Equal(t, reporter.pseudonym(&userID), queued.Player)
+	assert.NotContains(t, queued.Player, userID.String())
+	assert.Equal(t, 10.0, queued.Bet)
+	assert.Equal(t, 50.0, queued.Win)
+	assert.WithinDuration(t, time.Now(), queued.Timestamp, time.Minute)
+}
+
+func TestReporter_FailedDeliveryIsRetried(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, webhook.Sign("secret", body), r.Header.Get(HeaderSignature))
+		assert.Equal(t, models.SpinReportSchema, r.Header.Get(HeaderSchema))
+
+		var report models.SpinReport
+		assert.NoError(t, json.Unmarshal(body, &report))
+		assert.Equal(t, "report-1", report.ID)
+
+		// The first delivery fails, the retry is accepted
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	mockQueue := mocks.NewMockIReportQueue(ctrl)
+	reporter := NewReporter(newTestConfig(server.URL), mockQueue)
+	report := &models.SpinReport{ID: "report-1", Schema: models.SpinReportSchema, GameID: "slot-game", Player: "player", Bet: 10, Win: 50}
+
+	gomock.InOrder(
+		mockQueue.EXPECT().Claim(gomock.Any(), gomock.Any(), 30*time.Second, claimLimit).Return([]*models.SpinReport{report}, nil),
+		mockQueue.EXPECT().Claim(gomock.Any(), gomock.Any(), 30*time.Second, claimLimit).Return([]*models.SpinReport{report}, nil),
+		mockQueue.EXPECT().Ack(gomock.Any(), "report-1").Return(nil),
+	)
+
+	// The failed delivery is not acknowledged, so the report is claimed again once its lease expires
+	reporter.deliverDue(context.Background())
+	reporter.deliverDue(context.Background())
+
+	assert.Equal(t, int32(2), attempts.Load())
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// authEventRepository implements the IAuthEventRepository interface for recording
+// and reading the authentication audit log.
+type authEventRepository struct{}
+
+// AddEvent records a new authentication event in the database.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - event: A pointer to the AuthEvent model instance to be recorded.
+//
+// Returns:
+//   - An error if the transaction or event creation fails; otherwise, nil.
+func (r authEventRepository) AddEvent(ctx context.Context, event *models.AuthEvent) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Create(&event)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// GetEvents retrieves a page of the authentication events of a specified user, newest first,
+// together with the total number of the user's events.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The unique numeric ID of the user.
+//   - limit: The maximum number of events to return.
+//   - offset: The number of events to skip.
+//
+// Returns:
+//   - A slice of pointers to AuthEvent model instances representing the requested page.
+//   - The total number of events of the user.
+//   - An error if the transaction or retrieval fails; otherwise, nil.
+func (r authEventRepository) GetEvents(ctx context.Context, userID uint, limit, offset int) ([]*models.AuthEvent, int64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	query := tr.Provider().Model(&models.AuthEvent{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+
+	var events []*models.AuthEvent
+	result := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&events)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	return events, total, tr.Commit(id)
+}
+
+// NewAuthEventRepository creates and returns a new instance of authEventRepository.
+func NewAuthEventRepository() interfaces.IAuthEventRepository {
+	return &authEventRepository{}
+}
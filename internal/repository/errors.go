@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+)
+
+// uniqueViolationCode is the PostgreSQL SQLSTATE code for unique constraint violations.
+const uniqueViolationCode = "23505"
+
+// sqlStateError is implemented by PostgreSQL driver errors that expose their SQLSTATE code.
+type sqlStateError interface {
+	SQLState() string
+}
+
+// isUniqueViolation reports whether err, or any error it wraps, is a PostgreSQL
+// unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var sqlErr sqlStateError
+	return errors.As(err, &sqlErr) && sqlErr.SQLState() == uniqueViolationCode
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pgError mimics a PostgreSQL driver error exposing its SQLSTATE code.
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string {
+	return "pq: error " + e.code
+}
+
+func (e *pgError) SQLState() string {
+	return e.code
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"UniqueViolation", &pgError{code: "23505"}, true},
+		{"WrappedUniqueViolation", fmt.Errorf("insert user: %w", &pgError{code: "23505"}), true},
+		{"OtherConstraint", &pgError{code: "23503"}, false},
+		{"PlainError", errors.New("connection refused"), false},
+		{"Nil", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isUniqueViolation(tc.err))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// ledgerRepository implements the ILedgerRepository interface for recording
+// balance changes in the ledger.
+type ledgerRepository struct{}
+
+// AddEntry records a new ledger entry in the database.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - entry: A pointer to the LedgerEntry model instance to be recorded.
+//
+// Returns:
+//   - An error if the transaction or entry creation fails; otherwise, nil.
+func (r ledgerRepository) AddEntry(ctx context.Context, entry *models.LedgerEntry) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Create(&entry)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// SumEntries sums the amounts of a user's ledger entries of the given type recorded since a given time.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The unique numeric ID of the user.
+//   - entryType: The type of the summed entries, one of the LedgerType constants.
+//   - since: The time from which entries are summed.
+//
+// Returns:
+//   - The sum of the amounts, 0 if there are no such entries.
+//   - An error if the query fails.
+func (r ledgerRepository) SumEntries(ctx context.Context, userID uint, entryType string, since time.Time) (float64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var sum struct {
+		Total float64
+	}
+	result := tr.Provider().Model(&models.LedgerEntry{}).
+		Select("COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ? AND type = ? AND created_at >= ?", userID, entryType, since).
+		Scan(&sum)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return 0, err
+	}
+	return sum.Total, tr.Commit(id)
+}
+
+// NewLedgerRepository creates and returns a new instance of ledgerRepository.
+func NewLedgerRepository() interfaces.ILedgerRepository {
+	return &ledgerRepository{}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// promoRepository implements the IPromoRepository interface for accessing
+// promo codes and their redemptions in the database.
+type promoRepository struct{}
+
+// GetByCode retrieves a promo code by its code.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - code: The promo code entered by the user.
+//
+// Returns:
+//   - A pointer to a PromoCode model if found, or nil if not found.
+//   - An error if the retrieval fails.
+func (r promoRepository) GetByCode(ctx context.Context, code string) (*models.PromoCode, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	promo := &models.PromoCode{}
+	result := tr.Provider().Model(&models.PromoCode{}).Where("code = ?", code).First(promo)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return promo, tr.Commit(id)
+}
+
+// CountRedemptions returns how many times a user has redeemed a promo code.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - promoCodeID: The numeric ID of the promo code.
+//   - userID: The numeric ID of the user.
+//
+// Returns:
+//   - The number of redemptions.
+//   - An error if the transaction or count fails; otherwise, nil.
+func (r promoRepository) CountRedemptions(ctx context.Context, promoCodeID, userID uint) (int, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	result := tr.Provider().Model(&models.PromoRedemption{}).
+		Where("promo_code_id = ? AND user_id = ?", promoCodeID, userID).
+		Count(&count)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return 0, err
+	}
+	return count, tr.Commit(id)
+}
+
+// AddRedemption records a promo code redemption in the database.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - redemption: A pointer to the PromoRedemption model instance to be recorded.
+//
+// Returns:
+//   - An error if the transaction or redemption creation fails; otherwise, nil.
+func (r promoRepository) AddRedemption(ctx context.Context, redemption *models.PromoRedemption) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Create(&redemption)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// NewPromoRepository creates and returns a new instance of promoRepository.
+func NewPromoRepository() interfaces.IPromoRepository {
+	return &promoRepository{}
+}
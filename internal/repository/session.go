@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/vadymlab/slot-game/internal/database"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"time"
+)
+
+// endOpenSessions ends the sessions of a user that have not ended yet at their last spin, or at
+// their start if no spin was played. Sessions that timed out are only ended this way, as the
+// session store forgets them without telling the database.
+const endOpenSessions = `UPDATE game_sessions SET ended_at = COALESCE(
+	(SELECT MAX(spins.created_at) FROM spins WHERE spins.session_id = game_sessions.id), game_sessions.created_at)
+WHERE user_id = ? AND ended_at IS NULL AND deleted_at IS NULL`
+
+// sessionSummaryColumns selects the summary of each game session from the sessions joined with their spins.
+const sessionSummaryColumns = "game_sessions.id AS session_id, " +
+	"game_sessions.created_at AS started_at, " +
+	"game_sessions.ended_at AS ended_at, " +
+	"MAX(spins.created_at) AS last_spin_at, " +
+	"COUNT(spins.id) AS total_spins, " +
+	"COALESCE(SUM(spins.bet_amount), 0) AS total_wagered, " +
+	"COALESCE(SUM(spins.win_amount), 0) AS total_won"
+
+// sessionSpinsJoin joins the spins of each game session that have not been voided.
+const sessionSpinsJoin = "LEFT JOIN spins ON spins.session_id = game_sessions.id AND spins.deleted_at IS NULL AND spins.voided_at IS NULL"
+
+// sessionRepository implements the ISessionRepository interface for storing game sessions.
+type sessionRepository struct {
+	reads *database.ReadRouter // Router serving the session summaries of read-only requests from the replica
+}
+
+// AddSession ends the user's open sessions and records the new session in the database.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - session: A pointer to the Session model instance to be recorded.
+//
+// Returns:
+//   - An error if the transaction or session creation fails; otherwise, nil.
+func (r sessionRepository) AddSession(ctx context.Context, session *models.Session) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := tr.Provider().Exec(endOpenSessions, session.UserID).Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	if err := tr.Provider().Create(session).Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// EndSession ends a session that has not ended yet; a session that already ended keeps its end.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - sessionID: The unique numeric ID of the session.
+//   - endedAt: The time the session ended.
+//
+// Returns:
+//   - An error if the transaction or update fails; otherwise, nil.
+func (r sessionRepository) EndSession(ctx context.Context, sessionID uint, endedAt time.Time) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.Session{}).
+		Where("id = ? AND ended_at IS NULL", sessionID).
+		Update("ended_at", endedAt)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// GetSessionSummaries retrieves a page of the summaries of a user's sessions, newest first,
+// together with the total number of the user's sessions. Voided spins are not counted, as their
+// bets and wins have been reversed. The summaries of read-only requests are served by the read
+// replica when one is configured.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The unique numeric ID of the user.
+//   - limit: The maximum number of sessions to return.
+//   - offset: The number of sessions to skip.
+//
+// Returns:
+//   - A slice of pointers to SessionSummary models representing the requested page.
+//   - The total number of sessions of the user.
+//   - An error if the transaction or aggregation fails; otherwise, nil.
+func (r sessionRepository) GetSessionSummaries(ctx context.Context, userID uint, limit, offset int) ([]*models.SessionSummary, int64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	db := r.reads.DB(ctx, tr.Provider())
+	var total int64
+	if err := db.Model(&models.Session{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+
+	var summaries []*models.SessionSummary
+	result := db.Table(models.Session{}.TableName()).
+		Select(sessionSummaryColumns).
+		Joins(sessionSpinsJoin).
+		Where("game_sessions.user_id = ? AND game_sessions.deleted_at IS NULL", userID).
+		Group("game_sessions.id").
+		Order("game_sessions.created_at DESC").
+		Limit(limit).Offset(offset).
+		Scan(&summaries)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	return summaries, total, tr.Commit(id)
+}
+
+// GetSession retrieves a game session by its ID.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - sessionID: The unique numeric ID of the session.
+//
+// Returns:
+//   - A pointer to the Session model if found, or nil if not found.
+//   - An error if the transaction or retrieval fails; otherwise, nil.
+func (r sessionRepository) GetSession(ctx context.Context, sessionID uint) (*models.Session, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &models.Session{}
+	if err := tr.Provider().Where("id = ?", sessionID).First(session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, tr.Commit(id)
+		}
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return session, tr.Commit(id)
+}
+
+// GetSessionSummary retrieves the summary of a single game session. Voided spins are not counted,
+// as their bets and wins have been reversed.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - sessionID: The unique numeric ID of the session.
+//
+// Returns:
+//   - A pointer to the SessionSummary model if the session exists, or nil if not found.
+//   - An error if the transaction or aggregation fails; otherwise, nil.
+func (r sessionRepository) GetSessionSummary(ctx context.Context, sessionID uint) (*models.SessionSummary, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []*models.SessionSummary
+	result := tr.Provider().Table(models.Session{}.TableName()).
+		Select(sessionSummaryColumns).
+		Joins(sessionSpinsJoin).
+		Where("game_sessions.id = ? AND game_sessions.deleted_at IS NULL", sessionID).
+		Group("game_sessions.id").
+		Scan(&summaries)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, tr.Commit(id)
+	}
+	return summaries[0], tr.Commit(id)
+}
+
+// AcknowledgeRealityCheck records the time the user acknowledged a reality check of the session.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - sessionID: The unique numeric ID of the session.
+//   - acknowledgedAt: The time the reality check was acknowledged.
+//
+// Returns:
+//   - An error if the transaction or update fails; otherwise, nil.
+func (r sessionRepository) AcknowledgeRealityCheck(ctx context.Context, sessionID uint, acknowledgedAt time.Time) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.Session{}).
+		Where("id = ?", sessionID).
+		Update("reality_checked_at", acknowledgedAt)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// NewSessionRepository initializes and returns a new instance of sessionRepository,
+// implementing the ISessionRepository interface. The session summaries of read-only
+// requests are read through the given router.
+func NewSessionRepository(reads *database.ReadRouter) interfaces.ISessionRepository {
+	return &sessionRepository{reads: reads}
+}
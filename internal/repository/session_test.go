@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// TestAddSession_EndsOpenSessionsFirst checks that the user's sessions that were never ended, such
+// as one that timed out, are ended at their last spin before the new session is inserted.
+func TestAddSession_EndsOpenSessionsFirst(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	err := NewSessionRepository(nil).AddSession(ctx, &models.Session{UserID: 42})
+
+	assert.NoError(t, err)
+	queries := recorder.recorded()[before:]
+	require.Len(t, queries, 2)
+	assert.True(t, strings.HasPrefix(queries[0], "UPDATE game_sessions SET ended_at"))
+	assert.Contains(t, queries[0], "MAX(spins.created_at)")
+	assert.Contains(t, queries[0], "ended_at IS NULL")
+	assert.True(t, strings.HasPrefix(queries[1], `INSERT INTO "game_sessions"`))
+}
+
+// TestGetSessionSummaries_AggregatesSpinsPerSession checks that the summaries group the user's
+// spins by session, leave voided spins out and keep sessions without spins.
+func TestGetSessionSummaries_AggregatesSpinsPerSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	_, total, err := NewSessionRepository(nil).GetSessionSummaries(ctx, 42, 20, 40)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	queries := recorder.recorded()[before:]
+	require.Len(t, queries, 2)
+	page := queries[1]
+	assert.Contains(t, page, "LEFT JOIN spins ON spins.session_id = game_sessions.id")
+	assert.Contains(t, page, "spins.voided_at IS NULL")
+	assert.Contains(t, page, "game_sessions.user_id = $1")
+	assert.Contains(t, page, "GROUP BY game_sessions.id")
+	assert.Contains(t, page, "ORDER BY game_sessions.created_at DESC")
+	assert.Contains(t, page, "LIMIT 20 OFFSET 40")
+}
+
+// TestGetSessionSummary_AggregatesSingleSession checks that the summary of a session aggregates
+// only the spins of that session, voided spins left out, and that a missing session yields nil.
+func TestGetSessionSummary_AggregatesSingleSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	summary, err := NewSessionRepository(nil).GetSessionSummary(ctx, 7)
+
+	assert.NoError(t, err)
+	assert.Nil(t, summary)
+	queries := recorder.recorded()[before:]
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "LEFT JOIN spins ON spins.session_id = game_sessions.id")
+	assert.Contains(t, queries[0], "spins.voided_at IS NULL")
+	assert.Contains(t, queries[0], "game_sessions.id = $1")
+	assert.Contains(t, queries[0], "GROUP BY game_sessions.id")
+}
@@ -2,14 +2,21 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"github.com/jinzhu/gorm"
 	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/vadymlab/slot-game/internal/database"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
 	"github.com/vadymlab/slot-game/internal/interfaces"
 	"github.com/vadymlab/slot-game/internal/models"
+	"time"
 )
 
 // slotRepository implements the ISlotRepository interface for managing
 // slot game operations within the database.
-type slotRepository struct{}
+type slotRepository struct {
+	reads *database.ReadRouter // Router serving the history and leaderboard of read-only requests from the replica
+}
 
 // AddSpin records a new spin entry in the database.
 //
@@ -34,33 +41,431 @@ func (s slotRepository) AddSpin(ctx context.Context, spin *models.Spin) error {
 	return tr.Commit(id)
 }
 
-// GetSpins retrieves the spin history for a specified user.
+// AddSpins records a batch of spins in the database within a single transaction, so that
+// the batch is either recorded as a whole or not at all.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - spins: Pointers to the Spin model instances to be recorded.
+//
+// Returns:
+//   - An error if the transaction or any spin creation fails; otherwise, nil.
+func (s slotRepository) AddSpins(ctx context.Context, spins []*models.Spin) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, spin := range spins {
+		if err := tr.Provider().Create(spin).Error; err != nil {
+			_ = tr.Rollback()
+			return err
+		}
+	}
+	return tr.Commit(id)
+}
+
+// GetSpins retrieves a page of the spin history for a specified user, newest first,
+// together with the total number of the user's spins within the date range.
+// The history of read-only requests is served by the read replica when one is configured.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
 //   - userId: The unique numeric ID of the user whose spin history is being retrieved.
+//   - from: Optional inclusive lower bound for the spin creation time.
+//   - to: Optional exclusive upper bound for the spin creation time.
+//   - limit: The maximum number of spins to return.
+//   - offset: The number of spins to skip.
 //
 // Returns:
-//   - A slice of pointers to Spin model instances representing the user's spin history.
+//   - A slice of pointers to Spin model instances representing the requested page.
+//   - The total number of spins of the user within the date range.
 //   - An error if the transaction or retrieval fails; otherwise, nil.
-func (s slotRepository) GetSpins(ctx context.Context, userID uint) ([]*models.Spin, error) {
+func (s slotRepository) GetSpins(ctx context.Context, userID uint, from, to *time.Time, limit, offset int) ([]*models.Spin, int64, error) {
 	tr, _ := postgres.GetTransactionContext(ctx)
 	id, err := tr.Begin()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	var total int64
+	query := spinHistoryQuery(s.reads.DB(ctx, tr.Provider()), userID, from, to)
+	if err := query.Count(&total).Error; err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
 	}
 
 	var spins []*models.Spin
-	result := tr.Provider().Model(&models.Spin{}).Where("user_id = ?", userID).Find(&spins)
+	result := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&spins)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	return spins, total, tr.Commit(id)
+}
+
+// EachSpin walks the whole spin history of a user within the date range, newest first, reading
+// the spins one row at a time so that exporting a long history does not load it into memory.
+// The walk stops at the first error returned by fn.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The unique numeric ID of the user whose spin history is being read.
+//   - from: Optional inclusive lower bound for the spin creation time.
+//   - to: Optional exclusive upper bound for the spin creation time.
+//   - fn: Function called with each spin.
+//
+// Returns:
+//   - An error if the transaction or retrieval fails, or the error returned by fn; otherwise, nil.
+func (s slotRepository) EachSpin(ctx context.Context, userID uint, from, to *time.Time, fn func(*models.Spin) error) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	db := tr.Provider()
+	rows, err := spinHistoryQuery(db, userID, from, to).Order("created_at DESC").Rows()
+	if err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		spin := &models.Spin{}
+		if err := db.ScanRows(rows, spin); err != nil {
+			_ = tr.Rollback()
+			return err
+		}
+		if err := fn(spin); err != nil {
+			_ = tr.Rollback()
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// LastSpinHash returns the hash of the user's latest chained spin. Called within the transaction
+// that holds the lock on the user's row, so that no other spin of the user is chained meanwhile.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The unique numeric ID of the user.
+//
+// Returns:
+//   - The hash of the latest chained spin, or an empty string if the user has none.
+//   - An error if the transaction or retrieval fails.
+func (s slotRepository) LastSpinHash(ctx context.Context, userID uint) (string, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	spin := &models.Spin{}
+	err = tr.Provider().Unscoped().Select("hash").
+		Where("user_id = ? AND hash <> ''", userID).
+		Order("id DESC").
+		First(spin).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", tr.Commit(id)
+		}
+		_ = tr.Rollback()
+		return "", err
+	}
+	return spin.Hash, tr.Commit(id)
+}
+
+// EachChainedSpin walks the user's chained spins in the order they were written, reading them one
+// row at a time. Spins written while the hash chain was disabled are skipped.
+// The walk stops at the first error returned by fn.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The unique numeric ID of the user.
+//   - fn: Function called with each spin.
+//
+// Returns:
+//   - An error if the transaction or retrieval fails, or the error returned by fn; otherwise, nil.
+func (s slotRepository) EachChainedSpin(ctx context.Context, userID uint, fn func(*models.Spin) error) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	db := tr.Provider()
+	rows, err := db.Unscoped().Model(&models.Spin{}).
+		Where("user_id = ? AND hash <> ''", userID).
+		Order("id").
+		Rows()
+	if err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		spin := &models.Spin{}
+		if err := db.ScanRows(rows, spin); err != nil {
+			_ = tr.Rollback()
+			return err
+		}
+		if err := fn(spin); err != nil {
+			_ = tr.Rollback()
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// spinHistoryQuery selects the spins of a user created within the optional date range.
+func spinHistoryQuery(db *gorm.DB, userID uint, from, to *time.Time) *gorm.DB {
+	query := db.Model(&models.Spin{}).Where("user_id = ?", userID)
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+	return query
+}
+
+// GetLeaderboard aggregates total winnings per user and returns the top entries,
+// ordered by total win amount in descending order. Voided spins are not counted.
+// The leaderboard of read-only requests is served by the read replica when one is configured.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - since: Optional lower bound for the spin creation time; nil aggregates all spins.
+//   - limit: The maximum number of entries to return.
+//
+// Returns:
+//   - A slice of pointers to LeaderboardEntry instances.
+//   - An error if the transaction or aggregation fails; otherwise, nil.
+func (s slotRepository) GetLeaderboard(ctx context.Context, since *time.Time, limit int) ([]*models.LeaderboardEntry, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.reads.DB(ctx, tr.Provider()).Table(models.Spin{}.TableName()).
+		Select("spins.user_id, users.login, SUM(spins.win_amount) AS total_win, SUM(spins.bet_amount) AS total_bet").
+		Joins("JOIN users ON users.id = spins.user_id").
+		Where("spins.deleted_at IS NULL AND spins.voided_at IS NULL")
+	if since != nil {
+		query = query.Where("spins.created_at >= ?", *since)
+	}
+
+	var entries []*models.LeaderboardEntry
+	result := query.Group("spins.user_id, users.login").
+		Order("total_win DESC").
+		Limit(limit).
+		Scan(&entries)
 	if err := result.Error; err != nil {
 		_ = tr.Rollback()
 		return nil, err
 	}
-	return spins, tr.Commit(id)
+	return entries, tr.Commit(id)
+}
+
+// GetSpinStats aggregates the spins of a user into their play statistics. Voided spins are not
+// counted, as their bets and wins have been reversed. The aggregates are coalesced to zero, so a
+// user without spins gets all zeros.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The unique numeric ID of the user.
+//
+// Returns:
+//   - A pointer to the SpinStats of the user.
+//   - An error if the transaction or aggregation fails; otherwise, nil.
+func (s slotRepository) GetSpinStats(ctx context.Context, userID uint) (*models.SpinStats, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.SpinStats{}
+	result := tr.Provider().Table(models.Spin{}.TableName()).
+		Select("COUNT(*) AS total_spins, "+
+			"COALESCE(SUM(bet_amount), 0) AS total_wagered, "+
+			"COALESCE(SUM(win_amount), 0) AS total_won, "+
+			"COALESCE(MAX(win_amount), 0) AS biggest_win, "+
+			"COUNT(*) FILTER (WHERE win_amount > 0) AS winning_spins").
+		Where("user_id = ? AND deleted_at IS NULL AND voided_at IS NULL", userID).
+		Scan(stats)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return stats, tr.Commit(id)
+}
+
+// GetSpinStatsBetween aggregates the spins of all users created within a window. Voided spins are
+// not counted, as their bets and wins have been reversed. The query is served by the
+// idx_spins_created_at index created by migration 000009.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - from: Inclusive lower bound for the spin creation time.
+//   - to: Exclusive upper bound for the spin creation time.
+//
+// Returns:
+//   - A pointer to the SpinStats of the window.
+//   - An error if the transaction or aggregation fails; otherwise, nil.
+func (s slotRepository) GetSpinStatsBetween(ctx context.Context, from, to time.Time) (*models.SpinStats, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.SpinStats{}
+	result := tr.Provider().Table(models.Spin{}.TableName()).
+		Select("COUNT(*) AS total_spins, "+
+			"COALESCE(SUM(bet_amount), 0) AS total_wagered, "+
+			"COALESCE(SUM(win_amount), 0) AS total_won, "+
+			"COALESCE(MAX(win_amount), 0) AS biggest_win, "+
+			"COUNT(*) FILTER (WHERE win_amount > 0) AS winning_spins").
+		Where("created_at >= ? AND created_at < ? AND deleted_at IS NULL AND voided_at IS NULL", from, to).
+		Scan(stats)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return stats, tr.Commit(id)
+}
+
+// AddRTPReport records a report of the realized RTP of a window in the database.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - report: A pointer to the RTPReport instance to be recorded.
+//
+// Returns:
+//   - An error if the transaction or creation fails; otherwise, nil.
+func (s slotRepository) AddRTPReport(ctx context.Context, report *models.RTPReport) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Create(report)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// GetSpinForUpdate retrieves a spin by its numeric ID, locking the row with SELECT ... FOR UPDATE
+// so that concurrent voids of the same spin are serialized.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - spinID: The unique numeric ID of the spin.
+//
+// Returns:
+//   - A pointer to a Spin model if found, or nil if not found.
+//   - An error if the retrieval fails.
+func (s slotRepository) GetSpinForUpdate(ctx context.Context, spinID uint) (*models.Spin, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	spin := &models.Spin{}
+	result := tr.Provider().Set("gorm:query_option", "FOR UPDATE").Where("id = ?", spinID).First(spin)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return spin, tr.Commit(id)
+}
+
+// VoidSpin marks a spin as voided. The update only applies to a spin that has not been
+// voided yet, so a concurrent or repeated void never succeeds twice.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - spinID: The unique numeric ID of the spin.
+//   - reason: The reason for voiding the spin.
+//   - voidedAt: The time the spin is voided at.
+//
+// Returns:
+//   - ErrSpinAlreadyVoided if the spin has already been voided.
+//   - An error if the update fails; otherwise, nil.
+func (s slotRepository) VoidSpin(ctx context.Context, spinID uint, reason string, voidedAt time.Time) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.Spin{}).
+		Where("id = ? AND voided_at IS NULL", spinID).
+		Updates(map[string]interface{}{"voided_at": voidedAt, "void_reason": reason})
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	if result.RowsAffected == 0 {
+		_ = tr.Rollback()
+		return serviceError.ErrSpinAlreadyVoided
+	}
+	return tr.Commit(id)
+}
+
+// DeleteSpinsBefore permanently deletes up to limit spins created before the given time, oldest first.
+// Deleting in bounded batches keeps each statement short, so pruning a large backlog does not hold
+// long row locks. The query is served by the idx_spins_created_at index created by migration 000009.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - before: Spins created before this time are deleted.
+//   - limit: The maximum number of spins to delete.
+//
+// Returns:
+//   - The number of deleted spins.
+//   - An error if the transaction or deletion fails.
+func (s slotRepository) DeleteSpinsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	result := tr.Provider().Exec(
+		"DELETE FROM spins WHERE id IN (SELECT id FROM spins WHERE created_at < ? ORDER BY created_at LIMIT ?)",
+		before, limit,
+	)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return 0, err
+	}
+	return result.RowsAffected, tr.Commit(id)
 }
 
 // NewSlotRepository initializes and returns a new instance of slotRepository,
 // implementing the ISlotRepository interface for slot game database operations.
-func NewSlotRepository() interfaces.ISlotRepository {
-	return &slotRepository{}
+// The spin history and the leaderboard of read-only requests are read through the given router.
+func NewSlotRepository(reads *database.ReadRouter) interfaces.ISlotRepository {
+	return &slotRepository{reads: reads}
 }
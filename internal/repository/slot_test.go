@@ -0,0 +1,642 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/stretchr/testify/assert"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// recordingDriver is a database/sql driver recording the executed queries.
+// Count queries return 0, inserts return the ID 1 and all other queries return no rows.
+type recordingDriver struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{driver: d}, nil }
+
+// recorded returns the queries executed so far.
+func (d *recordingDriver) recorded() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.queries...)
+}
+
+type recordingConn struct{ driver *recordingDriver }
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{driver: c.driver, query: query}, nil
+}
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *recordingConn) Commit() error             { return nil }
+func (c *recordingConn) Rollback() error           { return nil }
+
+type recordingStmt struct {
+	driver *recordingDriver
+	query  string
+}
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+func (s *recordingStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.record()
+	return driver.RowsAffected(0), nil
+}
+func (s *recordingStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.record()
+	if strings.Contains(strings.ToLower(s.query), "count(*)") {
+		return &recordingRows{columns: []string{"count"}, values: []driver.Value{int64(0)}}, nil
+	}
+	if strings.HasPrefix(s.query, "INSERT") {
+		return &recordingRows{columns: []string{"id"}, values: []driver.Value{int64(1)}}, nil
+	}
+	return &recordingRows{columns: []string{"id"}}, nil
+}
+
+func (s *recordingStmt) record() {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+	s.driver.queries = append(s.driver.queries, s.query)
+}
+
+type recordingRows struct {
+	columns []string
+	values  []driver.Value
+}
+
+func (r *recordingRows) Columns() []string { return r.columns }
+func (r *recordingRows) Close() error      { return nil }
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.values == nil {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.values = nil
+	return nil
+}
+
+var (
+	recorder     = &recordingDriver{}
+	registerOnce sync.Once
+)
+
+// newRecordingContext returns a context whose transaction provider records the executed queries.
+func newRecordingContext(t *testing.T, ctrl *gomock.Controller) context.Context {
+	registerOnce.Do(func() { sql.Register("recording", recorder) })
+	sqlDB, err := sql.Open("recording", "")
+	assert.NoError(t, err)
+	db, err := gorm.Open("postgres", sqlDB)
+	assert.NoError(t, err)
+
+	mockTx := postgres.NewMockITransactionContext(ctrl)
+	mockTx.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockTx.EXPECT().Rollback().Return(nil).AnyTimes()
+	mockTx.EXPECT().Provider().Return(db).AnyTimes()
+	return context.WithValue(context.Background(), postgres.TransactionContextKey, mockTx)
+}
+
+// TestGetSpins_UsesIndexedOrderedQuery documents that the history query filters on user_id and
+// orders by created_at DESC with a limit, so Postgres can serve it from the
+// idx_spins_user_id_created_at index instead of scanning and sorting the spins table.
+func TestGetSpins_UsesIndexedOrderedQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	_, total, err := NewSlotRepository(nil).GetSpins(ctx, 42, nil, nil, 20, 40)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	queries := recorder.recorded()[before:]
+	assert.Len(t, queries, 2)
+	page := queries[1]
+	assert.Contains(t, page, `"spins"."deleted_at" IS NULL`)
+	assert.Contains(t, page, "user_id = $1")
+	assert.Contains(t, page, "ORDER BY created_at DESC")
+	assert.Contains(t, page, "LIMIT 20 OFFSET 40")
+}
+
+// TestEachSpin_FiltersByDateRange checks that the export walks the user's spins within the
+// date range in the same index order as the history page.
+func TestEachSpin_FiltersByDateRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	calls := 0
+	err := NewSlotRepository(nil).EachSpin(ctx, 42, &from, &to, func(*models.Spin) error {
+		calls++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Zero(t, calls)
+	queries := recorder.recorded()[before:]
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "user_id = $1")
+	assert.Contains(t, queries[0], "created_at >= $2")
+	assert.Contains(t, queries[0], "created_at < $3")
+	assert.Contains(t, queries[0], "ORDER BY created_at DESC")
+}
+
+// TestEachChainedSpin_WalksChainInWriteOrder checks that the chain is walked oldest first, by ID,
+// skipping the spins written without the chain.
+func TestEachChainedSpin_WalksChainInWriteOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	err := NewSlotRepository(nil).EachChainedSpin(ctx, 42, func(*models.Spin) error { return nil })
+
+	assert.NoError(t, err)
+	queries := recorder.recorded()[before:]
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "user_id = $1 AND hash <> ''")
+	assert.Contains(t, queries[0], `ORDER BY "id"`)
+}
+
+// TestLastSpinHash_NoChainedSpin checks that a user without chained spins starts a new chain.
+func TestLastSpinHash_NoChainedSpin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	hash, err := NewSlotRepository(nil).LastSpinHash(ctx, 42)
+
+	assert.NoError(t, err)
+	assert.Empty(t, hash)
+	queries := recorder.recorded()[before:]
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "user_id = $1 AND hash <> ''")
+	assert.Contains(t, queries[0], "ORDER BY id DESC")
+}
+
+// TestAddSpins_InsertsEverySpin checks that a batch of the background spin writer inserts
+// each of its spins.
+func TestAddSpins_InsertsEverySpin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	spins := []*models.Spin{{UserID: 1, BetAmount: 10}, {UserID: 2, BetAmount: 20}, {UserID: 1, BetAmount: 5}}
+	err := NewSlotRepository(nil).AddSpins(ctx, spins)
+
+	assert.NoError(t, err)
+	inserts := 0
+	for _, query := range recorder.recorded()[before:] {
+		if strings.HasPrefix(query, `INSERT INTO "spins"`) {
+			inserts++
+		}
+	}
+	assert.Equal(t, len(spins), inserts)
+}
+
+// TestSpinsIndexMigration_Idempotent checks that the history index matches the query above
+// and that the migration can be applied and reverted repeatedly.
+func TestSpinsIndexMigration_Idempotent(t *testing.T) {
+	up, err := os.ReadFile("../../database/migration/000006_add_spins_user_id_created_at_index.up.sql")
+	assert.NoError(t, err)
+	down, err := os.ReadFile("../../database/migration/000006_add_spins_user_id_created_at_index.down.sql")
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(up), "CREATE INDEX IF NOT EXISTS idx_spins_user_id_created_at ON spins (user_id, created_at DESC)")
+	assert.Contains(t, string(down), "DROP INDEX IF EXISTS idx_spins_user_id_created_at")
+}
+
+// TestVoidSpin_GuardsAgainstDoubleVoid checks that the void only updates a spin that has not been
+// voided yet, and that an update matching no rows is reported as ErrSpinAlreadyVoided.
+func TestVoidSpin_GuardsAgainstDoubleVoid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	err := NewSlotRepository(nil).VoidSpin(ctx, 7, "duplicate charge", time.Now())
+
+	assert.ErrorIs(t, err, serviceError.ErrSpinAlreadyVoided)
+	queries := recorder.recorded()[before:]
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "voided_at IS NULL")
+}
+
+// spinStoreDriver is a database/sql driver holding the creation times of spins. It applies
+// the batched retention DELETE to them: the oldest spins created before the $1 cutoff are
+// removed, at most $2 of them.
+type spinStoreDriver struct {
+	mu        sync.Mutex
+	createdAt []time.Time
+}
+
+func (d *spinStoreDriver) Open(string) (driver.Conn, error) { return &spinStoreConn{driver: d}, nil }
+
+type spinStoreConn struct{ driver *spinStoreDriver }
+
+func (c *spinStoreConn) Prepare(query string) (driver.Stmt, error) {
+	return &spinStoreStmt{driver: c.driver, query: query}, nil
+}
+func (c *spinStoreConn) Close() error              { return nil }
+func (c *spinStoreConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *spinStoreConn) Commit() error             { return nil }
+func (c *spinStoreConn) Rollback() error           { return nil }
+
+type spinStoreStmt struct {
+	driver *spinStoreDriver
+	query  string
+}
+
+func (s *spinStoreStmt) Close() error  { return nil }
+func (s *spinStoreStmt) NumInput() int { return -1 }
+func (s *spinStoreStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if !strings.Contains(s.query, "DELETE FROM spins") || !strings.Contains(s.query, "created_at < $1 ORDER BY created_at LIMIT $2") {
+		return driver.RowsAffected(0), nil
+	}
+	cutoff, limit := args[0].(time.Time), args[1].(int64)
+
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+	sort.Slice(s.driver.createdAt, func(i, j int) bool { return s.driver.createdAt[i].Before(s.driver.createdAt[j]) })
+	var deleted int64
+	kept := s.driver.createdAt[:0]
+	for _, createdAt := range s.driver.createdAt {
+		if createdAt.Before(cutoff) && deleted < limit {
+			deleted++
+			continue
+		}
+		kept = append(kept, createdAt)
+	}
+	s.driver.createdAt = kept
+	return driver.RowsAffected(deleted), nil
+}
+func (s *spinStoreStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &recordingRows{columns: []string{"id"}}, nil
+}
+
+var (
+	spinStore             = &spinStoreDriver{}
+	registerSpinStoreOnce sync.Once
+)
+
+// TestDeleteSpinsBefore_KeepsRecentSpins checks that spins outside the retention window are
+// deleted in bounded batches, while spins inside the window remain.
+func TestDeleteSpinsBefore_KeepsRecentSpins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registerSpinStoreOnce.Do(func() { sql.Register("spinstore", spinStore) })
+	sqlDB, err := sql.Open("spinstore", "")
+	assert.NoError(t, err)
+	db, err := gorm.Open("postgres", sqlDB)
+	assert.NoError(t, err)
+	mockTx := postgres.NewMockITransactionContext(ctrl)
+	mockTx.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockTx.EXPECT().Provider().Return(db).AnyTimes()
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTx)
+
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -90)
+	recent := []time.Time{now, now.AddDate(0, 0, -89)}
+	spinStore.createdAt = append([]time.Time{now.AddDate(0, 0, -91), now.AddDate(0, 0, -200), now.AddDate(-1, 0, 0)}, recent...)
+
+	repo := NewSlotRepository(nil)
+	deleted, err := repo.DeleteSpinsBefore(ctx, cutoff, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	deleted, err = repo.DeleteSpinsBefore(ctx, cutoff, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	assert.ElementsMatch(t, recent, spinStore.createdAt)
+}
+
+// statsSpin is a spin row held by the spinStatsDriver.
+type statsSpin struct {
+	userID    int64
+	bet, win  float64
+	voided    bool
+	deletedAt bool
+	createdAt time.Time
+}
+
+// spinStatsDriver is a database/sql driver holding spin rows. It answers the statistics
+// aggregate over the $1 user's spins, or over all spins created from $1 up to $2, skipping
+// deleted and voided spins as the query requires.
+type spinStatsDriver struct {
+	mu    sync.Mutex
+	spins []statsSpin
+	query string
+}
+
+func (d *spinStatsDriver) Open(string) (driver.Conn, error) { return &spinStatsConn{driver: d}, nil }
+
+type spinStatsConn struct{ driver *spinStatsDriver }
+
+func (c *spinStatsConn) Prepare(query string) (driver.Stmt, error) {
+	return &spinStatsStmt{driver: c.driver, query: query}, nil
+}
+func (c *spinStatsConn) Close() error              { return nil }
+func (c *spinStatsConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *spinStatsConn) Commit() error             { return nil }
+func (c *spinStatsConn) Rollback() error           { return nil }
+
+type spinStatsStmt struct {
+	driver *spinStatsDriver
+	query  string
+}
+
+func (s *spinStatsStmt) Close() error  { return nil }
+func (s *spinStatsStmt) NumInput() int { return -1 }
+func (s *spinStatsStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (s *spinStatsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+	s.driver.query = s.query
+	byUser := strings.Contains(s.query, "user_id = $1")
+	filterDeleted := strings.Contains(s.query, "deleted_at IS NULL")
+	filterVoided := strings.Contains(s.query, "voided_at IS NULL")
+
+	var spins, winning int64
+	var wagered, won, biggest float64
+	for _, spin := range s.driver.spins {
+		if byUser && spin.userID != args[0].(int64) ||
+			!byUser && (spin.createdAt.Before(args[0].(time.Time)) || !spin.createdAt.Before(args[1].(time.Time))) ||
+			(filterDeleted && spin.deletedAt) || (filterVoided && spin.voided) {
+			continue
+		}
+		spins++
+		wagered += spin.bet
+		won += spin.win
+		if spin.win > biggest {
+			biggest = spin.win
+		}
+		if spin.win > 0 {
+			winning++
+		}
+	}
+	return &recordingRows{
+		columns: []string{"total_spins", "total_wagered", "total_won", "biggest_win", "winning_spins"},
+		values:  []driver.Value{spins, wagered, won, biggest, winning},
+	}, nil
+}
+
+var (
+	spinStats             = &spinStatsDriver{}
+	registerSpinStatsOnce sync.Once
+)
+
+// TestGetSpinStats_Aggregates checks each aggregate over a few seeded spins, that voided and
+// deleted spins as well as other users' spins are left out, and that a user without spins gets zeros.
+func TestGetSpinStats_Aggregates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registerSpinStatsOnce.Do(func() { sql.Register("spinstats", spinStats) })
+	sqlDB, err := sql.Open("spinstats", "")
+	assert.NoError(t, err)
+	db, err := gorm.Open("postgres", sqlDB)
+	assert.NoError(t, err)
+	mockTx := postgres.NewMockITransactionContext(ctrl)
+	mockTx.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockTx.EXPECT().Provider().Return(db).AnyTimes()
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTx)
+
+	spinStats.spins = []statsSpin{
+		{userID: 1, bet: 10, win: 0},
+		{userID: 1, bet: 10, win: 20},
+		{userID: 1, bet: 5, win: 50},
+		{userID: 1, bet: 20, win: 0},
+		{userID: 1, bet: 10, win: 1000, voided: true},
+		{userID: 1, bet: 10, win: 500, deletedAt: true},
+		{userID: 2, bet: 100, win: 300},
+	}
+
+	repo := NewSlotRepository(nil)
+	stats, err := repo.GetSpinStats(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), stats.TotalSpins)
+	assert.Equal(t, 45.0, stats.TotalWagered)
+	assert.Equal(t, 70.0, stats.TotalWon)
+	assert.Equal(t, 25.0, stats.Net())
+	assert.Equal(t, 50.0, stats.BiggestWin)
+	assert.Equal(t, int64(2), stats.WinningSpins)
+	assert.Equal(t, 0.5, stats.WinRate())
+	assert.Contains(t, spinStats.query, "COALESCE(SUM(bet_amount), 0)")
+
+	stats, err = repo.GetSpinStats(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SpinStats{}, stats)
+	assert.Equal(t, 0.0, stats.WinRate())
+}
+
+// TestGetSpinStatsBetween_AggregatesWindow checks that the spins of all users created within the
+// window are aggregated, while voided and deleted spins and spins outside the window are left out.
+func TestGetSpinStatsBetween_AggregatesWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registerSpinStatsOnce.Do(func() { sql.Register("spinstats", spinStats) })
+	sqlDB, err := sql.Open("spinstats", "")
+	assert.NoError(t, err)
+	db, err := gorm.Open("postgres", sqlDB)
+	assert.NoError(t, err)
+	mockTx := postgres.NewMockITransactionContext(ctrl)
+	mockTx.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockTx.EXPECT().Provider().Return(db).AnyTimes()
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTx)
+
+	to := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+	from := to.Add(-24 * time.Hour)
+	spinStats.spins = []statsSpin{
+		{userID: 1, bet: 10, win: 0, createdAt: from},
+		{userID: 1, bet: 10, win: 25, createdAt: from.Add(time.Hour)},
+		{userID: 2, bet: 20, win: 10, createdAt: to.Add(-time.Second)},
+		{userID: 2, bet: 10, win: 1000, createdAt: from.Add(2 * time.Hour), voided: true},
+		{userID: 3, bet: 10, win: 500, createdAt: from.Add(3 * time.Hour), deletedAt: true},
+		{userID: 1, bet: 50, win: 0, createdAt: from.Add(-time.Second)},
+		{userID: 3, bet: 50, win: 200, createdAt: to},
+	}
+
+	repo := NewSlotRepository(nil)
+	stats, err := repo.GetSpinStatsBetween(ctx, from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), stats.TotalSpins)
+	assert.Equal(t, 40.0, stats.TotalWagered)
+	assert.Equal(t, 35.0, stats.TotalWon)
+	assert.Equal(t, int64(2), stats.WinningSpins)
+	assert.NotContains(t, spinStats.query, "user_id")
+
+	stats, err = repo.GetSpinStatsBetween(ctx, to.Add(time.Hour), to.Add(2*time.Hour))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SpinStats{}, stats)
+}
+
+// leaderboardDriver is a database/sql driver holding spin rows and user logins. It answers the
+// leaderboard query as written: spins created before the $1 lower bound and deleted or voided
+// spins are skipped only when the query filters them, and the rows are grouped, ordered and
+// limited only as far as the query asks for.
+type leaderboardDriver struct {
+	mu     sync.Mutex
+	spins  []statsSpin
+	logins map[int64]string
+}
+
+func (d *leaderboardDriver) Open(string) (driver.Conn, error) {
+	return &leaderboardConn{driver: d}, nil
+}
+
+type leaderboardConn struct{ driver *leaderboardDriver }
+
+func (c *leaderboardConn) Prepare(query string) (driver.Stmt, error) {
+	return &leaderboardStmt{driver: c.driver, query: query}, nil
+}
+func (c *leaderboardConn) Close() error              { return nil }
+func (c *leaderboardConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *leaderboardConn) Commit() error             { return nil }
+func (c *leaderboardConn) Rollback() error           { return nil }
+
+type leaderboardStmt struct {
+	driver *leaderboardDriver
+	query  string
+}
+
+func (s *leaderboardStmt) Close() error  { return nil }
+func (s *leaderboardStmt) NumInput() int { return -1 }
+func (s *leaderboardStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (s *leaderboardStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+	since := strings.Contains(s.query, "spins.created_at >= $1")
+	filterDeleted := strings.Contains(s.query, "spins.deleted_at IS NULL")
+	filterVoided := strings.Contains(s.query, "spins.voided_at IS NULL")
+
+	var rows [][]driver.Value
+	byUser := map[int64]int{}
+	for _, spin := range s.driver.spins {
+		if since && spin.createdAt.Before(args[0].(time.Time)) ||
+			(filterDeleted && spin.deletedAt) || (filterVoided && spin.voided) {
+			continue
+		}
+		i, grouped := byUser[spin.userID]
+		if !grouped || !strings.Contains(s.query, "GROUP BY spins.user_id") {
+			byUser[spin.userID] = len(rows)
+			rows = append(rows, []driver.Value{spin.userID, s.driver.logins[spin.userID], 0.0, 0.0})
+			i = len(rows) - 1
+		}
+		rows[i][2] = rows[i][2].(float64) + spin.win
+		rows[i][3] = rows[i][3].(float64) + spin.bet
+	}
+	if strings.Contains(s.query, "ORDER BY total_win DESC") {
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i][2].(float64) > rows[j][2].(float64) })
+	}
+	if _, limit, ok := strings.Cut(s.query, "LIMIT "); ok {
+		if n, err := strconv.Atoi(strings.Fields(limit)[0]); err == nil && n < len(rows) {
+			rows = rows[:n]
+		}
+	}
+	return &leaderboardRows{rows: rows}, nil
+}
+
+type leaderboardRows struct{ rows [][]driver.Value }
+
+func (r *leaderboardRows) Columns() []string {
+	return []string{"user_id", "login", "total_win", "total_bet"}
+}
+func (r *leaderboardRows) Close() error { return nil }
+func (r *leaderboardRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var (
+	leaderboard             = &leaderboardDriver{}
+	registerLeaderboardOnce sync.Once
+)
+
+// TestGetLeaderboard_RanksUsersByTotalWin checks that the spins of each user are summed into a
+// single entry, that the entries are ranked by total win and cut at the limit, and that voided
+// and deleted spins as well as spins before the lower bound are left out.
+func TestGetLeaderboard_RanksUsersByTotalWin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registerLeaderboardOnce.Do(func() { sql.Register("leaderboard", leaderboard) })
+	sqlDB, err := sql.Open("leaderboard", "")
+	assert.NoError(t, err)
+	db, err := gorm.Open("postgres", sqlDB)
+	assert.NoError(t, err)
+	mockTx := postgres.NewMockITransactionContext(ctrl)
+	mockTx.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockTx.EXPECT().Provider().Return(db).AnyTimes()
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTx)
+
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	leaderboard.logins = map[int64]string{1: "alice", 2: "bob", 3: "carol", 4: "dave"}
+	leaderboard.spins = []statsSpin{
+		{userID: 1, bet: 10, win: 30, createdAt: since},
+		{userID: 2, bet: 10, win: 40, createdAt: since},
+		{userID: 1, bet: 10, win: 30, createdAt: since.Add(time.Hour)},
+		{userID: 3, bet: 5, win: 50, createdAt: since.Add(time.Hour)},
+		{userID: 2, bet: 10, win: 1000, createdAt: since.Add(2 * time.Hour), voided: true},
+		{userID: 4, bet: 10, win: 500, createdAt: since.Add(2 * time.Hour), deletedAt: true},
+		{userID: 4, bet: 10, win: 20, createdAt: since.Add(3 * time.Hour)},
+		{userID: 4, bet: 10, win: 900, createdAt: since.Add(-time.Second)},
+	}
+
+	repo := NewSlotRepository(nil)
+	entries, err := repo.GetLeaderboard(ctx, &since, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.LeaderboardEntry{
+		{UserID: 1, Login: "alice", TotalWin: 60, TotalBet: 20},
+		{UserID: 3, Login: "carol", TotalWin: 50, TotalBet: 5},
+		{UserID: 2, Login: "bob", TotalWin: 40, TotalBet: 10},
+	}, entries)
+
+	entries, err = repo.GetLeaderboard(ctx, nil, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.LeaderboardEntry{
+		{UserID: 4, Login: "dave", TotalWin: 920, TotalBet: 20},
+	}, entries)
+}
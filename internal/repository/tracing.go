@@ -0,0 +1,355 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/tracing"
+)
+
+// tracedSlotRepository decorates an ISlotRepository with an OpenTelemetry span around every call.
+type tracedSlotRepository struct {
+	interfaces.ISlotRepository // Wrapped repository
+}
+
+// tracedUserRepository decorates an IUserRepository with an OpenTelemetry span around every call.
+type tracedUserRepository struct {
+	interfaces.IUserRepository // Wrapped repository
+}
+
+// tracedWalletRepository decorates an IWalletRepository with an OpenTelemetry span around every call.
+type tracedWalletRepository struct {
+	interfaces.IWalletRepository // Wrapped repository
+}
+
+// tracedLedgerRepository decorates an ILedgerRepository with an OpenTelemetry span around every call.
+type tracedLedgerRepository struct {
+	interfaces.ILedgerRepository // Wrapped repository
+}
+
+// NewTracedSlotRepository wraps the given ISlotRepository with spans when the trace export is
+// enabled; otherwise it returns the repository unchanged.
+func NewTracedSlotRepository(cfg *tracing.Config, slotRepository interfaces.ISlotRepository) interfaces.ISlotRepository {
+	if !cfg.Enabled() {
+		return slotRepository
+	}
+	return &tracedSlotRepository{ISlotRepository: slotRepository}
+}
+
+// NewTracedUserRepository wraps the given IUserRepository with spans when the trace export is
+// enabled; otherwise it returns the repository unchanged.
+func NewTracedUserRepository(cfg *tracing.Config, userRepository interfaces.IUserRepository) interfaces.IUserRepository {
+	if !cfg.Enabled() {
+		return userRepository
+	}
+	return &tracedUserRepository{IUserRepository: userRepository}
+}
+
+// NewTracedWalletRepository wraps the given IWalletRepository with spans when the trace export is
+// enabled; otherwise it returns the repository unchanged.
+func NewTracedWalletRepository(cfg *tracing.Config, walletRepository interfaces.IWalletRepository) interfaces.IWalletRepository {
+	if !cfg.Enabled() {
+		return walletRepository
+	}
+	return &tracedWalletRepository{IWalletRepository: walletRepository}
+}
+
+// NewTracedLedgerRepository wraps the given ILedgerRepository with spans when the trace export is
+// enabled; otherwise it returns the repository unchanged.
+func NewTracedLedgerRepository(cfg *tracing.Config, ledgerRepository interfaces.ILedgerRepository) interfaces.ILedgerRepository {
+	if !cfg.Enabled() {
+		return ledgerRepository
+	}
+	return &tracedLedgerRepository{ILedgerRepository: ledgerRepository}
+}
+
+// AddSpin delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) AddSpin(ctx context.Context, spin *models.Spin) error {
+	ctx, span := tracing.Start(ctx, "SlotRepository.AddSpin")
+	err := r.ISlotRepository.AddSpin(ctx, spin)
+	tracing.End(span, err)
+	return err
+}
+
+// AddSpins delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) AddSpins(ctx context.Context, spins []*models.Spin) error {
+	ctx, span := tracing.Start(ctx, "SlotRepository.AddSpins")
+	err := r.ISlotRepository.AddSpins(ctx, spins)
+	tracing.End(span, err)
+	return err
+}
+
+// GetSpins delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) GetSpins(ctx context.Context, userID uint, from, to *time.Time, limit, offset int) ([]*models.Spin, int64, error) {
+	ctx, span := tracing.Start(ctx, "SlotRepository.GetSpins")
+	spins, total, err := r.ISlotRepository.GetSpins(ctx, userID, from, to, limit, offset)
+	tracing.End(span, err)
+	return spins, total, err
+}
+
+// EachSpin delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) EachSpin(ctx context.Context, userID uint, from, to *time.Time, fn func(*models.Spin) error) error {
+	ctx, span := tracing.Start(ctx, "SlotRepository.EachSpin")
+	err := r.ISlotRepository.EachSpin(ctx, userID, from, to, fn)
+	tracing.End(span, err)
+	return err
+}
+
+// GetLeaderboard delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) GetLeaderboard(ctx context.Context, since *time.Time, limit int) ([]*models.LeaderboardEntry, error) {
+	ctx, span := tracing.Start(ctx, "SlotRepository.GetLeaderboard")
+	entries, err := r.ISlotRepository.GetLeaderboard(ctx, since, limit)
+	tracing.End(span, err)
+	return entries, err
+}
+
+// GetSpinStats delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) GetSpinStats(ctx context.Context, userID uint) (*models.SpinStats, error) {
+	ctx, span := tracing.Start(ctx, "SlotRepository.GetSpinStats")
+	stats, err := r.ISlotRepository.GetSpinStats(ctx, userID)
+	tracing.End(span, err)
+	return stats, err
+}
+
+// GetSpinForUpdate delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) GetSpinForUpdate(ctx context.Context, spinID uint) (*models.Spin, error) {
+	ctx, span := tracing.Start(ctx, "SlotRepository.GetSpinForUpdate")
+	spin, err := r.ISlotRepository.GetSpinForUpdate(ctx, spinID)
+	tracing.End(span, err)
+	return spin, err
+}
+
+// VoidSpin delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) VoidSpin(ctx context.Context, spinID uint, reason string, voidedAt time.Time) error {
+	ctx, span := tracing.Start(ctx, "SlotRepository.VoidSpin")
+	err := r.ISlotRepository.VoidSpin(ctx, spinID, reason, voidedAt)
+	tracing.End(span, err)
+	return err
+}
+
+// LastSpinHash delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) LastSpinHash(ctx context.Context, userID uint) (string, error) {
+	ctx, span := tracing.Start(ctx, "SlotRepository.LastSpinHash")
+	hash, err := r.ISlotRepository.LastSpinHash(ctx, userID)
+	tracing.End(span, err)
+	return hash, err
+}
+
+// EachChainedSpin delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) EachChainedSpin(ctx context.Context, userID uint, fn func(*models.Spin) error) error {
+	ctx, span := tracing.Start(ctx, "SlotRepository.EachChainedSpin")
+	err := r.ISlotRepository.EachChainedSpin(ctx, userID, fn)
+	tracing.End(span, err)
+	return err
+}
+
+// GetSpinStatsBetween delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) GetSpinStatsBetween(ctx context.Context, from, to time.Time) (*models.SpinStats, error) {
+	ctx, span := tracing.Start(ctx, "SlotRepository.GetSpinStatsBetween")
+	stats, err := r.ISlotRepository.GetSpinStatsBetween(ctx, from, to)
+	tracing.End(span, err)
+	return stats, err
+}
+
+// AddRTPReport delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) AddRTPReport(ctx context.Context, report *models.RTPReport) error {
+	ctx, span := tracing.Start(ctx, "SlotRepository.AddRTPReport")
+	err := r.ISlotRepository.AddRTPReport(ctx, report)
+	tracing.End(span, err)
+	return err
+}
+
+// DeleteSpinsBefore delegates to the wrapped repository within a span.
+func (r *tracedSlotRepository) DeleteSpinsBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ctx, span := tracing.Start(ctx, "SlotRepository.DeleteSpinsBefore")
+	deleted, err := r.ISlotRepository.DeleteSpinsBefore(ctx, before, limit)
+	tracing.End(span, err)
+	return deleted, err
+}
+
+// GetByLogin delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) GetByLogin(ctx context.Context, login string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByLogin")
+	user, err := r.IUserRepository.GetByLogin(ctx, login)
+	tracing.End(span, err)
+	return user, err
+}
+
+// Create delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Create")
+	created, err := r.IUserRepository.Create(ctx, user)
+	tracing.End(span, err)
+	return created, err
+}
+
+// GetByExternalID delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) GetByExternalID(ctx context.Context, id *uuid.UUID) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByExternalID")
+	user, err := r.IUserRepository.GetByExternalID(ctx, id)
+	tracing.End(span, err)
+	return user, err
+}
+
+// GetByID delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByID")
+	user, err := r.IUserRepository.GetByID(ctx, id)
+	tracing.End(span, err)
+	return user, err
+}
+
+// GetUsers delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) GetUsers(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int64, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetUsers")
+	users, total, err := r.IUserRepository.GetUsers(ctx, filter, limit, offset)
+	tracing.End(span, err)
+	return users, total, err
+}
+
+// UpdateLogin delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) UpdateLogin(ctx context.Context, userID uint, login string) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdateLogin")
+	err := r.IUserRepository.UpdateLogin(ctx, userID, login)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateAutoStopWin delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) UpdateAutoStopWin(ctx context.Context, userID uint, autoStopWin *float64) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdateAutoStopWin")
+	err := r.IUserRepository.UpdateAutoStopWin(ctx, userID, autoStopWin)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateFrozen delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) UpdateFrozen(ctx context.Context, userID uint, frozen bool) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdateFrozen")
+	err := r.IUserRepository.UpdateFrozen(ctx, userID, frozen)
+	tracing.End(span, err)
+	return err
+}
+
+// ExtendExclusion delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) ExtendExclusion(ctx context.Context, userID uint, until time.Time) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.ExtendExclusion")
+	err := r.IUserRepository.ExtendExclusion(ctx, userID, until)
+	tracing.End(span, err)
+	return err
+}
+
+// UpdateDepositLimits delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) UpdateDepositLimits(ctx context.Context, userID uint, limits, pending models.DepositLimits, pendingAt *time.Time) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdateDepositLimits")
+	err := r.IUserRepository.UpdateDepositLimits(ctx, userID, limits, pending, pendingAt)
+	tracing.End(span, err)
+	return err
+}
+
+// Deposit delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) Deposit(ctx context.Context, userID uint, amount float64) (*float64, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Deposit")
+	balance, err := r.IUserRepository.Deposit(ctx, userID, amount)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// Withdraw delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) Withdraw(ctx context.Context, userID uint, amount float64) (*float64, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Withdraw")
+	balance, err := r.IUserRepository.Withdraw(ctx, userID, amount)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// Debit delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) Debit(ctx context.Context, userID uint, amount float64) (*float64, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Debit")
+	balance, err := r.IUserRepository.Debit(ctx, userID, amount)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// LockUsers delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) LockUsers(ctx context.Context, userIDs ...uint) error {
+	ctx, span := tracing.Start(ctx, "UserRepository.LockUsers")
+	err := r.IUserRepository.LockUsers(ctx, userIDs...)
+	tracing.End(span, err)
+	return err
+}
+
+// ApplySpinResult delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) ApplySpinResult(ctx context.Context, userID uint, bet, win float64) (*float64, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.ApplySpinResult")
+	balance, err := r.IUserRepository.ApplySpinResult(ctx, userID, bet, win)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// AddFreeSpins delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) AddFreeSpins(ctx context.Context, userID uint, count int) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.AddFreeSpins")
+	freeSpins, err := r.IUserRepository.AddFreeSpins(ctx, userID, count)
+	tracing.End(span, err)
+	return freeSpins, err
+}
+
+// UseFreeSpin delegates to the wrapped repository within a span.
+func (r *tracedUserRepository) UseFreeSpin(ctx context.Context, userID uint) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.UseFreeSpin")
+	freeSpins, err := r.IUserRepository.UseFreeSpin(ctx, userID)
+	tracing.End(span, err)
+	return freeSpins, err
+}
+
+// GetBalance delegates to the wrapped repository within a span.
+func (r *tracedWalletRepository) GetBalance(ctx context.Context, userID uint) (float64, error) {
+	ctx, span := tracing.Start(ctx, "WalletRepository.GetBalance")
+	balance, err := r.IWalletRepository.GetBalance(ctx, userID)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// GetWallets delegates to the wrapped repository within a span.
+func (r *tracedWalletRepository) GetWallets(ctx context.Context, userID uint) ([]*models.Wallet, error) {
+	ctx, span := tracing.Start(ctx, "WalletRepository.GetWallets")
+	wallets, err := r.IWalletRepository.GetWallets(ctx, userID)
+	tracing.End(span, err)
+	return wallets, err
+}
+
+// GetBalanceByExternalID delegates to the wrapped repository within a span.
+func (r *tracedWalletRepository) GetBalanceByExternalID(ctx context.Context, userID *uuid.UUID) (float64, error) {
+	ctx, span := tracing.Start(ctx, "WalletRepository.GetBalanceByExternalID")
+	balance, err := r.IWalletRepository.GetBalanceByExternalID(ctx, userID)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// GetWalletBalanceByExternalID delegates to the wrapped repository within a span.
+func (r *tracedWalletRepository) GetWalletBalanceByExternalID(ctx context.Context, userID *uuid.UUID, currency string) (float64, error) {
+	ctx, span := tracing.Start(ctx, "WalletRepository.GetWalletBalanceByExternalID")
+	balance, err := r.IWalletRepository.GetWalletBalanceByExternalID(ctx, userID, currency)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// AddEntry delegates to the wrapped repository within a span.
+func (r *tracedLedgerRepository) AddEntry(ctx context.Context, entry *models.LedgerEntry) error {
+	ctx, span := tracing.Start(ctx, "LedgerRepository.AddEntry")
+	err := r.ILedgerRepository.AddEntry(ctx, entry)
+	tracing.End(span, err)
+	return err
+}
+
+// SumEntries delegates to the wrapped repository within a span.
+func (r *tracedLedgerRepository) SumEntries(ctx context.Context, userID uint, entryType string, since time.Time) (float64, error) {
+	ctx, span := tracing.Start(ctx, "LedgerRepository.SumEntries")
+	sum, err := r.ILedgerRepository.SumEntries(ctx, userID, entryType, since)
+	tracing.End(span, err)
+	return sum, err
+}
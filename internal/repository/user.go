@@ -2,17 +2,24 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/vadymlab/slot-game/internal/database"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
 	"github.com/vadymlab/slot-game/internal/interfaces"
 	"github.com/vadymlab/slot-game/internal/models"
+	"strings"
+	"time"
 )
 
 // userRepository implements IUserRepository interface for accessing
 // and managing user-related data in the database.
-type userRepository struct{}
+type userRepository struct {
+	reads *database.ReadRouter // Router serving the user lookups of read-only requests from the replica
+}
 
 // GetByID retrieves a user by their numeric ID.
 //
@@ -42,7 +49,8 @@ func (r *userRepository) GetByID(ctx context.Context, uid uint) (*models.User, e
 	return user, tr.Commit(id)
 }
 
-// GetByExternalID retrieves a user by their UUID identifier.
+// GetByExternalID retrieves a user by their UUID identifier. The lookups of read-only requests
+// are served by the read replica when one is configured.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
@@ -59,7 +67,7 @@ func (r *userRepository) GetByExternalID(ctx context.Context, userID *uuid.UUID)
 	}
 
 	user := &models.User{}
-	result := tr.Provider().Model(&models.User{}).Where("external_id = ?", userID).First(user)
+	result := r.reads.DB(ctx, tr.Provider()).Model(&models.User{}).Where("external_id = ?", userID).First(user)
 	if err := result.Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -70,6 +78,66 @@ func (r *userRepository) GetByExternalID(ctx context.Context, userID *uuid.UUID)
 	return user, tr.Commit(id)
 }
 
+// userSortColumns maps the orders of the admin user list to the columns they sort by. Only these
+// columns ever reach the ORDER BY clause, so the order requested by a client cannot inject SQL.
+var userSortColumns = map[string]string{
+	models.UserSortCreated: "created_at",
+	models.UserSortBalance: "balance",
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern, so that a search matches them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// GetUsers retrieves a page of the users whose login contains the search, ordered as the filter
+// asks, together with the total number of matching users. Users with the same balance or creation
+// time are ordered by ID, so that the pages do not overlap. The search is passed as a parameter with
+// its wildcards escaped. The users of read-only requests are served by the read replica when one is
+// configured.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - filter: The search and order of the users.
+//   - limit: The maximum number of users to return.
+//   - offset: The number of users to skip.
+//
+// Returns:
+//   - A slice of pointers to User models representing the requested page.
+//   - The total number of users matching the filter.
+//   - An error if the transaction or retrieval fails; otherwise, nil.
+func (r *userRepository) GetUsers(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := r.reads.DB(ctx, tr.Provider()).Model(&models.User{})
+	if filter.Search != "" {
+		query = query.Where("login LIKE ?", "%"+likeEscaper.Replace(filter.Search)+"%")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+
+	column, ok := userSortColumns[filter.SortBy]
+	if !ok {
+		column = userSortColumns[models.UserSortCreated]
+	}
+	direction := " DESC"
+	if filter.Ascending {
+		direction = " ASC"
+	}
+	var users []*models.User
+	result := query.Order(column + direction).Order("id" + direction).Limit(limit).Offset(offset).Find(&users)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	return users, total, tr.Commit(id)
+}
+
 // Create inserts a new user record into the database.
 //
 // Parameters:
@@ -78,6 +146,7 @@ func (r *userRepository) GetByExternalID(ctx context.Context, userID *uuid.UUID)
 //
 // Returns:
 //   - A pointer to the created User model.
+//   - ErrUserExists if a user with the same login already exists.
 //   - An error if the creation fails.
 func (r *userRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
 	tr, _ := postgres.GetTransactionContext(ctx)
@@ -89,16 +158,21 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) (*models
 	result := tr.Provider().Create(&user)
 	if err := result.Error; err != nil {
 		_ = tr.Rollback()
+		if isUniqueViolation(err) {
+			return nil, serviceError.ErrUserExists
+		}
 		return nil, err
 	}
 	return user, tr.Commit(id)
 }
 
-// GetByLogin retrieves a user by their login name.
+// GetByLogin retrieves a user by their login name. The login column holds either the email
+// address or, when usernames are allowed, the username the user registered with, so both
+// are matched by the same lookup. The login is normalized first, so any capitalization matches.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
-//   - login: The login name of the user.
+//   - login: The login name of the user, an email address or a username.
 //
 // Returns:
 //   - A pointer to a User model if found, or nil if not found.
@@ -111,7 +185,7 @@ func (r *userRepository) GetByLogin(ctx context.Context, login string) (*models.
 	}
 
 	user := &models.User{}
-	result := tr.Provider().Model(&models.User{}).Where("login = ?", login).First(user)
+	result := tr.Provider().Model(&models.User{}).Where("login = ?", models.NormalizeLogin(login)).First(user)
 	if err := result.Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -122,6 +196,141 @@ func (r *userRepository) GetByLogin(ctx context.Context, login string) (*models.
 	return user, tr.Commit(id)
 }
 
+// UpdateLogin changes the login of a specified user.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//   - login: The new login name.
+//
+// Returns:
+//   - ErrUserExists if another user already has the login.
+//   - An error if the update fails; otherwise, nil.
+func (r *userRepository) UpdateLogin(ctx context.Context, userID uint, login string) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.User{}).Where("id = ?", userID).Update("login", login)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		if isUniqueViolation(err) {
+			return serviceError.ErrUserExists
+		}
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// UpdateAutoStopWin changes the auto-stop threshold of a specified user.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//   - autoStopWin: The new threshold; nil clears the column so that the configured default applies.
+//
+// Returns:
+//   - An error if the update fails; otherwise, nil.
+func (r *userRepository) UpdateAutoStopWin(ctx context.Context, userID uint, autoStopWin *float64) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.User{}).Where("id = ?", userID).Update("auto_stop_win", autoStopWin)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// ExtendExclusion sets the end of a specified user's self-exclusion. An exclusion that already
+// ends later is kept, so that a running exclusion can be extended but never shortened.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//   - until: The requested end of the self-exclusion.
+//
+// Returns:
+//   - An error if the update fails; otherwise, nil.
+func (r *userRepository) ExtendExclusion(ctx context.Context, userID uint, until time.Time) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.User{}).Where("id = ?", userID).
+		Update("excluded_until", gorm.Expr("GREATEST(COALESCE(excluded_until, ?), ?)", until, until))
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// UpdateFrozen freezes or unfreezes the account of a specified user.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//   - frozen: Whether the account is frozen.
+//
+// Returns:
+//   - An error if the update fails; otherwise, nil.
+func (r *userRepository) UpdateFrozen(ctx context.Context, userID uint, frozen bool) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.User{}).Where("id = ?", userID).Update("frozen", frozen)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// UpdateDepositLimits replaces the deposit limits of a specified user, along with the raised or
+// removed limits waiting out their cooldown. Nil limits are stored as NULL, leaving deposits unlimited.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//   - limits: The deposit limits applying right away.
+//   - pending: The deposit limits applying from pendingAt.
+//   - pendingAt: The time the pending limits apply from; nil if none are pending.
+//
+// Returns:
+//   - An error if the update fails; otherwise, nil.
+func (r *userRepository) UpdateDepositLimits(ctx context.Context, userID uint, limits, pending models.DepositLimits, pendingAt *time.Time) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"deposit_limit_per_transaction":         limits.PerTransaction,
+		"deposit_limit_daily":                   limits.Daily,
+		"pending_deposit_limit_per_transaction": pending.PerTransaction,
+		"pending_deposit_limit_daily":           pending.Daily,
+		"deposit_limits_pending_at":             pendingAt,
+	})
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
 // Deposit increases the balance of a specified user.
 //
 // Parameters:
@@ -150,46 +359,207 @@ func (r *userRepository) Withdraw(ctx context.Context, userID uint, amount float
 	return r.updateBalance(ctx, userID, -amount)
 }
 
-// updateBalance modifies the balance of a specified user by the given amount.
+// Debit decreases the balance of a specified user, provided the balance covers the amount. The
+// balance is checked in the same UPDATE, so concurrent debits can never take it below zero, and a
+// NULL balance is treated as zero.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
 //   - userId: The unique numeric ID of the user.
-//   - amount: The amount to be added to or deducted from the balance.
+//   - amount: The amount to be deducted from the user's balance.
 //
 // Returns:
 //   - A pointer to the updated balance as a float64.
+//   - ErrInsufficientFunds if the balance does not cover the amount or the user does not exist.
 //   - An error if the update fails.
-func (r *userRepository) updateBalance(ctx context.Context, userID uint, amount float64) (*float64, error) {
+func (r *userRepository) Debit(ctx context.Context, userID uint, amount float64) (*float64, error) {
 	tr, _ := postgres.GetTransactionContext(ctx)
 	id, err := tr.Begin()
 	if err != nil {
 		return nil, err
 	}
 
-	user := &models.User{}
-	result := tr.Provider().Model(user).Where("id = ?", userID).First(user)
-	if err := result.Error; err != nil {
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			_ = tr.Rollback()
-			return nil, err
+	var balance float64
+	err = tr.Provider().Raw(
+		"UPDATE users SET balance = COALESCE(balance, 0) - ?, updated_at = NOW() "+
+			"WHERE id = ? AND deleted_at IS NULL AND COALESCE(balance, 0) >= ? RETURNING balance",
+		amount, userID, amount,
+	).Row().Scan(&balance)
+	if err != nil {
+		_ = tr.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceError.ErrInsufficientFunds
 		}
+		return nil, err
+	}
+	return &balance, tr.Commit(id)
+}
+
+// LockUsers locks the rows of the specified users with SELECT ... FOR UPDATE until the surrounding
+// transaction ends. The rows are locked in ID order, so that transactions locking the same users
+// in any order cannot deadlock.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userIDs: The unique numeric IDs of the users.
+//
+// Returns:
+//   - An error if the transaction or the lock fails; otherwise, nil.
+func (r *userRepository) LockUsers(ctx context.Context, userIDs ...uint) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
 	}
-	user.Balance += amount
 
-	result = tr.Provider().Model(&user).
-		Where("id = ?", userID).
-		Update("balance", user.Balance).
-		Select("balance").
-		Scan(&user)
+	result := tr.Provider().Exec("SELECT id FROM users WHERE id IN (?) ORDER BY id FOR UPDATE", userIDs)
 	if err := result.Error; err != nil {
 		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// ApplySpinResult settles a spin by changing the balance of a specified user by the win minus the bet.
+// The bet is checked against the balance in the same UPDATE, so the balance can never be taken below
+// zero by concurrent spins, and a NULL balance is treated as zero.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//   - bet: The amount bet on the spin.
+//   - win: The amount won by the spin.
+//
+// Returns:
+//   - A pointer to the updated balance as a float64.
+//   - ErrInsufficientFunds if the balance does not cover the bet or the user does not exist.
+//   - An error if the update fails.
+func (r *userRepository) ApplySpinResult(ctx context.Context, userID uint, bet, win float64) (*float64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var balance float64
+	err = tr.Provider().Raw(
+		"UPDATE users SET balance = COALESCE(balance, 0) + ?, updated_at = NOW() "+
+			"WHERE id = ? AND deleted_at IS NULL AND COALESCE(balance, 0) >= ? RETURNING balance",
+		win-bet, userID, bet,
+	).Row().Scan(&balance)
+	if err != nil {
+		_ = tr.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceError.ErrInsufficientFunds
+		}
+		return nil, err
+	}
+	return &balance, tr.Commit(id)
+}
+
+// AddFreeSpins adds free spins to the free spins of a specified user in a single update.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//   - count: The number of free spins to add.
+//
+// Returns:
+//   - The free spins of the user after the update.
+//   - ErrUserNotFound if the user does not exist.
+//   - An error if the update fails.
+func (r *userRepository) AddFreeSpins(ctx context.Context, userID uint, count int) (int, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var freeSpins int
+	err = tr.Provider().Raw(
+		"UPDATE users SET free_spins = free_spins + ?, updated_at = NOW() WHERE id = ? AND deleted_at IS NULL RETURNING free_spins",
+		count, userID,
+	).Row().Scan(&freeSpins)
+	if err != nil {
+		_ = tr.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, serviceError.ErrUserNotFound
+		}
+		return 0, err
+	}
+	return freeSpins, tr.Commit(id)
+}
+
+// UseFreeSpin takes one free spin from the free spins of a specified user in a single update that
+// only applies while the user has free spins left, so that concurrent free spins cannot use the
+// same free spin twice.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//
+// Returns:
+//   - The free spins of the user left after the update.
+//   - ErrNoFreeSpins if the user has no free spins left or does not exist.
+//   - An error if the update fails.
+func (r *userRepository) UseFreeSpin(ctx context.Context, userID uint) (int, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var freeSpins int
+	err = tr.Provider().Raw(
+		"UPDATE users SET free_spins = free_spins - 1, updated_at = NOW() WHERE id = ? AND deleted_at IS NULL AND free_spins > 0 RETURNING free_spins",
+		userID,
+	).Row().Scan(&freeSpins)
+	if err != nil {
+		_ = tr.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, serviceError.ErrNoFreeSpins
+		}
+		return 0, err
+	}
+	return freeSpins, tr.Commit(id)
+}
+
+// updateBalance modifies the balance of a specified user by the given amount.
+// The change is applied atomically in the database, and a NULL balance is treated as zero.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//   - amount: The amount to be added to or deducted from the balance.
+//
+// Returns:
+//   - A pointer to the updated balance as a float64.
+//   - ErrUserNotFound if the user does not exist.
+//   - An error if the update fails.
+func (r *userRepository) updateBalance(ctx context.Context, userID uint, amount float64) (*float64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var balance float64
+	err = tr.Provider().Raw(
+		"UPDATE users SET balance = COALESCE(balance, 0) + ?, updated_at = NOW() WHERE id = ? AND deleted_at IS NULL RETURNING balance",
+		amount, userID,
+	).Row().Scan(&balance)
+	if err != nil {
+		_ = tr.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceError.ErrUserNotFound
+		}
 		return nil, err
 	}
-	return &user.Balance, tr.Commit(id)
+	return &balance, tr.Commit(id)
 }
 
 // NewUserRepository creates and returns a new instance of userRepository.
-func NewUserRepository() interfaces.IUserRepository {
-	return &userRepository{}
+// The user lookups of read-only requests are read through the given router.
+func NewUserRepository(reads *database.ReadRouter) interfaces.IUserRepository {
+	return &userRepository{reads: reads}
 }
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// balanceDriver is a database/sql driver simulating the balance column of a single user.
+// A nil balance represents NULL; COALESCE(balance, 0) updates are applied to it and the
+// new balance is returned, as Postgres does for UPDATE ... RETURNING. A floor condition
+// on the balance, as used by ApplySpinResult and Debit, leaves the balance unchanged when
+// not met.
+type balanceDriver struct {
+	mu      sync.Mutex
+	exists  bool
+	balance *float64
+}
+
+func (d *balanceDriver) Open(string) (driver.Conn, error) { return &balanceConn{driver: d}, nil }
+
+type balanceConn struct{ driver *balanceDriver }
+
+func (c *balanceConn) Prepare(query string) (driver.Stmt, error) {
+	return &balanceStmt{driver: c.driver, query: query}, nil
+}
+func (c *balanceConn) Close() error              { return nil }
+func (c *balanceConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *balanceConn) Commit() error             { return nil }
+func (c *balanceConn) Rollback() error           { return nil }
+
+type balanceStmt struct {
+	driver *balanceDriver
+	query  string
+}
+
+func (s *balanceStmt) Close() error  { return nil }
+func (s *balanceStmt) NumInput() int { return -1 }
+func (s *balanceStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (s *balanceStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+	rows := &balanceRows{}
+	credit := strings.Contains(s.query, "COALESCE(balance, 0) + $1")
+	if !s.driver.exists || !credit && !strings.Contains(s.query, "COALESCE(balance, 0) - $1") {
+		return rows, nil
+	}
+	var current float64
+	if s.driver.balance != nil {
+		current = *s.driver.balance
+	}
+	if strings.Contains(s.query, "COALESCE(balance, 0) >= $3") && current < args[2].(float64) {
+		return rows, nil
+	}
+	balance := current + args[0].(float64)
+	if !credit {
+		balance = current - args[0].(float64)
+	}
+	s.driver.balance = &balance
+	rows.values = []driver.Value{balance}
+	return rows, nil
+}
+
+type balanceRows struct{ values []driver.Value }
+
+func (r *balanceRows) Columns() []string { return []string{"balance"} }
+func (r *balanceRows) Close() error      { return nil }
+func (r *balanceRows) Next(dest []driver.Value) error {
+	if r.values == nil {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.values = nil
+	return nil
+}
+
+var (
+	balances             = &balanceDriver{}
+	registerBalancesOnce sync.Once
+)
+
+// newBalanceContext returns a context whose transaction provider is backed by the balance driver.
+func newBalanceContext(t *testing.T, ctrl *gomock.Controller) context.Context {
+	registerBalancesOnce.Do(func() { sql.Register("balances", balances) })
+	sqlDB, err := sql.Open("balances", "")
+	assert.NoError(t, err)
+	db, err := gorm.Open("postgres", sqlDB)
+	assert.NoError(t, err)
+
+	mockTx := postgres.NewMockITransactionContext(ctrl)
+	mockTx.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockTx.EXPECT().Rollback().Return(nil).AnyTimes()
+	mockTx.EXPECT().Provider().Return(db).AnyTimes()
+	return context.WithValue(context.Background(), postgres.TransactionContextKey, mockTx)
+}
+
+func TestDeposit_NullBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newBalanceContext(t, ctrl)
+	balances.exists, balances.balance = true, nil
+
+	balance, err := NewUserRepository(nil).Deposit(ctx, 1, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, *balance)
+
+	balance, err = NewUserRepository(nil).Withdraw(ctx, 1, 30)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 70.0, *balance)
+}
+
+func TestDeposit_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newBalanceContext(t, ctrl)
+	balances.exists, balances.balance = false, nil
+
+	_, err := NewUserRepository(nil).Deposit(ctx, 1, 100)
+
+	assert.ErrorIs(t, err, serviceError.ErrUserNotFound)
+}
+
+func TestApplySpinResult_NetBalance(t *testing.T) {
+	testCases := []struct {
+		name     string
+		bet      float64
+		win      float64
+		expected float64
+	}{
+		{"Winning", 10, 25, 115},
+		{"Losing", 10, 0, 90},
+		{"BreakEven", 10, 10, 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := newBalanceContext(t, ctrl)
+			start := 100.0
+			balances.exists, balances.balance = true, &start
+
+			balance, err := NewUserRepository(nil).ApplySpinResult(ctx, 1, tc.bet, tc.win)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, *balance)
+			assert.Equal(t, tc.expected, *balances.balance)
+		})
+	}
+}
+
+func TestApplySpinResult_InsufficientFunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newBalanceContext(t, ctrl)
+	start := 5.0
+	balances.exists, balances.balance = true, &start
+
+	// The win would cover the bet, but the bet must be covered by the balance alone
+	balance, err := NewUserRepository(nil).ApplySpinResult(ctx, 1, 10, 50)
+
+	assert.ErrorIs(t, err, serviceError.ErrInsufficientFunds)
+	assert.Nil(t, balance)
+	assert.Equal(t, 5.0, *balances.balance)
+}
+
+func TestDebit_GuardedByBalance(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   float64
+		wantErr  error
+		expected float64
+	}{
+		{"Covered", 30, nil, 70},
+		{"WholeBalance", 100, nil, 0},
+		{"InsufficientFunds", 130, serviceError.ErrInsufficientFunds, 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := newBalanceContext(t, ctrl)
+			start := 100.0
+			balances.exists, balances.balance = true, &start
+
+			balance, err := NewUserRepository(nil).Debit(ctx, 1, tc.amount)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, balance)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, *balance)
+			}
+			// A refused debit leaves the balance unchanged
+			assert.Equal(t, tc.expected, *balances.balance)
+		})
+	}
+}
+
+// TestLockUsers_LocksInIDOrder checks that the rows are locked in ID order, so that two
+// transfers between the same users lock them in the same order whatever their direction.
+func TestLockUsers_LocksInIDOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	err := NewUserRepository(nil).LockUsers(ctx, 2, 1)
+
+	assert.NoError(t, err)
+	queries := recorder.recorded()[before:]
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "WHERE id IN ($1,$2)")
+	assert.Contains(t, queries[0], "ORDER BY id FOR UPDATE")
+}
+
+// TestUseFreeSpin_NoFreeSpinsLeft checks that a free spin is only taken while the user has one
+// left, so that the update matching no row is reported as ErrNoFreeSpins.
+func TestUseFreeSpin_NoFreeSpinsLeft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	freeSpins, err := NewUserRepository(nil).UseFreeSpin(ctx, 42)
+
+	assert.ErrorIs(t, err, serviceError.ErrNoFreeSpins)
+	assert.Zero(t, freeSpins)
+	queries := recorder.recorded()[before:]
+	assert.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "free_spins = free_spins - 1")
+	assert.Contains(t, queries[0], "AND free_spins > 0 RETURNING free_spins")
+}
+
+// TestGetUsers_SearchesAndSortsSafely checks that the search is passed as a query parameter and the
+// requested order only ever selects a known column, with the ID breaking ties between pages.
+func TestGetUsers_SearchesAndSortsSafely(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := newRecordingContext(t, ctrl)
+	before := len(recorder.recorded())
+
+	filter := &models.UserFilter{Search: "bob'; DROP TABLE users; --", SortBy: models.UserSortBalance}
+	_, total, err := NewUserRepository(nil).GetUsers(ctx, filter, 20, 40)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	queries := recorder.recorded()[before:]
+	require.Len(t, queries, 2)
+	for _, query := range queries {
+		assert.Contains(t, query, "login LIKE $1")
+		assert.NotContains(t, query, "DROP TABLE")
+	}
+	assert.Contains(t, queries[1], "ORDER BY balance DESC,id DESC")
+	assert.Contains(t, queries[1], "LIMIT 20 OFFSET 40")
+
+	// An unknown order falls back to the creation time, and an empty search matches all users
+	before = len(recorder.recorded())
+	_, _, err = NewUserRepository(nil).GetUsers(ctx, &models.UserFilter{SortBy: "password", Ascending: true}, 20, 0)
+
+	assert.NoError(t, err)
+	queries = recorder.recorded()[before:]
+	require.Len(t, queries, 2)
+	assert.NotContains(t, queries[1], "LIKE")
+	assert.Contains(t, queries[1], "ORDER BY created_at ASC,id ASC")
+}
+
+func TestLikeEscaper_EscapesWildcards(t *testing.T) {
+	assert.Equal(t, `50\%\_off\\`, likeEscaper.Replace(`50%_off\`))
+	assert.Equal(t, "bob", likeEscaper.Replace("bob"))
+}
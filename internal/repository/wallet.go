@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// walletRepository implements the IWalletRepository interface for reading
+// user balances in the database.
+type walletRepository struct{}
+
+// GetBalance retrieves the base currency balance of a specified user, reading only the balance column.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//
+// Returns:
+//   - The user's balance.
+//   - ErrUserNotFound if the user does not exist, or an error if the retrieval fails.
+func (r *walletRepository) GetBalance(ctx context.Context, userID uint) (float64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var balance float64
+	err = tr.Provider().Raw("SELECT balance FROM users WHERE id = ? AND deleted_at IS NULL", userID).Row().Scan(&balance)
+	if err != nil {
+		_ = tr.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, serviceError.ErrUserNotFound
+		}
+		return 0, err
+	}
+	return balance, tr.Commit(id)
+}
+
+// GetBalanceByExternalID retrieves the base currency balance of a user identified by their UUID,
+// reading only the balance column.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - The user's balance.
+//   - ErrUserNotFound if the user does not exist, or an error if the retrieval fails.
+func (r *walletRepository) GetBalanceByExternalID(ctx context.Context, userID *uuid.UUID) (float64, error) {
+	return r.scanBalance(ctx, "SELECT balance FROM users WHERE external_id = ? AND deleted_at IS NULL", userID)
+}
+
+// GetWalletBalanceByExternalID retrieves the balance of the wallet a user identified by their UUID
+// holds in a currency other than the base currency, reading only the balance column. A user holding
+// no wallet in the currency has a balance of 0.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - currency: The ISO 4217 code of the wallet's currency.
+//
+// Returns:
+//   - The wallet's balance.
+//   - ErrUserNotFound if the user does not exist, or an error if the retrieval fails.
+func (r *walletRepository) GetWalletBalanceByExternalID(ctx context.Context, userID *uuid.UUID, currency string) (float64, error) {
+	return r.scanBalance(ctx, `SELECT COALESCE(wallets.balance, 0) FROM users
+	LEFT JOIN wallets ON wallets.user_id = users.id AND wallets.currency = ? AND wallets.deleted_at IS NULL
+	WHERE users.external_id = ? AND users.deleted_at IS NULL`, currency, userID)
+}
+
+// scanBalance runs a query selecting a single balance of a user; no row means the user does not exist.
+func (r *walletRepository) scanBalance(ctx context.Context, query string, args ...interface{}) (float64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var balance float64
+	err = tr.Provider().Raw(query, args...).Row().Scan(&balance)
+	if err != nil {
+		_ = tr.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, serviceError.ErrUserNotFound
+		}
+		return 0, err
+	}
+	return balance, tr.Commit(id)
+}
+
+// GetWallets retrieves the wallets a user holds in currencies other than the base currency,
+// ordered by currency.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The unique numeric ID of the user.
+//
+// Returns:
+//   - A slice of pointers to the user's Wallet models.
+//   - An error if the retrieval fails.
+func (r *walletRepository) GetWallets(ctx context.Context, userID uint) ([]*models.Wallet, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var wallets []*models.Wallet
+	result := tr.Provider().Where("user_id = ?", userID).Order("currency").Find(&wallets)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return wallets, tr.Commit(id)
+}
+
+// NewWalletRepository initializes and returns a new instance of walletRepository,
+// implementing the IWalletRepository interface for balance reads.
+func NewWalletRepository() interfaces.IWalletRepository {
+	return &walletRepository{}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"time"
+)
+
+// withdrawalRepository implements the IWithdrawalRepository interface for storing
+// withdrawals awaiting admin approval.
+type withdrawalRepository struct{}
+
+// AddWithdrawal records a new pending withdrawal in the database.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - withdrawal: A pointer to the PendingWithdrawal model instance to be recorded.
+//
+// Returns:
+//   - An error if the transaction or withdrawal creation fails; otherwise, nil.
+func (r withdrawalRepository) AddWithdrawal(ctx context.Context, withdrawal *models.PendingWithdrawal) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Create(withdrawal)
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// GetWithdrawalForUpdate retrieves a withdrawal by its numeric ID, locking the row with
+// SELECT ... FOR UPDATE so that concurrent decisions on the same withdrawal are serialized.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - withdrawalID: The unique numeric ID of the withdrawal.
+//
+// Returns:
+//   - A pointer to a PendingWithdrawal model if found, or nil if not found.
+//   - An error if the retrieval fails.
+func (r withdrawalRepository) GetWithdrawalForUpdate(ctx context.Context, withdrawalID uint) (*models.PendingWithdrawal, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawal := &models.PendingWithdrawal{}
+	result := tr.Provider().Set("gorm:query_option", "FOR UPDATE").Where("id = ?", withdrawalID).First(withdrawal)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return withdrawal, tr.Commit(id)
+}
+
+// DecideWithdrawal moves a withdrawal to its final status. The update only applies to a
+// withdrawal that is still pending, so a concurrent or repeated decision never succeeds twice.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - withdrawalID: The unique numeric ID of the withdrawal.
+//   - status: The final status, approved or rejected.
+//   - reason: The reason for the decision, if any.
+//   - decidedAt: The time of the decision.
+//
+// Returns:
+//   - ErrWithdrawalNotPending if the withdrawal has already been decided on.
+//   - An error if the update fails; otherwise, nil.
+func (r withdrawalRepository) DecideWithdrawal(ctx context.Context, withdrawalID uint, status, reason string, decidedAt time.Time) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+
+	result := tr.Provider().Model(&models.PendingWithdrawal{}).
+		Where("id = ? AND status = ?", withdrawalID, models.WithdrawalStatusPending).
+		Updates(map[string]interface{}{"status": status, "reason": reason, "decided_at": decidedAt})
+	if err := result.Error; err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	if result.RowsAffected == 0 {
+		_ = tr.Rollback()
+		return serviceError.ErrWithdrawalNotPending
+	}
+	return tr.Commit(id)
+}
+
+// NewWithdrawalRepository creates and returns a new instance of withdrawalRepository.
+func NewWithdrawalRepository() interfaces.IWithdrawalRepository {
+	return &withdrawalRepository{}
+}
@@ -0,0 +1,60 @@
+package retention
+
+import "github.com/urfave/cli/v2"
+
+// Constants defining the retention configuration flags.
+const (
+	spinRetentionDays = "spin-retention-days"
+	spinPruneInterval = "spin-prune-interval"
+	spinPruneBatch    = "spin-prune-batch-size"
+)
+
+// Config represents the retention policy of the spin history and the schedule of the pruning job.
+type Config struct {
+	SpinRetentionDays int // Number of days spins are kept; 0 keeps spins forever
+	PruneInterval     int // Interval between periodic pruning runs in minutes; 0 disables the periodic job
+	BatchSize         int // Maximum number of spins deleted by a single statement
+}
+
+// Enabled reports whether spins are pruned at all.
+func (c *Config) Enabled() bool {
+	return c.SpinRetentionDays > 0
+}
+
+// GetRetentionConfig reads the retention settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the retention settings.
+func GetRetentionConfig(c *cli.Context) *Config {
+	return &Config{
+		SpinRetentionDays: c.Int(spinRetentionDays),
+		PruneInterval:     c.Int(spinPruneInterval),
+		BatchSize:         c.Int(spinPruneBatch),
+	}
+}
+
+// Flags defines the CLI flags available for configuring the spin retention policy.
+var Flags = []cli.Flag{
+	&cli.IntFlag{
+		Name:    spinRetentionDays,
+		Value:   0,
+		Usage:   "Number of days spins are kept before they are pruned, e.g. 90; 0 keeps spins forever",
+		EnvVars: []string{"SPIN_RETENTION_DAYS"},
+	},
+	&cli.IntFlag{
+		Name:    spinPruneInterval,
+		Value:   60,
+		Usage:   "Interval between periodic spin pruning runs in minutes; 0 disables the periodic job",
+		EnvVars: []string{"SPIN_PRUNE_INTERVAL"},
+	},
+	&cli.IntFlag{
+		Name:    spinPruneBatch,
+		Value:   1000,
+		Usage:   "Maximum number of spins deleted by a single pruning statement",
+		EnvVars: []string{"SPIN_PRUNE_BATCH_SIZE"},
+	},
+}
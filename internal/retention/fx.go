@@ -0,0 +1,32 @@
+package retention
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the retention configuration and the spin Pruner as an Fx module.
+var Module = fx.Options(
+	fx.Provide(GetRetentionConfig),
+	fx.Provide(NewPruner),
+)
+
+// Scheduler starts the periodic pruning job with the application and stops it on shutdown.
+// The job is not started when retention or the periodic job is disabled.
+var Scheduler = fx.Invoke(func(lc fx.Lifecycle, config *Config, pruner *Pruner) {
+	if !config.Enabled() || config.PruneInterval <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go pruner.Start(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+})
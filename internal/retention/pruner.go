@@ -0,0 +1,122 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/advisorylock"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// lockName is the advisory lock name ensuring a single instance prunes spins at a time.
+const lockName = "spin-retention"
+
+// defaultBatchSize is used when no positive batch size is configured.
+const defaultBatchSize = 1000
+
+// Pruner deletes spins that fall outside the configured retention window.
+//
+// Only spin rows are deleted. Ledger entries are the financial record of balance changes,
+// including the refunds and clawbacks of voided spins, and are kept regardless of the age
+// of the spins they reference; balances are therefore unaffected by pruning.
+type Pruner struct {
+	config         *Config                    // Retention policy and pruning schedule
+	locker         *advisorylock.Locker       // Advisory locker keeping instances from pruning concurrently
+	slotRepository interfaces.ISlotRepository // Repository deleting the expired spins
+	now            func() time.Time           // Clock used to compute the retention cutoff
+}
+
+// Prune deletes all spins created before the retention window, in batches of the configured size.
+// It does nothing when retention is disabled.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//
+// Returns:
+//   - The number of deleted spins.
+//   - An error if a deletion fails or the context is done.
+func (p *Pruner) Prune(ctx context.Context) (int64, error) {
+	if !p.config.Enabled() {
+		return 0, nil
+	}
+	batchSize := p.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	cutoff := p.now().AddDate(0, 0, -p.config.SpinRetentionDays)
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		deleted, err := p.slotRepository.DeleteSpinsBefore(ctx, cutoff, batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < int64(batchSize) {
+			break
+		}
+	}
+	log.FromContext(ctx).Infof("pruned %d spins created before %s", total, cutoff.Format(time.RFC3339))
+	return total, nil
+}
+
+// Run prunes expired spins while holding the advisory lock, so that only one instance prunes at a time.
+// If another instance holds the lock, nothing is pruned.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//
+// Returns:
+//   - The number of deleted spins.
+//   - An error if the lock could not be queried or pruning fails.
+func (p *Pruner) Run(ctx context.Context) (int64, error) {
+	var deleted int64
+	_, err := p.locker.Run(ctx, lockName, func(ctx context.Context) error {
+		var err error
+		deleted, err = p.Prune(ctx)
+		return err
+	})
+	return deleted, err
+}
+
+// Start runs the pruning job every configured interval until the context is done.
+// Failures are logged and retried on the next tick.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the job.
+func (p *Pruner) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(p.config.PruneInterval) * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.Run(ctx); err != nil {
+				log.FromContext(ctx).Errorf("spin pruning failed: %v", err)
+			}
+		}
+	}
+}
+
+// NewPruner creates a Pruner for the given retention policy.
+//
+// Parameters:
+//   - config: The retention policy and pruning schedule.
+//   - locker: The advisory locker guarding the pruning job.
+//   - slotRepository: The repository deleting the expired spins.
+//
+// Returns:
+//   - A pointer to a Pruner instance.
+func NewPruner(config *Config, locker *advisorylock.Locker, slotRepository interfaces.ISlotRepository) *Pruner {
+	return &Pruner{
+		config:         config,
+		locker:         locker,
+		slotRepository: slotRepository,
+		now:            time.Now,
+	}
+}
@@ -0,0 +1,67 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+)
+
+func TestPrune_DeletesInBatchesBeforeCutoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -90)
+
+	gomock.InOrder(
+		mockSlotRepo.EXPECT().DeleteSpinsBefore(gomock.Any(), cutoff, 2).Return(int64(2), nil),
+		mockSlotRepo.EXPECT().DeleteSpinsBefore(gomock.Any(), cutoff, 2).Return(int64(2), nil),
+		mockSlotRepo.EXPECT().DeleteSpinsBefore(gomock.Any(), cutoff, 2).Return(int64(1), nil),
+	)
+
+	p := NewPruner(&Config{SpinRetentionDays: 90, BatchSize: 2}, nil, mockSlotRepo)
+	p.now = func() time.Time { return now }
+	deleted, err := p.Prune(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+}
+
+func TestPrune_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Any deletion fails the test
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+
+	p := NewPruner(&Config{SpinRetentionDays: 0}, nil, mockSlotRepo)
+	deleted, err := p.Prune(context.Background())
+
+	assert.NoError(t, err)
+	assert.Zero(t, deleted)
+}
+
+func TestPrune_StopsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	dbErr := errors.New("connection reset")
+
+	gomock.InOrder(
+		mockSlotRepo.EXPECT().DeleteSpinsBefore(gomock.Any(), gomock.Any(), 2).Return(int64(2), nil),
+		mockSlotRepo.EXPECT().DeleteSpinsBefore(gomock.Any(), gomock.Any(), 2).Return(int64(0), dbErr),
+	)
+
+	p := NewPruner(&Config{SpinRetentionDays: 30, BatchSize: 2}, nil, mockSlotRepo)
+	deleted, err := p.Prune(context.Background())
+
+	assert.ErrorIs(t, err, dbErr)
+	assert.Equal(t, int64(2), deleted)
+}
@@ -0,0 +1,112 @@
+package rtp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Constants defining the RTP reporting configuration flags.
+const (
+	rtpReportInterval = "rtp-report-interval"
+	rtpReportWindow   = "rtp-report-window"
+	rtpTarget         = "rtp-target"
+	rtpTolerance      = "rtp-tolerance"
+	rtpMinSpins       = "rtp-min-spins"
+)
+
+// Config represents the schedule of the RTP reporting job and the drift it alerts on.
+type Config struct {
+	Interval  int     // Interval between periodic RTP reports in minutes; 0 disables the periodic job
+	Window    int     // Number of hours of spins aggregated into a report, ending when the report runs
+	Target    float64 // Target RTP in percent the realized RTP is compared with
+	Tolerance float64 // Percentage points the realized RTP may deviate from the target; 0 disables the alert
+	MinSpins  int64   // Minimum number of spins within the window for a drift to alert
+}
+
+// Enabled reports whether RTP reports are produced periodically.
+func (c *Config) Enabled() bool {
+	return c.Interval > 0
+}
+
+// Validate checks that the window and the target are positive and the other settings are not negative.
+//
+// Returns:
+//   - An error listing every invalid setting, or nil if the configuration is valid.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Interval < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", rtpReportInterval, c.Interval))
+	}
+	if c.Window <= 0 {
+		errs = append(errs, fmt.Errorf("%s must be positive, got %d", rtpReportWindow, c.Window))
+	}
+	if c.Target <= 0 {
+		errs = append(errs, fmt.Errorf("%s must be positive, got %g", rtpTarget, c.Target))
+	}
+	if c.Tolerance < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %g", rtpTolerance, c.Tolerance))
+	}
+	if c.MinSpins < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative, got %d", rtpMinSpins, c.MinSpins))
+	}
+	return errors.Join(errs...)
+}
+
+// GetRTPConfig reads the RTP reporting settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the RTP reporting settings.
+//   - (error): An error if the settings are invalid.
+func GetRTPConfig(c *cli.Context) (*Config, error) {
+	cfg := &Config{
+		Interval:  c.Int(rtpReportInterval),
+		Window:    c.Int(rtpReportWindow),
+		Target:    c.Float64(rtpTarget),
+		Tolerance: c.Float64(rtpTolerance),
+		MinSpins:  c.Int64(rtpMinSpins),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Flags defines the CLI flags available for configuring the RTP reports.
+var Flags = []cli.Flag{
+	&cli.IntFlag{
+		Name:    rtpReportInterval,
+		Value:   0,
+		Usage:   "Interval between periodic reports of the realized RTP in minutes, e.g. 60; 0 disables the reports",
+		EnvVars: []string{"RTP_REPORT_INTERVAL"},
+	},
+	&cli.IntFlag{
+		Name:    rtpReportWindow,
+		Value:   24,
+		Usage:   "Number of hours of spins aggregated into an RTP report, ending when the report runs",
+		EnvVars: []string{"RTP_REPORT_WINDOW"},
+	},
+	&cli.Float64Flag{
+		Name:    rtpTarget,
+		Value:   96,
+		Usage:   "Target RTP in percent the realized RTP is compared with",
+		EnvVars: []string{"RTP_TARGET"},
+	},
+	&cli.Float64Flag{
+		Name:    rtpTolerance,
+		Value:   2,
+		Usage:   "Percentage points the realized RTP may deviate from the target before an alert is raised; 0 disables the alert",
+		EnvVars: []string{"RTP_TOLERANCE"},
+	},
+	&cli.Int64Flag{
+		Name:    rtpMinSpins,
+		Value:   1000,
+		Usage:   "Minimum number of spins within the window for a drift of the realized RTP to raise an alert",
+		EnvVars: []string{"RTP_MIN_SPINS"},
+	},
+}
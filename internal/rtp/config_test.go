@@ -0,0 +1,42 @@
+package rtp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{Interval: 60, Window: 24, Target: 96, Tolerance: 2, MinSpins: 1000}
+	with := func(change func(*Config)) Config {
+		cfg := valid
+		change(&cfg)
+		return cfg
+	}
+
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"Valid", valid, false},
+		{"Disabled", with(func(c *Config) { c.Interval = 0 }), false},
+		{"AlertDisabled", with(func(c *Config) { c.Tolerance = 0 }), false},
+		{"NegativeInterval", with(func(c *Config) { c.Interval = -1 }), true},
+		{"ZeroWindow", with(func(c *Config) { c.Window = 0 }), true},
+		{"ZeroTarget", with(func(c *Config) { c.Target = 0 }), true},
+		{"NegativeTolerance", with(func(c *Config) { c.Tolerance = -0.5 }), true},
+		{"NegativeMinSpins", with(func(c *Config) { c.MinSpins = -1 }), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package rtp
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the RTP reporting configuration and the Reporter as an Fx module.
+var Module = fx.Options(
+	fx.Provide(GetRTPConfig),
+	fx.Provide(NewReporter),
+)
+
+// Scheduler starts the periodic RTP reporting job with the application and stops it on shutdown.
+// The job is not started when the periodic reports are disabled.
+var Scheduler = fx.Invoke(func(lc fx.Lifecycle, config *Config, reporter *Reporter) {
+	if !config.Enabled() {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go reporter.Start(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+})
@@ -0,0 +1,144 @@
+package rtp
+
+import (
+	"context"
+	"time"
+
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/advisorylock"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// lockName is the advisory lock name ensuring a single instance reports the RTP at a time.
+const lockName = "rtp-report"
+
+// Reporter aggregates the bets and wins of a recent window into the realized return to player
+// (RTP), stores and logs it, and raises an alert when it drifts from the configured target.
+type Reporter struct {
+	config         *Config                                             // Report schedule, window, target and tolerance
+	locker         *advisorylock.Locker                                // Advisory locker keeping instances from reporting concurrently
+	slotRepository interfaces.ISlotRepository                          // Repository aggregating the spins and storing the reports
+	now            func() time.Time                                    // Clock the window ends at
+	alert          func(ctx context.Context, report *models.RTPReport) // Called with each report whose RTP drifted
+}
+
+// Report aggregates the spins of the configured window, ending now, into an RTPReport, stores and
+// logs it, and alerts when the realized RTP drifted beyond the tolerance.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//
+// Returns:
+//   - A pointer to the stored RTPReport.
+//   - An error if the aggregation or storing the report fails.
+func (r *Reporter) Report(ctx context.Context) (*models.RTPReport, error) {
+	end := r.now()
+	start := end.Add(-time.Duration(r.config.Window) * time.Hour)
+	stats, err := r.slotRepository.GetSpinStatsBetween(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	report := models.NewRTPReport(start, end, stats, r.config.Target)
+	report.Drifted = r.drifted(report)
+	if err := r.slotRepository.AddRTPReport(ctx, report); err != nil {
+		return nil, err
+	}
+	log.FromContext(ctx).Infow("realized RTP report",
+		"window_start", start.Format(time.RFC3339),
+		"window_end", end.Format(time.RFC3339),
+		"spins", report.TotalSpins,
+		"wagered", report.TotalWagered,
+		"won", report.TotalWon,
+		"win_rate", report.WinRate(),
+		"realized_rtp", report.RealizedRTP,
+		"target_rtp", report.TargetRTP,
+	)
+	if report.Drifted {
+		r.alert(ctx, report)
+	}
+	return report, nil
+}
+
+// driftEpsilon absorbs the floating point error of summing many bets and wins, so that a drift of
+// exactly the tolerance does not alert.
+const driftEpsilon = 1e-9
+
+// drifted reports whether the realized RTP deviates from the target by more than the tolerance.
+// Windows with fewer spins than the minimum never drift, as their RTP is dominated by chance.
+func (r *Reporter) drifted(report *models.RTPReport) bool {
+	if r.config.Tolerance <= 0 || report.TotalSpins == 0 || report.TotalSpins < r.config.MinSpins {
+		return false
+	}
+	return report.Drift() > r.config.Tolerance+driftEpsilon
+}
+
+// logAlert raises the drift alert as an error log entry, which the log based alerting picks up.
+func logAlert(ctx context.Context, report *models.RTPReport) {
+	log.FromContext(ctx).Errorw("realized RTP drifted beyond the tolerance",
+		"window_start", report.WindowStart.Format(time.RFC3339),
+		"window_end", report.WindowEnd.Format(time.RFC3339),
+		"spins", report.TotalSpins,
+		"realized_rtp", report.RealizedRTP,
+		"target_rtp", report.TargetRTP,
+		"drift", report.Drift(),
+	)
+}
+
+// Run reports the RTP while holding the advisory lock, so that only one instance reports at a time.
+// If another instance holds the lock, nothing is reported.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//
+// Returns:
+//   - A pointer to the stored RTPReport, or nil if another instance holds the lock.
+//   - An error if the lock could not be queried or reporting fails.
+func (r *Reporter) Run(ctx context.Context) (*models.RTPReport, error) {
+	var report *models.RTPReport
+	_, err := r.locker.Run(ctx, lockName, func(ctx context.Context) error {
+		var err error
+		report, err = r.Report(ctx)
+		return err
+	})
+	return report, err
+}
+
+// Start runs the reporting job every configured interval until the context is done.
+// Failures are logged and retried on the next tick.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the job.
+func (r *Reporter) Start(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(r.config.Interval) * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Run(ctx); err != nil {
+				log.FromContext(ctx).Errorf("RTP report failed: %v", err)
+			}
+		}
+	}
+}
+
+// NewReporter creates a Reporter for the given schedule and target.
+//
+// Parameters:
+//   - config: The report schedule, window, target and tolerance.
+//   - locker: The advisory locker guarding the reporting job.
+//   - slotRepository: The repository aggregating the spins and storing the reports.
+//
+// Returns:
+//   - A pointer to a Reporter instance.
+func NewReporter(config *Config, locker *advisorylock.Locker, slotRepository interfaces.ISlotRepository) *Reporter {
+	return &Reporter{
+		config:         config,
+		locker:         locker,
+		slotRepository: slotRepository,
+		now:            time.Now,
+		alert:          logAlert,
+	}
+}
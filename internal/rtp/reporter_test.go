@@ -0,0 +1,151 @@
+package rtp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// seededSpin is a spin seeded into a window by its bet and win.
+type seededSpin struct {
+	bet, win float64
+}
+
+// aggregate sums the seeded spins the way the repository aggregates a window.
+func aggregate(spins []seededSpin) *models.SpinStats {
+	stats := &models.SpinStats{}
+	for _, spin := range spins {
+		stats.TotalSpins++
+		stats.TotalWagered += spin.bet
+		stats.TotalWon += spin.win
+		if spin.win > 0 {
+			stats.WinningSpins++
+		}
+	}
+	return stats
+}
+
+// newTestReporter creates a Reporter reporting at now and recording the alerts it raises.
+func newTestReporter(config *Config, slotRepository *mocks.MockISlotRepository, now time.Time) (*Reporter, *[]*models.RTPReport) {
+	var alerts []*models.RTPReport
+	r := NewReporter(config, nil, slotRepository)
+	r.now = func() time.Time { return now }
+	r.alert = func(_ context.Context, report *models.RTPReport) { alerts = append(alerts, report) }
+	return r, &alerts
+}
+
+func TestReport_AggregatesWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	now := time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC)
+	from := now.Add(-24 * time.Hour)
+
+	// 100 spins of 1: 40 win 2.4 each, so 96 of the 100 wagered come back
+	spins := make([]seededSpin, 100)
+	for i := range spins {
+		spins[i] = seededSpin{bet: 1}
+		if i%5 < 2 {
+			spins[i].win = 2.4
+		}
+	}
+	var stored *models.RTPReport
+	mockSlotRepo.EXPECT().GetSpinStatsBetween(gomock.Any(), from, now).Return(aggregate(spins), nil)
+	mockSlotRepo.EXPECT().AddRTPReport(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, report *models.RTPReport) error {
+		stored = report
+		return nil
+	})
+
+	r, alerts := newTestReporter(&Config{Window: 24, Target: 96, Tolerance: 2, MinSpins: 100}, mockSlotRepo, now)
+	report, err := r.Report(context.Background())
+
+	require.NoError(t, err)
+	assert.Same(t, stored, report)
+	assert.Equal(t, from, report.WindowStart)
+	assert.Equal(t, now, report.WindowEnd)
+	assert.Equal(t, int64(100), report.TotalSpins)
+	assert.Equal(t, int64(40), report.WinningSpins)
+	assert.InDelta(t, 100.0, report.TotalWagered, 1e-9)
+	assert.InDelta(t, 96.0, report.TotalWon, 1e-9)
+	assert.InDelta(t, 96.0, report.RealizedRTP, 1e-9)
+	assert.Equal(t, 96.0, report.TargetRTP)
+	assert.Equal(t, 0.4, report.WinRate())
+	assert.False(t, report.Drifted)
+	assert.Empty(t, *alerts)
+}
+
+func TestReport_AlertsOnDrift(t *testing.T) {
+	// 100 spins of 1, 50 of which win the given amount
+	window := func(win float64) *models.SpinStats {
+		spins := make([]seededSpin, 100)
+		for i := range spins {
+			spins[i] = seededSpin{bet: 1}
+			if i%2 == 0 {
+				spins[i].win = win
+			}
+		}
+		return aggregate(spins)
+	}
+
+	testCases := []struct {
+		name    string
+		config  Config
+		stats   *models.SpinStats
+		drifted bool
+	}{
+		{"WithinTolerance", Config{Window: 24, Target: 96, Tolerance: 2}, window(1.9), false},
+		{"AtTolerance", Config{Window: 24, Target: 96, Tolerance: 2}, window(1.88), false},
+		{"AboveTarget", Config{Window: 24, Target: 96, Tolerance: 2}, window(2.2), true},
+		{"BelowTarget", Config{Window: 24, Target: 96, Tolerance: 2}, window(1.8), true},
+		{"TooFewSpins", Config{Window: 24, Target: 96, Tolerance: 2, MinSpins: 101}, window(2.2), false},
+		{"AlertDisabled", Config{Window: 24, Target: 96}, window(2.2), false},
+		{"NoSpins", Config{Window: 24, Target: 96, Tolerance: 2}, &models.SpinStats{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+			mockSlotRepo.EXPECT().GetSpinStatsBetween(gomock.Any(), gomock.Any(), gomock.Any()).Return(tc.stats, nil)
+			mockSlotRepo.EXPECT().AddRTPReport(gomock.Any(), gomock.Any()).Return(nil)
+
+			r, alerts := newTestReporter(&tc.config, mockSlotRepo, time.Now())
+			report, err := r.Report(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.drifted, report.Drifted)
+			if tc.drifted {
+				assert.Equal(t, []*models.RTPReport{report}, *alerts)
+			} else {
+				assert.Empty(t, *alerts)
+			}
+		})
+	}
+}
+
+func TestReport_AggregationFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Storing a report fails the test
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	aggregateErr := errors.New("connection reset")
+	mockSlotRepo.EXPECT().GetSpinStatsBetween(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, aggregateErr)
+
+	r, alerts := newTestReporter(&Config{Window: 24, Target: 96, Tolerance: 2}, mockSlotRepo, time.Now())
+	report, err := r.Report(context.Background())
+
+	assert.ErrorIs(t, err, aggregateErr)
+	assert.Nil(t, report)
+	assert.Empty(t, *alerts)
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Media types produced by the API endpoints.
+const (
+	MediaTypeJSON        = "application/json"  // Plain JSON response bodies
+	MediaTypeEventStream = "text/event-stream" // Server-sent events
+	MediaTypeCSV         = "text/csv"          // Comma-separated values exports
+)
+
+// AcceptJSON restricts an endpoint to clients accepting JSON. Requests whose Accept header
+// lists neither JSON, the envelope media type, a matching wildcard nor one of the additional
+// media types are rejected with 406 Not Acceptable. A missing Accept header accepts anything.
+//
+// Parameters:
+//   - additional: Further media types the endpoint produces, such as MediaTypeEventStream.
+//
+// Returns:
+//   - (gin.HandlerFunc): Gin middleware handler function.
+func AcceptJSON(additional ...string) gin.HandlerFunc {
+	produced := append([]string{MediaTypeJSON, MediaTypeEnvelope}, additional...)
+	return func(c *gin.Context) {
+		accept := c.GetHeader("Accept")
+		if accept != "" && !acceptsAny(accept, produced) {
+			NotAcceptableErrorResponse(c, fmt.Sprintf("unsupported Accept header %q, supported media types: %s",
+				accept, strings.Join(produced, ", ")))
+			return
+		}
+		c.Next()
+	}
+}
+
+// acceptsAny reports whether one of the media ranges of the Accept header, with a non-zero
+// quality, matches one of the produced media types.
+func acceptsAny(accept string, produced []string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if quality, err := strconv.ParseFloat(q, 64); err != nil || quality <= 0 {
+				continue
+			}
+		}
+		for _, mediaType := range produced {
+			if matchesMediaRange(mediaRange, mediaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesMediaRange reports whether the media type falls in the media range, such as "*/*" or "application/*".
+func matchesMediaRange(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	rangeType, rangeSubtype, _ := strings.Cut(mediaRange, "/")
+	typ, _, _ := strings.Cut(mediaType, "/")
+	return rangeSubtype == "*" && rangeType == typ
+}
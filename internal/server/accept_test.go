@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+)
+
+// newAcceptTestEngine builds an engine with a JSON-only route and a route also producing server-sent events.
+func newAcceptTestEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := NewEngine(&APIConfig{}, nil)
+	router.GET("/balance", AcceptJSON(), func(c *gin.Context) {
+		SuccessResponse(c, gin.H{"balance": 100})
+	})
+	router.GET("/stream", AcceptJSON(MediaTypeEventStream), func(c *gin.Context) {
+		SuccessResponse(c, gin.H{"balance": 100})
+	})
+	return router
+}
+
+func TestAcceptJSON_Accepted(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		accept string
+	}{
+		{"NoAcceptHeader", "/balance", ""},
+		{"JSON", "/balance", "application/json"},
+		{"AnyMediaType", "/balance", "*/*"},
+		{"Envelope", "/balance", MediaTypeEnvelope},
+		{"WildcardSubtype", "/balance", "text/html, application/*;q=0.5"},
+		{"AdditionalMediaType", "/stream", "text/event-stream"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(newAcceptTestEngine(), tc.path, tc.accept)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
+func TestAcceptJSON_NotAcceptable(t *testing.T) {
+	testCases := []struct {
+		name   string
+		path   string
+		accept string
+	}{
+		{"XML", "/balance", "application/xml"},
+		{"ZeroQuality", "/balance", "application/json;q=0, text/html"},
+		{"EventStreamOnJSONRoute", "/balance", "text/event-stream"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(newAcceptTestEngine(), tc.path, tc.accept)
+
+			body := &ErrorResponseMessage{}
+			assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+			assert.Contains(t, rec.Header().Get("Content-Type"), MediaTypeJSON)
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+			assert.Equal(t, serviceError.CodeNotAcceptable, body.Code)
+			assert.Equal(t, "trace-1", body.TraceID)
+		})
+	}
+}
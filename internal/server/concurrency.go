@@ -0,0 +1,65 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/database"
+)
+
+// ConcurrencyLimit answers requests with 503 Service Unavailable while the server is already
+// handling the maximum number of requests, so that a traffic spike is turned away instead of
+// queueing up for database connections. Rejected requests are told to retry after a second.
+// Requests to the exempt paths are always admitted and take no slot.
+//
+// Parameters:
+//   - limit: Maximum number of requests handled at once; 0 or less admits every request.
+//   - exempt: Path prefixes of the requests the limit does not apply to.
+//
+// Returns:
+//   - (gin.HandlerFunc): Gin middleware handler function.
+func ConcurrencyLimit(limit int, exempt ...string) gin.HandlerFunc {
+	if limit <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+	slots := make(chan struct{}, limit)
+	return func(c *gin.Context) {
+		for _, prefix := range exempt {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			c.Next()
+		default:
+			c.Header("Retry-After", "1")
+			ServerBusyErrorResponse(c, "the server is busy, please try again later")
+		}
+	}
+}
+
+// InFlightLimit returns the number of requests the server handles at once: the configured limit,
+// lowered to the size of the database pool when the pool is smaller, so that the admitted requests
+// do not wait for each other's connections.
+//
+// Parameters:
+//   - config: API configuration with the configured limit.
+//   - pool: Connection pool settings with the maximum number of open connections.
+//
+// Returns:
+//   - The maximum number of requests handled at once, or 0 if the number is not limited.
+func InFlightLimit(config *APIConfig, pool *database.PoolConfig) int {
+	limit := config.MaxInFlight
+	if limit > 0 && pool != nil && pool.MaxOpenConnections > 0 && limit > pool.MaxOpenConnections {
+		log.FromDefaultContext().Warnf("lowering the in-flight request limit from %d to the database pool size of %d",
+			limit, pool.MaxOpenConnections)
+		limit = pool.MaxOpenConnections
+	}
+	return limit
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/database"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+)
+
+func TestConcurrencyLimit_RejectsRequestsBeyondLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ConcurrencyLimit(2))
+	entered, release := make(chan struct{}), make(chan struct{})
+	router.GET("/slow", func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		SuccessResponse(c, gin.H{})
+	})
+	router.GET("/fast", func(c *gin.Context) { SuccessResponse(c, gin.H{}) })
+
+	// Occupy both slots with requests waiting for their release
+	var wg sync.WaitGroup
+	slow := make([]*httptest.ResponseRecorder, 2)
+	for i := range slow {
+		slow[i] = httptest.NewRecorder()
+		wg.Add(1)
+		go func(rec *httptest.ResponseRecorder) {
+			defer wg.Done()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		}(slow[i])
+		<-entered
+	}
+
+	busy := serveMethod(router, http.MethodGet, "/fast")
+	require.Equal(t, http.StatusServiceUnavailable, busy.Code)
+	assert.Equal(t, "1", busy.Header().Get("Retry-After"))
+	var body ErrorResponseMessage
+	require.NoError(t, json.Unmarshal(busy.Body.Bytes(), &body))
+	assert.Equal(t, serviceError.CodeServerBusy, body.Code)
+
+	close(release)
+	wg.Wait()
+	for _, rec := range slow {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+	// The finished requests free their slots
+	assert.Equal(t, http.StatusOK, serveMethod(router, http.MethodGet, "/fast").Code)
+}
+
+func TestNewEngine_InFlightLimitSparesStatusAndDocs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewEngine(&APIConfig{MaxInFlight: 1}, nil)
+	entered, release := make(chan struct{}), make(chan struct{})
+	router.GET("/api/slow", func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		SuccessResponse(c, gin.H{})
+	})
+	router.GET("/api/fast", func(c *gin.Context) { SuccessResponse(c, gin.H{}) })
+	router.GET("/api/status/status", func(c *gin.Context) { SuccessResponse(c, gin.H{"status": "ok"}) })
+	router.GET("/swagger/*any", func(c *gin.Context) { c.String(http.StatusOK, "docs") })
+
+	// Occupy the only slot with a request waiting for its release
+	slow := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		router.ServeHTTP(slow, httptest.NewRequest(http.MethodGet, "/api/slow", nil))
+	}()
+	<-entered
+
+	assert.Equal(t, http.StatusServiceUnavailable, serveMethod(router, http.MethodGet, "/api/fast").Code)
+	assert.Equal(t, http.StatusOK, serveMethod(router, http.MethodGet, "/api/status/status").Code)
+	assert.Equal(t, http.StatusOK, serveMethod(router, http.MethodGet, "/swagger/index.html").Code)
+
+	close(release)
+	<-done
+	assert.Equal(t, http.StatusOK, slow.Code)
+}
+
+func TestInFlightLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limit    int
+		pool     *database.PoolConfig
+		expected int
+	}{
+		{"BelowPool", 5, &database.PoolConfig{MaxOpenConnections: 10}, 5},
+		{"LoweredToPool", 50, &database.PoolConfig{MaxOpenConnections: 10}, 10},
+		{"UnlimitedPool", 50, &database.PoolConfig{}, 50},
+		{"Disabled", 0, &database.PoolConfig{MaxOpenConnections: 10}, 0},
+		{"NoPool", 50, nil, 50},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, InFlightLimit(&APIConfig{MaxInFlight: tc.limit}, tc.pool))
+		})
+	}
+}
@@ -6,26 +6,44 @@ import (
 
 // Constants defining CLI flags and environment variable names for API server configuration.
 const (
-	apiHost            = "server-host"                // API server host address
-	apiPort            = "server-port"                // API server port
-	apiMaxHeaderSize   = "server-max-header-size"     // Maximum size of request headers in bytes
-	apiRequestTimeout  = "server-request-timeout"     // Maximum duration for reading request data
-	apiResponseTimeout = "server-response-timeout"    // Maximum duration for writing response data
-	jwtSecret          = "server-jwt-secret"          // JWT secret for authentication
-	jwtSecretLifeTime  = "server-jwt-secret-lifetime" // JWT secret expiration time in minutes
-	logRequest         = "server-log-request"         // Flag to enable or disable request logging
+	apiHost            = "server-host"                    // API server host address
+	apiPort            = "server-port"                    // API server port
+	apiMaxHeaderSize   = "server-max-header-size"         // Maximum size of request headers in bytes
+	apiRequestTimeout  = "server-request-timeout"         // Maximum duration for reading request data
+	apiResponseTimeout = "server-response-timeout"        // Maximum duration for writing response data
+	jwtSecret          = "server-jwt-secret"              // JWT secret for authentication
+	jwtSecretLifeTime  = "server-jwt-secret-lifetime"     // JWT secret expiration time in minutes
+	jwtLeeway          = "server-jwt-leeway"              // Clock skew tolerance for token time claims in seconds
+	logRequest         = "server-log-request"             // Flag to enable or disable request logging
+	compression        = "server-compression"             // Flag to enable or disable gzip response compression
+	compressionMinSize = "server-compression-min-size"    // Minimum response size in bytes to compress
+	cacheMaxAge        = "server-cache-max-age"           // Seconds clients may reuse the responses of cacheable read endpoints
+	responseEnvelope   = "server-response-envelope"       // Flag to wrap all responses in the standard envelope
+	maintenance        = "server-maintenance"             // Flag to put the game and wallet endpoints into maintenance mode
+	maintenanceRetry   = "server-maintenance-retry-after" // Seconds clients are told to wait during maintenance
+	maintenanceMessage = "server-maintenance-message"     // Message returned during maintenance
+	maxInFlight        = "server-max-in-flight"           // Maximum number of requests handled at once
 )
 
 // APIConfig holds configuration settings for the API server.
 type APIConfig struct {
-	APIHost           string // Server host address
-	APIPort           string // Server port number
-	RequestTimeout    int    // Maximum request read duration in seconds
-	ResponseTimeout   int    // Maximum response write duration in seconds
-	MaxHeaderBytes    int    // Maximum size of request headers in bytes
-	JWTSecret         string // JWT secret for signing tokens
-	JWTSecretLifeTime int    // JWT token lifetime in minutes
-	LogRequest        bool   // Enable request logging
+	APIHost            string // Server host address
+	APIPort            string // Server port number
+	RequestTimeout     int    // Maximum request read duration in seconds
+	ResponseTimeout    int    // Maximum response write duration in seconds
+	MaxHeaderBytes     int    // Maximum size of request headers in bytes
+	JWTSecret          string // JWT secret for signing tokens
+	JWTSecretLifeTime  int    // JWT token lifetime in minutes
+	JWTLeeway          int    // Seconds tokens are still accepted after expiry, tolerating clock skew
+	LogRequest         bool   // Enable request logging
+	Compression        bool   // Enable gzip response compression
+	CompressionMinSize int    // Minimum response size in bytes to compress
+	CacheMaxAge        int    // Seconds clients may reuse the responses of cacheable read endpoints; 0 makes them revalidate every time
+	ResponseEnvelope   bool   // Wrap all responses in the standard envelope
+	Maintenance        bool   // Keep the game and wallet endpoints in maintenance mode regardless of the admin toggle
+	MaintenanceRetry   int    // Seconds clients are told to wait in the Retry-After header during maintenance
+	MaintenanceMessage string // Message returned by the endpoints in maintenance
+	MaxInFlight        int    // Maximum number of requests handled at once, at most the database pool size; 0 disables the limit
 }
 
 // GetAPIConfig retrieves API server configuration from CLI flags or environment variables
@@ -39,14 +57,23 @@ type APIConfig struct {
 //	A pointer to an ApiConfig instance populated with the specified configuration.
 func GetAPIConfig(c *cli.Context) *APIConfig {
 	return &APIConfig{
-		APIHost:           c.String(apiHost),
-		APIPort:           c.String(apiPort),
-		RequestTimeout:    c.Int(apiRequestTimeout),
-		ResponseTimeout:   c.Int(apiResponseTimeout),
-		MaxHeaderBytes:    c.Int(apiMaxHeaderSize),
-		LogRequest:        c.Bool(logRequest),
-		JWTSecret:         c.String(jwtSecret),
-		JWTSecretLifeTime: c.Int(jwtSecretLifeTime),
+		APIHost:            c.String(apiHost),
+		APIPort:            c.String(apiPort),
+		RequestTimeout:     c.Int(apiRequestTimeout),
+		ResponseTimeout:    c.Int(apiResponseTimeout),
+		MaxHeaderBytes:     c.Int(apiMaxHeaderSize),
+		LogRequest:         c.Bool(logRequest),
+		JWTSecret:          c.String(jwtSecret),
+		JWTSecretLifeTime:  c.Int(jwtSecretLifeTime),
+		JWTLeeway:          c.Int(jwtLeeway),
+		Compression:        c.Bool(compression),
+		CompressionMinSize: c.Int(compressionMinSize),
+		CacheMaxAge:        c.Int(cacheMaxAge),
+		ResponseEnvelope:   c.Bool(responseEnvelope),
+		Maintenance:        c.Bool(maintenance),
+		MaintenanceRetry:   c.Int(maintenanceRetry),
+		MaintenanceMessage: c.String(maintenanceMessage),
+		MaxInFlight:        c.Int(maxInFlight),
 	}
 }
 
@@ -101,4 +128,58 @@ var APIFlags = []cli.Flag{
 		Usage:   "JWT token lifetime in minutes",
 		EnvVars: []string{"JWT_SECRET_LIFE_TIME"},
 	},
+	&cli.IntFlag{
+		Name:    jwtLeeway,
+		Value:   30,
+		Usage:   "Seconds a JWT token is still accepted after it expires, tolerating clock skew between services; 0 disables the grace period",
+		EnvVars: []string{"JWT_LEEWAY"},
+	},
+	&cli.BoolFlag{
+		Name:    compression,
+		Value:   false,
+		Usage:   "Enable gzip compression of responses for clients that accept it",
+		EnvVars: []string{"API_COMPRESSION"},
+	},
+	&cli.IntFlag{
+		Name:    compressionMinSize,
+		Value:   1024,
+		Usage:   "Minimum response size in bytes before compression is applied",
+		EnvVars: []string{"API_COMPRESSION_MIN_SIZE"},
+	},
+	&cli.IntFlag{
+		Name:    cacheMaxAge,
+		Value:   60,
+		Usage:   "Seconds clients may reuse the responses of cacheable read endpoints, such as the game configuration, before revalidating them with their ETag; 0 makes them revalidate every time",
+		EnvVars: []string{"API_CACHE_MAX_AGE"},
+	},
+	&cli.BoolFlag{
+		Name:    responseEnvelope,
+		Value:   false,
+		Usage:   "Wrap all responses in a {success, data, trace_id, timestamp} envelope; clients may also request it per request with the Accept header",
+		EnvVars: []string{"API_RESPONSE_ENVELOPE"},
+	},
+	&cli.BoolFlag{
+		Name:    maintenance,
+		Value:   false,
+		Usage:   "Answer the game and wallet endpoints with 503 while keeping the status, user and admin endpoints up; admins may also switch maintenance mode on and off at runtime",
+		EnvVars: []string{"MAINTENANCE_MODE"},
+	},
+	&cli.IntFlag{
+		Name:    maintenanceRetry,
+		Value:   300,
+		Usage:   "Seconds clients are told to wait in the Retry-After header during maintenance",
+		EnvVars: []string{"MAINTENANCE_RETRY_AFTER"},
+	},
+	&cli.StringFlag{
+		Name:    maintenanceMessage,
+		Value:   "the service is under maintenance, please try again later",
+		Usage:   "Message returned by the endpoints in maintenance",
+		EnvVars: []string{"MAINTENANCE_MESSAGE"},
+	},
+	&cli.IntFlag{
+		Name:    maxInFlight,
+		Value:   0,
+		Usage:   "Maximum number of requests handled at once, lowered to --postgres-max-connection when the pool is smaller; further requests are answered with 503. 0 disables the limit",
+		EnvVars: []string{"API_MAX_IN_FLIGHT"},
+	},
 }
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Media types of XML request bodies, read alongside MediaTypeJSON.
+const (
+	MediaTypeXML     = "application/xml" // XML request bodies
+	MediaTypeTextXML = "text/xml"        // XML request bodies sent by older clients
+)
+
+// consumed lists the media types of the request bodies the write endpoints read.
+var consumed = []string{MediaTypeJSON, MediaTypeXML, MediaTypeTextXML}
+
+// RequireJSONOrXML restricts a write endpoint to JSON and XML request bodies. Requests carrying a
+// body whose Content-Type is neither are rejected with 415 Unsupported Media Type before the handler
+// binds them with gin's Content-Type based ShouldBind. Requests without a body pass, so that endpoints
+// whose body is optional keep working.
+//
+// Returns:
+//   - (gin.HandlerFunc): Gin middleware handler function.
+func RequireJSONOrXML() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+		contentType := c.GetHeader("Content-Type")
+		if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || !slices.Contains(consumed, mediaType) {
+			UnsupportedMediaTypeErrorResponse(c, fmt.Sprintf("unsupported Content-Type %q, the request body must be one of: %s",
+				contentType, strings.Join(consumed, ", ")))
+			return
+		}
+		c.Next()
+	}
+}
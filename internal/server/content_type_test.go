@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+)
+
+// postWithContentType sends a body with the given Content-Type to a route requiring JSON or XML.
+func postWithContentType(contentType, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/deposit", RequireJSONOrXML(), func(c *gin.Context) {
+		SuccessResponse(c, gin.H{"balance": 100})
+	})
+	req := httptest.NewRequest(http.MethodPost, "/deposit", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRequireJSONOrXML_Accepted(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"JSON", "application/json", `{"amount":10}`},
+		{"JSONWithCharset", "application/json; charset=utf-8", `{"amount":10}`},
+		{"XML", "application/xml", `<deposit><amount>10</amount></deposit>`},
+		{"TextXML", "text/xml; charset=utf-8", `<deposit><amount>10</amount></deposit>`},
+		{"EmptyBody", "", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := postWithContentType(tc.contentType, tc.body)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
+func TestRequireJSONOrXML_UnsupportedMediaType(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+	}{
+		{"Form", "application/x-www-form-urlencoded"},
+		{"Multipart", "multipart/form-data; boundary=xyz"},
+		{"PlainText", "text/plain"},
+		{"Missing", ""},
+		{"Malformed", "application/"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := postWithContentType(tc.contentType, "amount=10")
+
+			body := &ErrorResponseMessage{}
+			assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+			assert.Equal(t, serviceError.CodeUnsupportedMediaType, body.Code)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediaTypeEnvelope is the media type a client sends in the Accept header to receive
+// enveloped responses when the envelope is not enabled for all clients.
+const MediaTypeEnvelope = "application/vnd.slot-game.v2+json"
+
+// ctxKeyEnvelope is the gin context key marking requests answered with enveloped responses.
+const ctxKeyEnvelope = "response_envelope"
+
+// Envelope represents the standardized response body wrapping the payload of a response
+// together with a success flag, the request trace ID and the response time.
+type Envelope struct {
+	Success   bool                  `json:"success"`            // Whether the request succeeded
+	Data      interface{}           `json:"data,omitempty"`     // Response payload of a successful request
+	Error     *ErrorResponseMessage `json:"error,omitempty"`    // Error details of a failed request
+	TraceID   string                `json:"trace_id,omitempty"` // Trace ID of the request
+	Timestamp time.Time             `json:"timestamp"`          // Time the response was created, in UTC
+}
+
+// EnvelopeMiddleware selects whether the responses to a request are wrapped in an Envelope.
+// Responses are enveloped when enabled for all clients, or when the client accepts MediaTypeEnvelope;
+// otherwise the raw response bodies are sent.
+//
+// Parameters:
+//   - enabled: Whether all responses are enveloped.
+//
+// Returns:
+//   - (gin.HandlerFunc): Gin middleware handler function.
+func EnvelopeMiddleware(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if enabled || acceptsEnvelope(c.GetHeader("Accept")) {
+			c.Set(ctxKeyEnvelope, true)
+		}
+		c.Next()
+	}
+}
+
+// acceptsEnvelope reports whether the Accept header lists MediaTypeEnvelope.
+func acceptsEnvelope(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == MediaTypeEnvelope {
+			return true
+		}
+	}
+	return false
+}
+
+// envelope wraps the response body in an Envelope if the request asks for one;
+// otherwise it returns the body unchanged.
+func envelope(ctx *gin.Context, body interface{}) interface{} {
+	if !ctx.GetBool(ctxKeyEnvelope) {
+		return body
+	}
+	env := &Envelope{
+		TraceID:   traceID(ctx),
+		Timestamp: time.Now().UTC(),
+	}
+	if errBody, ok := body.(*ErrorResponseMessage); ok {
+		env.Error = errBody
+	} else {
+		env.Success = true
+		env.Data = body
+	}
+	return env
+}
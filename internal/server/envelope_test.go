@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/middlewares"
+)
+
+// newEnvelopeTestEngine builds an engine with the same handlers served raw or enveloped.
+func newEnvelopeTestEngine(enabled bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := NewEngine(&APIConfig{ResponseEnvelope: enabled}, nil)
+	router.GET("/balance", func(c *gin.Context) {
+		SuccessResponse(c, gin.H{"balance": 100})
+	})
+	router.GET("/error", func(c *gin.Context) {
+		ErrorBadRequest(c, serviceError.ErrInsufficientFunds)
+	})
+	return router
+}
+
+// serve performs a GET request with the given Accept header and trace ID.
+func serve(router *gin.Engine, path, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	req.Header.Set(middlewares.HeaderTraceID, "trace-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSuccessResponse_RawByDefault(t *testing.T) {
+	rec := serve(newEnvelopeTestEngine(false), "/balance", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"balance":100}`, rec.Body.String())
+}
+
+func TestSuccessResponse_Enveloped(t *testing.T) {
+	testCases := []struct {
+		name    string
+		enabled bool
+		accept  string
+	}{
+		{"EnabledByConfig", true, ""},
+		{"RequestedByAcceptHeader", false, "application/json, " + MediaTypeEnvelope + "; q=0.9"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(newEnvelopeTestEngine(tc.enabled), "/balance", tc.accept)
+
+			var body struct {
+				Envelope
+				Data map[string]float64 `json:"data"`
+			}
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.True(t, body.Success)
+			assert.Equal(t, map[string]float64{"balance": 100}, body.Data)
+			assert.Equal(t, "trace-1", body.TraceID)
+			assert.False(t, body.Timestamp.IsZero())
+		})
+	}
+}
+
+func TestErrorResponse_Enveloped(t *testing.T) {
+	rec := serve(newEnvelopeTestEngine(true), "/error", "")
+
+	body := &Envelope{}
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.False(t, body.Success)
+	assert.Nil(t, body.Data)
+	assert.Equal(t, serviceError.CodeInsufficientFunds, body.Error.Code)
+	assert.Equal(t, "trace-1", body.TraceID)
+}
+
+func TestErrorResponse_RawByDefault(t *testing.T) {
+	rec := serve(newEnvelopeTestEngine(false), "/error", "")
+
+	body := &ErrorResponseMessage{}
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeInsufficientFunds, body.Code)
+}
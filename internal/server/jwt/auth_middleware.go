@@ -2,54 +2,45 @@ package jwt
 
 import (
 	"context"
+
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	log "github.com/public-forge/go-logger"
 	"github.com/vadymlab/slot-game/internal/constants"
-	"net/http"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/server"
 )
 
 // AuthMiddleware is a middleware function for Gin that authenticates requests using a JWT token.
 // It checks for a valid "Authorization" header in the Bearer format. If the token is valid, the middleware
 // extracts the user ID from the token's claims and stores it in the request context.
-func AuthMiddleware(secret string) gin.HandlerFunc {
+// Tokens that expired at most leeway seconds ago are still accepted. With a token store, tokens
+// whose login session has been ended, for instance by a newer login over the session limit, are
+// rejected; while the store cannot be reached, the tokens are accepted.
+func AuthMiddleware(secret string, leeway int, tokens interfaces.ITokenStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		// Retrieve the token from the "Authorization" header.
-		tokenString := c.GetHeader("Authorization")
-		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token is required"})
-			c.Abort()
-			return
-		}
-		// Verify that the token follows the "Bearer " format.
-		if len(tokenString) < 7 || tokenString[:7] != "Bearer " {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
-			c.Abort()
-			return
-		}
-		jwtToken := tokenString[7:]
-
-		// Parse and validate the token using the provided secret.
-		token, err := jwt.ParseWithClaims(jwtToken, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		})
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
+		// Validate the token from the "Authorization" header and retrieve the user ID.
+		userID, tokenID, err := Authenticate(c.GetHeader("Authorization"), secret, leeway)
+		if err != nil {
+			server.UnauthorizedErrorResponse(c, err.Error())
 			return
 		}
-
-		// Retrieve claims from the token, specifically the subject (user ID).
-		claims, ok := token.Claims.(*jwt.RegisteredClaims)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
-			return
+		if tokens != nil {
+			uID := uuid.MustParse(userID)
+			active, err := tokens.Active(c.Request.Context(), &uID, tokenID)
+			if err != nil {
+				log.FromContext(c).Warnf("failed to check the login session, accepting the token: %v", err)
+			} else if !active {
+				server.UnauthorizedErrorResponse(c, ErrTokenRevoked.Error())
+				return
+			}
 		}
 
 		// Store the user ID from the claims in Gin's context and in the request context.
-		c.Set(string(constants.CtxFieldUserID), claims.Subject)
-		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), constants.CtxFieldUserID, claims.Subject))
+		c.Set(string(constants.CtxFieldUserID), userID)
+		c.Set(string(constants.CtxFieldTokenID), tokenID)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), constants.CtxFieldUserID, userID))
 
 		// Continue to the next handler.
 		c.Next()
@@ -1,15 +1,17 @@
 package jwt
 
 import (
+	"errors"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"strings"
 	"time"
 )
 
 // GenerateToken creates a signed JWT token for a given user ID with a specified lifetime.
 // The token includes standard claims, such as expiration time, issue time, user ID (as the subject), and a unique token ID.
-// Returns the signed token string or an error if signing fails.
-func GenerateToken(userID *uuid.UUID, secret string, lifeTime int) (string, error) {
+// Returns the signed token string and its unique ID, or an error if signing fails.
+func GenerateToken(userID *uuid.UUID, secret string, lifeTime int) (string, string, error) {
 	claims := jwt.RegisteredClaims{
 		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(lifeTime) * time.Minute)), // Token expiration time
 		IssuedAt:  jwt.NewNumericDate(time.Now()),                                            // Token issue time
@@ -19,5 +21,83 @@ func GenerateToken(userID *uuid.UUID, secret string, lifeTime int) (string, erro
 
 	// Create a new token with HS256 signing method and add claims
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret)) // Sign the token with the provided secret
+	signed, err := token.SignedString([]byte(secret)) // Sign the token with the provided secret
+	if err != nil {
+		return "", "", err
+	}
+	return signed, claims.ID, nil
+}
+
+// Token validation errors. Their messages are returned to clients as is.
+var (
+	ErrTokenRequired       = errors.New("Token is required")
+	ErrInvalidTokenFormat  = errors.New("Invalid token format")
+	ErrInvalidToken        = errors.New("Invalid token")
+	ErrInvalidTokenClaims  = errors.New("Invalid token claims")
+	ErrInvalidTokenSubject = errors.New("Invalid token subject")
+	ErrTokenRevoked        = errors.New("Token has been revoked")
+)
+
+// bearerPrefix is the prefix of the Authorization header value carrying a JWT token.
+const bearerPrefix = "Bearer "
+
+// leewayClaims are the registered claims of a token, validated with a leeway for clock skew
+// between the services issuing and accepting the token.
+type leewayClaims struct {
+	jwt.RegisteredClaims
+	leeway time.Duration // Tolerance applied to the exp, iat and nbf claims
+}
+
+// Valid validates the time based claims, accepting tokens that expired at most leeway ago
+// and tokens issued or valid from at most leeway in the future.
+func (c *leewayClaims) Valid() error {
+	now := jwt.TimeFunc()
+	if !c.VerifyExpiresAt(now.Add(-c.leeway), false) {
+		return jwt.ErrTokenExpired
+	}
+	if !c.VerifyIssuedAt(now.Add(c.leeway), false) {
+		return jwt.ErrTokenUsedBeforeIssued
+	}
+	if !c.VerifyNotBefore(now.Add(c.leeway), false) {
+		return jwt.ErrTokenNotValidYet
+	}
+	return nil
+}
+
+// Authenticate validates an Authorization header value in the "Bearer <token>" format and
+// returns the user ID stored in the token's subject along with the unique ID of the token. It is transport-agnostic, so the same
+// validation can back the HTTP middleware and other API transports. Tokens that expired at
+// most leeway seconds ago are still accepted, so that slightly skewed clocks do not reject them.
+// Returns one of the token validation errors if the value is missing, the token is invalid or its
+// subject is not a UUID.
+func Authenticate(authorization, secret string, leeway int) (string, string, error) {
+	if authorization == "" {
+		return "", "", ErrTokenRequired
+	}
+	// Verify that the token follows the "Bearer " format.
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return "", "", ErrInvalidTokenFormat
+	}
+
+	// Parse and validate the token, accepting only the HMAC signing method used by GenerateToken.
+	claims := &leewayClaims{leeway: time.Duration(leeway) * time.Second}
+	token, err := jwt.ParseWithClaims(authorization[len(bearerPrefix):], claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return "", "", ErrInvalidToken
+	}
+
+	// Retrieve claims from the token, specifically the subject (user ID).
+	claims, ok := token.Claims.(*leewayClaims)
+	if !ok {
+		return "", "", ErrInvalidTokenClaims
+	}
+	if _, err := uuid.Parse(claims.Subject); err != nil {
+		return "", "", ErrInvalidTokenSubject
+	}
+	return claims.Subject, claims.ID, nil
 }
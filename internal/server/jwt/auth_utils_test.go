@@ -0,0 +1,106 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticate(t *testing.T) {
+	secret := "secret"
+	userID := uuid.New()
+	valid, tokenID, err := GenerateToken(&userID, secret, 60)
+	assert.NoError(t, err)
+	expired, _, err := GenerateToken(&userID, secret, -1)
+	assert.NoError(t, err)
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{Subject: userID.String()}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+	notUUID, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "not-a-uuid"}).
+		SignedString([]byte(secret))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		authorization string
+		expectedErr   error
+	}{
+		{"Valid", "Bearer " + valid, nil},
+		{"Missing", "", ErrTokenRequired},
+		{"NoBearerPrefix", valid, ErrInvalidTokenFormat},
+		{"WrongSecret", "Bearer " + valid + "x", ErrInvalidToken},
+		{"Expired", "Bearer " + expired, ErrInvalidToken},
+		{"UnsignedToken", "Bearer " + unsigned, ErrInvalidToken},
+		{"SubjectNotUUID", "Bearer " + notUUID, ErrInvalidTokenSubject},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subject, id, err := Authenticate(tc.authorization, secret, 0)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, subject)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, userID.String(), subject)
+			assert.Equal(t, tokenID, id)
+		})
+	}
+}
+
+func TestAuthenticate_Leeway(t *testing.T) {
+	secret := "secret"
+	userID := uuid.New()
+	// expiredAgo signs a token that expired the given duration ago.
+	expiredAgo := func(d time.Duration) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-d)),
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+			Subject:   userID.String(),
+		}).SignedString([]byte(secret))
+		assert.NoError(t, err)
+		return "Bearer " + token
+	}
+	// issuedIn signs a token issued the given duration in the future, as seen by a clock running behind.
+	issuedIn := func(d time.Duration) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(d)),
+			Subject:   userID.String(),
+		}).SignedString([]byte(secret))
+		assert.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	testCases := []struct {
+		name          string
+		authorization string
+		leeway        int
+		expectedErr   error
+	}{
+		{"ExpiredInsideLeeway", expiredAgo(10 * time.Second), 30, nil},
+		{"ExpiredOutsideLeeway", expiredAgo(40 * time.Second), 30, ErrInvalidToken},
+		{"ExpiredWithoutLeeway", expiredAgo(10 * time.Second), 0, ErrInvalidToken},
+		{"IssuedInFutureInsideLeeway", issuedIn(10 * time.Second), 30, nil},
+		{"IssuedInFutureOutsideLeeway", issuedIn(40 * time.Second), 30, ErrInvalidToken},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subject, _, err := Authenticate(tc.authorization, secret, tc.leeway)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, subject)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, userID.String(), subject)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// Maintenance answers the endpoints it guards with 503 Service Unavailable while maintenance mode
+// is on, telling clients in the Retry-After header when to try again. Maintenance mode is on when
+// the maintenance flag is set or an admin switched the shared toggle on. While the toggle cannot be
+// read, only the flag decides, so that an unreachable Redis never takes the endpoints down.
+//
+// Parameters:
+//   - config: API configuration with the maintenance flag, Retry-After delay and message.
+//   - store: Store holding the maintenance mode toggle shared by all instances.
+//
+// Returns:
+//   - (gin.HandlerFunc): Gin middleware handler function.
+func Maintenance(config *APIConfig, store interfaces.IMaintenanceStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !MaintenanceEnabled(c, config, store) {
+			c.Next()
+			return
+		}
+		if config.MaintenanceRetry > 0 {
+			c.Header("Retry-After", strconv.Itoa(config.MaintenanceRetry))
+		}
+		ServiceUnavailableErrorResponse(c, config.MaintenanceMessage)
+	}
+}
+
+// MaintenanceEnabled reports whether maintenance mode is on, either by the maintenance flag or by
+// the shared toggle. A toggle that cannot be read is logged and treated as off.
+func MaintenanceEnabled(c *gin.Context, config *APIConfig, store interfaces.IMaintenanceStore) bool {
+	if config.Maintenance {
+		return true
+	}
+	enabled, err := store.Enabled(c.Request.Context())
+	if err != nil {
+		log.FromContext(c).Warnf("maintenance toggle unavailable, assuming maintenance mode is off: %v", err)
+		return false
+	}
+	return enabled
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+)
+
+// memoryMaintenanceStore is an in-memory IMaintenanceStore.
+type memoryMaintenanceStore struct {
+	enabled bool
+	err     error
+}
+
+func (s *memoryMaintenanceStore) Enabled(context.Context) (bool, error) {
+	return s.enabled, s.err
+}
+
+func (s *memoryMaintenanceStore) SetEnabled(_ context.Context, enabled bool) error {
+	s.enabled = enabled
+	return s.err
+}
+
+// newMaintenanceTestEngine serves a spin route guarded by the maintenance middleware next to an
+// unguarded status route.
+func newMaintenanceTestEngine(config *APIConfig, store *memoryMaintenanceStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/status", func(c *gin.Context) { c.String(http.StatusOK, "OK") })
+	router.POST("/api/slot/spin", Maintenance(config, store), func(c *gin.Context) {
+		SuccessResponse(c, gin.H{"win_amount": 0})
+	})
+	return router
+}
+
+// serveMethod performs a request without a body with the given method.
+func serveMethod(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestMaintenance_ToggleBlocksSpinsButNotStatus(t *testing.T) {
+	config := &APIConfig{MaintenanceRetry: 120, MaintenanceMessage: "back soon"}
+	store := &memoryMaintenanceStore{}
+	router := newMaintenanceTestEngine(config, store)
+
+	assert.Equal(t, http.StatusOK, serveMethod(router, http.MethodPost, "/api/slot/spin").Code)
+
+	require.NoError(t, store.SetEnabled(context.Background(), true))
+	spin := serveMethod(router, http.MethodPost, "/api/slot/spin")
+	status := serveMethod(router, http.MethodGet, "/status")
+
+	body := &ErrorResponseMessage{}
+	assert.Equal(t, http.StatusServiceUnavailable, spin.Code)
+	assert.Equal(t, "120", spin.Header().Get("Retry-After"))
+	require.NoError(t, json.Unmarshal(spin.Body.Bytes(), body))
+	assert.Equal(t, serviceError.CodeMaintenance, body.Code)
+	assert.Equal(t, []string{"back soon"}, body.Errors)
+	assert.Equal(t, http.StatusOK, status.Code)
+
+	require.NoError(t, store.SetEnabled(context.Background(), false))
+	assert.Equal(t, http.StatusOK, serveMethod(router, http.MethodPost, "/api/slot/spin").Code)
+}
+
+func TestMaintenance_Flag(t *testing.T) {
+	testCases := []struct {
+		name     string
+		flag     bool
+		storeErr error
+		expected int
+	}{
+		{"FlagOverridesToggle", true, nil, http.StatusServiceUnavailable},
+		{"FlagWithToggleUnavailable", true, errors.New("connection refused"), http.StatusServiceUnavailable},
+		{"ToggleUnavailable", false, errors.New("connection refused"), http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &memoryMaintenanceStore{err: tc.storeErr}
+			router := newMaintenanceTestEngine(&APIConfig{Maintenance: tc.flag}, store)
+
+			rec := serveMethod(router, http.MethodPost, "/api/slot/spin")
+
+			assert.Equal(t, tc.expected, rec.Code)
+			assert.Empty(t, rec.Header().Get("Retry-After"), "no Retry-After without a delay")
+		})
+	}
+}
@@ -1,27 +1,68 @@
 package server
 
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
 	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/constants"
+	dto "github.com/vadymlab/slot-game/internal/dto/response"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/middlewares"
 	"net/http"
 	"reflect"
 )
 
-// ErrorResponseMessage represents the structure of an error response with a list of error messages.
+// ErrorResponseMessage represents the structure of an error response with a stable
+// machine-readable error code, a list of human-readable error messages and the trace ID
+// of the request for support correlation. Insufficient funds errors that know the balance
+// also carry the balance and the shortfall.
 type ErrorResponseMessage struct {
-	Errors []string `json:"errors"`
+	Code      string     `json:"code" example:"INSUFFICIENT_FUNDS"`
+	Errors    []string   `json:"errors"`
+	TraceID   string     `json:"trace_id,omitempty" example:"3f1c2a9e-8d4b-4a47-9a3e-0d9b1f2c7e11"`
+	Balance   *dto.Money `json:"balance,omitempty" swaggertype:"number" example:"20.00"`
+	Shortfall *dto.Money `json:"shortfall,omitempty" swaggertype:"number" example:"30.00"`
 }
 
 // SuccessResponse sends a successful HTTP response with status 200 and a response body.
+// The body is wrapped in an Envelope when the request asks for one.
 func SuccessResponse(ctx *gin.Context, body interface{}) {
-	response(ctx, http.StatusOK, body)
+	response(ctx, http.StatusOK, envelope(ctx, body))
+}
+
+// AcceptedResponse sends an HTTP response with status 202 and a response body, for a request
+// that was accepted but is completed later. The body is wrapped in an Envelope when the request asks for one.
+func AcceptedResponse(ctx *gin.Context, body interface{}) {
+	response(ctx, http.StatusAccepted, envelope(ctx, body))
+}
+
+// Flush sends the part of a streamed response written so far to the client. Responses whose writer
+// cannot flush, such as the writer of http.TimeoutHandler, stay buffered until the handler returns
+// instead of panicking.
+func Flush(ctx *gin.Context) {
+	if canFlush(ctx.Writer) {
+		ctx.Writer.Flush()
+	}
+}
+
+// canFlush reports whether the innermost writer wrapped by w can flush.
+func canFlush(w http.ResponseWriter) bool {
+	for {
+		wrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			_, ok := w.(http.Flusher)
+			return ok
+		}
+		w = wrapper.Unwrap()
+	}
 }
 
 // UnauthorizedErrorResponse logs the error message and sends an unauthorized response with status 401.
 // The function also aborts the current context.
-func UnauthorizedErrorResponse(ctx *gin.Context, message string) {
+func UnauthorizedErrorResponse(ctx *gin.Context, message interface{}) {
 	log.FromContext(ctx).Error(message)
-	response(ctx, http.StatusUnauthorized, NewErrorMessage(message))
+	errorResponse(ctx, http.StatusUnauthorized, NewErrorMessage(message, serviceError.CodeUnauthorized))
 	ctx.Abort()
 }
 
@@ -29,7 +70,7 @@ func UnauthorizedErrorResponse(ctx *gin.Context, message string) {
 // It uses a list of error messages and aborts the current context.
 func ErrorsBadRequest(ctx *gin.Context, message []string) {
 	log.FromContext(ctx).Error(message)
-	response(ctx, http.StatusBadRequest, NewErrorMessages(message))
+	errorResponse(ctx, http.StatusBadRequest, NewErrorMessages(message, serviceError.CodeValidation))
 	ctx.Abort()
 }
 
@@ -37,47 +78,155 @@ func ErrorsBadRequest(ctx *gin.Context, message []string) {
 // The message can be of any type, and the context is aborted.
 func ErrorBadRequest(ctx *gin.Context, message interface{}) {
 	log.FromContext(ctx).Error(message)
-	response(ctx, http.StatusBadRequest, NewErrorMessage(message))
+	errorResponse(ctx, http.StatusBadRequest, NewErrorMessage(message, serviceError.CodeBadRequest))
 	ctx.Abort()
 }
 
 // NewErrorMessage creates a new ErrorResponseMessage with a single error message.
 // It accepts either an error object or a string and returns a pointer to ErrorResponseMessage.
-func NewErrorMessage(err interface{}) *ErrorResponseMessage {
+// The code is derived from the predefined service errors when err is one of them;
+// otherwise the fallback code is used. A FundsShortfall adds the balance and the shortfall.
+func NewErrorMessage(err interface{}, fallbackCode string) *ErrorResponseMessage {
 	var errorMessage string
+	code := fallbackCode
+	body := &ErrorResponseMessage{}
 	if e, ok := err.(error); ok {
 		errorMessage = e.Error()
+		if c := serviceError.Code(e); c != "" {
+			code = c
+		}
+		var shortfall *serviceError.FundsShortfall
+		if errors.As(e, &shortfall) {
+			body.Balance = dto.MoneyPtr(&shortfall.Balance)
+			body.Shortfall = dto.MoneyPtr(&shortfall.Shortfall)
+		}
 	} else if msg, ok := err.(string); ok {
 		errorMessage = msg
 	}
-	return &ErrorResponseMessage{
-		Errors: []string{errorMessage},
-	}
+	body.Code = code
+	body.Errors = []string{errorMessage}
+	return body
 }
 
-// NewErrorMessages creates an ErrorResponseMessage with multiple error messages.
-func NewErrorMessages(errors []string) *ErrorResponseMessage {
+// NewErrorMessages creates an ErrorResponseMessage with multiple error messages and the given code.
+func NewErrorMessages(errors []string, code string) *ErrorResponseMessage {
 	return &ErrorResponseMessage{
+		Code:   code,
 		Errors: errors,
 	}
 }
 
 // InternalErrorResponse logs the error message and sends an internal server error response with status 500.
 // The function also aborts the current context.
-func InternalErrorResponse(ctx *gin.Context, message string) {
+func InternalErrorResponse(ctx *gin.Context, message interface{}) {
+	log.FromContext(ctx).Error(message)
+	errorResponse(ctx, http.StatusInternalServerError, NewErrorMessage(message, serviceError.CodeInternal))
+	ctx.Abort()
+}
+
+// ForbiddenErrorResponse logs the error message and sends a forbidden response with status 403.
+// The function also aborts the current context.
+func ForbiddenErrorResponse(ctx *gin.Context, message interface{}) {
 	log.FromContext(ctx).Error(message)
-	response(ctx, http.StatusInternalServerError, NewErrorMessage(message))
+	errorResponse(ctx, http.StatusForbidden, NewErrorMessage(message, serviceError.CodeForbidden))
+	ctx.Abort()
+}
+
+// NotFoundErrorResponse logs the error message and sends a not found response with status 404.
+// The function also aborts the current context.
+func NotFoundErrorResponse(ctx *gin.Context, message interface{}) {
+	log.FromContext(ctx).Error(message)
+	errorResponse(ctx, http.StatusNotFound, NewErrorMessage(message, serviceError.CodeNotFound))
 	ctx.Abort()
 }
 
 // ConflictErrorResponse logs the error message and sends a conflict response with status 409.
 // The function also aborts the current context.
-func ConflictErrorResponse(ctx *gin.Context, message string) {
+func ConflictErrorResponse(ctx *gin.Context, message interface{}) {
 	log.FromContext(ctx).Error(message)
-	response(ctx, http.StatusConflict, NewErrorMessage(message))
+	errorResponse(ctx, http.StatusConflict, NewErrorMessage(message, serviceError.CodeConflict))
 	ctx.Abort()
 }
 
+// TooManyRequestsErrorResponse logs the error message and sends a too many requests response with status 429.
+// The function also aborts the current context.
+func TooManyRequestsErrorResponse(ctx *gin.Context, message interface{}) {
+	log.FromContext(ctx).Error(message)
+	errorResponse(ctx, http.StatusTooManyRequests, NewErrorMessage(message, serviceError.CodeTooManyRequests))
+	ctx.Abort()
+}
+
+// LockedErrorResponse logs the error message and sends a locked response with status 423.
+// The function also aborts the current context.
+func LockedErrorResponse(ctx *gin.Context, message interface{}) {
+	log.FromContext(ctx).Error(message)
+	errorResponse(ctx, http.StatusLocked, NewErrorMessage(message, serviceError.CodeAccountLocked))
+	ctx.Abort()
+}
+
+// NotAcceptableErrorResponse logs the error message and sends a not acceptable response with status 406.
+// The client accepts none of the media types of the endpoint, so the error is sent as JSON regardless
+// of the Accept header. The function also aborts the current context.
+func NotAcceptableErrorResponse(ctx *gin.Context, message interface{}) {
+	log.FromContext(ctx).Error(message)
+	body := NewErrorMessage(message, serviceError.CodeNotAcceptable)
+	attachTraceID(ctx, body)
+	ctx.JSON(http.StatusNotAcceptable, envelope(ctx, body))
+	ctx.Abort()
+}
+
+// UnsupportedMediaTypeErrorResponse logs the error message and sends an unsupported media type
+// response with status 415. The function also aborts the current context.
+func UnsupportedMediaTypeErrorResponse(ctx *gin.Context, message interface{}) {
+	log.FromContext(ctx).Error(message)
+	errorResponse(ctx, http.StatusUnsupportedMediaType, NewErrorMessage(message, serviceError.CodeUnsupportedMediaType))
+	ctx.Abort()
+}
+
+// ServiceUnavailableErrorResponse logs the error message and sends a service unavailable response
+// with status 503. The function also aborts the current context.
+func ServiceUnavailableErrorResponse(ctx *gin.Context, message interface{}) {
+	log.FromContext(ctx).Error(message)
+	errorResponse(ctx, http.StatusServiceUnavailable, NewErrorMessage(message, serviceError.CodeMaintenance))
+	ctx.Abort()
+}
+
+// ServerBusyErrorResponse logs the error message and sends a service unavailable response with
+// status 503 for a request the server is too busy to handle. The function also aborts the current context.
+func ServerBusyErrorResponse(ctx *gin.Context, message interface{}) {
+	log.FromContext(ctx).Warn(message)
+	errorResponse(ctx, http.StatusServiceUnavailable, NewErrorMessage(message, serviceError.CodeServerBusy))
+	ctx.Abort()
+}
+
+// errorResponse attaches the request trace ID to the error body and the response headers,
+// then sends the error response, wrapped in an Envelope when the request asks for one.
+func errorResponse(ctx *gin.Context, code int, body *ErrorResponseMessage) {
+	attachTraceID(ctx, body)
+	response(ctx, code, envelope(ctx, body))
+}
+
+// attachTraceID sets the request trace ID on the error body and the response headers.
+func attachTraceID(ctx *gin.Context, body *ErrorResponseMessage) {
+	if traceID := traceID(ctx); traceID != "" {
+		body.TraceID = traceID
+		ctx.Header(middlewares.HeaderTraceID, traceID)
+	}
+}
+
+// traceID returns the trace ID assigned to the request by TraceMiddleware, or an empty string.
+func traceID(ctx *gin.Context) string {
+	if traceID := ctx.GetString(string(constants.CtxFieldTraceID)); traceID != "" {
+		return traceID
+	}
+	if ctx.Request != nil {
+		if traceID, ok := ctx.Request.Context().Value(constants.CtxFieldTraceID).(string); ok {
+			return traceID
+		}
+	}
+	return ""
+}
+
 // response sends an HTTP response based on the Accept header.
 // Supports JSON and XML formats. Defaults to JSON if no specific format is requested.
 // Handles nil and empty slice cases gracefully by setting appropriate HTTP status codes.
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	dto "github.com/vadymlab/slot-game/internal/dto/response"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/middlewares"
+)
+
+// performError runs handler on a test context and decodes the error response.
+func performError(t *testing.T, handler func(ctx *gin.Context)) (int, *ErrorResponseMessage) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	handler(ctx)
+
+	body := &ErrorResponseMessage{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	return rec.Code, body
+}
+
+func TestErrorResponses_Codes(t *testing.T) {
+	testCases := []struct {
+		name            string
+		handler         func(ctx *gin.Context)
+		expectedStatus  int
+		expectedErrCode string
+	}{
+		{"InsufficientFunds", func(ctx *gin.Context) { ErrorBadRequest(ctx, serviceError.ErrInsufficientFunds) }, http.StatusBadRequest, serviceError.CodeInsufficientFunds},
+		{"InvalidAmount", func(ctx *gin.Context) { ErrorBadRequest(ctx, serviceError.ErrInvalidAmount) }, http.StatusBadRequest, serviceError.CodeInvalidAmount},
+		{"InvalidCredentials", func(ctx *gin.Context) { ErrorBadRequest(ctx, serviceError.ErrInvalidPass) }, http.StatusBadRequest, serviceError.CodeInvalidCreds},
+		{"UserNotFound", func(ctx *gin.Context) { ErrorBadRequest(ctx, serviceError.ErrUserNotFound) }, http.StatusBadRequest, serviceError.CodeUserNotFound},
+		{"InvalidPeriod", func(ctx *gin.Context) { ErrorBadRequest(ctx, serviceError.ErrInvalidPeriod) }, http.StatusBadRequest, serviceError.CodeInvalidPeriod},
+		{"PromoExpired", func(ctx *gin.Context) { ErrorBadRequest(ctx, serviceError.ErrPromoExpired) }, http.StatusBadRequest, serviceError.CodePromoExpired},
+		{"MalformedRequest", func(ctx *gin.Context) { ErrorBadRequest(ctx, errors.New("unexpected EOF")) }, http.StatusBadRequest, serviceError.CodeBadRequest},
+		{"Validation", func(ctx *gin.Context) { ErrorsBadRequest(ctx, []string{"amount::required::"}) }, http.StatusBadRequest, serviceError.CodeValidation},
+		{"UserExists", func(ctx *gin.Context) { ConflictErrorResponse(ctx, serviceError.ErrUserExists) }, http.StatusConflict, serviceError.CodeUserExists},
+		{"Unauthorized", func(ctx *gin.Context) { UnauthorizedErrorResponse(ctx, "Token is required") }, http.StatusUnauthorized, serviceError.CodeUnauthorized},
+		{"Internal", func(ctx *gin.Context) { InternalErrorResponse(ctx, "database unavailable") }, http.StatusInternalServerError, serviceError.CodeInternal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := performError(t, tc.handler)
+
+			assert.Equal(t, tc.expectedStatus, status)
+			assert.Equal(t, tc.expectedErrCode, body.Code)
+			assert.NotEmpty(t, body.Errors)
+		})
+	}
+}
+
+func TestErrorResponses_TraceID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middlewares.TraceMiddleware())
+	engine.GET("/", func(ctx *gin.Context) { ErrorBadRequest(ctx, serviceError.ErrInvalidAmount) })
+
+	t.Run("FromRequestHeader", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(middlewares.HeaderTraceID, "support-trace-1")
+		engine.ServeHTTP(rec, req)
+
+		body := &ErrorResponseMessage{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "support-trace-1", body.TraceID)
+		assert.Equal(t, "support-trace-1", rec.Header().Get(middlewares.HeaderTraceID))
+	})
+
+	t.Run("Generated", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		body := &ErrorResponseMessage{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+		assert.NotEmpty(t, body.TraceID)
+		assert.Equal(t, body.TraceID, rec.Header().Get(middlewares.HeaderTraceID))
+	})
+}
+
+func TestSuccessResponse_PageEnvelope(t *testing.T) {
+	testCases := []struct {
+		name     string
+		page     interface{}
+		expected string
+	}{
+		{"Empty", dto.NewPage[*dto.SpinHistoryResponse](nil, 0, 20, 0), `{"data":[],"total":0,"limit":20,"offset":0}`},
+		{"NonEmpty", dto.NewPage([]*dto.SpinResponse{{WinAmount: 10, Wins: []*dto.LineWinResponse{}}}, 21, 1, 20), `{"data":[{"win_amount":10,"wins":[]}],"total":21,"limit":1,"offset":20}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+			SuccessResponse(ctx, tc.page)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, tc.expected, rec.Body.String())
+		})
+	}
+}
@@ -4,14 +4,28 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/database"
 	"github.com/vadymlab/slot-game/internal/middlewares"
 	"net/http"
 	"time"
 )
 
+// inFlightExempt lists the path prefixes the in-flight request limit does not apply to: the status
+// check polled by load balancers and the Swagger UI.
+var inFlightExempt = []string{"/api/status", "/swagger/"}
+
+// streamingPaths lists the paths of the endpoints that stream their responses. http.TimeoutHandler
+// buffers the whole response and cannot flush it, so these endpoints are served without it; the
+// request timeout middleware still cancels their operations.
+var streamingPaths = []string{"/api/slot/spin/stream", "/api/slot/history.csv"}
+
 // NewEngine creates and configures a new Gin engine instance.
-// It applies middleware, including request logging (if enabled), request recovery, and CORS settings.
-func NewEngine(config *APIConfig) *gin.Engine {
+// It applies middleware, including request logging (if enabled), request recovery, request tracing,
+// the in-flight request limit (if enabled), CORS settings, gzip response compression (if enabled) and
+// the response envelope selection. The in-flight request limit is kept within the database pool size
+// and only applies to the API endpoints, so that the status check and the API docs stay reachable
+// while the server is busy.
+func NewEngine(config *APIConfig, pool *database.PoolConfig) *gin.Engine {
 	var router *gin.Engine
 	if config.LogRequest {
 		// Use the default Gin engine with logging and recovery middleware
@@ -26,6 +40,12 @@ func NewEngine(config *APIConfig) *gin.Engine {
 	router.Use(gin.Recovery())
 	// Apply a trace middleware to manage request tracing IDs
 	router.Use(middlewares.TraceMiddleware())
+	// Run each request in an OpenTelemetry span, joining the caller's trace if it sent a traceparent
+	router.Use(middlewares.Tracing())
+	// Turn away the requests beyond the in-flight limit before they wait for database connections
+	router.Use(ConcurrencyLimit(InFlightLimit(config, pool), inFlightExempt...))
+	// Bind the request context to the request timeout so that timed-out operations are cancelled
+	router.Use(middlewares.RequestTimeout(time.Duration(config.RequestTimeout) * time.Second))
 
 	// Configure CORS settings to allow all origins, methods, and headers,
 	// with preflight requests cached for 12 hours
@@ -40,21 +60,44 @@ func NewEngine(config *APIConfig) *gin.Engine {
 		},
 		MaxAge: 12 * time.Hour,
 	}))
+
+	// Compress responses for clients that accept gzip, skipping responses below the size threshold
+	if config.Compression {
+		router.Use(middlewares.Gzip(config.CompressionMinSize))
+	}
+	// Select between raw and enveloped responses
+	router.Use(EnvelopeMiddleware(config.ResponseEnvelope))
 	return router
 }
 
 // NewServer creates and configures a new HTTP server with a specified Gin router and API configuration.
 // The server includes settings for address, timeouts, and max header bytes, with a timeout handler for request limits.
+// The streaming endpoints are not wrapped in the timeout handler, so that their responses can be flushed.
 func NewServer(router *gin.Engine, config *APIConfig) *http.Server {
 	server := &http.Server{
-		Addr:           config.APIHost + ":" + config.APIPort,                                                            // Server address
-		Handler:        http.TimeoutHandler(router, time.Duration(config.RequestTimeout)*time.Second, "Request timeout"), // Timeout handler
-		MaxHeaderBytes: config.MaxHeaderBytes,                                                                            // Maximum allowed header size
-		ReadTimeout:    time.Duration(config.RequestTimeout) * time.Second,                                               // Timeout for reading request
-		WriteTimeout:   time.Duration(config.ResponseTimeout) * time.Second,                                              // Timeout for writing response
+		Addr:           config.APIHost + ":" + config.APIPort,                                                       // Server address
+		Handler:        timeoutHandler(router, time.Duration(config.RequestTimeout)*time.Second, streamingPaths...), // Timeout handler
+		MaxHeaderBytes: config.MaxHeaderBytes,                                                                       // Maximum allowed header size
+		ReadTimeout:    time.Duration(config.RequestTimeout) * time.Second,                                          // Timeout for reading request
+		WriteTimeout:   time.Duration(config.ResponseTimeout) * time.Second,                                         // Timeout for writing response
 	}
 
 	// Log server startup details
 	log.FromDefaultContext().Info("Starting server on " + config.APIHost + ":" + config.APIPort)
 	return server
 }
+
+// timeoutHandler wraps the router in http.TimeoutHandler, except for the requests to the streaming
+// paths, which the router serves directly.
+func timeoutHandler(router http.Handler, timeout time.Duration, streaming ...string) http.Handler {
+	timed := http.TimeoutHandler(router, timeout, "Request timeout")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, path := range streaming {
+			if r.URL.Path == path {
+				router.ServeHTTP(w, r)
+				return
+			}
+		}
+		timed.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	dto "github.com/vadymlab/slot-game/internal/dto/response"
+)
+
+// newCompressionTestHandler builds an engine with compression enabled and wraps it
+// in the same timeout handler used by NewServer.
+func newCompressionTestHandler() http.Handler {
+	gin.SetMode(gin.TestMode)
+	router := NewEngine(&APIConfig{Compression: true, CompressionMinSize: 1024}, nil)
+	router.GET("/history", func(c *gin.Context) {
+		history := make([]*dto.SpinHistoryResponse, 200)
+		for i := range history {
+			history[i] = &dto.SpinHistoryResponse{BetAmount: 10, WinAmount: 20, Date: "2024-01-01 12:00:00"}
+		}
+		SuccessResponse(c, history)
+	})
+	router.GET("/status", func(c *gin.Context) {
+		SuccessResponse(c, gin.H{"status": "ok"})
+	})
+	return http.TimeoutHandler(router, 5*time.Second, "Request timeout")
+}
+
+func TestCompression_LargeResponseGzipped(t *testing.T) {
+	handler := newCompressionTestHandler()
+	req := httptest.NewRequest(http.MethodGet, "/history", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	reader, err := gzip.NewReader(rec.Body)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	var history []*dto.SpinHistoryResponse
+	assert.NoError(t, json.Unmarshal(body, &history))
+	assert.Len(t, history, 200)
+}
+
+func TestCompression_SmallResponseNotGzipped(t *testing.T) {
+	handler := newCompressionTestHandler()
+	req := httptest.NewRequest(http.MethodGet, "/status", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestCompression_ClientWithoutGzip(t *testing.T) {
+	handler := newCompressionTestHandler()
+	req := httptest.NewRequest(http.MethodGet, "/history", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	var history []*dto.SpinHistoryResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &history))
+}
+
+func TestRequestTimeout_DeadlineAttached(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewEngine(&APIConfig{RequestTimeout: 5}, nil)
+	var deadline time.Time
+	var hasDeadline bool
+	router.GET("/deadline", func(c *gin.Context) {
+		deadline, hasDeadline = c.Request.Context().Deadline()
+		SuccessResponse(c, gin.H{})
+	})
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/deadline", nil))
+
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), deadline, time.Second)
+}
+
+func TestFlush_UnderTimeoutHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/stream", func(c *gin.Context) {
+		c.String(http.StatusOK, "first")
+		Flush(c)
+		c.String(http.StatusOK, " second")
+	})
+	handler := http.TimeoutHandler(router, 5*time.Second, "Request timeout")
+	rec := httptest.NewRecorder()
+
+	assert.NotPanics(t, func() { handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil)) })
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "first second", rec.Body.String())
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	log "github.com/public-forge/go-logger"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// authAuditService implements the IAuthAuditService interface, keeping the audit log of
+// logins, registrations and login changes.
+type authAuditService struct {
+	userRepository      interfaces.IUserRepository      // Repository resolving the users of the events
+	authEventRepository interfaces.IAuthEventRepository // Repository storing the events
+}
+
+// Record writes the event in the background, detached from the request's cancellation.
+// Failures are logged, as the audit log must never fail the authentication it describes.
+//
+// Parameters:
+//   - ctx: Context of the request that performed the operation.
+//   - event: The event to record.
+func (s *authAuditService) Record(ctx context.Context, event *models.AuthEvent) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.record(ctx, event); err != nil {
+			log.FromContext(ctx).Errorf("failed to record %s auth event for %q: %v", event.Type, event.Login, err)
+		}
+	}()
+}
+
+// record attributes the event to the user holding its login, if it has no user yet, and stores it.
+func (s *authAuditService) record(ctx context.Context, event *models.AuthEvent) error {
+	if event.UserID == nil && event.Login != "" {
+		user, err := s.userRepository.GetByLogin(ctx, event.Login)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			event.UserID = &user.ID
+		}
+	}
+	return s.authEventRepository.AddEvent(ctx, event)
+}
+
+// Events retrieves a page of the authentication events of a user, newest first.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - limit: The maximum number of events to return.
+//   - offset: The number of events to skip.
+//
+// Returns:
+//   - A slice of pointers to AuthEvent models representing the requested page.
+//   - The total number of events of the user.
+//   - ErrUserNotFound if the user does not exist, or an error if the retrieval fails.
+func (s *authAuditService) Events(ctx context.Context, userID *uuid.UUID, limit, offset int) ([]*models.AuthEvent, int64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, 0, serviceError.ErrUserNotFound
+	}
+	events, total, err := s.authEventRepository.GetEvents(ctx, user.ID, limit, offset)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	return events, total, tr.Commit(id)
+}
+
+// NewAuthAuditService initializes a new authAuditService with the provided repositories.
+//
+// Parameters:
+//   - userRepository: UserRepository resolving the users of the events.
+//   - authEventRepository: AuthEventRepository storing the events.
+//
+// Returns:
+//   - An instance of authAuditService implementing IAuthAuditService.
+func NewAuthAuditService(
+	userRepository interfaces.IUserRepository,
+	authEventRepository interfaces.IAuthEventRepository,
+) interfaces.IAuthAuditService {
+	return &authAuditService{
+		userRepository:      userRepository,
+		authEventRepository: authEventRepository,
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+func TestAuthAuditService_RecordAttributesEventByLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockEventRepo := mocks.NewMockIAuthEventRepository(ctrl)
+	recorded := make(chan *models.AuthEvent, 1)
+	mockUserRepo.EXPECT().GetByLogin(gomock.Any(), "player@example.com").
+		Return(&models.User{Model: gorm.Model{ID: 7}}, nil)
+	mockEventRepo.EXPECT().AddEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event *models.AuthEvent) error {
+			recorded <- event
+			return nil
+		})
+
+	s := NewAuthAuditService(mockUserRepo, mockEventRepo)
+	s.Record(context.Background(), &models.AuthEvent{Login: "player@example.com", Type: models.AuthEventTypeLogin})
+
+	select {
+	case event := <-recorded:
+		if assert.NotNil(t, event.UserID) {
+			assert.Equal(t, uint(7), *event.UserID)
+		}
+		assert.False(t, event.Success)
+	case <-time.After(time.Second):
+		t.Fatal("event was not recorded")
+	}
+}
+
+func TestAuthAuditService_RecordUnknownLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockEventRepo := mocks.NewMockIAuthEventRepository(ctrl)
+	recorded := make(chan *models.AuthEvent, 1)
+	mockUserRepo.EXPECT().GetByLogin(gomock.Any(), "nobody@example.com").Return(nil, nil)
+	mockEventRepo.EXPECT().AddEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event *models.AuthEvent) error {
+			recorded <- event
+			return errors.New("db down") // Only logged, the caller is never affected
+		})
+
+	s := NewAuthAuditService(mockUserRepo, mockEventRepo)
+	s.Record(context.Background(), &models.AuthEvent{Login: "nobody@example.com", Type: models.AuthEventTypeLogin})
+
+	select {
+	case event := <-recorded:
+		assert.Nil(t, event.UserID)
+	case <-time.After(time.Second):
+		t.Fatal("event was not recorded")
+	}
+}
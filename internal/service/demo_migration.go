@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+)
+
+// noopDemoMigration implements IDemoMigration by ignoring the balance of ended demo sessions, so that
+// even with the demo balance migration enabled, no play money reaches the real wallet unless a
+// deployment provides a hook of its own.
+type noopDemoMigration struct{}
+
+// Migrate ignores the balance of the ended demo session.
+func (noopDemoMigration) Migrate(context.Context, *uuid.UUID, float64) error {
+	return nil
+}
+
+// NewDemoMigration creates the default demo migration hook, which does nothing.
+//
+// Returns:
+//   - An instance of IDemoMigration ignoring the balances of ended demo sessions.
+func NewDemoMigration() interfaces.IDemoMigration {
+	return noopDemoMigration{}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/webhook"
+)
+
+// EventNotifier publishes significant wins and deposits to external subscribers, and every
+// committed spin, deposit and withdrawal to the event bus.
+// Events are published asynchronously so that slow or failing subscribers never delay
+// or fail the request; callers must only notify after the transaction has committed.
+// A nil EventNotifier is valid and publishes nothing.
+type EventNotifier struct {
+	cfg       *webhook.Config            // Thresholds above which events are published
+	publisher interfaces.IEventPublisher // Transport delivering the events
+	bus       interfaces.IEventBus       // Broker receiving the domain events
+}
+
+// NotifyWin publishes a big win event if the spin won at least the configured threshold.
+//
+// Parameters:
+//   - ctx: Context of the request that performed the spin.
+//   - userID: A UUID representing the user's external identifier.
+//   - spin: The committed spin.
+func (n *EventNotifier) NotifyWin(ctx context.Context, userID *uuid.UUID, spin *models.Spin) {
+	if n == nil || spin.WinAmount <= 0 || spin.WinAmount < n.cfg.WinThreshold {
+		return
+	}
+	n.publish(ctx, models.EventTypeBigWin, userID, spin.WinAmount)
+}
+
+// NotifySpin publishes the SpinCompleted event of a committed spin to the event bus.
+//
+// Parameters:
+//   - ctx: Context of the request that performed the spin.
+//   - userID: A UUID representing the user's external identifier.
+//   - spin: The committed spin.
+func (n *EventNotifier) NotifySpin(ctx context.Context, userID *uuid.UUID, spin *models.Spin) {
+	if n == nil {
+		return
+	}
+	event := n.domainEvent(ctx, models.DomainEventSpinCompleted, userID, spin.BetAmount)
+	event.Spin = &models.SpinCompletedEvent{
+		SpinID:    spin.ID,
+		GameID:    spin.GameID,
+		SessionID: spin.SessionID,
+		BetAmount: spin.BetAmount,
+		WinAmount: spin.WinAmount,
+		Reels:     spin.Reels,
+		Bonuses:   spin.Bonuses,
+	}
+	n.publishDomain(ctx, event)
+}
+
+// NotifyDeposit publishes the DepositMade event of a committed deposit to the event bus, and a
+// deposit event if the amount is at least the configured threshold.
+//
+// Parameters:
+//   - ctx: Context of the request that performed the deposit.
+//   - userID: A UUID representing the user's external identifier.
+//   - amount: The committed deposit amount.
+func (n *EventNotifier) NotifyDeposit(ctx context.Context, userID *uuid.UUID, amount float64) {
+	if n == nil {
+		return
+	}
+	n.publishDomain(ctx, n.domainEvent(ctx, models.DomainEventDepositMade, userID, amount))
+	if amount < n.cfg.DepositThreshold {
+		return
+	}
+	n.publish(ctx, models.EventTypeDeposit, userID, amount)
+}
+
+// NotifyWithdrawal publishes the WithdrawalMade event of a paid out withdrawal to the event bus.
+//
+// Parameters:
+//   - ctx: Context of the request that paid out the withdrawal.
+//   - userID: A UUID representing the user's external identifier.
+//   - amount: The withdrawn amount.
+func (n *EventNotifier) NotifyWithdrawal(ctx context.Context, userID *uuid.UUID, amount float64) {
+	if n == nil {
+		return
+	}
+	n.publishDomain(ctx, n.domainEvent(ctx, models.DomainEventWithdrawalMade, userID, amount))
+}
+
+// publish delivers the event in the background, detached from the request's cancellation.
+func (n *EventNotifier) publish(ctx context.Context, eventType string, userID *uuid.UUID, amount float64) {
+	event := &models.Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		UserID:     userID.String(),
+		Amount:     amount,
+		OccurredAt: time.Now().UTC(),
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := n.publisher.Publish(ctx, event); err != nil {
+			log.FromContext(ctx).Errorf("failed to publish %s event %s: %v", event.Type, event.ID, err)
+		}
+	}()
+}
+
+// domainEvent creates a domain event carrying the trace ID of the request.
+func (n *EventNotifier) domainEvent(ctx context.Context, eventType string, userID *uuid.UUID, amount float64) *models.DomainEvent {
+	traceID, _ := ctx.Value(constants.CtxFieldTraceID).(string)
+	return &models.DomainEvent{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		TraceID:    traceID,
+		UserID:     userID.String(),
+		Amount:     amount,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// publishDomain publishes the domain event to the bus in the background, detached from the
+// request's cancellation.
+func (n *EventNotifier) publishDomain(ctx context.Context, event *models.DomainEvent) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := n.bus.Publish(ctx, event); err != nil {
+			log.FromContext(ctx).Errorf("failed to publish %s event %s to the event bus: %v", event.Type, event.ID, err)
+		}
+	}()
+}
+
+// NewEventNotifier creates an EventNotifier publishing through the given publisher and event bus.
+//
+// Parameters:
+//   - cfg: Webhook configuration, including the event thresholds.
+//   - publisher: Transport delivering the events.
+//   - bus: Broker receiving the domain events.
+//
+// Returns:
+//   - A pointer to an EventNotifier.
+func NewEventNotifier(cfg *webhook.Config, publisher interfaces.IEventPublisher, bus interfaces.IEventBus) *EventNotifier {
+	return &EventNotifier{
+		cfg:       cfg,
+		publisher: publisher,
+		bus:       bus,
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/constants"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/webhook"
+)
+
+func TestEventNotifier_BelowThresholdWinNotPublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No Publish expectation: any call fails the test
+	mockPublisher := mocks.NewMockIEventPublisher(ctrl)
+	userID := uuid.New()
+
+	n := NewEventNotifier(&webhook.Config{WinThreshold: 100}, mockPublisher, mocks.NewMockIEventBus(ctrl))
+	n.NotifyWin(context.Background(), &userID, &models.Spin{BetAmount: 10, WinAmount: 99})
+	n.NotifyWin(context.Background(), &userID, &models.Spin{BetAmount: 10, WinAmount: 0})
+}
+
+func TestEventNotifier_WinAboveThresholdPublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisher := mocks.NewMockIEventPublisher(ctrl)
+	userID := uuid.New()
+	published := make(chan *models.Event, 1)
+	mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event *models.Event) error {
+			published <- event
+			return nil
+		})
+
+	n := NewEventNotifier(&webhook.Config{WinThreshold: 100}, mockPublisher, mocks.NewMockIEventBus(ctrl))
+	n.NotifyWin(context.Background(), &userID, &models.Spin{BetAmount: 10, WinAmount: 100})
+
+	select {
+	case event := <-published:
+		assert.Equal(t, models.EventTypeBigWin, event.Type)
+		assert.Equal(t, userID.String(), event.UserID)
+		assert.Equal(t, 100.0, event.Amount)
+	case <-time.After(time.Second):
+		t.Fatal("event was not published")
+	}
+}
+
+func TestEventNotifier_DepositPublishedToBus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The deposit is below the webhook threshold, so Publish of the webhook fails the test
+	mockBus := mocks.NewMockIEventBus(ctrl)
+	userID := uuid.New()
+	published := make(chan *models.DomainEvent, 1)
+	mockBus.EXPECT().Publish(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event *models.DomainEvent) error {
+			published <- event
+			return nil
+		})
+
+	n := NewEventNotifier(&webhook.Config{DepositThreshold: 100}, mocks.NewMockIEventPublisher(ctrl), mockBus)
+	n.NotifyDeposit(context.WithValue(context.Background(), constants.CtxFieldTraceID, "trace-1"), &userID, 25)
+
+	select {
+	case event := <-published:
+		assert.Equal(t, models.DomainEventDepositMade, event.Type)
+		assert.Equal(t, "trace-1", event.TraceID)
+		assert.Equal(t, userID.String(), event.UserID)
+		assert.Equal(t, 25.0, event.Amount)
+		assert.Nil(t, event.Spin)
+	case <-time.After(time.Second):
+		t.Fatal("event was not published")
+	}
+}
+
+func TestEventNotifier_Nil(t *testing.T) {
+	var n *EventNotifier
+	userID := uuid.New()
+
+	assert.NotPanics(t, func() {
+		n.NotifyWin(context.Background(), &userID, &models.Spin{WinAmount: 1000})
+		n.NotifyDeposit(context.Background(), &userID, 1000)
+		n.NotifySpin(context.Background(), &userID, &models.Spin{BetAmount: 10})
+		n.NotifyWithdrawal(context.Background(), &userID, 1000)
+	})
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
+	error2 "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// forcedOutcome returns the outcome QA forces on the spins of the request, stored in the context
+// under CtxFieldForcedOutcome. It is nil unless forced outcomes are enabled in the configuration
+// and the request forces one, so that the outcome can never be forced in production.
+//
+// Parameters:
+//   - ctx: The request context.
+//   - game: The configuration of the game played.
+//
+// Returns:
+//   - A pointer to the forced outcome, or nil to draw the spin.
+//   - ErrInvalidForcedOutcome if the forced reels do not match the reels and symbols of the game, or
+//     the forced win is negative.
+func (s *slotService) forcedOutcome(ctx context.Context, game *config.SlotConfig) (*models.ForcedOutcome, error) {
+	if !s.config.ForcedOutcomes {
+		return nil, nil
+	}
+	outcome, _ := ctx.Value(constants.CtxFieldForcedOutcome).(*models.ForcedOutcome)
+	if outcome == nil {
+		return nil, nil
+	}
+	if len(outcome.Reels) > 0 {
+		if len(outcome.Reels) != game.Reels() {
+			return nil, error2.ErrInvalidForcedOutcome
+		}
+		for _, symbol := range outcome.Reels {
+			if !slices.Contains(game.ReelSymbols(), symbol) && symbol != game.WildSymbol && symbol != game.ScatterSymbol {
+				return nil, error2.ErrInvalidForcedOutcome
+			}
+		}
+	}
+	if outcome.Win != nil && *outcome.Win < 0 {
+		return nil, error2.ErrInvalidForcedOutcome
+	}
+	return outcome, nil
+}
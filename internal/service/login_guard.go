@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+
+	log "github.com/public-forge/go-logger"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/redis"
+)
+
+// loginGuard implements ILoginGuard, locking a login for the configured window after too many
+// failed attempts and rejecting replayed nonces. Store failures never block a login:
+// the guard logs them and lets the request through.
+type loginGuard struct {
+	cfg   *redis.Config                 // Lockout threshold and window
+	store interfaces.ILoginAttemptStore // Store for failed attempts and used nonces
+}
+
+// Check verifies that the login is not locked and that the optional nonce is fresh.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - login: The login the request is made for.
+//   - nonce: An optional client nonce; empty skips the replay check.
+//
+// Returns:
+//   - ErrNonceReused if the nonce was used within the window.
+//   - ErrAccountLocked if the login has reached the lockout threshold.
+//   - nil otherwise.
+func (g *loginGuard) Check(ctx context.Context, login, nonce string) error {
+	if nonce != "" {
+		fresh, err := g.store.UseNonce(ctx, nonce)
+		if err != nil {
+			log.FromContext(ctx).Warnf("login nonce check failed: %v", err)
+		} else if !fresh {
+			return serviceError.ErrNonceReused
+		}
+	}
+	if !g.lockoutEnabled() {
+		return nil
+	}
+	count, err := g.store.Count(ctx, login)
+	if err != nil {
+		log.FromContext(ctx).Warnf("login attempts read failed: %v", err)
+		return nil
+	}
+	if count >= g.cfg.LoginLockoutThreshold {
+		return serviceError.ErrAccountLocked
+	}
+	return nil
+}
+
+// RecordFailure records a failed attempt and reports whether it locked the login.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - login: The login the attempt was made for.
+//
+// Returns:
+//   - ErrAccountLocked if the attempt reached the lockout threshold; otherwise, nil.
+func (g *loginGuard) RecordFailure(ctx context.Context, login string) error {
+	if !g.lockoutEnabled() {
+		return nil
+	}
+	count, err := g.store.Increment(ctx, login)
+	if err != nil {
+		log.FromContext(ctx).Warnf("login attempts write failed: %v", err)
+		return nil
+	}
+	if count >= g.cfg.LoginLockoutThreshold {
+		log.FromContext(ctx).Warnf("login locked after %d failed attempts", count)
+		return serviceError.ErrAccountLocked
+	}
+	return nil
+}
+
+// RecordSuccess clears the failed attempts of the login.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - login: The authenticated login.
+func (g *loginGuard) RecordSuccess(ctx context.Context, login string) {
+	if !g.lockoutEnabled() {
+		return
+	}
+	if err := g.store.Reset(ctx, login); err != nil {
+		log.FromContext(ctx).Warnf("login attempts reset failed: %v", err)
+	}
+}
+
+// lockoutEnabled reports whether failed attempts are tracked.
+func (g *loginGuard) lockoutEnabled() bool {
+	return g.cfg.LoginLockoutThreshold > 0
+}
+
+// NewLoginGuard creates an ILoginGuard backed by the given attempt store.
+//
+// Parameters:
+//   - cfg: Redis configuration, including the lockout threshold.
+//   - store: Store for failed attempts and used nonces.
+//
+// Returns:
+//   - An ILoginGuard implementation.
+func NewLoginGuard(cfg *redis.Config, store interfaces.ILoginAttemptStore) interfaces.ILoginGuard {
+	return &loginGuard{
+		cfg:   cfg,
+		store: store,
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/redis"
+)
+
+func TestLoginGuard_LockoutAfterFailedAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockILoginAttemptStore(ctrl)
+	ctx := context.Background()
+	login := "player@example.com"
+
+	gomock.InOrder(
+		mockStore.EXPECT().Increment(ctx, login).Return(1, nil),
+		mockStore.EXPECT().Increment(ctx, login).Return(2, nil),
+		mockStore.EXPECT().Increment(ctx, login).Return(3, nil),
+		mockStore.EXPECT().Count(ctx, login).Return(3, nil),
+	)
+
+	g := NewLoginGuard(&redis.Config{LoginLockoutThreshold: 3}, mockStore)
+
+	// The first failures are reported as regular failures, the third one locks the login
+	assert.NoError(t, g.RecordFailure(ctx, login))
+	assert.NoError(t, g.RecordFailure(ctx, login))
+	assert.ErrorIs(t, g.RecordFailure(ctx, login), serviceError.ErrAccountLocked)
+	// Further attempts are rejected before the credentials are checked
+	assert.ErrorIs(t, g.Check(ctx, login, ""), serviceError.ErrAccountLocked)
+}
+
+func TestLoginGuard_UnlockAfterWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockILoginAttemptStore(ctrl)
+	ctx := context.Background()
+	login := "player@example.com"
+
+	// The attempts counter expires with the window, after which the login is accepted again
+	gomock.InOrder(
+		mockStore.EXPECT().Count(ctx, login).Return(3, nil),
+		mockStore.EXPECT().Count(ctx, login).Return(0, nil),
+	)
+
+	g := NewLoginGuard(&redis.Config{LoginLockoutThreshold: 3, LoginLockoutWindow: 900}, mockStore)
+
+	assert.ErrorIs(t, g.Check(ctx, login, ""), serviceError.ErrAccountLocked)
+	assert.NoError(t, g.Check(ctx, login, ""))
+}
+
+func TestLoginGuard_SuccessResetsAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockILoginAttemptStore(ctrl)
+	ctx := context.Background()
+	login := "player@example.com"
+
+	mockStore.EXPECT().Reset(ctx, login).Return(nil)
+
+	g := NewLoginGuard(&redis.Config{LoginLockoutThreshold: 3}, mockStore)
+	g.RecordSuccess(ctx, login)
+}
+
+func TestLoginGuard_NonceReplayRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockILoginAttemptStore(ctrl)
+	ctx := context.Background()
+	nonce := "3f1c9a7e5b2d4c6a"
+
+	gomock.InOrder(
+		mockStore.EXPECT().UseNonce(ctx, nonce).Return(true, nil),
+		mockStore.EXPECT().UseNonce(ctx, nonce).Return(false, nil),
+	)
+
+	// Lockout disabled: only the nonce is checked
+	g := NewLoginGuard(&redis.Config{}, mockStore)
+
+	assert.NoError(t, g.Check(ctx, "player@example.com", nonce))
+	assert.ErrorIs(t, g.Check(ctx, "player@example.com", nonce), serviceError.ErrNonceReused)
+}
+
+func TestLoginGuard_StoreUnavailableFailsOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockILoginAttemptStore(ctrl)
+	ctx := context.Background()
+	login := "player@example.com"
+	redisErr := errors.New("dial tcp: connection refused")
+
+	mockStore.EXPECT().Count(ctx, login).Return(0, redisErr)
+	mockStore.EXPECT().Increment(ctx, login).Return(0, redisErr)
+
+	g := NewLoginGuard(&redis.Config{LoginLockoutThreshold: 3}, mockStore)
+
+	assert.NoError(t, g.Check(ctx, login, ""))
+	assert.NoError(t, g.RecordFailure(ctx, login))
+}
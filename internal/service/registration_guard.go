@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/public-forge/go-logger"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// registrationGuard implements IRegistrationGuard, making registrations sent with an idempotency
+// key safe to retry. Store failures never block a registration: the guard logs them and registers
+// the user as if no key had been sent.
+type registrationGuard struct {
+	userService interfaces.IUserService          // Service registering and authenticating users
+	store       interfaces.IRegistrationKeyStore // Store for the used idempotency keys
+}
+
+// Register registers a user. A registration retried with the same idempotency key and the same
+// credentials returns the user created by the original request instead of failing as a duplicate.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - key: An optional idempotency key; empty registers without replay protection.
+//   - login: The login identifier for the new user.
+//   - password: The user's password.
+//
+// Returns:
+//   - A pointer to the registered or originally registered User model.
+//   - ErrIdempotencyKeyReused if the key was used for different credentials,
+//     or an error if the registration fails.
+func (g *registrationGuard) Register(ctx context.Context, key, login, password string) (*models.User, error) {
+	if key == "" {
+		return g.userService.Register(ctx, login, password)
+	}
+	if user, err := g.replay(ctx, key, login, password); user != nil || err != nil {
+		return user, err
+	}
+
+	user, err := g.userService.Register(ctx, login, password)
+	if err != nil {
+		// A concurrent request with the same key may have registered the user meanwhile
+		if errors.Is(err, serviceError.ErrUserExists) {
+			if user, replayErr := g.replay(ctx, key, login, password); user != nil || replayErr != nil {
+				return user, replayErr
+			}
+		}
+		return nil, err
+	}
+
+	saved, err := g.store.Save(ctx, key, &models.RegistrationKey{Login: login, UserID: *user.ExternalID})
+	if err != nil {
+		log.FromContext(ctx).Warnf("registration key write failed: %v", err)
+	} else if !saved {
+		log.FromContext(ctx).Warnf("registration key was used by a concurrent registration")
+	}
+	return user, nil
+}
+
+// replay returns the user registered with the key, if the key was used before for the same credentials.
+//
+// Returns:
+//   - The originally registered user, or nil if the key is unused or the store cannot be reached.
+//   - ErrIdempotencyKeyReused if the key was used for different credentials, or an error if the lookup fails.
+func (g *registrationGuard) replay(ctx context.Context, key, login, password string) (*models.User, error) {
+	registration, err := g.store.Get(ctx, key)
+	if err != nil {
+		log.FromContext(ctx).Warnf("registration key read failed: %v", err)
+		return nil, nil
+	}
+	if registration == nil {
+		return nil, nil
+	}
+	if registration.Login != login {
+		return nil, serviceError.ErrIdempotencyKeyReused
+	}
+	user, err := g.userService.Login(ctx, login, password)
+	if err != nil {
+		if errors.Is(err, serviceError.ErrInvalidPass) || errors.Is(err, serviceError.ErrUserNotFound) {
+			return nil, serviceError.ErrIdempotencyKeyReused
+		}
+		return nil, err
+	}
+	if user.ExternalID == nil || *user.ExternalID != registration.UserID {
+		return nil, serviceError.ErrIdempotencyKeyReused
+	}
+	return user, nil
+}
+
+// NewRegistrationGuard creates an IRegistrationGuard backed by the given key store.
+//
+// Parameters:
+//   - userService: Service registering and authenticating users.
+//   - store: Store for the used idempotency keys.
+//
+// Returns:
+//   - An IRegistrationGuard implementation.
+func NewRegistrationGuard(userService interfaces.IUserService, store interfaces.IRegistrationKeyStore) interfaces.IRegistrationGuard {
+	return &registrationGuard{
+		userService: userService,
+		store:       store,
+	}
+}
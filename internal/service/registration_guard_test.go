@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+func TestRegistrationGuard_ReplaysRetriedRegistration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockStore := mocks.NewMockIRegistrationKeyStore(ctrl)
+	ctx := context.Background()
+	key, login, password := "5d0c3a1e-retry", "player@example.com", "Str0ng!Passw0rd"
+	userID := uuid.New()
+	user := &models.User{ExternalID: &userID, Login: login}
+	registration := &models.RegistrationKey{Login: login, UserID: userID}
+
+	gomock.InOrder(
+		// The first request registers the user and remembers the key
+		mockStore.EXPECT().Get(ctx, key).Return(nil, nil),
+		mockUserService.EXPECT().Register(ctx, login, password).Return(user, nil),
+		mockStore.EXPECT().Save(ctx, key, registration).Return(true, nil),
+		// The retry is answered with the original user without registering again
+		mockStore.EXPECT().Get(ctx, key).Return(registration, nil),
+		mockUserService.EXPECT().Login(ctx, login, password).Return(user, nil),
+	)
+
+	g := NewRegistrationGuard(mockUserService, mockStore)
+
+	first, err := g.Register(ctx, key, login, password)
+	assert.NoError(t, err)
+	retried, err := g.Register(ctx, key, login, password)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ExternalID, retried.ExternalID)
+}
+
+func TestRegistrationGuard_KeyReusedForOtherCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockStore := mocks.NewMockIRegistrationKeyStore(ctrl)
+	ctx := context.Background()
+	key := "5d0c3a1e-retry"
+	registration := &models.RegistrationKey{Login: "player@example.com", UserID: uuid.New()}
+
+	mockStore.EXPECT().Get(ctx, key).Return(registration, nil).Times(2)
+	mockUserService.EXPECT().Login(ctx, "player@example.com", "wrong").Return(nil, serviceError.ErrInvalidPass)
+
+	g := NewRegistrationGuard(mockUserService, mockStore)
+
+	// A different login with the same key is rejected without registering it
+	_, err := g.Register(ctx, key, "other@example.com", "Str0ng!Passw0rd")
+	assert.ErrorIs(t, err, serviceError.ErrIdempotencyKeyReused)
+	// So is the same login with another password
+	_, err = g.Register(ctx, key, "player@example.com", "wrong")
+	assert.ErrorIs(t, err, serviceError.ErrIdempotencyKeyReused)
+}
+
+func TestRegistrationGuard_ConcurrentRetryReplayed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockStore := mocks.NewMockIRegistrationKeyStore(ctrl)
+	ctx := context.Background()
+	key, login, password := "5d0c3a1e-retry", "player@example.com", "Str0ng!Passw0rd"
+	userID := uuid.New()
+	user := &models.User{ExternalID: &userID, Login: login}
+
+	// The original request stores the key after this one checked it, so the registration conflicts
+	gomock.InOrder(
+		mockStore.EXPECT().Get(ctx, key).Return(nil, nil),
+		mockUserService.EXPECT().Register(ctx, login, password).Return(nil, serviceError.ErrUserExists),
+		mockStore.EXPECT().Get(ctx, key).Return(&models.RegistrationKey{Login: login, UserID: userID}, nil),
+		mockUserService.EXPECT().Login(ctx, login, password).Return(user, nil),
+	)
+
+	g := NewRegistrationGuard(mockUserService, mockStore)
+
+	replayed, err := g.Register(ctx, key, login, password)
+	assert.NoError(t, err)
+	assert.Equal(t, &userID, replayed.ExternalID)
+}
+
+func TestRegistrationGuard_WithoutKeyOrStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockStore := mocks.NewMockIRegistrationKeyStore(ctrl)
+	ctx := context.Background()
+	login, password := "player@example.com", "Str0ng!Passw0rd"
+	userID := uuid.New()
+	user := &models.User{ExternalID: &userID, Login: login}
+
+	// Without a key a duplicate registration still conflicts
+	mockUserService.EXPECT().Register(ctx, login, password).Return(nil, serviceError.ErrUserExists)
+	// An unreachable store does not block the registration
+	mockStore.EXPECT().Get(ctx, "key").Return(nil, errors.New("connection refused"))
+	mockUserService.EXPECT().Register(ctx, login, password).Return(user, nil)
+	mockStore.EXPECT().Save(ctx, "key", gomock.Any()).Return(false, errors.New("connection refused"))
+
+	g := NewRegistrationGuard(mockUserService, mockStore)
+
+	_, err := g.Register(ctx, "", login, password)
+	assert.ErrorIs(t, err, serviceError.ErrUserExists)
+	registered, err := g.Register(ctx, "key", login, password)
+	assert.NoError(t, err)
+	assert.Equal(t, user, registered)
+}
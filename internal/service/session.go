@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// sessionService implements the ISessionService interface. The sessions are recorded in the
+// database, while the active session of each user is tracked in the session store, which forgets
+// it once the user has been inactive for the session timeout.
+type sessionService struct {
+	userService       interfaces.IUserService       // Service resolving the users' numeric IDs
+	sessionRepository interfaces.ISessionRepository // Repository recording the sessions
+	store             interfaces.ISessionStore      // Store tracking the active session of each user
+}
+
+// Start records a new session of the user and makes it the active one. The user's previous
+// session, whether it timed out or not, ends.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - A pointer to the started session.
+//   - ErrUserNotFound if the user does not exist, or an error if the session cannot be started.
+func (s *sessionService) Start(ctx context.Context, userID *uuid.UUID) (*models.Session, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userService.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+
+	session := &models.Session{UserID: user.ID}
+	if err := s.sessionRepository.AddSession(ctx, session); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if err := tr.Commit(id); err != nil {
+		return nil, err
+	}
+	return session, s.store.Set(ctx, userID, session.ID)
+}
+
+// Current returns the active session of the user and extends it by the session timeout, so that
+// every spin keeps the session alive. Without an active session a new one is started.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - The numeric ID of the active session.
+//   - ErrUserNotFound if the user does not exist, or an error if the session cannot be tracked.
+func (s *sessionService) Current(ctx context.Context, userID *uuid.UUID) (uint, error) {
+	sessionID, err := s.store.Get(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if sessionID == 0 {
+		session, err := s.Start(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		return session.ID, nil
+	}
+	return sessionID, s.store.Set(ctx, userID, sessionID)
+}
+
+// End ends the active session of the user. Nothing happens if the user has no active session.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - An error if the session cannot be ended.
+func (s *sessionService) End(ctx context.Context, userID *uuid.UUID) error {
+	sessionID, err := s.store.Get(ctx, userID)
+	if err != nil || sessionID == 0 {
+		return err
+	}
+	if err := s.sessionRepository.EndSession(ctx, sessionID, time.Now()); err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, userID)
+}
+
+// Summaries retrieves a page of the summaries of the user's sessions, newest first.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - limit: The maximum number of sessions to return.
+//   - offset: The number of sessions to skip.
+//
+// Returns:
+//   - A slice of pointers to SessionSummary models representing the requested page.
+//   - The total number of sessions of the user.
+//   - ErrUserNotFound if the user does not exist, or an error if retrieval fails.
+func (s *sessionService) Summaries(ctx context.Context, userID *uuid.UUID, limit, offset int) ([]*models.SessionSummary, int64, error) {
+	user, err := s.userService.GetByExternalID(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if user == nil {
+		return nil, 0, serviceError.ErrUserNotFound
+	}
+	return s.sessionRepository.GetSessionSummaries(ctx, user.ID, limit, offset)
+}
+
+// RealityCheck returns the reality check of a game session once the interval has passed since the
+// start of the session or, after one was acknowledged, since the acknowledgment. The spins are only
+// summarized when a reality check is due.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - sessionID: The numeric ID of the session.
+//   - interval: The time of play between two reality checks.
+//
+// Returns:
+//   - A pointer to the RealityCheck of the session, or nil if none is due or the session does not exist.
+//   - An error if the session cannot be read.
+func (s *sessionService) RealityCheck(ctx context.Context, sessionID uint, interval time.Duration) (*models.RealityCheck, error) {
+	session, err := s.sessionRepository.GetSession(ctx, sessionID)
+	if err != nil || session == nil {
+		return nil, err
+	}
+	now := time.Now()
+	due := session.CreatedAt.Add(interval)
+	if session.RealityCheckedAt != nil {
+		due = session.RealityCheckedAt.Add(interval)
+	}
+	if now.Before(due) {
+		return nil, nil
+	}
+
+	summary, err := s.sessionRepository.GetSessionSummary(ctx, sessionID)
+	if err != nil || summary == nil {
+		return nil, err
+	}
+	return &models.RealityCheck{
+		SessionID:    sessionID,
+		StartedAt:    session.CreatedAt,
+		Duration:     now.Sub(session.CreatedAt),
+		TotalSpins:   summary.TotalSpins,
+		TotalWagered: summary.TotalWagered,
+		TotalWon:     summary.TotalWon,
+	}, nil
+}
+
+// AcknowledgeRealityCheck acknowledges the reality check of the user's active game session, so
+// that the next one is due an interval from now. Nothing happens if the user has no active session.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - An error if the acknowledgment cannot be recorded.
+func (s *sessionService) AcknowledgeRealityCheck(ctx context.Context, userID *uuid.UUID) error {
+	sessionID, err := s.store.Get(ctx, userID)
+	if err != nil || sessionID == 0 {
+		return err
+	}
+	return s.sessionRepository.AcknowledgeRealityCheck(ctx, sessionID, time.Now())
+}
+
+// NewSessionService creates and returns a new instance of sessionService.
+//
+// Parameters:
+//   - userService: UserService resolving the users' numeric IDs.
+//   - sessionRepository: SessionRepository recording the sessions.
+//   - store: SessionStore tracking the active session of each user.
+//
+// Returns:
+//   - An instance of sessionService implementing ISessionService.
+func NewSessionService(
+	userService interfaces.IUserService,
+	sessionRepository interfaces.ISessionRepository,
+	store interfaces.ISessionStore,
+) interfaces.ISessionService {
+	return &sessionService{
+		userService:       userService,
+		sessionRepository: sessionRepository,
+		store:             store,
+	}
+}
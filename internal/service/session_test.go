@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// expiringSessionStore is an in-memory ISessionStore that forgets a session once it has not been
+// extended for the timeout, measured on a clock the test moves forward.
+type expiringSessionStore struct {
+	now       time.Time
+	timeout   time.Duration
+	sessionID uint
+	expiresAt time.Time
+}
+
+func (s *expiringSessionStore) Get(context.Context, *uuid.UUID) (uint, error) {
+	if !s.now.Before(s.expiresAt) {
+		return 0, nil
+	}
+	return s.sessionID, nil
+}
+
+func (s *expiringSessionStore) Set(_ context.Context, _ *uuid.UUID, sessionID uint) error {
+	s.sessionID, s.expiresAt = sessionID, s.now.Add(s.timeout)
+	return nil
+}
+
+func (s *expiringSessionStore) Delete(context.Context, *uuid.UUID) error {
+	s.sessionID, s.expiresAt = 0, time.Time{}
+	return nil
+}
+
+func TestSessionService_CurrentWithinAndAfterTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSessionRepo := mocks.NewMockISessionRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	store := &expiringSessionStore{now: time.Now(), timeout: 30 * time.Minute}
+	s := NewSessionService(mockUserService, mockSessionRepo, store)
+
+	// Each started session is recorded with the next ID
+	nextID := uint(0)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil).Times(2)
+	mockSessionRepo.EXPECT().AddSession(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, session *models.Session) error {
+		assert.Equal(t, uint(1), session.UserID)
+		nextID++
+		session.ID = nextID
+		return nil
+	}).Times(2)
+
+	first, err := s.Current(ctx, &userID)
+	require.NoError(t, err)
+
+	// Every spin within the timeout extends the session, so it outlives the timeout in total
+	for i := 0; i < 3; i++ {
+		store.now = store.now.Add(20 * time.Minute)
+		current, err := s.Current(ctx, &userID)
+		require.NoError(t, err)
+		assert.Equal(t, first, current)
+	}
+
+	// A spin after the timeout starts a new session
+	store.now = store.now.Add(31 * time.Minute)
+	second, err := s.Current(ctx, &userID)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	current, err := s.Current(ctx, &userID)
+	require.NoError(t, err)
+	assert.Equal(t, second, current)
+}
+
+func TestSessionService_EndEndsActiveSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionRepo := mocks.NewMockISessionRepository(ctrl)
+	mockStore := mocks.NewMockISessionStore(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockStore.EXPECT().Get(ctx, &userID).Return(uint(7), nil)
+	mockSessionRepo.EXPECT().EndSession(ctx, uint(7), gomock.Any()).Return(nil)
+	mockStore.EXPECT().Delete(ctx, &userID).Return(nil)
+
+	s := NewSessionService(nil, mockSessionRepo, mockStore)
+	assert.NoError(t, s.End(ctx, &userID))
+}
+
+func TestSessionService_EndWithoutActiveSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Ending or deleting a session fails the test
+	mockSessionRepo := mocks.NewMockISessionRepository(ctrl)
+	mockStore := mocks.NewMockISessionStore(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockStore.EXPECT().Get(ctx, &userID).Return(uint(0), nil)
+
+	s := NewSessionService(nil, mockSessionRepo, mockStore)
+	assert.NoError(t, s.End(ctx, &userID))
+}
+
+func TestSessionService_RealityCheckDueAfterInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionRepo := mocks.NewMockISessionRepository(ctrl)
+	ctx := context.Background()
+	s := NewSessionService(nil, mockSessionRepo, nil)
+	startedAt := time.Now().Add(-20 * time.Minute)
+	session := &models.Session{Model: gorm.Model{ID: 7, CreatedAt: startedAt}}
+	mockSessionRepo.EXPECT().GetSession(ctx, uint(7)).Return(session, nil).Times(2)
+
+	// Within the interval no reality check is due and the spins are not summarized
+	check, err := s.RealityCheck(ctx, 7, 30*time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, check)
+
+	// Once the interval has passed, the reality check reports the whole session
+	mockSessionRepo.EXPECT().GetSessionSummary(ctx, uint(7)).Return(&models.SessionSummary{
+		SessionID: 7, StartedAt: startedAt, TotalSpins: 12, TotalWagered: 60, TotalWon: 25,
+	}, nil)
+	check, err = s.RealityCheck(ctx, 7, 15*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, check)
+	assert.Equal(t, uint(7), check.SessionID)
+	assert.Equal(t, startedAt, check.StartedAt)
+	assert.GreaterOrEqual(t, check.Duration, 20*time.Minute)
+	assert.Equal(t, int64(12), check.TotalSpins)
+	assert.Equal(t, 35.0, check.NetLoss())
+}
+
+func TestSessionService_AcknowledgedRealityCheckWaitsForNextInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionRepo := mocks.NewMockISessionRepository(ctrl)
+	mockStore := mocks.NewMockISessionStore(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	s := NewSessionService(nil, mockSessionRepo, mockStore)
+
+	var acknowledgedAt time.Time
+	mockStore.EXPECT().Get(ctx, &userID).Return(uint(7), nil)
+	mockSessionRepo.EXPECT().AcknowledgeRealityCheck(ctx, uint(7), gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, at time.Time) error {
+		acknowledgedAt = at
+		return nil
+	})
+	require.NoError(t, s.AcknowledgeRealityCheck(ctx, &userID))
+
+	// The session started long ago, but the next reality check is only due an interval after the acknowledgment
+	mockSessionRepo.EXPECT().GetSession(ctx, uint(7)).Return(&models.Session{
+		Model:            gorm.Model{ID: 7, CreatedAt: acknowledgedAt.Add(-2 * time.Hour)},
+		RealityCheckedAt: &acknowledgedAt,
+	}, nil)
+	check, err := s.RealityCheck(ctx, 7, 30*time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, check)
+}
+
+func TestSessionService_AcknowledgeRealityCheckWithoutActiveSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Recording an acknowledgment fails the test
+	mockSessionRepo := mocks.NewMockISessionRepository(ctrl)
+	mockStore := mocks.NewMockISessionStore(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockStore.EXPECT().Get(ctx, &userID).Return(uint(0), nil)
+
+	s := NewSessionService(nil, mockSessionRepo, mockStore)
+	assert.NoError(t, s.AcknowledgeRealityCheck(ctx, &userID))
+}
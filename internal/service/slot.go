@@ -6,38 +6,155 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	error2 "github.com/vadymlab/slot-game/internal/error"
 	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/public-forge/go-gorm-unit-of-work/postgres"
 	log "github.com/public-forge/go-logger"
 	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/database"
+	"github.com/vadymlab/slot-game/internal/exchange"
 	"github.com/vadymlab/slot-game/internal/interfaces"
 	"github.com/vadymlab/slot-game/internal/models"
 )
 
-// symbols defines the available slot machine symbols.
-var symbols = []string{"A", "B", "C", "D"}
+// Supported leaderboard aggregation periods.
+const (
+	PeriodDay  = "day"  // Spins from the last 24 hours
+	PeriodWeek = "week" // Spins from the last 7 days
+	PeriodAll  = "all"  // All spins
+)
 
 // slotService implements ISlotService, providing slot game logic and methods.
 type slotService struct {
-	config         *config.SlotConfig         // Slot configuration settings
-	userService    interfaces.IUserService    // Service for managing user-related operations
-	slotRepository interfaces.ISlotRepository // Repository for managing slot spin records
-	rng            *rand.Rand                 // Custom random number generator for reproducibility
-	backoff        *backoff.ExponentialBackOff
+	config           *config.SlotConfig                 // Slot configuration settings
+	userService      interfaces.IUserService            // Service for managing user-related operations
+	slotRepository   interfaces.ISlotRepository         // Repository for managing slot spin records
+	ledgerRepository interfaces.ILedgerRepository       // Repository for recording the balance changes of voided spins
+	rng              *rand.Rand                         // Custom random number generator for reproducibility; safe for the concurrent spins
+	newBackoff       func() *backoff.ExponentialBackOff // Factory of the retry policy of RetrySpin; a backoff is stateful, so every call gets its own
+	notifier         *EventNotifier                     // Publisher of the spins and big win events
+	spinLock         interfaces.ISpinLock               // Guard limiting the spins a user may have in flight; may be nil
+	demoWallet       interfaces.IDemoWallet             // Play-money balances of demo sessions
+	demoMigration    interfaces.IDemoMigration          // Hook receiving the balance of ended demo sessions when the migration is enabled; may be nil
+	winStreaks       interfaces.IWinStreakStore         // Consecutive wins of the users; may be nil
+	spinWriter       interfaces.ISpinWriter             // Background writer persisting spins in batches; nil writes each spin within its transaction
+	reporter         interfaces.ISpinReporter           // Reporter of the committed spins to the regulator; may be nil
+	sessions         interfaces.ISessionService         // Service grouping the spins into game sessions; may be nil
+	spinCounter      interfaces.IDailySpinCounter       // Counter of the spins per user and day backing the spin limit; may be nil
+	jackpots         interfaces.IJackpotStore           // Pool of the progressive jackpot shared by all instances; may be nil
+	exchangeRates    interfaces.IExchangeRateProvider   // Rates converting bets placed in other currencies; nil accepts bets in the base currency only
+	lastSpins        interfaces.ILastSpinStore          // Last spin of each user kept for replay; nil disables the replay
+	spinLogs         atomic.Uint64                      // Number of spin results considered for logging, backing the log sampling
+	limitLocation    *time.Location                     // Time zone in which the days of the spin limit are counted
+	now              func() time.Time                   // Clock deciding the day of the spin limit
+}
+
+// History retrieves a page of the spin history for a specified user, newest first.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: A UUID representing the user's external identifier.
+//   - from: Optional inclusive lower bound for the spin creation time.
+//   - to: Optional exclusive upper bound for the spin creation time.
+//   - limit: The maximum number of spins to return.
+//   - offset: The number of spins to skip.
+//
+// Returns:
+//   - A slice of pointers to Spin models representing the requested page.
+//   - The total number of spins of the user within the date range.
+//   - An error if the transaction or retrieval fails; otherwise, nil.
+func (s *slotService) History(ctx context.Context, userID *uuid.UUID, from, to *time.Time, limit, offset int) ([]*models.Spin, int64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+	user, err := s.userService.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	history, total, err := s.slotRepository.GetSpins(ctx, user.ID, from, to, limit, offset)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	return history, total, tr.Commit(id)
+}
+
+// ExportHistory walks the whole spin history of a user within the date range, newest first,
+// reading the spins one at a time so that the export does not load the history into memory.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: A UUID representing the user's external identifier.
+//   - from: Optional inclusive lower bound for the spin creation time.
+//   - to: Optional exclusive upper bound for the spin creation time.
+//   - fn: Function called with each spin; an error stops the export.
+//
+// Returns:
+//   - An error if the transaction or retrieval fails, or the error returned by fn; otherwise, nil.
+func (s *slotService) ExportHistory(ctx context.Context, userID *uuid.UUID, from, to *time.Time, fn func(*models.Spin) error) error {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return err
+	}
+	user, err := s.userService.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	if err := s.slotRepository.EachSpin(ctx, user.ID, from, to, fn); err != nil {
+		_ = tr.Rollback()
+		return err
+	}
+	return tr.Commit(id)
+}
+
+// Leaderboard retrieves the top users by total winnings for the given period.
+// The number of returned entries is limited by the configured leaderboard size.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - period: The aggregation window, one of "day", "week" or "all"; empty means "all".
+//
+// Returns:
+//   - A slice of pointers to LeaderboardEntry models ordered by total winnings.
+//   - An error if the period is invalid or the retrieval fails; otherwise, nil.
+func (s *slotService) Leaderboard(ctx context.Context, period string) ([]*models.LeaderboardEntry, error) {
+	var since *time.Time
+	now := time.Now()
+	switch period {
+	case PeriodDay:
+		t := now.Add(-24 * time.Hour)
+		since = &t
+	case PeriodWeek:
+		t := now.Add(-7 * 24 * time.Hour)
+		since = &t
+	case PeriodAll, "":
+	default:
+		return nil, error2.ErrInvalidPeriod
+	}
+	return s.slotRepository.GetLeaderboard(ctx, since, s.config.LeaderboardSize)
 }
 
-// History retrieves the spin history for a specified user.
+// Stats retrieves the play statistics of a specified user.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
 //   - userId: A UUID representing the user's external identifier.
 //
 // Returns:
-//   - A slice of pointers to Spin models representing the user's spin history.
+//   - A pointer to the SpinStats of the user.
 //   - An error if the transaction or retrieval fails; otherwise, nil.
-func (s *slotService) History(ctx context.Context, userID *uuid.UUID) ([]*models.Spin, error) {
+func (s *slotService) Stats(ctx context.Context, userID *uuid.UUID) (*models.SpinStats, error) {
 	tr, _ := postgres.GetTransactionContext(ctx)
 	id, err := tr.Begin()
 	if err != nil {
@@ -48,14 +165,59 @@ func (s *slotService) History(ctx context.Context, userID *uuid.UUID) ([]*models
 		_ = tr.Rollback()
 		return nil, err
 	}
-	history, err := s.slotRepository.GetSpins(ctx, user.ID)
+	stats, err := s.slotRepository.GetSpinStats(ctx, user.ID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return stats, tr.Commit(id)
+}
+
+// VerifySpinChain walks the hash chain of a user's spins in the order they were written and stops
+// at the first spin that does not match its stored hash or does not link to the hash of the spin
+// before it. The first spin walked anchors the chain: its own link cannot be checked, as the spins
+// before it may have been pruned by the retention job. Spins written while the chain was disabled
+// are not part of it.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - A pointer to the SpinChainVerification of the user's spins.
+//   - ErrUserNotFound if the user does not exist, or an error if the transaction or retrieval fails.
+func (s *slotService) VerifySpinChain(ctx context.Context, userID *uuid.UUID) (*models.SpinChainVerification, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userService.GetByExternalID(ctx, userID)
 	if err != nil {
 		_ = tr.Rollback()
 		return nil, err
 	}
-	return history, tr.Commit(id)
+	result := &models.SpinChainVerification{}
+	err = s.slotRepository.EachChainedSpin(ctx, user.ID, func(spin *models.Spin) error {
+		result.Checked++
+		linked := result.Checked == 1 || spin.PrevHash == result.LastHash
+		if !linked || spin.ComputeHash() != spin.Hash {
+			result.BrokenAt = &spin.ID
+			return errChainBroken
+		}
+		result.LastHash = spin.Hash
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return result, tr.Commit(id)
 }
 
+// errChainBroken stops the walk of VerifySpinChain at the first broken spin.
+var errChainBroken = errors.New("spin chain broken")
+
 // RetrySpin performs a slot spin operation for a user with a retry mechanism.
 // The function attempts to execute a spin with a specified bet amount, automatically
 // retrying on errors except when the error is due to insufficient funds.
@@ -63,19 +225,24 @@ func (s *slotService) History(ctx context.Context, userID *uuid.UUID) ([]*models
 // Parameters:
 //   - ctx: A context.Context for request-scoped values and cancelation signals.
 //   - userId: A UUID pointer representing the unique identifier of the user.
+//   - gameID: A string naming the game to play; empty plays the default game.
 //   - betAmount: A float64 representing the bet amount for the spin.
 //
 // Returns:
 //   - *models.Spin: A pointer to a Spin object containing the spin details if successful.
-//   - error: An error indicating failure reason, or nil if the spin succeeds.
+//   - error: ErrGameNotFound if the game does not exist, another error indicating the failure reason,
+//     or nil if the spin succeeds.
 //
 // Workflow:
-//  1. Defines the `operation` function, which performs the spin and retries
-//     unless the error is due to insufficient funds, in which case it immediately returns.
+//  1. Defines the `operation` function, which performs the spin and retries it while the
+//     balance update reports insufficient funds; any other error, including a bet refused
+//     by the pre-spin balance check, is returned immediately.
 //  2. The `backoff.Retry` function is called, which retries `operation` based on
-//     the backoff configuration specified in `s.backoff`.
+//     a backoff created by `s.newBackoff` for this call alone, so that concurrent spins
+//     do not share the elapsed time and the current interval of their retries.
 //  3. Logs warning messages for insufficient funds and error messages for retries
 //     that exceed the allowed backoff configuration.
+//  4. Attaches the reality check of the game session to the spin once one is due.
 //
 // Logging:
 //
@@ -84,16 +251,95 @@ func (s *slotService) History(ctx context.Context, userID *uuid.UUID) ([]*models
 //
 // Example usage:
 //
-//	spin, err := slotService.RetrySpin(ctx, &userId, betAmount)
+//	spin, err := slotService.RetrySpin(ctx, &userId, gameID, betAmount)
 //	if err != nil {
 //	    // Handle error
 //	}
 //	// Process spin result
-func (s *slotService) RetrySpin(ctx context.Context, userID *uuid.UUID, betAmount float64) (*models.Spin, error) {
+func (s *slotService) RetrySpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64) (*models.Spin, error) {
+	return s.retrySpin(ctx, userID, gameID, betAmount, nil)
+}
+
+// SpinInCurrency performs a spin for a user with a bet placed in another currency than the base
+// currency, retried like RetrySpin. The bet is checked against the bet denominations in the
+// currency it was placed in, then converted into the base currency at the current exchange rate,
+// rounded to minor units. The spin is played and settled against the base currency balance, and
+// the win is converted back into the currency at the same rate; the currency, the rate and the
+// original amounts are recorded on the spin.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - gameID: The ID of the game to play; empty plays the default game.
+//   - currency: The ISO 4217 code of the currency of the bet, in any case; empty or the base
+//     currency plays an unconverted spin.
+//   - betAmount: The amount of the bet in the currency.
+//
+// Returns:
+//   - A pointer to a spin model representing the spin result.
+//   - ErrUnsupportedCurrency if no exchange rate is known for the currency, ErrInvalidAmount if the
+//     bet is worth less than a minor unit of the base currency, or any error of RetrySpin.
+func (s *slotService) SpinInCurrency(ctx context.Context, userID *uuid.UUID, gameID, currency string, betAmount float64) (*models.Spin, error) {
+	currency = strings.ToUpper(currency)
+	if currency == "" || currency == s.config.BaseCurrency {
+		return s.RetrySpin(ctx, userID, gameID, betAmount)
+	}
+	if s.exchangeRates == nil {
+		return nil, error2.ErrUnsupportedCurrency
+	}
+	rate, err := s.exchangeRates.Rate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	baseBet := exchange.ToBase(betAmount, rate)
+	if baseBet <= 0 {
+		return nil, error2.ErrInvalidAmount
+	}
+	return s.retrySpin(ctx, userID, gameID, baseBet, &models.Conversion{Currency: currency, Rate: rate, BetAmount: betAmount})
+}
+
+// retrySpin plays a spin with the retries of RetrySpin. The bet is checked against the bet
+// denominations in the currency it was placed in.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - gameID: The ID of the game to play; empty plays the default game.
+//   - betAmount: The amount of the bet in the base currency.
+//   - conversion: The exchange of a bet placed in another currency; nil for a bet in the base currency.
+//
+// Returns:
+//   - A pointer to a spin model representing the spin result.
+//   - ErrGameNotFound if the game does not exist, or another error indicating the failure reason.
+func (s *slotService) retrySpin(
+	ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64, conversion *models.Conversion,
+) (*models.Spin, error) {
+	gameID, game, err := s.game(gameID)
+	if err != nil {
+		return nil, err
+	}
+	placedBet := betAmount
+	if conversion != nil {
+		placedBet = conversion.BetAmount
+	}
+	if err := s.checkBet(placedBet); err != nil {
+		return nil, err
+	}
+	if err := s.acquireSpinLock(ctx, userID); err != nil {
+		return nil, err
+	}
+	// Release the slot on every return path, including panics
+	defer s.releaseSpinLock(ctx, userID)
+	day := s.config.SpinLimitDay(s.now(), s.limitLocation)
+	if err := s.checkSpinLimit(ctx, userID, day); err != nil {
+		return nil, err
+	}
+	sessionID := s.currentSession(ctx, userID)
+
 	var spin *models.Spin
 	operation := func() error {
 		var err error
-		spin, err = s.spin(ctx, userID, betAmount)
+		spin, err = s.spin(ctx, userID, gameID, game, betAmount, sessionID, conversion, false)
 		if err != nil {
 			if errors.Is(err, error2.ErrInsufficientFunds) {
 				log.FromContext(ctx).Warnf("RetrySpin encountered error: %v", err)
@@ -104,29 +350,309 @@ func (s *slotService) RetrySpin(ctx context.Context, userID *uuid.UUID, betAmoun
 		return nil
 	}
 
-	// Run the operation with retries
-	err := backoff.Retry(operation, s.backoff)
+	// Run the operation with retries, stopping as soon as the request context is done
+	retries := s.newBackoff()
+	err = backoff.Retry(operation, backoff.WithContext(retries, ctx))
+	if err != nil {
+		log.FromContext(ctx).Errorf("RetrySpin failed after %v retries: %v", retries.MaxElapsedTime, err)
+		return nil, err
+	}
+
+	log.FromContext(ctx).Debugf("RetrySpin succeeded after %v retries", retries.GetElapsedTime())
+	s.afterSpin(ctx, userID, spin, day)
+	spin.RealityCheck = s.realityCheck(ctx, sessionID)
+	s.rememberSpin(ctx, userID, spin)
+	return spin, nil
+}
+
+// BulkSpin plays up to count spins with the same bet in a row, each settled in its own
+// transaction like a single spin. Spins are not retried: the batch ends early when a spin cannot
+// be played, for instance because the balance no longer covers the bet or the daily spin limit is
+// reached, and the spins played before stand. The whole batch holds a single spin lock slot. The
+// reality check of the game session due after the batch, if any, is attached to it.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - gameID: The ID of the game to play; empty plays the default game.
+//   - betAmount: The amount of the bet placed for each spin.
+//   - count: The number of spins to play, at most MaxBulkSpins.
+//
+// Returns:
+//   - A pointer to the played batch, with the error that ended it early if any.
+//   - ErrInvalidSpinCount if the count is out of range, ErrGameNotFound if the game does not
+//     exist, or the error of the first spin if none could be played.
+func (s *slotService) BulkSpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64, count int) (*models.BulkSpin, error) {
+	if count < 1 || count > s.config.MaxBulkSpins {
+		return nil, error2.ErrInvalidSpinCount
+	}
+	gameID, game, err := s.game(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBet(betAmount); err != nil {
+		return nil, err
+	}
+	if err := s.acquireSpinLock(ctx, userID); err != nil {
+		return nil, err
+	}
+	defer s.releaseSpinLock(ctx, userID)
+	day := s.config.SpinLimitDay(s.now(), s.limitLocation)
+	sessionID := s.currentSession(ctx, userID)
+
+	batch := &models.BulkSpin{Requested: count, Spins: make([]*models.Spin, 0, count)}
+	for len(batch.Spins) < count {
+		spin, err := s.bulkSpin(ctx, userID, gameID, game, betAmount, sessionID, day)
+		if err != nil {
+			if len(batch.Spins) == 0 {
+				return nil, err
+			}
+			log.FromContext(ctx).Warnf("BulkSpin stopped after %d of %d spins: %v", len(batch.Spins), count, err)
+			batch.Stopped = err
+			break
+		}
+		s.afterSpin(ctx, userID, spin, day)
+		s.rememberSpin(ctx, userID, spin)
+		batch.Spins = append(batch.Spins, spin)
+	}
+	batch.RealityCheck = s.realityCheck(ctx, sessionID)
+	return batch, nil
+}
+
+// bulkSpin plays a single spin of a batch once the daily spin limit allows it.
+func (s *slotService) bulkSpin(
+	ctx context.Context, userID *uuid.UUID, gameID string, game *config.SlotConfig, betAmount float64, sessionID *uint, day string,
+) (*models.Spin, error) {
+	if err := s.checkSpinLimit(ctx, userID, day); err != nil {
+		return nil, err
+	}
+	return s.spin(ctx, userID, gameID, game, betAmount, sessionID, nil, false)
+}
+
+// FreeSpin plays one of the user's free spins. The spin uses up a free spin instead of debiting a
+// bet and pays its win as a spin of the game's free spin bet would, within a single transaction.
+// A free spin is not retried: a user without free spins left has nothing to retry.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - gameID: The ID of the game to play; empty plays the default game.
+//
+// Returns:
+//   - A pointer to a spin model representing the spin result, recorded without a bet.
+//   - ErrGameNotFound if the game does not exist, ErrNoFreeSpins if the user has no free spins left,
+//     or another error indicating the failure reason.
+func (s *slotService) FreeSpin(ctx context.Context, userID *uuid.UUID, gameID string) (*models.Spin, error) {
+	gameID, game, err := s.game(gameID)
 	if err != nil {
-		log.FromContext(ctx).Errorf("RetrySpin failed after %v retries: %v", s.backoff.MaxElapsedTime, err)
 		return nil, err
 	}
+	if err := s.acquireSpinLock(ctx, userID); err != nil {
+		return nil, err
+	}
+	defer s.releaseSpinLock(ctx, userID)
+	day := s.config.SpinLimitDay(s.now(), s.limitLocation)
+	if err := s.checkSpinLimit(ctx, userID, day); err != nil {
+		return nil, err
+	}
+	sessionID := s.currentSession(ctx, userID)
+
+	spin, err := s.spin(ctx, userID, gameID, game, game.FreeSpinBet, sessionID, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	s.afterSpin(ctx, userID, spin, day)
+	spin.RealityCheck = s.realityCheck(ctx, sessionID)
+	s.rememberSpin(ctx, userID, spin)
+	return spin, nil
+}
+
+// game resolves the game a spin plays.
+//
+// Parameters:
+//   - gameID: The ID of the game; empty plays the default game.
+//
+// Returns:
+//   - The ID of the game, DefaultGameID for an empty ID.
+//   - The configuration of the game.
+//   - ErrGameNotFound if no game has the ID.
+func (s *slotService) game(gameID string) (string, *config.SlotConfig, error) {
+	if gameID == "" {
+		gameID = config.DefaultGameID
+	}
+	game, ok := s.config.Game(gameID)
+	if !ok {
+		return "", nil, error2.ErrGameNotFound
+	}
+	return gameID, game, nil
+}
+
+// afterSpin completes a committed spin: it stores the win streak, counts the spin towards the
+// daily spin limit, adds the share of the bet to the jackpot, publishes the spin and a big win and
+// reports the spin to the regulator.
+func (s *slotService) afterSpin(ctx context.Context, userID *uuid.UUID, spin *models.Spin, day string) {
+	s.saveStreak(ctx, userID, spin.Streak)
+	s.countSpin(ctx, userID, day)
+	s.contributeJackpot(ctx, spin.BetAmount)
+	s.notifier.NotifySpin(ctx, userID, spin)
+	s.notifier.NotifyWin(ctx, userID, spin)
+	if s.reporter != nil {
+		s.reporter.Report(ctx, userID, spin)
+	}
+}
+
+// rememberSpin keeps the committed spin as the user's last spin for replay. Store failures are
+// only logged: the spin stands either way.
+func (s *slotService) rememberSpin(ctx context.Context, userID *uuid.UUID, spin *models.Spin) {
+	if s.lastSpins == nil {
+		return
+	}
+	if err := s.lastSpins.Set(context.WithoutCancel(ctx), userID, spin); err != nil {
+		log.FromContext(ctx).Warnf("failed to keep the last spin of user %s: %v", userID, err)
+	}
+}
 
-	log.FromContext(ctx).Debugf("RetrySpin succeeded after %v retries", s.backoff.GetElapsedTime())
+// LastSpin returns the user's last committed spin while it is kept for replay.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - A pointer to the last spin.
+//   - ErrSpinNotFound if no spin is kept for the user, or an error if the store cannot be reached.
+func (s *slotService) LastSpin(ctx context.Context, userID *uuid.UUID) (*models.Spin, error) {
+	if s.lastSpins == nil {
+		return nil, error2.ErrSpinNotFound
+	}
+	spin, err := s.lastSpins.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if spin == nil {
+		return nil, error2.ErrSpinNotFound
+	}
 	return spin, nil
 }
 
+// checkBet checks the bet amount against the configured bet denominations.
+// Any bet is accepted when no denominations are configured.
+//
+// Returns:
+//   - ErrInvalidBetDenomination if the bet is not one of the allowed denominations; otherwise, nil.
+func (s *slotService) checkBet(betAmount float64) error {
+	if len(s.config.BetDenominations) == 0 || slices.Contains(s.config.BetDenominations, betAmount) {
+		return nil
+	}
+	return error2.ErrInvalidBetDenomination
+}
+
+// acquireSpinLock reserves an in-flight spin slot for the user. Lock store failures
+// never block a spin: they are logged and the spin proceeds.
+//
+// Returns:
+//   - ErrSpinInProgress if the user already has the maximum number of spins in flight; otherwise, nil.
+func (s *slotService) acquireSpinLock(ctx context.Context, userID *uuid.UUID) error {
+	if s.spinLock == nil {
+		return nil
+	}
+	acquired, err := s.spinLock.Acquire(ctx, userID)
+	if err != nil {
+		log.FromContext(ctx).Warnf("spin lock acquire failed: %v", err)
+		return nil
+	}
+	if !acquired {
+		return error2.ErrSpinInProgress
+	}
+	return nil
+}
+
+// checkSpinLimit checks the spins the user made on the day against the daily spin limit. Only
+// committed spins are counted, and the spin lock bounds how many spins can pass the check at
+// once. Counter failures never block a spin: they are logged and the spin proceeds.
+//
+// Returns:
+//   - ErrSpinLimitReached if the user has made the maximum number of spins of the day; otherwise, nil.
+func (s *slotService) checkSpinLimit(ctx context.Context, userID *uuid.UUID, day string) error {
+	if s.spinCounter == nil || !s.config.SpinLimitEnabled() {
+		return nil
+	}
+	count, err := s.spinCounter.Count(ctx, userID, day)
+	if err != nil {
+		log.FromContext(ctx).Warnf("spin limit check failed: %v", err)
+		return nil
+	}
+	if count >= s.config.MaxSpinsPerDay {
+		return error2.ErrSpinLimitReached
+	}
+	return nil
+}
+
+// countSpin counts a committed spin towards the user's daily spin limit. The update is not bound
+// to the request context, so that a cancelled request does not lose a committed spin.
+func (s *slotService) countSpin(ctx context.Context, userID *uuid.UUID, day string) {
+	if s.spinCounter == nil || !s.config.SpinLimitEnabled() {
+		return
+	}
+	if _, err := s.spinCounter.Increment(context.WithoutCancel(ctx), userID, day); err != nil {
+		log.FromContext(ctx).Warnf("spin count update failed: %v", err)
+	}
+}
+
+// releaseSpinLock frees the spin slot reserved by acquireSpinLock. The release is not bound
+// to the request context, so it also happens when the request timed out or was cancelled.
+func (s *slotService) releaseSpinLock(ctx context.Context, userID *uuid.UUID) {
+	if s.spinLock == nil {
+		return
+	}
+	if err := s.spinLock.Release(context.WithoutCancel(ctx), userID); err != nil {
+		log.FromContext(ctx).Warnf("spin lock release failed: %v", err)
+	}
+}
+
 // spin initiates a spin for the slot machine with a specified bet amount,
-// calculates the payout, and updates the user's balance.
+// calculates the payout, and settles the bet and the win in a single balance update.
+// With the pre-spin balance check, a bet exceeding the balance read at the start is rejected
+// before the reels are spun; the balance update remains the authoritative check of the funds.
+// A win raises the payout by the multiplier of the user's streak of consecutive wins;
+// the streak itself is only stored by afterSpin once the spin has been committed. The payout is
+// then rounded according to the configured payout rounding before it is capped and credited.
+// A spin hitting the progressive jackpot is paid the jackpot on top of the capped payout; the
+// jackpot is taken from the pool before the balance update and returned if the spin is not committed.
+// Free spins triggered by scatters are awarded in the same transaction as the payout.
+// With a spin writer, the spin record is handed to it once the balance change has been
+// committed instead of being written within the transaction.
+// With the hash chain enabled, the spin is linked to the user's previous spin and always written
+// within the transaction, bypassing the spin writer.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
 //   - userId: A UUID representing the user's external identifier.
+//   - gameID: The ID of the game played, recorded on the spin.
+//   - game: The configuration of the game played.
 //   - betAmount: The amount of the bet placed for the spin.
+//   - sessionID: The game session the spin is played in; nil if none is tracked.
+//   - conversion: The exchange of a bet placed in another currency, recorded on the spin with the
+//     win converted back; nil for a bet in the base currency.
+//   - free: Whether the spin is a free spin, which uses up one of the user's free spins instead of
+//     debiting the bet; the win is still paid for the bet.
 //
 // Returns:
 //   - A pointer to a spin model representing the spin result.
-//   - An error if the spin process or transaction fails; otherwise, nil.
-func (s *slotService) spin(ctx context.Context, userID *uuid.UUID, betAmount float64) (*models.Spin, error) {
+//   - ErrNoFreeSpins if a free spin is played without free spins left, ErrAccountFrozen if an admin froze the account, ErrSelfExcluded if the user is self-excluded,
+//     ErrInsufficientFunds if the balance does not cover the bet, or an error if the spin process or
+//     transaction fails. When the pre-spin balance check refuses the bet, ErrInsufficientFunds is
+//     wrapped in backoff.Permanent, so that RetrySpin does not retry a bet the balance cannot cover.
+func (s *slotService) spin(
+	ctx context.Context, userID *uuid.UUID, gameID string, game *config.SlotConfig, betAmount float64, sessionID *uint,
+	conversion *models.Conversion, free bool,
+) (*models.Spin, error) {
+	forced, err := s.forcedOutcome(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	// The user cache invalidations of the joined operations are repeated once the transaction ends
+	defer database.TrackTransaction(ctx)()
 	tr, _ := postgres.GetTransactionContext(ctx)
 	id, err := tr.Begin()
 	if err != nil {
@@ -137,79 +663,797 @@ func (s *slotService) spin(ctx context.Context, userID *uuid.UUID, betAmount flo
 		_ = tr.Rollback()
 		return nil, err
 	}
-	_, err = s.userService.Withdraw(ctx, userID, betAmount)
+	if user.Frozen {
+		_ = tr.Rollback()
+		return nil, error2.ErrAccountFrozen
+	}
+	if user.SelfExcluded(s.now()) {
+		_ = tr.Rollback()
+		return nil, error2.ErrSelfExcluded
+	}
+	if !free && s.config.PreSpinBalanceCheck && user.Balance < betAmount {
+		_ = tr.Rollback()
+		return nil, backoff.Permanent(error2.ErrInsufficientFunds)
+	}
+	// A free spin is used up within the transaction settling the win, so it is only spent on a settled spin
+	debited := betAmount
+	if free {
+		if _, err := s.userService.UseFreeSpin(ctx, userID); err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+		debited = 0
+	}
+
+	payout, reels, bonuses, wins := s.play(game, betAmount, forced)
+	if free {
+		bonuses = append(bonuses, models.BonusFree)
+	}
+	streak := 0
+	if payout > 0 {
+		streak = s.currentStreak(ctx, userID) + 1
+		payout, bonuses = s.applyStreak(streak, payout, bonuses, wins)
+	}
+	// Round the payout as the balances store it, so that the credited win matches the recorded one
+	payout = s.config.RoundPayout(payout)
+	winAmount, capped := s.capWin(payout)
+	jackpot, taken := s.hitJackpot(ctx)
+	committed := false
+	defer func() {
+		if !committed {
+			s.returnJackpot(ctx, taken)
+		}
+	}()
+	if jackpot > 0 {
+		winAmount += jackpot
+		payout += jackpot
+		bonuses = append(bonuses, models.BonusJackpot)
+	}
+	// The bet and the win are settled with a single balance update that also checks the funds
+	balance, err := s.userService.ApplySpinResult(ctx, userID, debited, winAmount)
 	if err != nil {
 		_ = tr.Rollback()
 		return nil, err
 	}
+	// The free spins are awarded within the transaction settling the payout, so both apply or neither
+	freeSpins := freeSpinsAwarded(game, bonuses)
+	if freeSpins > 0 {
+		if _, err := s.userService.AwardFreeSpins(ctx, userID, freeSpins); err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+	}
 
-	payout := s.calculatePayout(betAmount)
-	if payout > 0 {
-		_, err = s.userService.Deposit(ctx, userID, payout)
+	spin := &models.Spin{
+		UserID:           user.ID,
+		GameID:           gameID,
+		BetAmount:        debited,
+		WinAmount:        winAmount,
+		RawWinAmount:     payout,
+		WinCapped:        capped,
+		Reels:            reels,
+		SessionID:        sessionID,
+		Bonuses:          bonuses,
+		Wins:             wins,
+		Streak:           streak,
+		Jackpot:          jackpot,
+		FreeSpinsAwarded: freeSpins,
+		Balance:          balance,
+		ShouldStop:       s.autoStop(user, winAmount),
+	}
+	if conversion != nil {
+		spin.Currency = conversion.Currency
+		spin.ExchangeRate = conversion.Rate
+		spin.OriginalBetAmount = conversion.BetAmount
+		spin.OriginalWinAmount = exchange.FromBase(winAmount, conversion.Rate)
+	}
+	spin.BigWin = s.bigWin(spin)
+	if s.config.SpinHashChain {
+		// The user's row is locked by the balance update, so no other spin of the user is chained meanwhile
+		spin.CreatedAt = s.now().UTC().Truncate(time.Microsecond)
+		prevHash, err := s.slotRepository.LastSpinHash(ctx, user.ID)
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+		spin.Chain(prevHash)
+	}
+	// A chained spin is written within the transaction, so that the next spin of the user links to it
+	if s.spinWriter == nil || s.config.SpinHashChain {
+		err = s.slotRepository.AddSpin(ctx, spin)
 		if err != nil {
 			_ = tr.Rollback()
 			return nil, err
 		}
 	}
+	// Roll back instead of committing if the request timed out or was cancelled meanwhile
+	if err := ctx.Err(); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
 
-	spin := &models.Spin{
-		UserID:    user.ID,
-		BetAmount: betAmount,
-		WinAmount: payout,
+	if s.sampleSpinLog(spin) {
+		log.FromContext(ctx).Infof("spin result: %+v", spin)
+	}
+	if err := tr.Commit(id); err != nil {
+		return nil, err
+	}
+	committed = true
+	if s.spinWriter != nil && !s.config.SpinHashChain {
+		s.spinWriter.Write(context.WithoutCancel(ctx), spin)
+	}
+	return spin, nil
+}
+
+// VoidSpin reverses a disputed spin. Within one transaction the spin row is locked, the bet is
+// refunded and the win is clawed back from the user's balance, each balance change is recorded
+// as a compensating ledger entry referencing the spin, and the spin is marked voided.
+// A spin can only be voided once; repeated requests fail without touching the balance.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - spinID: The unique numeric ID of the spin.
+//   - reason: The reason for voiding the spin.
+//
+// Returns:
+//   - A pointer to the voided spin.
+//   - ErrSpinNotFound if the spin does not exist, ErrSpinAlreadyVoided if it has already been voided,
+//     ErrInsufficientFunds if the user's balance does not cover the clawback, or another error if the void fails.
+func (s *slotService) VoidSpin(ctx context.Context, spinID uint, reason string) (*models.Spin, error) {
+	defer database.TrackTransaction(ctx)()
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
 	}
-	err = s.slotRepository.AddSpin(ctx, spin)
+	spin, err := s.slotRepository.GetSpinForUpdate(ctx, spinID)
 	if err != nil {
 		_ = tr.Rollback()
 		return nil, err
 	}
+	if spin == nil {
+		_ = tr.Rollback()
+		return nil, error2.ErrSpinNotFound
+	}
+	if spin.Voided() {
+		_ = tr.Rollback()
+		return nil, error2.ErrSpinAlreadyVoided
+	}
+	user, err := s.userService.GetByID(ctx, spin.UserID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, error2.ErrUserNotFound
+	}
+
+	reference := "spin:" + strconv.FormatUint(uint64(spin.ID), 10)
+	if spin.BetAmount > 0 {
+		if _, err := s.userService.Deposit(ctx, user.ExternalID, spin.BetAmount); err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+		err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: user.ID, Type: models.LedgerTypeVoidRefund, Amount: spin.BetAmount, Reference: reference})
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+	}
+	if spin.WinAmount > 0 {
+		// The win is taken back like a bet, which checks the funds in the same update without being
+		// subject to the rules of the withdrawals requested by players
+		if _, err := s.userService.ApplySpinResult(ctx, user.ExternalID, spin.WinAmount, 0); err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+		err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: user.ID, Type: models.LedgerTypeVoidClawback, Amount: -spin.WinAmount, Reference: reference})
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if err := s.slotRepository.VoidSpin(ctx, spin.ID, reason, now); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	spin.VoidedAt = &now
+	spin.VoidReason = reason
 
-	log.FromContext(ctx).Infof("spin result: %+v", spin)
+	log.FromContext(ctx).Infof("spin %d voided: %s", spin.ID, reason)
 	return spin, tr.Commit(id)
 }
 
-// calculatePayout determines the payout based on the bet amount and spin result.
-// It applies predefined multipliers and winning probabilities for symbol matches.
+// DemoSpin performs a play-money spin that only changes the user's demo balance.
+// Nothing is written to the database, so the real balance, the spin history and
+// the leaderboard are never affected, and demo spins neither hit nor feed the jackpot.
+// A demo session is started on the first demo spin.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: A UUID representing the user's external identifier.
+//   - gameID: The ID of the game to play; empty plays the default game.
+//   - betAmount: The play-money amount of the bet.
+//
+// Returns:
+//   - A pointer to an unsaved spin model representing the spin result.
+//   - ErrDemoDisabled if demo mode is disabled, ErrGameNotFound if the game does not exist,
+//     ErrInsufficientFunds if the demo balance does not cover the bet, or another error if the demo wallet fails.
+func (s *slotService) DemoSpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64) (*models.Spin, error) {
+	if !s.config.DemoEnabled {
+		return nil, error2.ErrDemoDisabled
+	}
+	gameID, game, err := s.game(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBet(betAmount); err != nil {
+		return nil, err
+	}
+	forced, err := s.forcedOutcome(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.demoWallet.Balance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if balance == nil {
+		if _, err := s.StartDemo(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	balance, err = s.demoWallet.Withdraw(ctx, userID, betAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	payout, reels, bonuses, wins := s.play(game, betAmount, forced)
+	payout = s.config.RoundPayout(payout)
+	winAmount, capped := s.capWin(payout)
+	if winAmount > 0 {
+		if balance, err = s.demoWallet.Deposit(ctx, userID, winAmount); err != nil {
+			return nil, err
+		}
+	}
+
+	spin := &models.Spin{
+		GameID:       gameID,
+		BetAmount:    betAmount,
+		WinAmount:    winAmount,
+		RawWinAmount: payout,
+		WinCapped:    capped,
+		Reels:        reels,
+		Bonuses:      bonuses,
+		Wins:         wins,
+		Balance:      balance,
+	}
+	spin.BigWin = s.bigWin(spin)
+	if s.sampleSpinLog(spin) {
+		log.FromContext(ctx).Debugf("demo spin result: %+v", spin)
+	}
+	return spin, nil
+}
+
+// StartDemo starts a new demo session, resetting the user's demo balance to the configured amount.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - A pointer to the demo balance of the new session.
+//   - ErrDemoDisabled if demo mode is disabled, or an error if the demo wallet fails.
+func (s *slotService) StartDemo(ctx context.Context, userID *uuid.UUID) (*float64, error) {
+	if !s.config.DemoEnabled {
+		return nil, error2.ErrDemoDisabled
+	}
+	balance := s.config.DemoBalance
+	if err := s.demoWallet.Reset(ctx, userID, balance); err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// EndDemo ends the user's demo session when switching back to real play. The demo balance is
+// deleted first, so that it cannot be spent or ended twice. It never reaches the real wallet: it is
+// only passed to the migration hook when the demo balance migration is enabled, and the default
+// hook ignores it. Sessions started before demo mode was disabled can still be ended.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - A pointer to the balance the demo session ended with, or nil if the user had no demo session.
+//   - Whether the balance was passed to the migration hook.
+//   - An error if the demo wallet or the migration hook fails.
+func (s *slotService) EndDemo(ctx context.Context, userID *uuid.UUID) (*float64, bool, error) {
+	balance, err := s.demoWallet.End(ctx, userID)
+	if err != nil || balance == nil {
+		return nil, false, err
+	}
+	if !s.config.DemoMigration || s.demoMigration == nil {
+		return balance, false, nil
+	}
+	if err := s.demoMigration.Migrate(ctx, userID, *balance); err != nil {
+		return nil, false, err
+	}
+	return balance, true, nil
+}
+
+// play spins the reels of the game and evaluates them for the given bet. A forced outcome replaces
+// the drawn reels with its reels, which are evaluated as usual, and pays its win instead of the
+// reels' payout; the spin is then marked with the forced bonus.
 //
 // Parameters:
+//   - game: The configuration of the game played.
 //   - betAmount: The amount of the bet placed for the spin.
+//   - forced: The outcome forced by QA; nil draws the spin.
+//
+// Returns:
+//   - The payout amount, based on the line match, the scatters and the paytable probabilities.
+//   - The symbols shown on each reel.
+//   - The bonus features triggered by the spin.
+//   - The paying combinations with their payouts; empty for a loss.
+func (s *slotService) play(game *config.SlotConfig, betAmount float64, forced *models.ForcedOutcome) (float64, []string, []string, []models.LineWin) {
+	var reels []string
+	if forced != nil && len(forced.Reels) > 0 {
+		reels = slices.Clone(forced.Reels)
+	} else {
+		reels = s.spinReels(game)
+	}
+	multiplier, bonuses, wins := s.evaluate(game, reels)
+	for i := range wins {
+		wins[i].Payout = betAmount * wins[i].Multiplier
+	}
+	payout := betAmount * multiplier
+	if forced != nil {
+		if forced.Win != nil {
+			// The forced win does not follow from the reels, so no combination is shown paying it
+			payout, bonuses, wins = *forced.Win, nil, make([]models.LineWin, 0)
+		}
+		bonuses = append(bonuses, models.BonusForced)
+	}
+	return payout, reels, bonuses, wins
+}
+
+// currentStreak returns the number of consecutive wins of the user before this spin.
+// Streak store failures never block a spin: they are logged and the streak starts over.
+func (s *slotService) currentStreak(ctx context.Context, userID *uuid.UUID) int {
+	if s.winStreaks == nil || !s.config.StreaksEnabled() {
+		return 0
+	}
+	streak, err := s.winStreaks.Get(ctx, userID)
+	if err != nil {
+		log.FromContext(ctx).Warnf("win streak read failed: %v", err)
+		return 0
+	}
+	return streak
+}
+
+// applyStreak raises the payout and the paying combinations by the multiplier of the streak.
+//
+// Parameters:
+//   - streak: The consecutive wins of the user, including this spin.
+//   - payout: The payout computed from the reels.
+//   - bonuses: The bonus features triggered by the spin.
+//   - wins: The paying combinations, updated in place.
+//
+// Returns:
+//   - The payout including the streak multiplier.
+//   - The bonus features, including BonusStreak if the multiplier raised the payout.
+func (s *slotService) applyStreak(streak int, payout float64, bonuses []string, wins []models.LineWin) (float64, []string) {
+	multiplier := s.config.StreakMultiplier(streak)
+	if multiplier == 1 {
+		return payout, bonuses
+	}
+	for i := range wins {
+		wins[i].Payout *= multiplier
+	}
+	return payout * multiplier, append(bonuses, models.BonusStreak)
+}
+
+// saveStreak stores the streak of a committed spin; a losing spin resets it. The write is not
+// bound to the request context, so that a cancelled request does not lose a committed result.
+func (s *slotService) saveStreak(ctx context.Context, userID *uuid.UUID, streak int) {
+	if s.winStreaks == nil || !s.config.StreaksEnabled() {
+		return
+	}
+	if err := s.winStreaks.Set(context.WithoutCancel(ctx), userID, streak); err != nil {
+		log.FromContext(ctx).Warnf("win streak update failed: %v", err)
+	}
+}
+
+// currentSession returns the game session the spin is played in, extending it. A spin is still
+// played when the session cannot be tracked; it is then recorded without a session.
+func (s *slotService) currentSession(ctx context.Context, userID *uuid.UUID) *uint {
+	if s.sessions == nil {
+		return nil
+	}
+	sessionID, err := s.sessions.Current(ctx, userID)
+	if err != nil {
+		log.FromContext(ctx).Warnf("game session tracking failed: %v", err)
+		return nil
+	}
+	return &sessionID
+}
+
+// realityCheck returns the reality check due after a spin of the game session, or nil if none is
+// due, reality checks are disabled or the spin was played without a session. A spin stands when
+// the reality check cannot be read; it is then logged and reported with the next spin.
+func (s *slotService) realityCheck(ctx context.Context, sessionID *uint) *models.RealityCheck {
+	if s.sessions == nil || sessionID == nil || !s.config.RealityCheckEnabled() {
+		return nil
+	}
+	check, err := s.sessions.RealityCheck(ctx, *sessionID, time.Duration(s.config.RealityCheckInterval)*time.Minute)
+	if err != nil {
+		log.FromContext(ctx).Warnf("reality check failed: %v", err)
+		return nil
+	}
+	return check
+}
+
+// capWin limits a payout to the configured maximum win per spin.
+//
+// Parameters:
+//   - payout: The payout computed from the reels.
+//
+// Returns:
+//   - The payout to credit, at most MaxWinPerSpin when the cap is enabled.
+//   - Whether the payout was reduced.
+func (s *slotService) capWin(payout float64) (float64, bool) {
+	if s.config.MaxWinPerSpin > 0 && payout > s.config.MaxWinPerSpin {
+		return s.config.MaxWinPerSpin, true
+	}
+	return payout, false
+}
+
+// autoStop reports whether a win exceeds the user's auto-stop threshold, in which case the client
+// is told to stop spinning. Users without a threshold of their own get the configured default.
+//
+// Parameters:
+//   - user: The user who played the spin.
+//   - winAmount: The payout credited for the spin.
+//
+// Returns:
+//   - Whether the client should stop; always false when the threshold is 0.
+func (s *slotService) autoStop(user *models.User, winAmount float64) bool {
+	threshold := user.AutoStopThreshold(s.config.AutoStopWin)
+	return threshold > 0 && winAmount > threshold
+}
+
+// sampleSpinLog reports whether the result of a spin is logged. Only every SpinLogSampleRate-th
+// spin is, so that the spin results do not flood the logs under load, but big wins, capped wins
+// and jackpots are always logged. Errors are logged where they occur and are never sampled.
+func (s *slotService) sampleSpinLog(spin *models.Spin) bool {
+	sampled := s.spinLogs.Add(1)%uint64(max(s.config.SpinLogSampleRate, 1)) == 0
+	return sampled || spin.BigWin || spin.WinCapped || spin.Jackpot > 0
+}
+
+// hitJackpot draws whether the spin hits the progressive jackpot and, if it does, takes the
+// contributions from the pool. Jackpot store failures never block a spin: they are logged and the
+// spin is played without the jackpot.
+//
+// Returns:
+//   - The jackpot to pay, the seed plus the contributions taken; 0 if the spin did not hit the jackpot.
+//   - The contributions taken from the pool, which returnJackpot gives back if the spin is not committed.
+func (s *slotService) hitJackpot(ctx context.Context) (float64, float64) {
+	if s.jackpots == nil || !s.config.JackpotEnabled() || s.rng.Float64() >= s.config.JackpotProbability {
+		return 0, 0
+	}
+	pool, err := s.jackpots.Hit(ctx)
+	if err != nil {
+		log.FromContext(ctx).Warnf("jackpot hit failed: %v", err)
+		return 0, 0
+	}
+	return s.config.RoundPayout(s.config.JackpotSeed + pool), pool
+}
+
+// returnJackpot gives the contributions taken by a spin that was not committed back to the pool.
+// The update is not bound to the request context, so that a cancelled request does not lose them.
+func (s *slotService) returnJackpot(ctx context.Context, taken float64) {
+	if taken <= 0 {
+		return
+	}
+	if _, err := s.jackpots.Contribute(context.WithoutCancel(ctx), taken); err != nil {
+		log.FromContext(ctx).Errorf("jackpot contributions of %v could not be returned: %v", taken, err)
+	}
+}
+
+// contributeJackpot adds the configured share of a committed spin's bet to the jackpot. The update
+// is not bound to the request context, so that a cancelled request does not lose the contribution.
+func (s *slotService) contributeJackpot(ctx context.Context, betAmount float64) {
+	if s.jackpots == nil || !s.config.JackpotEnabled() || s.config.JackpotContribution == 0 {
+		return
+	}
+	if _, err := s.jackpots.Contribute(context.WithoutCancel(ctx), betAmount*s.config.JackpotContribution); err != nil {
+		log.FromContext(ctx).Warnf("jackpot contribution failed: %v", err)
+	}
+}
+
+// bigWin reports whether the win to bet ratio of a spin exceeds the configured big win multiplier,
+// in which case the client may celebrate the win. The ratio is taken from the credited win, after
+// the streak multiplier and the win cap.
+//
+// Parameters:
+//   - spin: The spin result.
+//
+// Returns:
+//   - Whether the spin is a big win; always false when the multiplier is 0.
+func (s *slotService) bigWin(spin *models.Spin) bool {
+	return s.config.BigWinMultiplier > 0 && spin.WinMultiplier() > s.config.BigWinMultiplier
+}
+
+// spinReels generates the symbols shown on the reels. The number of matches is drawn from
+// the paytable probabilities, checking the highest number of matches first, and the reels
+// are then filled so that exactly that many consecutive reels, from the first one, show the
+// same symbol. When no win is drawn, the second reel differs from the first one.
+//
+// Wilds and scatters are then placed without changing the drawn line result: a wild may
+// replace any reel of a winning run except the first one, and a scatter may only land on
+// reels outside the run. Scatter wins therefore come on top of the configured line odds.
+//
+// Games with reel strips stop each strip instead, see stopReels.
+//
+// Parameters:
+//   - game: The configuration of the game played.
+//
+// Returns:
+//   - The symbols shown on each reel.
+func (s *slotService) spinReels(game *config.SlotConfig) []string {
+	if len(game.ReelStrips) > 0 {
+		return s.stopReels(game.ReelStrips)
+	}
+
+	matches := 1
+	for _, entry := range game.Paytable() {
+		if s.rng.Float64() <= entry.Probability {
+			matches = entry.Matches
+			break
+		}
+	}
+
+	symbols := game.ReelSymbols()
+	reels := make([]string, game.Reels())
+	symbol := symbols[s.rng.Intn(len(symbols))]
+	for i := range reels {
+		switch {
+		case i < matches:
+			// The winning symbol occupies the first reels.
+			reels[i] = symbol
+		case i == matches:
+			// The run of matching symbols ends with a different symbol.
+			reels[i] = s.otherSymbol(symbols, symbol)
+		default:
+			reels[i] = symbols[s.rng.Intn(len(symbols))]
+		}
+	}
+
+	for i := range reels {
+		switch {
+		case game.WildSymbol != "" && i > 0 && i < matches && matches > 1:
+			if s.rng.Float64() < game.WildProbability {
+				reels[i] = game.WildSymbol
+			}
+		case game.ScatterSymbol != "" && i >= matches:
+			if s.rng.Float64() < game.ScatterProbability {
+				reels[i] = game.ScatterSymbol
+			}
+		}
+	}
+	return reels
+}
+
+// stopReels stops each reel strip at a random position, drawn uniformly and independently for
+// every reel, and returns the symbol shown at each stop. The odds of the wins, wilds and scatters
+// follow from the composition of the strips, so the paytable, wild and scatter probabilities do not
+// apply.
+//
+// Parameters:
+//   - strips: The ordered symbols of each reel.
 //
 // Returns:
-//   - The calculated payout amount, based on the match conditions and probabilities.
-func (s *slotService) calculatePayout(betAmount float64) float64 {
-	// Generate random symbols for the spin result.
-	spinResult := []string{
-		symbols[s.rng.Intn(len(symbols))],
-		symbols[s.rng.Intn(len(symbols))],
-		symbols[s.rng.Intn(len(symbols))],
+//   - The symbols shown on each reel.
+func (s *slotService) stopReels(strips [][]string) []string {
+	reels := make([]string, len(strips))
+	for i, strip := range strips {
+		reels[i] = strip[s.rng.Intn(len(strip))]
 	}
+	return reels
+}
+
+// otherSymbol returns a random symbol of the symbols different from the given one.
+func (s *slotService) otherSymbol(symbols []string, symbol string) string {
+	for {
+		if other := symbols[s.rng.Intn(len(symbols))]; other != symbol {
+			return other
+		}
+	}
+}
+
+// lockedSource serializes the access to a random source, which is not safe for concurrent use,
+// so that concurrent spins can share the random number generator of the service.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (l *lockedSource) Int63() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src.Int63()
+}
+
+// Uint64 returns a pseudo-random 64-bit integer.
+func (l *lockedSource) Uint64() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src.Uint64()
+}
+
+// Seed reseeds the source.
+func (l *lockedSource) Seed(seed int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.src.Seed(seed)
+}
+
+// evaluate returns the multiplier won by the given reels, the bonus features they trigger and
+// the paying combinations that make up the multiplier.
+//
+// The line match is the number of consecutive reels, from the first one, showing the same symbol,
+// where wilds substitute for any regular symbol and a scatter ends the run; the paytable entry with
+// the most matches not exceeding that count is applied. Games paying both ways also count the match
+// from the last reel and add its payout. A match across all reels is the same run in both
+// directions, so it pays once unless the game pays full lines twice. Scatters pay regardless of
+// their position: when at least the configured number of them is shown, the scatter multiplier is added.
+//
+// Parameters:
+//   - game: The configuration of the game played.
+//   - reels: The symbols shown on each reel.
+//
+// Returns:
+//   - The multiplier to apply to the bet amount, or 0 for a loss.
+//   - The triggered bonus features, one of the Bonus constants each; nil if none.
+//   - The paying combinations, the line wins first, with their multipliers but no payouts; empty for a loss.
+func (s *slotService) evaluate(game *config.SlotConfig, reels []string) (float64, []string, []models.LineWin) {
+	var multiplier float64
+	var bonuses []string
+	wins := make([]models.LineWin, 0)
 
-	// Check for a three-symbol match based on ThreeMatchProbability.
-	// If probability conditions are met, create a matching three-symbol result
-	// and return the payout calculated with MultiplierThree.
-	if s.rng.Float64() <= s.config.ThreeMatchProbability {
-		spinResult[1] = spinResult[0]
-		spinResult[2] = spinResult[0]
-		return betAmount * s.config.MultiplierThree
+	win, wilds, ok := s.lineWin(game, reels)
+	if ok {
+		wins = append(wins, win)
+	}
+	if game.PayBothWays && (win.Count < len(reels) || game.FullLinePaysTwice) {
+		reversed := slices.Clone(reels)
+		slices.Reverse(reversed)
+		win, reversedWilds, ok := s.lineWin(game, reversed)
+		if ok {
+			win.Reversed = true
+			wins = append(wins, win)
+			wilds += reversedWilds
+		}
+	}
+	for _, win := range wins {
+		multiplier += win.Multiplier
+	}
+	if wilds > 0 {
+		bonuses = append(bonuses, models.BonusWild)
 	}
 
-	// Check for a two-symbol match based on TwoMatchProbability.
-	// If probability conditions are met, create a matching two-symbol result
-	// and return the payout calculated with MultiplierTwo.
-	if s.rng.Float64() <= s.config.TwoMatchProbability {
-		spinResult[1] = spinResult[0]
-		return betAmount * s.config.MultiplierTwo
+	if game.ScatterSymbol != "" {
+		scatters := 0
+		for _, symbol := range reels {
+			if symbol == game.ScatterSymbol {
+				scatters++
+			}
+		}
+		if scatters >= game.ScatterMinCount {
+			multiplier += game.ScatterMultiplier
+			bonuses = append(bonuses, models.BonusScatter)
+			wins = append(wins, models.LineWin{
+				Line: models.ScatterLine, Symbol: game.ScatterSymbol, Count: scatters, Multiplier: game.ScatterMultiplier,
+			})
+		}
 	}
+	return multiplier, bonuses, wins
+}
 
-	// No matching symbols result in a loss with zero payout.
+// freeSpinsAwarded returns the free spins awarded by a spin that triggered the given bonus features:
+// the configured free spins of the game if the scatter payout was triggered, along with any line win.
+func freeSpinsAwarded(game *config.SlotConfig, bonuses []string) int {
+	if game.ScatterFreeSpins > 0 && slices.Contains(bonuses, models.BonusScatter) {
+		return game.ScatterFreeSpins
+	}
 	return 0
 }
 
+// lineWin applies the paytable to the line match counted from the first of the given reels. A
+// two-match win pays the two-match multiplier of its symbol, falling back to the paytable entry.
+//
+// Parameters:
+//   - game: The configuration of the game played.
+//   - reels: The symbols shown on each reel, in the order the match is counted.
+//
+// Returns:
+//   - The line win, without a payout; its Count is the number of matching reels even if they do not pay.
+//   - The number of wilds completing the win; 0 if it does not pay.
+//   - Whether the match pays.
+func (s *slotService) lineWin(game *config.SlotConfig, reels []string) (models.LineWin, int, bool) {
+	symbol, matches, wilds := s.lineMatches(game, reels)
+	win := models.LineWin{Line: 0, Symbol: symbol, Count: matches}
+	for _, entry := range game.Paytable() {
+		if entry.Matches <= matches {
+			win.Multiplier = game.LineMultiplier(entry, symbol)
+			return win, wilds, true
+		}
+	}
+	return win, 0, false
+}
+
+// lineMatches counts the consecutive reels, from the first one, showing the line symbol or a wild.
+// The line symbol is the first regular symbol of the run.
+//
+// Parameters:
+//   - game: The configuration of the game played.
+//   - reels: The symbols shown on each reel.
+//
+// Returns:
+//   - The line symbol, or the wild symbol if the run consists of wilds only.
+//   - The number of matching reels.
+//   - The number of wilds among them.
+func (s *slotService) lineMatches(game *config.SlotConfig, reels []string) (string, int, int) {
+	var line string
+	matches, wilds := 0, 0
+run:
+	for _, symbol := range reels {
+		switch {
+		case game.ScatterSymbol != "" && symbol == game.ScatterSymbol:
+			break run
+		case game.WildSymbol != "" && symbol == game.WildSymbol:
+			wilds++
+		case line == "":
+			line = symbol
+		case symbol != line:
+			break run
+		}
+		matches++
+	}
+	if line == "" && wilds > 0 {
+		line = game.WildSymbol
+	}
+	return line, matches, wilds
+}
+
 // NewSlotService creates and returns a new instance of slotService.
 //
 // Parameters:
 //   - config: SlotConfig containing slot game settings.
 //   - userService: UserService for managing user-related operations.
 //   - slotRepository: SlotRepository for handling spin records.
+//   - ledgerRepository: LedgerRepository recording the balance changes of voided spins.
+//   - notifier: EventNotifier publishing the spins and big wins; may be nil.
+//   - spinLock: SpinLock limiting the spins a user may have in flight; may be nil.
+//   - demoWallet: DemoWallet holding the play-money balances of demo sessions.
+//   - winStreaks: WinStreakStore tracking the consecutive wins of the users; may be nil.
+//   - spinWriter: SpinWriter persisting spins in batches; nil writes each spin within its transaction.
+//   - reporter: SpinReporter reporting the committed spins to the regulator; may be nil.
+//   - sessions: SessionService grouping the spins into game sessions; may be nil.
+//   - spinCounter: DailySpinCounter counting the spins per user and day for the spin limit; may be nil.
+//   - jackpots: JackpotStore holding the pool of the progressive jackpot; may be nil.
+//   - exchangeRates: ExchangeRateProvider converting bets placed in other currencies; nil accepts bets in the base currency only.
+//   - demoMigration: DemoMigration hook receiving the balance of ended demo sessions when the migration is enabled; may be nil.
+//   - lastSpins: LastSpinStore keeping the last spin of each user for replay; nil disables the replay.
 //
 // Returns:
 //   - An instance of slotService implementing ISlotService.
@@ -217,16 +1461,56 @@ func NewSlotService(
 	config *config.SlotConfig,
 	userService interfaces.IUserService,
 	slotRepository interfaces.ISlotRepository,
+	ledgerRepository interfaces.ILedgerRepository,
+	notifier *EventNotifier,
+	spinLock interfaces.ISpinLock,
+	demoWallet interfaces.IDemoWallet,
+	winStreaks interfaces.IWinStreakStore,
+	spinWriter interfaces.ISpinWriter,
+	reporter interfaces.ISpinReporter,
+	sessions interfaces.ISessionService,
+	spinCounter interfaces.IDailySpinCounter,
+	jackpots interfaces.IJackpotStore,
+	exchangeRates interfaces.IExchangeRateProvider,
+	demoMigration interfaces.IDemoMigration,
+	lastSpins interfaces.ILastSpinStore,
 ) interfaces.ISlotService {
+	// The time zone has been validated with the configuration; an empty one counts in UTC
+	limitLocation := time.UTC
+	if config != nil {
+		if location, err := time.LoadLocation(config.SpinLimitTimezone); err == nil {
+			limitLocation = location
+		}
+	}
+	if config != nil && config.ForcedOutcomes {
+		log.FromDefaultContext().Warn("spin outcomes can be forced by requests; forced outcomes must never be enabled in production")
+	}
 	return &slotService{
-		config:         config,
-		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
-		userService:    userService,
-		slotRepository: slotRepository,
-		backoff: backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(500*time.Millisecond),
-			backoff.WithMaxElapsedTime(2*time.Second),
-			backoff.WithMultiplier(1.5),
-		),
+		spinCounter:      spinCounter,
+		jackpots:         jackpots,
+		exchangeRates:    exchangeRates,
+		lastSpins:        lastSpins,
+		limitLocation:    limitLocation,
+		now:              time.Now,
+		sessions:         sessions,
+		reporter:         reporter,
+		spinWriter:       spinWriter,
+		winStreaks:       winStreaks,
+		demoWallet:       demoWallet,
+		demoMigration:    demoMigration,
+		notifier:         notifier,
+		spinLock:         spinLock,
+		config:           config,
+		rng:              rand.New(&lockedSource{src: rand.NewSource(time.Now().UnixNano()).(rand.Source64)}),
+		userService:      userService,
+		slotRepository:   slotRepository,
+		ledgerRepository: ledgerRepository,
+		newBackoff: func() *backoff.ExponentialBackOff {
+			return backoff.NewExponentialBackOff(
+				backoff.WithInitialInterval(500*time.Millisecond),
+				backoff.WithMaxElapsedTime(2*time.Second),
+				backoff.WithMultiplier(1.5),
+			)
+		},
 	}
 }
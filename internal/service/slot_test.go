@@ -3,17 +3,30 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/public-forge/go-gorm-unit-of-work/postgres"
 	log "github.com/public-forge/go-logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/constants"
 	error2 "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/exchange"
+	"github.com/vadymlab/slot-game/internal/interfaces"
 	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
 	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/webhook"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestRetrySpin_Success(t *testing.T) {
@@ -34,25 +47,56 @@ func TestRetrySpin_Success(t *testing.T) {
 
 	slotConfig := &config.SlotConfig{ThreeMatchProbability: 1, TwoMatchProbability: 1, MultiplierThree: 10, MultiplierTwo: 2}
 
-	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo)
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	userID := uuid.New()
 	betAmount := 10.0
 
-	mockUserService.EXPECT().GetByExternalId(ctx, &userID).Return(&models.User{
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
 		Model: gorm.Model{
 			ID: 1,
 		}, Balance: 100,
 	}, nil)
-	mockUserService.EXPECT().Withdraw(gomock.Any(), &userID, gomock.Any()).Return(nil, nil)
-	mockUserService.EXPECT().Deposit(gomock.Any(), &userID, gomock.Any()).Return(nil, nil)
+	mockUserService.EXPECT().ApplySpinResult(gomock.Any(), &userID, gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSlotRepo.EXPECT().AddSpin(gomock.Any(), gomock.Any()).Return(nil)
 
-	spin, err := s.RetrySpin(ctx, &userID, betAmount)
+	spin, err := s.RetrySpin(ctx, &userID, "", betAmount)
 	assert.NoError(t, err)
 	assert.NotNil(t, spin)
 }
 
+func TestRetrySpin_WritesSpinAfterCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The spin is not written within the transaction, so AddSpin fails the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSpinWriter := mocks.NewMockISpinWriter(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	balance := 90.0
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(&balance, nil)
+	gomock.InOrder(
+		mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil),
+		mockSpinWriter.EXPECT().Write(gomock.Any(), gomock.Any()).Do(func(_ context.Context, spin *models.Spin) {
+			assert.Equal(t, uint(1), spin.UserID)
+			assert.Equal(t, 10.0, spin.BetAmount)
+		}),
+	)
+
+	s := NewSlotService(&config.SlotConfig{}, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, mockSpinWriter, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &balance, spin.Balance)
+}
+
 func TestRetrySpin_VariousConfigs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -100,22 +144,17 @@ func TestRetrySpin_VariousConfigs(t *testing.T) {
 			}
 
 			// Initialize slot service
-			s := NewSlotService(slotConfig, mockUserService, mockSlotRepo)
+			s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			// Expectations for user service and slot repository
-			mockUserService.EXPECT().GetByExternalId(ctx, &userID).Return(&models.User{
+			mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
 				Model: gorm.Model{ID: 1}, Balance: 100,
 			}, nil).Times(1)
-			mockUserService.EXPECT().Withdraw(ctx, &userID, betAmount).Return(nil, nil).Times(1)
-
-			// If expected win amount is greater than zero, expect a deposit
-			if tc.expectedWin > 0 {
-				mockUserService.EXPECT().Deposit(ctx, &userID, tc.expectedWin).Return(nil, nil).Times(1)
-			}
+			mockUserService.EXPECT().ApplySpinResult(ctx, &userID, betAmount, tc.expectedWin).Return(nil, nil).Times(1)
 			mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil).Times(1)
 
 			// Execute RetrySpin
-			spin, err := s.RetrySpin(ctx, &userID, betAmount)
+			spin, err := s.RetrySpin(ctx, &userID, "", betAmount)
 
 			// Assertions
 			assert.NoError(t, err)
@@ -125,7 +164,7 @@ func TestRetrySpin_VariousConfigs(t *testing.T) {
 	}
 }
 
-func TestRetrySpin_TemporaryError_RetrySuccess(t *testing.T) {
+func TestRetrySpin_WinCapped(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -133,152 +172,2478 @@ func TestRetrySpin_TemporaryError_RetrySuccess(t *testing.T) {
 	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
 	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
 
-	// Set up transaction expectations
-	mockTransactionContext.EXPECT().Begin().AnyTimes().Return(uuid.New(), nil)
-	mockTransactionContext.EXPECT().Commit(gomock.Any()).AnyTimes().Return(nil)
-	mockTransactionContext.EXPECT().Rollback().AnyTimes().Return(nil)
-	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+	userID := uuid.New()
+	betAmount := 10.0
 
-	// Slot service configuration with 100% probabilities
-	slotConfig := &config.SlotConfig{
-		ThreeMatchProbability: 1,
-		TwoMatchProbability:   1,
-		MultiplierThree:       10,
-		MultiplierTwo:         2,
+	testCases := []struct {
+		name          string
+		maxWinPerSpin float64
+		expectedWin   float64
+		capped        bool
+	}{
+		{"AboveCap", 50, 50, true},
+		{"BelowCap", 150, 100, false},
+		{"CapDisabled", 0, 100, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(1)
+			mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+			// A three-match always lands and pays 10 x 10 = 100 before the cap
+			slotConfig := &config.SlotConfig{ThreeMatchProbability: 1, MultiplierThree: 10, MultiplierTwo: 2, MaxWinPerSpin: tc.maxWinPerSpin}
+			s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+			mockUserService.EXPECT().ApplySpinResult(ctx, &userID, betAmount, tc.expectedWin).Return(nil, nil)
+			mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+			spin, err := s.RetrySpin(ctx, &userID, "", betAmount)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedWin, spin.WinAmount)
+			assert.Equal(t, 100.0, spin.RawWinAmount)
+			assert.Equal(t, tc.capped, spin.WinCapped)
+		})
 	}
-	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo)
+}
+
+func TestRetrySpin_AutoStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
 
 	userID := uuid.New()
 	betAmount := 10.0
+	threshold := func(v float64) *float64 { return &v }
 
-	// Expectations for the user and repository services
-	mockUserService.EXPECT().GetByExternalId(ctx, &userID).Return(&models.User{
-		Model: gorm.Model{ID: 1}, Balance: 100,
-	}, nil).Times(3) // Expecting this call three times due to retries
-	mockUserService.EXPECT().Withdraw(ctx, &userID, betAmount).Return(nil, error2.ErrInsufficientFunds).Times(2)
-	mockUserService.EXPECT().Withdraw(ctx, &userID, betAmount).Return(nil, nil).Times(1)
-	mockUserService.EXPECT().Deposit(ctx, &userID, gomock.Any()).Return(nil, nil).Times(1)
-	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil).Times(1)
+	testCases := []struct {
+		name        string
+		defaultStop float64
+		userStop    *float64
+		shouldStop  bool
+	}{
+		{"AboveUserThreshold", 0, threshold(50), true},
+		{"BelowUserThreshold", 0, threshold(150), false},
+		{"EqualToUserThreshold", 0, threshold(100), false},
+		{"AboveDefaultThreshold", 50, nil, true},
+		{"BelowDefaultThreshold", 150, nil, false},
+		{"UserDisabledDefault", 50, threshold(0), false},
+		{"Disabled", 0, nil, false},
+	}
 
-	// Execute RetrySpin
-	spin, err := s.RetrySpin(ctx, &userID, betAmount)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(1)
+			mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
 
-	// Assertions to verify retry behavior and results
-	assert.NoError(t, err)
-	assert.NotNil(t, spin)
-	assert.Equal(t, betAmount*slotConfig.MultiplierThree, spin.WinAmount)
+			// A three-match always lands and pays 10 x 10 = 100
+			slotConfig := &config.SlotConfig{ThreeMatchProbability: 1, MultiplierThree: 10, MultiplierTwo: 2, AutoStopWin: tc.defaultStop}
+			s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			user := &models.User{Model: gorm.Model{ID: 1}, Balance: 100, AutoStopWin: tc.userStop}
+			mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+			mockUserService.EXPECT().ApplySpinResult(ctx, &userID, betAmount, 100.0).Return(nil, nil)
+			mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+			spin, err := s.RetrySpin(ctx, &userID, "", betAmount)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 100.0, spin.WinAmount)
+			assert.Equal(t, tc.shouldStop, spin.ShouldStop)
+		})
+	}
 }
 
-func TestHistory_GetUserError(t *testing.T) {
+func TestRetrySpin_BigWin(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	// Arrange
-	mockTxContext := postgres.NewMockITransactionContext(ctrl)
 	mockUserService := mocks.NewMockIUserService(ctrl)
-	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
 
 	userID := uuid.New()
-	expectedErr := errors.New("user not found")
+	betAmount := 10.0
 
-	// Expectations
-	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
-	mockUserService.EXPECT().GetByExternalId(ctx, &userID).Return(nil, expectedErr)
-	mockTxContext.EXPECT().Rollback().Return(nil)
+	testCases := []struct {
+		name       string
+		multiplier float64
+		bigWin     bool
+	}{
+		{"WinAboveThreshold", 9.5, true},
+		{"WinAtThreshold", 10, false},
+		{"WinBelowThreshold", 10.5, false},
+		{"Disabled", 0, false},
+	}
 
-	// Instantiate the service
-	service := NewSlotService(nil, mockUserService, nil)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(1)
+			mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
 
-	// Act
-	history, err := service.History(ctx, &userID)
+			// A three-match always lands and pays 10 x 10 = 100, a win multiplier of 10
+			slotConfig := &config.SlotConfig{ThreeMatchProbability: 1, MultiplierThree: 10, MultiplierTwo: 2, BigWinMultiplier: tc.multiplier}
+			s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
-	// Assert
-	assert.ErrorIs(t, err, expectedErr)
-	assert.Nil(t, history)
+			user := &models.User{Model: gorm.Model{ID: 1}, Balance: 100}
+			mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+			mockUserService.EXPECT().ApplySpinResult(ctx, &userID, betAmount, 100.0).Return(nil, nil)
+			mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+			spin, err := s.RetrySpin(ctx, &userID, "", betAmount)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 10.0, spin.WinMultiplier())
+			assert.Equal(t, tc.bigWin, spin.BigWin)
+		})
+	}
 }
 
-func TestHistory_GetSpinsError(t *testing.T) {
+func TestRetrySpin_RoundsPayout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	// Arrange
-	mockTxContext := postgres.NewMockITransactionContext(ctrl)
 	mockUserService := mocks.NewMockIUserService(ctrl)
 	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
-	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
 
 	userID := uuid.New()
-	mockUser := &models.User{Model: gorm.Model{ID: 1}}
-	expectedErr := errors.New("failed to fetch spins")
+	betAmount := 3.0
 
-	// Expectations
-	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
-	mockUserService.EXPECT().GetByExternalId(ctx, &userID).Return(mockUser, nil)
-	mockSlotRepo.EXPECT().GetSpins(ctx, mockUser.ID).Return(nil, expectedErr)
-	mockTxContext.EXPECT().Rollback().Return(nil)
+	testCases := []struct {
+		name     string
+		rounding string
+		decimals int
+		expected float64
+	}{
+		{"Round", config.PayoutRoundingRound, 2, 6.67},
+		{"Floor", config.PayoutRoundingFloor, 2, 6.66},
+		{"Ceil", config.PayoutRoundingCeil, 2, 6.67},
+		{"CeilToWholeUnits", config.PayoutRoundingCeil, 0, 7},
+		{"RoundToWholeUnits", config.PayoutRoundingRound, 0, 7},
+		{"None", config.PayoutRoundingNone, 2, 3 * 2.2222},
+	}
 
-	// Instantiate the service
-	service := NewSlotService(nil, mockUserService, mockSlotRepo)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(1)
+			mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
 
-	// Act
-	history, err := service.History(ctx, &userID)
+			// A three-match always lands and pays 3 x 2.2222 = 6.6666
+			slotConfig := &config.SlotConfig{
+				ThreeMatchProbability: 1, MultiplierThree: 2.2222, MultiplierTwo: 2,
+				PayoutRounding: tc.rounding, PayoutDecimals: tc.decimals,
+			}
+			s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
-	// Assert
-	assert.ErrorIs(t, err, expectedErr)
-	assert.Nil(t, history)
+			user := &models.User{Model: gorm.Model{ID: 1}, Balance: 100}
+			mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+			mockUserService.EXPECT().ApplySpinResult(ctx, &userID, betAmount, tc.expected).Return(nil, nil)
+			mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+			spin, err := s.RetrySpin(ctx, &userID, "", betAmount)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, spin.WinAmount)
+			assert.Equal(t, tc.expected, spin.RawWinAmount)
+		})
+	}
 }
-func TestHistory_Success(t *testing.T) {
+
+func TestRetrySpin_ReportsSpin(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	// Arrange
-	mockTxContext := postgres.NewMockITransactionContext(ctrl)
 	mockUserService := mocks.NewMockIUserService(ctrl)
 	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
-	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	mockReporter := mocks.NewMockISpinReporter(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
 
-	// Test Data
 	userID := uuid.New()
-	mockUser := &models.User{Model: gorm.Model{ID: 1}}
-	mockHistory := []*models.Spin{{UserID: mockUser.ID}}
+	betAmount := 10.0
+	slotConfig := &config.SlotConfig{ThreeMatchProbability: 1, MultiplierThree: 10, MultiplierTwo: 2}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, mockReporter, nil, nil, nil, nil, nil, nil)
 
-	// Expectations
-	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
-	mockUserService.EXPECT().GetByExternalId(ctx, &userID).Return(mockUser, nil)
-	mockSlotRepo.EXPECT().GetSpins(ctx, mockUser.ID).Return(mockHistory, nil)
-	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	user := &models.User{Model: gorm.Model{ID: 1}, Balance: 100}
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, betAmount, 100.0).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+	mockReporter.EXPECT().Report(ctx, &userID, gomock.Any()).Do(func(_ context.Context, _ *uuid.UUID, spin *models.Spin) {
+		assert.Equal(t, betAmount, spin.BetAmount)
+		assert.Equal(t, 100.0, spin.WinAmount)
+	})
 
-	// Instantiate the service
-	service := NewSlotService(nil, mockUserService, mockSlotRepo)
+	_, err := s.RetrySpin(ctx, &userID, "", betAmount)
 
-	// Act
-	history, err := service.History(ctx, &userID)
+	assert.NoError(t, err)
+}
+
+func TestRetrySpin_PublishesSpinCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockBus := mocks.NewMockIEventBus(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+	ctx = context.WithValue(ctx, constants.CtxFieldTraceID, "trace-1")
+
+	userID := uuid.New()
+	sessionID := uint(3)
+	slotConfig := &config.SlotConfig{Symbols: []string{"A", "B"}, ThreeMatchProbability: 1, MultiplierThree: 10, MultiplierTwo: 2}
+	// The big win webhook is below its threshold, so only the event bus receives the spin
+	notifier := NewEventNotifier(&webhook.Config{WinThreshold: 1000}, mocks.NewMockIEventPublisher(ctrl), mockBus)
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, notifier, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	var published atomic.Int32
+	events := make(chan *models.DomainEvent, 2)
+	gomock.InOrder(
+		mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil),
+		mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil),
+		mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, 100.0).Return(nil, nil),
+		mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Do(func(_ context.Context, spin *models.Spin) {
+			spin.ID = 7
+			spin.SessionID = &sessionID
+		}).Return(nil),
+		mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+	mockBus.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *models.DomainEvent) error {
+		published.Add(1)
+		events <- event
+		return nil
+	}).AnyTimes()
+
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, models.DomainEventSpinCompleted, event.Type)
+		assert.Equal(t, "trace-1", event.TraceID)
+		assert.Equal(t, userID.String(), event.UserID)
+		assert.Equal(t, 10.0, event.Amount)
+		assert.NotEmpty(t, event.ID)
+		require.NotNil(t, event.Spin)
+		assert.Equal(t, models.SpinCompletedEvent{
+			SpinID:    7,
+			GameID:    config.DefaultGameID,
+			SessionID: &sessionID,
+			BetAmount: 10,
+			WinAmount: 100,
+			Reels:     spin.Reels,
+		}, *event.Spin)
+	case <-time.After(time.Second):
+		t.Fatal("spin was not published")
+	}
+	assert.Never(t, func() bool { return published.Load() > 1 }, 100*time.Millisecond, 10*time.Millisecond, "the spin is published exactly once")
+}
+
+func TestRetrySpin_StampsSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSessions := mocks.NewMockISessionService(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	s := NewSlotService(&config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2}, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, mockSessions, nil, nil, nil, nil, nil)
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockSessions.EXPECT().Current(ctx, &userID).Return(uint(7), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Do(func(_ context.Context, spin *models.Spin) {
+		require.NotNil(t, spin.SessionID)
+		assert.Equal(t, uint(7), *spin.SessionID)
+	})
+
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
 
-	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, mockHistory, history)
 }
 
-func TestHistory_BeginTransactionError(t *testing.T) {
+func TestRetrySpin_SessionTrackingFailureStillSpins(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	// Arrange
-	mockTxContext := postgres.NewMockITransactionContext(ctrl)
-	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
-	expectedErr := errors.New("failed to begin transaction")
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSessions := mocks.NewMockISessionService(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
 
-	// Expectations
-	mockTxContext.EXPECT().Begin().Return(uuid.Nil, expectedErr)
+	userID := uuid.New()
+	s := NewSlotService(&config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2}, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, mockSessions, nil, nil, nil, nil, nil)
 
-	// Instantiate the service
-	service := NewSlotService(nil, nil, nil)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockSessions.EXPECT().Current(ctx, &userID).Return(uint(0), errors.New("redis unavailable"))
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Do(func(_ context.Context, spin *models.Spin) {
+		assert.Nil(t, spin.SessionID)
+	})
 
-	uid := uuid.New()
-	// Act
-	history, err := service.History(ctx, &uid)
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
 
-	// Assert
-	assert.ErrorIs(t, err, expectedErr)
-	assert.Nil(t, history)
+	assert.NoError(t, err)
+}
+
+func TestRetrySpin_ReportsRealityCheckAfterInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSessionRepo := mocks.NewMockISessionRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	store := &expiringSessionStore{now: time.Now(), timeout: time.Hour}
+	require.NoError(t, store.Set(ctx, &userID, 7))
+	sessions := NewSessionService(mockUserService, mockSessionRepo, store)
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2, RealityCheckInterval: 30}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, sessions, nil, nil, nil, nil, nil)
+
+	session := &models.Session{Model: gorm.Model{ID: 7}}
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil).Times(2)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil).Times(2)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil).Times(2)
+	mockSessionRepo.EXPECT().GetSession(ctx, uint(7)).Return(session, nil).Times(2)
+
+	// A spin within the interval carries no reality check
+	session.CreatedAt = time.Now().Add(-29 * time.Minute)
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+	require.NoError(t, err)
+	assert.Nil(t, spin.RealityCheck)
+
+	// The first spin after the interval reports the time and money spent in the session
+	session.CreatedAt = time.Now().Add(-31 * time.Minute)
+	mockSessionRepo.EXPECT().GetSessionSummary(ctx, uint(7)).Return(&models.SessionSummary{
+		SessionID: 7, TotalSpins: 2, TotalWagered: 20, TotalWon: 5,
+	}, nil)
+	spin, err = s.RetrySpin(ctx, &userID, "", 10)
+	require.NoError(t, err)
+	require.NotNil(t, spin.RealityCheck)
+	assert.Equal(t, uint(7), spin.RealityCheck.SessionID)
+	assert.GreaterOrEqual(t, spin.RealityCheck.Duration, 31*time.Minute)
+	assert.Equal(t, 15.0, spin.RealityCheck.NetLoss())
+}
+
+func TestRetrySpin_RealityCheckFailureStillSpins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSessions := mocks.NewMockISessionService(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2, RealityCheckInterval: 30}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, mockSessions, nil, nil, nil, nil, nil)
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockSessions.EXPECT().Current(ctx, &userID).Return(uint(7), nil)
+	mockSessions.EXPECT().RealityCheck(ctx, uint(7), 30*time.Minute).Return(nil, errors.New("database unavailable"))
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	require.NoError(t, err)
+	assert.Nil(t, spin.RealityCheck)
+}
+
+// memorySpinCounter is an in-memory IDailySpinCounter counting the spins per user and day.
+type memorySpinCounter map[string]int
+
+func (c memorySpinCounter) Count(_ context.Context, userID *uuid.UUID, day string) (int, error) {
+	return c[userID.String()+":"+day], nil
+}
+
+func (c memorySpinCounter) Increment(_ context.Context, userID *uuid.UUID, day string) (int, error) {
+	c[userID.String()+":"+day]++
+	return c[userID.String()+":"+day], nil
+}
+
+func TestRetrySpin_DailySpinLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2, MaxSpinsPerDay: 2}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, memorySpinCounter{}, nil, nil, nil, nil)
+
+	// Only the two spins within the limit are played
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil).Times(2)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil).Times(2)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := s.RetrySpin(ctx, &userID, "", 10)
+		require.NoError(t, err)
+	}
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.ErrorIs(t, err, error2.ErrSpinLimitReached)
+}
+
+func TestRetrySpin_DailySpinLimitResets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{
+		MultiplierThree:    10,
+		MultiplierTwo:      2,
+		MaxSpinsPerDay:     1,
+		SpinLimitTimezone:  "Europe/Berlin",
+		SpinLimitResetHour: 6,
+	}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, memorySpinCounter{}, nil, nil, nil, nil).(*slotService)
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil).Times(2)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil).Times(2)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil).Times(2)
+
+	// After midnight but before the reset hour, the spins still count towards the previous day
+	s.now = func() time.Time { return time.Date(2024, 3, 10, 5, 30, 0, 0, berlin) }
+	_, err = s.RetrySpin(ctx, &userID, "", 10)
+	require.NoError(t, err)
+	s.now = func() time.Time { return time.Date(2024, 3, 10, 5, 59, 0, 0, berlin) }
+	_, err = s.RetrySpin(ctx, &userID, "", 10)
+	require.ErrorIs(t, err, error2.ErrSpinLimitReached)
+
+	s.now = func() time.Time { return time.Date(2024, 3, 10, 6, 0, 0, 0, berlin) }
+	_, err = s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.NoError(t, err)
+}
+
+func TestRetrySpin_SelfExcludedUntilExclusionEnds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	excludedUntil := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	user := &models.User{Model: gorm.Model{ID: 1}, Balance: 100, ExcludedUntil: &excludedUntil}
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*slotService)
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil).Times(2)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+	// The spin is rejected right away, without waiting for funds
+	s.now = func() time.Time { return excludedUntil.Add(-time.Minute) }
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
+	require.ErrorIs(t, err, error2.ErrSelfExcluded)
+
+	// Once the exclusion has ended, the user plays again without any action
+	s.now = func() time.Time { return excludedUntil }
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, spin)
+}
+
+func TestRetrySpin_FrozenUntilUnfrozen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	user := &models.User{Model: gorm.Model{ID: 1}, Balance: 100, Frozen: true}
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil).Times(2)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+	// The spin is rejected right away, without charging the bet
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
+	require.ErrorIs(t, err, error2.ErrAccountFrozen)
+
+	// Once unfrozen, the user plays again
+	user.Frozen = false
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, spin)
+}
+
+func TestSpin_PreSpinBalanceCheckRejectsUnderfundedBet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2, PreSpinBalanceCheck: true}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*slotService)
+
+	// Neither the balance update nor AddSpin is expected, so attempting either fails the test
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 5}, nil)
+
+	spin, err := s.spin(ctx, &userID, config.DefaultGameID, slotConfig, 10, nil, nil, false)
+
+	assert.Nil(t, spin)
+	assert.ErrorIs(t, err, error2.ErrInsufficientFunds)
+}
+
+// TestRetrySpin_PreSpinBalanceCheckNotRetried checks that a bet refused by the pre-spin balance
+// check is returned at once instead of being retried until the backoff gives up.
+func TestRetrySpin_PreSpinBalanceCheckNotRetried(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2, PreSpinBalanceCheck: true}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	// A single attempt: a retry would read the user again and fail the test
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 5}, nil)
+
+	start := time.Now()
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.Nil(t, spin)
+	assert.ErrorIs(t, err, error2.ErrInsufficientFunds)
+	assert.Equal(t, error2.CodeInsufficientFunds, error2.Code(err))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestRetrySpin_PlaysRequestedGame(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{
+		ThreeMatchProbability: 1,
+		MultiplierThree:       10,
+		MultiplierTwo:         2,
+		Games: map[string]config.SlotConfig{
+			"fruits": {
+				Symbols:               []string{"CHERRY", "LEMON"},
+				NumReels:              5,
+				ThreeMatchProbability: 1,
+				MultiplierThree:       50,
+				MultiplierTwo:         2,
+			},
+		},
+	}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	user := &models.User{Model: gorm.Model{ID: 1}, Balance: 100}
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil).Times(2)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 1.0, gomock.Any()).Return(nil, nil).Times(2)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil).Times(2)
+
+	classic, err := s.RetrySpin(ctx, &userID, "", 1)
+	require.NoError(t, err)
+	fruits, err := s.RetrySpin(ctx, &userID, "fruits", 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, config.DefaultGameID, classic.GameID)
+	assert.Equal(t, 10.0, classic.WinAmount)
+	assert.Len(t, classic.Reels, 3)
+	assert.Subset(t, config.Symbols, []string(classic.Reels))
+	assert.Equal(t, "fruits", fruits.GameID)
+	assert.Equal(t, 50.0, fruits.WinAmount)
+	assert.Len(t, fruits.Reels, 5)
+	assert.Subset(t, []string{"CHERRY", "LEMON"}, []string(fruits.Reels))
+}
+
+func TestRetrySpin_UnknownGame(t *testing.T) {
+	userID := uuid.New()
+	s := NewSlotService(&config.SlotConfig{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	spin, err := s.RetrySpin(context.Background(), &userID, "fruits", 10)
+
+	assert.Nil(t, spin)
+	assert.ErrorIs(t, err, error2.ErrGameNotFound)
+}
+
+func TestBulkSpin_StopsWhenFundsRunOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2, MaxBulkSpins: 10}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	// The third spin finds the balance exhausted, so the last two are never played
+	user := &models.User{Model: gorm.Model{ID: 1}, Balance: 20}
+	balances := []float64{10, 0}
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(3)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil).Times(3)
+	gomock.InOrder(
+		mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(&balances[0], nil),
+		mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(&balances[1], nil),
+		mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, error2.ErrInsufficientFunds),
+	)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil).Times(2)
+
+	batch, err := s.BulkSpin(ctx, &userID, "", 10, 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, batch.Requested)
+	assert.Len(t, batch.Spins, 2)
+	assert.ErrorIs(t, batch.Stopped, error2.ErrInsufficientFunds)
+	assert.Equal(t, 20.0, batch.TotalWagered())
+	require.NotNil(t, batch.Balance())
+	assert.Equal(t, 0.0, *batch.Balance())
+}
+
+func TestBulkSpin_FirstSpinFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	s := NewSlotService(&config.SlotConfig{MaxBulkSpins: 10}, mockUserService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, error2.ErrInsufficientFunds)
+
+	batch, err := s.BulkSpin(ctx, &userID, "", 10, 5)
+
+	assert.Nil(t, batch)
+	assert.ErrorIs(t, err, error2.ErrInsufficientFunds)
+}
+
+func TestBulkSpin_CountAboveMaximum(t *testing.T) {
+	userID := uuid.New()
+	s := NewSlotService(&config.SlotConfig{MaxBulkSpins: 10}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	batch, err := s.BulkSpin(context.Background(), &userID, "", 10, 11)
+
+	assert.Nil(t, batch)
+	assert.ErrorIs(t, err, error2.ErrInvalidSpinCount)
+}
+
+func TestRetrySpin_BetDenominations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2, BetDenominations: []float64{1, 2, 5, 10}}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	userID := uuid.New()
+
+	// A disallowed bet is rejected before any balance change
+	spin, err := s.RetrySpin(ctx, &userID, "", 3)
+	assert.ErrorIs(t, err, error2.ErrInvalidBetDenomination)
+	assert.Nil(t, spin)
+
+	// An allowed bet is played
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 5.0, gomock.Any()).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+	spin, err = s.RetrySpin(ctx, &userID, "", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, spin.BetAmount)
+}
+
+func TestRetrySpin_TemporaryError_RetrySuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Set up transaction expectations
+	mockTransactionContext.EXPECT().Begin().AnyTimes().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).AnyTimes().Return(nil)
+	mockTransactionContext.EXPECT().Rollback().AnyTimes().Return(nil)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	// Slot service configuration with 100% probabilities
+	slotConfig := &config.SlotConfig{
+		ThreeMatchProbability: 1,
+		TwoMatchProbability:   1,
+		MultiplierThree:       10,
+		MultiplierTwo:         2,
+	}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	userID := uuid.New()
+	betAmount := 10.0
+
+	// Expectations for the user and repository services
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
+		Model: gorm.Model{ID: 1}, Balance: 100,
+	}, nil).Times(3) // Expecting this call three times due to retries
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, betAmount, gomock.Any()).Return(nil, error2.ErrInsufficientFunds).Times(2)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, betAmount, gomock.Any()).Return(nil, nil).Times(1)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil).Times(1)
+
+	// Execute RetrySpin
+	spin, err := s.RetrySpin(ctx, &userID, "", betAmount)
+
+	// Assertions to verify retry behavior and results
+	assert.NoError(t, err)
+	assert.NotNil(t, spin)
+	assert.Equal(t, betAmount*slotConfig.MultiplierThree, spin.WinAmount)
+}
+
+func TestRetrySpin_ConcurrentRetriesIndependent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	mockTransactionContext.EXPECT().Begin().AnyTimes().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).AnyTimes().Return(nil)
+	mockTransactionContext.EXPECT().Rollback().AnyTimes().Return(nil)
+	// The request logger is set up front, as the fallback to the default logger is not safe for concurrent use
+	ctx := log.ToContext(context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext), log.GetDefaultLogger())
+
+	slotConfig := &config.SlotConfig{ThreeMatchProbability: 1, MultiplierThree: 10, MultiplierTwo: 2}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*slotService)
+	// Each spin is retried for at most 300ms; a backoff shared by the spins would run out of time
+	// long before the last ones get their retries
+	var created sync.WaitGroup
+	s.newBackoff = func() *backoff.ExponentialBackOff {
+		created.Done()
+		return backoff.NewExponentialBackOff(
+			backoff.WithInitialInterval(20*time.Millisecond),
+			backoff.WithRandomizationFactor(0),
+			backoff.WithMultiplier(1),
+			backoff.WithMaxElapsedTime(300*time.Millisecond),
+		)
+	}
+
+	// The funds of every user arrive after the first two attempts
+	const spins = 20
+	var mu sync.Mutex
+	attempts := make(map[uuid.UUID]int)
+	mockUserService.EXPECT().GetByExternalID(ctx, gomock.Any()).AnyTimes().Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, gomock.Any(), 10.0, 100.0).AnyTimes().DoAndReturn(
+		func(_ context.Context, userID *uuid.UUID, _, _ float64) (*float64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts[*userID]++
+			if attempts[*userID] <= 2 {
+				return nil, error2.ErrInsufficientFunds
+			}
+			return nil, nil
+		})
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Times(spins).Return(nil)
+
+	created.Add(spins)
+	errs := make(chan error, spins)
+	for i := 0; i < spins; i++ {
+		go func() {
+			userID := uuid.New()
+			_, err := s.RetrySpin(ctx, &userID, "", 10)
+			errs <- err
+		}()
+	}
+	for i := 0; i < spins; i++ {
+		assert.NoError(t, <-errs)
+	}
+	created.Wait()
+
+	// Every spin got a backoff of its own and its retries
+	assert.Len(t, attempts, spins)
+	for userID, count := range attempts {
+		assert.Equal(t, 3, count, "attempts of %s", userID)
+	}
+}
+
+// spinLogRecorder records the spin results and errors logged through it.
+type spinLogRecorder struct {
+	log.Logger
+	mu      sync.Mutex
+	results int
+	errors  []string
+}
+
+func (l *spinLogRecorder) Infof(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if strings.HasPrefix(format, "spin result") {
+		l.results++
+	}
+}
+
+func (l *spinLogRecorder) Errorf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRetrySpin_SampledSpinLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockTransactionContext.EXPECT().Rollback().Return(nil).AnyTimes()
+	logger := &spinLogRecorder{Logger: log.GetDefaultLogger()}
+	ctx := log.ToContext(context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext), logger)
+
+	userID := uuid.New()
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil).AnyTimes()
+	mockSlotRepo.EXPECT().AddSpin(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	slotConfig := &config.SlotConfig{ThreeMatchProbability: 1, MultiplierThree: 10, MultiplierTwo: 2, SpinLogSampleRate: 10}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	// One in ten spins is logged
+	mockUserService.EXPECT().ApplySpinResult(gomock.Any(), &userID, 10.0, 100.0).Return(nil, nil).Times(30)
+	for i := 0; i < 30; i++ {
+		_, err := s.RetrySpin(ctx, &userID, "", 10)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, logger.results)
+
+	// Big wins are logged whatever the sample
+	slotConfig.BigWinMultiplier = 5
+	mockUserService.EXPECT().ApplySpinResult(gomock.Any(), &userID, 10.0, 100.0).Return(nil, nil).Times(5)
+	for i := 0; i < 5; i++ {
+		_, err := s.RetrySpin(ctx, &userID, "", 10)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 8, logger.results)
+
+	// Failed spins are always logged
+	slotConfig.BigWinMultiplier = 0
+	mockUserService.EXPECT().ApplySpinResult(gomock.Any(), &userID, 10.0, 100.0).Return(nil, errors.New("database unavailable")).Times(5)
+	for i := 0; i < 5; i++ {
+		_, err := s.RetrySpin(ctx, &userID, "", 10)
+		require.Error(t, err)
+	}
+	assert.Len(t, logger.errors, 5)
+}
+
+func TestRetrySpin_TimeoutRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+
+	// The request deadline expires while the balance update is still running
+	ctx, cancel := context.WithTimeout(
+		context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext),
+		20*time.Millisecond,
+	)
+	defer cancel()
+
+	userID := uuid.New()
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Times(0)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *uuid.UUID, _, _ float64) (*float64, error) {
+			<-ctx.Done()
+			return nil, nil
+		})
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+	s := NewSlotService(&config.SlotConfig{}, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, spin)
+}
+
+func TestHistory_GetUserError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Arrange
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	userID := uuid.New()
+	expectedErr := errors.New("user not found")
+
+	// Expectations
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(nil, expectedErr)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+
+	// Instantiate the service
+	service := NewSlotService(nil, mockUserService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	// Act
+	history, _, err := service.History(ctx, &userID, nil, nil, 20, 0)
+
+	// Assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Nil(t, history)
+}
+
+func TestHistory_GetSpinsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Arrange
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	userID := uuid.New()
+	mockUser := &models.User{Model: gorm.Model{ID: 1}}
+	expectedErr := errors.New("failed to fetch spins")
+
+	// Expectations
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(mockUser, nil)
+	mockSlotRepo.EXPECT().GetSpins(ctx, mockUser.ID, nil, nil, 20, 0).Return(nil, int64(0), expectedErr)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+
+	// Instantiate the service
+	service := NewSlotService(nil, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	// Act
+	history, _, err := service.History(ctx, &userID, nil, nil, 20, 0)
+
+	// Assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Nil(t, history)
+}
+func TestHistory_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Arrange
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	// Test Data
+	userID := uuid.New()
+	mockUser := &models.User{Model: gorm.Model{ID: 1}}
+	mockHistory := []*models.Spin{{UserID: mockUser.ID}}
+
+	// Expectations
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(mockUser, nil)
+	mockSlotRepo.EXPECT().GetSpins(ctx, mockUser.ID, nil, nil, 20, 0).Return(mockHistory, int64(21), nil)
+	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	// Instantiate the service
+	service := NewSlotService(nil, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	// Act
+	history, total, err := service.History(ctx, &userID, nil, nil, 20, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, mockHistory, history)
+	assert.Equal(t, int64(21), total)
+}
+
+func TestHistory_BeginTransactionError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Arrange
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	expectedErr := errors.New("failed to begin transaction")
+
+	// Expectations
+	mockTxContext.EXPECT().Begin().Return(uuid.Nil, expectedErr)
+
+	// Instantiate the service
+	service := NewSlotService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	uid := uuid.New()
+	// Act
+	history, _, err := service.History(ctx, &uid, nil, nil, 20, 0)
+
+	// Assert
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Nil(t, history)
+}
+
+func TestLeaderboard_PeriodWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	ctx := context.Background()
+
+	testCases := []struct {
+		period string
+		window time.Duration // Expected distance between now and since; 0 means no lower bound
+	}{
+		{"day", 24 * time.Hour},
+		{"week", 7 * 24 * time.Hour},
+		{"all", 0},
+		{"", 0},
+	}
+
+	s := NewSlotService(&config.SlotConfig{LeaderboardSize: 3}, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	for _, tc := range testCases {
+		t.Run(tc.period, func(t *testing.T) {
+			entries := []*models.LeaderboardEntry{
+				{UserID: 2, Login: "bob@example.com", TotalWin: 300, TotalBet: 100},
+				{UserID: 1, Login: "alice@example.com", TotalWin: 200, TotalBet: 250},
+			}
+			mockSlotRepo.EXPECT().GetLeaderboard(ctx, gomock.Any(), 3).
+				DoAndReturn(func(_ context.Context, since *time.Time, _ int) ([]*models.LeaderboardEntry, error) {
+					if tc.window == 0 {
+						assert.Nil(t, since)
+					} else {
+						assert.WithinDuration(t, time.Now().Add(-tc.window), *since, time.Minute)
+					}
+					return entries, nil
+				})
+
+			res, err := s.Leaderboard(ctx, tc.period)
+			assert.NoError(t, err)
+			assert.Equal(t, entries, res)
+		})
+	}
+}
+
+func TestLeaderboard_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+
+	s := NewSlotService(&config.SlotConfig{LeaderboardSize: 10}, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	res, err := s.Leaderboard(context.Background(), "month")
+
+	assert.ErrorIs(t, err, error2.ErrInvalidPeriod)
+	assert.Nil(t, res)
+}
+
+func TestEvaluate_FiveReels(t *testing.T) {
+	slotConfig := &config.SlotConfig{
+		NumReels:        5,
+		MultiplierTwo:   2,
+		MultiplierThree: 10,
+		AdditionalPayouts: []config.PayoutEntry{
+			{Matches: 4, Multiplier: 25},
+			{Matches: 5, Multiplier: 100},
+		},
+	}
+	s := &slotService{config: slotConfig}
+
+	testCases := []struct {
+		name     string
+		reels    []string
+		expected float64
+	}{
+		{"NoMatch", []string{"A", "B", "A", "A", "A"}, 0},
+		{"TwoOfFive", []string{"C", "C", "A", "C", "C"}, 2},
+		{"ThreeOfFive", []string{"A", "A", "A", "B", "A"}, 10},
+		{"FourOfFive", []string{"D", "D", "D", "D", "A"}, 25},
+		{"FiveOfFive", []string{"B", "B", "B", "B", "B"}, 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			multiplier, _, _ := s.evaluate(s.config, tc.reels)
+			assert.Equal(t, tc.expected, multiplier)
+		})
+	}
+}
+
+func TestEvaluate_WildCompletesMatch(t *testing.T) {
+	s := &slotService{config: &config.SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, WildSymbol: "W"}}
+
+	testCases := []struct {
+		name     string
+		reels    []string
+		expected float64
+		bonuses  []string
+	}{
+		{"TwoOfThreeCompletedByWild", []string{"A", "A", "W"}, 10, []string{models.BonusWild}},
+		{"LeadingWild", []string{"W", "B", "B"}, 10, []string{models.BonusWild}},
+		{"WildPairsWithNextSymbol", []string{"C", "W", "D"}, 2, []string{models.BonusWild}},
+		{"AllWilds", []string{"W", "W", "W"}, 10, []string{models.BonusWild}},
+		{"NoWild", []string{"A", "A", "B"}, 2, nil},
+		{"WildWithoutWin", []string{"A", "B", "W"}, 0, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			multiplier, bonuses, _ := s.evaluate(s.config, tc.reels)
+			assert.Equal(t, tc.expected, multiplier)
+			assert.Equal(t, tc.bonuses, bonuses)
+		})
+	}
+}
+
+func TestEvaluate_ScatterPaysAnywhere(t *testing.T) {
+	s := &slotService{config: &config.SlotConfig{
+		MultiplierTwo: 2, MultiplierThree: 10, WildSymbol: "W",
+		ScatterSymbol: "S", ScatterMinCount: 2, ScatterMultiplier: 5,
+	}}
+
+	testCases := []struct {
+		name     string
+		reels    []string
+		expected float64
+		bonuses  []string
+	}{
+		{"NotAligned", []string{"S", "A", "S"}, 5, []string{models.BonusScatter}},
+		{"AddedToLineWin", []string{"B", "B", "S"}, 2, nil},
+		{"SingleScatter", []string{"A", "S", "B"}, 0, nil},
+		{"ScatterBreaksLine", []string{"A", "S", "A"}, 0, nil},
+		{"WildDoesNotSubstituteScatter", []string{"S", "W", "S"}, 5, []string{models.BonusScatter}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			multiplier, bonuses, _ := s.evaluate(s.config, tc.reels)
+			assert.Equal(t, tc.expected, multiplier)
+			assert.Equal(t, tc.bonuses, bonuses)
+		})
+	}
+
+	// Line and scatter wins add up
+	s.config.NumReels = 5
+	multiplier, bonuses, _ := s.evaluate(s.config, []string{"B", "B", "A", "S", "S"})
+	assert.Equal(t, 7.0, multiplier)
+	assert.Equal(t, []string{models.BonusScatter}, bonuses)
+}
+
+func TestSpinReels_WildsAndScattersKeepDrawnLine(t *testing.T) {
+	slotConfig := &config.SlotConfig{
+		NumReels: 5, MultiplierTwo: 2, MultiplierThree: 10, ThreeMatchProbability: 1,
+		WildSymbol: "W", WildProbability: 1,
+		ScatterSymbol: "S", ScatterProbability: 1, ScatterMinCount: 3, ScatterMultiplier: 5,
+	}
+	s := NewSlotService(slotConfig, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*slotService)
+
+	for i := 0; i < 100; i++ {
+		reels := s.spinReels(s.config)
+		// The first reel keeps the symbol, wilds fill the rest of the run and scatters the other reels
+		assert.NotContains(t, []string{"W", "S"}, reels[0])
+		assert.Equal(t, []string{"W", "W", "S", "S"}, reels[1:])
+		multiplier, bonuses, _ := s.evaluate(s.config, reels)
+		assert.Equal(t, 10.0, multiplier)
+		assert.Equal(t, []string{models.BonusWild}, bonuses)
+	}
+}
+
+func TestSpinReels_FiveReelsMatchesDrawnOutcome(t *testing.T) {
+	testCases := []struct {
+		name     string
+		payouts  []config.PayoutEntry
+		expected float64
+	}{
+		{"FullMatch", []config.PayoutEntry{{Matches: 4, Multiplier: 25, Probability: 1}, {Matches: 5, Multiplier: 100, Probability: 1}}, 100},
+		{"PartialMatch", []config.PayoutEntry{{Matches: 4, Multiplier: 25, Probability: 1}, {Matches: 5, Multiplier: 100, Probability: 0}}, 25},
+		{"NoMatch", []config.PayoutEntry{{Matches: 4, Multiplier: 25, Probability: 0}, {Matches: 5, Multiplier: 100, Probability: 0}}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			slotConfig := &config.SlotConfig{NumReels: 5, MultiplierTwo: 2, MultiplierThree: 10, AdditionalPayouts: tc.payouts}
+			s := NewSlotService(slotConfig, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*slotService)
+
+			for i := 0; i < 100; i++ {
+				reels := s.spinReels(s.config)
+				assert.Len(t, reels, 5)
+				multiplier, _, _ := s.evaluate(s.config, reels)
+				assert.Equal(t, tc.expected, multiplier)
+			}
+		})
+	}
+}
+
+func TestSpinReels_StopsReelStrips(t *testing.T) {
+	strips := [][]string{{"A", "B", "C"}, {"A", "A", "D", "B"}, {"C", "A", "A", "A", "B"}}
+	// The paytable would always draw three of a kind, but the strips decide the outcome
+	slotConfig := &config.SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, ThreeMatchProbability: 1, ReelStrips: strips}
+	s := NewSlotService(slotConfig, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*slotService)
+	s.rng = rand.New(rand.NewSource(42))
+	stops := rand.New(rand.NewSource(42))
+
+	outcomes := map[float64]int{}
+	for i := 0; i < 200; i++ {
+		expected := make([]string, len(strips))
+		for reel, strip := range strips {
+			expected[reel] = strip[stops.Intn(len(strip))]
+		}
+		var expectedMultiplier float64
+		if expected[0] == expected[1] {
+			expectedMultiplier = 2
+			if expected[1] == expected[2] {
+				expectedMultiplier = 10
+			}
+		}
+
+		reels := s.spinReels(s.config)
+		require.Equal(t, expected, reels, "spin %d", i)
+		multiplier, _, _ := s.evaluate(s.config, reels)
+		assert.Equal(t, expectedMultiplier, multiplier, "spin %d: %v", i, reels)
+		outcomes[multiplier]++
+	}
+	assert.Len(t, outcomes, 3, "the strips yield losses, two and three of a kind")
+}
+
+func TestRetrySpin_ConcurrentSpinRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSpinLock := mocks.NewMockISpinLock(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	s := NewSlotService(&config.SlotConfig{}, mockUserService, mockSlotRepo, nil, nil, mockSpinLock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	// The first spin holds the lock; the overlapping second spin must be rejected
+	// without touching the balance and without releasing the lock it never acquired
+	gomock.InOrder(
+		mockSpinLock.EXPECT().Acquire(ctx, &userID).Return(true, nil),
+		mockSpinLock.EXPECT().Acquire(ctx, &userID).Return(false, nil),
+		mockSpinLock.EXPECT().Release(gomock.Any(), &userID).Return(nil),
+	)
+	var overlapping error
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).DoAndReturn(func(ctx context.Context, id *uuid.UUID) (*models.User, error) {
+		_, overlapping = s.RetrySpin(ctx, &userID, "", 10)
+		return &models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil
+	})
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, spin)
+	assert.ErrorIs(t, overlapping, error2.ErrSpinInProgress)
+}
+
+func TestRetrySpin_ReleasesLockOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSpinLock := mocks.NewMockISpinLock(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	expectedErr := errors.New("database unavailable")
+
+	mockSpinLock.EXPECT().Acquire(ctx, &userID).Return(true, nil)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(nil, expectedErr)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockSpinLock.EXPECT().Release(gomock.Any(), &userID).Return(nil)
+
+	s := NewSlotService(&config.SlotConfig{}, mockUserService, nil, nil, nil, mockSpinLock, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.ErrorIs(t, err, expectedErr)
+}
+
+func TestDemoSpin_DoesNotPersist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The user service, slot repository and transaction context have no expectations:
+	// any database write or real balance change fails the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockDemoWallet := mocks.NewMockIDemoWallet(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	balance := 500.0
+	slotConfig := &config.SlotConfig{DemoEnabled: true, DemoBalance: 1000, ThreeMatchProbability: 1, MultiplierThree: 10}
+
+	mockDemoWallet.EXPECT().Balance(ctx, &userID).Return(&balance, nil)
+	mockDemoWallet.EXPECT().Withdraw(ctx, &userID, 10.0).Return(&balance, nil)
+	mockDemoWallet.EXPECT().Deposit(ctx, &userID, 100.0).Return(&balance, nil)
+
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, mockDemoWallet, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.DemoSpin(ctx, &userID, "", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, spin.WinAmount)
+	assert.Zero(t, spin.ID)
+}
+
+func TestDemoSpin_StartsSessionWithConfiguredBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDemoWallet := mocks.NewMockIDemoWallet(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	balance := 990.0
+
+	gomock.InOrder(
+		mockDemoWallet.EXPECT().Balance(ctx, &userID).Return(nil, nil),
+		mockDemoWallet.EXPECT().Reset(ctx, &userID, 1000.0).Return(nil),
+		mockDemoWallet.EXPECT().Withdraw(ctx, &userID, 10.0).Return(&balance, nil),
+	)
+
+	s := NewSlotService(&config.SlotConfig{DemoEnabled: true, DemoBalance: 1000}, nil, nil, nil, nil, nil, mockDemoWallet, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.DemoSpin(ctx, &userID, "", 10)
+
+	assert.NoError(t, err)
+	assert.Zero(t, spin.WinAmount)
+}
+
+func TestDemoSpin_InsufficientDemoFunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDemoWallet := mocks.NewMockIDemoWallet(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	balance := 5.0
+
+	mockDemoWallet.EXPECT().Balance(ctx, &userID).Return(&balance, nil)
+	mockDemoWallet.EXPECT().Withdraw(ctx, &userID, 10.0).Return(nil, error2.ErrInsufficientFunds)
+
+	s := NewSlotService(&config.SlotConfig{DemoEnabled: true}, nil, nil, nil, nil, nil, mockDemoWallet, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := s.DemoSpin(ctx, &userID, "", 10)
+
+	assert.ErrorIs(t, err, error2.ErrInsufficientFunds)
+}
+
+func TestDemoSpin_Disabled(t *testing.T) {
+	userID := uuid.New()
+	s := NewSlotService(&config.SlotConfig{DemoEnabled: false}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	_, err := s.DemoSpin(context.Background(), &userID, "", 10)
+	assert.ErrorIs(t, err, error2.ErrDemoDisabled)
+
+	_, err = s.StartDemo(context.Background(), &userID)
+	assert.ErrorIs(t, err, error2.ErrDemoDisabled)
+}
+
+func TestEndDemo_NeverCreditsRealWallet(t *testing.T) {
+	testCases := []struct {
+		name      string
+		migration bool
+	}{
+		{"MigrationDisabled", false},
+		{"MigrationEnabledWithDefaultHook", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The user service, slot repository and transaction context have no expectations:
+			// any database write or real balance change fails the test
+			mockUserService := mocks.NewMockIUserService(ctrl)
+			mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+			mockDemoWallet := mocks.NewMockIDemoWallet(ctrl)
+			mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+			userID := uuid.New()
+			balance, won := 990.0, 1090.0
+			slotConfig := &config.SlotConfig{DemoEnabled: true, DemoMigration: tc.migration, ThreeMatchProbability: 1, MultiplierThree: 10}
+
+			// A winning demo spin, then the switch back to real play
+			gomock.InOrder(
+				mockDemoWallet.EXPECT().Balance(ctx, &userID).Return(&balance, nil),
+				mockDemoWallet.EXPECT().Withdraw(ctx, &userID, 10.0).Return(&balance, nil),
+				mockDemoWallet.EXPECT().Deposit(ctx, &userID, 100.0).Return(&won, nil),
+				mockDemoWallet.EXPECT().End(ctx, &userID).Return(&won, nil),
+			)
+
+			s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, mockDemoWallet, nil, nil, nil, nil, nil, nil, nil, NewDemoMigration(), nil)
+			_, err := s.DemoSpin(ctx, &userID, "", 10)
+			require.NoError(t, err)
+			ended, migrated, err := s.EndDemo(ctx, &userID)
+
+			require.NoError(t, err)
+			assert.Equal(t, won, *ended)
+			assert.Equal(t, tc.migration, migrated)
+		})
+	}
+}
+
+func TestEndDemo_HookOnlyCalledWhenAllowed(t *testing.T) {
+	testCases := []struct {
+		name      string
+		migration bool
+	}{
+		{"MigrationDisabled", false},
+		{"MigrationEnabled", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDemoWallet := mocks.NewMockIDemoWallet(ctrl)
+			mockMigration := mocks.NewMockIDemoMigration(ctrl)
+			ctx := context.Background()
+			userID := uuid.New()
+			balance := 350.0
+
+			mockDemoWallet.EXPECT().End(ctx, &userID).Return(&balance, nil)
+			if tc.migration {
+				mockMigration.EXPECT().Migrate(ctx, &userID, 350.0).Return(nil)
+			}
+
+			s := NewSlotService(&config.SlotConfig{DemoMigration: tc.migration}, nil, nil, nil, nil, nil, mockDemoWallet, nil, nil, nil, nil, nil, nil, nil, mockMigration, nil)
+			ended, migrated, err := s.EndDemo(ctx, &userID)
+
+			require.NoError(t, err)
+			assert.Equal(t, 350.0, *ended)
+			assert.Equal(t, tc.migration, migrated)
+		})
+	}
+}
+
+func TestEndDemo_NoSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The hook has no expectations: there is no balance to migrate
+	mockDemoWallet := mocks.NewMockIDemoWallet(ctrl)
+	mockMigration := mocks.NewMockIDemoMigration(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockDemoWallet.EXPECT().End(ctx, &userID).Return(nil, nil)
+
+	s := NewSlotService(&config.SlotConfig{DemoMigration: true}, nil, nil, nil, nil, nil, mockDemoWallet, nil, nil, nil, nil, nil, nil, nil, mockMigration, nil)
+	ended, migrated, err := s.EndDemo(ctx, &userID)
+
+	assert.NoError(t, err)
+	assert.Nil(t, ended)
+	assert.False(t, migrated)
+}
+
+func TestRetrySpin_ForcedOutcomeHonoredOnlyWhenEnabled(t *testing.T) {
+	testCases := []struct {
+		name        string
+		enabled     bool
+		expectedWin float64
+	}{
+		// Three A pay 10 times the bet; without the forced reels no match is drawn
+		{"Enabled", true, 100},
+		{"Disabled", false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserService := mocks.NewMockIUserService(ctrl)
+			mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+			mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+			ctx = context.WithValue(ctx, constants.CtxFieldForcedOutcome, &models.ForcedOutcome{Reels: []string{"A", "A", "A"}})
+
+			userID := uuid.New()
+			balance := 100.0
+			slotConfig := &config.SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, ForcedOutcomes: tc.enabled}
+
+			mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+			mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+			mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+			mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, tc.expectedWin).Return(&balance, nil)
+			mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+			s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWin, spin.WinAmount)
+			assert.Equal(t, tc.enabled, slices.Contains(spin.Bonuses, models.BonusForced))
+			if tc.enabled {
+				assert.Equal(t, models.Reels{"A", "A", "A"}, spin.Reels)
+			}
+		})
+	}
+}
+
+func TestRetrySpin_LineWinAndFreeSpins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+	// Three A pay 10 times the bet on the line and the two scatters 5 times the bet anywhere
+	ctx = context.WithValue(ctx, constants.CtxFieldForcedOutcome, &models.ForcedOutcome{Reels: []string{"A", "A", "A", "S", "S"}})
+
+	userID := uuid.New()
+	balance := 240.0
+	slotConfig := &config.SlotConfig{
+		NumReels: 5, MultiplierTwo: 2, MultiplierThree: 10, ForcedOutcomes: true,
+		ScatterSymbol: "S", ScatterMinCount: 2, ScatterMultiplier: 5, ScatterFreeSpins: 10,
+	}
+
+	// The payout and the free spins are applied within the same transaction, before it commits
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	gomock.InOrder(
+		mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, 150.0).Return(&balance, nil),
+		mockUserService.EXPECT().AwardFreeSpins(ctx, &userID, 10).Return(10, nil),
+		mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Do(func(_ context.Context, spin *models.Spin) {
+			assert.Equal(t, 10, spin.FreeSpinsAwarded)
+		}).Return(nil),
+		mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, spin.WinAmount)
+	assert.Equal(t, 10, spin.FreeSpinsAwarded)
+	assert.Contains(t, spin.Bonuses, models.BonusScatter)
+	assert.Equal(t, []models.LineWin{
+		{Line: 0, Symbol: "A", Count: 3, Multiplier: 10, Payout: 100},
+		{Line: models.ScatterLine, Symbol: "S", Count: 2, Multiplier: 5, Payout: 50},
+	}, spin.Wins)
+}
+
+func TestRetrySpin_NoFreeSpinsWithoutScatter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// A line win alone awards no free spins, so AwardFreeSpins fails the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+	ctx = context.WithValue(ctx, constants.CtxFieldForcedOutcome, &models.ForcedOutcome{Reels: []string{"A", "A", "A", "B", "S"}})
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{
+		NumReels: 5, MultiplierTwo: 2, MultiplierThree: 10, ForcedOutcomes: true,
+		ScatterSymbol: "S", ScatterMinCount: 2, ScatterMultiplier: 5, ScatterFreeSpins: 10,
+	}
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, 100.0).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	require.NoError(t, err)
+	assert.Zero(t, spin.FreeSpinsAwarded)
+}
+
+func TestFreeSpin_PaysWinWithoutBet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+	ctx = context.WithValue(ctx, constants.CtxFieldForcedOutcome, &models.ForcedOutcome{Reels: []string{"A", "A", "A"}})
+
+	userID := uuid.New()
+	balance := 20.0
+	// The empty balance would refuse any bet, but a free spin debits none
+	slotConfig := &config.SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, ForcedOutcomes: true, PreSpinBalanceCheck: true, FreeSpinBet: 2}
+
+	// The free spin is used up within the transaction settling the win, before it commits
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+	gomock.InOrder(
+		mockUserService.EXPECT().UseFreeSpin(ctx, &userID).Return(4, nil),
+		mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 0.0, 20.0).Return(&balance, nil),
+		mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Do(func(_ context.Context, spin *models.Spin) {
+			assert.Zero(t, spin.BetAmount)
+			assert.Contains(t, spin.Bonuses, models.BonusFree)
+		}).Return(nil),
+		mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.FreeSpin(ctx, &userID, "")
+
+	require.NoError(t, err)
+	assert.Zero(t, spin.BetAmount)
+	assert.Equal(t, 20.0, spin.WinAmount)
+	assert.Equal(t, 20.0, *spin.Balance)
+}
+
+func TestFreeSpin_NoFreeSpinsLeft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The spin is rolled back before it is played, so ApplySpinResult and AddSpin fail the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, FreeSpinBet: 1}
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().UseFreeSpin(ctx, &userID).Return(0, error2.ErrNoFreeSpins)
+
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.FreeSpin(ctx, &userID, "")
+
+	assert.Nil(t, spin)
+	assert.ErrorIs(t, err, error2.ErrNoFreeSpins)
+}
+
+func TestRetrySpin_KeepsLastSpinAfterCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockLastSpins := mocks.NewMockILastSpinStore(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockLastSpins)
+
+	// The spin is only kept once it has been committed
+	var kept *models.Spin
+	gomock.InOrder(
+		mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil),
+		mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil),
+		mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil),
+		mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil),
+		mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil),
+		mockLastSpins.EXPECT().Set(gomock.Any(), &userID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *uuid.UUID, spin *models.Spin) error {
+				kept = spin
+				return nil
+			}),
+	)
+	spin, err := s.RetrySpin(ctx, &userID, "", 10)
+	require.NoError(t, err)
+	assert.Same(t, spin, kept)
+
+	mockLastSpins.EXPECT().Get(ctx, &userID).Return(kept, nil)
+	last, err := s.LastSpin(ctx, &userID)
+
+	require.NoError(t, err)
+	assert.Same(t, spin, last)
+}
+
+func TestRetrySpin_FailedSpinNotKept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// A spin that is not committed must not replace the last spin, so Set fails the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockLastSpins := mocks.NewMockILastSpinStore(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{MultiplierThree: 10, MultiplierTwo: 2}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockLastSpins)
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, error2.ErrUserNotFound)
+
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.ErrorIs(t, err, error2.ErrUserNotFound)
+}
+
+func TestLastSpin_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLastSpins := mocks.NewMockILastSpinStore(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// An expired spin is gone from the store; without a store the replay is disabled
+	mockLastSpins.EXPECT().Get(ctx, &userID).Return(nil, nil)
+	expired := NewSlotService(&config.SlotConfig{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockLastSpins)
+	disabled := NewSlotService(&config.SlotConfig{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	for name, s := range map[string]interfaces.ISlotService{"Expired": expired, "Disabled": disabled} {
+		_, err := s.LastSpin(ctx, &userID)
+		assert.ErrorIs(t, err, error2.ErrSpinNotFound, name)
+	}
+}
+
+func TestDemoSpin_ForcedWin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDemoWallet := mocks.NewMockIDemoWallet(ctrl)
+	userID := uuid.New()
+	balance, win := 990.0, 250.0
+	ctx := context.WithValue(context.Background(), constants.CtxFieldForcedOutcome, &models.ForcedOutcome{Win: &win})
+
+	mockDemoWallet.EXPECT().Balance(ctx, &userID).Return(&balance, nil)
+	mockDemoWallet.EXPECT().Withdraw(ctx, &userID, 10.0).Return(&balance, nil)
+	mockDemoWallet.EXPECT().Deposit(ctx, &userID, 250.0).Return(&balance, nil)
+
+	s := NewSlotService(&config.SlotConfig{DemoEnabled: true, ForcedOutcomes: true}, nil, nil, nil, nil, nil, mockDemoWallet, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	spin, err := s.DemoSpin(ctx, &userID, "", 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 250.0, spin.WinAmount)
+	assert.Equal(t, []string{models.BonusForced}, spin.Bonuses)
+	assert.Empty(t, spin.Wins)
+}
+
+func TestDemoSpin_InvalidForcedOutcome(t *testing.T) {
+	negative := -1.0
+	testCases := []struct {
+		name    string
+		outcome *models.ForcedOutcome
+	}{
+		{"TooFewReels", &models.ForcedOutcome{Reels: []string{"A", "A"}}},
+		{"UnknownSymbol", &models.ForcedOutcome{Reels: []string{"A", "A", "X"}}},
+		{"NegativeWin", &models.ForcedOutcome{Win: &negative}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The demo wallet is not touched by a rejected outcome
+			userID := uuid.New()
+			s := NewSlotService(&config.SlotConfig{DemoEnabled: true, ForcedOutcomes: true}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			_, err := s.DemoSpin(context.WithValue(context.Background(), constants.CtxFieldForcedOutcome, tc.outcome), &userID, "", 10)
+
+			assert.ErrorIs(t, err, error2.ErrInvalidForcedOutcome)
+		})
+	}
+}
+
+func TestSpinInCurrency_ConvertsBetAndWin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockRates := mocks.NewMockIExchangeRateProvider(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+	// Three A pay 10 times the bet
+	ctx = context.WithValue(ctx, constants.CtxFieldForcedOutcome, &models.ForcedOutcome{Reels: []string{"A", "A", "A"}})
+
+	userID := uuid.New()
+	balance := 197.11
+	slotConfig := &config.SlotConfig{
+		BaseCurrency: "USD", MultiplierTwo: 2, MultiplierThree: 10, ForcedOutcomes: true,
+		PayoutRounding: config.PayoutRoundingRound, PayoutDecimals: 2,
+	}
+
+	// 9.99 EUR at 1.08 are 10.7892 USD, rounded to 10.79; the win of 107.90 USD is 99.907 EUR, rounded to 99.91
+	mockRates.EXPECT().Rate(ctx, "EUR").Return(1.08, nil)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.79, 107.9).Return(&balance, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Do(func(_ context.Context, spin *models.Spin) {
+		assert.Equal(t, "EUR", spin.Currency)
+		assert.Equal(t, 1.08, spin.ExchangeRate)
+		assert.Equal(t, 9.99, spin.OriginalBetAmount)
+		assert.Equal(t, 99.91, spin.OriginalWinAmount)
+	}).Return(nil)
+
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockRates, nil, nil)
+	spin, err := s.SpinInCurrency(ctx, &userID, "", "eur", 9.99)
+
+	require.NoError(t, err)
+	assert.Equal(t, 10.79, spin.BetAmount)
+	assert.Equal(t, 107.9, spin.WinAmount)
+	assert.Equal(t, 99.91, spin.OriginalWinAmount)
+}
+
+func TestSpinInCurrency_BaseCurrencyIsNotConverted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The exchange rate provider is not asked for the base currency
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockRates := mocks.NewMockIExchangeRateProvider(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	slotConfig := &config.SlotConfig{BaseCurrency: "USD", MultiplierTwo: 2, MultiplierThree: 10}
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockRates, nil, nil)
+	spin, err := s.SpinInCurrency(ctx, &userID, "", "usd", 10)
+
+	require.NoError(t, err)
+	assert.Empty(t, spin.Currency)
+	assert.Zero(t, spin.ExchangeRate)
+}
+
+func TestSpinInCurrency_Rejected(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rates       map[string]float64
+		denominated []float64
+		betAmount   float64
+		expectedErr error
+	}{
+		{"NoExchangeRates", nil, nil, 10, error2.ErrUnsupportedCurrency},
+		{"UnknownCurrency", map[string]float64{"GBP": 1.27}, nil, 10, error2.ErrUnsupportedCurrency},
+		{"BetBelowMinorUnit", map[string]float64{"EUR": 0.0067}, nil, 0.5, error2.ErrInvalidAmount},
+		// The denominations apply to the bet as placed, not to the converted one
+		{"DisallowedDenomination", map[string]float64{"EUR": 1.08}, []float64{1, 5}, 2, error2.ErrInvalidBetDenomination},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Neither the balance nor the spins are touched by a rejected bet
+			var rates interfaces.IExchangeRateProvider
+			if tc.rates != nil {
+				rates = exchange.NewProvider(&exchange.Config{Rates: tc.rates})
+			}
+			userID := uuid.New()
+			slotConfig := &config.SlotConfig{BaseCurrency: "USD", BetDenominations: tc.denominated}
+			s := NewSlotService(slotConfig, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, rates, nil, nil)
+
+			_, err := s.SpinInCurrency(context.Background(), &userID, "", "EUR", tc.betAmount)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestVoidSpin_RestoresBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	user := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID}
+	spin := &models.Spin{Model: gorm.Model{ID: 7}, UserID: 1, BetAmount: 10, WinAmount: 50}
+	// Balance after a bet of 10 won 50, starting from 100
+	balance := 140.0
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockSlotRepo.EXPECT().GetSpinForUpdate(ctx, uint(7)).Return(spin, nil)
+	mockUserService.EXPECT().GetByID(ctx, uint(1)).Return(user, nil)
+	mockUserService.EXPECT().Deposit(ctx, &userID, 10.0).DoAndReturn(
+		func(_ context.Context, _ *uuid.UUID, amount float64) (*float64, error) {
+			balance += amount
+			return &balance, nil
+		})
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 50.0, 0.0).DoAndReturn(
+		func(_ context.Context, _ *uuid.UUID, bet, win float64) (*float64, error) {
+			balance += win - bet
+			return &balance, nil
+		})
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeVoidRefund, Amount: 10, Reference: "spin:7"})
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeVoidClawback, Amount: -50, Reference: "spin:7"})
+	mockSlotRepo.EXPECT().VoidSpin(ctx, uint(7), "duplicate charge", gomock.Any()).Return(nil)
+
+	s := NewSlotService(&config.SlotConfig{}, mockUserService, mockSlotRepo, mockLedgerRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	voided, err := s.VoidSpin(ctx, 7, "duplicate charge")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, balance)
+	assert.True(t, voided.Voided())
+	assert.Equal(t, "duplicate charge", voided.VoidReason)
+}
+
+func TestVoidSpin_RefundsSelfExcludedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	// The refund returns the user's own funds, so the self-exclusion does not hold it back
+	userID := uuid.New()
+	excludedUntil := time.Now().Add(time.Hour)
+	user := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, ExcludedUntil: &excludedUntil}
+	spin := &models.Spin{Model: gorm.Model{ID: 7}, UserID: 1, BetAmount: 10}
+	balance := 110.0
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	mockSlotRepo.EXPECT().GetSpinForUpdate(ctx, uint(7)).Return(spin, nil)
+	mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	mockUserRepo.EXPECT().Deposit(ctx, uint(1), 10.0).Return(&balance, nil)
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeVoidRefund, Amount: 10, Reference: "spin:7"})
+	mockSlotRepo.EXPECT().VoidSpin(ctx, uint(7), "duplicate charge", gomock.Any()).Return(nil)
+
+	userService := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, mockLedgerRepo, nil)
+	s := NewSlotService(&config.SlotConfig{}, userService, mockSlotRepo, mockLedgerRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	voided, err := s.VoidSpin(ctx, 7, "duplicate charge")
+
+	require.NoError(t, err)
+	assert.True(t, voided.Voided())
+}
+
+func TestVoidSpin_AlreadyVoided(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Any balance change or ledger entry fails the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	voidedAt := time.Now()
+	spin := &models.Spin{Model: gorm.Model{ID: 7}, UserID: 1, BetAmount: 10, WinAmount: 50, VoidedAt: &voidedAt}
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockSlotRepo.EXPECT().GetSpinForUpdate(ctx, uint(7)).Return(spin, nil)
+
+	s := NewSlotService(&config.SlotConfig{}, mockUserService, mockSlotRepo, mockLedgerRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := s.VoidSpin(ctx, 7, "duplicate charge")
+
+	assert.ErrorIs(t, err, error2.ErrSpinAlreadyVoided)
+}
+
+func TestVoidSpin_ConcurrentVoidRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	spin := &models.Spin{Model: gorm.Model{ID: 7}, UserID: 1, BetAmount: 10}
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockSlotRepo.EXPECT().GetSpinForUpdate(ctx, uint(7)).Return(spin, nil)
+	mockUserService.EXPECT().GetByID(ctx, uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID}, nil)
+	mockUserService.EXPECT().Deposit(ctx, &userID, 10.0).Return(nil, nil)
+	mockLedgerRepo.EXPECT().AddEntry(ctx, gomock.Any()).Return(nil)
+	// The guarded update finds the spin already voided by another request
+	mockSlotRepo.EXPECT().VoidSpin(ctx, uint(7), "duplicate charge", gomock.Any()).Return(error2.ErrSpinAlreadyVoided)
+
+	s := NewSlotService(&config.SlotConfig{}, mockUserService, mockSlotRepo, mockLedgerRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := s.VoidSpin(ctx, 7, "duplicate charge")
+
+	assert.ErrorIs(t, err, error2.ErrSpinAlreadyVoided)
+}
+
+func TestVoidSpin_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockSlotRepo.EXPECT().GetSpinForUpdate(ctx, uint(7)).Return(nil, nil)
+
+	s := NewSlotService(&config.SlotConfig{}, nil, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := s.VoidSpin(ctx, 7, "duplicate charge")
+
+	assert.ErrorIs(t, err, error2.ErrSpinNotFound)
+}
+
+func TestPlay_WinsBreakdown(t *testing.T) {
+	testCases := []struct {
+		name        string
+		probability float64
+		wins        int
+	}{
+		{"ThreeMatch", 1, 1},
+		{"NoWin", 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			slotConfig := &config.SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, ThreeMatchProbability: tc.probability}
+			s := NewSlotService(slotConfig, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*slotService)
+
+			payout, reels, _, wins := s.play(s.config, 5, nil)
+
+			assert.NotNil(t, wins)
+			assert.Len(t, wins, tc.wins)
+			if tc.wins == 0 {
+				assert.Zero(t, payout)
+				return
+			}
+			assert.Equal(t, models.LineWin{Line: 0, Symbol: reels[0], Count: 3, Multiplier: 10, Payout: 50}, wins[0])
+			assert.Equal(t, 50.0, payout)
+		})
+	}
+}
+
+func TestEvaluate_ScatterWinEntry(t *testing.T) {
+	s := &slotService{config: &config.SlotConfig{
+		NumReels: 5, MultiplierTwo: 2, MultiplierThree: 10, WildSymbol: "W",
+		ScatterSymbol: "S", ScatterMinCount: 2, ScatterMultiplier: 5,
+	}}
+
+	multiplier, _, wins := s.evaluate(s.config, []string{"W", "B", "A", "S", "S"})
+
+	assert.Equal(t, 7.0, multiplier)
+	assert.Equal(t, []models.LineWin{
+		{Line: 0, Symbol: "B", Count: 2, Multiplier: 2},
+		{Line: models.ScatterLine, Symbol: "S", Count: 2, Multiplier: 5},
+	}, wins)
+}
+
+func TestEvaluate_PayBothWays(t *testing.T) {
+	testCases := []struct {
+		name      string
+		bothWays  bool
+		fullTwice bool
+		reels     []string
+		expected  float64
+		wins      []models.LineWin
+	}{
+		{"LeftOnlyIgnoresRightMatch", false, false, []string{"A", "A", "B", "C", "C"}, 2, []models.LineWin{
+			{Line: 0, Symbol: "A", Count: 2, Multiplier: 2},
+		}},
+		{"BothWaysAddsRightMatch", true, false, []string{"A", "A", "B", "C", "C"}, 4, []models.LineWin{
+			{Line: 0, Symbol: "A", Count: 2, Multiplier: 2},
+			{Line: 0, Reversed: true, Symbol: "C", Count: 2, Multiplier: 2},
+		}},
+		{"RightMatchOnly", true, false, []string{"A", "B", "D", "D", "D"}, 10, []models.LineWin{
+			{Line: 0, Reversed: true, Symbol: "D", Count: 3, Multiplier: 10},
+		}},
+		{"FullLinePaysOnce", true, false, []string{"B", "B", "B", "B", "B"}, 100, []models.LineWin{
+			{Line: 0, Symbol: "B", Count: 5, Multiplier: 100},
+		}},
+		{"FullLinePaysTwice", true, true, []string{"B", "B", "B", "B", "B"}, 200, []models.LineWin{
+			{Line: 0, Symbol: "B", Count: 5, Multiplier: 100},
+			{Line: 0, Reversed: true, Symbol: "B", Count: 5, Multiplier: 100},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &slotService{config: &config.SlotConfig{
+				NumReels: 5, MultiplierTwo: 2, MultiplierThree: 10,
+				AdditionalPayouts: []config.PayoutEntry{{Matches: 4, Multiplier: 25}, {Matches: 5, Multiplier: 100}},
+				PayBothWays:       tc.bothWays, FullLinePaysTwice: tc.fullTwice,
+			}}
+			multiplier, bonuses, wins := s.evaluate(s.config, tc.reels)
+			assert.Equal(t, tc.expected, multiplier)
+			assert.Nil(t, bonuses)
+			assert.Equal(t, tc.wins, wins)
+		})
+	}
+
+	// A wild completing the match from the last reel triggers the wild bonus
+	s := &slotService{config: &config.SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, WildSymbol: "W", PayBothWays: true}}
+	multiplier, bonuses, _ := s.evaluate(s.config, []string{"A", "W", "B"})
+	assert.Equal(t, 4.0, multiplier)
+	assert.Equal(t, []string{models.BonusWild}, bonuses)
+}
+
+func TestEvaluate_TwoMatchMultipliers(t *testing.T) {
+	s := &slotService{config: &config.SlotConfig{
+		MultiplierTwo: 2, MultiplierThree: 10, WildSymbol: "W",
+		ScatterSymbol: "S", ScatterMinCount: 2, ScatterMultiplier: 5,
+		TwoMatchMultipliers: map[string]float64{"A": 4, "W": 8},
+	}}
+	testCases := []struct {
+		name     string
+		reels    []string
+		expected float64
+	}{
+		{"SymbolMultiplier", []string{"A", "A", "B"}, 4},
+		{"FallbackToMultiplierTwo", []string{"B", "B", "A"}, 2},
+		{"TwoWilds", []string{"W", "W", "S"}, 8},
+		{"WildCompletesSymbol", []string{"A", "W", "B"}, 4},
+		{"ThreeMatchUnaffected", []string{"A", "A", "A"}, 10},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			multiplier, _, wins := s.evaluate(s.config, tc.reels)
+			assert.Equal(t, tc.expected, multiplier)
+			require.Len(t, wins, 1)
+			assert.Equal(t, tc.expected, wins[0].Multiplier)
+		})
+	}
+}
+
+func TestRetrySpin_Jackpot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockJackpots := mocks.NewMockIJackpotStore(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockTransactionContext.EXPECT().Rollback().Return(nil).AnyTimes()
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil).AnyTimes()
+	mockSlotRepo.EXPECT().AddSpin(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// The reels never pay, so the whole win is the jackpot
+	slotConfig := &config.SlotConfig{
+		MultiplierTwo: 2, MultiplierThree: 10, JackpotProbability: 1, JackpotSeed: 1000, JackpotContribution: 0.5,
+	}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockJackpots, nil, nil, nil)
+
+	t.Run("HitPaysSeedAndPool", func(t *testing.T) {
+		gomock.InOrder(
+			mockJackpots.EXPECT().Hit(gomock.Any()).Return(40.0, nil),
+			mockUserService.EXPECT().ApplySpinResult(gomock.Any(), &userID, 10.0, 1040.0).Return(nil, nil),
+			mockJackpots.EXPECT().Contribute(gomock.Any(), 5.0).Return(5.0, nil),
+		)
+
+		spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1040.0, spin.Jackpot)
+		assert.Equal(t, 1040.0, spin.WinAmount)
+		assert.Contains(t, spin.Bonuses, models.BonusJackpot)
+	})
+
+	t.Run("SpinNotCommittedReturnsPool", func(t *testing.T) {
+		gomock.InOrder(
+			mockJackpots.EXPECT().Hit(gomock.Any()).Return(40.0, nil),
+			mockUserService.EXPECT().ApplySpinResult(gomock.Any(), &userID, 10.0, 1040.0).Return(nil, errors.New("database unavailable")),
+			mockJackpots.EXPECT().Contribute(gomock.Any(), 40.0).Return(40.0, nil),
+		)
+
+		_, err := s.RetrySpin(ctx, &userID, "", 10)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("StoreFailureSpinsWithoutJackpot", func(t *testing.T) {
+		gomock.InOrder(
+			mockJackpots.EXPECT().Hit(gomock.Any()).Return(0.0, errors.New("redis unavailable")),
+			mockUserService.EXPECT().ApplySpinResult(gomock.Any(), &userID, 10.0, 0.0).Return(nil, nil),
+			mockJackpots.EXPECT().Contribute(gomock.Any(), 5.0).Return(5.0, nil),
+		)
+
+		spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+		assert.NoError(t, err)
+		assert.Zero(t, spin.Jackpot)
+		assert.NotContains(t, spin.Bonuses, models.BonusJackpot)
+	})
+}
+
+// memoryWinStreaks is an in-memory IWinStreakStore.
+type memoryWinStreaks map[uuid.UUID]int
+
+func (m memoryWinStreaks) Get(_ context.Context, userID *uuid.UUID) (int, error) {
+	return m[*userID], nil
+}
+
+func (m memoryWinStreaks) Set(_ context.Context, userID *uuid.UUID, streak int) error {
+	m[*userID] = streak
+	return nil
+}
+
+func TestRetrySpin_WinStreak(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil).AnyTimes()
+	mockSlotRepo.EXPECT().AddSpin(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	streaks := memoryWinStreaks{}
+	slotConfig := &config.SlotConfig{MultiplierTwo: 2, MultiplierThree: 10, StreakMultipliers: []float64{1, 1.5, 2}}
+	s := NewSlotService(slotConfig, mockUserService, mockSlotRepo, nil, nil, nil, nil, streaks, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	testCases := []struct {
+		win            bool
+		expectedStreak int
+		expectedWin    float64
+	}{
+		{true, 1, 100},
+		{true, 2, 150},
+		{true, 3, 200},
+		{true, 4, 200}, // Longer streaks keep the last multiplier
+		{false, 0, 0},
+		{true, 1, 100},
+	}
+
+	for i, tc := range testCases {
+		slotConfig.ThreeMatchProbability = 0
+		if tc.win {
+			slotConfig.ThreeMatchProbability = 1
+		}
+		mockUserService.EXPECT().ApplySpinResult(gomock.Any(), &userID, 10.0, tc.expectedWin).Return(nil, nil)
+
+		spin, err := s.RetrySpin(ctx, &userID, "", 10)
+
+		assert.NoError(t, err, "spin %d", i)
+		assert.Equal(t, tc.expectedStreak, spin.Streak, "spin %d", i)
+		assert.Equal(t, tc.expectedWin, spin.WinAmount, "spin %d", i)
+		assert.Equal(t, tc.expectedStreak, streaks[userID], "spin %d", i)
+		if tc.win {
+			assert.Equal(t, tc.expectedWin, spin.Wins[0].Payout, "spin %d", i)
+		}
+		assert.Equal(t, tc.expectedWin > 100, slices.Contains(spin.Bonuses, models.BonusStreak), "spin %d", i)
+	}
+}
+
+func TestRetrySpin_ChainsSpinWithinTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The chained spin is written within the transaction, so a write by the spin writer fails the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSpinWriter := mocks.NewMockISpinWriter(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(nil, nil)
+	gomock.InOrder(
+		mockSlotRepo.EXPECT().LastSpinHash(ctx, uint(1)).Return("previous", nil),
+		mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Do(func(_ context.Context, spin *models.Spin) {
+			assert.Equal(t, "previous", spin.PrevHash)
+			assert.Len(t, spin.Hash, 64)
+			assert.Equal(t, spin.ComputeHash(), spin.Hash)
+		}).Return(nil),
+	)
+
+	s := NewSlotService(&config.SlotConfig{SpinHashChain: true}, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, mockSpinWriter, nil, nil, nil, nil, nil, nil, nil)
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
+
+	assert.NoError(t, err)
+}
+
+// chainedSpins returns three spins of a user chained as the spin method chains them.
+func chainedSpins() []*models.Spin {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 123456000, time.UTC)
+	prevHash := ""
+	spins := make([]*models.Spin, 0, 3)
+	for i := 1; i <= 3; i++ {
+		spin := &models.Spin{
+			Model:     gorm.Model{ID: uint(i), CreatedAt: createdAt.Add(time.Duration(i) * time.Second)},
+			UserID:    1,
+			GameID:    "default",
+			BetAmount: 10,
+			Reels:     models.Reels{"A", "B", "C"},
+		}
+		spin.Chain(prevHash)
+		prevHash = spin.Hash
+		spins = append(spins, spin)
+	}
+	return spins
+}
+
+func TestVerifySpinChain_AlteredMiddleSpinBreaksChain(t *testing.T) {
+	testCases := []struct {
+		name     string
+		alter    func(spins []*models.Spin) []*models.Spin
+		checked  int
+		brokenAt *uint
+	}{
+		{"Intact", func(spins []*models.Spin) []*models.Spin { return spins }, 3, nil},
+		{"AlteredWin", func(spins []*models.Spin) []*models.Spin {
+			spins[1].WinAmount = 500
+			return spins
+		}, 2, &[]uint{2}[0]},
+		{"AlteredAndRehashed", func(spins []*models.Spin) []*models.Spin {
+			spins[1].WinAmount = 500
+			spins[1].Hash = spins[1].ComputeHash()
+			return spins
+		}, 3, &[]uint{3}[0]},
+		{"Removed", func(spins []*models.Spin) []*models.Spin {
+			return []*models.Spin{spins[0], spins[2]}
+		}, 2, &[]uint{3}[0]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserService := mocks.NewMockIUserService(ctrl)
+			mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+			mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+			userID := uuid.New()
+			spins := tc.alter(chainedSpins())
+			mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+			mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+			mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+			mockSlotRepo.EXPECT().EachChainedSpin(ctx, uint(1), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ uint, fn func(*models.Spin) error) error {
+					for _, spin := range spins {
+						if err := fn(spin); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+
+			s := NewSlotService(&config.SlotConfig{}, mockUserService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			result, err := s.VerifySpinChain(ctx, &userID)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.checked, result.Checked)
+			assert.Equal(t, tc.brokenAt, result.BrokenAt)
+			assert.Equal(t, tc.brokenAt == nil, result.Valid())
+		})
+	}
 }
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/tracing"
+)
+
+// tracedSlotService decorates an ISlotService with an OpenTelemetry span around every call.
+type tracedSlotService struct {
+	interfaces.ISlotService // Wrapped service
+}
+
+// tracedUserService decorates an IUserService with an OpenTelemetry span around every call.
+type tracedUserService struct {
+	interfaces.IUserService // Wrapped service
+}
+
+// tracedWalletService decorates an IWalletService with an OpenTelemetry span around every call.
+type tracedWalletService struct {
+	interfaces.IWalletService // Wrapped service
+}
+
+// NewTracedSlotService wraps the given ISlotService with spans when the trace export is enabled;
+// otherwise it returns the service unchanged.
+//
+// Parameters:
+//   - cfg: Tracing configuration, including the export toggle.
+//   - slotService: The ISlotService to decorate.
+//
+// Returns:
+//   - An ISlotService, decorated with spans when enabled.
+func NewTracedSlotService(cfg *tracing.Config, slotService interfaces.ISlotService) interfaces.ISlotService {
+	if !cfg.Enabled() {
+		return slotService
+	}
+	return &tracedSlotService{ISlotService: slotService}
+}
+
+// NewTracedUserService wraps the given IUserService with spans when the trace export is enabled;
+// otherwise it returns the service unchanged.
+//
+// Parameters:
+//   - cfg: Tracing configuration, including the export toggle.
+//   - userService: The IUserService to decorate.
+//
+// Returns:
+//   - An IUserService, decorated with spans when enabled.
+func NewTracedUserService(cfg *tracing.Config, userService interfaces.IUserService) interfaces.IUserService {
+	if !cfg.Enabled() {
+		return userService
+	}
+	return &tracedUserService{IUserService: userService}
+}
+
+// NewTracedWalletService wraps the given IWalletService with spans when the trace export is enabled;
+// otherwise it returns the service unchanged.
+//
+// Parameters:
+//   - cfg: Tracing configuration, including the export toggle.
+//   - walletService: The IWalletService to decorate.
+//
+// Returns:
+//   - An IWalletService, decorated with spans when enabled.
+func NewTracedWalletService(cfg *tracing.Config, walletService interfaces.IWalletService) interfaces.IWalletService {
+	if !cfg.Enabled() {
+		return walletService
+	}
+	return &tracedWalletService{IWalletService: walletService}
+}
+
+// RetrySpin delegates to the wrapped service within a span.
+func (s *tracedSlotService) RetrySpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64) (*models.Spin, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.RetrySpin")
+	spin, err := s.ISlotService.RetrySpin(ctx, userID, gameID, betAmount)
+	tracing.End(span, err)
+	return spin, err
+}
+
+// BulkSpin delegates to the wrapped service within a span.
+func (s *tracedSlotService) BulkSpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64, count int) (*models.BulkSpin, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.BulkSpin")
+	batch, err := s.ISlotService.BulkSpin(ctx, userID, gameID, betAmount, count)
+	tracing.End(span, err)
+	return batch, err
+}
+
+// FreeSpin delegates to the wrapped service within a span.
+func (s *tracedSlotService) FreeSpin(ctx context.Context, userID *uuid.UUID, gameID string) (*models.Spin, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.FreeSpin")
+	spin, err := s.ISlotService.FreeSpin(ctx, userID, gameID)
+	tracing.End(span, err)
+	return spin, err
+}
+
+// LastSpin delegates to the wrapped service within a span.
+func (s *tracedSlotService) LastSpin(ctx context.Context, userID *uuid.UUID) (*models.Spin, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.LastSpin")
+	spin, err := s.ISlotService.LastSpin(ctx, userID)
+	tracing.End(span, err)
+	return spin, err
+}
+
+// SpinInCurrency delegates to the wrapped service within a span.
+func (s *tracedSlotService) SpinInCurrency(ctx context.Context, userID *uuid.UUID, gameID, currency string, betAmount float64) (*models.Spin, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.SpinInCurrency")
+	spin, err := s.ISlotService.SpinInCurrency(ctx, userID, gameID, currency, betAmount)
+	tracing.End(span, err)
+	return spin, err
+}
+
+// DemoSpin delegates to the wrapped service within a span.
+func (s *tracedSlotService) DemoSpin(ctx context.Context, userID *uuid.UUID, gameID string, betAmount float64) (*models.Spin, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.DemoSpin")
+	spin, err := s.ISlotService.DemoSpin(ctx, userID, gameID, betAmount)
+	tracing.End(span, err)
+	return spin, err
+}
+
+// StartDemo delegates to the wrapped service within a span.
+func (s *tracedSlotService) StartDemo(ctx context.Context, userID *uuid.UUID) (*float64, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.StartDemo")
+	balance, err := s.ISlotService.StartDemo(ctx, userID)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// EndDemo delegates to the wrapped service within a span.
+func (s *tracedSlotService) EndDemo(ctx context.Context, userID *uuid.UUID) (*float64, bool, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.EndDemo")
+	balance, migrated, err := s.ISlotService.EndDemo(ctx, userID)
+	tracing.End(span, err)
+	return balance, migrated, err
+}
+
+// History delegates to the wrapped service within a span.
+func (s *tracedSlotService) History(ctx context.Context, userID *uuid.UUID, from, to *time.Time, limit, offset int) ([]*models.Spin, int64, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.History")
+	spins, total, err := s.ISlotService.History(ctx, userID, from, to, limit, offset)
+	tracing.End(span, err)
+	return spins, total, err
+}
+
+// ExportHistory delegates to the wrapped service within a span.
+func (s *tracedSlotService) ExportHistory(ctx context.Context, userID *uuid.UUID, from, to *time.Time, fn func(*models.Spin) error) error {
+	ctx, span := tracing.Start(ctx, "SlotService.ExportHistory")
+	err := s.ISlotService.ExportHistory(ctx, userID, from, to, fn)
+	tracing.End(span, err)
+	return err
+}
+
+// Leaderboard delegates to the wrapped service within a span.
+func (s *tracedSlotService) Leaderboard(ctx context.Context, period string) ([]*models.LeaderboardEntry, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.Leaderboard")
+	entries, err := s.ISlotService.Leaderboard(ctx, period)
+	tracing.End(span, err)
+	return entries, err
+}
+
+// Stats delegates to the wrapped service within a span.
+func (s *tracedSlotService) Stats(ctx context.Context, userID *uuid.UUID) (*models.SpinStats, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.Stats")
+	stats, err := s.ISlotService.Stats(ctx, userID)
+	tracing.End(span, err)
+	return stats, err
+}
+
+// VerifySpinChain delegates to the wrapped service within a span.
+func (s *tracedSlotService) VerifySpinChain(ctx context.Context, userID *uuid.UUID) (*models.SpinChainVerification, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.VerifySpinChain")
+	result, err := s.ISlotService.VerifySpinChain(ctx, userID)
+	tracing.End(span, err)
+	return result, err
+}
+
+// VoidSpin delegates to the wrapped service within a span.
+func (s *tracedSlotService) VoidSpin(ctx context.Context, spinID uint, reason string) (*models.Spin, error) {
+	ctx, span := tracing.Start(ctx, "SlotService.VoidSpin")
+	spin, err := s.ISlotService.VoidSpin(ctx, spinID, reason)
+	tracing.End(span, err)
+	return spin, err
+}
+
+// Login delegates to the wrapped service within a span.
+func (s *tracedUserService) Login(ctx context.Context, login, password string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	user, err := s.IUserService.Login(ctx, login, password)
+	tracing.End(span, err)
+	return user, err
+}
+
+// Register delegates to the wrapped service within a span.
+func (s *tracedUserService) Register(ctx context.Context, login, password string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	user, err := s.IUserService.Register(ctx, login, password)
+	tracing.End(span, err)
+	return user, err
+}
+
+// GetByExternalID delegates to the wrapped service within a span.
+func (s *tracedUserService) GetByExternalID(ctx context.Context, id *uuid.UUID) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetByExternalID")
+	user, err := s.IUserService.GetByExternalID(ctx, id)
+	tracing.End(span, err)
+	return user, err
+}
+
+// GetByID delegates to the wrapped service within a span.
+func (s *tracedUserService) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetByID")
+	user, err := s.IUserService.GetByID(ctx, id)
+	tracing.End(span, err)
+	return user, err
+}
+
+// ListUsers delegates to the wrapped service within a span.
+func (s *tracedUserService) ListUsers(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListUsers")
+	users, total, err := s.IUserService.ListUsers(ctx, filter, limit, offset)
+	tracing.End(span, err)
+	return users, total, err
+}
+
+// UpdateLogin delegates to the wrapped service within a span.
+func (s *tracedUserService) UpdateLogin(ctx context.Context, userID *uuid.UUID, login, password string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateLogin")
+	user, err := s.IUserService.UpdateLogin(ctx, userID, login, password)
+	tracing.End(span, err)
+	return user, err
+}
+
+// UpdateSettings delegates to the wrapped service within a span.
+func (s *tracedUserService) UpdateSettings(ctx context.Context, userID *uuid.UUID, autoStopWin *float64) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateSettings")
+	user, err := s.IUserService.UpdateSettings(ctx, userID, autoStopWin)
+	tracing.End(span, err)
+	return user, err
+}
+
+// SelfExclude delegates to the wrapped service within a span.
+func (s *tracedUserService) SelfExclude(ctx context.Context, userID *uuid.UUID, period time.Duration) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SelfExclude")
+	user, err := s.IUserService.SelfExclude(ctx, userID, period)
+	tracing.End(span, err)
+	return user, err
+}
+
+// SetFrozen delegates to the wrapped service within a span.
+func (s *tracedUserService) SetFrozen(ctx context.Context, userID *uuid.UUID, frozen bool) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetFrozen")
+	user, err := s.IUserService.SetFrozen(ctx, userID, frozen)
+	tracing.End(span, err)
+	return user, err
+}
+
+// SetDepositLimits delegates to the wrapped service within a span.
+func (s *tracedUserService) SetDepositLimits(ctx context.Context, userID *uuid.UUID, limits models.DepositLimits) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetDepositLimits")
+	user, err := s.IUserService.SetDepositLimits(ctx, userID, limits)
+	tracing.End(span, err)
+	return user, err
+}
+
+// Deposit delegates to the wrapped service within a span.
+func (s *tracedUserService) Deposit(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Deposit")
+	balance, err := s.IUserService.Deposit(ctx, userID, amount)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// DepositWithPromo delegates to the wrapped service within a span.
+func (s *tracedUserService) DepositWithPromo(ctx context.Context, userID *uuid.UUID, amount float64, promoCode string) (*float64, float64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.DepositWithPromo")
+	balance, bonus, err := s.IUserService.DepositWithPromo(ctx, userID, amount, promoCode)
+	tracing.End(span, err)
+	return balance, bonus, err
+}
+
+// Withdraw delegates to the wrapped service within a span.
+func (s *tracedUserService) Withdraw(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Withdraw")
+	balance, err := s.IUserService.Withdraw(ctx, userID, amount)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// ApplySpinResult delegates to the wrapped service within a span.
+func (s *tracedUserService) ApplySpinResult(ctx context.Context, userID *uuid.UUID, bet, win float64) (*float64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ApplySpinResult")
+	balance, err := s.IUserService.ApplySpinResult(ctx, userID, bet, win)
+	tracing.End(span, err)
+	return balance, err
+}
+
+// AwardFreeSpins delegates to the wrapped service within a span.
+func (s *tracedUserService) AwardFreeSpins(ctx context.Context, userID *uuid.UUID, count int) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserService.AwardFreeSpins")
+	freeSpins, err := s.IUserService.AwardFreeSpins(ctx, userID, count)
+	tracing.End(span, err)
+	return freeSpins, err
+}
+
+// UseFreeSpin delegates to the wrapped service within a span.
+func (s *tracedUserService) UseFreeSpin(ctx context.Context, userID *uuid.UUID) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UseFreeSpin")
+	freeSpins, err := s.IUserService.UseFreeSpin(ctx, userID)
+	tracing.End(span, err)
+	return freeSpins, err
+}
+
+// GetBalances delegates to the wrapped service within a span.
+func (s *tracedWalletService) GetBalances(ctx context.Context, userID *uuid.UUID) ([]*models.Balance, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetBalances")
+	balances, err := s.IWalletService.GetBalances(ctx, userID)
+	tracing.End(span, err)
+	return balances, err
+}
+
+// GetBalance delegates to the wrapped service within a span.
+func (s *tracedWalletService) GetBalance(ctx context.Context, userID *uuid.UUID, currency string) (*models.Balance, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetBalance")
+	balance, err := s.IWalletService.GetBalance(ctx, userID, currency)
+	tracing.End(span, err)
+	return balance, err
+}
@@ -2,19 +2,27 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/public-forge/go-gorm-unit-of-work/postgres"
 	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/config"
 	serviceError "github.com/vadymlab/slot-game/internal/error"
 	"github.com/vadymlab/slot-game/internal/interfaces"
 	"github.com/vadymlab/slot-game/internal/models"
 	"golang.org/x/crypto/bcrypt"
+	"time"
 )
 
 // userService implements IUserService, providing business logic for user-related actions
 // such as authentication, registration, and balance management.
 type userService struct {
-	userRepository interfaces.IUserRepository // Repository for managing user data
+	config           *config.SlotConfig           // Slot configuration settings, including the welcome balance
+	userRepository   interfaces.IUserRepository   // Repository for managing user data
+	promoRepository  interfaces.IPromoRepository  // Repository for promo codes and their redemptions
+	ledgerRepository interfaces.ILedgerRepository // Repository for recording balance changes
+	notifier         *EventNotifier               // Publisher of the deposit and withdrawal events
+	now              func() time.Time             // Clock the deposit limits are checked against
 }
 
 // GetByID retrieves a user by their numeric ID.
@@ -35,6 +43,26 @@ func (s *userService) GetByID(ctx context.Context, id uint) (*models.User, error
 	return user, nil
 }
 
+// ListUsers retrieves a page of the users whose login contains the search, ordered as the filter
+// asks. As logins are stored normalized, the search is normalized the same way, which makes it
+// case-insensitive.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - filter: The search and order of the users.
+//   - limit: The maximum number of users to return.
+//   - offset: The number of users to skip.
+//
+// Returns:
+//   - A slice of pointers to User models representing the requested page.
+//   - The total number of users matching the filter.
+//   - An error if the retrieval fails.
+func (s *userService) ListUsers(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int64, error) {
+	normalized := *filter
+	normalized.Search = models.NormalizeLogin(filter.Search)
+	return s.userRepository.GetUsers(ctx, &normalized, limit, offset)
+}
+
 // GetByExternalID retrieves a user by their UUID identifier.
 //
 // Parameters:
@@ -57,8 +85,9 @@ func (s *userService) GetByExternalID(ctx context.Context, id *uuid.UUID) (*mode
 	return user, nil
 }
 
-// Login authenticates a user by verifying the provided login and password.
-// Logs the operation and returns an error if the user does not exist or the password is incorrect.
+// Login authenticates a user by verifying the provided login and password. The login is normalized,
+// so surrounding whitespace and capitalization do not matter. Logs the operation and returns an
+// error if the user does not exist or the password is incorrect.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
@@ -67,10 +96,11 @@ func (s *userService) GetByExternalID(ctx context.Context, id *uuid.UUID) (*mode
 //
 // Returns:
 //   - A pointer to the authenticated User model if login is successful.
-//   - An error if authentication fails or the user is not found.
+//   - ErrAccountFrozen if an admin froze the account, or an error if authentication fails or the
+//     user is not found.
 func (s *userService) Login(ctx context.Context, login, password string) (*models.User, error) {
 	log.FromContext(ctx).Debug("Login")
-	user, err := s.userRepository.GetByLogin(ctx, login)
+	user, err := s.userRepository.GetByLogin(ctx, models.NormalizeLogin(login))
 	if err != nil {
 		return nil, err
 	}
@@ -82,11 +112,17 @@ func (s *userService) Login(ctx context.Context, login, password string) (*model
 		log.FromContext(ctx).Error("Password is incorrect")
 		return nil, serviceError.ErrInvalidPass
 	}
+	if user.Frozen {
+		log.FromContext(ctx).Warn("Account is frozen")
+		return nil, serviceError.ErrAccountFrozen
+	}
 	return user, nil
 }
 
-// Register creates a new user with the specified login and password.
-// Checks if a user with the same login already exists, hashes the password, and logs the operation.
+// Register creates a new user with the specified login and password. The login is stored
+// normalized, trimmed and lowercased. Checks if a user with the same login already exists, hashes
+// the password, and logs the operation. When a welcome balance is configured, it is credited to the
+// new user and recorded in the ledger within the same transaction.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
@@ -98,6 +134,7 @@ func (s *userService) Login(ctx context.Context, login, password string) (*model
 //   - An error if the registration fails or the user already exists.
 func (s *userService) Register(ctx context.Context, login, password string) (*models.User, error) {
 	log.FromContext(ctx).Debug("Register")
+	login = models.NormalizeLogin(login)
 	tr, _ := postgres.GetTransactionContext(ctx)
 	id, err := tr.Begin()
 	if err != nil {
@@ -127,14 +164,287 @@ func (s *userService) Register(ctx context.Context, login, password string) (*mo
 	u, err := s.userRepository.Create(ctx, user)
 	if err != nil {
 		_ = tr.Rollback()
-		log.FromContext(ctx).Error(err)
+		// A concurrent registration may pass the check above and lose the race on the unique constraint
+		if !errors.Is(err, serviceError.ErrUserExists) {
+			log.FromContext(ctx).Error(err)
+		}
 		return nil, err
 	}
+
+	if s.config.WelcomeBalance > 0 {
+		balance, err := s.userRepository.Deposit(ctx, u.ID, s.config.WelcomeBalance)
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+		err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: u.ID, Type: models.LedgerTypeWelcome, Amount: s.config.WelcomeBalance})
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, err
+		}
+		u.Balance = *balance
+	}
 	return u, tr.Commit(id)
 }
 
+// UpdateLogin changes the login of a user within a transaction. The current password must
+// be confirmed, and the new login, stored normalized, must not belong to another user.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The UUID representing the user's external identifier.
+//   - login: The new login identifier.
+//   - password: The user's current password.
+//
+// Returns:
+//   - A pointer to the updated User model.
+//   - ErrUserNotFound if the user does not exist, ErrInvalidPass if the password is incorrect,
+//     ErrUserExists if the login is taken, or another error if the update fails.
+func (s *userService) UpdateLogin(ctx context.Context, userID *uuid.UUID, login, password string) (*models.User, error) {
+	log.FromContext(ctx).Debug("Update login")
+	login = models.NormalizeLogin(login)
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrInvalidPass
+	}
+	if user.Login == login {
+		return user, tr.Commit(id)
+	}
+
+	existUser, err := s.userRepository.GetByLogin(ctx, login)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if existUser != nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserExists
+	}
+	// A concurrent change may pass the check above and lose the race on the unique constraint
+	if err := s.userRepository.UpdateLogin(ctx, user.ID, login); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	user.Login = login
+	return user, tr.Commit(id)
+}
+
+// UpdateSettings changes the play settings of a user within a transaction.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The UUID representing the user's external identifier.
+//   - autoStopWin: The win above which the client is told to stop spinning; nil uses the configured default
+//     and 0 disables the auto-stop.
+//
+// Returns:
+//   - A pointer to the updated User model.
+//   - ErrInvalidAmount if the threshold is negative, ErrUserNotFound if the user does not exist,
+//     or another error if the update fails.
+func (s *userService) UpdateSettings(ctx context.Context, userID *uuid.UUID, autoStopWin *float64) (*models.User, error) {
+	if autoStopWin != nil && *autoStopWin < 0 {
+		return nil, serviceError.ErrInvalidAmount
+	}
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+	if err := s.userRepository.UpdateAutoStopWin(ctx, user.ID, autoStopWin); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	user.AutoStopWin = autoStopWin
+	return user, tr.Commit(id)
+}
+
+// SelfExclude excludes a user from spinning and depositing for the given period within a
+// transaction. The exclusion cannot be reversed early: when the user's running exclusion already
+// ends later, it is kept, so that requesting a shorter period never shortens it.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The UUID representing the user's external identifier.
+//   - period: How long the user is excluded for, starting now.
+//
+// Returns:
+//   - A pointer to the updated User model, carrying the end of the self-exclusion.
+//   - ErrInvalidPeriod if the period is not positive, ErrUserNotFound if the user does not exist,
+//     or another error if the update fails.
+func (s *userService) SelfExclude(ctx context.Context, userID *uuid.UUID, period time.Duration) (*models.User, error) {
+	if period <= 0 {
+		return nil, serviceError.ErrInvalidPeriod
+	}
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+	until := time.Now().Add(period)
+	if err := s.userRepository.ExtendExclusion(ctx, user.ID, until); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user.ExcludedUntil == nil || user.ExcludedUntil.Before(until) {
+		user.ExcludedUntil = &until
+	}
+	return user, tr.Commit(id)
+}
+
+// SetFrozen freezes or unfreezes the account of a user within a transaction. A frozen user can
+// neither log in, spin, deposit, withdraw nor transfer funds until the account is unfrozen.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The UUID representing the user's external identifier.
+//   - frozen: Whether the account is frozen.
+//
+// Returns:
+//   - A pointer to the updated User model.
+//   - ErrUserNotFound if the user does not exist, or another error if the update fails.
+func (s *userService) SetFrozen(ctx context.Context, userID *uuid.UUID, frozen bool) (*models.User, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+	if err := s.userRepository.UpdateFrozen(ctx, user.ID, frozen); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	user.Frozen = frozen
+	return user, tr.Commit(id)
+}
+
+// SetDepositLimits changes the deposit limits of a user within a transaction. Lowering a limit, or
+// setting one where there was none, applies right away. Raising or removing a limit only applies
+// once the configured cooldown has passed, so that a limit cannot be lifted on impulse; until then
+// the tighter of the old and the requested limits stays in effect. A new request replaces any
+// change still waiting out its cooldown.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The UUID representing the user's external identifier.
+//   - limits: The requested deposit limits; a nil limit removes it.
+//
+// Returns:
+//   - A pointer to the updated User model, carrying the current and the pending deposit limits.
+//   - ErrInvalidAmount if a limit is not positive, ErrUserNotFound if the user does not exist,
+//     or another error if the update fails.
+func (s *userService) SetDepositLimits(ctx context.Context, userID *uuid.UUID, limits models.DepositLimits) (*models.User, error) {
+	if (limits.PerTransaction != nil && *limits.PerTransaction <= 0) || (limits.Daily != nil && *limits.Daily <= 0) {
+		return nil, serviceError.ErrInvalidAmount
+	}
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+	now := time.Now()
+	current := user.DepositLimits(now)
+	applied, pending := limits, models.DepositLimits{}
+	var pendingAt *time.Time
+	if cooldown := time.Duration(s.config.DepositLimitCooldown) * time.Hour; cooldown > 0 && limits.Looser(current) {
+		applied, pending = current.Tightest(limits), limits
+		at := now.Add(cooldown)
+		pendingAt = &at
+	}
+	if err := s.userRepository.UpdateDepositLimits(ctx, user.ID, applied, pending, pendingAt); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	user.DepositLimitPerTransaction, user.DepositLimitDaily = applied.PerTransaction, applied.Daily
+	user.PendingDepositLimitPerTransaction, user.PendingDepositLimitDaily = pending.PerTransaction, pending.Daily
+	user.DepositLimitsPendingAt = pendingAt
+	return user, tr.Commit(id)
+}
+
+// checkDepositLimits checks a deposit against the user's deposit limits in effect now: the amount
+// must not exceed the per-transaction limit, and together with the deposits of the day, which starts
+// at midnight in the configured day time zone, it must not exceed the daily limit.
+//
+// Returns:
+//   - ErrDepositLimitExceeded if the deposit exceeds a limit, or an error if the deposits of the
+//     day cannot be summed; otherwise, nil.
+func (s *userService) checkDepositLimits(ctx context.Context, user *models.User, amount float64) error {
+	now := s.now()
+	limits := user.DepositLimits(now)
+	if limits.PerTransaction != nil && amount > *limits.PerTransaction {
+		return serviceError.ErrDepositLimitExceeded
+	}
+	if limits.Daily == nil {
+		return nil
+	}
+	deposited, err := s.ledgerRepository.SumEntries(ctx, user.ID, models.LedgerTypeDeposit, s.config.DayStart(now))
+	if err != nil {
+		return err
+	}
+	if deposited+amount > *limits.Daily {
+		return serviceError.ErrDepositLimitExceeded
+	}
+	return nil
+}
+
 // Deposit increases a user's balance by the specified amount.
 // Verifies the amount is positive, logs the operation, and performs the deposit transaction.
+// Neither the user's deposit limits nor a self-exclusion apply: it credits refunds of voided spins
+// and releases of rejected withdrawals, which return the user's own funds. Deposits made by the user
+// go through DepositWithPromo.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
@@ -165,11 +475,148 @@ func (s *userService) Deposit(ctx context.Context, userID *uuid.UUID, amount flo
 		_ = tr.Rollback()
 		return nil, err
 	}
+	if err := ctx.Err(); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
 	return balance, tr.Commit(id)
 }
 
+// DepositWithPromo deposits funds into the user's wallet and records the deposit in the ledger.
+// If a promo code is provided, it is validated for the user and the resulting bonus is credited
+// and recorded as a separate ledger entry within the same transaction. The deposit must stay within
+// the user's deposit limits; the bonus does not count towards them.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: The UUID representing the user's external identifier.
+//   - amount: The amount to be deposited to the user's balance.
+//   - promoCode: An optional promo code; empty means no bonus.
+//
+// Returns:
+//   - A pointer to the updated balance as a float64.
+//   - The credited bonus amount, or 0 if no promo code was applied.
+//   - ErrAccountFrozen if an admin froze the account, ErrSelfExcluded if the user is self-excluded,
+//     or ErrDepositLimitExceeded if the deposit exceeds the user's deposit limits.
+//   - An error if the deposit fails, the amount is invalid or the promo code cannot be applied.
+func (s *userService) DepositWithPromo(ctx context.Context, userID *uuid.UUID, amount float64, promoCode string) (*float64, float64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+	if amount <= 0 {
+		_ = tr.Rollback()
+		return nil, 0, serviceError.ErrInvalidAmount
+	}
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, 0, serviceError.ErrUserNotFound
+	}
+	if user.Frozen {
+		_ = tr.Rollback()
+		return nil, 0, serviceError.ErrAccountFrozen
+	}
+	if user.SelfExcluded(time.Now()) {
+		_ = tr.Rollback()
+		return nil, 0, serviceError.ErrSelfExcluded
+	}
+	if err := s.checkDepositLimits(ctx, user, amount); err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+
+	var promo *models.PromoCode
+	if promoCode != "" {
+		promo, err = s.getApplicablePromo(ctx, promoCode, user.ID, amount)
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, 0, err
+		}
+	}
+
+	balance, err := s.userRepository.Deposit(ctx, user.ID, amount)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: user.ID, Type: models.LedgerTypeDeposit, Amount: amount})
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+
+	var bonus float64
+	if promo != nil {
+		bonus = promo.Bonus(amount)
+		balance, err = s.userRepository.Deposit(ctx, user.ID, bonus)
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, 0, err
+		}
+		err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: user.ID, Type: models.LedgerTypeBonus, Amount: bonus, Reference: promo.Code})
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, 0, err
+		}
+		err = s.promoRepository.AddRedemption(ctx, &models.PromoRedemption{PromoCodeID: promo.ID, UserID: user.ID, BonusAmount: bonus})
+		if err != nil {
+			_ = tr.Rollback()
+			return nil, 0, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		_ = tr.Rollback()
+		return nil, 0, err
+	}
+	if err := tr.Commit(id); err != nil {
+		return nil, 0, err
+	}
+	// Publish only once the deposit is committed
+	s.notifier.NotifyDeposit(ctx, userID, amount)
+	return balance, bonus, nil
+}
+
+// getApplicablePromo loads a promo code and checks that it can be applied to the user's deposit:
+// the code must exist, must not be expired, the deposit must meet its minimum and the user must
+// not have exhausted its per-user limit.
+func (s *userService) getApplicablePromo(ctx context.Context, code string, userID uint, amount float64) (*models.PromoCode, error) {
+	promo, err := s.promoRepository.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if promo == nil {
+		return nil, serviceError.ErrPromoNotFound
+	}
+	if promo.Expired(time.Now()) {
+		return nil, serviceError.ErrPromoExpired
+	}
+	if amount < promo.MinDeposit {
+		return nil, serviceError.ErrPromoMinDeposit
+	}
+	if promo.PerUserLimit > 0 {
+		used, err := s.promoRepository.CountRedemptions(ctx, promo.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= promo.PerUserLimit {
+			return nil, serviceError.ErrPromoLimitReached
+		}
+	}
+	return promo, nil
+}
+
 // Withdraw decreases a user's balance by the specified amount.
-// Checks if the user has sufficient funds, logs the operation, and performs the withdrawal transaction.
+// Checks that the account is old enough to withdraw, and debits the balance with an update that
+// checks the balance covers the amount, so that concurrent withdrawals cannot take it below zero.
+// Without withdrawal approval the withdrawal is paid out
+// right away and published once committed; otherwise the funds are only held, and the withdrawal
+// is published when an admin approves it.
 //
 // Parameters:
 //   - ctx: Context for managing request-scoped values and cancellation signals.
@@ -178,7 +625,11 @@ func (s *userService) Deposit(ctx context.Context, userID *uuid.UUID, amount flo
 //
 // Returns:
 //   - A pointer to the updated balance as a float64.
-//   - An error if the withdrawal fails or there are insufficient funds.
+//   - ErrAccountFrozen if an admin froze the account.
+//   - ErrWithdrawalNotAllowedYet if the account is younger than the minimum withdrawal account age.
+//   - ErrInsufficientFunds if the balance does not cover the amount; when shortfall details are
+//     enabled, it is a FundsShortfall carrying the balance and the missing amount.
+//   - An error if the withdrawal fails.
 func (s *userService) Withdraw(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
 	tr, _ := postgres.GetTransactionContext(ctx)
 	id, err := tr.Begin()
@@ -190,28 +641,267 @@ func (s *userService) Withdraw(ctx context.Context, userID *uuid.UUID, amount fl
 		_ = tr.Rollback()
 		return nil, err
 	}
-	if user.Balance < amount {
+	if user.Frozen {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrAccountFrozen
+	}
+	if minAge := time.Duration(s.config.MinWithdrawalAge) * time.Hour; user.AccountAge(time.Now()) < minAge {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrWithdrawalNotAllowedYet
+	}
+	wallet, err := s.userRepository.Debit(ctx, user.ID, amount)
+	if err != nil {
+		if errors.Is(err, serviceError.ErrInsufficientFunds) && s.config.ShortfallDetails {
+			if current, getErr := s.userRepository.GetByExternalID(ctx, userID); getErr == nil && current != nil {
+				err = serviceError.NewFundsShortfall(current.Balance, amount)
+			}
+		}
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if err := tr.Commit(id); err != nil {
+		return nil, err
+	}
+	if !s.config.WithdrawalApproval {
+		s.notifier.NotifyWithdrawal(ctx, userID, amount)
+	}
+	return wallet, nil
+}
+
+// Transfer moves funds from the sender's balance to the recipient's balance. The debit and the
+// credit are recorded as two ledger entries referencing the other user, and are committed together,
+// so that the recipient is never credited without the sender being debited. Both rows are locked in
+// ID order before the balances change, so that transfers in opposite directions cannot deadlock,
+// and the debit checks the sender's balance in the same update, so that concurrent transfers
+// cannot take it below zero.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - senderID: The UUID representing the sender's external identifier.
+//   - recipientID: The UUID representing the recipient's external identifier.
+//   - amount: The amount to be transferred.
+//
+// Returns:
+//   - A pointer to the sender's updated balance as a float64.
+//   - ErrInvalidAmount if the amount is not positive, ErrSelfTransfer if the sender is the recipient,
+//     ErrUserNotFound if the sender does not exist, ErrRecipientNotFound if the recipient does not exist,
+//     ErrAccountFrozen if an admin froze the sender's account, ErrRecipientUnavailable if the
+//     recipient's account is frozen or self-excluded.
+//   - ErrInsufficientFunds if the sender's balance does not cover the amount; when shortfall details
+//     are enabled, it is a FundsShortfall carrying the balance and the missing amount.
+//   - An error if the transfer fails.
+func (s *userService) Transfer(ctx context.Context, senderID, recipientID *uuid.UUID, amount float64) (*float64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrInvalidAmount
+	}
+	if *senderID == *recipientID {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrSelfTransfer
+	}
+	sender, err := s.userRepository.GetByExternalID(ctx, senderID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if sender == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+	if sender.Frozen {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrAccountFrozen
+	}
+	recipient, err := s.userRepository.GetByExternalID(ctx, recipientID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if recipient == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrRecipientNotFound
+	}
+	if recipient.Frozen || recipient.SelfExcluded(time.Now()) {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrRecipientUnavailable
+	}
+	if err := s.userRepository.LockUsers(ctx, sender.ID, recipient.ID); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+
+	balance, err := s.userRepository.Debit(ctx, sender.ID, amount)
+	if err != nil {
+		if errors.Is(err, serviceError.ErrInsufficientFunds) && s.config.ShortfallDetails {
+			// The row is locked, so the balance read now is the one the debit was refused for
+			if locked, getErr := s.userRepository.GetByExternalID(ctx, senderID); getErr == nil && locked != nil {
+				err = serviceError.NewFundsShortfall(locked.Balance, amount)
+			}
+		}
+		_ = tr.Rollback()
+		return nil, err
+	}
+	err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: sender.ID, Type: models.LedgerTypeTransferOut, Amount: -amount, Reference: recipientID.String()})
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if _, err := s.userRepository.Deposit(ctx, recipient.ID, amount); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: recipient.ID, Type: models.LedgerTypeTransferIn, Amount: amount, Reference: senderID.String()})
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return balance, tr.Commit(id)
+}
+
+// ApplySpinResult settles a spin of a user: the bet is withdrawn and the win credited with a single
+// balance update, which fails if the balance does not cover the bet.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The UUID representing the user's external identifier.
+//   - bet: The amount bet on the spin.
+//   - win: The amount won by the spin.
+//
+// Returns:
+//   - A pointer to the updated balance as a float64.
+//   - ErrUserNotFound if the user does not exist, ErrInsufficientFunds if the balance does not cover
+//     the bet, or another error if the update fails.
+func (s *userService) ApplySpinResult(ctx context.Context, userID *uuid.UUID, bet, win float64) (*float64, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
 		_ = tr.Rollback()
-		return nil, serviceError.ErrInsufficientFunds
+		return nil, serviceError.ErrUserNotFound
 	}
-	wallet, err := s.userRepository.Withdraw(ctx, user.ID, amount)
+	balance, err := s.userRepository.ApplySpinResult(ctx, user.ID, bet, win)
 	if err != nil {
 		_ = tr.Rollback()
 		return nil, err
 	}
-	return wallet, tr.Commit(id)
+	if err := ctx.Err(); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	return balance, tr.Commit(id)
+}
+
+// AwardFreeSpins adds free spins to the free spins of a user.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The UUID representing the user's external identifier.
+//   - count: The number of free spins awarded.
+//
+// Returns:
+//   - The free spins of the user after the award.
+//   - ErrUserNotFound if the user does not exist, or another error if the update fails.
+func (s *userService) AwardFreeSpins(ctx context.Context, userID *uuid.UUID, count int) (int, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return 0, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return 0, serviceError.ErrUserNotFound
+	}
+	freeSpins, err := s.userRepository.AddFreeSpins(ctx, user.ID, count)
+	if err != nil {
+		_ = tr.Rollback()
+		return 0, err
+	}
+	return freeSpins, tr.Commit(id)
+}
+
+// UseFreeSpin takes one free spin from the free spins of a user.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userId: The UUID representing the user's external identifier.
+//
+// Returns:
+//   - The free spins of the user left after the free spin.
+//   - ErrUserNotFound if the user does not exist, ErrNoFreeSpins if the user has no free spins left,
+//     or another error if the update fails.
+func (s *userService) UseFreeSpin(ctx context.Context, userID *uuid.UUID) (int, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return 0, err
+	}
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return 0, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return 0, serviceError.ErrUserNotFound
+	}
+	freeSpins, err := s.userRepository.UseFreeSpin(ctx, user.ID)
+	if err != nil {
+		_ = tr.Rollback()
+		return 0, err
+	}
+	return freeSpins, tr.Commit(id)
 }
 
-// NewUserService creates and returns a new instance of userService with the given user repository.
+// NewUserService creates and returns a new instance of userService with the given repositories.
 //
 // Parameters:
+//   - config: SlotConfig containing the welcome balance of new users.
 //   - userRepository: An implementation of IUserRepository for managing user data.
+//   - promoRepository: An implementation of IPromoRepository for promo code lookups and redemptions.
+//   - ledgerRepository: An implementation of ILedgerRepository for recording balance changes.
+//   - notifier: EventNotifier publishing the deposits and withdrawals; may be nil.
 //
 // Returns:
 //   - A new instance of userService implementing IUserService.
-func NewUserService(userRepository interfaces.IUserRepository) interfaces.IUserService {
+func NewUserService(
+	config *config.SlotConfig,
+	userRepository interfaces.IUserRepository,
+	promoRepository interfaces.IPromoRepository,
+	ledgerRepository interfaces.ILedgerRepository,
+	notifier *EventNotifier,
+) interfaces.IUserService {
 	return &userService{
-		userRepository: userRepository,
+		config:           config,
+		userRepository:   userRepository,
+		promoRepository:  promoRepository,
+		ledgerRepository: ledgerRepository,
+		notifier:         notifier,
+		now:              time.Now,
 	}
 }
 
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/database"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/redis"
+)
+
+// cachedUserService decorates an IUserService with a read-through cache for
+// GetByExternalID. Balance-changing operations invalidate the cached entry.
+// Cache failures never fail a request: the decorator falls back to the wrapped service.
+type cachedUserService struct {
+	interfaces.IUserService                       // Wrapped service handling all non-cached operations
+	cache                   interfaces.IUserCache // Cache for user records keyed by external id
+}
+
+// GetByExternalID returns the cached user when present, otherwise loads the user
+// from the wrapped service and populates the cache.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - id: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - A pointer to a User model if found.
+//   - An error if the user is not found or the retrieval fails.
+func (s *cachedUserService) GetByExternalID(ctx context.Context, id *uuid.UUID) (*models.User, error) {
+	user, err := s.cache.Get(ctx, id)
+	if err != nil {
+		log.FromContext(ctx).Warnf("user cache read failed, falling back to database: %v", err)
+	} else if user != nil {
+		log.FromContext(ctx).Debug("Get user by external id from cache")
+		return user, nil
+	}
+
+	user, err = s.IUserService.GetByExternalID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// A user read from the replica may lag behind the primary, so it is not cached
+	if database.IsReadOnly(ctx) {
+		return user, nil
+	}
+	if err := s.cache.Set(ctx, user); err != nil {
+		log.FromContext(ctx).Warnf("user cache write failed: %v", err)
+	}
+	return user, nil
+}
+
+// UpdateLogin delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) UpdateLogin(ctx context.Context, userID *uuid.UUID, login, password string) (*models.User, error) {
+	user, err := s.IUserService.UpdateLogin(ctx, userID, login, password)
+	s.invalidate(ctx, userID)
+	return user, err
+}
+
+// UpdateSettings delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) UpdateSettings(ctx context.Context, userID *uuid.UUID, autoStopWin *float64) (*models.User, error) {
+	user, err := s.IUserService.UpdateSettings(ctx, userID, autoStopWin)
+	s.invalidate(ctx, userID)
+	return user, err
+}
+
+// SelfExclude delegates to the wrapped service and invalidates the cached user, so that the
+// self-exclusion applies to the user's very next spin.
+func (s *cachedUserService) SelfExclude(ctx context.Context, userID *uuid.UUID, period time.Duration) (*models.User, error) {
+	user, err := s.IUserService.SelfExclude(ctx, userID, period)
+	s.invalidate(ctx, userID)
+	return user, err
+}
+
+// SetFrozen delegates to the wrapped service and invalidates the cached user, so that freezing
+// the account blocks the user's very next spin.
+func (s *cachedUserService) SetFrozen(ctx context.Context, userID *uuid.UUID, frozen bool) (*models.User, error) {
+	user, err := s.IUserService.SetFrozen(ctx, userID, frozen)
+	s.invalidate(ctx, userID)
+	return user, err
+}
+
+// SetDepositLimits delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) SetDepositLimits(ctx context.Context, userID *uuid.UUID, limits models.DepositLimits) (*models.User, error) {
+	user, err := s.IUserService.SetDepositLimits(ctx, userID, limits)
+	s.invalidate(ctx, userID)
+	return user, err
+}
+
+// Deposit delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) Deposit(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
+	balance, err := s.IUserService.Deposit(ctx, userID, amount)
+	s.invalidate(ctx, userID)
+	return balance, err
+}
+
+// DepositWithPromo delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) DepositWithPromo(ctx context.Context, userID *uuid.UUID, amount float64, promoCode string) (*float64, float64, error) {
+	balance, bonus, err := s.IUserService.DepositWithPromo(ctx, userID, amount, promoCode)
+	s.invalidate(ctx, userID)
+	return balance, bonus, err
+}
+
+// Withdraw delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) Withdraw(ctx context.Context, userID *uuid.UUID, amount float64) (*float64, error) {
+	balance, err := s.IUserService.Withdraw(ctx, userID, amount)
+	s.invalidate(ctx, userID)
+	return balance, err
+}
+
+// Transfer delegates to the wrapped service and invalidates the cached sender and recipient.
+func (s *cachedUserService) Transfer(ctx context.Context, senderID, recipientID *uuid.UUID, amount float64) (*float64, error) {
+	balance, err := s.IUserService.Transfer(ctx, senderID, recipientID, amount)
+	s.invalidate(ctx, senderID)
+	s.invalidate(ctx, recipientID)
+	return balance, err
+}
+
+// ApplySpinResult delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) ApplySpinResult(ctx context.Context, userID *uuid.UUID, bet, win float64) (*float64, error) {
+	balance, err := s.IUserService.ApplySpinResult(ctx, userID, bet, win)
+	s.invalidate(ctx, userID)
+	return balance, err
+}
+
+// AwardFreeSpins delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) AwardFreeSpins(ctx context.Context, userID *uuid.UUID, count int) (int, error) {
+	freeSpins, err := s.IUserService.AwardFreeSpins(ctx, userID, count)
+	s.invalidate(ctx, userID)
+	return freeSpins, err
+}
+
+// UseFreeSpin delegates to the wrapped service and invalidates the cached user.
+func (s *cachedUserService) UseFreeSpin(ctx context.Context, userID *uuid.UUID) (int, error) {
+	freeSpins, err := s.IUserService.UseFreeSpin(ctx, userID)
+	s.invalidate(ctx, userID)
+	return freeSpins, err
+}
+
+// invalidate removes the user from the cache, logging but otherwise ignoring failures. Within a
+// tracked transaction, the change is not visible to other requests until the outermost transaction
+// ends, and a concurrent read may cache the row as it was before meanwhile, so the user is removed
+// again once it ends.
+func (s *cachedUserService) invalidate(ctx context.Context, userID *uuid.UUID) {
+	s.delete(ctx, userID)
+	database.AfterTransaction(ctx, func() {
+		s.delete(context.WithoutCancel(ctx), userID)
+	})
+}
+
+// delete removes the user from the cache, logging but otherwise ignoring failures.
+func (s *cachedUserService) delete(ctx context.Context, userID *uuid.UUID) {
+	if err := s.cache.Delete(ctx, userID); err != nil {
+		log.FromContext(ctx).Warnf("user cache invalidation failed: %v", err)
+	}
+}
+
+// NewCachedUserService wraps the given IUserService with a Redis-backed cache
+// when caching is enabled in the configuration; otherwise it returns the service unchanged.
+//
+// Parameters:
+//   - cfg: Redis configuration, including the cache toggle.
+//   - cache: Cache implementation used to store users.
+//   - userService: The IUserService to decorate.
+//
+// Returns:
+//   - An IUserService, decorated with caching when enabled.
+func NewCachedUserService(cfg *redis.Config, cache interfaces.IUserCache, userService interfaces.IUserService) interfaces.IUserService {
+	if !cfg.UserCacheEnabled {
+		return userService
+	}
+	return &cachedUserService{
+		IUserService: userService,
+		cache:        cache,
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/config"
+	"github.com/vadymlab/slot-game/internal/database"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/redis"
+)
+
+func TestCachedUserService_CacheHit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockCache := mocks.NewMockIUserCache(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	cached := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Balance: 42}
+
+	// The wrapped service must not be called on a cache hit
+	mockCache.EXPECT().Get(ctx, &userID).Return(cached, nil)
+
+	s := NewCachedUserService(&redis.Config{UserCacheEnabled: true}, mockCache, mockUserService)
+	user, err := s.GetByExternalID(ctx, &userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cached, user)
+}
+
+func TestCachedUserService_CacheMissPopulatesCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockCache := mocks.NewMockIUserCache(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	dbUser := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Balance: 42}
+
+	mockCache.EXPECT().Get(ctx, &userID).Return(nil, nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(dbUser, nil)
+	mockCache.EXPECT().Set(ctx, dbUser).Return(nil)
+
+	s := NewCachedUserService(&redis.Config{UserCacheEnabled: true}, mockCache, mockUserService)
+	user, err := s.GetByExternalID(ctx, &userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dbUser, user)
+}
+
+func TestCachedUserService_ReadOnlyMissNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockCache := mocks.NewMockIUserCache(ctrl)
+	ctx := database.WithReadOnly(context.Background())
+	userID := uuid.New()
+	replicaUser := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Balance: 42}
+
+	// The user may come from a lagging replica, so Set fails the test
+	mockCache.EXPECT().Get(ctx, &userID).Return(nil, nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(replicaUser, nil)
+
+	s := NewCachedUserService(&redis.Config{UserCacheEnabled: true}, mockCache, mockUserService)
+	user, err := s.GetByExternalID(ctx, &userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, replicaUser, user)
+}
+
+func TestCachedUserService_RedisUnavailableFailsOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockCache := mocks.NewMockIUserCache(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	dbUser := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID}
+	redisErr := errors.New("dial tcp: connection refused")
+
+	mockCache.EXPECT().Get(ctx, &userID).Return(nil, redisErr)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(dbUser, nil)
+	mockCache.EXPECT().Set(ctx, dbUser).Return(redisErr)
+
+	s := NewCachedUserService(&redis.Config{UserCacheEnabled: true}, mockCache, mockUserService)
+	user, err := s.GetByExternalID(ctx, &userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dbUser, user)
+}
+
+func TestCachedUserService_BalanceChangesInvalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockCache := mocks.NewMockIUserCache(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	balance := 100.0
+
+	mockUserService.EXPECT().Deposit(ctx, &userID, 50.0).Return(&balance, nil)
+	mockUserService.EXPECT().Withdraw(ctx, &userID, 25.0).Return(&balance, nil)
+	mockUserService.EXPECT().DepositWithPromo(ctx, &userID, 100.0, "WELCOME").Return(&balance, 10.0, nil)
+	mockCache.EXPECT().Delete(ctx, &userID).Return(nil).Times(3)
+
+	s := NewCachedUserService(&redis.Config{UserCacheEnabled: true}, mockCache, mockUserService)
+	_, err := s.Deposit(ctx, &userID, 50)
+	assert.NoError(t, err)
+	_, err = s.Withdraw(ctx, &userID, 25)
+	assert.NoError(t, err)
+	_, _, err = s.DepositWithPromo(ctx, &userID, 100, "WELCOME")
+	assert.NoError(t, err)
+}
+
+func TestCachedUserService_SpinInvalidatesAgainAfterCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockCache := mocks.NewMockIUserCache(ctrl)
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+	userID := uuid.New()
+	balance := 90.0
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockCache.EXPECT().Get(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Balance: 100}, nil)
+	mockSlotRepo.EXPECT().AddSpin(ctx, gomock.Any()).Return(nil)
+	// A read between the settlement and the commit may cache the row as it was before the spin,
+	// so the user is removed from the cache again once the spin has committed
+	gomock.InOrder(
+		mockUserService.EXPECT().ApplySpinResult(ctx, &userID, 10.0, gomock.Any()).Return(&balance, nil),
+		mockCache.EXPECT().Delete(ctx, &userID).Return(nil),
+		mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil),
+		mockCache.EXPECT().Delete(gomock.Any(), &userID).Return(nil),
+	)
+
+	userService := NewCachedUserService(&redis.Config{UserCacheEnabled: true}, mockCache, mockUserService)
+	s := NewSlotService(&config.SlotConfig{}, userService, mockSlotRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := s.RetrySpin(ctx, &userID, "", 10)
+	assert.NoError(t, err)
+}
+
+func TestCachedUserService_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+
+	s := NewCachedUserService(&redis.Config{UserCacheEnabled: false}, nil, mockUserService)
+
+	assert.Equal(t, mockUserService, s)
+}
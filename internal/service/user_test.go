@@ -8,11 +8,14 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/public-forge/go-gorm-unit-of-work/postgres"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/config"
 	serviceError "github.com/vadymlab/slot-game/internal/error"
 	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
 	"github.com/vadymlab/slot-game/internal/models"
 	"golang.org/x/crypto/bcrypt"
 	"testing"
+	"time"
 )
 
 func TestGetById_Success(t *testing.T) {
@@ -26,10 +29,10 @@ func TestGetById_Success(t *testing.T) {
 	expectedUser := &models.User{Model: gorm.Model{ID: userID}}
 
 	// Expectations
-	mockUserRepo.EXPECT().GetById(ctx, userID).Return(expectedUser, nil)
+	mockUserRepo.EXPECT().GetByID(ctx, userID).Return(expectedUser, nil)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.GetByID(ctx, userID)
@@ -50,10 +53,10 @@ func TestGetById_UserNotFound(t *testing.T) {
 	expectedErr := errors.New("user not found")
 
 	// Expectations
-	mockUserRepo.EXPECT().GetById(ctx, userID).Return(nil, expectedErr)
+	mockUserRepo.EXPECT().GetByID(ctx, userID).Return(nil, expectedErr)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.GetByID(ctx, userID)
@@ -74,10 +77,10 @@ func TestGetById_RepositoryError(t *testing.T) {
 	expectedErr := errors.New("repository error")
 
 	// Expectations
-	mockUserRepo.EXPECT().GetById(ctx, userID).Return(nil, expectedErr)
+	mockUserRepo.EXPECT().GetByID(ctx, userID).Return(nil, expectedErr)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.GetByID(ctx, userID)
@@ -98,10 +101,10 @@ func TestGetById_EmptyUser(t *testing.T) {
 	emptyUser := &models.User{} // Empty user struct
 
 	// Expectations
-	mockUserRepo.EXPECT().GetById(ctx, userID).Return(emptyUser, nil)
+	mockUserRepo.EXPECT().GetByID(ctx, userID).Return(emptyUser, nil)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.GetByID(ctx, userID)
@@ -112,6 +115,32 @@ func TestGetById_EmptyUser(t *testing.T) {
 	assert.Equal(t, uint(0), user.ID) // Проверка, что ID равен 0
 }
 
+func TestListUsers_NormalizesSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Arrange
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	ctx := context.Background()
+	users := []*models.User{{Login: "bob@example.com"}}
+
+	// Expectations: logins are stored normalized, so the search is normalized the same way
+	mockUserRepo.EXPECT().GetUsers(ctx, &models.UserFilter{Search: "bob", SortBy: models.UserSortBalance}, 10, 0).Return(users, int64(1), nil)
+
+	// Instantiate the service
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	filter := &models.UserFilter{Search: "  BoB ", SortBy: models.UserSortBalance}
+	listed, total, err := service.ListUsers(ctx, filter, 10, 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, users, listed)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "  BoB ", filter.Search, "the caller's filter is left unchanged")
+}
+
 func TestGetByExternalId_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -123,10 +152,10 @@ func TestGetByExternalId_Success(t *testing.T) {
 	expectedUser := &models.User{ExternalID: &externalID}
 
 	// Expectations
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &externalID).Return(expectedUser, nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &externalID).Return(expectedUser, nil)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.GetByExternalID(ctx, &externalID)
@@ -146,10 +175,10 @@ func TestGetByExternalId_UserNotFound(t *testing.T) {
 	externalID := uuid.New()
 
 	// Expectations
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &externalID).Return(nil, nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &externalID).Return(nil, nil)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.GetByExternalID(ctx, &externalID)
@@ -170,10 +199,10 @@ func TestGetByExternalId_RepositoryError(t *testing.T) {
 	expectedError := errors.New("repository error")
 
 	// Expectations
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &externalID).Return(nil, expectedError)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &externalID).Return(nil, expectedError)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.GetByExternalID(ctx, &externalID)
@@ -199,7 +228,7 @@ func TestLogin_Success(t *testing.T) {
 	mockUserRepo.EXPECT().GetByLogin(ctx, login).Return(expectedUser, nil)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.Login(ctx, login, password)
@@ -209,6 +238,45 @@ func TestLogin_Success(t *testing.T) {
 	assert.Equal(t, expectedUser, user)
 }
 
+func TestRegisterAndLogin_NormalizesLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	password := "password123"
+
+	// Arrange: the account is looked up and stored trimmed and lowercased
+	var stored *models.User
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserRepo.EXPECT().GetByLogin(ctx, "user@example.com").Return(nil, nil)
+	mockUserRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, user *models.User) (*models.User, error) {
+		stored = user
+		return user, nil
+	})
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	registered, err := service.Register(ctx, " User@Example.com ", password)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", registered.Login)
+
+	// Any spelling of the login finds the stored account
+	for _, login := range []string{" User@Example.com ", "user@example.com", "USER@EXAMPLE.COM\t"} {
+		mockUserRepo.EXPECT().GetByLogin(ctx, "user@example.com").Return(stored, nil)
+
+		user, err := service.Login(ctx, login, password)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stored, user)
+	}
+}
+
 func TestLogin_UserNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -223,7 +291,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockUserRepo.EXPECT().GetByLogin(ctx, login).Return(nil, nil)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.Login(ctx, login, password)
@@ -248,7 +316,7 @@ func TestLogin_RepositoryError(t *testing.T) {
 	mockUserRepo.EXPECT().GetByLogin(ctx, login).Return(nil, expectedError)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.Login(ctx, login, password)
@@ -275,7 +343,7 @@ func TestLogin_IncorrectPassword(t *testing.T) {
 	mockUserRepo.EXPECT().GetByLogin(ctx, login).Return(expectedUser, nil)
 
 	// Instantiate the service
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.Login(ctx, login, wrongPassword)
@@ -302,7 +370,7 @@ func TestRegister_Success(t *testing.T) {
 	// Using AssignableToTypeOf to ignore the specific password hash value
 	mockUserRepo.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&models.User{Login: login})).Return(&models.User{Login: login}, nil)
 
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.Register(ctx, login, password)
@@ -312,6 +380,36 @@ func TestRegister_Success(t *testing.T) {
 	assert.Equal(t, login, user.Login)
 }
 
+func TestRegister_CreditsWelcomeBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	login := "newuser"
+	balance := 50.0
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserRepo.EXPECT().GetByLogin(ctx, login).Return(nil, nil)
+	mockUserRepo.EXPECT().Create(ctx, gomock.Any()).Return(&models.User{Model: gorm.Model{ID: 1}, Login: login}, nil)
+	mockUserRepo.EXPECT().Deposit(ctx, uint(1), 50.0).Return(&balance, nil)
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeWelcome, Amount: 50}).Return(nil)
+
+	service := NewUserService(&config.SlotConfig{WelcomeBalance: 50}, mockUserRepo, nil, mockLedgerRepo, nil)
+
+	// Act
+	user, err := service.Register(ctx, login, "password123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 50.0, user.Balance)
+}
+
 func TestRegister_UserExists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -329,7 +427,35 @@ func TestRegister_UserExists(t *testing.T) {
 	mockTxContext.EXPECT().Rollback().Return(nil)
 	mockUserRepo.EXPECT().GetByLogin(ctx, login).Return(existingUser, nil)
 
-	service := NewUserService(mockUserRepo)
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	user, err := service.Register(ctx, login, password)
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrUserExists)
+	assert.Nil(t, user)
+}
+
+func TestRegister_ConcurrentDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	login := "raceuser"
+	password := "password123"
+
+	// The existence check passes, but a concurrent registration wins the unique constraint
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByLogin(ctx, login).Return(nil, nil)
+	mockUserRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil, serviceError.ErrUserExists)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
 
 	// Act
 	user, err := service.Register(ctx, login, password)
@@ -353,7 +479,7 @@ func TestDeposit_Success(t *testing.T) {
 	expectedBalance := initialBalance + amount
 
 	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &userID).Return(&models.User{
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
 		Model: gorm.Model{ID: 1},
 	}, nil)
 	mockUserRepo.EXPECT().Deposit(ctx, uint(1), amount).Return(&expectedBalance, nil)
@@ -403,7 +529,7 @@ func TestDeposit_UserNotFound(t *testing.T) {
 	amount := 100.0
 
 	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &userID).Return(nil, serviceError.ErrUserNotFound)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(nil, serviceError.ErrUserNotFound)
 	mockTxContext.EXPECT().Rollback().Return(nil)
 
 	service := userService{
@@ -427,7 +553,7 @@ func TestDeposit_DepositError(t *testing.T) {
 	amount := 100.0
 
 	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &userID).Return(&models.User{
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
 		Model: gorm.Model{ID: 1},
 	}, nil)
 	mockUserRepo.EXPECT().Deposit(ctx, uint(1), amount).Return(nil, errors.New("deposit error"))
@@ -456,7 +582,7 @@ func TestDeposit_CommitError(t *testing.T) {
 	expectedBalance := initialBalance + amount
 
 	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &userID).Return(&models.User{
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
 		Model: gorm.Model{ID: 1},
 	}, nil)
 	mockUserRepo.EXPECT().Deposit(ctx, uint(1), amount).Return(&expectedBalance, nil)
@@ -493,10 +619,11 @@ func TestWithdraw_Success(t *testing.T) {
 	expectedBalance := user.Balance - amount // Calculate expected balance
 
 	// Set up expectations for repository methods
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &userID).Return(user, nil).Times(1)
-	mockUserRepo.EXPECT().Withdraw(ctx, user.ID, amount).Return(&expectedBalance, nil).Times(1)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil).Times(1)
+	mockUserRepo.EXPECT().Debit(ctx, user.ID, amount).Return(&expectedBalance, nil).Times(1)
 
 	service := userService{
+		config:         &config.SlotConfig{},
 		userRepository: mockUserRepo,
 	}
 
@@ -531,10 +658,12 @@ func TestWithdraw_InsufficientFunds(t *testing.T) {
 		Balance: 100.0,
 	}
 
-	// Set up expectations for repository methods
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &userID).Return(user, nil)
+	// Set up expectations for repository methods; the guarded debit refuses the amount
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	mockUserRepo.EXPECT().Debit(ctx, user.ID, amount).Return(nil, serviceError.ErrInsufficientFunds)
 
 	service := userService{
+		config:         &config.SlotConfig{},
 		userRepository: mockUserRepo,
 	}
 
@@ -544,6 +673,76 @@ func TestWithdraw_InsufficientFunds(t *testing.T) {
 	// Verify results
 	assert.Nil(t, wallet)
 	assert.ErrorIs(t, err, serviceError.ErrInsufficientFunds)
+	var shortfall *serviceError.FundsShortfall
+	assert.False(t, errors.As(err, &shortfall), "shortfall details are disabled")
+}
+
+func TestWithdraw_InsufficientFundsShortfall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	userID := uuid.New()
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
+		Model:   gorm.Model{ID: 1},
+		Balance: 200.0,
+	}, nil)
+	mockUserRepo.EXPECT().Debit(ctx, uint(1), 150.0).Return(nil, serviceError.ErrInsufficientFunds)
+	// The shortfall is computed from the balance the debit was refused for, not the one read before
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
+		Model:   gorm.Model{ID: 1},
+		Balance: 100.0,
+	}, nil)
+
+	service := userService{
+		config:         &config.SlotConfig{ShortfallDetails: true},
+		userRepository: mockUserRepo,
+	}
+
+	wallet, err := service.Withdraw(ctx, &userID, 150.0)
+
+	assert.Nil(t, wallet)
+	assert.ErrorIs(t, err, serviceError.ErrInsufficientFunds)
+	var shortfall *serviceError.FundsShortfall
+	require.True(t, errors.As(err, &shortfall))
+	assert.Equal(t, 100.0, shortfall.Balance)
+	assert.Equal(t, 50.0, shortfall.Shortfall)
+}
+
+// TestWithdraw_BalanceCheckedByDebit checks that the balance read before the debit is not trusted:
+// a concurrent withdrawal may have spent it since, so a debit refused by its balance guard is
+// reported as insufficient funds.
+func TestWithdraw_BalanceCheckedByDebit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	userID := uuid.New()
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{
+		Model:   gorm.Model{ID: 1},
+		Balance: 100.0,
+	}, nil)
+	mockUserRepo.EXPECT().Debit(ctx, uint(1), 80.0).Return(nil, serviceError.ErrInsufficientFunds)
+
+	service := userService{
+		config:         &config.SlotConfig{},
+		userRepository: mockUserRepo,
+	}
+
+	wallet, err := service.Withdraw(ctx, &userID, 80.0)
+
+	assert.Nil(t, wallet)
+	assert.ErrorIs(t, err, serviceError.ErrInsufficientFunds)
 }
 
 func TestWithdraw_ErrorInWithdrawRepository(t *testing.T) {
@@ -570,10 +769,11 @@ func TestWithdraw_ErrorInWithdrawRepository(t *testing.T) {
 
 	// Set up expectations for repository methods
 	expectedError := errors.New("repository error")
-	mockUserRepo.EXPECT().GetByExternalId(ctx, &userID).Return(user, nil)
-	mockUserRepo.EXPECT().Withdraw(ctx, user.ID, amount).Return(nil, expectedError)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	mockUserRepo.EXPECT().Debit(ctx, user.ID, amount).Return(nil, expectedError)
 
 	service := userService{
+		config:         &config.SlotConfig{},
 		userRepository: mockUserRepo,
 	}
 
@@ -584,3 +784,864 @@ func TestWithdraw_ErrorInWithdrawRepository(t *testing.T) {
 	assert.Nil(t, wallet)
 	assert.ErrorIs(t, err, expectedError)
 }
+
+func TestWithdraw_MinAccountAge(t *testing.T) {
+	testCases := []struct {
+		name    string
+		age     time.Duration
+		wantErr error
+	}{
+		{"FreshAccountBlocked", time.Hour, serviceError.ErrWithdrawalNotAllowedYet},
+		{"OldAccountAllowed", 73 * time.Hour, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTxContext := postgres.NewMockITransactionContext(ctrl)
+			mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+			userID := uuid.New()
+			user := &models.User{
+				Model:   gorm.Model{ID: 1, CreatedAt: time.Now().Add(-tc.age)},
+				Balance: 100.0,
+			}
+			balance := 50.0
+			mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+			mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+			if tc.wantErr != nil {
+				// The balance is left untouched, so Debit fails the test
+				mockTxContext.EXPECT().Rollback().Return(nil)
+			} else {
+				mockUserRepo.EXPECT().Debit(ctx, user.ID, 50.0).Return(&balance, nil)
+				mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+			}
+
+			service := userService{
+				config:         &config.SlotConfig{MinWithdrawalAge: 72},
+				userRepository: mockUserRepo,
+			}
+
+			wallet, err := service.Withdraw(ctx, &userID, 50.0)
+
+			if tc.wantErr != nil {
+				assert.Nil(t, wallet)
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 50.0, *wallet)
+		})
+	}
+}
+
+func TestDepositWithPromo_ValidCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockPromoRepo := mocks.NewMockIPromoRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	user := &models.User{Model: gorm.Model{ID: 1}, Balance: 50}
+	expiresAt := time.Now().Add(time.Hour)
+	// 20% bonus capped at 10: a deposit of 100 yields a bonus of 10
+	promo := &models.PromoCode{Model: gorm.Model{ID: 7}, Code: "WELCOME", Type: models.PromoTypePercentage, Value: 20, Cap: 10, PerUserLimit: 1, ExpiresAt: &expiresAt}
+	afterDeposit, afterBonus := 150.0, 160.0
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	mockPromoRepo.EXPECT().GetByCode(ctx, "WELCOME").Return(promo, nil)
+	mockPromoRepo.EXPECT().CountRedemptions(ctx, uint(7), uint(1)).Return(0, nil)
+	mockUserRepo.EXPECT().Deposit(ctx, uint(1), 100.0).Return(&afterDeposit, nil)
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeDeposit, Amount: 100})
+	mockUserRepo.EXPECT().Deposit(ctx, uint(1), 10.0).Return(&afterBonus, nil)
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeBonus, Amount: 10, Reference: "WELCOME"})
+	mockPromoRepo.EXPECT().AddRedemption(ctx, &models.PromoRedemption{PromoCodeID: 7, UserID: 1, BonusAmount: 10})
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, mockPromoRepo, mockLedgerRepo, nil)
+
+	// Act
+	balance, bonus, err := service.DepositWithPromo(ctx, &userID, 100, "WELCOME")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 160.0, *balance)
+	assert.Equal(t, 10.0, bonus)
+}
+
+func TestDepositWithPromo_ExpiredCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockPromoRepo := mocks.NewMockIPromoRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	expiredAt := time.Now().Add(-time.Hour)
+	promo := &models.PromoCode{Model: gorm.Model{ID: 7}, Code: "OLD", Type: models.PromoTypeFixed, Value: 10, ExpiresAt: &expiredAt}
+
+	// No funds must be credited when the promo code is rejected
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+	mockPromoRepo.EXPECT().GetByCode(ctx, "OLD").Return(promo, nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, mockPromoRepo, mockLedgerRepo, nil)
+
+	// Act
+	balance, bonus, err := service.DepositWithPromo(ctx, &userID, 100, "OLD")
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrPromoExpired)
+	assert.Nil(t, balance)
+	assert.Zero(t, bonus)
+}
+
+func TestDepositWithPromo_PerUserLimitReached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockPromoRepo := mocks.NewMockIPromoRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	promo := &models.PromoCode{Model: gorm.Model{ID: 7}, Code: "TWICE", Type: models.PromoTypeFixed, Value: 10, PerUserLimit: 2}
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}}, nil)
+	mockPromoRepo.EXPECT().GetByCode(ctx, "TWICE").Return(promo, nil)
+	mockPromoRepo.EXPECT().CountRedemptions(ctx, uint(7), uint(1)).Return(2, nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, mockPromoRepo, mockLedgerRepo, nil)
+
+	// Act
+	balance, bonus, err := service.DepositWithPromo(ctx, &userID, 100, "TWICE")
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrPromoLimitReached)
+	assert.Nil(t, balance)
+	assert.Zero(t, bonus)
+}
+
+func TestUpdateLogin_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	existing := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Login: "old@example.com", Password: string(hashedPassword)}
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(existing, nil)
+	mockUserRepo.EXPECT().GetByLogin(ctx, "new@example.com").Return(nil, nil)
+	mockUserRepo.EXPECT().UpdateLogin(ctx, uint(1), "new@example.com").Return(nil)
+	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	user, err := service.UpdateLogin(ctx, &userID, "new@example.com", password)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", user.Login)
+}
+
+func TestUpdateLogin_LoginTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	existing := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Login: "old@example.com", Password: string(hashedPassword)}
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(existing, nil)
+	mockUserRepo.EXPECT().GetByLogin(ctx, "taken@example.com").Return(&models.User{Model: gorm.Model{ID: 2}}, nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	user, err := service.UpdateLogin(ctx, &userID, "taken@example.com", password)
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrUserExists)
+	assert.Nil(t, user)
+}
+
+func TestUpdateLogin_ConcurrentConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	existing := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Login: "old@example.com", Password: string(hashedPassword)}
+
+	// The existence check passes, but a concurrent change wins the unique constraint
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(existing, nil)
+	mockUserRepo.EXPECT().GetByLogin(ctx, "new@example.com").Return(nil, nil)
+	mockUserRepo.EXPECT().UpdateLogin(ctx, uint(1), "new@example.com").Return(serviceError.ErrUserExists)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	user, err := service.UpdateLogin(ctx, &userID, "new@example.com", password)
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrUserExists)
+	assert.Nil(t, user)
+}
+
+func TestUpdateLogin_WrongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	existing := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Login: "old@example.com", Password: string(hashedPassword)}
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(existing, nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	user, err := service.UpdateLogin(ctx, &userID, "new@example.com", "wrong-password")
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrInvalidPass)
+	assert.Nil(t, user)
+}
+
+func TestUpdateSettings_StoresAutoStopWin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	autoStopWin := 250.0
+	existing := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID}
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(existing, nil)
+	mockUserRepo.EXPECT().UpdateAutoStopWin(ctx, uint(1), &autoStopWin).Return(nil)
+	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	user, err := service.UpdateSettings(ctx, &userID, &autoStopWin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 250.0, user.AutoStopThreshold(0))
+}
+
+func TestUpdateSettings_NegativeThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Any repository call fails the test
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	userID := uuid.New()
+	autoStopWin := -1.0
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+	_, err := service.UpdateSettings(context.Background(), &userID, &autoStopWin)
+
+	assert.ErrorIs(t, err, serviceError.ErrInvalidAmount)
+}
+
+func TestSelfExclude_CannotBeShortened(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing time.Duration // Time left of the running exclusion; 0 if none
+		period   time.Duration
+		expected time.Duration
+	}{
+		{"NoRunningExclusion", 0, 7 * 24 * time.Hour, 7 * 24 * time.Hour},
+		{"Extended", 24 * time.Hour, 30 * 24 * time.Hour, 30 * 24 * time.Hour},
+		{"ShorterPeriodKeepsExclusion", 30 * 24 * time.Hour, 24 * time.Hour, 30 * 24 * time.Hour},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+			mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+			// Arrange
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+			userID := uuid.New()
+			existing := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID}
+			if tc.existing > 0 {
+				until := time.Now().Add(tc.existing)
+				existing.ExcludedUntil = &until
+			}
+
+			mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+			mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(existing, nil)
+			mockUserRepo.EXPECT().ExtendExclusion(ctx, uint(1), gomock.Any()).Return(nil)
+			mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+
+			service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+			// Act
+			user, err := service.SelfExclude(ctx, &userID, tc.period)
+
+			// Assert
+			require.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(tc.expected), *user.ExcludedUntil, time.Minute)
+			assert.True(t, user.SelfExcluded(time.Now()))
+		})
+	}
+}
+
+func TestSelfExclude_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Any repository call fails the test
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	userID := uuid.New()
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+	_, err := service.SelfExclude(context.Background(), &userID, 0)
+
+	assert.ErrorIs(t, err, serviceError.ErrInvalidPeriod)
+}
+
+func TestDepositWithPromo_SelfExcluded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	excludedUntil := time.Now().Add(time.Hour)
+
+	// No funds must be credited while the user is excluded
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, ExcludedUntil: &excludedUntil}, nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	// Act
+	balance, bonus, err := service.DepositWithPromo(ctx, &userID, 100, "")
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrSelfExcluded)
+	assert.Nil(t, balance)
+	assert.Zero(t, bonus)
+}
+
+func TestDepositWithPromo_ExceedsPerTransactionLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The deposits of the day are not summed without a daily limit
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	perTransaction := 50.0
+
+	// No funds must be credited above the limit
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, DepositLimitPerTransaction: &perTransaction}, nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, mockLedgerRepo, nil)
+
+	// Act
+	balance, bonus, err := service.DepositWithPromo(ctx, &userID, 50.01, "")
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrDepositLimitExceeded)
+	assert.Nil(t, balance)
+	assert.Zero(t, bonus)
+}
+
+func TestDepositWithPromo_ExceedsDailyLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+	// Arrange
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+	perTransaction, daily := 50.0, 100.0
+	user := &models.User{Model: gorm.Model{ID: 1}, DepositLimitPerTransaction: &perTransaction, DepositLimitDaily: &daily}
+
+	// Each deposit stays within the per-transaction limit, but 80 deposited since midnight leave
+	// room for only 20 more
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	mockLedgerRepo.EXPECT().SumEntries(ctx, uint(1), models.LedgerTypeDeposit, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)).Return(80.0, nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, mockLedgerRepo, nil).(*userService)
+	service.now = func() time.Time { return now }
+
+	// Act
+	balance, bonus, err := service.DepositWithPromo(ctx, &userID, 30, "")
+
+	// Assert
+	assert.ErrorIs(t, err, serviceError.ErrDepositLimitExceeded)
+	assert.Nil(t, balance)
+	assert.Zero(t, bonus)
+}
+
+func TestDepositWithPromo_DailyLimitRollsOverAtMidnightInDayTimezone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Midnight in New York is 04:00 UTC in summer, not 00:00
+	testCases := []struct {
+		name  string
+		now   time.Time
+		since time.Time
+	}{
+		{"BeforeMidnight", time.Date(2024, 6, 16, 3, 59, 59, 0, time.UTC), time.Date(2024, 6, 15, 4, 0, 0, 0, time.UTC)},
+		{"AtMidnight", time.Date(2024, 6, 16, 4, 0, 0, 0, time.UTC), time.Date(2024, 6, 16, 4, 0, 0, 0, time.UTC)},
+		{"AfterUTCMidnight", time.Date(2024, 6, 16, 0, 30, 0, 0, time.UTC), time.Date(2024, 6, 15, 4, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+			mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+			mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+			// Arrange
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+			userID := uuid.New()
+			daily := 100.0
+			user := &models.User{Model: gorm.Model{ID: 1}, DepositLimitDaily: &daily}
+
+			mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+			mockTxContext.EXPECT().Rollback().Return(nil)
+			mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+			mockLedgerRepo.EXPECT().SumEntries(ctx, uint(1), models.LedgerTypeDeposit, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ uint, _ string, since time.Time) (float64, error) {
+					assert.True(t, tc.since.Equal(since), "summed since %s, want %s", since, tc.since)
+					return 100, nil
+				})
+
+			service := NewUserService(&config.SlotConfig{DayLocation: newYork}, mockUserRepo, nil, mockLedgerRepo, nil).(*userService)
+			service.now = func() time.Time { return tc.now }
+
+			// Act
+			_, _, err := service.DepositWithPromo(ctx, &userID, 10, "")
+
+			// Assert
+			assert.ErrorIs(t, err, serviceError.ErrDepositLimitExceeded)
+		})
+	}
+}
+
+func TestSetDepositLimits_RaisingWaitsForCooldown(t *testing.T) {
+	limit := func(v float64) *float64 { return &v }
+	testCases := []struct {
+		name      string
+		current   models.DepositLimits
+		requested models.DepositLimits
+		cooldown  int
+		applied   models.DepositLimits
+		pending   bool
+	}{
+		{"FirstLimit", models.DepositLimits{}, models.DepositLimits{Daily: limit(100)}, 24, models.DepositLimits{Daily: limit(100)}, false},
+		{"Lowered", models.DepositLimits{Daily: limit(100)}, models.DepositLimits{Daily: limit(50)}, 24, models.DepositLimits{Daily: limit(50)}, false},
+		{"Raised", models.DepositLimits{Daily: limit(100)}, models.DepositLimits{Daily: limit(500)}, 24, models.DepositLimits{Daily: limit(100)}, true},
+		{"Removed", models.DepositLimits{PerTransaction: limit(20)}, models.DepositLimits{}, 24, models.DepositLimits{PerTransaction: limit(20)}, true},
+		{"LoweredAndRaised", models.DepositLimits{PerTransaction: limit(20), Daily: limit(100)}, models.DepositLimits{PerTransaction: limit(10), Daily: limit(500)}, 24, models.DepositLimits{PerTransaction: limit(10), Daily: limit(100)}, true},
+		{"RaisedWithoutCooldown", models.DepositLimits{Daily: limit(100)}, models.DepositLimits{Daily: limit(500)}, 0, models.DepositLimits{Daily: limit(500)}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+			mockTxContext := postgres.NewMockITransactionContext(ctrl)
+
+			// Arrange
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+			userID := uuid.New()
+			existing := &models.User{Model: gorm.Model{ID: 1}, DepositLimitPerTransaction: tc.current.PerTransaction, DepositLimitDaily: tc.current.Daily}
+
+			mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+			mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(existing, nil)
+			mockUserRepo.EXPECT().UpdateDepositLimits(ctx, uint(1), tc.applied, gomock.Any(), gomock.Any()).Return(nil)
+			mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+
+			service := NewUserService(&config.SlotConfig{DepositLimitCooldown: tc.cooldown}, mockUserRepo, nil, nil, nil)
+
+			// Act
+			user, err := service.SetDepositLimits(ctx, &userID, tc.requested)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.applied, user.DepositLimits(time.Now()))
+			if !tc.pending {
+				assert.Nil(t, user.PendingDepositLimits(time.Now()))
+				return
+			}
+			assert.Equal(t, &tc.requested, user.PendingDepositLimits(time.Now()))
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), *user.DepositLimitsPendingAt, time.Minute)
+			assert.Equal(t, tc.requested, user.DepositLimits(time.Now().Add(25*time.Hour)))
+		})
+	}
+}
+
+func TestSetDepositLimits_NotPositive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Any repository call fails the test
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	userID := uuid.New()
+	daily := 0.0
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+	_, err := service.SetDepositLimits(context.Background(), &userID, models.DepositLimits{Daily: &daily})
+
+	assert.ErrorIs(t, err, serviceError.ErrInvalidAmount)
+}
+
+func TestTransfer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	senderID, recipientID := uuid.New(), uuid.New()
+	sender := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &senderID, Balance: 100}
+	recipient := &models.User{Model: gorm.Model{ID: 2}, ExternalID: &recipientID, Balance: 10}
+	senderBalance, recipientBalance := 70.0, 40.0
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &senderID).Return(sender, nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &recipientID).Return(recipient, nil)
+	gomock.InOrder(
+		mockUserRepo.EXPECT().LockUsers(ctx, uint(1), uint(2)).Return(nil),
+		mockUserRepo.EXPECT().Debit(ctx, uint(1), 30.0).Return(&senderBalance, nil),
+		mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeTransferOut, Amount: -30, Reference: recipientID.String()}),
+		mockUserRepo.EXPECT().Deposit(ctx, uint(2), 30.0).Return(&recipientBalance, nil),
+		mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 2, Type: models.LedgerTypeTransferIn, Amount: 30, Reference: senderID.String()}),
+	)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, mockLedgerRepo, nil)
+
+	balance, err := service.Transfer(ctx, &senderID, &recipientID, 30)
+
+	require.NoError(t, err)
+	assert.Equal(t, 70.0, *balance)
+}
+
+func TestTransfer_RecipientNotCreditedWhenDebitFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	senderID, recipientID := uuid.New(), uuid.New()
+	debitErr := errors.New("balance update failed")
+
+	// The transfer is rolled back without crediting the recipient or writing the ledger
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &senderID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &recipientID).Return(&models.User{Model: gorm.Model{ID: 2}}, nil)
+	mockUserRepo.EXPECT().LockUsers(ctx, uint(1), uint(2)).Return(nil)
+	mockUserRepo.EXPECT().Debit(ctx, uint(1), 30.0).Return(nil, debitErr)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, mockLedgerRepo, nil)
+
+	balance, err := service.Transfer(ctx, &senderID, &recipientID, 30)
+
+	assert.Nil(t, balance)
+	assert.ErrorIs(t, err, debitErr)
+}
+
+func TestTransfer_RolledBackWhenCreditFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	senderID, recipientID := uuid.New(), uuid.New()
+	senderBalance := 70.0
+
+	// The debit of the sender is rolled back together with the failed credit
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &senderID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &recipientID).Return(&models.User{Model: gorm.Model{ID: 2}}, nil)
+	mockUserRepo.EXPECT().LockUsers(ctx, uint(1), uint(2)).Return(nil)
+	mockUserRepo.EXPECT().Debit(ctx, uint(1), 30.0).Return(&senderBalance, nil)
+	mockLedgerRepo.EXPECT().AddEntry(ctx, gomock.Any())
+	mockUserRepo.EXPECT().Deposit(ctx, uint(2), 30.0).Return(nil, serviceError.ErrUserNotFound)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, mockLedgerRepo, nil)
+
+	balance, err := service.Transfer(ctx, &senderID, &recipientID, 30)
+
+	assert.Nil(t, balance)
+	assert.ErrorIs(t, err, serviceError.ErrUserNotFound)
+}
+
+func TestTransfer_Rejected(t *testing.T) {
+	senderID, recipientID := uuid.New(), uuid.New()
+	excludedUntil := time.Now().Add(24 * time.Hour)
+	testCases := []struct {
+		name        string
+		recipientID uuid.UUID
+		amount      float64
+		recipient   *models.User
+		wantErr     error
+	}{
+		{"InvalidAmount", recipientID, 0, nil, serviceError.ErrInvalidAmount},
+		{"SelfTransfer", senderID, 30, nil, serviceError.ErrSelfTransfer},
+		{"RecipientNotFound", recipientID, 30, nil, serviceError.ErrRecipientNotFound},
+		{"RecipientFrozen", recipientID, 30, &models.User{Model: gorm.Model{ID: 2}, Frozen: true}, serviceError.ErrRecipientUnavailable},
+		{"RecipientSelfExcluded", recipientID, 30, &models.User{Model: gorm.Model{ID: 2}, ExcludedUntil: &excludedUntil}, serviceError.ErrRecipientUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+			mockTxContext := postgres.NewMockITransactionContext(ctrl)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+			// No balance is changed for a rejected transfer
+			mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+			mockTxContext.EXPECT().Rollback().Return(nil)
+			mockUserRepo.EXPECT().GetByExternalID(ctx, &senderID).Return(&models.User{Model: gorm.Model{ID: 1}, Balance: 100}, nil).AnyTimes()
+			mockUserRepo.EXPECT().GetByExternalID(ctx, &tc.recipientID).Return(tc.recipient, nil).AnyTimes()
+
+			service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+			balance, err := service.Transfer(ctx, &senderID, &tc.recipientID, tc.amount)
+
+			assert.Nil(t, balance)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+// TestTransfer_InsufficientFundsCheckedOnLockedRow checks that the balance read before the
+// lock does not decide the transfer: a concurrent spend that leaves too little behind is caught
+// by the guarded debit and the transfer is rolled back without crediting the recipient.
+func TestTransfer_InsufficientFundsCheckedOnLockedRow(t *testing.T) {
+	testCases := []struct {
+		name             string
+		shortfallDetails bool
+		wantShortfall    bool
+	}{
+		{"PlainError", false, false},
+		{"ShortfallDetails", true, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+			mockTxContext := postgres.NewMockITransactionContext(ctrl)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+			senderID, recipientID := uuid.New(), uuid.New()
+			// The sender had enough when read, but only 20 is left once the row is locked
+			stale := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &senderID, Balance: 100}
+			locked := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &senderID, Balance: 20}
+
+			mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+			mockTxContext.EXPECT().Rollback().Return(nil)
+			gomock.InOrder(
+				mockUserRepo.EXPECT().GetByExternalID(ctx, &senderID).Return(stale, nil),
+				mockUserRepo.EXPECT().GetByExternalID(ctx, &senderID).Return(locked, nil).MaxTimes(1),
+			)
+			mockUserRepo.EXPECT().GetByExternalID(ctx, &recipientID).Return(&models.User{Model: gorm.Model{ID: 2}}, nil)
+			mockUserRepo.EXPECT().LockUsers(ctx, uint(1), uint(2)).Return(nil)
+			mockUserRepo.EXPECT().Debit(ctx, uint(1), 30.0).Return(nil, serviceError.ErrInsufficientFunds)
+
+			cfg := &config.SlotConfig{ShortfallDetails: tc.shortfallDetails}
+			service := NewUserService(cfg, mockUserRepo, nil, nil, nil)
+
+			balance, err := service.Transfer(ctx, &senderID, &recipientID, 30)
+
+			assert.Nil(t, balance)
+			assert.ErrorIs(t, err, serviceError.ErrInsufficientFunds)
+			var shortfall *serviceError.FundsShortfall
+			if assert.Equal(t, tc.wantShortfall, errors.As(err, &shortfall)) && tc.wantShortfall {
+				assert.Equal(t, 20.0, shortfall.Balance)
+				assert.Equal(t, 10.0, shortfall.Shortfall)
+			}
+		})
+	}
+}
+
+func TestSetFrozen_BlocksAccountUntilUnfrozen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	userID, recipientID := uuid.New(), uuid.New()
+	user := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Login: "player", Password: string(hashedPassword), Balance: 100}
+	recipient := &models.User{Model: gorm.Model{ID: 2}, ExternalID: &recipientID}
+	balance := 100.0
+
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil).AnyTimes()
+	mockTxContext.EXPECT().Rollback().Return(nil).AnyTimes()
+	mockTxContext.EXPECT().Commit(gomock.Any()).Return(nil).AnyTimes()
+	mockUserRepo.EXPECT().GetByLogin(ctx, "player").Return(user, nil).AnyTimes()
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil).AnyTimes()
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &recipientID).Return(recipient, nil).AnyTimes()
+	mockUserRepo.EXPECT().UpdateFrozen(ctx, uint(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ uint, frozen bool) error {
+		user.Frozen = frozen
+		return nil
+	}).Times(2)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, mockLedgerRepo, nil)
+
+	// Act: freeze the account; no funds may move while it is frozen
+	frozen, err := service.SetFrozen(ctx, &userID, true)
+	require.NoError(t, err)
+	assert.True(t, frozen.Frozen)
+
+	_, err = service.Login(ctx, "player", password)
+	assert.ErrorIs(t, err, serviceError.ErrAccountFrozen)
+	_, _, err = service.DepositWithPromo(ctx, &userID, 10, "")
+	assert.ErrorIs(t, err, serviceError.ErrAccountFrozen)
+	_, err = service.Withdraw(ctx, &userID, 10)
+	assert.ErrorIs(t, err, serviceError.ErrAccountFrozen)
+	_, err = service.Transfer(ctx, &userID, &recipientID, 10)
+	assert.ErrorIs(t, err, serviceError.ErrAccountFrozen)
+
+	// Act: unfreeze the account; every operation goes through again
+	unfrozen, err := service.SetFrozen(ctx, &userID, false)
+	require.NoError(t, err)
+	assert.False(t, unfrozen.Frozen)
+
+	mockUserRepo.EXPECT().Deposit(ctx, gomock.Any(), 10.0).Return(&balance, nil).Times(2)
+	mockUserRepo.EXPECT().LockUsers(ctx, uint(1), uint(2)).Return(nil)
+	mockUserRepo.EXPECT().Debit(ctx, uint(1), 10.0).Return(&balance, nil).Times(2)
+	mockLedgerRepo.EXPECT().AddEntry(ctx, gomock.Any()).Return(nil).Times(3)
+
+	_, err = service.Login(ctx, "player", password)
+	assert.NoError(t, err)
+	_, _, err = service.DepositWithPromo(ctx, &userID, 10, "")
+	assert.NoError(t, err)
+	_, err = service.Withdraw(ctx, &userID, 10)
+	assert.NoError(t, err)
+	_, err = service.Transfer(ctx, &userID, &recipientID, 10)
+	assert.NoError(t, err)
+}
+
+func TestSetFrozen_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTxContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTxContext)
+	userID := uuid.New()
+
+	// UpdateFrozen is not expected, so attempting it fails the test
+	mockTxContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTxContext.EXPECT().Rollback().Return(nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(nil, nil)
+
+	service := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, nil, nil)
+
+	user, err := service.SetFrozen(ctx, &userID, true)
+
+	assert.Nil(t, user)
+	assert.ErrorIs(t, err, serviceError.ErrUserNotFound)
+}
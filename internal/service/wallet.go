@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/vadymlab/slot-game/internal/config"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// walletService implements the IWalletService interface, reading the balances
+// a user holds in the base currency and in their currency wallets.
+type walletService struct {
+	config           *config.SlotConfig           // Slot configuration settings, including the base currency
+	userRepository   interfaces.IUserRepository   // Repository resolving users by their external identifier
+	walletRepository interfaces.IWalletRepository // Repository reading the balances
+}
+
+// GetBalances retrieves all balances of a user. The base currency balance comes first,
+// followed by the currency wallets ordered by currency.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//
+// Returns:
+//   - A slice of pointers to the user's Balance models.
+//   - ErrUserNotFound if the user does not exist, or an error if the retrieval fails.
+func (s *walletService) GetBalances(ctx context.Context, userID *uuid.UUID) ([]*models.Balance, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepository.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+	balance, err := s.walletRepository.GetBalance(ctx, user.ID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	wallets, err := s.walletRepository.GetWallets(ctx, user.ID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+
+	balances := make([]*models.Balance, 0, len(wallets)+1)
+	balances = append(balances, &models.Balance{Currency: s.config.BaseCurrency, Amount: balance})
+	for _, wallet := range wallets {
+		// The base currency balance is kept on the user, never in a wallet
+		if wallet.Currency == s.config.BaseCurrency {
+			continue
+		}
+		balances = append(balances, &models.Balance{Currency: wallet.Currency, Amount: wallet.Balance})
+	}
+	return balances, tr.Commit(id)
+}
+
+// GetBalance retrieves the balance of a user in a single currency. Only the balance column is read,
+// so polling the balance is cheaper than loading the user or all of their wallets. A user holding no
+// wallet in the currency has a balance of 0.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - currency: The ISO 4217 code of the currency, in any case; empty selects the base currency.
+//
+// Returns:
+//   - A pointer to the user's Balance model in the currency.
+//   - ErrUserNotFound if the user does not exist, or an error if the retrieval fails.
+func (s *walletService) GetBalance(ctx context.Context, userID *uuid.UUID, currency string) (*models.Balance, error) {
+	currency = strings.ToUpper(currency)
+	if currency == "" || currency == s.config.BaseCurrency {
+		balance, err := s.walletRepository.GetBalanceByExternalID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return &models.Balance{Currency: s.config.BaseCurrency, Amount: balance}, nil
+	}
+	balance, err := s.walletRepository.GetWalletBalanceByExternalID(ctx, userID, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Balance{Currency: currency, Amount: balance}, nil
+}
+
+// NewWalletService initializes a new walletService with the provided configuration and repositories.
+//
+// Parameters:
+//   - config: SlotConfig containing the base currency.
+//   - userRepository: UserRepository resolving users by their external identifier.
+//   - walletRepository: WalletRepository reading the balances.
+//
+// Returns:
+//   - An instance of walletService implementing IWalletService.
+func NewWalletService(
+	config *config.SlotConfig,
+	userRepository interfaces.IUserRepository,
+	walletRepository interfaces.IWalletRepository,
+) interfaces.IWalletService {
+	return &walletService{
+		config:           config,
+		userRepository:   userRepository,
+		walletRepository: walletRepository,
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/config"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+func TestGetBalances_TwoCurrencyWallets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockWalletRepo := mocks.NewMockIWalletRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Balance: 100}, nil)
+	mockWalletRepo.EXPECT().GetBalance(ctx, uint(1)).Return(100.0, nil)
+	mockWalletRepo.EXPECT().GetWallets(ctx, uint(1)).Return([]*models.Wallet{
+		{UserID: 1, Currency: "EUR", Balance: 25.5},
+		{UserID: 1, Currency: "GBP", Balance: 10},
+	}, nil)
+
+	s := NewWalletService(&config.SlotConfig{BaseCurrency: "USD"}, mockUserRepo, mockWalletRepo)
+	balances, err := s.GetBalances(ctx, &userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Balance{
+		{Currency: "USD", Amount: 100},
+		{Currency: "EUR", Amount: 25.5},
+		{Currency: "GBP", Amount: 10},
+	}, balances)
+}
+
+func TestGetBalances_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(nil, nil)
+
+	s := NewWalletService(&config.SlotConfig{BaseCurrency: "USD"}, mockUserRepo, nil)
+	_, err := s.GetBalances(ctx, &userID)
+
+	assert.ErrorIs(t, err, serviceError.ErrUserNotFound)
+}
+
+func TestGetBalance_BaseCurrencyMatchesBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockWalletRepo := mocks.NewMockIWalletRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(&models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Balance: 100}, nil)
+	mockWalletRepo.EXPECT().GetBalance(ctx, uint(1)).Return(100.0, nil)
+	mockWalletRepo.EXPECT().GetWallets(ctx, uint(1)).Return(nil, nil)
+	// The balance alone is read without loading the user
+	mockWalletRepo.EXPECT().GetBalanceByExternalID(ctx, &userID).Return(100.0, nil).Times(2)
+
+	s := NewWalletService(&config.SlotConfig{BaseCurrency: "USD"}, mockUserRepo, mockWalletRepo)
+	balances, err := s.GetBalances(ctx, &userID)
+	assert.NoError(t, err)
+
+	for _, currency := range []string{"", "usd"} {
+		balance, err := s.GetBalance(ctx, &userID, currency)
+
+		assert.NoError(t, err)
+		assert.Equal(t, balances[0], balance)
+	}
+}
+
+func TestGetBalance_CurrencyWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mocks.NewMockIWalletRepository(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	mockWalletRepo.EXPECT().GetWalletBalanceByExternalID(ctx, &userID, "EUR").Return(25.5, nil)
+
+	s := NewWalletService(&config.SlotConfig{BaseCurrency: "USD"}, nil, mockWalletRepo)
+	balance, err := s.GetBalance(ctx, &userID, "eur")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Balance{Currency: "EUR", Amount: 25.5}, balance)
+}
+
+func TestGetBalance_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletRepo := mocks.NewMockIWalletRepository(ctrl)
+	ctx := context.Background()
+	userID := uuid.New()
+	mockWalletRepo.EXPECT().GetBalanceByExternalID(ctx, &userID).Return(0.0, serviceError.ErrUserNotFound)
+
+	s := NewWalletService(&config.SlotConfig{BaseCurrency: "USD"}, nil, mockWalletRepo)
+	_, err := s.GetBalance(ctx, &userID, "")
+
+	assert.ErrorIs(t, err, serviceError.ErrUserNotFound)
+}
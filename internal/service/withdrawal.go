@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/database"
+	serviceError "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// withdrawalService implements the IWithdrawalService interface, holding withdrawn funds
+// until an admin approves or rejects the withdrawal.
+type withdrawalService struct {
+	userService          interfaces.IUserService          // Service moving the held funds in and out of the balance
+	withdrawalRepository interfaces.IWithdrawalRepository // Repository storing the pending withdrawals
+	ledgerRepository     interfaces.ILedgerRepository     // Repository recording the holds and releases
+	notifier             *EventNotifier                   // Publisher of the approved withdrawals
+}
+
+// Request holds the amount of a withdrawal. The amount is taken from the user's balance right
+// away, so that it can no longer be bet on spins, and a hold is recorded in the ledger.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - userID: A UUID representing the user's external identifier.
+//   - amount: The amount to withdraw.
+//
+// Returns:
+//   - A pointer to the pending withdrawal.
+//   - A pointer to the user's balance after the funds are held.
+//   - ErrInvalidAmount, ErrUserNotFound or ErrInsufficientFunds, or another error if the request fails.
+func (s *withdrawalService) Request(ctx context.Context, userID *uuid.UUID, amount float64) (*models.PendingWithdrawal, *float64, error) {
+	defer database.TrackTransaction(ctx)()
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	if amount <= 0 {
+		_ = tr.Rollback()
+		return nil, nil, serviceError.ErrInvalidAmount
+	}
+	user, err := s.userService.GetByExternalID(ctx, userID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, nil, serviceError.ErrUserNotFound
+	}
+	balance, err := s.userService.Withdraw(ctx, userID, amount)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, nil, err
+	}
+
+	withdrawal := &models.PendingWithdrawal{UserID: user.ID, Amount: amount, Status: models.WithdrawalStatusPending}
+	if err := s.withdrawalRepository.AddWithdrawal(ctx, withdrawal); err != nil {
+		_ = tr.Rollback()
+		return nil, nil, err
+	}
+	err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: user.ID, Type: models.LedgerTypeWithdrawalHold, Amount: -amount, Reference: withdrawalReference(withdrawal)})
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, nil, err
+	}
+	return withdrawal, balance, tr.Commit(id)
+}
+
+// Approve finalizes a pending withdrawal. The held funds already left the balance when the
+// withdrawal was requested, so only its status changes; the paid out withdrawal is published once
+// the approval is committed.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - withdrawalID: The unique numeric ID of the withdrawal.
+//
+// Returns:
+//   - A pointer to the approved withdrawal.
+//   - ErrWithdrawalNotFound, ErrWithdrawalNotPending or ErrUserNotFound, or another error if the approval fails.
+func (s *withdrawalService) Approve(ctx context.Context, withdrawalID uint) (*models.PendingWithdrawal, error) {
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+	withdrawal, err := s.getPending(ctx, withdrawalID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if err := s.decide(ctx, withdrawal, models.WithdrawalStatusApproved, ""); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	user, err := s.userService.GetByID(ctx, withdrawal.UserID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+	if err := tr.Commit(id); err != nil {
+		return nil, err
+	}
+
+	log.FromContext(ctx).Infof("withdrawal %d approved", withdrawal.ID)
+	s.notifier.NotifyWithdrawal(ctx, user.ExternalID, withdrawal.Amount)
+	return withdrawal, nil
+}
+
+// Reject refuses a pending withdrawal, returning the held funds to the user's balance and
+// recording the release in the ledger.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - withdrawalID: The unique numeric ID of the withdrawal.
+//   - reason: The reason for rejecting the withdrawal.
+//
+// Returns:
+//   - A pointer to the rejected withdrawal.
+//   - ErrWithdrawalNotFound or ErrWithdrawalNotPending, or another error if the rejection fails.
+func (s *withdrawalService) Reject(ctx context.Context, withdrawalID uint, reason string) (*models.PendingWithdrawal, error) {
+	defer database.TrackTransaction(ctx)()
+	tr, _ := postgres.GetTransactionContext(ctx)
+	id, err := tr.Begin()
+	if err != nil {
+		return nil, err
+	}
+	withdrawal, err := s.getPending(ctx, withdrawalID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	user, err := s.userService.GetByID(ctx, withdrawal.UserID)
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if user == nil {
+		_ = tr.Rollback()
+		return nil, serviceError.ErrUserNotFound
+	}
+
+	if _, err := s.userService.Deposit(ctx, user.ExternalID, withdrawal.Amount); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	err = s.ledgerRepository.AddEntry(ctx, &models.LedgerEntry{UserID: user.ID, Type: models.LedgerTypeWithdrawalRelease, Amount: withdrawal.Amount, Reference: withdrawalReference(withdrawal)})
+	if err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+	if err := s.decide(ctx, withdrawal, models.WithdrawalStatusRejected, reason); err != nil {
+		_ = tr.Rollback()
+		return nil, err
+	}
+
+	log.FromContext(ctx).Infof("withdrawal %d rejected: %s", withdrawal.ID, reason)
+	return withdrawal, tr.Commit(id)
+}
+
+// getPending locks a withdrawal for the decision on it and checks that it is still pending.
+func (s *withdrawalService) getPending(ctx context.Context, withdrawalID uint) (*models.PendingWithdrawal, error) {
+	withdrawal, err := s.withdrawalRepository.GetWithdrawalForUpdate(ctx, withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+	if withdrawal == nil {
+		return nil, serviceError.ErrWithdrawalNotFound
+	}
+	if !withdrawal.Pending() {
+		return nil, serviceError.ErrWithdrawalNotPending
+	}
+	return withdrawal, nil
+}
+
+// decide stores the final status of a withdrawal and reflects it on the model.
+func (s *withdrawalService) decide(ctx context.Context, withdrawal *models.PendingWithdrawal, status, reason string) error {
+	now := time.Now()
+	if err := s.withdrawalRepository.DecideWithdrawal(ctx, withdrawal.ID, status, reason, now); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	withdrawal.Status = status
+	withdrawal.Reason = reason
+	withdrawal.DecidedAt = &now
+	return nil
+}
+
+// withdrawalReference returns the ledger reference of the entries of a withdrawal.
+func withdrawalReference(withdrawal *models.PendingWithdrawal) string {
+	return "withdrawal:" + strconv.FormatUint(uint64(withdrawal.ID), 10)
+}
+
+// NewWithdrawalService initializes a new withdrawalService with the provided dependencies.
+//
+// Parameters:
+//   - userService: UserService moving the held funds in and out of the balance.
+//   - withdrawalRepository: WithdrawalRepository storing the pending withdrawals.
+//   - ledgerRepository: LedgerRepository recording the holds and releases.
+//   - notifier: EventNotifier publishing the approved withdrawals; may be nil.
+//
+// Returns:
+//   - An instance of withdrawalService implementing IWithdrawalService.
+func NewWithdrawalService(
+	userService interfaces.IUserService,
+	withdrawalRepository interfaces.IWithdrawalRepository,
+	ledgerRepository interfaces.ILedgerRepository,
+	notifier *EventNotifier,
+) interfaces.IWithdrawalService {
+	return &withdrawalService{
+		userService:          userService,
+		withdrawalRepository: withdrawalRepository,
+		ledgerRepository:     ledgerRepository,
+		notifier:             notifier,
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/public-forge/go-gorm-unit-of-work/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/config"
+	error2 "github.com/vadymlab/slot-game/internal/error"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+	"github.com/vadymlab/slot-game/internal/webhook"
+)
+
+func TestRequestWithdrawal_HoldsFunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockWithdrawalRepo := mocks.NewMockIWithdrawalRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	user := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Balance: 100}
+	balance := 70.0
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	// The held amount leaves the spendable balance right away
+	mockUserService.EXPECT().Withdraw(ctx, &userID, 30.0).Return(&balance, nil)
+	mockWithdrawalRepo.EXPECT().AddWithdrawal(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, withdrawal *models.PendingWithdrawal) error {
+			withdrawal.ID = 5
+			return nil
+		})
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeWithdrawalHold, Amount: -30, Reference: "withdrawal:5"})
+
+	s := NewWithdrawalService(mockUserService, mockWithdrawalRepo, mockLedgerRepo, nil)
+	withdrawal, newBalance, err := s.Request(ctx, &userID, 30)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 70.0, *newBalance)
+	assert.Equal(t, uint(1), withdrawal.UserID)
+	assert.Equal(t, 30.0, withdrawal.Amount)
+	assert.True(t, withdrawal.Pending())
+}
+
+func TestRequestWithdrawal_InsufficientFunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Any withdrawal or ledger entry fails the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockWithdrawalRepo := mocks.NewMockIWithdrawalRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	user := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, Balance: 10}
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Rollback().Return(nil)
+	mockUserService.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	mockUserService.EXPECT().Withdraw(ctx, &userID, 30.0).Return(nil, error2.ErrInsufficientFunds)
+
+	s := NewWithdrawalService(mockUserService, mockWithdrawalRepo, mockLedgerRepo, nil)
+	_, _, err := s.Request(ctx, &userID, 30)
+
+	assert.ErrorIs(t, err, error2.ErrInsufficientFunds)
+}
+
+func TestApproveWithdrawal_KeepsFundsHeld(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Any balance change or ledger entry fails the test
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockWithdrawalRepo := mocks.NewMockIWithdrawalRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	pending := &models.PendingWithdrawal{Model: gorm.Model{ID: 5}, UserID: 1, Amount: 30, Status: models.WithdrawalStatusPending}
+
+	externalID := uuid.New()
+	published := make(chan *models.DomainEvent, 1)
+	mockBus := mocks.NewMockIEventBus(ctrl)
+	mockBus.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *models.DomainEvent) error {
+		published <- event
+		return nil
+	})
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockWithdrawalRepo.EXPECT().GetWithdrawalForUpdate(ctx, uint(5)).Return(pending, nil)
+	mockWithdrawalRepo.EXPECT().DecideWithdrawal(ctx, uint(5), models.WithdrawalStatusApproved, "", gomock.Any()).Return(nil)
+	mockUserService.EXPECT().GetByID(ctx, uint(1)).Return(&models.User{Model: gorm.Model{ID: 1}, ExternalID: &externalID}, nil)
+
+	notifier := NewEventNotifier(&webhook.Config{}, mocks.NewMockIEventPublisher(ctrl), mockBus)
+	s := NewWithdrawalService(mockUserService, mockWithdrawalRepo, mockLedgerRepo, notifier)
+	withdrawal, err := s.Approve(ctx, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.WithdrawalStatusApproved, withdrawal.Status)
+	assert.NotNil(t, withdrawal.DecidedAt)
+	select {
+	case event := <-published:
+		assert.Equal(t, models.DomainEventWithdrawalMade, event.Type)
+		assert.Equal(t, externalID.String(), event.UserID)
+		assert.Equal(t, 30.0, event.Amount)
+	case <-time.After(time.Second):
+		t.Fatal("withdrawal was not published")
+	}
+}
+
+func TestRejectWithdrawal_ReturnsFunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockIUserService(ctrl)
+	mockWithdrawalRepo := mocks.NewMockIWithdrawalRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	userID := uuid.New()
+	user := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID}
+	pending := &models.PendingWithdrawal{Model: gorm.Model{ID: 5}, UserID: 1, Amount: 30, Status: models.WithdrawalStatusPending}
+	// Balance while 30 of 100 is held
+	balance := 70.0
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil)
+	mockWithdrawalRepo.EXPECT().GetWithdrawalForUpdate(ctx, uint(5)).Return(pending, nil)
+	mockUserService.EXPECT().GetByID(ctx, uint(1)).Return(user, nil)
+	mockUserService.EXPECT().Deposit(ctx, &userID, 30.0).DoAndReturn(
+		func(_ context.Context, _ *uuid.UUID, amount float64) (*float64, error) {
+			balance += amount
+			return &balance, nil
+		})
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeWithdrawalRelease, Amount: 30, Reference: "withdrawal:5"})
+	mockWithdrawalRepo.EXPECT().DecideWithdrawal(ctx, uint(5), models.WithdrawalStatusRejected, "failed KYC", gomock.Any()).Return(nil)
+
+	s := NewWithdrawalService(mockUserService, mockWithdrawalRepo, mockLedgerRepo, nil)
+	withdrawal, err := s.Reject(ctx, 5, "failed KYC")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, balance)
+	assert.Equal(t, models.WithdrawalStatusRejected, withdrawal.Status)
+	assert.Equal(t, "failed KYC", withdrawal.Reason)
+}
+
+func TestRejectWithdrawal_ReturnsFundsOfSelfExcludedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockWithdrawalRepo := mocks.NewMockIWithdrawalRepository(ctrl)
+	mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+	mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+	ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+	// The held funds are the user's own, so the self-exclusion does not keep them held
+	userID := uuid.New()
+	excludedUntil := time.Now().Add(time.Hour)
+	user := &models.User{Model: gorm.Model{ID: 1}, ExternalID: &userID, ExcludedUntil: &excludedUntil}
+	pending := &models.PendingWithdrawal{Model: gorm.Model{ID: 5}, UserID: 1, Amount: 30, Status: models.WithdrawalStatusPending}
+	balance := 100.0
+
+	mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+	mockTransactionContext.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	mockWithdrawalRepo.EXPECT().GetWithdrawalForUpdate(ctx, uint(5)).Return(pending, nil)
+	mockUserRepo.EXPECT().GetByID(ctx, uint(1)).Return(user, nil)
+	mockUserRepo.EXPECT().GetByExternalID(ctx, &userID).Return(user, nil)
+	mockUserRepo.EXPECT().Deposit(ctx, uint(1), 30.0).Return(&balance, nil)
+	mockLedgerRepo.EXPECT().AddEntry(ctx, &models.LedgerEntry{UserID: 1, Type: models.LedgerTypeWithdrawalRelease, Amount: 30, Reference: "withdrawal:5"})
+	mockWithdrawalRepo.EXPECT().DecideWithdrawal(ctx, uint(5), models.WithdrawalStatusRejected, "failed KYC", gomock.Any()).Return(nil)
+
+	userService := NewUserService(&config.SlotConfig{}, mockUserRepo, nil, mockLedgerRepo, nil)
+	s := NewWithdrawalService(userService, mockWithdrawalRepo, mockLedgerRepo, nil)
+	withdrawal, err := s.Reject(ctx, 5, "failed KYC")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.WithdrawalStatusRejected, withdrawal.Status)
+}
+
+func TestDecideWithdrawal_NotPending(t *testing.T) {
+	decidedAt := time.Now()
+	testCases := []struct {
+		name       string
+		withdrawal *models.PendingWithdrawal
+		expected   error
+	}{
+		{"NotFound", nil, error2.ErrWithdrawalNotFound},
+		{"Approved", &models.PendingWithdrawal{Model: gorm.Model{ID: 5}, Status: models.WithdrawalStatusApproved, DecidedAt: &decidedAt}, error2.ErrWithdrawalNotPending},
+		{"Rejected", &models.PendingWithdrawal{Model: gorm.Model{ID: 5}, Status: models.WithdrawalStatusRejected, DecidedAt: &decidedAt}, error2.ErrWithdrawalNotPending},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Any balance change, ledger entry or status update fails the test
+			mockUserService := mocks.NewMockIUserService(ctrl)
+			mockWithdrawalRepo := mocks.NewMockIWithdrawalRepository(ctrl)
+			mockLedgerRepo := mocks.NewMockILedgerRepository(ctrl)
+			mockTransactionContext := postgres.NewMockITransactionContext(ctrl)
+			ctx := context.WithValue(context.Background(), postgres.TransactionContextKey, mockTransactionContext)
+
+			mockTransactionContext.EXPECT().Begin().Return(uuid.New(), nil).Times(2)
+			mockTransactionContext.EXPECT().Rollback().Return(nil).Times(2)
+			mockWithdrawalRepo.EXPECT().GetWithdrawalForUpdate(ctx, uint(5)).Return(tc.withdrawal, nil).Times(2)
+
+			s := NewWithdrawalService(mockUserService, mockWithdrawalRepo, mockLedgerRepo, nil)
+			_, err := s.Approve(ctx, 5)
+			assert.ErrorIs(t, err, tc.expected)
+			_, err = s.Reject(ctx, 5, "failed KYC")
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package spinbatch
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Constants defining the spin batching configuration flags.
+const (
+	spinBatchSize     = "spin-batch-size"
+	spinBatchInterval = "spin-batch-interval"
+)
+
+// Config represents the settings of the batched spin writer.
+type Config struct {
+	BatchSize     int // Maximum number of spins written by a single flush; 0 writes every spin within its own transaction
+	FlushInterval int // Maximum time a buffered spin waits for its flush in milliseconds
+}
+
+// Enabled reports whether spins are written in batches.
+func (c *Config) Enabled() bool {
+	return c.BatchSize > 0
+}
+
+// Validate checks that the batch size is not negative and that enabled batching has a positive flush interval.
+//
+// Returns:
+//   - An error describing the first invalid setting, or nil if the configuration is valid.
+func (c *Config) Validate() error {
+	if c.BatchSize < 0 {
+		return fmt.Errorf("%s must not be negative, got %d", spinBatchSize, c.BatchSize)
+	}
+	if c.Enabled() && c.FlushInterval <= 0 {
+		return fmt.Errorf("%s must be positive, got %d", spinBatchInterval, c.FlushInterval)
+	}
+	return nil
+}
+
+// GetSpinBatchConfig reads the spin batching settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the spin batching settings.
+//   - (error): An error if the settings are invalid.
+func GetSpinBatchConfig(c *cli.Context) (*Config, error) {
+	cfg := &Config{
+		BatchSize:     c.Int(spinBatchSize),
+		FlushInterval: c.Int(spinBatchInterval),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Flags defines the CLI flags available for configuring the batched spin writer.
+var Flags = []cli.Flag{
+	&cli.IntFlag{
+		Name:    spinBatchSize,
+		Value:   0,
+		Usage:   "Maximum number of spins written to the database by a single flush of the background writer; 0 writes every spin within its own transaction",
+		EnvVars: []string{"SPIN_BATCH_SIZE"},
+	},
+	&cli.IntFlag{
+		Name:    spinBatchInterval,
+		Value:   500,
+		Usage:   "Maximum time a spin waits in the background writer before it is flushed, in milliseconds",
+		EnvVars: []string{"SPIN_BATCH_INTERVAL"},
+	},
+}
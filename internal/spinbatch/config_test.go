@@ -0,0 +1,32 @@
+package spinbatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"Disabled", Config{FlushInterval: 500}, false},
+		{"DisabledWithoutInterval", Config{}, false},
+		{"Enabled", Config{BatchSize: 100, FlushInterval: 500}, false},
+		{"NegativeBatchSize", Config{BatchSize: -1, FlushInterval: 500}, true},
+		{"EnabledWithoutInterval", Config{BatchSize: 100}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package spinbatch
+
+import (
+	"context"
+
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"go.uber.org/fx"
+)
+
+// Module provides the spin batching configuration and the batched Writer as an Fx module.
+var Module = fx.Options(
+	fx.Provide(GetSpinBatchConfig),
+	fx.Provide(NewWriter),
+	fx.Provide(newSpinWriter),
+)
+
+// Flusher starts the batched writer with the application and flushes the buffered spins on shutdown.
+// The writer is not started when batching is disabled.
+var Flusher = fx.Invoke(func(lc fx.Lifecycle, config *Config, writer *Writer) {
+	if !config.Enabled() {
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			writer.Start()
+			return nil
+		},
+		OnStop: writer.Stop,
+	})
+})
+
+// newSpinWriter hands the batched writer to the slot service. Without batching no writer is provided,
+// so that every spin is written within the transaction that settles its balance.
+func newSpinWriter(config *Config, writer *Writer) interfaces.ISpinWriter {
+	if !config.Enabled() {
+		return nil
+	}
+	return writer
+}
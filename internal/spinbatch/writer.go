@@ -0,0 +1,132 @@
+package spinbatch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/public-forge/go-logger"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// queueBatches is the number of full batches the queue holds before Write blocks.
+const queueBatches = 4
+
+// Writer persists spins on a background goroutine, in batches of the configured size or
+// whenever the flush interval elapses, whichever comes first.
+//
+// Balances are settled synchronously before a spin is handed to the writer; only the spin
+// record is written later. A batch that fails to be written is kept and retried with the next
+// flush, so spins are not dropped while the database is briefly unavailable.
+type Writer struct {
+	config         *Config                    // Batch size and flush interval
+	slotRepository interfaces.ISlotRepository // Repository writing the batches
+	queue          chan *models.Spin          // Spins waiting for the background goroutine
+	done           chan struct{}              // Closed once the background goroutine has flushed its last batch
+	mu             sync.RWMutex               // Guards stopped against concurrent writes
+	stopped        bool                       // Whether Stop has closed the queue
+}
+
+// Write hands a settled spin over for the next batch. When the queue is full, Write waits until the
+// background goroutine catches up. Spins written after Stop are written right away instead.
+//
+// Parameters:
+//   - ctx: Context of the request that settled the spin.
+//   - spin: The spin to persist.
+func (w *Writer) Write(ctx context.Context, spin *models.Spin) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.stopped {
+		if err := w.slotRepository.AddSpin(ctx, spin); err != nil {
+			log.FromContext(ctx).Errorf("failed to write spin of user %d: %v", spin.UserID, err)
+		}
+		return
+	}
+	w.queue <- spin
+}
+
+// Start runs the background goroutine flushing the queued spins until Stop is called.
+func (w *Writer) Start() {
+	go w.run()
+}
+
+// Stop closes the queue and waits until the spins queued so far have been flushed.
+//
+// Parameters:
+//   - ctx: Context bounding the wait for the last flush.
+//
+// Returns:
+//   - An error if the context is done before the last flush has completed.
+func (w *Writer) Stop(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.stopped {
+		w.stopped = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run collects the queued spins into batches and flushes them when a batch is full, when the
+// flush interval elapses and when the queue is closed.
+func (w *Writer) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(time.Duration(w.config.FlushInterval) * time.Millisecond)
+	defer ticker.Stop()
+
+	batch := make([]*models.Spin, 0, w.config.BatchSize)
+	for {
+		select {
+		case spin, ok := <-w.queue:
+			if !ok {
+				if batch = w.flush(batch); len(batch) > 0 {
+					log.FromContext(context.Background()).Errorf("%d spins were not written before shutdown", len(batch))
+				}
+				return
+			}
+			batch = append(batch, spin)
+			if len(batch) >= w.config.BatchSize {
+				batch = w.flush(batch)
+			}
+		case <-ticker.C:
+			batch = w.flush(batch)
+		}
+	}
+}
+
+// flush writes the batch and returns it emptied, or unchanged if the write failed.
+func (w *Writer) flush(batch []*models.Spin) []*models.Spin {
+	if len(batch) == 0 {
+		return batch
+	}
+	ctx := context.Background()
+	if err := w.slotRepository.AddSpins(ctx, batch); err != nil {
+		log.FromContext(ctx).Errorf("failed to write a batch of %d spins, retrying with the next flush: %v", len(batch), err)
+		return batch
+	}
+	return batch[:0]
+}
+
+// NewWriter creates a Writer for the given batching settings. The writer only flushes once started.
+//
+// Parameters:
+//   - config: The batch size and flush interval.
+//   - slotRepository: The repository writing the batches.
+//
+// Returns:
+//   - A pointer to a Writer instance.
+func NewWriter(config *Config, slotRepository interfaces.ISlotRepository) *Writer {
+	return &Writer{
+		config:         config,
+		slotRepository: slotRepository,
+		queue:          make(chan *models.Spin, queueBatches*max(config.BatchSize, 1)),
+		done:           make(chan struct{}),
+	}
+}
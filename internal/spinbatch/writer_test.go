@@ -0,0 +1,148 @@
+package spinbatch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vadymlab/slot-game/internal/interfaces/mocks"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+// recorder collects the batches written through a mocked repository.
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]*models.Spin
+}
+
+// add records a copy of the batch, as the writer reuses its slice after a flush.
+func (r *recorder) add(_ context.Context, spins []*models.Spin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, append([]*models.Spin(nil), spins...))
+	return nil
+}
+
+// spins returns the IDs of all recorded spins.
+func (r *recorder) spins() []uint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []uint
+	for _, batch := range r.batches {
+		for _, spin := range batch {
+			ids = append(ids, spin.ID)
+		}
+	}
+	return ids
+}
+
+// newSpins creates n spins with the IDs 1 to n.
+func newSpins(n int) []*models.Spin {
+	spins := make([]*models.Spin, n)
+	for i := range spins {
+		spins[i] = &models.Spin{Model: gorm.Model{ID: uint(i + 1)}, UserID: 1, BetAmount: 10}
+	}
+	return spins
+}
+
+func TestWriter_WritesAllSpinsInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec := &recorder{}
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSlotRepo.EXPECT().AddSpins(gomock.Any(), gomock.Any()).DoAndReturn(rec.add).AnyTimes()
+
+	// The interval never elapses, so only full batches and the final flush write spins
+	w := NewWriter(&Config{BatchSize: 10, FlushInterval: int(time.Hour / time.Millisecond)}, mockSlotRepo)
+	w.Start()
+
+	spins := newSpins(250)
+	var wg sync.WaitGroup
+	for _, spin := range spins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Write(context.Background(), spin)
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, w.Stop(context.Background()))
+
+	expected := make([]uint, len(spins))
+	for i, spin := range spins {
+		expected[i] = spin.ID
+	}
+	assert.ElementsMatch(t, expected, rec.spins())
+	assert.Len(t, rec.batches, 25)
+	for _, batch := range rec.batches {
+		assert.LessOrEqual(t, len(batch), 10)
+	}
+}
+
+func TestWriter_FlushesOnInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec := &recorder{}
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSlotRepo.EXPECT().AddSpins(gomock.Any(), gomock.Any()).DoAndReturn(rec.add).AnyTimes()
+
+	w := NewWriter(&Config{BatchSize: 100, FlushInterval: 10}, mockSlotRepo)
+	w.Start()
+	defer func() { _ = w.Stop(context.Background()) }()
+
+	for _, spin := range newSpins(3) {
+		w.Write(context.Background(), spin)
+	}
+
+	// The batch is far from full, so only the interval flushes it
+	assert.Eventually(t, func() bool {
+		return len(rec.spins()) == 3
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestWriter_RetriesFailedBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rec := &recorder{}
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	gomock.InOrder(
+		mockSlotRepo.EXPECT().AddSpins(gomock.Any(), gomock.Any()).Return(errors.New("connection refused")),
+		mockSlotRepo.EXPECT().AddSpins(gomock.Any(), gomock.Any()).DoAndReturn(rec.add).AnyTimes(),
+	)
+
+	w := NewWriter(&Config{BatchSize: 5, FlushInterval: 10}, mockSlotRepo)
+	w.Start()
+	for _, spin := range newSpins(5) {
+		w.Write(context.Background(), spin)
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(rec.spins()) == 5
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, w.Stop(context.Background()))
+	assert.ElementsMatch(t, []uint{1, 2, 3, 4, 5}, rec.spins())
+}
+
+func TestWriter_WritesDirectlyAfterStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spin := newSpins(1)[0]
+	mockSlotRepo := mocks.NewMockISlotRepository(ctrl)
+	mockSlotRepo.EXPECT().AddSpin(gomock.Any(), spin).Return(nil)
+
+	w := NewWriter(&Config{BatchSize: 10, FlushInterval: 10}, mockSlotRepo)
+	w.Start()
+	require.NoError(t, w.Stop(context.Background()))
+
+	w.Write(context.Background(), spin)
+}
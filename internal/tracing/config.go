@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Constants defining the tracing configuration flags.
+const (
+	tracingEndpoint    = "tracing-otlp-endpoint"
+	tracingServiceName = "tracing-service-name"
+	tracingSampleRatio = "tracing-sample-ratio"
+)
+
+// Config represents the settings of the OpenTelemetry trace export.
+type Config struct {
+	Endpoint    string  // OTLP/HTTP traces URL of the collector, http or https; empty disables the export
+	ServiceName string  // Service name reported with every span
+	SampleRatio float64 // Fraction of new traces that are sampled; requests joining a trace follow its decision
+}
+
+// Enabled reports whether spans should be exported.
+func (c *Config) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// Validate checks that an enabled export has a valid collector URL and that the sample ratio is within [0, 1].
+//
+// Returns:
+//   - An error describing the first invalid setting, or nil if the configuration is valid.
+func (c *Config) Validate() error {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("%s must be between 0 and 1, got %v", tracingSampleRatio, c.SampleRatio)
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%s must be an absolute URL, got %q", tracingEndpoint, c.Endpoint)
+	}
+	return nil
+}
+
+// GetTracingConfig reads the tracing settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the tracing settings.
+//   - (error): An error if the settings are invalid.
+func GetTracingConfig(c *cli.Context) (*Config, error) {
+	cfg := &Config{
+		Endpoint:    c.String(tracingEndpoint),
+		ServiceName: c.String(tracingServiceName),
+		SampleRatio: c.Float64(tracingSampleRatio),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Flags defines the CLI flags available for configuring the trace export.
+var Flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    tracingEndpoint,
+		Usage:   "OTLP/HTTP traces URL of the OpenTelemetry collector, e.g. http://otel-collector:4318/v1/traces; empty disables the export",
+		EnvVars: []string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"},
+	},
+	&cli.StringFlag{
+		Name:    tracingServiceName,
+		Value:   "slot-game",
+		Usage:   "Service name reported with every exported span",
+		EnvVars: []string{"OTEL_SERVICE_NAME"},
+	},
+	&cli.Float64Flag{
+		Name:    tracingSampleRatio,
+		Value:   1,
+		Usage:   "Fraction of new traces that are sampled, between 0 and 1; requests carrying a traceparent follow the caller's decision",
+		EnvVars: []string{"TRACING_SAMPLE_RATIO"},
+	},
+}
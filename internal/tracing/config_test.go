@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"Disabled", Config{SampleRatio: 1}, false},
+		{"HTTP", Config{Endpoint: "http://otel-collector:4318/v1/traces", SampleRatio: 0.25}, false},
+		{"HTTPS", Config{Endpoint: "https://collector.example.com/v1/traces", SampleRatio: 1}, false},
+		{"RelativeEndpoint", Config{Endpoint: "otel-collector:4318", SampleRatio: 1}, true},
+		{"NegativeRatio", Config{SampleRatio: -0.1}, true},
+		{"RatioAboveOne", Config{Endpoint: "http://otel-collector:4318/v1/traces", SampleRatio: 1.5}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+// Module provides the tracing configuration and installs the tracer provider as an Fx module.
+// The provider is installed eagerly, before the server starts handling requests.
+var Module = fx.Options(
+	fx.Provide(GetTracingConfig),
+	fx.Provide(NewTracerProvider),
+	fx.Invoke(func(trace.TracerProvider) {}),
+)
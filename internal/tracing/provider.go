@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+)
+
+// NewTracerProvider creates the tracer provider exporting spans in batches to the configured
+// OTLP collector and installs it, together with the W3C trace context propagator, as the global
+// OpenTelemetry provider used by Start and the tracing middleware. When the export is disabled a
+// no-op provider is installed, so that instrumented code runs unchanged at no cost. Pending spans
+// are flushed when the application stops.
+//
+// Parameters:
+//   - lc: Fx lifecycle used to shut the provider down.
+//   - cfg: Tracing settings.
+//
+// Returns:
+//   - The installed trace.TracerProvider.
+//   - An error if the exporter cannot be created.
+func NewTracerProvider(lc fx.Lifecycle, cfg *Config) (trace.TracerProvider, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if !cfg.Enabled() {
+		provider := noop.NewTracerProvider()
+		otel.SetTracerProvider(provider)
+		return provider, nil
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	lc.Append(fx.Hook{
+		OnStop: provider.Shutdown,
+	})
+	return provider, nil
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies the spans created by the application.
+const InstrumentationName = "github.com/vadymlab/slot-game"
+
+// AttributeTraceID is the span attribute holding the X-Trace-ID of the request, linking spans
+// to the application logs and error responses.
+const AttributeTraceID = "slot.trace_id"
+
+// Start starts a span named after the operation as a child of the span in ctx, using the global
+// tracer provider installed by NewTracerProvider.
+//
+// Parameters:
+//   - ctx: Context carrying the parent span, if any.
+//   - name: Name of the operation, such as "SlotService.RetrySpin".
+//   - opts: Further options of the span, such as its kind or attributes.
+//
+// Returns:
+//   - The context carrying the new span.
+//   - The new span, to be ended with End.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, opts...)
+}
+
+// End marks the span as failed if err is not nil and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
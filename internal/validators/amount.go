@@ -0,0 +1,19 @@
+package validators
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ValidateAmount checks that the amount has at most the given number of decimals, so that bets
+// and wallet amounts stay in the minor units the balances are stored with. The decimals are
+// counted on the shortest representation of the amount, which is how the client sent it.
+// It returns a slice of error messages in the same "field::tag::param" format used by Validate,
+// or nil if the amount is precise enough.
+func ValidateAmount(field string, amount float64, decimals int) []string {
+	formatted := strconv.FormatFloat(amount, 'f', -1, 64)
+	if dot := strings.IndexByte(formatted, '.'); dot >= 0 && len(formatted)-dot-1 > decimals {
+		return []string{field + "::decimals::" + strconv.Itoa(decimals)}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAmount_TooPreciseRejected(t *testing.T) {
+	assert.Equal(t, []string{"betamount::decimals::2"}, ValidateAmount("betamount", 10.123456789, 2))
+	assert.Equal(t, []string{"amount::decimals::2"}, ValidateAmount("amount", 0.005, 2))
+	assert.Equal(t, []string{"amount::decimals::0"}, ValidateAmount("amount", 1.5, 0))
+}
+
+func TestValidateAmount_MinorUnitsPass(t *testing.T) {
+	assert.Nil(t, ValidateAmount("betamount", 10, 2))
+	assert.Nil(t, ValidateAmount("betamount", 10.1, 2))
+	assert.Nil(t, ValidateAmount("betamount", 0.29, 2))
+	assert.Nil(t, ValidateAmount("amount", 1e6, 0))
+}
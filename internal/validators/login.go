@@ -0,0 +1,29 @@
+package validators
+
+import (
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/vadymlab/slot-game/internal/config"
+)
+
+// username matches a username of 3 to 32 letters, digits, dots, underscores or hyphens,
+// starting with a letter or digit.
+var username = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{2,31}$`)
+
+// ValidateLogin checks the login against the provided policy. A login must be an email address
+// unless the policy allows usernames, in which case a username is accepted as well.
+// It returns a slice of error messages in the same "field::tag::param" format used by Validate,
+// or nil if the login is acceptable.
+func ValidateLogin(login string, policy *config.LoginPolicy) []string {
+	if validator.New().Var(login, "email") == nil {
+		return nil
+	}
+	if policy != nil && policy.AllowUsernames {
+		if username.MatchString(login) {
+			return nil
+		}
+		return []string{"login::username::"}
+	}
+	return []string{"login::email::"}
+}
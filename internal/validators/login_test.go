@@ -0,0 +1,26 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/config"
+)
+
+func TestValidateLogin_EmailRequiredByDefault(t *testing.T) {
+	policy := &config.LoginPolicy{}
+
+	assert.Nil(t, ValidateLogin("player@example.com", policy))
+	assert.Equal(t, []string{"login::email::"}, ValidateLogin("player_one", policy))
+}
+
+func TestValidateLogin_UsernamesAllowed(t *testing.T) {
+	policy := &config.LoginPolicy{AllowUsernames: true}
+
+	assert.Nil(t, ValidateLogin("player@example.com", policy))
+	assert.Nil(t, ValidateLogin("player_one", policy))
+	assert.Nil(t, ValidateLogin("Lucky.7-reels", policy))
+	assert.Equal(t, []string{"login::username::"}, ValidateLogin("ab", policy))
+	assert.Equal(t, []string{"login::username::"}, ValidateLogin("player one", policy))
+	assert.Equal(t, []string{"login::username::"}, ValidateLogin("player@", policy))
+}
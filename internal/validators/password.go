@@ -0,0 +1,62 @@
+package validators
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/vadymlab/slot-game/internal/config"
+)
+
+// commonPasswords is a list of frequently used passwords that satisfy the minimum
+// length requirement but are trivially guessable.
+var commonPasswords = []string{
+	"password", "password1", "password123", "passw0rd", "12345678", "123456789",
+	"1234567890", "87654321", "11111111", "00000000", "qwertyui", "qwerty123",
+	"qwertyuiop", "1q2w3e4r", "1qaz2wsx", "abcd1234", "iloveyou", "sunshine",
+	"princess", "football", "baseball", "welcome1", "letmein1", "trustno1",
+	"superman", "whatever", "starwars", "dragon123", "monkey123", "admin123",
+}
+
+// ValidatePassword checks the password against the provided policy.
+// It returns a slice of error messages in the same "field::tag::param" format
+// used by Validate, or nil if the password satisfies the policy.
+func ValidatePassword(password string, policy *config.PasswordPolicy) []string {
+	if policy == nil {
+		return nil
+	}
+	var errs []string
+	if isBlacklisted(password, policy) {
+		errs = append(errs, "password::blacklisted::")
+	}
+	if policy.RequireMixedCase && !(containsFunc(password, unicode.IsUpper) && containsFunc(password, unicode.IsLower)) {
+		errs = append(errs, "password::mixedcase::")
+	}
+	if policy.RequireDigit && !containsFunc(password, unicode.IsDigit) {
+		errs = append(errs, "password::digit::")
+	}
+	return errs
+}
+
+// isBlacklisted reports whether the password matches, case-insensitively, one of the
+// built-in common passwords (when enabled) or the configured blacklist.
+func isBlacklisted(password string, policy *config.PasswordPolicy) bool {
+	lowered := strings.ToLower(password)
+	if policy.BlockCommon {
+		for _, p := range commonPasswords {
+			if lowered == p {
+				return true
+			}
+		}
+	}
+	for _, p := range policy.Blacklist {
+		if lowered == strings.ToLower(strings.TrimSpace(p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFunc reports whether any rune in s satisfies f.
+func containsFunc(s string, f func(rune) bool) bool {
+	return strings.IndexFunc(s, f) >= 0
+}
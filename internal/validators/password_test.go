@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/config"
+)
+
+func TestValidatePassword_CommonPasswordRejected(t *testing.T) {
+	policy := &config.PasswordPolicy{BlockCommon: true}
+
+	errs := ValidatePassword("Password", policy)
+
+	assert.Equal(t, []string{"password::blacklisted::"}, errs)
+}
+
+func TestValidatePassword_ConfiguredBlacklistRejected(t *testing.T) {
+	policy := &config.PasswordPolicy{Blacklist: []string{"SlotGame2024"}}
+
+	errs := ValidatePassword("slotgame2024", policy)
+
+	assert.Equal(t, []string{"password::blacklisted::"}, errs)
+}
+
+func TestValidatePassword_StrongPasswordPasses(t *testing.T) {
+	policy := &config.PasswordPolicy{BlockCommon: true, RequireMixedCase: true, RequireDigit: true}
+
+	errs := ValidatePassword("Tr1cky-Reels", policy)
+
+	assert.Nil(t, errs)
+}
+
+func TestValidatePassword_ComplexityRules(t *testing.T) {
+	policy := &config.PasswordPolicy{RequireMixedCase: true, RequireDigit: true}
+
+	errs := ValidatePassword("onlylowercase", policy)
+
+	assert.Equal(t, []string{"password::mixedcase::", "password::digit::"}, errs)
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import "github.com/urfave/cli/v2"
+
+// Constants defining the webhook configuration flags.
+const (
+	webhookURL              = "webhook-url"
+	webhookSecret           = "webhook-secret"
+	webhookTimeout          = "webhook-timeout"
+	webhookMaxRetries       = "webhook-max-retries"
+	webhookWinThreshold     = "webhook-win-threshold"
+	webhookDepositThreshold = "webhook-deposit-threshold"
+)
+
+// Config represents the settings of the webhook event publisher and the thresholds
+// above which spins and deposits are published.
+type Config struct {
+	URL              string  // Endpoint receiving the events; empty disables publishing
+	Secret           string  // Secret used to sign payloads with HMAC-SHA256
+	Timeout          int     // Timeout of a single delivery attempt in seconds
+	MaxRetries       int     // Maximum number of retries after a failed delivery
+	WinThreshold     float64 // Minimum win amount that triggers an event
+	DepositThreshold float64 // Minimum deposit amount that triggers an event
+}
+
+// Enabled reports whether events should be published.
+func (c *Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// GetWebhookConfig reads the webhook settings from the CLI context, allowing configuration via
+// command-line arguments or environment variables.
+//
+// Parameters:
+//   - c (*cli.Context): The CLI context containing flag and environment variable values.
+//
+// Returns:
+//   - (*Config): A Config struct populated with the webhook settings.
+func GetWebhookConfig(c *cli.Context) *Config {
+	return &Config{
+		URL:              c.String(webhookURL),
+		Secret:           c.String(webhookSecret),
+		Timeout:          c.Int(webhookTimeout),
+		MaxRetries:       c.Int(webhookMaxRetries),
+		WinThreshold:     c.Float64(webhookWinThreshold),
+		DepositThreshold: c.Float64(webhookDepositThreshold),
+	}
+}
+
+// Flags defines the CLI flags available for configuring the webhook publisher.
+var Flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    webhookURL,
+		Usage:   "Webhook endpoint receiving win and deposit events; empty disables publishing",
+		EnvVars: []string{"WEBHOOK_URL"},
+	},
+	&cli.StringFlag{
+		Name:    webhookSecret,
+		Usage:   "Secret used to sign webhook payloads with HMAC-SHA256",
+		EnvVars: []string{"WEBHOOK_SECRET"},
+	},
+	&cli.IntFlag{
+		Name:    webhookTimeout,
+		Value:   5,
+		Usage:   "Timeout of a single webhook delivery attempt in seconds",
+		EnvVars: []string{"WEBHOOK_TIMEOUT"},
+	},
+	&cli.IntFlag{
+		Name:    webhookMaxRetries,
+		Value:   5,
+		Usage:   "Maximum number of retries after a failed webhook delivery",
+		EnvVars: []string{"WEBHOOK_MAX_RETRIES"},
+	},
+	&cli.Float64Flag{
+		Name:    webhookWinThreshold,
+		Value:   1000,
+		Usage:   "Minimum win amount that triggers a webhook event",
+		EnvVars: []string{"WEBHOOK_WIN_THRESHOLD"},
+	},
+	&cli.Float64Flag{
+		Name:    webhookDepositThreshold,
+		Value:   1000,
+		Usage:   "Minimum deposit amount that triggers a webhook event",
+		EnvVars: []string{"WEBHOOK_DEPOSIT_THRESHOLD"},
+	},
+}
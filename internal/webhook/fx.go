@@ -0,0 +1,9 @@
+package webhook
+
+import "go.uber.org/fx"
+
+// Module provides the webhook configuration and event publisher as an Fx module.
+var Module = fx.Options(
+	fx.Provide(GetWebhookConfig),
+	fx.Provide(NewPublisher),
+)
@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/vadymlab/slot-game/internal/httpclient"
+	"github.com/vadymlab/slot-game/internal/interfaces"
+	"github.com/vadymlab/slot-game/internal/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Headers set on every webhook delivery.
+const (
+	HeaderEvent     = "X-Webhook-Event"     // Type of the delivered event
+	HeaderSignature = "X-Webhook-Signature" // HMAC-SHA256 signature of the body, formatted as "sha256=<hex>"
+)
+
+// publisher implements IEventPublisher by POSTing signed JSON payloads to a webhook endpoint.
+type publisher struct {
+	cfg    *Config      // Webhook settings
+	client *http.Client // HTTP client used for deliveries
+}
+
+// Publish delivers the event to the configured endpoint. Failed deliveries are retried
+// with exponential backoff; client errors other than 429 are not retried.
+//
+// Parameters:
+//   - ctx: Context for managing request-scoped values and cancellation signals.
+//   - event: The event to deliver.
+//
+// Returns:
+//   - An error if the event could not be delivered within the allowed retries.
+func (p *publisher) Publish(ctx context.Context, event *models.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	signature := Sign(p.cfg.Secret, body)
+
+	operation := func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.URL, bytes.NewReader(body))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(HeaderEvent, event.Type)
+		req.Header.Set(HeaderSignature, signature)
+		// Let the receiver join the trace of the request that raised the event
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		err = fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 500 * time.Millisecond
+	return backoff.Retry(operation, backoff.WithContext(backoff.WithMaxRetries(b, uint64(p.cfg.MaxRetries)), ctx))
+}
+
+// Sign returns the signature of body, formatted as "sha256=<hex-encoded HMAC-SHA256>".
+// Receivers verify deliveries by computing the same value with the shared secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// noopPublisher discards all events; it is used when no webhook URL is configured.
+type noopPublisher struct{}
+
+// Publish discards the event.
+func (noopPublisher) Publish(context.Context, *models.Event) error {
+	return nil
+}
+
+// NewPublisher creates an IEventPublisher delivering events to the configured webhook.
+// When no URL is configured, a publisher discarding all events is returned.
+//
+// Parameters:
+//   - cfg: Webhook settings.
+//
+// Returns:
+//   - An IEventPublisher implementation.
+func NewPublisher(cfg *Config) interfaces.IEventPublisher {
+	if !cfg.Enabled() {
+		return noopPublisher{}
+	}
+	return &publisher{
+		cfg:    cfg,
+		client: httpclient.New(time.Duration(cfg.Timeout) * time.Second),
+	}
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vadymlab/slot-game/internal/models"
+)
+
+func TestPublisher_SignedDeliveryWithRetry(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, Sign("secret", body), r.Header.Get(HeaderSignature))
+		assert.Equal(t, models.EventTypeBigWin, r.Header.Get(HeaderEvent))
+		// Fail the first delivery to exercise the retry
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	p := NewPublisher(&Config{URL: server.URL, Secret: "secret", Timeout: 1, MaxRetries: 3})
+	err := p.Publish(context.Background(), &models.Event{ID: "1", Type: models.EventTypeBigWin, Amount: 500})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestPublisher_ClientErrorNotRetried(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	p := NewPublisher(&Config{URL: server.URL, Timeout: 1, MaxRetries: 3})
+	err := p.Publish(context.Background(), &models.Event{ID: "1", Type: models.EventTypeDeposit})
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestNewPublisher_DisabledWithoutURL(t *testing.T) {
+	p := NewPublisher(&Config{})
+
+	assert.IsType(t, noopPublisher{}, p)
+	assert.NoError(t, p.Publish(context.Background(), &models.Event{}))
+}
@@ -5,20 +5,52 @@ import (
 	app2 "github.com/vadymlab/slot-game/app"
 	"github.com/vadymlab/slot-game/internal/config"
 	"github.com/vadymlab/slot-game/internal/database"
+	"github.com/vadymlab/slot-game/internal/eventbus"
+	"github.com/vadymlab/slot-game/internal/exchange"
+	"github.com/vadymlab/slot-game/internal/grpcserver"
 	"github.com/vadymlab/slot-game/internal/redis"
+	"github.com/vadymlab/slot-game/internal/reporting"
+	"github.com/vadymlab/slot-game/internal/retention"
+	"github.com/vadymlab/slot-game/internal/rtp"
 	"github.com/vadymlab/slot-game/internal/server"
+	"github.com/vadymlab/slot-game/internal/spinbatch"
+	"github.com/vadymlab/slot-game/internal/tracing"
 	"github.com/vadymlab/slot-game/internal/utils"
+	"github.com/vadymlab/slot-game/internal/webhook"
 	"log"
 	"os"
 )
 
+// @title Slot Game API
+// @version 1.0
+// @description REST API for the slot game: user management, wallet operations and slot spins.
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT token in the format "Bearer <token>".
+
 // main is the entry point for the application. It configures and starts the CLI application.
 // It sets up flags for configuration and starts the server using app2.RunServer.
+// The migrate command applies pending schema migrations and exits.
+// The prune-spins command deletes spins outside the retention window once and exits.
 func main() {
 	// Initialize the CLI application with flags merged from config, database, and server packages.
 	app := &cli.App{
-		Flags:  utils.MergeSlices(config.LogFlags, database.DatabaseFlags, server.APIFlags, config.SlotFlags, redis.Flags),
+		Flags:  utils.MergeSlices(config.LogFlags, database.DatabaseFlags, database.ReplicaFlags, server.APIFlags, config.SlotFlags, config.PasswordFlags, config.LoginFlags, redis.Flags, webhook.Flags, retention.Flags, rtp.Flags, tracing.Flags, spinbatch.Flags, reporting.Flags, eventbus.Flags, exchange.Flags, grpcserver.Flags),
 		Action: app2.RunServer,
+		Commands: []*cli.Command{
+			{
+				Name:   "migrate",
+				Usage:  "Apply pending database schema migrations and exit",
+				Action: app2.RunMigrate,
+			},
+			{
+				Name:   "prune-spins",
+				Usage:  "Delete spins older than the retention window and exit",
+				Action: app2.RunPruneSpins,
+			},
+		},
 	}
 
 	// Run the CLI application and handle any errors encountered during execution.